@@ -1,7 +1,51 @@
 package main
 
-import "fmt"
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Krabik6/trading-simulator/analytics/config"
+	"github.com/Krabik6/trading-simulator/analytics/internal/app"
+	"github.com/Krabik6/trading-simulator/analytics/internal/logger"
+)
 
 func main() {
-	fmt.Println("analytics kafka started")
+	// Load and validate config
+	cfg, err := config.Load()
+	if err != nil {
+		// Use basic logging before logger is initialized
+		println("FATAL: " + err.Error())
+		os.Exit(1)
+	}
+
+	// Initialize structured logger
+	logger.Init(cfg.Service.LogLevel)
+
+	logger.Info("config loaded successfully",
+		"service", cfg.Service.Name,
+		"trades_topic", cfg.Kafka.TradesTopic,
+	)
+
+	// Create application
+	analyticsApp, err := app.New(cfg)
+	if err != nil {
+		logger.Error("failed to create app", "error", err)
+		os.Exit(1)
+	}
+	defer analyticsApp.Close()
+
+	// Setup graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(),
+		syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Run application
+	if err := analyticsApp.Run(ctx); err != nil {
+		logger.Error("application error", "error", err)
+		os.Exit(1)
+	}
+
+	logger.Info("application stopped gracefully")
 }
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Service  ServiceConfig
+	Database DatabaseConfig
+	Kafka    KafkaConfig
+}
+
+type ServiceConfig struct {
+	Name           string
+	HTTPPort       int
+	LogLevel       string
+	MetricsEnabled bool
+}
+
+type DatabaseConfig struct {
+	Host            string
+	Port            int
+	User            string
+	Password        string
+	Name            string
+	SSLMode         string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnectRetries  int
+	RetryInterval   int // seconds
+}
+
+type KafkaConfig struct {
+	Brokers       []string
+	TradesTopic   string
+	ConsumerGroup string
+}
+
+func (d *DatabaseConfig) DSN() string {
+	return fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		d.Host, d.Port, d.User, d.Password, d.Name, d.SSLMode,
+	)
+}
+
+func Load() (*Config, error) {
+	cfg := &Config{
+		Service: ServiceConfig{
+			Name:           getEnv("SERVICE_NAME", "analytics"),
+			HTTPPort:       getEnvInt("HTTP_PORT", 8082),
+			LogLevel:       getEnv("LOG_LEVEL", "info"),
+			MetricsEnabled: getEnvBool("METRICS_ENABLED", true),
+		},
+		Database: DatabaseConfig{
+			Host:            getEnv("DB_HOST", "localhost"),
+			Port:            getEnvInt("DB_PORT", 5433),
+			User:            getEnv("DB_USER", "analytics"),
+			Password:        getEnv("DB_PASSWORD", "analytics"),
+			Name:            getEnv("DB_NAME", "analytics"),
+			SSLMode:         getEnv("DB_SSLMODE", "disable"),
+			MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 10),
+			MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
+			ConnMaxLifetime: time.Duration(getEnvInt("DB_CONN_MAX_LIFETIME_MIN", 5)) * time.Minute,
+			ConnectRetries:  getEnvInt("DB_CONNECT_RETRIES", 10),
+			RetryInterval:   getEnvInt("DB_RETRY_INTERVAL_SEC", 3),
+		},
+		Kafka: KafkaConfig{
+			Brokers:       getEnvSlice("KAFKA_BROKERS", []string{"localhost:9092"}),
+			TradesTopic:   getEnv("KAFKA_TRADES_TOPIC", "trades"),
+			ConsumerGroup: getEnv("KAFKA_CONSUMER_GROUP", "analytics-service"),
+		},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) Validate() error {
+	var errs []string
+
+	// Service validation
+	if c.Service.HTTPPort < 1 || c.Service.HTTPPort > 65535 {
+		errs = append(errs, fmt.Sprintf("invalid HTTP_PORT: %d", c.Service.HTTPPort))
+	}
+
+	validLogLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+	if !validLogLevels[strings.ToLower(c.Service.LogLevel)] {
+		errs = append(errs, fmt.Sprintf("invalid LOG_LEVEL: %s (must be debug/info/warn/error)", c.Service.LogLevel))
+	}
+
+	// Database validation
+	if c.Database.Host == "" {
+		errs = append(errs, "DB_HOST cannot be empty")
+	}
+
+	if c.Database.ConnectRetries < 1 {
+		errs = append(errs, fmt.Sprintf("invalid DB_CONNECT_RETRIES: %d", c.Database.ConnectRetries))
+	}
+
+	// Kafka validation
+	if len(c.Kafka.Brokers) == 0 {
+		errs = append(errs, "KAFKA_BROKERS cannot be empty")
+	}
+
+	if c.Kafka.TradesTopic == "" {
+		errs = append(errs, "KAFKA_TRADES_TOPIC cannot be empty")
+	}
+
+	if c.Kafka.ConsumerGroup == "" {
+		errs = append(errs, "KAFKA_CONSUMER_GROUP cannot be empty")
+	}
+
+	if len(errs) > 0 {
+		return errors.New("config validation failed: " + strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+func getEnvSlice(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		return strings.Split(value, ",")
+	}
+	return defaultValue
+}
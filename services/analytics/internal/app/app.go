@@ -0,0 +1,201 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Krabik6/trading-simulator/analytics/config"
+	"github.com/Krabik6/trading-simulator/analytics/internal/domain"
+	"github.com/Krabik6/trading-simulator/analytics/internal/handler"
+	"github.com/Krabik6/trading-simulator/analytics/internal/kafka"
+	"github.com/Krabik6/trading-simulator/analytics/internal/logger"
+	"github.com/Krabik6/trading-simulator/analytics/internal/metrics"
+	"github.com/Krabik6/trading-simulator/analytics/internal/store"
+	"github.com/Krabik6/trading-simulator/analytics/migrations"
+)
+
+type App struct {
+	config   *config.Config
+	db       *store.DB
+	store    *store.MetricsStore
+	consumer *kafka.TradeConsumer
+	server   *http.Server
+
+	applied    atomic.Int64
+	duplicates atomic.Int64
+}
+
+func New(cfg *config.Config) (*App, error) {
+	db, err := store.NewDB(&cfg.Database)
+	if err != nil {
+		return nil, fmt.Errorf("create database: %w", err)
+	}
+
+	consumer := kafka.NewTradeConsumer(
+		cfg.Kafka.Brokers,
+		cfg.Kafka.TradesTopic,
+		cfg.Kafka.ConsumerGroup,
+	)
+
+	return &App{
+		config:   cfg,
+		db:       db,
+		store:    store.NewMetricsStore(db),
+		consumer: consumer,
+	}, nil
+}
+
+func (a *App) Run(ctx context.Context) error {
+	logger.Info("starting analytics service",
+		"kafka_brokers", a.config.Kafka.Brokers,
+		"kafka_topic", a.config.Kafka.TradesTopic,
+		"consumer_group", a.config.Kafka.ConsumerGroup,
+	)
+
+	if err := a.db.Connect(
+		ctx,
+		a.config.Database.ConnectRetries,
+		time.Duration(a.config.Database.RetryInterval)*time.Second,
+	); err != nil {
+		return fmt.Errorf("database connection: %w", err)
+	}
+
+	if err := a.db.RunMigrations(migrations.FS); err != nil {
+		return fmt.Errorf("migrations: %w", err)
+	}
+
+	// Start HTTP server
+	go a.startHTTPServer()
+
+	// Log progress and export consumer lag
+	go a.reportStats(ctx)
+
+	return a.consumer.Run(ctx, a.handleTrade)
+}
+
+func (a *App) handleTrade(ctx context.Context, trade *domain.TradeEvent) error {
+	start := time.Now()
+
+	applied, err := a.store.Apply(ctx, trade)
+	if err != nil {
+		return err
+	}
+	metrics.RecordStore(time.Since(start).Seconds())
+
+	if !applied {
+		a.duplicates.Add(1)
+		metrics.RecordDuplicate()
+		logger.Debug("duplicate trade skipped", "trade_id", trade.TradeID)
+		return nil
+	}
+
+	a.applied.Add(1)
+	metrics.RecordTrade(string(trade.Type), float64(trade.Timestamp.Unix()))
+
+	logger.Debug("trade applied",
+		"trade_id", trade.TradeID,
+		"user_id", trade.UserID,
+		"symbol", trade.Symbol,
+		"type", trade.Type,
+	)
+
+	return nil
+}
+
+func (a *App) reportStats(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			lag := a.consumer.Lag()
+			metrics.SetConsumerLag(lag)
+			logger.Info("processing stats",
+				"applied", a.applied.Load(),
+				"duplicates", a.duplicates.Load(),
+				"lag", lag,
+			)
+		}
+	}
+}
+
+func (a *App) startHTTPServer() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", a.healthHandler)
+	mux.HandleFunc("/ready", a.readyHandler)
+	mux.Handle("/metrics", promhttp.Handler())
+	handler.NewStatsHandler(a.store).Register(mux)
+
+	a.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", a.config.Service.HTTPPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	logger.Info("http server starting", "port", a.config.Service.HTTPPort)
+	if err := a.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.Error("http server error", "error", err)
+	}
+}
+
+func (a *App) healthHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status":"healthy"}`))
+}
+
+func (a *App) readyHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	for _, check := range []func() error{a.db.Health, a.consumer.Health} {
+		if err := check(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(fmt.Sprintf(`{"status":"not ready","error":"%s"}`, err.Error())))
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status":"ready"}`))
+}
+
+func (a *App) Close() error {
+	logger.Info("closing application")
+
+	var errs []error
+
+	if a.consumer != nil {
+		if err := a.consumer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close consumer: %w", err))
+		}
+	}
+
+	if a.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.server.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown server: %w", err))
+		}
+	}
+
+	if a.db != nil {
+		if err := a.db.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close database: %w", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("errors closing app: %v", errs)
+	}
+
+	logger.Info("application closed successfully")
+	return nil
+}
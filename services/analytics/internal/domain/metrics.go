@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// TradeMetrics aggregates trades of a user, a symbol or both. A zero UserID
+// means all users and an empty Symbol means all symbols.
+type TradeMetrics struct {
+	UserID       int64     `json:"user_id,omitempty"`
+	Symbol       string    `json:"symbol,omitempty"`
+	Trades       int64     `json:"trades"`
+	ClosedTrades int64     `json:"closed_trades"` // CLOSE, LIQUIDATE and SPOT_SELL
+	Wins         int64     `json:"wins"`
+	Losses       int64     `json:"losses"`
+	Liquidations int64     `json:"liquidations"`
+	Volume       string    `json:"volume"` // sum of quantity * price
+	RealizedPnL  string    `json:"realized_pnl"`
+	Fees         string    `json:"fees"`
+	FirstTradeAt time.Time `json:"first_trade_at"`
+	LastTradeAt  time.Time `json:"last_trade_at"`
+}
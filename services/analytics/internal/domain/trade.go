@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type TradeType string
+
+const (
+	TradeTypeOpen      TradeType = "OPEN"
+	TradeTypeClose     TradeType = "CLOSE"
+	TradeTypeAdd       TradeType = "ADD"
+	TradeTypeLiquidate TradeType = "LIQUIDATE"
+	TradeTypeSpotBuy   TradeType = "SPOT_BUY"
+	TradeTypeSpotSell  TradeType = "SPOT_SELL"
+)
+
+// IsClosing returns true for trades that realize PnL
+func (t TradeType) IsClosing() bool {
+	return t == TradeTypeClose || t == TradeTypeLiquidate || t == TradeTypeSpotSell
+}
+
+// TradeEvent is a trade published to the trades topic by the trading service.
+// Decimal fields are kept as strings and aggregated by the store.
+type TradeEvent struct {
+	TradeID    int64     `json:"trade_id"`
+	UserID     int64     `json:"user_id"`
+	AccountID  int64     `json:"account_id"`
+	PositionID int64     `json:"position_id"`
+	OrderID    int64     `json:"order_id"`
+	Symbol     string    `json:"symbol"`
+	Side       string    `json:"side"`
+	Type       TradeType `json:"type"`
+	Quantity   string    `json:"quantity"`
+	Price      string    `json:"price"`
+	PnL        string    `json:"pnl"`
+	Fee        string    `json:"fee"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func TradeEventFromJSON(data []byte) (*TradeEvent, error) {
+	var event TradeEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (e *TradeEvent) Validate() error {
+	if e.TradeID <= 0 {
+		return fmt.Errorf("invalid trade_id: %d", e.TradeID)
+	}
+	if e.UserID <= 0 {
+		return fmt.Errorf("invalid user_id: %d", e.UserID)
+	}
+	if e.Symbol == "" {
+		return fmt.Errorf("empty symbol")
+	}
+	if e.Quantity == "" || e.Price == "" {
+		return fmt.Errorf("empty quantity or price")
+	}
+	if e.PnL == "" {
+		e.PnL = "0"
+	}
+	if e.Fee == "" {
+		e.Fee = "0"
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+	return nil
+}
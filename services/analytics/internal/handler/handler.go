@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Krabik6/trading-simulator/analytics/internal/domain"
+	"github.com/Krabik6/trading-simulator/analytics/internal/logger"
+)
+
+// MetricsReader is the read side of the metrics store
+type MetricsReader interface {
+	GetTotals(ctx context.Context) (*domain.TradeMetrics, error)
+	GetUser(ctx context.Context, userID int64) (*domain.TradeMetrics, error)
+	GetUserSymbols(ctx context.Context, userID int64) ([]domain.TradeMetrics, error)
+	GetSymbol(ctx context.Context, symbol string) (*domain.TradeMetrics, error)
+	GetSymbols(ctx context.Context) ([]domain.TradeMetrics, error)
+}
+
+type StatsHandler struct {
+	store MetricsReader
+}
+
+func NewStatsHandler(store MetricsReader) *StatsHandler {
+	return &StatsHandler{store: store}
+}
+
+// Register adds the stats routes to mux
+func (h *StatsHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /stats", h.GetStats)
+	mux.HandleFunc("GET /stats/users/{id}", h.GetUserStats)
+	mux.HandleFunc("GET /stats/symbols/{symbol}", h.GetSymbolStats)
+}
+
+type statsResponse struct {
+	Totals  *domain.TradeMetrics  `json:"totals"`
+	Symbols []domain.TradeMetrics `json:"symbols"`
+}
+
+// GetStats returns platform-wide totals and per-symbol aggregates
+// GET /stats
+func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	totals, err := h.store.GetTotals(r.Context())
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		writeInternalError(w, err)
+		return
+	}
+	if totals == nil {
+		totals = &domain.TradeMetrics{Volume: "0", RealizedPnL: "0", Fees: "0"}
+	}
+
+	symbols, err := h.store.GetSymbols(r.Context())
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, statsResponse{Totals: totals, Symbols: symbols})
+}
+
+// GetUserStats returns a user's totals and per-symbol aggregates
+// GET /stats/users/{id}
+func (h *StatsHandler) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || userID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	totals, err := h.store.GetUser(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "no trades for user")
+			return
+		}
+		writeInternalError(w, err)
+		return
+	}
+
+	symbols, err := h.store.GetUserSymbols(r.Context(), userID)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, statsResponse{Totals: totals, Symbols: symbols})
+}
+
+// GetSymbolStats returns a symbol's aggregate over all users
+// GET /stats/symbols/{symbol}
+func (h *StatsHandler) GetSymbolStats(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.ToUpper(r.PathValue("symbol"))
+
+	m, err := h.store.GetSymbol(r.Context(), symbol)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "no trades for symbol")
+			return
+		}
+		writeInternalError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, m)
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeInternalError(w http.ResponseWriter, err error) {
+	logger.Error("stats query failed", "error", err)
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
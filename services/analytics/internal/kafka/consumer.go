@@ -0,0 +1,150 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/Krabik6/trading-simulator/analytics/internal/domain"
+	"github.com/Krabik6/trading-simulator/analytics/internal/logger"
+	"github.com/Krabik6/trading-simulator/analytics/internal/metrics"
+)
+
+// TradeHandler applies a consumed trade. Returning an error leaves the message
+// uncommitted and retries it.
+type TradeHandler func(ctx context.Context, trade *domain.TradeEvent) error
+
+type TradeConsumer struct {
+	reader    *kafka.Reader
+	brokers   []string
+	closeOnce sync.Once
+}
+
+func NewTradeConsumer(brokers []string, topic, groupID string) *TradeConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        brokers,
+		Topic:          topic,
+		GroupID:        groupID,
+		MinBytes:       1,
+		MaxBytes:       10e6,
+		MaxWait:        500 * time.Millisecond,
+		StartOffset:    kafka.FirstOffset,
+		CommitInterval: time.Second,
+	})
+
+	logger.Info("trade consumer initialized",
+		"brokers", brokers,
+		"topic", topic,
+		"group", groupID,
+	)
+
+	return &TradeConsumer{
+		reader:  reader,
+		brokers: brokers,
+	}
+}
+
+// Run consumes trades until ctx is done. Trades are committed only after the
+// handler succeeds, so every trade is applied at least once.
+func (c *TradeConsumer) Run(ctx context.Context, handle TradeHandler) error {
+	logger.Info("starting trade consumer")
+
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			metrics.RecordError("fetch")
+			logger.Error("failed to fetch message", "error", err)
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		trade, err := domain.TradeEventFromJSON(msg.Value)
+		if err != nil {
+			metrics.RecordError("parse")
+			logger.Error("failed to parse trade, skipping",
+				"offset", msg.Offset,
+				"partition", msg.Partition,
+				"error", err,
+			)
+			c.commit(ctx, msg)
+			continue
+		}
+
+		if err := c.handleWithRetry(ctx, handle, trade); err != nil {
+			// Context cancelled, the message will be redelivered
+			return nil
+		}
+
+		c.commit(ctx, msg)
+	}
+}
+
+func (c *TradeConsumer) handleWithRetry(ctx context.Context, handle TradeHandler, trade *domain.TradeEvent) error {
+	backoff := 500 * time.Millisecond
+
+	for {
+		err := handle(ctx, trade)
+		if err == nil {
+			return nil
+		}
+
+		metrics.RecordError("store")
+		logger.Error("failed to apply trade, retrying",
+			"trade_id", trade.TradeID,
+			"backoff", backoff,
+			"error", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func (c *TradeConsumer) commit(ctx context.Context, msg kafka.Message) {
+	if err := c.reader.CommitMessages(ctx, msg); err != nil && ctx.Err() == nil {
+		metrics.RecordError("commit")
+		logger.Error("failed to commit message", "error", err)
+	}
+}
+
+// Lag returns the number of messages behind the end of the partition
+func (c *TradeConsumer) Lag() int64 {
+	return c.reader.Stats().Lag
+}
+
+func (c *TradeConsumer) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		logger.Info("closing trade consumer")
+		err = c.reader.Close()
+	})
+	return err
+}
+
+func (c *TradeConsumer) Health() error {
+	conn, err := kafka.Dial("tcp", c.brokers[0])
+	if err != nil {
+		return fmt.Errorf("kafka connection failed: %w", err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Brokers()
+	if err != nil {
+		return fmt.Errorf("failed to get brokers: %w", err)
+	}
+
+	return nil
+}
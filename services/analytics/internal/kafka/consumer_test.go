@@ -0,0 +1,99 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Krabik6/trading-simulator/analytics/internal/domain"
+	"github.com/Krabik6/trading-simulator/analytics/internal/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.Init("error")
+	os.Exit(m.Run())
+}
+
+func TestHandleWithRetry_RetriesUntilApplied(t *testing.T) {
+	c := &TradeConsumer{}
+	trade := &domain.TradeEvent{TradeID: 1}
+
+	calls := 0
+	handle := func(ctx context.Context, tr *domain.TradeEvent) error {
+		calls++
+		assert.Same(t, trade, tr)
+		if calls < 2 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	}
+
+	require.NoError(t, c.handleWithRetry(context.Background(), handle, trade))
+	assert.Equal(t, 2, calls)
+}
+
+func TestHandleWithRetry_StopsOnCancel(t *testing.T) {
+	c := &TradeConsumer{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	handle := func(ctx context.Context, tr *domain.TradeEvent) error {
+		calls++
+		cancel()
+		return errors.New("database unavailable")
+	}
+
+	err := c.handleWithRetry(ctx, handle, &domain.TradeEvent{TradeID: 1})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestTradeEventFromJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+		check   func(t *testing.T, e *domain.TradeEvent)
+	}{
+		{
+			name: "valid",
+			data: `{"trade_id":3,"user_id":7,"symbol":"BTCUSDT","type":"CLOSE","quantity":"0.5","price":"40000","pnl":"12.5","fee":"1","timestamp":"2026-01-01T00:00:00Z"}`,
+			check: func(t *testing.T, e *domain.TradeEvent) {
+				assert.Equal(t, int64(3), e.TradeID)
+				assert.Equal(t, domain.TradeTypeClose, e.Type)
+				assert.Equal(t, "12.5", e.PnL)
+				assert.Equal(t, "1", e.Fee)
+			},
+		},
+		{
+			name: "pnl, fee and timestamp default",
+			data: `{"trade_id":3,"user_id":7,"symbol":"BTCUSDT","type":"OPEN","quantity":"0.5","price":"40000"}`,
+			check: func(t *testing.T, e *domain.TradeEvent) {
+				assert.Equal(t, "0", e.PnL)
+				assert.Equal(t, "0", e.Fee)
+				assert.False(t, e.Timestamp.IsZero())
+			},
+		},
+		{name: "malformed", data: `{"trade_id":`, wantErr: true},
+		{name: "missing trade id", data: `{"user_id":7,"symbol":"BTCUSDT","quantity":"1","price":"1"}`, wantErr: true},
+		{name: "missing user id", data: `{"trade_id":3,"symbol":"BTCUSDT","quantity":"1","price":"1"}`, wantErr: true},
+		{name: "missing symbol", data: `{"trade_id":3,"user_id":7,"quantity":"1","price":"1"}`, wantErr: true},
+		{name: "missing price", data: `{"trade_id":3,"user_id":7,"symbol":"BTCUSDT","quantity":"1"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := domain.TradeEventFromJSON([]byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, e)
+		})
+	}
+}
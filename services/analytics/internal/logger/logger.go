@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+var defaultLogger *slog.Logger
+
+func Init(level string) {
+	var logLevel slog.Level
+	switch strings.ToLower(level) {
+	case "debug":
+		logLevel = slog.LevelDebug
+	case "warn", "warning":
+		logLevel = slog.LevelWarn
+	case "error":
+		logLevel = slog.LevelError
+	default:
+		logLevel = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{
+		Level: logLevel,
+	}
+
+	handler := slog.NewJSONHandler(os.Stdout, opts)
+	defaultLogger = slog.New(handler)
+	slog.SetDefault(defaultLogger)
+}
+
+func Debug(msg string, args ...any) {
+	defaultLogger.Debug(msg, args...)
+}
+
+func Info(msg string, args ...any) {
+	defaultLogger.Info(msg, args...)
+}
+
+func Warn(msg string, args ...any) {
+	defaultLogger.Warn(msg, args...)
+}
+
+func Error(msg string, args ...any) {
+	defaultLogger.Error(msg, args...)
+}
+
+func With(args ...any) *slog.Logger {
+	return defaultLogger.With(args...)
+}
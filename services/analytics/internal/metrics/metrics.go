@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	TradesConsumed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "analytics",
+			Name:      "trades_consumed_total",
+			Help:      "Total number of trades aggregated",
+		},
+		[]string{"type"},
+	)
+
+	TradesDuplicate = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "analytics",
+			Name:      "trades_duplicate_total",
+			Help:      "Total number of redelivered trades skipped",
+		},
+	)
+
+	ConsumeErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "analytics",
+			Name:      "consume_errors_total",
+			Help:      "Total number of trade consumption errors",
+		},
+		[]string{"error_type"},
+	)
+
+	StoreDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "analytics",
+			Name:      "store_duration_seconds",
+			Help:      "Duration of aggregating a trade into the store",
+			Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1},
+		},
+	)
+
+	ConsumerLag = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "analytics",
+			Name:      "consumer_lag",
+			Help:      "Messages behind the end of the trades topic",
+		},
+	)
+
+	LastTradeTimestamp = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "analytics",
+			Name:      "last_trade_timestamp_seconds",
+			Help:      "Timestamp of the last aggregated trade",
+		},
+	)
+)
+
+func RecordTrade(tradeType string, timestamp float64) {
+	TradesConsumed.WithLabelValues(tradeType).Inc()
+	LastTradeTimestamp.Set(timestamp)
+}
+
+func RecordDuplicate() {
+	TradesDuplicate.Inc()
+}
+
+func RecordError(errorType string) {
+	ConsumeErrors.WithLabelValues(errorType).Inc()
+}
+
+func RecordStore(duration float64) {
+	StoreDuration.Observe(duration)
+}
+
+func SetConsumerLag(lag int64) {
+	ConsumerLag.Set(float64(lag))
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/lib/pq"
+
+	"github.com/Krabik6/trading-simulator/analytics/config"
+	"github.com/Krabik6/trading-simulator/analytics/internal/logger"
+)
+
+type DB struct {
+	*sql.DB
+}
+
+func NewDB(cfg *config.DatabaseConfig) (*DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	return &DB{db}, nil
+}
+
+func (db *DB) Connect(ctx context.Context, retries int, interval time.Duration) error {
+	var lastErr error
+
+	for i := 0; i < retries; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if err := db.PingContext(ctx); err == nil {
+			logger.Info("database connected successfully", "attempt", i+1)
+			return nil
+		} else {
+			lastErr = err
+		}
+
+		if i < retries-1 {
+			logger.Warn("database connection failed, retrying",
+				"attempt", i+1,
+				"max_retries", retries,
+				"error", lastErr,
+			)
+			time.Sleep(interval)
+		}
+	}
+
+	return fmt.Errorf("failed to connect to database after %d attempts: %w", retries, lastErr)
+}
+
+func (db *DB) Health() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return db.PingContext(ctx)
+}
+
+func (db *DB) RunMigrations(fs embed.FS) error {
+	source, err := iofs.New(fs, ".")
+	if err != nil {
+		return fmt.Errorf("create iofs source: %w", err)
+	}
+
+	driver, err := postgres.WithInstance(db.DB, &postgres.Config{})
+	if err != nil {
+		return fmt.Errorf("create postgres driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		return fmt.Errorf("create migrate instance: %w", err)
+	}
+
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("run migrations: %w", err)
+	}
+
+	version, dirty, _ := m.Version()
+	logger.Info("migrations applied", "version", version, "dirty", dirty)
+
+	return nil
+}
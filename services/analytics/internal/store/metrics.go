@@ -0,0 +1,172 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Krabik6/trading-simulator/analytics/internal/domain"
+)
+
+// allUsers and allSymbols mark the aggregate rows spanning every user or symbol
+const (
+	allUsers   int64 = 0
+	allSymbols       = ""
+)
+
+type MetricsStore struct {
+	db *DB
+}
+
+func NewMetricsStore(db *DB) *MetricsStore {
+	return &MetricsStore{db: db}
+}
+
+// Apply folds the trade into the user, symbol and global aggregates. It returns
+// false without changes if the trade was already applied.
+func (s *MetricsStore) Apply(ctx context.Context, trade *domain.TradeEvent) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO processed_trades (trade_id) VALUES ($1) ON CONFLICT DO NOTHING`,
+		trade.TradeID,
+	)
+	if err != nil {
+		return false, err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	var closed, liquidations int
+	if trade.Type.IsClosing() {
+		closed = 1
+	}
+	if trade.Type == domain.TradeTypeLiquidate {
+		liquidations = 1
+	}
+
+	query := `
+		WITH t AS (
+			SELECT $3::numeric * $4::numeric AS volume, $5::numeric AS pnl, $6::numeric AS fee
+		)
+		INSERT INTO trade_metrics (user_id, symbol, trades, closed_trades, wins, losses, liquidations,
+			volume, realized_pnl, fees, first_trade_at, last_trade_at)
+		SELECT k.user_id, k.symbol, 1, $7,
+			   CASE WHEN $7 = 1 AND t.pnl > 0 THEN 1 ELSE 0 END,
+			   CASE WHEN $7 = 1 AND t.pnl < 0 THEN 1 ELSE 0 END,
+			   $8, t.volume, t.pnl, t.fee, $9, $9
+		FROM t, (VALUES ($1::bigint, $2::varchar), ($1, ''), (0, $2), (0, '')) AS k(user_id, symbol)
+		ON CONFLICT (user_id, symbol) DO UPDATE SET
+			trades = trade_metrics.trades + 1,
+			closed_trades = trade_metrics.closed_trades + EXCLUDED.closed_trades,
+			wins = trade_metrics.wins + EXCLUDED.wins,
+			losses = trade_metrics.losses + EXCLUDED.losses,
+			liquidations = trade_metrics.liquidations + EXCLUDED.liquidations,
+			volume = trade_metrics.volume + EXCLUDED.volume,
+			realized_pnl = trade_metrics.realized_pnl + EXCLUDED.realized_pnl,
+			fees = trade_metrics.fees + EXCLUDED.fees,
+			first_trade_at = LEAST(trade_metrics.first_trade_at, EXCLUDED.first_trade_at),
+			last_trade_at = GREATEST(trade_metrics.last_trade_at, EXCLUDED.last_trade_at)`
+
+	_, err = tx.ExecContext(ctx, query,
+		trade.UserID, trade.Symbol, trade.Quantity, trade.Price, trade.PnL, trade.Fee,
+		closed, liquidations, trade.Timestamp,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetTotals returns the aggregate over all users and symbols
+func (s *MetricsStore) GetTotals(ctx context.Context) (*domain.TradeMetrics, error) {
+	return s.get(ctx, allUsers, allSymbols)
+}
+
+// GetUser returns the user's aggregate over all symbols
+func (s *MetricsStore) GetUser(ctx context.Context, userID int64) (*domain.TradeMetrics, error) {
+	return s.get(ctx, userID, allSymbols)
+}
+
+// GetSymbol returns the symbol's aggregate over all users
+func (s *MetricsStore) GetSymbol(ctx context.Context, symbol string) (*domain.TradeMetrics, error) {
+	return s.get(ctx, allUsers, symbol)
+}
+
+// GetSymbols returns the per-symbol aggregates over all users, by volume
+func (s *MetricsStore) GetSymbols(ctx context.Context) ([]domain.TradeMetrics, error) {
+	query := selectMetrics + `
+		WHERE user_id = 0 AND symbol != ''
+		ORDER BY volume DESC, symbol ASC`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return s.scanMetricsList(rows)
+}
+
+// GetUserSymbols returns the user's per-symbol aggregates, by volume
+func (s *MetricsStore) GetUserSymbols(ctx context.Context, userID int64) ([]domain.TradeMetrics, error) {
+	query := selectMetrics + `
+		WHERE user_id = $1 AND symbol != ''
+		ORDER BY volume DESC, symbol ASC`
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return s.scanMetricsList(rows)
+}
+
+const selectMetrics = `
+		SELECT user_id, symbol, trades, closed_trades, wins, losses, liquidations,
+			   volume::text, realized_pnl::text, fees::text, first_trade_at, last_trade_at
+		FROM trade_metrics`
+
+func (s *MetricsStore) get(ctx context.Context, userID int64, symbol string) (*domain.TradeMetrics, error) {
+	query := selectMetrics + `
+		WHERE user_id = $1 AND symbol = $2`
+
+	var m domain.TradeMetrics
+	err := s.db.QueryRowContext(ctx, query, userID, symbol).Scan(
+		&m.UserID, &m.Symbol, &m.Trades, &m.ClosedTrades, &m.Wins, &m.Losses, &m.Liquidations,
+		&m.Volume, &m.RealizedPnL, &m.Fees, &m.FirstTradeAt, &m.LastTradeAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (s *MetricsStore) scanMetricsList(rows *sql.Rows) ([]domain.TradeMetrics, error) {
+	list := []domain.TradeMetrics{}
+	for rows.Next() {
+		var m domain.TradeMetrics
+		err := rows.Scan(
+			&m.UserID, &m.Symbol, &m.Trades, &m.ClosedTrades, &m.Wins, &m.Losses, &m.Liquidations,
+			&m.Volume, &m.RealizedPnL, &m.Fees, &m.FirstTradeAt, &m.LastTradeAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, m)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,186 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Krabik6/trading-simulator/analytics/internal/domain"
+	"github.com/Krabik6/trading-simulator/analytics/internal/logger"
+	"github.com/Krabik6/trading-simulator/analytics/migrations"
+)
+
+// testDSNEnv points the store tests at a disposable Postgres database; the
+// tests are skipped when it is unset
+const testDSNEnv = "ANALYTICS_TEST_DSN"
+
+func TestMain(m *testing.M) {
+	logger.Init("error")
+	os.Exit(m.Run())
+}
+
+func setupStore(t *testing.T) *MetricsStore {
+	t.Helper()
+
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s not set", testDSNEnv)
+	}
+
+	conn, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db := &DB{conn}
+	require.NoError(t, db.RunMigrations(migrations.FS))
+	_, err = db.Exec(`TRUNCATE processed_trades, trade_metrics`)
+	require.NoError(t, err)
+
+	return NewMetricsStore(db)
+}
+
+func trade(id, userID int64, symbol string, typ domain.TradeType, qty, price, pnl, fee string, at time.Time) *domain.TradeEvent {
+	return &domain.TradeEvent{
+		TradeID:   id,
+		UserID:    userID,
+		Symbol:    symbol,
+		Type:      typ,
+		Quantity:  qty,
+		Price:     price,
+		PnL:       pnl,
+		Fee:       fee,
+		Timestamp: at,
+	}
+}
+
+func TestApply_Idempotent(t *testing.T) {
+	store := setupStore(t)
+	ctx := context.Background()
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	event := trade(1, 7, "BTCUSDT", domain.TradeTypeClose, "0.5", "40000", "150", "10", at)
+
+	applied, err := store.Apply(ctx, event)
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	applied, err = store.Apply(ctx, event)
+	require.NoError(t, err)
+	assert.False(t, applied, "redelivered trade must not be applied again")
+
+	totals, err := store.GetTotals(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), totals.Trades)
+	assert.Equal(t, int64(1), totals.ClosedTrades)
+	assert.Equal(t, "20000.00000000", totals.Volume)
+	assert.Equal(t, "150.00000000", totals.RealizedPnL)
+	assert.Equal(t, "10.00000000", totals.Fees)
+}
+
+func TestApply_Aggregates(t *testing.T) {
+	store := setupStore(t)
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	trades := []*domain.TradeEvent{
+		trade(1, 1, "BTCUSDT", domain.TradeTypeOpen, "1", "100", "0", "1", t0),
+		trade(2, 1, "BTCUSDT", domain.TradeTypeClose, "1", "110", "10", "1", t0.Add(time.Hour)),
+		trade(3, 1, "ETHUSDT", domain.TradeTypeLiquidate, "2", "50", "-20", "0", t0.Add(2*time.Hour)),
+		trade(4, 2, "BTCUSDT", domain.TradeTypeSpotBuy, "3", "100", "0", "0.5", t0.Add(-time.Hour)),
+		trade(5, 2, "BTCUSDT", domain.TradeTypeSpotSell, "3", "90", "-30", "0.5", t0.Add(3*time.Hour)),
+	}
+	for _, tr := range trades {
+		applied, err := store.Apply(ctx, tr)
+		require.NoError(t, err)
+		require.True(t, applied)
+	}
+
+	tests := []struct {
+		name  string
+		get   func() (*domain.TradeMetrics, error)
+		want  domain.TradeMetrics
+		first time.Time
+		last  time.Time
+	}{
+		{
+			name: "totals",
+			get:  func() (*domain.TradeMetrics, error) { return store.GetTotals(ctx) },
+			want: domain.TradeMetrics{
+				Trades: 5, ClosedTrades: 3, Wins: 1, Losses: 2, Liquidations: 1,
+				Volume: "880.00000000", RealizedPnL: "-40.00000000", Fees: "3.00000000",
+			},
+			first: t0.Add(-time.Hour),
+			last:  t0.Add(3 * time.Hour),
+		},
+		{
+			name: "user",
+			get:  func() (*domain.TradeMetrics, error) { return store.GetUser(ctx, 1) },
+			want: domain.TradeMetrics{
+				UserID: 1, Trades: 3, ClosedTrades: 2, Wins: 1, Losses: 1, Liquidations: 1,
+				Volume: "310.00000000", RealizedPnL: "-10.00000000", Fees: "2.00000000",
+			},
+			first: t0,
+			last:  t0.Add(2 * time.Hour),
+		},
+		{
+			name: "symbol",
+			get:  func() (*domain.TradeMetrics, error) { return store.GetSymbol(ctx, "BTCUSDT") },
+			want: domain.TradeMetrics{
+				Symbol: "BTCUSDT", Trades: 4, ClosedTrades: 2, Wins: 1, Losses: 1,
+				Volume: "780.00000000", RealizedPnL: "-20.00000000", Fees: "3.00000000",
+			},
+			first: t0.Add(-time.Hour),
+			last:  t0.Add(3 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get()
+			require.NoError(t, err)
+			assert.True(t, tt.first.Equal(got.FirstTradeAt), "first_trade_at %v", got.FirstTradeAt)
+			assert.True(t, tt.last.Equal(got.LastTradeAt), "last_trade_at %v", got.LastTradeAt)
+			got.FirstTradeAt, got.LastTradeAt = time.Time{}, time.Time{}
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+
+	t.Run("symbols by volume", func(t *testing.T) {
+		list, err := store.GetSymbols(ctx)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, "BTCUSDT", list[0].Symbol)
+		assert.Equal(t, "ETHUSDT", list[1].Symbol)
+		assert.Equal(t, "100.00000000", list[1].Volume)
+	})
+
+	t.Run("user symbols", func(t *testing.T) {
+		list, err := store.GetUserSymbols(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, int64(2), list[0].UserID)
+		assert.Equal(t, "BTCUSDT", list[0].Symbol)
+		assert.Equal(t, int64(2), list[0].Trades)
+		assert.Equal(t, "570.00000000", list[0].Volume)
+	})
+}
+
+func TestGet_NotFound(t *testing.T) {
+	store := setupStore(t)
+	ctx := context.Background()
+
+	_, err := store.GetTotals(ctx)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = store.GetUser(ctx, 42)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	list, err := store.GetSymbols(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
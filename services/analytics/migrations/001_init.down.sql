@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS trade_metrics;
+DROP TABLE IF EXISTS processed_trades;
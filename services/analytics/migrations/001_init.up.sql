@@ -0,0 +1,24 @@
+-- Trades already aggregated, so redelivered messages are counted once
+CREATE TABLE processed_trades (
+    trade_id BIGINT PRIMARY KEY,
+    processed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Aggregates per (user, symbol). user_id = 0 is all users, symbol = '' is all symbols.
+CREATE TABLE trade_metrics (
+    user_id BIGINT NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    trades BIGINT NOT NULL DEFAULT 0,
+    closed_trades BIGINT NOT NULL DEFAULT 0,
+    wins BIGINT NOT NULL DEFAULT 0,
+    losses BIGINT NOT NULL DEFAULT 0,
+    liquidations BIGINT NOT NULL DEFAULT 0,
+    volume DECIMAL(30, 8) NOT NULL DEFAULT 0,
+    realized_pnl DECIMAL(30, 8) NOT NULL DEFAULT 0,
+    fees DECIMAL(30, 8) NOT NULL DEFAULT 0,
+    first_trade_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_trade_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, symbol)
+);
+
+CREATE INDEX idx_trade_metrics_symbol ON trade_metrics(symbol, user_id);
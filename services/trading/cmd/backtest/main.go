@@ -0,0 +1,159 @@
+// Command backtest replays a candle CSV through the trading engine and prints
+// the report:
+//
+//	go run ./cmd/backtest -data btc_1h.csv -symbol BTCUSDT -strategy sma_cross -params fast=20,slow=50
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/shopspring/decimal"
+
+	"trading/config"
+	"trading/internal/backtest"
+	"trading/internal/engine"
+	"trading/internal/logger"
+)
+
+func main() {
+	data := flag.String("data", "", "candle CSV file: time,open,high,low,close[,volume]")
+	symbol := flag.String("symbol", "BTCUSDT", "symbol the candles belong to")
+	strategyName := flag.String("strategy", "buy_and_hold", "strategy: "+strings.Join(backtest.StrategyNames(), ", "))
+	params := flag.String("params", "", "strategy parameters as key=value,key=value")
+	balance := flag.Float64("balance", 10000, "starting balance in USDT")
+	spread := flag.Float64("spread", 0, "bid/ask spread as a share of the price, e.g. 0.0002")
+	equityOut := flag.String("equity", "", "write the equity curve to this CSV file")
+	tradesOut := flag.String("trades", "", "write the trades to this CSV file")
+	flag.Parse()
+
+	if err := run(*data, *symbol, *strategyName, *params, *balance, *spread, *equityOut, *tradesOut); err != nil {
+		fmt.Fprintln(os.Stderr, "backtest: "+err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(data, symbol, strategyName, rawParams string, balance, spread float64, equityOut, tradesOut string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	// Triggered closes are logged by the position code; keep the output to the report
+	logger.Init("error")
+
+	if data == "" {
+		return fmt.Errorf("-data is required")
+	}
+	f, err := os.Open(data)
+	if err != nil {
+		return err
+	}
+	candles, err := backtest.ReadCSV(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	params, err := parseParams(rawParams)
+	if err != nil {
+		return err
+	}
+	strategy, err := backtest.NewStrategy(strategyName, params)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	eng := engine.NewEngine(cfg.Trading.MaxLeverage, cfg.Trading.MaintenanceRate, cfg.Trading.CollateralHaircuts)
+	report, err := backtest.New(eng).Run(ctx, backtest.Input{
+		Symbol:          strings.ToUpper(symbol),
+		Candles:         candles,
+		Strategy:        strategy,
+		StartingBalance: decimal.NewFromFloat(balance),
+		Spread:          spread,
+	})
+	if err != nil {
+		return err
+	}
+
+	printReport(strategyName, report)
+
+	if equityOut != "" {
+		rows := [][]string{{"time", "equity", "drawdown"}}
+		for _, p := range report.Equity {
+			rows = append(rows, []string{p.Time.UTC().Format("2006-01-02T15:04:05Z"), p.Equity.StringFixed(2), p.Drawdown.StringFixed(2)})
+		}
+		if err := writeCSV(equityOut, rows); err != nil {
+			return err
+		}
+	}
+	if tradesOut != "" {
+		rows := [][]string{{"time", "side", "type", "quantity", "price", "pnl", "fee"}}
+		for _, t := range report.Trades {
+			rows = append(rows, []string{
+				t.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"), string(t.Side), string(t.Type),
+				t.Quantity.String(), t.Price.String(), t.PnL.StringFixed(2), t.Fee.StringFixed(2),
+			})
+		}
+		if err := writeCSV(tradesOut, rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseParams reads "fast=10,slow=30"
+func parseParams(raw string) (map[string]float64, error) {
+	params := make(map[string]float64)
+	if raw == "" {
+		return params, nil
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid parameter %q", pair)
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %v", k, err)
+		}
+		params[strings.TrimSpace(k)] = f
+	}
+	return params, nil
+}
+
+func printReport(strategy string, r *backtest.Report) {
+	s := r.Stats
+	fmt.Printf("Strategy:         %s on %s\n", strategy, r.Symbol)
+	fmt.Printf("Period:           %s - %s (%d candles)\n", r.From.UTC().Format("2006-01-02 15:04"), r.To.UTC().Format("2006-01-02 15:04"), r.Candles)
+	fmt.Printf("Starting balance: %s\n", r.StartingBalance.StringFixed(2))
+	fmt.Printf("Final equity:     %s (%s%%)\n", r.FinalEquity.StringFixed(2), s.ReturnPct.StringFixed(2))
+	fmt.Printf("Max drawdown:     %s%%\n", s.MaxDrawdownPct.StringFixed(2))
+	fmt.Printf("Trades:           %d (%d closed, %d liquidations, %d rejected orders)\n", s.TotalTrades, s.ClosedTrades, s.Liquidations, s.RejectedOrders)
+	fmt.Printf("Win rate:         %s%% (%d won, %d lost)\n", s.WinRate.StringFixed(2), s.WinningTrades, s.LosingTrades)
+	fmt.Printf("Realized PnL:     %s (fees %s)\n", s.RealizedPnL.StringFixed(2), s.Fees.StringFixed(2))
+	fmt.Printf("Profit factor:    %s\n", s.ProfitFactor.StringFixed(2))
+	fmt.Printf("Average win/loss: %s / %s\n", s.AverageWin.StringFixed(2), s.AverageLoss.StringFixed(2))
+}
+
+func writeCSV(path string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(rows); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -15,6 +15,9 @@ type Config struct {
 	Kafka    KafkaConfig
 	JWT      JWTConfig
 	Trading  TradingConfig
+	Webhook  WebhookConfig
+	Backtest BacktestConfig
+	Script   ScriptConfig
 }
 
 type ServiceConfig struct {
@@ -54,7 +57,30 @@ type TradingConfig struct {
 	MaxLeverage      int
 	InitialBalance   float64
 	SupportedSymbols []string
-	MaintenanceRate  float64 // maintenance margin rate (e.g., 0.005 = 0.5%)
+	SpotSymbols      []string // spot pairs, e.g., "BTC/USDT"
+	MaintenanceRate  float64  // maintenance margin rate (e.g., 0.005 = 0.5%)
+	// CollateralHaircuts maps wallet assets to the share of value ignored as margin (e.g., BTC: 0.1)
+	CollateralHaircuts map[string]float64
+}
+
+type WebhookConfig struct {
+	Timeout     time.Duration // per request
+	MaxAttempts int           // attempts before a delivery is marked failed
+	RetryBase   time.Duration // first retry delay, doubled on each further attempt
+	// AllowPrivate lets webhooks target loopback and private networks, for local setups only
+	AllowPrivate bool
+}
+
+type BacktestConfig struct {
+	DataDir    string // directory of candle CSV datasets
+	MaxRunning int    // backtests run at the same time; further jobs wait
+	MaxCandles int    // per backtest
+}
+
+type ScriptConfig struct {
+	MaxSteps      int           // Starlark execution steps per callback
+	Timeout       time.Duration // wall time per callback, including its orders
+	PriceInterval time.Duration // minimum time between on_price calls of a script
 }
 
 func (d *DatabaseConfig) DSN() string {
@@ -99,7 +125,29 @@ func Load() (*Config, error) {
 			MaxLeverage:      getEnvInt("MAX_LEVERAGE", 100),
 			InitialBalance:   getEnvFloat("INITIAL_BALANCE", 10000),
 			SupportedSymbols: getEnvSlice("SUPPORTED_SYMBOLS", []string{"BTCUSDT", "ETHUSDT", "SOLUSDT"}),
+			SpotSymbols:      getEnvSlice("SPOT_SYMBOLS", []string{"BTC/USDT", "ETH/USDT"}),
 			MaintenanceRate:  getEnvFloat("MAINTENANCE_RATE", 0.005),
+			CollateralHaircuts: getEnvFloatMap("COLLATERAL_HAIRCUTS", map[string]float64{
+				"USDC": 0.01,
+				"BTC":  0.1,
+				"ETH":  0.15,
+			}),
+		},
+		Webhook: WebhookConfig{
+			Timeout:      time.Duration(getEnvInt("WEBHOOK_TIMEOUT_SEC", 10)) * time.Second,
+			MaxAttempts:  getEnvInt("WEBHOOK_MAX_ATTEMPTS", 6),
+			RetryBase:    time.Duration(getEnvInt("WEBHOOK_RETRY_BASE_SEC", 30)) * time.Second,
+			AllowPrivate: getEnvBool("WEBHOOK_ALLOW_PRIVATE", false),
+		},
+		Backtest: BacktestConfig{
+			DataDir:    getEnv("BACKTEST_DATA_DIR", "data/candles"),
+			MaxRunning: getEnvInt("BACKTEST_MAX_RUNNING", 2),
+			MaxCandles: getEnvInt("BACKTEST_MAX_CANDLES", 500000),
+		},
+		Script: ScriptConfig{
+			MaxSteps:      getEnvInt("SCRIPT_MAX_STEPS", 100000),
+			Timeout:       time.Duration(getEnvInt("SCRIPT_TIMEOUT_MS", 1000)) * time.Millisecond,
+			PriceInterval: time.Duration(getEnvInt("SCRIPT_PRICE_INTERVAL_MS", 1000)) * time.Millisecond,
 		},
 	}
 
@@ -146,6 +194,44 @@ func (c *Config) Validate() error {
 		errs = append(errs, "SUPPORTED_SYMBOLS cannot be empty")
 	}
 
+	for asset, haircut := range c.Trading.CollateralHaircuts {
+		if haircut < 0 || haircut >= 1 {
+			errs = append(errs, fmt.Sprintf("invalid COLLATERAL_HAIRCUTS for %s: %v (must be 0-1)", asset, haircut))
+		}
+	}
+
+	if c.Webhook.Timeout <= 0 {
+		errs = append(errs, "WEBHOOK_TIMEOUT_SEC must be positive")
+	}
+
+	if c.Webhook.MaxAttempts < 1 {
+		errs = append(errs, fmt.Sprintf("invalid WEBHOOK_MAX_ATTEMPTS: %d (must be at least 1)", c.Webhook.MaxAttempts))
+	}
+
+	if c.Webhook.RetryBase <= 0 {
+		errs = append(errs, "WEBHOOK_RETRY_BASE_SEC must be positive")
+	}
+
+	if c.Backtest.MaxRunning < 1 {
+		errs = append(errs, fmt.Sprintf("invalid BACKTEST_MAX_RUNNING: %d (must be at least 1)", c.Backtest.MaxRunning))
+	}
+
+	if c.Backtest.MaxCandles < 1 {
+		errs = append(errs, fmt.Sprintf("invalid BACKTEST_MAX_CANDLES: %d (must be at least 1)", c.Backtest.MaxCandles))
+	}
+
+	if c.Script.MaxSteps < 1 {
+		errs = append(errs, fmt.Sprintf("invalid SCRIPT_MAX_STEPS: %d (must be at least 1)", c.Script.MaxSteps))
+	}
+
+	if c.Script.Timeout <= 0 {
+		errs = append(errs, "SCRIPT_TIMEOUT_MS must be positive")
+	}
+
+	if c.Script.PriceInterval < 0 {
+		errs = append(errs, "SCRIPT_PRICE_INTERVAL_MS cannot be negative")
+	}
+
 	if len(errs) > 0 {
 		return errors.New("config validation failed: " + strings.Join(errs, "; "))
 	}
@@ -193,3 +279,22 @@ func getEnvSlice(key string, defaultValue []string) []string {
 	}
 	return defaultValue
 }
+
+// getEnvFloatMap parses "KEY:value,KEY:value" pairs
+func getEnvFloatMap(key string, defaultValue map[string]float64) map[string]float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	result := make(map[string]float64)
+	for _, pair := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(pair, ":")
+		if !ok {
+			continue
+		}
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			result[strings.TrimSpace(k)] = f
+		}
+	}
+	return result
+}
@@ -8,19 +8,37 @@ import (
 
 	"trading/config"
 	"trading/internal/auth"
+	"trading/internal/backtest"
 	httpdelivery "trading/internal/delivery/http"
 	"trading/internal/delivery/http/handler"
 	"trading/internal/delivery/http/middleware"
 	"trading/internal/delivery/ws"
+	"trading/internal/domain"
 	"trading/internal/engine"
 	"trading/internal/kafka"
 	"trading/internal/logger"
 	"trading/internal/repository/postgres"
 	accountuc "trading/internal/usecase/account"
+	alertuc "trading/internal/usecase/alert"
+	algouc "trading/internal/usecase/algo"
 	authuc "trading/internal/usecase/auth"
+	backtestuc "trading/internal/usecase/backtest"
+	challengeuc "trading/internal/usecase/challenge"
+	competitionuc "trading/internal/usecase/competition"
+	copyuc "trading/internal/usecase/copytrade"
+	dcauc "trading/internal/usecase/dca"
+	equityuc "trading/internal/usecase/equity"
+	griduc "trading/internal/usecase/grid"
+	leaderboarduc "trading/internal/usecase/leaderboard"
 	orderuc "trading/internal/usecase/order"
 	positionuc "trading/internal/usecase/position"
 	priceuc "trading/internal/usecase/price"
+	reportuc "trading/internal/usecase/report"
+	scriptuc "trading/internal/usecase/script"
+	seasonuc "trading/internal/usecase/season"
+	signaluc "trading/internal/usecase/signal"
+	statsuc "trading/internal/usecase/stats"
+	webhookuc "trading/internal/usecase/webhook"
 	"trading/migrations"
 )
 
@@ -73,10 +91,38 @@ func (a *App) Run(ctx context.Context) error {
 	orderRepo := postgres.NewOrderRepository(a.db)
 	positionRepo := postgres.NewPositionRepository(a.db)
 	tradeRepo := postgres.NewTradeRepository(a.db)
+	ledgerRepo := postgres.NewLedgerRepository(a.db)
+	seasonRepo := postgres.NewAccountSeasonRepository(a.db)
+	walletRepo := postgres.NewWalletRepository(a.db)
+	spotLotRepo := postgres.NewSpotLotRepository(a.db)
+	alertRepo := postgres.NewPriceAlertRepository(a.db)
+	notificationRepo := postgres.NewNotificationRepository(a.db)
+	webhookRepo := postgres.NewWebhookRepository(a.db)
+	webhookDeliveryRepo := postgres.NewWebhookDeliveryRepository(a.db)
+	equitySnapshotRepo := postgres.NewEquitySnapshotRepository(a.db)
+	leaderboardRepo := postgres.NewLeaderboardRepository(a.db)
+	competitionRepo := postgres.NewCompetitionRepository(a.db)
+	challengeRepo := postgres.NewChallengeRepository(a.db)
+	gridBotRepo := postgres.NewGridBotRepository(a.db)
+	dcaRepo := postgres.NewDCARepository(a.db)
+	algoRepo := postgres.NewAlgoOrderRepository(a.db)
+	followRepo := postgres.NewFollowRepository(a.db)
+	scriptRepo := postgres.NewScriptRepository(a.db)
+	signalRepo := postgres.NewSignalStrategyRepository(a.db)
 	priceCache := postgres.NewPriceCache()
 
+	// Initialize instruments
+	instruments, err := domain.NewInstruments(a.config.Trading.SupportedSymbols, a.config.Trading.SpotSymbols)
+	if err != nil {
+		return fmt.Errorf("instruments: %w", err)
+	}
+
 	// Initialize engine
-	eng := engine.NewEngine(a.config.Trading.MaxLeverage, a.config.Trading.MaintenanceRate)
+	eng := engine.NewEngine(
+		a.config.Trading.MaxLeverage,
+		a.config.Trading.MaintenanceRate,
+		a.config.Trading.CollateralHaircuts,
+	)
 
 	// Initialize JWT service
 	jwtService := auth.NewJWTService(a.config.JWT.Secret, a.config.JWT.ExpiryHours)
@@ -110,31 +156,193 @@ func (a *App) Run(ctx context.Context) error {
 		a.config.Trading.InitialBalance,
 	)
 
+	webhookUC := webhookuc.NewUseCase(
+		webhookRepo,
+		webhookDeliveryRepo,
+		a.config.Webhook.Timeout,
+		a.config.Webhook.MaxAttempts,
+		a.config.Webhook.RetryBase,
+		a.config.Webhook.AllowPrivate,
+	)
+
+	statsUC := statsuc.NewUseCase(
+		accountRepo,
+		seasonRepo,
+		positionRepo,
+		tradeRepo,
+	)
+
+	// Leader trades are mirrored onto follower accounts
+	copyUC := copyuc.NewUseCase(
+		followRepo,
+		userRepo,
+		accountRepo,
+		positionRepo,
+		statsUC,
+	)
+
+	// Backtests run on in-memory repositories with the live engine settings
+	backtestUC := backtestuc.NewUseCase(
+		backtest.New(eng),
+		a.config.Backtest.DataDir,
+		a.config.Backtest.MaxRunning,
+		a.config.Backtest.MaxCandles,
+	)
+
+	// User scripts get price and fill callbacks; backtests run them offline
+	scriptUC := scriptuc.NewUseCase(
+		scriptRepo,
+		accountRepo,
+		orderRepo,
+		positionRepo,
+		backtestUC,
+		a.config.Script.MaxSteps,
+		a.config.Script.Timeout,
+		a.config.Script.PriceInterval,
+	)
+
+	// Fills go to webhooks, to the trades topic, to copy trading and to scripts
+	events := domain.EventPublishers{webhookUC, a.tradeProducer, copyUC, scriptUC}
+
 	positionUC := positionuc.NewUseCase(
 		positionRepo,
 		accountRepo,
 		tradeRepo,
 		orderRepo,
+		ledgerRepo,
 		priceCache,
 		eng,
+		events,
+	)
+
+	accountUC := accountuc.NewUseCase(
+		accountRepo,
+		positionRepo,
+		ledgerRepo,
+		walletRepo,
+		spotLotRepo,
+		competitionRepo,
+		priceCache,
+		eng,
+		a.config.Trading.InitialBalance,
+	)
+
+	challengeUC := challengeuc.NewUseCase(
+		challengeRepo,
+		accountRepo,
+		orderRepo,
+		positionRepo,
+		positionUC,
+		accountUC,
+	)
+
+	// Grid bots and algo orders follow the fills of their resting limit orders
+	gridUC := griduc.NewUseCase(
+		gridBotRepo,
+		accountRepo,
+		accountUC,
+		priceCache,
+	)
+
+	algoUC := algouc.NewUseCase(
+		algoRepo,
+		orderRepo,
+		priceCache,
+		a.config.Backtest.DataDir,
 	)
 
+	// Order fills are also checked against challenge rules
+	orderEvents := domain.EventPublishers{webhookUC, a.tradeProducer, challengeUC, copyUC, scriptUC, gridUC, algoUC}
+
 	orderUC := orderuc.NewUseCase(
 		orderRepo,
 		positionRepo,
 		accountRepo,
 		tradeRepo,
+		ledgerRepo,
+		walletRepo,
+		spotLotRepo,
+		competitionRepo,
 		priceCache,
 		eng,
-		a.config.Trading.SupportedSymbols,
+		instruments,
+		orderEvents,
+	)
+	copyUC.SetTrading(orderUC, positionUC)
+	scriptUC.SetTrading(accountUC, orderUC, positionUC)
+	gridUC.SetTrading(orderUC)
+	algoUC.SetTrading(orderUC)
+
+	equityUC := equityuc.NewUseCase(
+		accountRepo,
+		equitySnapshotRepo,
+		accountUC,
+	)
+
+	reportUC := reportuc.NewUseCase(
+		tradeRepo,
+		ledgerRepo,
+		positionRepo,
+	)
+
+	leaderboardUC := leaderboarduc.NewUseCase(
+		userRepo,
+		leaderboardRepo,
+	)
+
+	competitionUC := competitionuc.NewUseCase(
+		competitionRepo,
+		accountRepo,
+		userRepo,
+		orderUC,
+		positionUC,
+		accountUC,
+		eng,
+		instruments,
+	)
+
+	dcaUC := dcauc.NewUseCase(
+		dcaRepo,
+		positionRepo,
+		orderUC,
+		priceCache,
+	)
+
+	seasonUC := seasonuc.NewUseCase(
+		accountRepo,
+		seasonRepo,
+		tradeRepo,
+		orderUC,
+		positionUC,
+		accountUC,
+		gridUC,
+		dcaUC,
+		scriptUC,
+		a.config.Trading.InitialBalance,
 	)
 
-	accountUC := accountuc.NewUseCase(accountRepo, positionRepo)
+	signalUC := signaluc.NewUseCase(
+		signalRepo,
+		positionRepo,
+		priceCache,
+		orderUC,
+		positionUC,
+	)
+
+	alertUC := alertuc.NewUseCase(
+		alertRepo,
+		notificationRepo,
+		priceCache,
+		instruments,
+	)
 
 	// Initialize WebSocket hub
 	a.wsHub = ws.NewHub()
 	go a.wsHub.Run()
 
+	// Price triggered closes go to webhooks, to copy trading and to scripts
+	closeEvents := domain.EventPublishers{webhookUC, copyUC, scriptUC}
+
 	// Initialize price processor with WebSocket hub
 	a.priceProcessor = priceuc.NewProcessor(
 		positionRepo,
@@ -143,6 +351,11 @@ func (a *App) Run(ctx context.Context) error {
 		a.tradeProducer,
 		positionUC,
 		a.wsHub,
+		alertUC,
+		challengeUC,
+		orderUC,
+		scriptUC,
+		closeEvents,
 	)
 
 	// Initialize handlers
@@ -151,30 +364,70 @@ func (a *App) Run(ctx context.Context) error {
 	orderHandler := handler.NewOrderHandler(orderUC)
 	positionHandler := handler.NewPositionHandler(positionUC)
 	tradeHandler := handler.NewTradeHandler(tradeRepo)
+	exportHandler := handler.NewExportHandler(tradeRepo, orderRepo, positionRepo)
+	seasonHandler := handler.NewSeasonHandler(seasonUC)
+	equityHandler := handler.NewEquityHandler(equityUC)
+	statsHandler := handler.NewStatsHandler(statsUC)
+	reportHandler := handler.NewReportHandler(reportUC)
+	leaderboardHandler := handler.NewLeaderboardHandler(leaderboardUC)
+	competitionHandler := handler.NewCompetitionHandler(competitionUC)
+	challengeHandler := handler.NewChallengeHandler(challengeUC)
+	backtestHandler := handler.NewBacktestHandler(backtestUC)
+	gridBotHandler := handler.NewGridBotHandler(gridUC)
+	dcaHandler := handler.NewDCAHandler(dcaUC)
+	algoOrderHandler := handler.NewAlgoOrderHandler(algoUC)
+	copyTradingHandler := handler.NewCopyTradingHandler(copyUC)
+	scriptHandler := handler.NewScriptHandler(scriptUC)
+	signalHandler := handler.NewSignalHandler(signalUC)
 	userHandler := handler.NewUserHandler(userRepo)
-	priceHandler := handler.NewPriceHandler(priceCache, a.config.Trading.SupportedSymbols)
+	alertHandler := handler.NewAlertHandler(alertUC)
+	notificationHandler := handler.NewNotificationHandler(alertUC)
+	webhookHandler := handler.NewWebhookHandler(webhookUC)
+	priceHandler := handler.NewPriceHandler(priceCache, instruments)
 	candleHandler := handler.NewCandleHandler()
 	tickerHandler := handler.NewTickerHandler(a.config.Trading.SupportedSymbols)
 	wsHandler := handler.NewWebSocketHandler(a.wsHub, jwtService)
 
 	// Initialize middleware
 	authMiddleware := middleware.NewAuthMiddleware(jwtService)
+	accountMiddleware := middleware.NewAccountMiddleware(accountRepo)
+	adminMiddleware := middleware.NewAdminMiddleware(userRepo)
 
 	// Create router
 	router := httpdelivery.NewRouter(httpdelivery.RouterDeps{
-		AuthMiddleware:   authMiddleware,
-		AuthHandler:      authHandler,
-		AccountHandler:   accountHandler,
-		OrderHandler:     orderHandler,
-		PositionHandler:  positionHandler,
-		TradeHandler:     tradeHandler,
-		UserHandler:      userHandler,
-		PriceHandler:     priceHandler,
-		CandleHandler:    candleHandler,
-		TickerHandler:    tickerHandler,
-		WebSocketHandler: wsHandler,
-		UserRepo:         userRepo,
-		HealthChecker:    a.healthCheck,
+		AuthMiddleware:      authMiddleware,
+		AccountMiddleware:   accountMiddleware,
+		AdminMiddleware:     adminMiddleware,
+		AuthHandler:         authHandler,
+		AccountHandler:      accountHandler,
+		OrderHandler:        orderHandler,
+		PositionHandler:     positionHandler,
+		TradeHandler:        tradeHandler,
+		ExportHandler:       exportHandler,
+		SeasonHandler:       seasonHandler,
+		EquityHandler:       equityHandler,
+		StatsHandler:        statsHandler,
+		ReportHandler:       reportHandler,
+		LeaderboardHandler:  leaderboardHandler,
+		CompetitionHandler:  competitionHandler,
+		ChallengeHandler:    challengeHandler,
+		BacktestHandler:     backtestHandler,
+		GridBotHandler:      gridBotHandler,
+		DCAHandler:          dcaHandler,
+		AlgoOrderHandler:    algoOrderHandler,
+		CopyTradingHandler:  copyTradingHandler,
+		ScriptHandler:       scriptHandler,
+		SignalHandler:       signalHandler,
+		UserHandler:         userHandler,
+		AlertHandler:        alertHandler,
+		NotificationHandler: notificationHandler,
+		WebhookHandler:      webhookHandler,
+		PriceHandler:        priceHandler,
+		CandleHandler:       candleHandler,
+		TickerHandler:       tickerHandler,
+		WebSocketHandler:    wsHandler,
+		UserRepo:            userRepo,
+		HealthChecker:       a.healthCheck,
 	})
 
 	// Start HTTP server
@@ -199,12 +452,39 @@ func (a *App) Run(ctx context.Context) error {
 	// Start price processor
 	go a.priceProcessor.Start(ctx, a.priceConsumer.Prices())
 
+	// Start webhook delivery worker
+	go webhookUC.Start(ctx)
+
+	// Start copy trading workers
+	go copyUC.Start(ctx)
+
+	// Start equity snapshotter
+	go equityUC.Start(ctx)
+
+	// Start leaderboard refresher
+	go leaderboardUC.Start(ctx)
+
+	// Start competition finalizer
+	go competitionUC.Start(ctx)
+
+	// Start challenge sweeper
+	go challengeUC.Start(ctx)
+
+	// Start DCA scheduler
+	go dcaUC.Start(ctx)
+
+	// Start algo order scheduler
+	go algoUC.Start(ctx)
+
 	logger.Info("trading service started successfully")
 
 	// Wait for shutdown signal
 	<-ctx.Done()
 
 	logger.Info("shutting down trading service")
+
+	// Leader trades being copied finish before the database is closed
+	copyUC.Wait()
 	return nil
 }
 
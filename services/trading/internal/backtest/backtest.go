@@ -0,0 +1,300 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/engine"
+	"trading/internal/repository/memory"
+	"trading/internal/repository/postgres"
+	orderuc "trading/internal/usecase/order"
+	positionuc "trading/internal/usecase/position"
+	priceuc "trading/internal/usecase/price"
+)
+
+// quantityPlaces is the precision strategies size orders with
+const quantityPlaces = 6
+
+// Backtester replays candles through the same order, position and price
+// processing code as the live service, backed by in-memory repositories
+type Backtester struct {
+	engine *engine.Engine
+}
+
+func New(eng *engine.Engine) *Backtester {
+	return &Backtester{engine: eng}
+}
+
+type Input struct {
+	Symbol          string
+	Candles         []Candle // oldest first
+	Strategy        Strategy
+	StartingBalance decimal.Decimal
+	Spread          float64 // bid/ask spread as a share of the price
+}
+
+// Validate checks the input before a run
+func (in Input) Validate() error {
+	if in.Symbol == "" || in.Strategy == nil {
+		return domain.ErrInvalidBacktest
+	}
+	if len(in.Candles) == 0 {
+		return domain.ErrInvalidCandles
+	}
+	if !in.StartingBalance.IsPositive() {
+		return fmt.Errorf("%w: starting balance must be positive", domain.ErrInvalidBacktest)
+	}
+	if in.Spread < 0 || in.Spread >= 0.1 {
+		return fmt.Errorf("%w: spread must be between 0 and 0.1", domain.ErrInvalidBacktest)
+	}
+	return nil
+}
+
+// Run replays the candles. Each candle is fed to the price processor as four
+// ticks along its path, so liquidations and SL/TP fire inside the bar, then the
+// strategy sees the closed candle. Orders the engine rejects are counted and
+// the run goes on; any other error aborts it.
+func (b *Backtester) Run(ctx context.Context, input Input) (*Report, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	clock := input.Candles[0].Time
+	store := memory.NewStore(func() time.Time { return clock })
+	accountRepo := memory.NewAccountRepository(store)
+	ledgerRepo := memory.NewLedgerRepository(store)
+	walletRepo := memory.NewWalletRepository(store)
+	spotLotRepo := memory.NewSpotLotRepository(store)
+	orderRepo := memory.NewOrderRepository(store)
+	positionRepo := memory.NewPositionRepository(store)
+	tradeRepo := memory.NewTradeRepository(store)
+	priceCache := postgres.NewPriceCache()
+
+	positionUC := positionuc.NewUseCase(positionRepo, accountRepo, tradeRepo, orderRepo, ledgerRepo, priceCache, b.engine, nil)
+	orderUC := orderuc.NewUseCase(
+		orderRepo, positionRepo, accountRepo, tradeRepo, ledgerRepo, walletRepo, spotLotRepo, nil,
+		priceCache, b.engine, []domain.Instrument{domain.NewPerpetualInstrument(input.Symbol)}, nil,
+	)
+	processor := priceuc.NewProcessor(positionRepo, priceCache, b.engine, nil, positionUC, nil, nil, nil, nil, nil, nil)
+
+	account := &domain.Account{
+		Name:                  "backtest",
+		Balance:               input.StartingBalance,
+		SeasonStartingBalance: input.StartingBalance,
+	}
+	if err := accountRepo.Create(ctx, account); err != nil {
+		return nil, err
+	}
+
+	trader := &Trader{
+		symbol:       input.Symbol,
+		accountID:    account.ID,
+		accountRepo:  accountRepo,
+		positionRepo: positionRepo,
+		tradeRepo:    tradeRepo,
+		orderUC:      orderUC,
+		positionUC:   positionUC,
+	}
+	report := newReport(input)
+
+	for i, c := range input.Candles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		step := interval(input.Candles, i) / 3
+		for k, p := range c.path() {
+			clock = c.Time.Add(step * time.Duration(k))
+			price := quote(input.Symbol, p, input.Spread, clock)
+			priceCache.Set(price.Symbol, price)
+			if err := processor.ProcessPositions(ctx, price); err != nil {
+				return nil, err
+			}
+		}
+
+		trader.candles = input.Candles[:i+1]
+		if err := input.Strategy.OnCandle(ctx, trader, c); err != nil {
+			if !isRejection(err) {
+				return nil, fmt.Errorf("strategy at %s: %w", c.Time.Format(time.RFC3339), err)
+			}
+			report.Stats.RejectedOrders++
+		}
+
+		equity, err := trader.Equity(ctx)
+		if err != nil {
+			return nil, err
+		}
+		report.addEquity(clock, equity)
+	}
+
+	trades, err := tradeRepo.GetByAccountIDAfter(ctx, account.ID, nil, nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := tradeRepo.Summarize(ctx, account.ID, time.Time{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	report.finish(trades, *summary)
+
+	return report, nil
+}
+
+// interval is the length of bar i, taken from its neighbours
+func interval(candles []Candle, i int) time.Duration {
+	switch {
+	case i+1 < len(candles):
+		return candles[i+1].Time.Sub(candles[i].Time)
+	case i > 0:
+		return candles[i].Time.Sub(candles[i-1].Time)
+	}
+	return 0
+}
+
+func quote(symbol string, mid, spread float64, at time.Time) *domain.Price {
+	half := mid * spread / 2
+	return &domain.Price{
+		Symbol:    symbol,
+		Bid:       mid - half,
+		Ask:       mid + half,
+		Timestamp: at,
+		Source:    "backtest",
+	}
+}
+
+// isRejection reports whether the engine refused an order the strategy placed
+func isRejection(err error) bool {
+	for _, target := range []error{
+		domain.ErrInsufficientMargin,
+		domain.ErrInsufficientBalance,
+		domain.ErrInvalidQuantity,
+		domain.ErrInvalidLeverage,
+		domain.ErrInvalidStopLoss,
+		domain.ErrInvalidTakeProfit,
+		domain.ErrPositionNotFound,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderOptions are the optional parameters of a strategy order
+type OrderOptions struct {
+	Leverage   int // defaults to 1
+	StopLoss   *decimal.Decimal
+	TakeProfit *decimal.Decimal
+}
+
+// Trader is the account a strategy trades on during a backtest. Market orders
+// fill at the last price, i.e. the close of the current candle plus half the spread.
+type Trader struct {
+	symbol    string
+	accountID domain.AccountID
+	candles   []Candle
+
+	accountRepo  domain.AccountRepository
+	positionRepo domain.PositionRepository
+	tradeRepo    domain.TradeRepository
+	orderUC      *orderuc.UseCase
+	positionUC   *positionuc.UseCase
+}
+
+// Symbol returns the symbol the backtest trades
+func (t *Trader) Symbol() string {
+	return t.symbol
+}
+
+// Candles returns the candles seen so far, oldest first, ending with the current one
+func (t *Trader) Candles() []Candle {
+	return t.candles
+}
+
+// Position returns the open position, or nil when flat
+func (t *Trader) Position(ctx context.Context) (*domain.Position, error) {
+	position, err := t.positionRepo.GetOpenByAccountIDAndSymbol(ctx, t.accountID, t.symbol)
+	if errors.Is(err, domain.ErrPositionNotFound) {
+		return nil, nil
+	}
+	return position, err
+}
+
+// Equity returns the balance plus the unrealized PnL of the open position
+func (t *Trader) Equity(ctx context.Context) (decimal.Decimal, error) {
+	summary, err := t.Summary(ctx)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return summary.Equity, nil
+}
+
+// Summary returns the balance and margin of the account
+func (t *Trader) Summary(ctx context.Context) (*domain.AccountSummary, error) {
+	account, err := t.accountRepo.GetByID(ctx, t.accountID)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := t.positionRepo.GetOpenByAccountID(ctx, t.accountID)
+	if err != nil {
+		return nil, err
+	}
+	summary := account.CalculateSummary(positions, nil)
+	return &summary, nil
+}
+
+// Trades returns the trades of the run so far, oldest first
+func (t *Trader) Trades(ctx context.Context) ([]domain.Trade, error) {
+	return t.tradeRepo.GetByAccountIDAfter(ctx, t.accountID, nil, nil, 0, 0)
+}
+
+// Buy places a market buy, adding to a long or reducing a short
+func (t *Trader) Buy(ctx context.Context, quantity decimal.Decimal, opts OrderOptions) error {
+	_, err := t.Place(ctx, domain.OrderSideBuy, quantity, opts)
+	return err
+}
+
+// Sell places a market sell, adding to a short or reducing a long
+func (t *Trader) Sell(ctx context.Context, quantity decimal.Decimal, opts OrderOptions) error {
+	_, err := t.Place(ctx, domain.OrderSideSell, quantity, opts)
+	return err
+}
+
+// Close closes the open position, if any
+func (t *Trader) Close(ctx context.Context) error {
+	position, err := t.Position(ctx)
+	if err != nil || position == nil {
+		return err
+	}
+	_, err = t.positionUC.ClosePosition(ctx, positionuc.ClosePositionInput{
+		AccountID:  t.accountID,
+		PositionID: position.ID,
+	})
+	return err
+}
+
+// Place places a market order and returns its ID
+func (t *Trader) Place(ctx context.Context, side domain.OrderSide, quantity decimal.Decimal, opts OrderOptions) (domain.OrderID, error) {
+	if opts.Leverage == 0 {
+		opts.Leverage = 1
+	}
+	output, err := t.orderUC.PlaceOrder(ctx, orderuc.PlaceOrderInput{
+		AccountID:  t.accountID,
+		Symbol:     t.symbol,
+		Side:       side,
+		Type:       domain.OrderTypeMarket,
+		Quantity:   quantity.Truncate(quantityPlaces),
+		Leverage:   opts.Leverage,
+		StopLoss:   opts.StopLoss,
+		TakeProfit: opts.TakeProfit,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return output.Order.ID, nil
+}
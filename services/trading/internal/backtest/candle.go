@@ -0,0 +1,149 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading/internal/domain"
+)
+
+// Candle is one OHLCV bar; Time is the bar's open time
+type Candle struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// path returns the prices the bar is assumed to have traded through:
+// open, the nearer extreme, the farther extreme, close. A rising bar is
+// assumed to dip first and a falling bar to rally first.
+func (c Candle) path() [4]float64 {
+	if c.Close >= c.Open {
+		return [4]float64{c.Open, c.Low, c.High, c.Close}
+	}
+	return [4]float64{c.Open, c.High, c.Low, c.Close}
+}
+
+func (c Candle) valid() bool {
+	return c.Low > 0 && c.Low <= c.Open && c.Low <= c.Close &&
+		c.High >= c.Open && c.High >= c.Close && c.Volume >= 0
+}
+
+// ReadCSV parses candles with the columns time,open,high,low,close[,volume].
+// Time is unix seconds, unix milliseconds or RFC3339; a header row is skipped.
+// Extra columns, as in exchange kline dumps, are ignored. Candles are returned
+// oldest first.
+func ReadCSV(r io.Reader) ([]Candle, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var candles []Candle
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidCandles, err)
+		}
+		if len(record) < 5 {
+			return nil, fmt.Errorf("%w: line %d has %d columns", domain.ErrInvalidCandles, line, len(record))
+		}
+
+		c, err := parseCandle(record)
+		if err != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("%w: line %d: %v", domain.ErrInvalidCandles, line, err)
+		}
+		if !c.valid() {
+			return nil, fmt.Errorf("%w: line %d is not a valid bar", domain.ErrInvalidCandles, line)
+		}
+		candles = append(candles, c)
+	}
+
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("%w: no candles", domain.ErrInvalidCandles)
+	}
+	sort.SliceStable(candles, func(i, j int) bool { return candles[i].Time.Before(candles[j].Time) })
+	for i := 1; i < len(candles); i++ {
+		if !candles[i].Time.After(candles[i-1].Time) {
+			return nil, fmt.Errorf("%w: duplicate bar at %s", domain.ErrInvalidCandles, candles[i].Time.Format(time.RFC3339))
+		}
+	}
+	return candles, nil
+}
+
+// LoadDataset reads a CSV file stored in dir. The name must be a plain file name.
+func LoadDataset(dir, name string) ([]Candle, error) {
+	if name == "" || filepath.Base(name) != name || !strings.HasSuffix(name, ".csv") {
+		return nil, domain.ErrDatasetNotFound
+	}
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, domain.ErrDatasetNotFound
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadCSV(f)
+}
+
+// Datasets lists the CSV files stored in dir
+func Datasets(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".csv") {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+func parseCandle(record []string) (Candle, error) {
+	t, err := parseCandleTime(record[0])
+	if err != nil {
+		return Candle{}, err
+	}
+	values := make([]float64, 5)
+	for i := 1; i < len(record) && i <= 5; i++ {
+		v, err := strconv.ParseFloat(record[i], 64)
+		if err != nil {
+			return Candle{}, err
+		}
+		values[i-1] = v
+	}
+	return Candle{Time: t, Open: values[0], High: values[1], Low: values[2], Close: values[3], Volume: values[4]}, nil
+}
+
+func parseCandleTime(value string) (time.Time, error) {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if n > 1e12 {
+			return time.UnixMilli(n).UTC(), nil
+		}
+		return time.Unix(n, 0).UTC(), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,103 @@
+package backtest
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// EquityPoint is the account equity after a candle closed
+type EquityPoint struct {
+	Time     time.Time
+	Equity   decimal.Decimal
+	Drawdown decimal.Decimal // percent below the highest equity so far
+}
+
+// Stats summarize the trades and equity curve of a run
+type Stats struct {
+	domain.TradeSummary
+	WinRate        decimal.Decimal // percent of closed trades with positive PnL
+	GrossProfit    decimal.Decimal
+	GrossLoss      decimal.Decimal // positive
+	ProfitFactor   decimal.Decimal // gross profit / gross loss, zero without losing trades
+	AverageWin     decimal.Decimal
+	AverageLoss    decimal.Decimal // positive
+	Fees           decimal.Decimal
+	ReturnPct      decimal.Decimal // final equity against the starting balance
+	MaxDrawdownPct decimal.Decimal
+	RejectedOrders int // orders the engine refused, e.g. for lack of margin
+}
+
+// Report is the result of a backtest
+type Report struct {
+	Symbol          string
+	From            time.Time // open time of the first candle
+	To              time.Time // close of the last candle
+	Candles         int
+	StartingBalance decimal.Decimal
+	FinalEquity     decimal.Decimal
+	Stats           Stats
+	Trades          []domain.Trade // oldest first
+	Equity          []EquityPoint  // one point per candle
+
+	peak decimal.Decimal
+}
+
+func newReport(input Input) *Report {
+	return &Report{
+		Symbol:          input.Symbol,
+		From:            input.Candles[0].Time,
+		Candles:         len(input.Candles),
+		StartingBalance: input.StartingBalance,
+		FinalEquity:     input.StartingBalance,
+		Equity:          make([]EquityPoint, 0, len(input.Candles)),
+		peak:            input.StartingBalance,
+	}
+}
+
+func (r *Report) addEquity(at time.Time, equity decimal.Decimal) {
+	if equity.GreaterThan(r.peak) {
+		r.peak = equity
+	}
+	drawdown := decimal.Zero
+	if r.peak.IsPositive() {
+		drawdown = r.peak.Sub(equity).Div(r.peak).Mul(hundred).Round(2)
+	}
+	if drawdown.GreaterThan(r.Stats.MaxDrawdownPct) {
+		r.Stats.MaxDrawdownPct = drawdown
+	}
+
+	r.To = at
+	r.FinalEquity = equity
+	r.Equity = append(r.Equity, EquityPoint{Time: at, Equity: equity, Drawdown: drawdown})
+}
+
+func (r *Report) finish(trades []domain.Trade, summary domain.TradeSummary) {
+	r.Trades = trades
+	s := &r.Stats
+	s.TradeSummary = summary
+	s.WinRate = summary.WinRate().Mul(hundred).Round(2)
+
+	for _, t := range trades {
+		s.Fees = s.Fees.Add(t.Fee)
+		if t.PnL.IsPositive() {
+			s.GrossProfit = s.GrossProfit.Add(t.PnL)
+		} else {
+			s.GrossLoss = s.GrossLoss.Sub(t.PnL)
+		}
+	}
+	if s.GrossLoss.IsPositive() {
+		s.ProfitFactor = s.GrossProfit.Div(s.GrossLoss).Round(4)
+	}
+	if summary.WinningTrades > 0 {
+		s.AverageWin = s.GrossProfit.Div(decimal.NewFromInt(int64(summary.WinningTrades)))
+	}
+	if summary.LosingTrades > 0 {
+		s.AverageLoss = s.GrossLoss.Div(decimal.NewFromInt(int64(summary.LosingTrades)))
+	}
+	s.ReturnPct = r.FinalEquity.Sub(r.StartingBalance).Div(r.StartingBalance).Mul(hundred).Round(2)
+}
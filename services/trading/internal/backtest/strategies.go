@@ -0,0 +1,190 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+// sizing turns the quantity, allocation and leverage parameters into order sizes.
+// A fixed quantity wins; otherwise the order uses a share of equity times leverage.
+type sizing struct {
+	quantity   float64
+	allocation float64
+	leverage   int
+}
+
+func newSizing(params map[string]float64) (sizing, error) {
+	quantity, err := param(params, "quantity", 0, 0, math.MaxFloat64)
+	if err != nil {
+		return sizing{}, err
+	}
+	allocation, err := param(params, "allocation", 0.95, 0.01, 1)
+	if err != nil {
+		return sizing{}, err
+	}
+	leverage, err := param(params, "leverage", 1, 1, 1000)
+	if err != nil {
+		return sizing{}, err
+	}
+	return sizing{quantity: quantity, allocation: allocation, leverage: int(leverage)}, nil
+}
+
+func (s sizing) size(ctx context.Context, t *Trader, price float64) (decimal.Decimal, error) {
+	if s.quantity > 0 {
+		return decimal.NewFromFloat(s.quantity), nil
+	}
+	equity, err := t.Equity(ctx)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	notional := equity.Mul(decimal.NewFromFloat(s.allocation)).Mul(decimal.NewFromInt(int64(s.leverage)))
+	return notional.Div(decimal.NewFromFloat(price)).Truncate(quantityPlaces), nil
+}
+
+// buyAndHold goes long on the first candle and keeps the position
+type buyAndHold struct {
+	sizing
+	bought bool
+}
+
+func newBuyAndHold(params map[string]float64) (Strategy, error) {
+	s, err := newSizing(params)
+	if err != nil {
+		return nil, err
+	}
+	return &buyAndHold{sizing: s}, nil
+}
+
+func (s *buyAndHold) OnCandle(ctx context.Context, t *Trader, c Candle) error {
+	if s.bought {
+		return nil
+	}
+	s.bought = true
+
+	quantity, err := s.size(ctx, t, c.Close)
+	if err != nil {
+		return err
+	}
+	return t.Buy(ctx, quantity, OrderOptions{Leverage: s.leverage})
+}
+
+// smaCross is always in the market once the slow average is available: long
+// while the fast simple moving average of closes is above the slow one, short
+// while it is below. Optional stop loss and take profit are set in percent
+// from the close the position was opened on.
+type smaCross struct {
+	sizing
+	fast, slow           int
+	stopLoss, takeProfit float64
+	prevDiff             float64
+	hasPrev              bool
+}
+
+func newSMACross(params map[string]float64) (Strategy, error) {
+	s, err := newSizing(params)
+	if err != nil {
+		return nil, err
+	}
+	fast, err := param(params, "fast", 10, 1, 1000)
+	if err != nil {
+		return nil, err
+	}
+	slow, err := param(params, "slow", 30, 2, 5000)
+	if err != nil {
+		return nil, err
+	}
+	if fast >= slow {
+		return nil, fmt.Errorf("%w: fast must be below slow", domain.ErrInvalidStrategy)
+	}
+	stopLoss, err := param(params, "stop_loss_pct", 0, 0, 99)
+	if err != nil {
+		return nil, err
+	}
+	takeProfit, err := param(params, "take_profit_pct", 0, 0, 1000)
+	if err != nil {
+		return nil, err
+	}
+	return &smaCross{
+		sizing:     s,
+		fast:       int(fast),
+		slow:       int(slow),
+		stopLoss:   stopLoss,
+		takeProfit: takeProfit,
+	}, nil
+}
+
+func (s *smaCross) OnCandle(ctx context.Context, t *Trader, c Candle) error {
+	candles := t.Candles()
+	if len(candles) < s.slow {
+		return nil
+	}
+	diff := sma(candles, s.fast) - sma(candles, s.slow)
+	prev, hasPrev := s.prevDiff, s.hasPrev
+	s.prevDiff, s.hasPrev = diff, true
+	if !hasPrev {
+		return nil
+	}
+
+	switch {
+	case prev <= 0 && diff > 0:
+		return s.enter(ctx, t, c, domain.PositionSideLong)
+	case prev >= 0 && diff < 0:
+		return s.enter(ctx, t, c, domain.PositionSideShort)
+	}
+	return nil
+}
+
+// enter closes an opposite position and opens one on side
+func (s *smaCross) enter(ctx context.Context, t *Trader, c Candle, side domain.PositionSide) error {
+	position, err := t.Position(ctx)
+	if err != nil {
+		return err
+	}
+	if position != nil {
+		if position.Side == side {
+			return nil
+		}
+		if err := t.Close(ctx); err != nil {
+			return err
+		}
+	}
+
+	quantity, err := s.size(ctx, t, c.Close)
+	if err != nil {
+		return err
+	}
+
+	// Stop loss below and take profit above the close for longs, the other way round for shorts
+	sign := 1.0
+	if side == domain.PositionSideShort {
+		sign = -1
+	}
+	opts := OrderOptions{Leverage: s.leverage}
+	if s.stopLoss > 0 {
+		sl := decimal.NewFromFloat(c.Close * (1 - sign*s.stopLoss/100))
+		opts.StopLoss = &sl
+	}
+	if s.takeProfit > 0 {
+		tp := decimal.NewFromFloat(c.Close * (1 + sign*s.takeProfit/100))
+		opts.TakeProfit = &tp
+	}
+
+	if side == domain.PositionSideLong {
+		return t.Buy(ctx, quantity, opts)
+	}
+	return t.Sell(ctx, quantity, opts)
+}
+
+// sma is the simple moving average of the last n closes
+func sma(candles []Candle, n int) float64 {
+	sum := 0.0
+	for _, c := range candles[len(candles)-n:] {
+		sum += c.Close
+	}
+	return sum / float64(n)
+}
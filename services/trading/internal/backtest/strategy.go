@@ -0,0 +1,73 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"trading/internal/domain"
+)
+
+// Strategy decides on orders each time a candle closes. Orders placed from
+// OnCandle fill at the candle's close price.
+type Strategy interface {
+	OnCandle(ctx context.Context, t *Trader, c Candle) error
+}
+
+// StrategyFactory builds a strategy from its numeric parameters
+type StrategyFactory func(params map[string]float64) (Strategy, error)
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]StrategyFactory{
+		"buy_and_hold": newBuyAndHold,
+		"sma_cross":    newSMACross,
+	}
+)
+
+// Register makes a strategy available by name
+func Register(name string, factory StrategyFactory) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	strategies[name] = factory
+}
+
+// NewStrategy builds a registered strategy
+func NewStrategy(name string, params map[string]float64) (Strategy, error) {
+	strategiesMu.RLock()
+	factory, ok := strategies[name]
+	strategiesMu.RUnlock()
+	if !ok {
+		return nil, domain.ErrUnknownStrategy
+	}
+	if params == nil {
+		params = map[string]float64{}
+	}
+	return factory(params)
+}
+
+// StrategyNames lists the registered strategies
+func StrategyNames() []string {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// param returns a parameter or its default, rejecting values outside [min, max]
+func param(params map[string]float64, name string, def, min, max float64) (float64, error) {
+	v, ok := params[name]
+	if !ok {
+		return def, nil
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("%w: %s must be between %v and %v", domain.ErrInvalidStrategy, name, min, max)
+	}
+	return v, nil
+}
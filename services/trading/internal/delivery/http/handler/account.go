@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
 
 	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
 	accountuc "trading/internal/usecase/account"
 )
 
@@ -16,9 +23,9 @@ func NewAccountHandler(accountUC *accountuc.UseCase) *AccountHandler {
 }
 
 func (h *AccountHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
 
-	info, err := h.accountUC.GetAccountInfo(r.Context(), userID)
+	info, err := h.accountUC.GetAccountInfo(r.Context(), accountID)
 	if err != nil {
 		writeError(w, "failed to get account info", http.StatusInternalServerError)
 		return
@@ -26,3 +33,250 @@ func (h *AccountHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, info, http.StatusOK)
 }
+
+// ListAccounts returns all accounts of the user
+// GET /accounts
+func (h *AccountHandler) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	accounts, err := h.accountUC.ListAccounts(r.Context(), userID)
+	if err != nil {
+		writeError(w, "failed to get accounts", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, accounts, http.StatusOK)
+}
+
+type CreateAccountRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateAccount opens a new sub-account
+// POST /accounts
+func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	var req CreateAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	info, err := h.accountUC.CreateAccount(r.Context(), userID, req.Name)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAccountName) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, domain.ErrAccountNameTaken) {
+			writeError(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, domain.ErrTooManyAccounts) {
+			writeError(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		writeError(w, "failed to create account", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, info, http.StatusCreated)
+}
+
+type TransferRequest struct {
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        string `json:"amount"`
+}
+
+// Transfer moves balance between the user's accounts
+// POST /accounts/transfer
+func (h *AccountHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	var req TransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		writeError(w, "invalid amount", http.StatusBadRequest)
+		return
+	}
+
+	err = h.accountUC.Transfer(r.Context(), accountuc.TransferInput{
+		UserID:        userID,
+		FromAccountID: domain.AccountID(req.FromAccountID),
+		ToAccountID:   domain.AccountID(req.ToAccountID),
+		Amount:        amount,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrIsolatedAccount) {
+			writeError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidTransfer) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			writeError(w, "account not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrInsufficientMargin) || errors.Is(err, domain.ErrInsufficientBalance) {
+			writeError(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		writeError(w, "failed to transfer", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]string{"status": "completed"}, http.StatusOK)
+}
+
+type ConvertRequest struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount string `json:"amount"` // in units of From
+}
+
+type ConvertResponse struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Amount   string `json:"amount"`
+	Received string `json:"received"`
+}
+
+// Convert exchanges wallet assets at current prices
+// POST /account/convert
+func (h *AccountHandler) Convert(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	var req ConvertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		writeError(w, "invalid amount", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.accountUC.Convert(r.Context(), accountuc.ConvertInput{
+		AccountID: accountID,
+		From:      domain.Asset(strings.ToUpper(req.From)),
+		To:        domain.Asset(strings.ToUpper(req.To)),
+		Amount:    amount,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAsset) || errors.Is(err, domain.ErrInvalidConversion) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, domain.ErrInsufficientMargin) || errors.Is(err, domain.ErrInsufficientBalance) {
+			writeError(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		if errors.Is(err, domain.ErrAccountLocked) ||
+			errors.Is(err, domain.ErrCompetitionNotActive) ||
+			errors.Is(err, domain.ErrSymbolNotAllowed) {
+			writeError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, domain.ErrPriceNotAvailable) {
+			writeError(w, "price not available", http.StatusServiceUnavailable)
+			return
+		}
+		writeError(w, "failed to convert", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, ConvertResponse{
+		From:     string(output.From),
+		To:       string(output.To),
+		Amount:   output.Amount.String(),
+		Received: output.Received.String(),
+	}, http.StatusOK)
+}
+
+type LedgerEntryResponse struct {
+	ID           int64  `json:"id"`
+	Type         string `json:"type"`
+	Asset        string `json:"asset"`
+	Amount       string `json:"amount"`
+	BalanceAfter string `json:"balance_after"`
+	TradeID      *int64 `json:"trade_id,omitempty"`
+	PositionID   *int64 `json:"position_id,omitempty"`
+	Description  string `json:"description,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// GetLedger returns the account's balance ledger
+// GET /account/ledger?type=REALIZED_PNL,FEE&asset=&from=&to=&limit=&offset=
+func (h *AccountHandler) GetLedger(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+	q := r.URL.Query()
+
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	offset, _ := strconv.Atoi(q.Get("offset"))
+
+	filter := domain.LedgerFilter{
+		Asset:  domain.Asset(strings.ToUpper(q.Get("asset"))),
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	if types := q.Get("type"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			filter.Types = append(filter.Types, domain.LedgerEntryType(strings.ToUpper(strings.TrimSpace(t))))
+		}
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(q.Get("from")); err != nil {
+		writeError(w, "invalid from", http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = parseTimeParam(q.Get("to")); err != nil {
+		writeError(w, "invalid to", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := h.accountUC.GetLedger(r.Context(), accountID, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidLedgerEntryType) || errors.Is(err, domain.ErrInvalidAsset) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeError(w, "failed to get ledger", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]LedgerEntryResponse, len(entries))
+	for i, e := range entries {
+		response[i] = LedgerEntryResponse{
+			ID:           int64(e.ID),
+			Type:         string(e.Type),
+			Asset:        string(e.Asset),
+			Amount:       e.Amount.String(),
+			BalanceAfter: e.BalanceAfter.String(),
+			Description:  e.Description,
+			CreatedAt:    e.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+		if e.TradeID != nil {
+			id := int64(*e.TradeID)
+			response[i].TradeID = &id
+		}
+		if e.PositionID != nil {
+			id := int64(*e.PositionID)
+			response[i].PositionID = &id
+		}
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	alertuc "trading/internal/usecase/alert"
+)
+
+type AlertHandler struct {
+	alertUC *alertuc.UseCase
+}
+
+func NewAlertHandler(alertUC *alertuc.UseCase) *AlertHandler {
+	return &AlertHandler{alertUC: alertUC}
+}
+
+type CreateAlertRequest struct {
+	Symbol        string  `json:"symbol"`
+	Condition     string  `json:"condition"`      // ABOVE, BELOW, CROSS or CHANGE_PERCENT
+	TargetPrice   *string `json:"target_price"`   // for ABOVE, BELOW, CROSS
+	ChangePercent *string `json:"change_percent"` // for CHANGE_PERCENT
+	Window        string  `json:"window"`         // for CHANGE_PERCENT, e.g. "1h"
+	Note          string  `json:"note"`
+}
+
+type UpdateAlertRequest struct {
+	TargetPrice   *string `json:"target_price"`
+	ChangePercent *string `json:"change_percent"`
+	Window        *string `json:"window"`
+	Note          *string `json:"note"`
+}
+
+type AlertResponse struct {
+	ID             int64   `json:"id"`
+	Symbol         string  `json:"symbol"`
+	Condition      string  `json:"condition"`
+	TargetPrice    *string `json:"target_price,omitempty"`
+	ReferencePrice *string `json:"reference_price,omitempty"`
+	ChangePercent  *string `json:"change_percent,omitempty"`
+	Window         string  `json:"window,omitempty"`
+	Note           string  `json:"note,omitempty"`
+	Status         string  `json:"status"`
+	TriggeredPrice *string `json:"triggered_price,omitempty"`
+	TriggeredAt    *string `json:"triggered_at,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+// CreateAlert creates a price alert
+// POST /alerts
+func (h *AlertHandler) CreateAlert(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	var req CreateAlertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := alertuc.CreateAlertInput{
+		UserID:    userID,
+		Symbol:    req.Symbol,
+		Condition: domain.AlertCondition(req.Condition),
+		Note:      req.Note,
+	}
+
+	if req.TargetPrice != nil {
+		p, err := decimal.NewFromString(*req.TargetPrice)
+		if err != nil {
+			writeError(w, "invalid target_price", http.StatusBadRequest)
+			return
+		}
+		input.TargetPrice = &p
+	}
+	if req.ChangePercent != nil {
+		c, err := decimal.NewFromString(*req.ChangePercent)
+		if err != nil {
+			writeError(w, "invalid change_percent", http.StatusBadRequest)
+			return
+		}
+		input.ChangePercent = &c
+	}
+	if req.Window != "" {
+		window, err := time.ParseDuration(req.Window)
+		if err != nil {
+			writeError(w, "invalid window", http.StatusBadRequest)
+			return
+		}
+		input.Window = window
+	}
+
+	alert, err := h.alertUC.CreateAlert(r.Context(), input)
+	if err != nil {
+		h.writeAlertError(w, err)
+		return
+	}
+
+	writeJSON(w, alertToResponse(alert), http.StatusCreated)
+}
+
+// GetAlerts returns the user's alerts, newest first
+// GET /alerts?status=ACTIVE|TRIGGERED
+func (h *AlertHandler) GetAlerts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	status := domain.AlertStatus(r.URL.Query().Get("status"))
+
+	alerts, err := h.alertUC.GetAlerts(r.Context(), userID, status)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAlert) {
+			writeError(w, "invalid status", http.StatusBadRequest)
+			return
+		}
+		writeError(w, "failed to get alerts", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]AlertResponse, len(alerts))
+	for i, a := range alerts {
+		response[i] = alertToResponse(&a)
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetAlert returns a single alert
+// GET /alerts/{id}
+func (h *AlertHandler) GetAlert(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	alertID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid alert id", http.StatusBadRequest)
+		return
+	}
+
+	alert, err := h.alertUC.GetAlert(r.Context(), userID, domain.AlertID(alertID))
+	if err != nil {
+		h.writeAlertError(w, err)
+		return
+	}
+
+	writeJSON(w, alertToResponse(alert), http.StatusOK)
+}
+
+// UpdateAlert changes an alert and re-arms it
+// PATCH /alerts/{id}
+func (h *AlertHandler) UpdateAlert(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	alertID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid alert id", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateAlertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := alertuc.UpdateAlertInput{Note: req.Note}
+
+	if req.TargetPrice != nil {
+		p, err := decimal.NewFromString(*req.TargetPrice)
+		if err != nil {
+			writeError(w, "invalid target_price", http.StatusBadRequest)
+			return
+		}
+		input.TargetPrice = &p
+	}
+	if req.ChangePercent != nil {
+		c, err := decimal.NewFromString(*req.ChangePercent)
+		if err != nil {
+			writeError(w, "invalid change_percent", http.StatusBadRequest)
+			return
+		}
+		input.ChangePercent = &c
+	}
+	if req.Window != nil {
+		window, err := time.ParseDuration(*req.Window)
+		if err != nil {
+			writeError(w, "invalid window", http.StatusBadRequest)
+			return
+		}
+		input.Window = &window
+	}
+
+	alert, err := h.alertUC.UpdateAlert(r.Context(), userID, domain.AlertID(alertID), input)
+	if err != nil {
+		h.writeAlertError(w, err)
+		return
+	}
+
+	writeJSON(w, alertToResponse(alert), http.StatusOK)
+}
+
+// DeleteAlert removes an alert
+// DELETE /alerts/{id}
+func (h *AlertHandler) DeleteAlert(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	alertID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid alert id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.alertUC.DeleteAlert(r.Context(), userID, domain.AlertID(alertID)); err != nil {
+		h.writeAlertError(w, err)
+		return
+	}
+
+	writeJSON(w, map[string]string{"status": "deleted"}, http.StatusOK)
+}
+
+func (h *AlertHandler) writeAlertError(w http.ResponseWriter, err error) {
+	if errors.Is(err, domain.ErrAlertNotFound) {
+		writeError(w, "alert not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidAlert) || errors.Is(err, domain.ErrSymbolNotSupported) {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, domain.ErrTooManyAlerts) {
+		writeError(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if errors.Is(err, domain.ErrPriceNotAvailable) {
+		writeError(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	writeError(w, "failed to process alert", http.StatusInternalServerError)
+}
+
+func alertToResponse(a *domain.PriceAlert) AlertResponse {
+	resp := AlertResponse{
+		ID:        int64(a.ID),
+		Symbol:    a.Symbol,
+		Condition: string(a.Condition),
+		Note:      a.Note,
+		Status:    string(a.Status),
+		CreatedAt: a.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if a.TargetPrice != nil {
+		s := a.TargetPrice.String()
+		resp.TargetPrice = &s
+	}
+	if a.ReferencePrice != nil {
+		s := a.ReferencePrice.String()
+		resp.ReferencePrice = &s
+	}
+	if a.ChangePercent != nil {
+		s := a.ChangePercent.String()
+		resp.ChangePercent = &s
+	}
+	if a.Window > 0 {
+		resp.Window = a.Window.String()
+	}
+	if a.TriggeredPrice != nil {
+		s := a.TriggeredPrice.String()
+		resp.TriggeredPrice = &s
+	}
+	if a.TriggeredAt != nil {
+		s := a.TriggeredAt.Format("2006-01-02T15:04:05Z")
+		resp.TriggeredAt = &s
+	}
+	return resp
+}
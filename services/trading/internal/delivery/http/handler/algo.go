@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	algouc "trading/internal/usecase/algo"
+)
+
+type AlgoOrderHandler struct {
+	algoUC *algouc.UseCase
+}
+
+func NewAlgoOrderHandler(algoUC *algouc.UseCase) *AlgoOrderHandler {
+	return &AlgoOrderHandler{algoUC: algoUC}
+}
+
+type CreateAlgoOrderRequest struct {
+	Symbol          string `json:"symbol"`
+	Side            string `json:"side"` // BUY or SELL
+	Type            string `json:"type"` // TWAP, VWAP, ICEBERG or SCALE
+	Quantity        string `json:"quantity"`
+	Price           string `json:"price,omitempty"` // optional TWAP/VWAP limit, the ICEBERG limit
+	Leverage        int    `json:"leverage,omitempty"`
+	Duration        string `json:"duration,omitempty"` // TWAP/VWAP, e.g. "30m", "2h"
+	Slices          int    `json:"slices,omitempty"`
+	Dataset         string `json:"dataset,omitempty"`          // VWAP volume profile
+	VisibleQuantity string `json:"visible_quantity,omitempty"` // ICEBERG
+	PriceFrom       string `json:"price_from,omitempty"`       // SCALE
+	PriceTo         string `json:"price_to,omitempty"`         // SCALE
+	Distribution    string `json:"distribution,omitempty"`     // SCALE: LINEAR or GEOMETRIC
+}
+
+type AlgoSliceResponse struct {
+	Index          int    `json:"index"`
+	DueAt          string `json:"due_at"`
+	Quantity       string `json:"quantity"`
+	LimitPrice     string `json:"limit_price"` // 0 for TWAP and VWAP slices
+	Status         string `json:"status"`
+	OrderID        *int64 `json:"order_id"`
+	FilledQuantity string `json:"filled_quantity"`
+	Price          string `json:"price"`
+	Error          string `json:"error,omitempty"`
+}
+
+type AlgoOrderResponse struct {
+	ID                int64               `json:"id"`
+	Symbol            string              `json:"symbol"`
+	Side              string              `json:"side"`
+	Type              string              `json:"type"`
+	Status            string              `json:"status"`
+	Quantity          string              `json:"quantity"`
+	Price             string              `json:"price"` // limit, 0 for none
+	Leverage          int                 `json:"leverage"`
+	Duration          string              `json:"duration"`
+	Dataset           string              `json:"dataset,omitempty"`
+	VisibleQuantity   string              `json:"visible_quantity,omitempty"`
+	PriceFrom         string              `json:"price_from,omitempty"`
+	PriceTo           string              `json:"price_to,omitempty"`
+	Distribution      string              `json:"distribution,omitempty"`
+	StartAt           string              `json:"start_at"`
+	EndAt             string              `json:"end_at"`
+	FilledQuantity    string              `json:"filled_quantity"`
+	RemainingQuantity string              `json:"remaining_quantity"`
+	AveragePrice      string              `json:"average_price"`
+	ArrivalPrice      string              `json:"arrival_price"`
+	SlippageBps       string              `json:"slippage_bps"` // average price vs arrival, positive is worse
+	Slices            []AlgoSliceResponse `json:"slices"`
+	CreatedAt         string              `json:"created_at"`
+}
+
+// CreateAlgoOrder places a TWAP or VWAP order that executes in slices over
+// its duration, the first slice right away, or an iceberg or scale order whose
+// slices rest as limit orders until filled
+// POST /orders/algo
+func (h *AlgoOrderHandler) CreateAlgoOrder(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	var req CreateAlgoOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := algouc.CreateInput{
+		AccountID:    accountID,
+		Symbol:       req.Symbol,
+		Side:         domain.OrderSide(req.Side),
+		Type:         domain.OrderType(req.Type),
+		Leverage:     req.Leverage,
+		Slices:       req.Slices,
+		Dataset:      req.Dataset,
+		Distribution: domain.ScaleDistribution(req.Distribution),
+	}
+
+	var err error
+	if input.Quantity, err = decimal.NewFromString(req.Quantity); err != nil {
+		writeError(w, "invalid quantity", http.StatusBadRequest)
+		return
+	}
+	fields := []struct {
+		name  string
+		value string
+		dest  *decimal.Decimal
+	}{
+		{"price", req.Price, &input.Price},
+		{"visible_quantity", req.VisibleQuantity, &input.Visible},
+		{"price_from", req.PriceFrom, &input.PriceFrom},
+		{"price_to", req.PriceTo, &input.PriceTo},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if *f.dest, err = decimal.NewFromString(f.value); err != nil {
+			writeError(w, "invalid "+f.name, http.StatusBadRequest)
+			return
+		}
+	}
+	if req.Duration != "" {
+		if input.Duration, err = time.ParseDuration(req.Duration); err != nil {
+			writeError(w, "invalid duration", http.StatusBadRequest)
+			return
+		}
+	}
+
+	execution, err := h.algoUC.Create(r.Context(), input)
+	if err != nil {
+		writeAlgoError(w, err, "failed to create algo order")
+		return
+	}
+
+	writeJSON(w, algoOrderToResponse(execution), http.StatusCreated)
+}
+
+// GetAlgoOrder returns an algo order with its progress and slices
+// GET /orders/algo/{id}
+func (h *AlgoOrderHandler) GetAlgoOrder(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseAlgoOrderID(w, r)
+	if !ok {
+		return
+	}
+
+	execution, err := h.algoUC.Get(r.Context(), accountID, id)
+	if err != nil {
+		writeAlgoError(w, err, "failed to get algo order")
+		return
+	}
+
+	writeJSON(w, algoOrderToResponse(execution), http.StatusOK)
+}
+
+// CancelAlgoOrder cancels the remaining slices; filled slices are kept
+// POST /orders/algo/{id}/cancel
+func (h *AlgoOrderHandler) CancelAlgoOrder(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseAlgoOrderID(w, r)
+	if !ok {
+		return
+	}
+
+	execution, err := h.algoUC.Cancel(r.Context(), accountID, id)
+	if err != nil {
+		writeAlgoError(w, err, "failed to cancel algo order")
+		return
+	}
+
+	writeJSON(w, algoOrderToResponse(execution), http.StatusOK)
+}
+
+func parseAlgoOrderID(w http.ResponseWriter, r *http.Request) (domain.OrderID, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid order id", http.StatusBadRequest)
+		return 0, false
+	}
+	return domain.OrderID(id), true
+}
+
+func writeAlgoError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidAlgoOrder),
+		errors.Is(err, domain.ErrInvalidOrderType),
+		errors.Is(err, domain.ErrInvalidOrderSide),
+		errors.Is(err, domain.ErrInvalidQuantity),
+		errors.Is(err, domain.ErrInvalidLeverage),
+		errors.Is(err, domain.ErrInvalidPrice),
+		errors.Is(err, domain.ErrSymbolNotSupported),
+		errors.Is(err, domain.ErrDatasetNotFound),
+		errors.Is(err, domain.ErrInvalidCandles):
+		writeError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrAlgoOrderNotFound),
+		errors.Is(err, domain.ErrOrderNotFound):
+		writeError(w, domain.ErrAlgoOrderNotFound.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrOrderNotPending):
+		writeError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrTooManyAlgoOrders):
+		writeError(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, domain.ErrCompetitionNotActive),
+		errors.Is(err, domain.ErrSymbolNotAllowed),
+		errors.Is(err, domain.ErrAccountLocked):
+		writeError(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, domain.ErrPriceNotAvailable):
+		writeError(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		writeError(w, fallback, http.StatusInternalServerError)
+	}
+}
+
+func algoOrderToResponse(e *algouc.Execution) AlgoOrderResponse {
+	o, a := e.Order, e.Algo
+	filled := a.FilledQuantity()
+	response := AlgoOrderResponse{
+		ID:                int64(o.ID),
+		Symbol:            o.Symbol,
+		Side:              string(o.Side),
+		Type:              string(o.Type),
+		Status:            string(o.Status),
+		Quantity:          o.Quantity.String(),
+		Price:             o.Price.String(),
+		Leverage:          o.Leverage,
+		Duration:          a.Duration.String(),
+		Dataset:           a.Dataset,
+		Distribution:      string(a.Distribution),
+		StartAt:           a.StartAt.UTC().Format("2006-01-02T15:04:05Z"),
+		EndAt:             a.EndAt().UTC().Format("2006-01-02T15:04:05Z"),
+		FilledQuantity:    filled.String(),
+		RemainingQuantity: decimal.Max(o.Quantity.Sub(filled), decimal.Zero).String(),
+		AveragePrice:      a.AveragePrice().Round(8).String(),
+		ArrivalPrice:      a.ArrivalPrice.String(),
+		SlippageBps:       a.SlippageBps(o.Side).String(),
+		Slices:            make([]AlgoSliceResponse, len(a.Slices)),
+		CreatedAt:         o.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if a.VisibleQuantity.IsPositive() {
+		response.VisibleQuantity = a.VisibleQuantity.String()
+	}
+	if o.Type == domain.OrderTypeScale {
+		from, to := a.LimitRange()
+		response.PriceFrom, response.PriceTo = from.String(), to.String()
+	}
+	for i, s := range a.Slices {
+		response.Slices[i] = AlgoSliceResponse{
+			Index:          s.Index,
+			DueAt:          s.DueAt.UTC().Format("2006-01-02T15:04:05Z"),
+			Quantity:       s.Quantity.String(),
+			LimitPrice:     s.LimitPrice.String(),
+			Status:         string(s.Status),
+			FilledQuantity: s.FilledQuantity.String(),
+			Price:          s.Price.String(),
+			Error:          s.Error,
+		}
+		if s.ChildID != nil {
+			childID := int64(*s.ChildID)
+			response.Slices[i].OrderID = &childID
+		}
+	}
+	return response
+}
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/backtest"
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	backtestuc "trading/internal/usecase/backtest"
+)
+
+type BacktestHandler struct {
+	backtestUC *backtestuc.UseCase
+}
+
+func NewBacktestHandler(backtestUC *backtestuc.UseCase) *BacktestHandler {
+	return &BacktestHandler{backtestUC: backtestUC}
+}
+
+type SubmitBacktestRequest struct {
+	Strategy        string             `json:"strategy"`
+	Params          map[string]float64 `json:"params,omitempty"`
+	Symbol          string             `json:"symbol"`
+	Dataset         string             `json:"dataset,omitempty"` // stored CSV file
+	CSV             string             `json:"csv,omitempty"`     // inline candles
+	StartingBalance string             `json:"starting_balance,omitempty"`
+	Spread          float64            `json:"spread,omitempty"`
+}
+
+type BacktestResponse struct {
+	ID         int64                   `json:"id"`
+	Status     string                  `json:"status"`
+	Strategy   string                  `json:"strategy"`
+	Params     map[string]float64      `json:"params"`
+	Symbol     string                  `json:"symbol"`
+	Dataset    string                  `json:"dataset,omitempty"`
+	Error      string                  `json:"error,omitempty"`
+	CreatedAt  string                  `json:"created_at"`
+	FinishedAt *string                 `json:"finished_at"`
+	Report     *BacktestReportResponse `json:"report,omitempty"`
+}
+
+type BacktestReportResponse struct {
+	From            string                        `json:"from"`
+	To              string                        `json:"to"`
+	Candles         int                           `json:"candles"`
+	StartingBalance string                        `json:"starting_balance"`
+	FinalEquity     string                        `json:"final_equity"`
+	Stats           BacktestStatsResponse         `json:"stats"`
+	Trades          []TradeResponse               `json:"trades"`
+	Equity          []BacktestEquityPointResponse `json:"equity"`
+}
+
+type BacktestStatsResponse struct {
+	TotalTrades    int    `json:"total_trades"`
+	ClosedTrades   int    `json:"closed_trades"`
+	WinningTrades  int    `json:"winning_trades"`
+	LosingTrades   int    `json:"losing_trades"`
+	Liquidations   int    `json:"liquidations"`
+	RealizedPnL    string `json:"realized_pnl"`
+	Volume         string `json:"volume"`
+	Fees           string `json:"fees"`
+	WinRate        string `json:"win_rate"`
+	GrossProfit    string `json:"gross_profit"`
+	GrossLoss      string `json:"gross_loss"`
+	ProfitFactor   string `json:"profit_factor"`
+	AverageWin     string `json:"average_win"`
+	AverageLoss    string `json:"average_loss"`
+	ReturnPct      string `json:"return_pct"`
+	MaxDrawdownPct string `json:"max_drawdown_pct"`
+	RejectedOrders int    `json:"rejected_orders"`
+}
+
+type BacktestEquityPointResponse struct {
+	Time     string `json:"time"`
+	Equity   string `json:"equity"`
+	Drawdown string `json:"drawdown"`
+}
+
+type BacktestOptionsResponse struct {
+	Strategies []string `json:"strategies"`
+	Datasets   []string `json:"datasets"`
+}
+
+// SubmitBacktest starts a backtest job; poll GET /backtests/{id} for the report
+// POST /backtests
+func (h *BacktestHandler) SubmitBacktest(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	var req SubmitBacktestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := backtestuc.SubmitInput{
+		UserID:   userID,
+		Strategy: req.Strategy,
+		Params:   req.Params,
+		Symbol:   req.Symbol,
+		Dataset:  req.Dataset,
+		CSV:      req.CSV,
+		Spread:   req.Spread,
+	}
+	if req.StartingBalance != "" {
+		balance, err := decimal.NewFromString(req.StartingBalance)
+		if err != nil {
+			writeError(w, "invalid starting_balance", http.StatusBadRequest)
+			return
+		}
+		input.StartingBalance = balance
+	}
+
+	job, err := h.backtestUC.Submit(r.Context(), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnknownStrategy),
+			errors.Is(err, domain.ErrInvalidStrategy),
+			errors.Is(err, domain.ErrInvalidBacktest),
+			errors.Is(err, domain.ErrInvalidCandles):
+			writeError(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, domain.ErrDatasetNotFound):
+			writeError(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, domain.ErrTooManyBacktests):
+			writeError(w, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			writeError(w, "failed to submit backtest", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJSON(w, backtestToResponse(job, false), http.StatusAccepted)
+}
+
+// GetBacktests returns the user's backtests without reports, newest first
+// GET /backtests
+func (h *BacktestHandler) GetBacktests(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	jobs := h.backtestUC.List(r.Context(), userID)
+	response := make([]BacktestResponse, len(jobs))
+	for i := range jobs {
+		response[i] = backtestToResponse(&jobs[i], false)
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetBacktest returns a backtest with its report once it is done
+// GET /backtests/{id}
+func (h *BacktestHandler) GetBacktest(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid backtest id", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.backtestUC.Get(r.Context(), userID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrBacktestNotFound) {
+			writeError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeError(w, "failed to get backtest", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, backtestToResponse(job, true), http.StatusOK)
+}
+
+// GetBacktestOptions lists the available strategies and stored datasets
+// GET /backtests/options
+func (h *BacktestHandler) GetBacktestOptions(w http.ResponseWriter, r *http.Request) {
+	datasets, err := h.backtestUC.Datasets()
+	if err != nil {
+		writeError(w, "failed to list datasets", http.StatusInternalServerError)
+		return
+	}
+	if datasets == nil {
+		datasets = []string{}
+	}
+
+	writeJSON(w, BacktestOptionsResponse{Strategies: h.backtestUC.Strategies(), Datasets: datasets}, http.StatusOK)
+}
+
+func backtestToResponse(job *backtestuc.Job, withReport bool) BacktestResponse {
+	response := BacktestResponse{
+		ID:        job.ID,
+		Status:    string(job.Status),
+		Strategy:  job.Strategy,
+		Params:    job.Params,
+		Symbol:    job.Symbol,
+		Dataset:   job.Dataset,
+		Error:     job.Error,
+		CreatedAt: job.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if response.Params == nil {
+		response.Params = map[string]float64{}
+	}
+	if job.FinishedAt != nil {
+		finishedAt := job.FinishedAt.UTC().Format("2006-01-02T15:04:05Z")
+		response.FinishedAt = &finishedAt
+	}
+	if withReport && job.Report != nil {
+		report := backtestReportToResponse(job.Report)
+		response.Report = &report
+	}
+	return response
+}
+
+func backtestReportToResponse(r *backtest.Report) BacktestReportResponse {
+	s := r.Stats
+	response := BacktestReportResponse{
+		From:            r.From.UTC().Format("2006-01-02T15:04:05Z"),
+		To:              r.To.UTC().Format("2006-01-02T15:04:05Z"),
+		Candles:         r.Candles,
+		StartingBalance: r.StartingBalance.StringFixed(2),
+		FinalEquity:     r.FinalEquity.StringFixed(2),
+		Stats: BacktestStatsResponse{
+			TotalTrades:    s.TotalTrades,
+			ClosedTrades:   s.ClosedTrades,
+			WinningTrades:  s.WinningTrades,
+			LosingTrades:   s.LosingTrades,
+			Liquidations:   s.Liquidations,
+			RealizedPnL:    s.RealizedPnL.StringFixed(2),
+			Volume:         s.Volume.StringFixed(2),
+			Fees:           s.Fees.StringFixed(2),
+			WinRate:        s.WinRate.StringFixed(2),
+			GrossProfit:    s.GrossProfit.StringFixed(2),
+			GrossLoss:      s.GrossLoss.StringFixed(2),
+			ProfitFactor:   s.ProfitFactor.StringFixed(2),
+			AverageWin:     s.AverageWin.StringFixed(2),
+			AverageLoss:    s.AverageLoss.StringFixed(2),
+			ReturnPct:      s.ReturnPct.StringFixed(2),
+			MaxDrawdownPct: s.MaxDrawdownPct.StringFixed(2),
+			RejectedOrders: s.RejectedOrders,
+		},
+		Trades: make([]TradeResponse, len(r.Trades)),
+		Equity: make([]BacktestEquityPointResponse, len(r.Equity)),
+	}
+	for i := range r.Trades {
+		response.Trades[i] = tradeToResponse(&r.Trades[i])
+	}
+	for i, p := range r.Equity {
+		response.Equity[i] = BacktestEquityPointResponse{
+			Time:     p.Time.UTC().Format("2006-01-02T15:04:05Z"),
+			Equity:   p.Equity.StringFixed(2),
+			Drawdown: p.Drawdown.StringFixed(2),
+		}
+	}
+	return response
+}
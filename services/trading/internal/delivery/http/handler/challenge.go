@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	challengeuc "trading/internal/usecase/challenge"
+)
+
+type ChallengeHandler struct {
+	challengeUC *challengeuc.UseCase
+}
+
+func NewChallengeHandler(challengeUC *challengeuc.UseCase) *ChallengeHandler {
+	return &ChallengeHandler{challengeUC: challengeUC}
+}
+
+type CreateChallengeRequest struct {
+	StartingBalance string `json:"starting_balance,omitempty"`
+	ProfitTarget    string `json:"profit_target,omitempty"`
+	MaxDailyLoss    string `json:"max_daily_loss,omitempty"`
+	MaxDrawdown     string `json:"max_drawdown,omitempty"`
+	MinTradingDays  *int   `json:"min_trading_days,omitempty"`
+	MaxPositionSize string `json:"max_position_size,omitempty"`
+}
+
+type ChallengeResponse struct {
+	ID              int64                   `json:"id"`
+	AccountID       int64                   `json:"account_id"`
+	Status          string                  `json:"status"`
+	StartingBalance string                  `json:"starting_balance"`
+	Rules           ChallengeRulesResponse  `json:"rules"`
+	TradingDays     int                     `json:"trading_days"`
+	FailureReason   string                  `json:"failure_reason,omitempty"`
+	Equity          *string                 `json:"equity,omitempty"`
+	Progress        []ChallengeRuleResponse `json:"progress,omitempty"`
+	CreatedAt       string                  `json:"created_at"`
+	EndedAt         *string                 `json:"ended_at"`
+}
+
+type ChallengeRulesResponse struct {
+	ProfitTarget    string `json:"profit_target"`
+	MaxDailyLoss    string `json:"max_daily_loss"`
+	MaxDrawdown     string `json:"max_drawdown"`
+	MinTradingDays  int    `json:"min_trading_days"`
+	MaxPositionSize string `json:"max_position_size"`
+}
+
+type ChallengeRuleResponse struct {
+	Rule   string `json:"rule"`
+	Value  string `json:"value"`
+	Limit  string `json:"limit"`
+	Status string `json:"status"`
+}
+
+// CreateChallenge starts a challenge on a new dedicated account
+// POST /challenges
+func (h *ChallengeHandler) CreateChallenge(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	var req CreateChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := challengeuc.CreateInput{
+		UserID:         userID,
+		MinTradingDays: req.MinTradingDays,
+	}
+	fields := []struct {
+		name  string
+		value string
+		dest  *decimal.Decimal
+	}{
+		{"starting_balance", req.StartingBalance, &input.StartingBalance},
+		{"profit_target", req.ProfitTarget, &input.ProfitTarget},
+		{"max_daily_loss", req.MaxDailyLoss, &input.MaxDailyLoss},
+		{"max_drawdown", req.MaxDrawdown, &input.MaxDrawdown},
+		{"max_position_size", req.MaxPositionSize, &input.MaxPositionSize},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		value, err := decimal.NewFromString(f.value)
+		if err != nil {
+			writeError(w, "invalid "+f.name, http.StatusBadRequest)
+			return
+		}
+		*f.dest = value
+	}
+
+	challenge, err := h.challengeUC.Create(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidChallenge) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, domain.ErrTooManyChallenges) {
+			writeError(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		writeError(w, "failed to create challenge", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, challengeToResponse(challenge), http.StatusCreated)
+}
+
+// GetChallenges returns the user's challenges, newest first
+// GET /challenges
+func (h *ChallengeHandler) GetChallenges(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	challenges, err := h.challengeUC.List(r.Context(), userID)
+	if err != nil {
+		writeError(w, "failed to get challenges", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]ChallengeResponse, len(challenges))
+	for i := range challenges {
+		response[i] = challengeToResponse(&challenges[i])
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetChallenge returns a challenge with live progress against each rule
+// GET /challenges/{id}
+func (h *ChallengeHandler) GetChallenge(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid challenge id", http.StatusBadRequest)
+		return
+	}
+
+	progress, challenge, err := h.challengeUC.GetProgress(r.Context(), userID, domain.ChallengeID(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrChallengeNotFound) {
+			writeError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeError(w, "failed to get challenge", http.StatusInternalServerError)
+		return
+	}
+
+	response := challengeToResponse(challenge)
+	equity := progress.Equity.StringFixed(2)
+	response.Equity = &equity
+	response.Progress = make([]ChallengeRuleResponse, len(progress.Rules))
+	for i, rule := range progress.Rules {
+		response.Progress[i] = ChallengeRuleResponse{
+			Rule:   string(rule.Rule),
+			Value:  rule.Value.StringFixed(2),
+			Limit:  rule.Limit.StringFixed(2),
+			Status: rule.Status,
+		}
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+func challengeToResponse(c *domain.Challenge) ChallengeResponse {
+	response := ChallengeResponse{
+		ID:              int64(c.ID),
+		AccountID:       int64(c.AccountID),
+		Status:          string(c.Status),
+		StartingBalance: c.StartingBalance.StringFixed(2),
+		Rules: ChallengeRulesResponse{
+			ProfitTarget:    c.Rules.ProfitTarget.String(),
+			MaxDailyLoss:    c.Rules.MaxDailyLoss.String(),
+			MaxDrawdown:     c.Rules.MaxDrawdown.String(),
+			MinTradingDays:  c.Rules.MinTradingDays,
+			MaxPositionSize: c.Rules.MaxPositionSize.StringFixed(2),
+		},
+		TradingDays:   c.TradingDays,
+		FailureReason: c.FailureReason,
+		CreatedAt:     c.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if c.EndedAt != nil {
+		at := c.EndedAt.UTC().Format("2006-01-02T15:04:05Z")
+		response.EndedAt = &at
+	}
+	return response
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	competitionuc "trading/internal/usecase/competition"
+)
+
+type CompetitionHandler struct {
+	competitionUC *competitionuc.UseCase
+}
+
+func NewCompetitionHandler(competitionUC *competitionuc.UseCase) *CompetitionHandler {
+	return &CompetitionHandler{competitionUC: competitionUC}
+}
+
+type CreateCompetitionRequest struct {
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	StartsAt        time.Time `json:"starts_at"`
+	EndsAt          time.Time `json:"ends_at"`
+	AllowedSymbols  []string  `json:"allowed_symbols"`
+	MaxLeverage     int       `json:"max_leverage"`
+	StartingBalance string    `json:"starting_balance"`
+	RankingMetric   string    `json:"ranking_metric"`
+}
+
+type CompetitionResponse struct {
+	ID              int64    `json:"id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Status          string   `json:"status"`
+	StartsAt        string   `json:"starts_at"`
+	EndsAt          string   `json:"ends_at"`
+	AllowedSymbols  []string `json:"allowed_symbols"`
+	MaxLeverage     int      `json:"max_leverage"`
+	StartingBalance string   `json:"starting_balance"`
+	RankingMetric   string   `json:"ranking_metric"`
+	FinalizedAt     *string  `json:"finalized_at"`
+	CreatedAt       string   `json:"created_at"`
+}
+
+type CompetitionStandingsResponse struct {
+	CompetitionID int64                         `json:"competition_id"`
+	Status        string                        `json:"status"`
+	RankingMetric string                        `json:"ranking_metric"`
+	Final         bool                          `json:"final"`
+	Standings     []CompetitionStandingResponse `json:"standings"`
+}
+
+type CompetitionStandingResponse struct {
+	Rank         int     `json:"rank"`
+	DisplayName  string  `json:"display_name"`
+	Equity       string  `json:"equity"`
+	PnL          string  `json:"pnl"`
+	ROI          string  `json:"roi"`
+	RiskAdjusted *string `json:"risk_adjusted"`
+	Trades       int     `json:"trades"`
+}
+
+// CreateCompetition schedules a new competition (admin only)
+// POST /admin/competitions
+func (h *CompetitionHandler) CreateCompetition(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	var req CreateCompetitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	balance, err := decimal.NewFromString(req.StartingBalance)
+	if err != nil {
+		writeError(w, "invalid starting_balance", http.StatusBadRequest)
+		return
+	}
+
+	competition, err := h.competitionUC.Create(r.Context(), competitionuc.CreateInput{
+		CreatedBy:       userID,
+		Name:            req.Name,
+		Description:     req.Description,
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+		AllowedSymbols:  req.AllowedSymbols,
+		MaxLeverage:     req.MaxLeverage,
+		StartingBalance: balance,
+		RankingMetric:   domain.LeaderboardMetric(req.RankingMetric),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCompetition) ||
+			errors.Is(err, domain.ErrSymbolNotSupported) ||
+			errors.Is(err, domain.ErrInvalidLeaderboardMetric) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeError(w, "failed to create competition", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, competitionToResponse(competition, time.Now()), http.StatusCreated)
+}
+
+// GetCompetitions returns all competitions, latest start first
+// GET /competitions
+func (h *CompetitionHandler) GetCompetitions(w http.ResponseWriter, r *http.Request) {
+	competitions, err := h.competitionUC.List(r.Context())
+	if err != nil {
+		writeError(w, "failed to get competitions", http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	response := make([]CompetitionResponse, len(competitions))
+	for i := range competitions {
+		response[i] = competitionToResponse(&competitions[i], now)
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetCompetition returns a competition by ID
+// GET /competitions/{id}
+func (h *CompetitionHandler) GetCompetition(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseCompetitionID(w, r)
+	if !ok {
+		return
+	}
+
+	competition, err := h.competitionUC.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrCompetitionNotFound) {
+			writeError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeError(w, "failed to get competition", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, competitionToResponse(competition, time.Now()), http.StatusOK)
+}
+
+// JoinCompetition opens the user's dedicated competition account
+// POST /competitions/{id}/join
+func (h *CompetitionHandler) JoinCompetition(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	id, ok := parseCompetitionID(w, r)
+	if !ok {
+		return
+	}
+
+	info, err := h.competitionUC.Join(r.Context(), userID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrCompetitionNotFound) {
+			writeError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrAlreadyJoined) {
+			writeError(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, domain.ErrCompetitionEnded) {
+			writeError(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		writeError(w, "failed to join competition", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, info, http.StatusCreated)
+}
+
+// GetStandings returns the competition ranking, live while running and frozen once finalized
+// GET /competitions/{id}/standings
+func (h *CompetitionHandler) GetStandings(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseCompetitionID(w, r)
+	if !ok {
+		return
+	}
+
+	competition, standings, err := h.competitionUC.GetStandings(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrCompetitionNotFound) {
+			writeError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrPriceNotAvailable) {
+			writeError(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		writeError(w, "failed to get standings", http.StatusInternalServerError)
+		return
+	}
+
+	response := CompetitionStandingsResponse{
+		CompetitionID: int64(competition.ID),
+		Status:        string(competition.Status(time.Now())),
+		RankingMetric: string(competition.RankingMetric),
+		Final:         competition.FinalizedAt != nil,
+		Standings:     make([]CompetitionStandingResponse, len(standings)),
+	}
+	for i, s := range standings {
+		entry := CompetitionStandingResponse{
+			Rank:        s.Rank,
+			DisplayName: s.DisplayName,
+			Equity:      s.Equity.StringFixed(2),
+			PnL:         s.PnL.StringFixed(2),
+			ROI:         s.ROI.StringFixed(4),
+			Trades:      s.Trades,
+		}
+		if s.RiskAdjusted != nil {
+			v := s.RiskAdjusted.StringFixed(4)
+			entry.RiskAdjusted = &v
+		}
+		response.Standings[i] = entry
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+func parseCompetitionID(w http.ResponseWriter, r *http.Request) (domain.CompetitionID, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid competition id", http.StatusBadRequest)
+		return 0, false
+	}
+	return domain.CompetitionID(id), true
+}
+
+func competitionToResponse(c *domain.Competition, now time.Time) CompetitionResponse {
+	response := CompetitionResponse{
+		ID:              int64(c.ID),
+		Name:            c.Name,
+		Description:     c.Description,
+		Status:          string(c.Status(now)),
+		StartsAt:        c.StartsAt.UTC().Format("2006-01-02T15:04:05Z"),
+		EndsAt:          c.EndsAt.UTC().Format("2006-01-02T15:04:05Z"),
+		AllowedSymbols:  c.AllowedSymbols,
+		MaxLeverage:     c.MaxLeverage,
+		StartingBalance: c.StartingBalance.StringFixed(2),
+		RankingMetric:   string(c.RankingMetric),
+		CreatedAt:       c.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if c.FinalizedAt != nil {
+		at := c.FinalizedAt.UTC().Format("2006-01-02T15:04:05Z")
+		response.FinalizedAt = &at
+	}
+	return response
+}
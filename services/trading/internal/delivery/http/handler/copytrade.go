@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	copyuc "trading/internal/usecase/copytrade"
+	statsuc "trading/internal/usecase/stats"
+)
+
+type CopyTradingHandler struct {
+	copyUC *copyuc.UseCase
+}
+
+func NewCopyTradingHandler(copyUC *copyuc.UseCase) *CopyTradingHandler {
+	return &CopyTradingHandler{copyUC: copyUC}
+}
+
+type FollowRequest struct {
+	Leader              string  `json:"leader"` // display name
+	Mode                string  `json:"mode,omitempty"`
+	Ratio               string  `json:"ratio,omitempty"`
+	Amount              string  `json:"amount,omitempty"`
+	MaxLeverage         int     `json:"max_leverage,omitempty"`
+	MaxPositionNotional *string `json:"max_position_notional,omitempty"`
+	MaxLoss             *string `json:"max_loss,omitempty"`
+}
+
+type FollowResponse struct {
+	ID                  int64   `json:"id"`
+	Leader              string  `json:"leader"`
+	Mode                string  `json:"mode"`
+	Ratio               string  `json:"ratio"`
+	Amount              string  `json:"amount"`
+	MaxLeverage         int     `json:"max_leverage"`
+	MaxPositionNotional *string `json:"max_position_notional"`
+	MaxLoss             *string `json:"max_loss"`
+	Status              string  `json:"status"`
+	StopReason          string  `json:"stop_reason,omitempty"`
+	CopiedTrades        int     `json:"copied_trades"`
+	RealizedPnL         string  `json:"realized_pnl"`
+	CreatedAt           string  `json:"created_at"`
+}
+
+type CopyTradeResponse struct {
+	ID              int64  `json:"id"`
+	LeaderTradeID   int64  `json:"leader_trade_id"`
+	LeaderTradeType string `json:"leader_trade_type"`
+	Symbol          string `json:"symbol"`
+	Side            string `json:"side"`
+	Status          string `json:"status"`
+	OrderID         *int64 `json:"order_id"`
+	Quantity        string `json:"quantity"`
+	Price           string `json:"price"`
+	PnL             string `json:"pnl"`
+	Error           string `json:"error,omitempty"`
+	CreatedAt       string `json:"created_at"`
+}
+
+type LeaderResponse struct {
+	DisplayName string        `json:"display_name"`
+	Followers   int           `json:"followers"`
+	Stats       StatsResponse `json:"stats"`
+}
+
+// GetLeader returns a leader's follower count and performance statistics
+// GET /copy/leaders/{name}?from=&to=
+func (h *CopyTradingHandler) GetLeader(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var input statsuc.Input
+	var err error
+	if input.From, err = parseTimeParam(q.Get("from")); err != nil {
+		writeError(w, "invalid from", http.StatusBadRequest)
+		return
+	}
+	if input.To, err = parseTimeParam(q.Get("to")); err != nil {
+		writeError(w, "invalid to", http.StatusBadRequest)
+		return
+	}
+
+	leader, err := h.copyUC.GetLeader(r.Context(), chi.URLParam(r, "name"), input)
+	if err != nil {
+		writeCopyError(w, err, "failed to get leader")
+		return
+	}
+
+	writeJSON(w, LeaderResponse{
+		DisplayName: leader.DisplayName,
+		Followers:   leader.Followers,
+		Stats:       statsToResponse(leader.Stats),
+	}, http.StatusOK)
+}
+
+// Follow starts copying a leader's trades onto the account
+// POST /copy/follows
+func (h *CopyTradingHandler) Follow(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
+
+	var req FollowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := copyuc.FollowInput{
+		UserID:      userID,
+		AccountID:   accountID,
+		Leader:      req.Leader,
+		Mode:        domain.CopyMode(req.Mode),
+		MaxLeverage: req.MaxLeverage,
+	}
+
+	var err error
+	if req.Ratio != "" {
+		if input.Ratio, err = decimal.NewFromString(req.Ratio); err != nil {
+			writeError(w, "invalid ratio", http.StatusBadRequest)
+			return
+		}
+	}
+	if req.Amount != "" {
+		if input.Amount, err = decimal.NewFromString(req.Amount); err != nil {
+			writeError(w, "invalid amount", http.StatusBadRequest)
+			return
+		}
+	}
+	if req.MaxPositionNotional != nil {
+		limit, err := decimal.NewFromString(*req.MaxPositionNotional)
+		if err != nil {
+			writeError(w, "invalid max_position_notional", http.StatusBadRequest)
+			return
+		}
+		input.MaxPositionNotional = &limit
+	}
+	if req.MaxLoss != nil {
+		limit, err := decimal.NewFromString(*req.MaxLoss)
+		if err != nil {
+			writeError(w, "invalid max_loss", http.StatusBadRequest)
+			return
+		}
+		input.MaxLoss = &limit
+	}
+
+	follow, err := h.copyUC.Follow(r.Context(), input)
+	if err != nil {
+		writeCopyError(w, err, "failed to follow")
+		return
+	}
+
+	writeJSON(w, followToResponse(follow), http.StatusCreated)
+}
+
+// GetFollows returns the account's follows, newest first
+// GET /copy/follows
+func (h *CopyTradingHandler) GetFollows(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	follows, err := h.copyUC.List(r.Context(), accountID)
+	if err != nil {
+		writeError(w, "failed to get follows", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]FollowResponse, len(follows))
+	for i := range follows {
+		response[i] = followToResponse(&follows[i])
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetFollow returns a follow with its copy results
+// GET /copy/follows/{id}
+func (h *CopyTradingHandler) GetFollow(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseFollowID(w, r)
+	if !ok {
+		return
+	}
+
+	follow, err := h.copyUC.Get(r.Context(), accountID, id)
+	if err != nil {
+		writeCopyError(w, err, "failed to get follow")
+		return
+	}
+
+	writeJSON(w, followToResponse(follow), http.StatusOK)
+}
+
+// GetCopyTrades returns the follow's audit trail, newest first
+// GET /copy/follows/{id}/trades?limit=
+func (h *CopyTradingHandler) GetCopyTrades(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseFollowID(w, r)
+	if !ok {
+		return
+	}
+
+	trades, err := h.copyUC.CopyTrades(r.Context(), accountID, id, parseLimitParam(r.URL.Query().Get("limit")))
+	if err != nil {
+		writeCopyError(w, err, "failed to get copy trades")
+		return
+	}
+
+	response := make([]CopyTradeResponse, len(trades))
+	for i, t := range trades {
+		response[i] = CopyTradeResponse{
+			ID:              int64(t.ID),
+			LeaderTradeID:   int64(t.LeaderTradeID),
+			LeaderTradeType: string(t.LeaderTradeType),
+			Symbol:          t.Symbol,
+			Side:            string(t.Side),
+			Status:          string(t.Status),
+			Quantity:        t.Quantity.String(),
+			Price:           t.Price.String(),
+			PnL:             t.PnL.StringFixed(2),
+			Error:           t.Error,
+			CreatedAt:       t.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		}
+		if t.OrderID != nil {
+			orderID := int64(*t.OrderID)
+			response[i].OrderID = &orderID
+		}
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// Unfollow stops copying; copied positions stay open
+// POST /copy/follows/{id}/unfollow
+func (h *CopyTradingHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseFollowID(w, r)
+	if !ok {
+		return
+	}
+
+	follow, err := h.copyUC.Unfollow(r.Context(), accountID, id)
+	if err != nil {
+		writeCopyError(w, err, "failed to unfollow")
+		return
+	}
+
+	writeJSON(w, followToResponse(follow), http.StatusOK)
+}
+
+func parseFollowID(w http.ResponseWriter, r *http.Request) (domain.FollowID, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid follow id", http.StatusBadRequest)
+		return 0, false
+	}
+	return domain.FollowID(id), true
+}
+
+func writeCopyError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidFollow),
+		errors.Is(err, domain.ErrCannotFollowSelf),
+		errors.Is(err, domain.ErrInvalidTimeRange):
+		writeError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrLeaderNotFound),
+		errors.Is(err, domain.ErrFollowNotFound):
+		writeError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrAlreadyFollowing),
+		errors.Is(err, domain.ErrFollowEnded):
+		writeError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrTooManyFollows):
+		writeError(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		writeError(w, fallback, http.StatusInternalServerError)
+	}
+}
+
+func followToResponse(f *domain.Follow) FollowResponse {
+	response := FollowResponse{
+		ID:           int64(f.ID),
+		Leader:       f.LeaderName,
+		Mode:         string(f.Mode),
+		Ratio:        f.Ratio.String(),
+		Amount:       f.Amount.String(),
+		MaxLeverage:  f.MaxLeverage,
+		Status:       string(f.Status),
+		StopReason:   f.StopReason,
+		CopiedTrades: f.CopiedTrades,
+		RealizedPnL:  f.RealizedPnL.StringFixed(2),
+		CreatedAt:    f.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if f.MaxPositionNotional != nil {
+		limit := f.MaxPositionNotional.String()
+		response.MaxPositionNotional = &limit
+	}
+	if f.MaxLoss != nil {
+		limit := f.MaxLoss.String()
+		response.MaxLoss = &limit
+	}
+	return response
+}
@@ -0,0 +1,313 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	dcauc "trading/internal/usecase/dca"
+)
+
+type DCAHandler struct {
+	dcaUC *dcauc.UseCase
+}
+
+func NewDCAHandler(dcaUC *dcauc.UseCase) *DCAHandler {
+	return &DCAHandler{dcaUC: dcaUC}
+}
+
+type CreateDCAPlanRequest struct {
+	Symbol          string  `json:"symbol"`
+	Amount          string  `json:"amount"` // USDT per run
+	Leverage        int     `json:"leverage,omitempty"`
+	Interval        string  `json:"interval"`           // e.g. "24h", "4h"
+	StartAt         *string `json:"start_at,omitempty"` // RFC3339, first run
+	MissedRuns      string  `json:"missed_runs,omitempty"`
+	TakeProfitPct   *string `json:"take_profit_pct,omitempty"`
+	SafetyDropPct   *string `json:"safety_drop_pct,omitempty"`
+	SafetyAmount    *string `json:"safety_amount,omitempty"`
+	MaxSafetyOrders int     `json:"max_safety_orders,omitempty"`
+}
+
+type DCAPlanResponse struct {
+	ID              int64   `json:"id"`
+	Symbol          string  `json:"symbol"`
+	Amount          string  `json:"amount"`
+	Leverage        int     `json:"leverage"`
+	Interval        string  `json:"interval"`
+	NextRunAt       string  `json:"next_run_at"`
+	MissedRuns      string  `json:"missed_runs"`
+	TakeProfitPct   *string `json:"take_profit_pct"`
+	SafetyDropPct   *string `json:"safety_drop_pct"`
+	SafetyAmount    *string `json:"safety_amount"`
+	MaxSafetyOrders int     `json:"max_safety_orders"`
+	Status          string  `json:"status"`
+	Quantity        string  `json:"quantity"`
+	Cost            string  `json:"cost"`
+	AverageEntry    string  `json:"average_entry"`
+	SafetyOrders    int     `json:"safety_orders"`
+	Runs            int     `json:"runs"`
+	Cycles          int     `json:"cycles"`
+	RealizedPnL     string  `json:"realized_pnl"`
+	CreatedAt       string  `json:"created_at"`
+}
+
+type DCAExecutionResponse struct {
+	ID        int64  `json:"id"`
+	Kind      string `json:"kind"`
+	Status    string `json:"status"`
+	RunAt     string `json:"run_at"`
+	OrderID   *int64 `json:"order_id"`
+	Quantity  string `json:"quantity"`
+	Price     string `json:"price"`
+	Error     string `json:"error,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// CreateDCAPlan schedules recurring buys on the account
+// POST /bots/dca
+func (h *DCAHandler) CreateDCAPlan(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
+
+	var req CreateDCAPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := dcauc.CreateInput{
+		UserID:          userID,
+		AccountID:       accountID,
+		Symbol:          req.Symbol,
+		Leverage:        req.Leverage,
+		MissedRuns:      domain.DCAMissedRuns(req.MissedRuns),
+		MaxSafetyOrders: req.MaxSafetyOrders,
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		writeError(w, "invalid amount", http.StatusBadRequest)
+		return
+	}
+	input.Amount = amount
+
+	if input.Interval, err = time.ParseDuration(req.Interval); err != nil {
+		writeError(w, "invalid interval", http.StatusBadRequest)
+		return
+	}
+	if req.StartAt != nil {
+		if input.StartAt, err = time.Parse(time.RFC3339, *req.StartAt); err != nil {
+			writeError(w, "invalid start_at", http.StatusBadRequest)
+			return
+		}
+	}
+	if req.TakeProfitPct != nil {
+		pct, err := decimal.NewFromString(*req.TakeProfitPct)
+		if err != nil {
+			writeError(w, "invalid take_profit_pct", http.StatusBadRequest)
+			return
+		}
+		input.TakeProfitPct = &pct
+	}
+	if req.SafetyDropPct != nil {
+		pct, err := decimal.NewFromString(*req.SafetyDropPct)
+		if err != nil {
+			writeError(w, "invalid safety_drop_pct", http.StatusBadRequest)
+			return
+		}
+		input.SafetyDropPct = &pct
+	}
+	if req.SafetyAmount != nil {
+		if input.SafetyAmount, err = decimal.NewFromString(*req.SafetyAmount); err != nil {
+			writeError(w, "invalid safety_amount", http.StatusBadRequest)
+			return
+		}
+	}
+
+	plan, err := h.dcaUC.Create(r.Context(), input)
+	if err != nil {
+		writeDCAError(w, err, "failed to create dca plan")
+		return
+	}
+
+	writeJSON(w, dcaPlanToResponse(plan), http.StatusCreated)
+}
+
+// GetDCAPlans returns the account's plans, newest first
+// GET /bots/dca
+func (h *DCAHandler) GetDCAPlans(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	plans, err := h.dcaUC.List(r.Context(), accountID)
+	if err != nil {
+		writeError(w, "failed to get dca plans", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]DCAPlanResponse, len(plans))
+	for i := range plans {
+		response[i] = dcaPlanToResponse(&plans[i])
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetDCAPlan returns a plan with its current cycle
+// GET /bots/dca/{id}
+func (h *DCAHandler) GetDCAPlan(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseDCAPlanID(w, r)
+	if !ok {
+		return
+	}
+
+	plan, err := h.dcaUC.Get(r.Context(), accountID, id)
+	if err != nil {
+		writeDCAError(w, err, "failed to get dca plan")
+		return
+	}
+
+	writeJSON(w, dcaPlanToResponse(plan), http.StatusOK)
+}
+
+// GetDCAExecutions returns the plan's execution history, newest first
+// GET /bots/dca/{id}/executions?limit=
+func (h *DCAHandler) GetDCAExecutions(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseDCAPlanID(w, r)
+	if !ok {
+		return
+	}
+
+	executions, err := h.dcaUC.Executions(r.Context(), accountID, id, parseLimitParam(r.URL.Query().Get("limit")))
+	if err != nil {
+		writeDCAError(w, err, "failed to get dca executions")
+		return
+	}
+
+	response := make([]DCAExecutionResponse, len(executions))
+	for i, e := range executions {
+		response[i] = DCAExecutionResponse{
+			ID:        int64(e.ID),
+			Kind:      string(e.Kind),
+			Status:    string(e.Status),
+			RunAt:     e.RunAt.UTC().Format("2006-01-02T15:04:05Z"),
+			Quantity:  e.Quantity.String(),
+			Price:     e.Price.String(),
+			Error:     e.Error,
+			CreatedAt: e.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		}
+		if e.OrderID != nil {
+			orderID := int64(*e.OrderID)
+			response[i].OrderID = &orderID
+		}
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// PauseDCAPlan stops the plan's runs and triggers until it is resumed
+// POST /bots/dca/{id}/pause
+func (h *DCAHandler) PauseDCAPlan(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.dcaUC.Pause, "failed to pause dca plan")
+}
+
+// ResumeDCAPlan continues the plan from its next scheduled time
+// POST /bots/dca/{id}/resume
+func (h *DCAHandler) ResumeDCAPlan(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.dcaUC.Resume, "failed to resume dca plan")
+}
+
+// CancelDCAPlan ends the plan for good
+// POST /bots/dca/{id}/cancel
+func (h *DCAHandler) CancelDCAPlan(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.dcaUC.Cancel, "failed to cancel dca plan")
+}
+
+type dcaStatusChange func(ctx context.Context, accountID domain.AccountID, id domain.DCAPlanID) (*domain.DCAPlan, error)
+
+func (h *DCAHandler) changeStatus(w http.ResponseWriter, r *http.Request, change dcaStatusChange, fallback string) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseDCAPlanID(w, r)
+	if !ok {
+		return
+	}
+
+	plan, err := change(r.Context(), accountID, id)
+	if err != nil {
+		writeDCAError(w, err, fallback)
+		return
+	}
+
+	writeJSON(w, dcaPlanToResponse(plan), http.StatusOK)
+}
+
+func parseDCAPlanID(w http.ResponseWriter, r *http.Request) (domain.DCAPlanID, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid dca plan id", http.StatusBadRequest)
+		return 0, false
+	}
+	return domain.DCAPlanID(id), true
+}
+
+func writeDCAError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidDCAPlan),
+		errors.Is(err, domain.ErrSymbolNotSupported):
+		writeError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrDCAPlanNotFound):
+		writeError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrDCAPlanCancelled):
+		writeError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrTooManyDCAPlans):
+		writeError(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		writeError(w, fallback, http.StatusInternalServerError)
+	}
+}
+
+func dcaPlanToResponse(p *domain.DCAPlan) DCAPlanResponse {
+	response := DCAPlanResponse{
+		ID:              int64(p.ID),
+		Symbol:          p.Symbol,
+		Amount:          p.Amount.String(),
+		Leverage:        p.Leverage,
+		Interval:        p.Interval.String(),
+		NextRunAt:       p.NextRunAt.UTC().Format("2006-01-02T15:04:05Z"),
+		MissedRuns:      string(p.MissedRuns),
+		MaxSafetyOrders: p.MaxSafetyOrders,
+		Status:          string(p.Status),
+		Quantity:        p.Quantity.String(),
+		Cost:            p.Cost.StringFixed(2),
+		AverageEntry:    p.AverageEntry().StringFixed(2),
+		SafetyOrders:    p.SafetyOrders,
+		Runs:            p.Runs,
+		Cycles:          p.Cycles,
+		RealizedPnL:     p.RealizedPnL.StringFixed(2),
+		CreatedAt:       p.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if p.TakeProfitPct != nil {
+		pct := p.TakeProfitPct.String()
+		response.TakeProfitPct = &pct
+	}
+	if p.SafetyDropPct != nil {
+		pct := p.SafetyDropPct.String()
+		amount := p.SafetyAmount.String()
+		response.SafetyDropPct = &pct
+		response.SafetyAmount = &amount
+	}
+	return response
+}
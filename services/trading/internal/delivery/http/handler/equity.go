@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	equityuc "trading/internal/usecase/equity"
+)
+
+type EquityHandler struct {
+	equityUC *equityuc.UseCase
+}
+
+func NewEquityHandler(equityUC *equityuc.UseCase) *EquityHandler {
+	return &EquityHandler{equityUC: equityUC}
+}
+
+type EquityCurveResponse struct {
+	Resolution  string                `json:"resolution"`
+	From        string                `json:"from"`
+	To          string                `json:"to"`
+	MaxDrawdown string                `json:"max_drawdown"`
+	Points      []EquityPointResponse `json:"points"`
+}
+
+type EquityPointResponse struct {
+	Time          string `json:"time"`
+	Balance       string `json:"balance"`
+	Equity        string `json:"equity"`
+	EquityLow     string `json:"equity_low"`
+	EquityHigh    string `json:"equity_high"`
+	UnrealizedPnL string `json:"unrealized_pnl"`
+	UsedMargin    string `json:"used_margin"`
+	Drawdown      string `json:"drawdown"`
+}
+
+// GetEquity returns the account's equity curve
+// GET /account/equity?from=&to=&resolution=1m|1h|1d
+func (h *EquityHandler) GetEquity(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+	q := r.URL.Query()
+
+	input := equityuc.CurveInput{
+		Resolution: domain.EquityResolution(q.Get("resolution")),
+	}
+
+	var err error
+	if input.From, err = parseTimeParam(q.Get("from")); err != nil {
+		writeError(w, "invalid from", http.StatusBadRequest)
+		return
+	}
+	if input.To, err = parseTimeParam(q.Get("to")); err != nil {
+		writeError(w, "invalid to", http.StatusBadRequest)
+		return
+	}
+
+	curve, err := h.equityUC.GetCurve(r.Context(), accountID, input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidEquityResolution) || errors.Is(err, domain.ErrInvalidTimeRange) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeError(w, "failed to get equity curve", http.StatusInternalServerError)
+		return
+	}
+
+	response := EquityCurveResponse{
+		Resolution:  string(curve.Resolution),
+		From:        curve.From.Format("2006-01-02T15:04:05Z"),
+		To:          curve.To.Format("2006-01-02T15:04:05Z"),
+		MaxDrawdown: curve.MaxDrawdown.StringFixed(4),
+		Points:      make([]EquityPointResponse, len(curve.Points)),
+	}
+	for i, p := range curve.Points {
+		response.Points[i] = EquityPointResponse{
+			Time:          p.Time.Format("2006-01-02T15:04:05Z"),
+			Balance:       p.Balance.StringFixed(2),
+			Equity:        p.Equity.StringFixed(2),
+			EquityLow:     p.EquityLow.StringFixed(2),
+			EquityHigh:    p.EquityHigh.StringFixed(2),
+			UnrealizedPnL: p.UnrealizedPnL.StringFixed(2),
+			UsedMargin:    p.UsedMargin.StringFixed(2),
+			Drawdown:      p.Drawdown.StringFixed(4),
+		}
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
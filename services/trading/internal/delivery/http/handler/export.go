@@ -0,0 +1,374 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	"trading/internal/logger"
+)
+
+// exportBatchSize is the number of rows read from the database per query
+const exportBatchSize = 500
+
+type ExportHandler struct {
+	tradeRepo    domain.TradeRepository
+	orderRepo    domain.OrderRepository
+	positionRepo domain.PositionRepository
+}
+
+func NewExportHandler(
+	tradeRepo domain.TradeRepository,
+	orderRepo domain.OrderRepository,
+	positionRepo domain.PositionRepository,
+) *ExportHandler {
+	return &ExportHandler{
+		tradeRepo:    tradeRepo,
+		orderRepo:    orderRepo,
+		positionRepo: positionRepo,
+	}
+}
+
+// exportRecord is one exported row, written as a CSV record or a JSON object
+type exportRecord interface {
+	record() []string
+}
+
+// exportBatch reads the next batch of records after the given ID and returns
+// the ID to continue from
+type exportBatch func(ctx context.Context, afterID int64) ([]exportRecord, int64, error)
+
+type TradeExport struct {
+	ID         int64  `json:"id"`
+	PositionID int64  `json:"position_id,omitempty"`
+	OrderID    int64  `json:"order_id"`
+	Symbol     string `json:"symbol"`
+	Side       string `json:"side"`
+	Type       string `json:"type"`
+	Quantity   string `json:"quantity"`
+	Price      string `json:"price"`
+	PnL        string `json:"pnl"`
+	Fee        string `json:"fee"`
+	CreatedAt  string `json:"created_at"`
+}
+
+var tradeExportHeader = []string{"id", "position_id", "order_id", "symbol", "side", "type", "quantity", "price", "pnl", "fee", "created_at"}
+
+func (t TradeExport) record() []string {
+	positionID := ""
+	if t.PositionID != 0 {
+		positionID = strconv.FormatInt(t.PositionID, 10)
+	}
+	return []string{
+		strconv.FormatInt(t.ID, 10), positionID, strconv.FormatInt(t.OrderID, 10),
+		t.Symbol, t.Side, t.Type, t.Quantity, t.Price, t.PnL, t.Fee, t.CreatedAt,
+	}
+}
+
+type OrderExport struct {
+	ID         int64   `json:"id"`
+	Symbol     string  `json:"symbol"`
+	Side       string  `json:"side"`
+	Type       string  `json:"type"`
+	Status     string  `json:"status"`
+	Quantity   string  `json:"quantity"`
+	Price      string  `json:"price"`
+	Leverage   int     `json:"leverage"`
+	StopLoss   *string `json:"stop_loss"`
+	TakeProfit *string `json:"take_profit"`
+	FilledAt   *string `json:"filled_at"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+var orderExportHeader = []string{"id", "symbol", "side", "type", "status", "quantity", "price", "leverage", "stop_loss", "take_profit", "filled_at", "created_at"}
+
+func (o OrderExport) record() []string {
+	return []string{
+		strconv.FormatInt(o.ID, 10), o.Symbol, o.Side, o.Type, o.Status, o.Quantity, o.Price,
+		strconv.Itoa(o.Leverage), optionalField(o.StopLoss), optionalField(o.TakeProfit),
+		optionalField(o.FilledAt), o.CreatedAt,
+	}
+}
+
+type PositionExport struct {
+	ID            int64   `json:"id"`
+	Symbol        string  `json:"symbol"`
+	Side          string  `json:"side"`
+	Status        string  `json:"status"`
+	Quantity      string  `json:"quantity"`
+	EntryPrice    string  `json:"entry_price"`
+	MarkPrice     string  `json:"mark_price"`
+	Leverage      int     `json:"leverage"`
+	InitialMargin string  `json:"initial_margin"`
+	UnrealizedPnL string  `json:"unrealized_pnl"`
+	RealizedPnL   string  `json:"realized_pnl"`
+	CreatedAt     string  `json:"created_at"`
+	ClosedAt      *string `json:"closed_at"`
+}
+
+var positionExportHeader = []string{"id", "symbol", "side", "status", "quantity", "entry_price", "mark_price", "leverage", "initial_margin", "unrealized_pnl", "realized_pnl", "created_at", "closed_at"}
+
+func (p PositionExport) record() []string {
+	return []string{
+		strconv.FormatInt(p.ID, 10), p.Symbol, p.Side, p.Status, p.Quantity, p.EntryPrice, p.MarkPrice,
+		strconv.Itoa(p.Leverage), p.InitialMargin, p.UnrealizedPnL, p.RealizedPnL,
+		p.CreatedAt, optionalField(p.ClosedAt),
+	}
+}
+
+// Export streams the account's trades, orders or positions as CSV or JSON.
+// Rows are read in batches, oldest first, so large histories are not held in memory.
+// GET /export/{kind}?format=csv|json&from=&to=
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+	kind := chi.URLParam(r, "kind")
+	q := r.URL.Query()
+
+	format := q.Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		writeError(w, "invalid format", http.StatusBadRequest)
+		return
+	}
+
+	from, err := parseTimeParam(q.Get("from"))
+	if err != nil {
+		writeError(w, "invalid from", http.StatusBadRequest)
+		return
+	}
+	to, err := parseTimeParam(q.Get("to"))
+	if err != nil {
+		writeError(w, "invalid to", http.StatusBadRequest)
+		return
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		writeError(w, "from must be before to", http.StatusBadRequest)
+		return
+	}
+
+	var header []string
+	var next exportBatch
+	switch kind {
+	case "trades":
+		header, next = tradeExportHeader, h.trades(accountID, from, to)
+	case "orders":
+		header, next = orderExportHeader, h.orders(accountID, from, to)
+	case "positions":
+		header, next = positionExportHeader, h.positions(accountID, from, to)
+	default:
+		writeError(w, "unknown export", http.StatusNotFound)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%d.%s", kind, accountID, format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	// The status is sent before the first row; a later failure truncates the file
+	if format == "csv" {
+		err = streamCSV(r.Context(), w, header, next)
+	} else {
+		err = streamJSON(r.Context(), w, next)
+	}
+	if err != nil {
+		logger.Error("export failed", "kind", kind, "account_id", accountID, "error", err)
+	}
+}
+
+func (h *ExportHandler) trades(accountID domain.AccountID, from, to *time.Time) exportBatch {
+	return func(ctx context.Context, afterID int64) ([]exportRecord, int64, error) {
+		trades, err := h.tradeRepo.GetByAccountIDAfter(ctx, accountID, from, to, domain.TradeID(afterID), exportBatchSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		records := make([]exportRecord, len(trades))
+		for i, t := range trades {
+			records[i] = TradeExport{
+				ID:         int64(t.ID),
+				PositionID: int64(t.PositionID),
+				OrderID:    int64(t.OrderID),
+				Symbol:     t.Symbol,
+				Side:       string(t.Side),
+				Type:       string(t.Type),
+				Quantity:   t.Quantity.String(),
+				Price:      t.Price.String(),
+				PnL:        t.PnL.String(),
+				Fee:        t.Fee.String(),
+				CreatedAt:  t.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+			}
+			afterID = int64(t.ID)
+		}
+		return records, afterID, nil
+	}
+}
+
+func (h *ExportHandler) orders(accountID domain.AccountID, from, to *time.Time) exportBatch {
+	return func(ctx context.Context, afterID int64) ([]exportRecord, int64, error) {
+		orders, err := h.orderRepo.GetByAccountIDAfter(ctx, accountID, from, to, domain.OrderID(afterID), exportBatchSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		records := make([]exportRecord, len(orders))
+		for i, o := range orders {
+			records[i] = OrderExport{
+				ID:         int64(o.ID),
+				Symbol:     o.Symbol,
+				Side:       string(o.Side),
+				Type:       string(o.Type),
+				Status:     string(o.Status),
+				Quantity:   o.Quantity.String(),
+				Price:      o.Price.String(),
+				Leverage:   o.Leverage,
+				StopLoss:   optionalDecimal(o.StopLoss),
+				TakeProfit: optionalDecimal(o.TakeProfit),
+				FilledAt:   optionalTime(o.FilledAt),
+				CreatedAt:  o.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+			}
+			afterID = int64(o.ID)
+		}
+		return records, afterID, nil
+	}
+}
+
+func (h *ExportHandler) positions(accountID domain.AccountID, from, to *time.Time) exportBatch {
+	return func(ctx context.Context, afterID int64) ([]exportRecord, int64, error) {
+		positions, err := h.positionRepo.GetByAccountIDAfter(ctx, accountID, from, to, domain.PositionID(afterID), exportBatchSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		records := make([]exportRecord, len(positions))
+		for i, p := range positions {
+			records[i] = PositionExport{
+				ID:            int64(p.ID),
+				Symbol:        p.Symbol,
+				Side:          string(p.Side),
+				Status:        string(p.Status),
+				Quantity:      p.Quantity.String(),
+				EntryPrice:    p.EntryPrice.String(),
+				MarkPrice:     p.MarkPrice.String(),
+				Leverage:      p.Leverage,
+				InitialMargin: p.InitialMargin.String(),
+				UnrealizedPnL: p.UnrealizedPnL.String(),
+				RealizedPnL:   p.RealizedPnL.String(),
+				CreatedAt:     p.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+				ClosedAt:      optionalTime(p.ClosedAt),
+			}
+			afterID = int64(p.ID)
+		}
+		return records, afterID, nil
+	}
+}
+
+// streamCSV writes a header row followed by every record, flushing after each batch
+func streamCSV(ctx context.Context, w http.ResponseWriter, header []string, next exportBatch) error {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	var afterID int64
+	for {
+		records, lastID, err := next(ctx, afterID)
+		if err != nil {
+			return err
+		}
+		for _, rec := range records {
+			if err := cw.Write(rec.record()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		flush(w)
+
+		if len(records) < exportBatchSize {
+			return nil
+		}
+		afterID = lastID
+	}
+}
+
+// streamJSON writes the records as a single JSON array, flushing after each batch
+func streamJSON(ctx context.Context, w http.ResponseWriter, next exportBatch) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write([]byte("[")); err != nil {
+		return err
+	}
+
+	var afterID int64
+	first := true
+	for {
+		records, lastID, err := next(ctx, afterID)
+		if err != nil {
+			return err
+		}
+		for _, rec := range records {
+			data, err := json.Marshal(rec)
+			if err != nil {
+				return err
+			}
+			if !first {
+				data = append([]byte(","), data...)
+			}
+			first = false
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+		flush(w)
+
+		if len(records) < exportBatchSize {
+			break
+		}
+		afterID = lastID
+	}
+
+	_, err := w.Write([]byte("]\n"))
+	return err
+}
+
+func flush(w http.ResponseWriter) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func optionalField(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func optionalDecimal(value *decimal.Decimal) *string {
+	if value == nil {
+		return nil
+	}
+	s := value.String()
+	return &s
+}
+
+func optionalTime(value *time.Time) *string {
+	if value == nil {
+		return nil
+	}
+	s := value.UTC().Format("2006-01-02T15:04:05Z")
+	return &s
+}
@@ -0,0 +1,294 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	griduc "trading/internal/usecase/grid"
+)
+
+type GridBotHandler struct {
+	gridUC *griduc.UseCase
+}
+
+func NewGridBotHandler(gridUC *griduc.UseCase) *GridBotHandler {
+	return &GridBotHandler{gridUC: gridUC}
+}
+
+type CreateGridBotRequest struct {
+	Symbol     string `json:"symbol"`
+	LowerPrice string `json:"lower_price"`
+	UpperPrice string `json:"upper_price"`
+	Grids      int    `json:"grids"`
+	Investment string `json:"investment"`
+	Leverage   int    `json:"leverage,omitempty"`
+}
+
+type StopGridBotRequest struct {
+	ClosePosition bool `json:"close_position"`
+}
+
+type GridBotResponse struct {
+	ID            int64                  `json:"id"`
+	Symbol        string                 `json:"symbol"`
+	LowerPrice    string                 `json:"lower_price"`
+	UpperPrice    string                 `json:"upper_price"`
+	Grids         int                    `json:"grids"`
+	Investment    string                 `json:"investment"`
+	Leverage      int                    `json:"leverage"`
+	Quantity      string                 `json:"quantity"`
+	Status        string                 `json:"status"`
+	Orders        []GridBotOrderResponse `json:"orders"`
+	BuyFills      int                    `json:"buy_fills"`
+	SellFills     int                    `json:"sell_fills"`
+	Rounds        int                    `json:"rounds"`
+	GridProfit    string                 `json:"grid_profit"`
+	Position      string                 `json:"position"`
+	Fees          string                 `json:"fees"`
+	MarkPrice     *string                `json:"mark_price"`
+	UnrealizedPnL string                 `json:"unrealized_pnl"`
+	PnL           string                 `json:"pnl"`
+	ROI           string                 `json:"roi"`
+	CreatedAt     string                 `json:"created_at"`
+	StoppedAt     *string                `json:"stopped_at"`
+}
+
+type GridBotOrderResponse struct {
+	Level   int    `json:"level"`
+	Price   string `json:"price"`
+	Side    string `json:"side"`
+	OrderID int64  `json:"order_id"`
+}
+
+// CreateGridBot starts a grid bot on the account
+// POST /bots/grid
+func (h *GridBotHandler) CreateGridBot(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
+
+	var req CreateGridBotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	lower, err := decimal.NewFromString(req.LowerPrice)
+	if err != nil {
+		writeError(w, "invalid lower_price", http.StatusBadRequest)
+		return
+	}
+	upper, err := decimal.NewFromString(req.UpperPrice)
+	if err != nil {
+		writeError(w, "invalid upper_price", http.StatusBadRequest)
+		return
+	}
+	investment, err := decimal.NewFromString(req.Investment)
+	if err != nil {
+		writeError(w, "invalid investment", http.StatusBadRequest)
+		return
+	}
+
+	summary, err := h.gridUC.Create(r.Context(), griduc.CreateInput{
+		UserID:     userID,
+		AccountID:  accountID,
+		Symbol:     req.Symbol,
+		LowerPrice: lower,
+		UpperPrice: upper,
+		Grids:      req.Grids,
+		Investment: investment,
+		Leverage:   req.Leverage,
+	})
+	if err != nil {
+		writeGridBotError(w, err, "failed to create grid bot")
+		return
+	}
+
+	writeJSON(w, gridBotToResponse(summary), http.StatusCreated)
+}
+
+// GetGridBots returns the account's grid bots, newest first
+// GET /bots/grid
+func (h *GridBotHandler) GetGridBots(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	summaries, err := h.gridUC.List(r.Context(), accountID)
+	if err != nil {
+		writeError(w, "failed to get grid bots", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]GridBotResponse, len(summaries))
+	for i := range summaries {
+		response[i] = gridBotToResponse(&summaries[i])
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetGridBot returns a grid bot with its PnL summary
+// GET /bots/grid/{id}
+func (h *GridBotHandler) GetGridBot(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseGridBotID(w, r)
+	if !ok {
+		return
+	}
+
+	summary, err := h.gridUC.Get(r.Context(), accountID, id)
+	if err != nil {
+		writeGridBotError(w, err, "failed to get grid bot")
+		return
+	}
+
+	writeJSON(w, gridBotToResponse(summary), http.StatusOK)
+}
+
+// PauseGridBot cancels the bot's orders and keeps its position
+// POST /bots/grid/{id}/pause
+func (h *GridBotHandler) PauseGridBot(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseGridBotID(w, r)
+	if !ok {
+		return
+	}
+
+	summary, err := h.gridUC.Pause(r.Context(), accountID, id)
+	if err != nil {
+		writeGridBotError(w, err, "failed to pause grid bot")
+		return
+	}
+
+	writeJSON(w, gridBotToResponse(summary), http.StatusOK)
+}
+
+// ResumeGridBot places the grid again around the current price
+// POST /bots/grid/{id}/resume
+func (h *GridBotHandler) ResumeGridBot(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseGridBotID(w, r)
+	if !ok {
+		return
+	}
+
+	summary, err := h.gridUC.Resume(r.Context(), accountID, id)
+	if err != nil {
+		writeGridBotError(w, err, "failed to resume grid bot")
+		return
+	}
+
+	writeJSON(w, gridBotToResponse(summary), http.StatusOK)
+}
+
+// StopGridBot stops the bot for good, optionally closing its position at market
+// POST /bots/grid/{id}/stop
+func (h *GridBotHandler) StopGridBot(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseGridBotID(w, r)
+	if !ok {
+		return
+	}
+
+	// The body is optional
+	var req StopGridBotRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	summary, err := h.gridUC.Stop(r.Context(), accountID, id, req.ClosePosition)
+	if err != nil {
+		writeGridBotError(w, err, "failed to stop grid bot")
+		return
+	}
+
+	writeJSON(w, gridBotToResponse(summary), http.StatusOK)
+}
+
+func parseGridBotID(w http.ResponseWriter, r *http.Request) (domain.GridBotID, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid grid bot id", http.StatusBadRequest)
+		return 0, false
+	}
+	return domain.GridBotID(id), true
+}
+
+func writeGridBotError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidGridBot),
+		errors.Is(err, domain.ErrPriceOutsideGrid),
+		errors.Is(err, domain.ErrSymbolNotSupported),
+		errors.Is(err, domain.ErrInvalidQuantity),
+		errors.Is(err, domain.ErrInvalidLeverage):
+		writeError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrGridBotNotFound):
+		writeError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrAccountLocked):
+		writeError(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, domain.ErrGridBotStopped):
+		writeError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrTooManyGridBots),
+		errors.Is(err, domain.ErrInsufficientMargin):
+		writeError(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, domain.ErrPriceNotAvailable):
+		writeError(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		writeError(w, fallback, http.StatusInternalServerError)
+	}
+}
+
+func gridBotToResponse(s *griduc.Summary) GridBotResponse {
+	b := s.Bot
+	response := GridBotResponse{
+		ID:            int64(b.ID),
+		Symbol:        b.Symbol,
+		LowerPrice:    b.LowerPrice.String(),
+		UpperPrice:    b.UpperPrice.String(),
+		Grids:         b.Grids,
+		Investment:    b.Investment.String(),
+		Leverage:      b.Leverage,
+		Quantity:      b.Quantity.String(),
+		Status:        string(b.Status),
+		Orders:        make([]GridBotOrderResponse, len(b.Orders)),
+		BuyFills:      b.BuyFills,
+		SellFills:     b.SellFills,
+		Rounds:        b.Rounds,
+		GridProfit:    b.GridProfit.StringFixed(2),
+		Position:      b.Position.String(),
+		Fees:          b.Fees.StringFixed(2),
+		UnrealizedPnL: s.UnrealizedPnL.StringFixed(2),
+		PnL:           s.PnL.StringFixed(2),
+		ROI:           s.ROI.StringFixed(4),
+		CreatedAt:     b.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	for i, o := range b.Orders {
+		response.Orders[i] = GridBotOrderResponse{
+			Level:   o.Level,
+			Price:   b.LevelPrice(o.Level).String(),
+			Side:    string(o.Side),
+			OrderID: int64(o.OrderID),
+		}
+	}
+	if s.MarkPrice != nil {
+		mark := s.MarkPrice.String()
+		response.MarkPrice = &mark
+	}
+	if b.StoppedAt != nil {
+		stoppedAt := b.StoppedAt.UTC().Format("2006-01-02T15:04:05Z")
+		response.StoppedAt = &stoppedAt
+	}
+	return response
+}
@@ -3,8 +3,15 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
+
+	"trading/internal/domain"
 )
 
+// nextCursorHeader carries the next page cursor of endpoints that return plain arrays
+const nextCursorHeader = "X-Next-Cursor"
+
 func writeJSON(w http.ResponseWriter, data interface{}, status int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -16,3 +23,35 @@ func writeError(w http.ResponseWriter, message string, status int) {
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(map[string]string{"error": message})
 }
+
+// parseTimeParam parses an optional RFC3339 query parameter
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// parseCursorParam parses an optional pagination cursor
+func parseCursorParam(value string) (*domain.Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+	return domain.ParseCursor(value)
+}
+
+// parseLimitParam parses a page size, 50 by default and at most 100
+func parseLimitParam(value string) int {
+	limit, _ := strconv.Atoi(value)
+	if limit <= 0 {
+		return 50
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	leaderboarduc "trading/internal/usecase/leaderboard"
+)
+
+type LeaderboardHandler struct {
+	leaderboardUC *leaderboarduc.UseCase
+}
+
+func NewLeaderboardHandler(leaderboardUC *leaderboarduc.UseCase) *LeaderboardHandler {
+	return &LeaderboardHandler{leaderboardUC: leaderboardUC}
+}
+
+type LeaderboardResponse struct {
+	Period    string                     `json:"period"`
+	Metric    string                     `json:"metric"`
+	From      *string                    `json:"from"` // null for all_time
+	UpdatedAt string                     `json:"updated_at"`
+	Entries   []LeaderboardEntryResponse `json:"entries"`
+}
+
+type LeaderboardEntryResponse struct {
+	Rank         int     `json:"rank"`
+	DisplayName  string  `json:"display_name"`
+	RealizedPnL  string  `json:"realized_pnl"`
+	ROI          string  `json:"roi"`
+	RiskAdjusted *string `json:"risk_adjusted"`
+	Trades       int     `json:"trades"`
+	WinRate      string  `json:"win_rate"`
+}
+
+type LeaderboardProfileRequest struct {
+	DisplayName *string `json:"display_name"`
+	OptIn       *bool   `json:"opt_in"`
+}
+
+type LeaderboardProfileResponse struct {
+	DisplayName string `json:"display_name"`
+	OptIn       bool   `json:"opt_in"`
+}
+
+// GetLeaderboard returns the public ranking of opted-in traders
+// GET /leaderboard?period=daily|weekly|monthly|all_time&metric=pnl|roi|risk_adjusted&limit=
+func (h *LeaderboardHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	input := leaderboarduc.Input{
+		Period: domain.LeaderboardPeriod(q.Get("period")),
+		Metric: domain.LeaderboardMetric(q.Get("metric")),
+	}
+	if l := q.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit <= 0 {
+			writeError(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		input.Limit = limit
+	}
+
+	board, err := h.leaderboardUC.GetLeaderboard(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidLeaderboardPeriod) || errors.Is(err, domain.ErrInvalidLeaderboardMetric) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeError(w, "failed to get leaderboard", http.StatusInternalServerError)
+		return
+	}
+
+	response := LeaderboardResponse{
+		Period:    string(board.Period),
+		Metric:    string(board.Metric),
+		UpdatedAt: board.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		Entries:   make([]LeaderboardEntryResponse, len(board.Entries)),
+	}
+	if board.From != nil {
+		from := board.From.Format("2006-01-02T15:04:05Z")
+		response.From = &from
+	}
+	for i, e := range board.Entries {
+		entry := LeaderboardEntryResponse{
+			Rank:        e.Rank,
+			DisplayName: e.DisplayName,
+			RealizedPnL: e.RealizedPnL.StringFixed(2),
+			ROI:         e.ROI.StringFixed(4),
+			Trades:      e.Trades,
+			WinRate:     e.WinRate.StringFixed(4),
+		}
+		if e.RiskAdjusted != nil {
+			v := e.RiskAdjusted.StringFixed(4)
+			entry.RiskAdjusted = &v
+		}
+		response.Entries[i] = entry
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetProfile returns the user's leaderboard display name and opt-in
+// GET /leaderboard/profile
+func (h *LeaderboardHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	profile, err := h.leaderboardUC.GetProfile(r.Context(), userID)
+	if err != nil {
+		h.writeProfileError(w, err)
+		return
+	}
+
+	writeJSON(w, LeaderboardProfileResponse{
+		DisplayName: profile.DisplayName,
+		OptIn:       profile.OptIn,
+	}, http.StatusOK)
+}
+
+// UpdateProfile sets the user's display name and leaderboard opt-in
+// PUT /leaderboard/profile
+func (h *LeaderboardHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	var req LeaderboardProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.leaderboardUC.UpdateProfile(r.Context(), userID, leaderboarduc.UpdateProfileInput{
+		DisplayName: req.DisplayName,
+		OptIn:       req.OptIn,
+	})
+	if err != nil {
+		h.writeProfileError(w, err)
+		return
+	}
+
+	writeJSON(w, LeaderboardProfileResponse{
+		DisplayName: profile.DisplayName,
+		OptIn:       profile.OptIn,
+	}, http.StatusOK)
+}
+
+func (h *LeaderboardHandler) writeProfileError(w http.ResponseWriter, err error) {
+	if errors.Is(err, domain.ErrInvalidDisplayName) || errors.Is(err, domain.ErrDisplayNameMissing) {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, domain.ErrDisplayNameTaken) {
+		writeError(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if errors.Is(err, domain.ErrUserNotFound) {
+		writeError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeError(w, "failed to update leaderboard profile", http.StatusInternalServerError)
+}
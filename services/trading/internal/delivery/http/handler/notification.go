@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	alertuc "trading/internal/usecase/alert"
+)
+
+type NotificationHandler struct {
+	alertUC *alertuc.UseCase
+}
+
+func NewNotificationHandler(alertUC *alertuc.UseCase) *NotificationHandler {
+	return &NotificationHandler{alertUC: alertUC}
+}
+
+type NotificationResponse struct {
+	ID        int64   `json:"id"`
+	Type      string  `json:"type"`
+	Title     string  `json:"title"`
+	Message   string  `json:"message,omitempty"`
+	AlertID   *int64  `json:"alert_id,omitempty"`
+	Read      bool    `json:"read"`
+	ReadAt    *string `json:"read_at,omitempty"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type NotificationsResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	Unread        int                    `json:"unread"`
+}
+
+// GetNotifications returns the user's notification inbox, newest first
+// GET /notifications?unread=true&limit=&offset=
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	notifications, unread, err := h.alertUC.GetNotifications(r.Context(), userID, unreadOnly, limit, offset)
+	if err != nil {
+		writeError(w, "failed to get notifications", http.StatusInternalServerError)
+		return
+	}
+
+	response := NotificationsResponse{
+		Notifications: make([]NotificationResponse, len(notifications)),
+		Unread:        unread,
+	}
+	for i, n := range notifications {
+		response.Notifications[i] = notificationToResponse(&n)
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// MarkRead marks a notification as read
+// POST /notifications/{id}/read
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid notification id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.alertUC.MarkNotificationRead(r.Context(), userID, domain.NotificationID(id)); err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			writeError(w, "notification not found", http.StatusNotFound)
+			return
+		}
+		writeError(w, "failed to mark notification", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]string{"status": "read"}, http.StatusOK)
+}
+
+// MarkAllRead marks every notification of the user as read
+// POST /notifications/read-all
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	if err := h.alertUC.MarkAllNotificationsRead(r.Context(), userID); err != nil {
+		writeError(w, "failed to mark notifications", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]string{"status": "read"}, http.StatusOK)
+}
+
+func notificationToResponse(n *domain.Notification) NotificationResponse {
+	resp := NotificationResponse{
+		ID:        int64(n.ID),
+		Type:      string(n.Type),
+		Title:     n.Title,
+		Message:   n.Message,
+		Read:      n.IsRead(),
+		CreatedAt: n.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if n.AlertID != nil {
+		id := int64(*n.AlertID)
+		resp.AlertID = &id
+	}
+	if n.ReadAt != nil {
+		s := n.ReadAt.Format("2006-01-02T15:04:05Z")
+		resp.ReadAt = &s
+	}
+	return resp
+}
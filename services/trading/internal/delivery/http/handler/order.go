@@ -44,11 +44,12 @@ type OrderResponse struct {
 	Leverage   int     `json:"leverage"`
 	StopLoss   *string `json:"stop_loss,omitempty"`
 	TakeProfit *string `json:"take_profit,omitempty"`
+	ParentID   *int64  `json:"parent_id,omitempty"`
 	CreatedAt  string  `json:"created_at"`
 }
 
 func (h *OrderHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
 
 	var req PlaceOrderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -90,7 +91,7 @@ func (h *OrderHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 	}
 
 	output, err := h.orderUC.PlaceOrder(r.Context(), orderuc.PlaceOrderInput{
-		UserID:     userID,
+		AccountID:  accountID,
 		Symbol:     req.Symbol,
 		Side:       domain.OrderSide(req.Side),
 		Type:       domain.OrderType(req.Type),
@@ -106,6 +107,10 @@ func (h *OrderHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 			status = http.StatusUnprocessableEntity
 		} else if errors.Is(err, domain.ErrPriceNotAvailable) {
 			status = http.StatusServiceUnavailable
+		} else if errors.Is(err, domain.ErrCompetitionNotActive) ||
+			errors.Is(err, domain.ErrSymbolNotAllowed) ||
+			errors.Is(err, domain.ErrAccountLocked) {
+			status = http.StatusForbidden
 		}
 		writeError(w, err.Error(), status)
 		return
@@ -114,18 +119,49 @@ func (h *OrderHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, orderToResponse(output.Order), http.StatusCreated)
 }
 
+// GetOrders returns orders newest first. Pass the X-Next-Cursor response header
+// back as cursor to get the next page; offset is still accepted.
+// GET /orders?symbol=&side=&type=&status=&parent_id=&from=&to=&cursor=&limit=&offset=
 func (h *OrderHandler) GetOrders(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserID(r.Context())
-
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-
-	if limit <= 0 {
-		limit = 50
+	accountID := middleware.GetAccountID(r.Context())
+	q := r.URL.Query()
+
+	filter := domain.OrderFilter{
+		Symbol: q.Get("symbol"),
+		Side:   domain.OrderSide(q.Get("side")),
+		Type:   domain.OrderType(q.Get("type")),
+		Status: domain.OrderStatus(q.Get("status")),
+		Limit:  parseLimitParam(q.Get("limit")),
+	}
+	filter.Offset, _ = strconv.Atoi(q.Get("offset"))
+	if v := q.Get("parent_id"); v != "" {
+		parentID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeError(w, "invalid parent_id", http.StatusBadRequest)
+			return
+		}
+		filter.ParentID = (*domain.OrderID)(&parentID)
+	}
+	var err error
+	if filter.From, err = parseTimeParam(q.Get("from")); err != nil {
+		writeError(w, "invalid from", http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = parseTimeParam(q.Get("to")); err != nil {
+		writeError(w, "invalid to", http.StatusBadRequest)
+		return
+	}
+	if filter.Cursor, err = parseCursorParam(q.Get("cursor")); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	orders, err := h.orderUC.GetOrders(r.Context(), userID, limit, offset)
+	orders, next, err := h.orderUC.GetOrders(r.Context(), accountID, filter)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidOrderFilter) || errors.Is(err, domain.ErrInvalidTimeRange) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		writeError(w, "failed to get orders", http.StatusInternalServerError)
 		return
 	}
@@ -134,12 +170,15 @@ func (h *OrderHandler) GetOrders(w http.ResponseWriter, r *http.Request) {
 	for i, o := range orders {
 		response[i] = orderToResponse(&o)
 	}
+	if next != nil {
+		w.Header().Set(nextCursorHeader, next.Encode())
+	}
 
 	writeJSON(w, response, http.StatusOK)
 }
 
 func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
 
 	orderIDStr := chi.URLParam(r, "id")
 	orderID, err := strconv.ParseInt(orderIDStr, 10, 64)
@@ -148,7 +187,7 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	order, err := h.orderUC.GetOrder(r.Context(), userID, domain.OrderID(orderID))
+	order, err := h.orderUC.GetOrder(r.Context(), accountID, domain.OrderID(orderID))
 	if err != nil {
 		if errors.Is(err, domain.ErrOrderNotFound) {
 			writeError(w, "order not found", http.StatusNotFound)
@@ -162,7 +201,7 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
 
 	orderIDStr := chi.URLParam(r, "id")
 	orderID, err := strconv.ParseInt(orderIDStr, 10, 64)
@@ -171,7 +210,7 @@ func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.orderUC.CancelOrder(r.Context(), userID, domain.OrderID(orderID)); err != nil {
+	if err := h.orderUC.CancelOrder(r.Context(), accountID, domain.OrderID(orderID)); err != nil {
 		if errors.Is(err, domain.ErrOrderNotFound) {
 			writeError(w, "order not found", http.StatusNotFound)
 			return
@@ -195,7 +234,7 @@ type UpdateOrderRequest struct {
 }
 
 func (h *OrderHandler) UpdateOrder(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
 
 	orderIDStr := chi.URLParam(r, "id")
 	orderID, err := strconv.ParseInt(orderIDStr, 10, 64)
@@ -258,7 +297,7 @@ func (h *OrderHandler) UpdateOrder(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	order, err := h.orderUC.UpdateOrder(r.Context(), userID, domain.OrderID(orderID), input)
+	order, err := h.orderUC.UpdateOrder(r.Context(), accountID, domain.OrderID(orderID), input)
 	if err != nil {
 		if errors.Is(err, domain.ErrOrderNotFound) {
 			writeError(w, "order not found", http.StatusNotFound)
@@ -275,6 +314,45 @@ func (h *OrderHandler) UpdateOrder(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, orderToResponse(order), http.StatusOK)
 }
 
+type SpotLotResponse struct {
+	ID        int64  `json:"id"`
+	Asset     string `json:"asset"`
+	TradeID   int64  `json:"trade_id"`
+	Quantity  string `json:"quantity"`
+	Remaining string `json:"remaining"`
+	Price     string `json:"price"`
+	CostBasis string `json:"cost_basis"`
+	CreatedAt string `json:"created_at"`
+}
+
+// GetSpotLots returns open cost basis lots of spot holdings
+// GET /spot/lots
+func (h *OrderHandler) GetSpotLots(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	lots, err := h.orderUC.GetSpotLots(r.Context(), accountID)
+	if err != nil {
+		writeError(w, "failed to get spot lots", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]SpotLotResponse, len(lots))
+	for i, l := range lots {
+		response[i] = SpotLotResponse{
+			ID:        int64(l.ID),
+			Asset:     string(l.Asset),
+			TradeID:   int64(l.TradeID),
+			Quantity:  l.Quantity.String(),
+			Remaining: l.Remaining.String(),
+			Price:     l.Price.String(),
+			CostBasis: l.CostBasis().StringFixed(2),
+			CreatedAt: l.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
 func orderToResponse(o *domain.Order) OrderResponse {
 	resp := OrderResponse{
 		ID:        int64(o.ID),
@@ -295,5 +373,9 @@ func orderToResponse(o *domain.Order) OrderResponse {
 		tp := o.TakeProfit.String()
 		resp.TakeProfit = &tp
 	}
+	if o.ParentID != nil {
+		parentID := int64(*o.ParentID)
+		resp.ParentID = &parentID
+	}
 	return resp
 }
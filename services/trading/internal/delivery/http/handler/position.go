@@ -43,9 +43,9 @@ type PositionResponse struct {
 }
 
 func (h *PositionHandler) GetPositions(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
 
-	positions, err := h.positionUC.GetPositions(r.Context(), userID)
+	positions, err := h.positionUC.GetPositions(r.Context(), accountID)
 	if err != nil {
 		writeError(w, "failed to get positions", http.StatusInternalServerError)
 		return
@@ -60,7 +60,7 @@ func (h *PositionHandler) GetPositions(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *PositionHandler) GetPosition(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
 
 	positionIDStr := chi.URLParam(r, "id")
 	positionID, err := strconv.ParseInt(positionIDStr, 10, 64)
@@ -69,7 +69,7 @@ func (h *PositionHandler) GetPosition(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	position, err := h.positionUC.GetPosition(r.Context(), userID, domain.PositionID(positionID))
+	position, err := h.positionUC.GetPosition(r.Context(), accountID, domain.PositionID(positionID))
 	if err != nil {
 		if errors.Is(err, domain.ErrPositionNotFound) {
 			writeError(w, "position not found", http.StatusNotFound)
@@ -83,7 +83,7 @@ func (h *PositionHandler) GetPosition(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *PositionHandler) ClosePosition(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
 
 	positionIDStr := chi.URLParam(r, "id")
 	positionID, err := strconv.ParseInt(positionIDStr, 10, 64)
@@ -100,7 +100,7 @@ func (h *PositionHandler) ClosePosition(w http.ResponseWriter, r *http.Request)
 	_ = json.NewDecoder(r.Body).Decode(&closeReq)
 
 	input := positionuc.ClosePositionInput{
-		UserID:     userID,
+		AccountID:  accountID,
 		PositionID: domain.PositionID(positionID),
 	}
 
@@ -151,7 +151,7 @@ type UpdateTPSLRequest struct {
 }
 
 func (h *PositionHandler) UpdateTPSL(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
 
 	positionIDStr := chi.URLParam(r, "id")
 	positionID, err := strconv.ParseInt(positionIDStr, 10, 64)
@@ -185,7 +185,7 @@ func (h *PositionHandler) UpdateTPSL(w http.ResponseWriter, r *http.Request) {
 	}
 
 	position, err := h.positionUC.UpdateTPSL(r.Context(), positionuc.UpdateTPSLInput{
-		UserID:         userID,
+		AccountID:      accountID,
 		PositionID:     domain.PositionID(positionID),
 		StopLoss:       stopLoss,
 		TakeProfit:     takeProfit,
@@ -220,6 +220,84 @@ func (h *PositionHandler) UpdateTPSL(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, positionToResponse(position), http.StatusOK)
 }
 
+type PositionHistoryEntryResponse struct {
+	Position       PositionResponse `json:"position"`
+	Trades         []TradeResponse  `json:"trades"`
+	RealizedPnL    string           `json:"realized_pnl"`
+	Fees           string           `json:"fees"`
+	ROE            string           `json:"roe"`
+	HoldingTimeSec int64            `json:"holding_time_sec"`
+	ClosedAt       string           `json:"closed_at"`
+}
+
+type PositionHistoryResponse struct {
+	Positions  []PositionHistoryEntryResponse `json:"positions"`
+	NextCursor *string                        `json:"next_cursor,omitempty"`
+}
+
+// GetPositionHistory returns closed and liquidated positions, latest close first
+// GET /positions/history?symbol=&side=LONG|SHORT&status=CLOSED|LIQUIDATED&from=&to=&cursor=&limit=
+func (h *PositionHandler) GetPositionHistory(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+	q := r.URL.Query()
+
+	filter := domain.PositionHistoryFilter{
+		Symbol: q.Get("symbol"),
+		Side:   domain.PositionSide(q.Get("side")),
+		Status: domain.PositionStatus(q.Get("status")),
+	}
+	var err error
+	if filter.From, err = parseTimeParam(q.Get("from")); err != nil {
+		writeError(w, "invalid from", http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = parseTimeParam(q.Get("to")); err != nil {
+		writeError(w, "invalid to", http.StatusBadRequest)
+		return
+	}
+	if filter.Cursor, err = parseCursorParam(q.Get("cursor")); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter.Limit = parseLimitParam(q.Get("limit"))
+
+	history, err := h.positionUC.GetHistory(r.Context(), accountID, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPositionFilter) || errors.Is(err, domain.ErrInvalidTimeRange) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeError(w, "failed to get position history", http.StatusInternalServerError)
+		return
+	}
+
+	response := PositionHistoryResponse{Positions: make([]PositionHistoryEntryResponse, len(history.Entries))}
+	for i := range history.Entries {
+		e := &history.Entries[i]
+		entry := PositionHistoryEntryResponse{
+			Position:       positionToResponse(&e.Position),
+			Trades:         make([]TradeResponse, len(e.Trades)),
+			RealizedPnL:    e.RealizedPnL.StringFixed(2),
+			Fees:           e.Fees.StringFixed(2),
+			ROE:            e.ROE.StringFixed(2),
+			HoldingTimeSec: int64(e.HoldingTime.Seconds()),
+		}
+		if e.Position.ClosedAt != nil {
+			entry.ClosedAt = e.Position.ClosedAt.UTC().Format("2006-01-02T15:04:05Z")
+		}
+		for j := range e.Trades {
+			entry.Trades[j] = tradeToResponse(&e.Trades[j])
+		}
+		response.Positions[i] = entry
+	}
+	if history.NextCursor != nil {
+		next := history.NextCursor.Encode()
+		response.NextCursor = &next
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
 func positionToResponse(p *domain.Position) PositionResponse {
 	resp := PositionResponse{
 		ID:               int64(p.ID),
@@ -8,15 +8,15 @@ import (
 
 // PriceHandler handles price-related requests
 type PriceHandler struct {
-	priceCache domain.PriceCache
-	symbols    []string
+	priceCache  domain.PriceCache
+	instruments []domain.Instrument
 }
 
 // NewPriceHandler creates a new PriceHandler
-func NewPriceHandler(priceCache domain.PriceCache, symbols []string) *PriceHandler {
+func NewPriceHandler(priceCache domain.PriceCache, instruments []domain.Instrument) *PriceHandler {
 	return &PriceHandler{
-		priceCache: priceCache,
-		symbols:    symbols,
+		priceCache:  priceCache,
+		instruments: instruments,
 	}
 }
 
@@ -53,6 +53,7 @@ func (h *PriceHandler) GetPrices(w http.ResponseWriter, r *http.Request) {
 // SymbolInfo represents trading pair information
 type SymbolInfo struct {
 	Symbol          string `json:"symbol"`
+	MarketType      string `json:"market_type"`
 	BaseCurrency    string `json:"base_currency"`
 	QuoteCurrency   string `json:"quote_currency"`
 	MinQuantity     string `json:"min_quantity"`
@@ -66,24 +67,27 @@ type SymbolInfo struct {
 // GetSymbols returns supported trading symbols
 // GET /symbols
 func (h *PriceHandler) GetSymbols(w http.ResponseWriter, r *http.Request) {
-	symbols := make([]SymbolInfo, 0, len(h.symbols))
+	symbols := make([]SymbolInfo, 0, len(h.instruments))
 
-	for _, s := range h.symbols {
-		// Parse symbol to get base and quote currencies
-		base := s[:len(s)-4]  // e.g., "BTC" from "BTCUSDT"
-		quote := s[len(s)-4:] // e.g., "USDT"
-
-		symbols = append(symbols, SymbolInfo{
-			Symbol:          s,
-			BaseCurrency:    base,
-			QuoteCurrency:   quote,
+	for _, i := range h.instruments {
+		info := SymbolInfo{
+			Symbol:          i.Symbol,
+			MarketType:      string(i.MarketType),
+			BaseCurrency:    string(i.BaseAsset),
+			QuoteCurrency:   string(i.QuoteAsset),
 			MinQuantity:     "0.001",
 			MaxQuantity:     "1000",
 			QuantityStep:    "0.001",
 			MinLeverage:     1,
 			MaxLeverage:     100,
 			MaintenanceRate: "0.005",
-		})
+		}
+		// Spot has no leverage and no liquidation
+		if i.IsSpot() {
+			info.MaxLeverage = 1
+			info.MaintenanceRate = "0"
+		}
+		symbols = append(symbols, info)
 	}
 
 	writeJSON(w, symbols, http.StatusOK)
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	reportuc "trading/internal/usecase/report"
+)
+
+type ReportHandler struct {
+	reportUC *reportuc.UseCase
+}
+
+func NewReportHandler(reportUC *reportuc.UseCase) *ReportHandler {
+	return &ReportHandler{reportUC: reportUC}
+}
+
+type PnLAmountsResponse struct {
+	RealizedPnL string `json:"realized_pnl"`
+	Fees        string `json:"fees"`
+	Funding     string `json:"funding"`
+	NetPnL      string `json:"net_pnl"`
+	Lots        int    `json:"lots"`
+}
+
+type PnLSymbolResponse struct {
+	Symbol string `json:"symbol"`
+	PnLAmountsResponse
+}
+
+type PnLPeriodResponse struct {
+	Start string `json:"start"`
+	PnLAmountsResponse
+	BySymbol []PnLSymbolResponse `json:"by_symbol"`
+}
+
+type ClosedLotResponse struct {
+	PositionID     int64  `json:"position_id"`
+	Symbol         string `json:"symbol"`
+	Side           string `json:"side"`
+	Quantity       string `json:"quantity"`
+	EntryTradeID   int64  `json:"entry_trade_id"`
+	EntryTime      string `json:"entry_time"`
+	EntryPrice     string `json:"entry_price"`
+	ExitTradeID    int64  `json:"exit_trade_id"`
+	ExitTime       string `json:"exit_time"`
+	ExitPrice      string `json:"exit_price"`
+	HoldingTimeSec int64  `json:"holding_time_sec"`
+	PnL            string `json:"pnl"`
+	Liquidated     bool   `json:"liquidated"`
+}
+
+type PnLReportResponse struct {
+	Period  string              `json:"period"`
+	From    *string             `json:"from,omitempty"`
+	To      *string             `json:"to,omitempty"`
+	Total   PnLAmountsResponse  `json:"total"`
+	Periods []PnLPeriodResponse `json:"periods"`
+	Lots    []ClosedLotResponse `json:"lots"`
+}
+
+// GetRealizedPnL returns the realized PnL statement with FIFO matched lots.
+// CSV has one row per period and symbol, or per closed lot with view=lots.
+// GET /account/reports/pnl?period=day|month|year&from=&to=&format=json|csv&view=summary|lots
+func (h *ReportHandler) GetRealizedPnL(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+	q := r.URL.Query()
+
+	input := reportuc.Input{Period: reportuc.Period(q.Get("period"))}
+	if input.Period == "" {
+		input.Period = reportuc.PeriodMonth
+	}
+	var err error
+	if input.From, err = parseTimeParam(q.Get("from")); err != nil {
+		writeError(w, "invalid from", http.StatusBadRequest)
+		return
+	}
+	if input.To, err = parseTimeParam(q.Get("to")); err != nil {
+		writeError(w, "invalid to", http.StatusBadRequest)
+		return
+	}
+
+	format := q.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	view := q.Get("view")
+	if view == "" {
+		view = "summary"
+	}
+	if (format != "json" && format != "csv") || (view != "summary" && view != "lots") {
+		writeError(w, "invalid format", http.StatusBadRequest)
+		return
+	}
+
+	statement, err := h.reportUC.GetRealizedPnL(r.Context(), accountID, input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidReportPeriod) || errors.Is(err, domain.ErrInvalidTimeRange) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeError(w, "failed to build report", http.StatusInternalServerError)
+		return
+	}
+
+	if format == "csv" {
+		writePnLCSV(w, statement, view, fmt.Sprintf("pnl-%s-%d-%s.csv", statement.Period, accountID, view))
+		return
+	}
+
+	response := PnLReportResponse{
+		Period:  string(statement.Period),
+		Total:   pnlAmountsToResponse(statement.Total),
+		Periods: make([]PnLPeriodResponse, len(statement.Periods)),
+		Lots:    make([]ClosedLotResponse, len(statement.Lots)),
+	}
+	if statement.From != nil {
+		from := statement.From.UTC().Format("2006-01-02T15:04:05Z")
+		response.From = &from
+	}
+	if statement.To != nil {
+		to := statement.To.UTC().Format("2006-01-02T15:04:05Z")
+		response.To = &to
+	}
+	for i, p := range statement.Periods {
+		line := PnLPeriodResponse{
+			Start:              p.Start.Format("2006-01-02T15:04:05Z"),
+			PnLAmountsResponse: pnlAmountsToResponse(p.Amounts),
+			BySymbol:           make([]PnLSymbolResponse, len(p.BySymbol)),
+		}
+		for j, s := range p.BySymbol {
+			line.BySymbol[j] = PnLSymbolResponse{Symbol: s.Symbol, PnLAmountsResponse: pnlAmountsToResponse(s.Amounts)}
+		}
+		response.Periods[i] = line
+	}
+	for i := range statement.Lots {
+		response.Lots[i] = closedLotToResponse(&statement.Lots[i])
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+func writePnLCSV(w http.ResponseWriter, statement *reportuc.Statement, view, filename string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	if view == "lots" {
+		cw.Write([]string{"position_id", "symbol", "side", "quantity", "entry_time", "entry_price", "exit_time", "exit_price", "holding_time_sec", "pnl", "liquidated"})
+		for i := range statement.Lots {
+			lot := closedLotToResponse(&statement.Lots[i])
+			cw.Write([]string{
+				strconv.FormatInt(lot.PositionID, 10), lot.Symbol, lot.Side, lot.Quantity,
+				lot.EntryTime, lot.EntryPrice, lot.ExitTime, lot.ExitPrice,
+				strconv.FormatInt(lot.HoldingTimeSec, 10), lot.PnL, strconv.FormatBool(lot.Liquidated),
+			})
+		}
+	} else {
+		cw.Write([]string{"period_start", "symbol", "realized_pnl", "fees", "funding", "net_pnl", "lots"})
+		for _, p := range statement.Periods {
+			start := p.Start.Format("2006-01-02T15:04:05Z")
+			for _, s := range p.BySymbol {
+				a := pnlAmountsToResponse(s.Amounts)
+				cw.Write([]string{start, s.Symbol, a.RealizedPnL, a.Fees, a.Funding, a.NetPnL, strconv.Itoa(a.Lots)})
+			}
+		}
+	}
+	cw.Flush()
+}
+
+func pnlAmountsToResponse(a reportuc.Amounts) PnLAmountsResponse {
+	return PnLAmountsResponse{
+		RealizedPnL: a.RealizedPnL.StringFixed(2),
+		Fees:        a.Fees.StringFixed(2),
+		Funding:     a.Funding.StringFixed(2),
+		NetPnL:      a.NetPnL.StringFixed(2),
+		Lots:        a.Lots,
+	}
+}
+
+func closedLotToResponse(l *reportuc.ClosedLot) ClosedLotResponse {
+	return ClosedLotResponse{
+		PositionID:     int64(l.PositionID),
+		Symbol:         l.Symbol,
+		Side:           string(l.Side),
+		Quantity:       l.Quantity.String(),
+		EntryTradeID:   int64(l.EntryTradeID),
+		EntryTime:      l.EntryTime.UTC().Format("2006-01-02T15:04:05Z"),
+		EntryPrice:     l.EntryPrice.String(),
+		ExitTradeID:    int64(l.ExitTradeID),
+		ExitTime:       l.ExitTime.UTC().Format("2006-01-02T15:04:05Z"),
+		ExitPrice:      l.ExitPrice.String(),
+		HoldingTimeSec: int64(l.HoldingPeriod().Seconds()),
+		PnL:            l.PnL.StringFixed(2),
+		Liquidated:     l.Liquidated,
+	}
+}
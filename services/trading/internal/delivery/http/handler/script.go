@@ -0,0 +1,346 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	scriptuc "trading/internal/usecase/script"
+)
+
+type ScriptHandler struct {
+	scriptUC *scriptuc.UseCase
+}
+
+func NewScriptHandler(scriptUC *scriptuc.UseCase) *ScriptHandler {
+	return &ScriptHandler{scriptUC: scriptUC}
+}
+
+type CreateScriptRequest struct {
+	Name   string             `json:"name"`
+	Symbol string             `json:"symbol"`
+	Source string             `json:"source"`
+	Params map[string]float64 `json:"params,omitempty"`
+}
+
+type UpdateScriptRequest struct {
+	Name       *string            `json:"name,omitempty"`
+	Source     *string            `json:"source,omitempty"`
+	Params     map[string]float64 `json:"params,omitempty"`
+	ResetState bool               `json:"reset_state,omitempty"`
+}
+
+type BacktestScriptRequest struct {
+	Dataset         string  `json:"dataset,omitempty"`
+	CSV             string  `json:"csv,omitempty"`
+	StartingBalance string  `json:"starting_balance,omitempty"`
+	Spread          float64 `json:"spread,omitempty"`
+}
+
+type ScriptResponse struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	Symbol    string             `json:"symbol"`
+	Source    string             `json:"source"`
+	Params    map[string]float64 `json:"params"`
+	Status    string             `json:"status"`
+	LastError string             `json:"last_error,omitempty"`
+	State     json.RawMessage    `json:"state"`
+	OrderIDs  []int64            `json:"order_ids"`
+	StartedAt *string            `json:"started_at"`
+	CreatedAt string             `json:"created_at"`
+	UpdatedAt string             `json:"updated_at"`
+}
+
+type ScriptLogResponse struct {
+	ID        int64  `json:"id"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+	CreatedAt string `json:"created_at"`
+}
+
+// CreateScript stores a strategy script; it runs once started
+// POST /scripts
+func (h *ScriptHandler) CreateScript(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
+
+	var req CreateScriptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	script, err := h.scriptUC.Create(r.Context(), scriptuc.CreateInput{
+		UserID:    userID,
+		AccountID: accountID,
+		Name:      req.Name,
+		Symbol:    req.Symbol,
+		Source:    req.Source,
+		Params:    req.Params,
+	})
+	if err != nil {
+		writeScriptError(w, err, "failed to create script")
+		return
+	}
+
+	writeJSON(w, scriptToResponse(script), http.StatusCreated)
+}
+
+// GetScripts returns the account's scripts, newest first
+// GET /scripts
+func (h *ScriptHandler) GetScripts(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	scripts, err := h.scriptUC.List(r.Context(), accountID)
+	if err != nil {
+		writeError(w, "failed to get scripts", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]ScriptResponse, len(scripts))
+	for i := range scripts {
+		response[i] = scriptToResponse(&scripts[i])
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetScript returns a script with its state
+// GET /scripts/{id}
+func (h *ScriptHandler) GetScript(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseScriptID(w, r)
+	if !ok {
+		return
+	}
+
+	script, err := h.scriptUC.Get(r.Context(), accountID, id)
+	if err != nil {
+		writeScriptError(w, err, "failed to get script")
+		return
+	}
+
+	writeJSON(w, scriptToResponse(script), http.StatusOK)
+}
+
+// UpdateScript changes the name, source or parameters of a stopped script
+// PUT /scripts/{id}
+func (h *ScriptHandler) UpdateScript(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseScriptID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateScriptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	script, err := h.scriptUC.Update(r.Context(), accountID, id, scriptuc.UpdateInput{
+		Name:       req.Name,
+		Source:     req.Source,
+		Params:     req.Params,
+		ResetState: req.ResetState,
+	})
+	if err != nil {
+		writeScriptError(w, err, "failed to update script")
+		return
+	}
+
+	writeJSON(w, scriptToResponse(script), http.StatusOK)
+}
+
+// DeleteScript removes a stopped script and its logs
+// DELETE /scripts/{id}
+func (h *ScriptHandler) DeleteScript(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseScriptID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.scriptUC.Delete(r.Context(), accountID, id); err != nil {
+		writeScriptError(w, err, "failed to delete script")
+		return
+	}
+
+	writeJSON(w, map[string]string{"status": "deleted"}, http.StatusOK)
+}
+
+// StartScript runs the script's top level and starts its callbacks
+// POST /scripts/{id}/start
+func (h *ScriptHandler) StartScript(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseScriptID(w, r)
+	if !ok {
+		return
+	}
+
+	script, err := h.scriptUC.Start(r.Context(), accountID, id)
+	if err != nil {
+		writeScriptError(w, err, "failed to start script")
+		return
+	}
+
+	writeJSON(w, scriptToResponse(script), http.StatusOK)
+}
+
+// StopScript stops the callbacks and cancels the script's resting orders
+// POST /scripts/{id}/stop
+func (h *ScriptHandler) StopScript(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseScriptID(w, r)
+	if !ok {
+		return
+	}
+
+	script, err := h.scriptUC.Stop(r.Context(), accountID, id)
+	if err != nil {
+		writeScriptError(w, err, "failed to stop script")
+		return
+	}
+
+	writeJSON(w, scriptToResponse(script), http.StatusOK)
+}
+
+// GetScriptLogs returns the script's latest log lines, newest first
+// GET /scripts/{id}/logs?limit=
+func (h *ScriptHandler) GetScriptLogs(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseScriptID(w, r)
+	if !ok {
+		return
+	}
+
+	logs, err := h.scriptUC.Logs(r.Context(), accountID, id, parseLimitParam(r.URL.Query().Get("limit")))
+	if err != nil {
+		writeScriptError(w, err, "failed to get script logs")
+		return
+	}
+
+	response := make([]ScriptLogResponse, len(logs))
+	for i, l := range logs {
+		response[i] = ScriptLogResponse{
+			ID:        l.ID,
+			Level:     string(l.Level),
+			Message:   l.Message,
+			CreatedAt: l.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		}
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// BacktestScript runs the script over historical candles as a backtest job;
+// poll GET /backtests/{id} for the report
+// POST /scripts/{id}/backtest
+func (h *ScriptHandler) BacktestScript(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseScriptID(w, r)
+	if !ok {
+		return
+	}
+
+	var req BacktestScriptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := scriptuc.BacktestInput{
+		UserID:  userID,
+		Dataset: req.Dataset,
+		CSV:     req.CSV,
+		Spread:  req.Spread,
+	}
+	if req.StartingBalance != "" {
+		balance, err := decimal.NewFromString(req.StartingBalance)
+		if err != nil {
+			writeError(w, "invalid starting_balance", http.StatusBadRequest)
+			return
+		}
+		input.StartingBalance = balance
+	}
+
+	job, err := h.scriptUC.Backtest(r.Context(), accountID, id, input)
+	if err != nil {
+		writeScriptError(w, err, "failed to submit backtest")
+		return
+	}
+
+	writeJSON(w, backtestToResponse(job, false), http.StatusAccepted)
+}
+
+func parseScriptID(w http.ResponseWriter, r *http.Request) (domain.ScriptID, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid script id", http.StatusBadRequest)
+		return 0, false
+	}
+	return domain.ScriptID(id), true
+}
+
+func writeScriptError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidScript),
+		errors.Is(err, domain.ErrSymbolNotSupported),
+		errors.Is(err, domain.ErrInvalidBacktest),
+		errors.Is(err, domain.ErrInvalidCandles):
+		writeError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrScriptNotFound),
+		errors.Is(err, domain.ErrDatasetNotFound):
+		writeError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrScriptRunning),
+		errors.Is(err, domain.ErrScriptSymbolBusy):
+		writeError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrTooManyScripts),
+		errors.Is(err, domain.ErrTooManyBacktests):
+		writeError(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		writeError(w, fallback, http.StatusInternalServerError)
+	}
+}
+
+func scriptToResponse(s *domain.Script) ScriptResponse {
+	response := ScriptResponse{
+		ID:        int64(s.ID),
+		Name:      s.Name,
+		Symbol:    s.Symbol,
+		Source:    s.Source,
+		Params:    s.Params,
+		Status:    string(s.Status),
+		LastError: s.LastError,
+		State:     json.RawMessage(s.State),
+		OrderIDs:  make([]int64, len(s.Orders)),
+		CreatedAt: s.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: s.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if response.Params == nil {
+		response.Params = map[string]float64{}
+	}
+	for i, id := range s.Orders {
+		response.OrderIDs[i] = int64(id)
+	}
+	if s.StartedAt != nil {
+		startedAt := s.StartedAt.UTC().Format("2006-01-02T15:04:05Z")
+		response.StartedAt = &startedAt
+	}
+	return response
+}
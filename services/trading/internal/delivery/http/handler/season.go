@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	seasonuc "trading/internal/usecase/season"
+)
+
+type SeasonHandler struct {
+	seasonUC *seasonuc.UseCase
+}
+
+func NewSeasonHandler(seasonUC *seasonuc.UseCase) *SeasonHandler {
+	return &SeasonHandler{seasonUC: seasonUC}
+}
+
+type SeasonResponse struct {
+	Season          int     `json:"season"`
+	Current         bool    `json:"current"`
+	StartedAt       string  `json:"started_at"`
+	EndedAt         *string `json:"ended_at,omitempty"`
+	StartingBalance string  `json:"starting_balance"`
+	FinalBalance    string  `json:"final_balance"`
+	RealizedPnL     string  `json:"realized_pnl"`
+	ROI             string  `json:"roi"`
+	Volume          string  `json:"volume"`
+	TotalTrades     int     `json:"total_trades"`
+	ClosedTrades    int     `json:"closed_trades"`
+	WinningTrades   int     `json:"winning_trades"`
+	LosingTrades    int     `json:"losing_trades"`
+	WinRate         string  `json:"win_rate"`
+	Liquidations    int     `json:"liquidations"`
+}
+
+// Reset closes everything, archives the current season and restores the initial balance
+// POST /account/reset
+func (h *SeasonHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	season, err := h.seasonUC.Reset(r.Context(), accountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrIsolatedAccount) {
+			writeError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, domain.ErrPriceNotAvailable) {
+			writeError(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		writeError(w, "failed to reset account", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, seasonToResponse(season), http.StatusOK)
+}
+
+// GetSeasons returns the current and archived seasons
+// GET /account/seasons
+func (h *SeasonHandler) GetSeasons(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	seasons, err := h.seasonUC.GetSeasons(r.Context(), accountID)
+	if err != nil {
+		writeError(w, "failed to get seasons", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]SeasonResponse, len(seasons))
+	for i, s := range seasons {
+		response[i] = seasonToResponse(&s)
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetSeason returns a single season
+// GET /account/seasons/{season}
+func (h *SeasonHandler) GetSeason(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	number, err := strconv.Atoi(chi.URLParam(r, "season"))
+	if err != nil {
+		writeError(w, "invalid season", http.StatusBadRequest)
+		return
+	}
+
+	season, err := h.seasonUC.GetSeason(r.Context(), accountID, number)
+	if err != nil {
+		if errors.Is(err, domain.ErrSeasonNotFound) {
+			writeError(w, "season not found", http.StatusNotFound)
+			return
+		}
+		writeError(w, "failed to get season", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, seasonToResponse(season), http.StatusOK)
+}
+
+// GetSeasonTrades returns the trades of a single season
+// GET /account/seasons/{season}/trades?limit=&offset=
+func (h *SeasonHandler) GetSeasonTrades(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	number, err := strconv.Atoi(chi.URLParam(r, "season"))
+	if err != nil {
+		writeError(w, "invalid season", http.StatusBadRequest)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	trades, err := h.seasonUC.GetSeasonTrades(r.Context(), accountID, number, limit, offset)
+	if err != nil {
+		if errors.Is(err, domain.ErrSeasonNotFound) {
+			writeError(w, "season not found", http.StatusNotFound)
+			return
+		}
+		writeError(w, "failed to get trades", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]TradeResponse, len(trades))
+	for i, t := range trades {
+		response[i] = tradeToResponse(&t)
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+func seasonToResponse(s *domain.AccountSeason) SeasonResponse {
+	resp := SeasonResponse{
+		Season:          s.Season,
+		Current:         s.EndedAt == nil,
+		StartedAt:       s.StartedAt.Format("2006-01-02T15:04:05Z"),
+		StartingBalance: s.StartingBalance.StringFixed(2),
+		FinalBalance:    s.FinalBalance.StringFixed(2),
+		RealizedPnL:     s.Stats.RealizedPnL.StringFixed(2),
+		ROI:             s.ROI().StringFixed(4),
+		Volume:          s.Stats.Volume.StringFixed(2),
+		TotalTrades:     s.Stats.TotalTrades,
+		ClosedTrades:    s.Stats.ClosedTrades,
+		WinningTrades:   s.Stats.WinningTrades,
+		LosingTrades:    s.Stats.LosingTrades,
+		WinRate:         s.Stats.WinRate().StringFixed(4),
+		Liquidations:    s.Stats.Liquidations,
+	}
+	if s.EndedAt != nil {
+		endedAt := s.EndedAt.Format("2006-01-02T15:04:05Z")
+		resp.EndedAt = &endedAt
+	}
+	return resp
+}
@@ -0,0 +1,360 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	signaluc "trading/internal/usecase/signal"
+)
+
+type SignalHandler struct {
+	signalUC *signaluc.UseCase
+}
+
+func NewSignalHandler(signalUC *signaluc.UseCase) *SignalHandler {
+	return &SignalHandler{signalUC: signalUC}
+}
+
+type SignalTemplateRequest struct {
+	Symbol        string  `json:"symbol,omitempty"`
+	Quantity      *string `json:"quantity,omitempty"`
+	MaxQuantity   *string `json:"max_quantity,omitempty"`
+	Leverage      int     `json:"leverage,omitempty"`
+	StopLossPct   *string `json:"stop_loss_pct,omitempty"`
+	TakeProfitPct *string `json:"take_profit_pct,omitempty"`
+}
+
+type CreateSignalStrategyRequest struct {
+	Name     string                `json:"name"`
+	Template SignalTemplateRequest `json:"template"`
+}
+
+type UpdateSignalStrategyRequest struct {
+	Name     *string                `json:"name,omitempty"`
+	Template *SignalTemplateRequest `json:"template,omitempty"`
+	Active   *bool                  `json:"active,omitempty"`
+}
+
+type SignalTemplateResponse struct {
+	Symbol        string  `json:"symbol"`
+	Quantity      *string `json:"quantity"`
+	MaxQuantity   *string `json:"max_quantity"`
+	Leverage      int     `json:"leverage"`
+	StopLossPct   *string `json:"stop_loss_pct"`
+	TakeProfitPct *string `json:"take_profit_pct"`
+}
+
+type SignalStrategyResponse struct {
+	ID        int64                  `json:"id"`
+	Name      string                 `json:"name"`
+	Token     string                 `json:"token,omitempty"` // only returned on creation and rotation
+	URL       string                 `json:"url,omitempty"`   // path to POST signals to, with the token
+	Template  SignalTemplateResponse `json:"template"`
+	Active    bool                   `json:"active"`
+	CreatedAt string                 `json:"created_at"`
+	UpdatedAt string                 `json:"updated_at"`
+}
+
+type SignalResponse struct {
+	ID        int64  `json:"id"`
+	Payload   string `json:"payload"`
+	Action    string `json:"action"`
+	Symbol    string `json:"symbol"`
+	Side      string `json:"side"`
+	Status    string `json:"status"`
+	OrderID   *int64 `json:"order_id"`
+	Quantity  string `json:"quantity"`
+	Price     string `json:"price"`
+	Error     string `json:"error,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ReceiveSignal executes a signal sent by an external alert. The token in the
+// path authenticates it; signals that cannot be executed are logged and
+// answered with 422.
+// POST /signals/{token}
+func (h *SignalHandler) ReceiveSignal(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, domain.MaxSignalPayloadSize))
+	if err != nil {
+		writeError(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	signal, err := h.signalUC.Receive(r.Context(), chi.URLParam(r, "token"), body)
+	if err != nil {
+		writeSignalError(w, err, "failed to receive signal")
+		return
+	}
+
+	status := http.StatusOK
+	if signal.Status == domain.SignalFailed {
+		status = http.StatusUnprocessableEntity
+	}
+	writeJSON(w, signalToResponse(signal), status)
+}
+
+// CreateSignalStrategy adds a strategy; the response carries its token and
+// URL, which are not shown again
+// POST /signals/strategies
+func (h *SignalHandler) CreateSignalStrategy(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
+
+	var req CreateSignalStrategyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	template, ok := parseSignalTemplate(w, req.Template)
+	if !ok {
+		return
+	}
+
+	strategy, err := h.signalUC.Create(r.Context(), signaluc.CreateInput{
+		UserID:    userID,
+		AccountID: accountID,
+		Name:      req.Name,
+		Template:  template,
+	})
+	if err != nil {
+		writeSignalError(w, err, "failed to create signal strategy")
+		return
+	}
+
+	writeJSON(w, signalStrategyToResponse(strategy), http.StatusCreated)
+}
+
+// GetSignalStrategies returns the account's strategies, newest first
+// GET /signals/strategies
+func (h *SignalHandler) GetSignalStrategies(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	strategies, err := h.signalUC.List(r.Context(), accountID)
+	if err != nil {
+		writeError(w, "failed to get signal strategies", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]SignalStrategyResponse, len(strategies))
+	for i := range strategies {
+		response[i] = signalStrategyToResponse(&strategies[i])
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetSignalStrategy returns a strategy
+// GET /signals/strategies/{id}
+func (h *SignalHandler) GetSignalStrategy(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseSignalStrategyID(w, r)
+	if !ok {
+		return
+	}
+
+	strategy, err := h.signalUC.Get(r.Context(), accountID, id)
+	if err != nil {
+		writeSignalError(w, err, "failed to get signal strategy")
+		return
+	}
+
+	writeJSON(w, signalStrategyToResponse(strategy), http.StatusOK)
+}
+
+// UpdateSignalStrategy renames, pauses or resumes a strategy, or replaces its template
+// PATCH /signals/strategies/{id}
+func (h *SignalHandler) UpdateSignalStrategy(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseSignalStrategyID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateSignalStrategyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := signaluc.UpdateInput{Name: req.Name, Active: req.Active}
+	if req.Template != nil {
+		template, ok := parseSignalTemplate(w, *req.Template)
+		if !ok {
+			return
+		}
+		input.Template = &template
+	}
+
+	strategy, err := h.signalUC.Update(r.Context(), accountID, id, input)
+	if err != nil {
+		writeSignalError(w, err, "failed to update signal strategy")
+		return
+	}
+
+	writeJSON(w, signalStrategyToResponse(strategy), http.StatusOK)
+}
+
+// DeleteSignalStrategy removes a strategy and its signal log
+// DELETE /signals/strategies/{id}
+func (h *SignalHandler) DeleteSignalStrategy(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseSignalStrategyID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.signalUC.Delete(r.Context(), accountID, id); err != nil {
+		writeSignalError(w, err, "failed to delete signal strategy")
+		return
+	}
+
+	writeJSON(w, map[string]string{"status": "deleted"}, http.StatusOK)
+}
+
+// RotateSignalToken issues a new token; the old URL stops working
+// POST /signals/strategies/{id}/rotate-token
+func (h *SignalHandler) RotateSignalToken(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseSignalStrategyID(w, r)
+	if !ok {
+		return
+	}
+
+	strategy, err := h.signalUC.RotateToken(r.Context(), accountID, id)
+	if err != nil {
+		writeSignalError(w, err, "failed to rotate token")
+		return
+	}
+
+	writeJSON(w, signalStrategyToResponse(strategy), http.StatusOK)
+}
+
+// GetSignals returns the strategy's signal log, newest first
+// GET /signals/strategies/{id}/signals?limit=
+func (h *SignalHandler) GetSignals(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+
+	id, ok := parseSignalStrategyID(w, r)
+	if !ok {
+		return
+	}
+
+	signals, err := h.signalUC.Signals(r.Context(), accountID, id, parseLimitParam(r.URL.Query().Get("limit")))
+	if err != nil {
+		writeSignalError(w, err, "failed to get signals")
+		return
+	}
+
+	response := make([]SignalResponse, len(signals))
+	for i := range signals {
+		response[i] = signalToResponse(&signals[i])
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+func parseSignalStrategyID(w http.ResponseWriter, r *http.Request) (domain.SignalStrategyID, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid signal strategy id", http.StatusBadRequest)
+		return 0, false
+	}
+	return domain.SignalStrategyID(id), true
+}
+
+// parseSignalTemplate parses the template's decimal fields
+func parseSignalTemplate(w http.ResponseWriter, req SignalTemplateRequest) (domain.SignalTemplate, bool) {
+	template := domain.SignalTemplate{Symbol: req.Symbol, Leverage: req.Leverage}
+	fields := []struct {
+		name  string
+		value *string
+		dest  **decimal.Decimal
+	}{
+		{"quantity", req.Quantity, &template.Quantity},
+		{"max_quantity", req.MaxQuantity, &template.MaxQuantity},
+		{"stop_loss_pct", req.StopLossPct, &template.StopLossPct},
+		{"take_profit_pct", req.TakeProfitPct, &template.TakeProfitPct},
+	}
+	for _, f := range fields {
+		if f.value == nil {
+			continue
+		}
+		d, err := decimal.NewFromString(*f.value)
+		if err != nil {
+			writeError(w, "invalid "+f.name, http.StatusBadRequest)
+			return template, false
+		}
+		*f.dest = &d
+	}
+	return template, true
+}
+
+func writeSignalError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSignalStrategy),
+		errors.Is(err, domain.ErrSymbolNotSupported):
+		writeError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrSignalStrategyNotFound):
+		writeError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrTooManySignalStrategies):
+		writeError(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		writeError(w, fallback, http.StatusInternalServerError)
+	}
+}
+
+func signalStrategyToResponse(s *domain.SignalStrategy) SignalStrategyResponse {
+	response := SignalStrategyResponse{
+		ID:    int64(s.ID),
+		Name:  s.Name,
+		Token: s.Token,
+		Template: SignalTemplateResponse{
+			Symbol:        s.Template.Symbol,
+			Quantity:      optionalDecimal(s.Template.Quantity),
+			MaxQuantity:   optionalDecimal(s.Template.MaxQuantity),
+			Leverage:      s.Template.Leverage,
+			StopLossPct:   optionalDecimal(s.Template.StopLossPct),
+			TakeProfitPct: optionalDecimal(s.Template.TakeProfitPct),
+		},
+		Active:    s.Active,
+		CreatedAt: s.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: s.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if s.Token != "" {
+		response.URL = "/signals/" + s.Token
+	}
+	return response
+}
+
+func signalToResponse(s *domain.Signal) SignalResponse {
+	response := SignalResponse{
+		ID:        int64(s.ID),
+		Payload:   s.Payload,
+		Action:    string(s.Action),
+		Symbol:    s.Symbol,
+		Side:      string(s.Side),
+		Status:    string(s.Status),
+		Quantity:  s.Quantity.String(),
+		Price:     s.Price.String(),
+		Error:     s.Error,
+		CreatedAt: s.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if s.OrderID != nil {
+		orderID := int64(*s.OrderID)
+		response.OrderID = &orderID
+	}
+	return response
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	statsuc "trading/internal/usecase/stats"
+)
+
+type StatsHandler struct {
+	statsUC *statsuc.UseCase
+}
+
+func NewStatsHandler(statsUC *statsuc.UseCase) *StatsHandler {
+	return &StatsHandler{statsUC: statsUC}
+}
+
+type PerformanceResponse struct {
+	Positions         int     `json:"positions"`
+	Wins              int     `json:"wins"`
+	Losses            int     `json:"losses"`
+	Liquidations      int     `json:"liquidations"`
+	WinRate           string  `json:"win_rate"`
+	NetPnL            string  `json:"net_pnl"`
+	GrossProfit       string  `json:"gross_profit"`
+	GrossLoss         string  `json:"gross_loss"`
+	Fees              string  `json:"fees"`
+	ProfitFactor      *string `json:"profit_factor"`
+	AvgWin            string  `json:"avg_win"`
+	AvgLoss           string  `json:"avg_loss"`
+	LargestWin        string  `json:"largest_win"`
+	LargestLoss       string  `json:"largest_loss"`
+	Expectancy        string  `json:"expectancy"`
+	AvgHoldingTimeSec int64   `json:"avg_holding_time_sec"`
+}
+
+type SymbolStatsResponse struct {
+	Symbol string `json:"symbol"`
+	PerformanceResponse
+}
+
+type SideStatsResponse struct {
+	Side string `json:"side"`
+	PerformanceResponse
+}
+
+type StatsResponse struct {
+	From *string `json:"from,omitempty"`
+	To   *string `json:"to,omitempty"`
+	PerformanceResponse
+	MaxDrawdown        string                `json:"max_drawdown"`
+	MaxDrawdownPercent string                `json:"max_drawdown_percent"`
+	Sharpe             *string               `json:"sharpe"`
+	Sortino            *string               `json:"sortino"`
+	LongestWinStreak   int                   `json:"longest_win_streak"`
+	LongestLossStreak  int                   `json:"longest_loss_streak"`
+	BySymbol           []SymbolStatsResponse `json:"by_symbol"`
+	BySide             []SideStatsResponse   `json:"by_side"`
+}
+
+// GetStats returns trading performance statistics over closed positions
+// GET /account/stats?from=&to=
+func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	accountID := middleware.GetAccountID(r.Context())
+	q := r.URL.Query()
+
+	var input statsuc.Input
+	var err error
+	if input.From, err = parseTimeParam(q.Get("from")); err != nil {
+		writeError(w, "invalid from", http.StatusBadRequest)
+		return
+	}
+	if input.To, err = parseTimeParam(q.Get("to")); err != nil {
+		writeError(w, "invalid to", http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.statsUC.GetStats(r.Context(), accountID, input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTimeRange) {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeError(w, "failed to get stats", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, statsToResponse(stats), http.StatusOK)
+}
+
+func statsToResponse(stats *statsuc.Stats) StatsResponse {
+	response := StatsResponse{
+		PerformanceResponse: performanceToResponse(stats.Performance),
+		MaxDrawdown:         stats.MaxDrawdown.StringFixed(2),
+		MaxDrawdownPercent:  stats.MaxDrawdownPercent.StringFixed(4),
+		Sharpe:              ratioToResponse(stats.Sharpe),
+		Sortino:             ratioToResponse(stats.Sortino),
+		LongestWinStreak:    stats.LongestWinStreak,
+		LongestLossStreak:   stats.LongestLossStreak,
+		BySymbol:            make([]SymbolStatsResponse, len(stats.BySymbol)),
+		BySide:              make([]SideStatsResponse, len(stats.BySide)),
+	}
+	if stats.From != nil {
+		from := stats.From.UTC().Format("2006-01-02T15:04:05Z")
+		response.From = &from
+	}
+	if stats.To != nil {
+		to := stats.To.UTC().Format("2006-01-02T15:04:05Z")
+		response.To = &to
+	}
+	for i, b := range stats.BySymbol {
+		response.BySymbol[i] = SymbolStatsResponse{Symbol: b.Key, PerformanceResponse: performanceToResponse(b.Performance)}
+	}
+	for i, b := range stats.BySide {
+		response.BySide[i] = SideStatsResponse{Side: b.Key, PerformanceResponse: performanceToResponse(b.Performance)}
+	}
+	return response
+}
+
+func performanceToResponse(p statsuc.Performance) PerformanceResponse {
+	return PerformanceResponse{
+		Positions:         p.Positions,
+		Wins:              p.Wins,
+		Losses:            p.Losses,
+		Liquidations:      p.Liquidations,
+		WinRate:           p.WinRate.StringFixed(4),
+		NetPnL:            p.NetPnL.StringFixed(2),
+		GrossProfit:       p.GrossProfit.StringFixed(2),
+		GrossLoss:         p.GrossLoss.StringFixed(2),
+		Fees:              p.Fees.StringFixed(2),
+		ProfitFactor:      ratioToResponse(p.ProfitFactor),
+		AvgWin:            p.AvgWin.StringFixed(2),
+		AvgLoss:           p.AvgLoss.StringFixed(2),
+		LargestWin:        p.LargestWin.StringFixed(2),
+		LargestLoss:       p.LargestLoss.StringFixed(2),
+		Expectancy:        p.Expectancy.StringFixed(2),
+		AvgHoldingTimeSec: int64(p.AvgHoldingTime.Seconds()),
+	}
+}
+
+// ratioToResponse formats an optional ratio; nil stays null
+func ratioToResponse(v *decimal.Decimal) *string {
+	if v == nil {
+		return nil
+	}
+	s := v.StringFixed(4)
+	return &s
+}
@@ -18,7 +18,7 @@ func NewTradeHandler(tradeRepo domain.TradeRepository) *TradeHandler {
 
 type TradeResponse struct {
 	ID         int64  `json:"id"`
-	PositionID int64  `json:"position_id"`
+	PositionID int64  `json:"position_id,omitempty"` // absent for spot trades
 	OrderID    int64  `json:"order_id"`
 	Symbol     string `json:"symbol"`
 	Side       string `json:"side"`
@@ -30,41 +30,72 @@ type TradeResponse struct {
 	CreatedAt  string `json:"created_at"`
 }
 
+// GetTrades returns trades newest first. Pass the X-Next-Cursor response header
+// back as cursor to get the next page; offset is still accepted.
+// GET /trades?symbol=&side=&type=&from=&to=&cursor=&limit=&offset=
 func (h *TradeHandler) GetTrades(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetUserID(r.Context())
+	accountID := middleware.GetAccountID(r.Context())
+	q := r.URL.Query()
 
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-
-	if limit <= 0 {
-		limit = 50
+	filter := domain.TradeFilter{
+		Symbol: q.Get("symbol"),
+		Side:   domain.PositionSide(q.Get("side")),
+		Type:   domain.TradeType(q.Get("type")),
+	}
+	filter.Offset, _ = strconv.Atoi(q.Get("offset"))
+	var err error
+	if filter.From, err = parseTimeParam(q.Get("from")); err != nil {
+		writeError(w, "invalid from", http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = parseTimeParam(q.Get("to")); err != nil {
+		writeError(w, "invalid to", http.StatusBadRequest)
+		return
+	}
+	if filter.Cursor, err = parseCursorParam(q.Get("cursor")); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-	if limit > 100 {
-		limit = 100
+	if err := filter.Validate(); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	trades, err := h.tradeRepo.GetByUserID(r.Context(), userID, limit, offset)
+	// One extra row tells whether there is a next page
+	limit := parseLimitParam(q.Get("limit"))
+	filter.Limit = limit + 1
+	trades, err := h.tradeRepo.GetByAccountID(r.Context(), accountID, filter)
 	if err != nil {
 		writeError(w, "failed to get trades", http.StatusInternalServerError)
 		return
 	}
+	if len(trades) > limit {
+		trades = trades[:limit]
+		last := trades[limit-1]
+		next := domain.Cursor{Time: last.CreatedAt, ID: int64(last.ID)}
+		w.Header().Set(nextCursorHeader, next.Encode())
+	}
 
 	response := make([]TradeResponse, len(trades))
 	for i, t := range trades {
-		response[i] = TradeResponse{
-			ID:         int64(t.ID),
-			PositionID: int64(t.PositionID),
-			OrderID:    int64(t.OrderID),
-			Symbol:     t.Symbol,
-			Side:       string(t.Side),
-			Type:       string(t.Type),
-			Quantity:   t.Quantity.String(),
-			Price:      t.Price.String(),
-			PnL:        t.PnL.String(),
-			Fee:        t.Fee.String(),
-			CreatedAt:  t.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		}
+		response[i] = tradeToResponse(&t)
 	}
 
 	writeJSON(w, response, http.StatusOK)
 }
+
+func tradeToResponse(t *domain.Trade) TradeResponse {
+	return TradeResponse{
+		ID:         int64(t.ID),
+		PositionID: int64(t.PositionID),
+		OrderID:    int64(t.OrderID),
+		Symbol:     t.Symbol,
+		Side:       string(t.Side),
+		Type:       string(t.Type),
+		Quantity:   t.Quantity.String(),
+		Price:      t.Price.String(),
+		PnL:        t.PnL.String(),
+		Fee:        t.Fee.String(),
+		CreatedAt:  t.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"trading/internal/delivery/http/middleware"
+	"trading/internal/domain"
+	webhookuc "trading/internal/usecase/webhook"
+)
+
+type WebhookHandler struct {
+	webhookUC *webhookuc.UseCase
+}
+
+func NewWebhookHandler(webhookUC *webhookuc.UseCase) *WebhookHandler {
+	return &WebhookHandler{webhookUC: webhookUC}
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"` // empty = all events
+}
+
+type UpdateWebhookRequest struct {
+	URL    *string  `json:"url"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+type WebhookResponse struct {
+	ID        int64    `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	Secret    string   `json:"secret,omitempty"` // only returned on creation
+	CreatedAt string   `json:"created_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	Event          string          `json:"event"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *string         `json:"next_attempt_at,omitempty"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	RedeliveryOf   *int64          `json:"redelivery_of,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      string          `json:"created_at"`
+	DeliveredAt    *string         `json:"delivered_at,omitempty"`
+}
+
+// CreateWebhook subscribes an HTTP endpoint to account events
+// POST /webhooks
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	var req CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.webhookUC.CreateWebhook(r.Context(), webhookuc.CreateWebhookInput{
+		UserID: userID,
+		URL:    req.URL,
+		Events: toEventTypes(req.Events),
+	})
+	if err != nil {
+		h.writeWebhookError(w, err)
+		return
+	}
+
+	resp := webhookToResponse(webhook)
+	resp.Secret = webhook.Secret
+	writeJSON(w, resp, http.StatusCreated)
+}
+
+// GetWebhooks returns the user's webhooks
+// GET /webhooks
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	webhooks, err := h.webhookUC.GetWebhooks(r.Context(), userID)
+	if err != nil {
+		writeError(w, "failed to get webhooks", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]WebhookResponse, len(webhooks))
+	for i, wh := range webhooks {
+		response[i] = webhookToResponse(&wh)
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// GetWebhook returns a single webhook
+// GET /webhooks/{id}
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid webhook id", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.webhookUC.GetWebhook(r.Context(), userID, domain.WebhookID(webhookID))
+	if err != nil {
+		h.writeWebhookError(w, err)
+		return
+	}
+
+	writeJSON(w, webhookToResponse(webhook), http.StatusOK)
+}
+
+// UpdateWebhook changes the URL, event filter or active flag
+// PATCH /webhooks/{id}
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid webhook id", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := webhookuc.UpdateWebhookInput{
+		URL:    req.URL,
+		Active: req.Active,
+	}
+	if req.Events != nil {
+		input.Events = toEventTypes(req.Events)
+	}
+
+	webhook, err := h.webhookUC.UpdateWebhook(r.Context(), userID, domain.WebhookID(webhookID), input)
+	if err != nil {
+		h.writeWebhookError(w, err)
+		return
+	}
+
+	writeJSON(w, webhookToResponse(webhook), http.StatusOK)
+}
+
+// DeleteWebhook removes a webhook and its delivery log
+// DELETE /webhooks/{id}
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid webhook id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.webhookUC.DeleteWebhook(r.Context(), userID, domain.WebhookID(webhookID)); err != nil {
+		h.writeWebhookError(w, err)
+		return
+	}
+
+	writeJSON(w, map[string]string{"status": "deleted"}, http.StatusOK)
+}
+
+// GetDeliveries returns the webhook's delivery log, newest first
+// GET /webhooks/{id}/deliveries?limit=&offset=
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid webhook id", http.StatusBadRequest)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	deliveries, err := h.webhookUC.GetDeliveries(r.Context(), userID, domain.WebhookID(webhookID), limit, offset)
+	if err != nil {
+		h.writeWebhookError(w, err)
+		return
+	}
+
+	response := make([]WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		response[i] = webhookDeliveryToResponse(&d)
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
+
+// Redeliver queues a delivery again with its original payload
+// POST /webhooks/{id}/deliveries/{deliveryID}/redeliver
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+
+	webhookID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid webhook id", http.StatusBadRequest)
+		return
+	}
+	deliveryID, err := strconv.ParseInt(chi.URLParam(r, "deliveryID"), 10, 64)
+	if err != nil {
+		writeError(w, "invalid delivery id", http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := h.webhookUC.Redeliver(r.Context(), userID, domain.WebhookID(webhookID), domain.WebhookDeliveryID(deliveryID))
+	if err != nil {
+		h.writeWebhookError(w, err)
+		return
+	}
+
+	writeJSON(w, webhookDeliveryToResponse(delivery), http.StatusAccepted)
+}
+
+func (h *WebhookHandler) writeWebhookError(w http.ResponseWriter, err error) {
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		writeError(w, "webhook not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, domain.ErrWebhookDeliveryNotFound) {
+		writeError(w, "delivery not found", http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidWebhook) {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, domain.ErrTooManyWebhooks) {
+		writeError(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	writeError(w, "failed to process webhook", http.StatusInternalServerError)
+}
+
+func toEventTypes(events []string) []domain.EventType {
+	result := make([]domain.EventType, len(events))
+	for i, e := range events {
+		result[i] = domain.EventType(e)
+	}
+	return result
+}
+
+func webhookToResponse(wh *domain.Webhook) WebhookResponse {
+	events := make([]string, len(wh.Events))
+	for i, e := range wh.Events {
+		events[i] = string(e)
+	}
+	return WebhookResponse{
+		ID:        int64(wh.ID),
+		URL:       wh.URL,
+		Events:    events,
+		Active:    wh.Active,
+		CreatedAt: wh.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func webhookDeliveryToResponse(d *domain.WebhookDelivery) WebhookDeliveryResponse {
+	resp := WebhookDeliveryResponse{
+		ID:             int64(d.ID),
+		Event:          string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		Payload:        json.RawMessage(d.Payload),
+		CreatedAt:      d.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if d.NextAttemptAt != nil {
+		s := d.NextAttemptAt.Format("2006-01-02T15:04:05Z")
+		resp.NextAttemptAt = &s
+	}
+	if d.RedeliveryOf != nil {
+		id := int64(*d.RedeliveryOf)
+		resp.RedeliveryOf = &id
+	}
+	if d.DeliveredAt != nil {
+		s := d.DeliveredAt.Format("2006-01-02T15:04:05Z")
+		resp.DeliveredAt = &s
+	}
+	return resp
+}
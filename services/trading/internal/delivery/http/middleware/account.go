@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"trading/internal/domain"
+)
+
+const AccountIDKey contextKey = "account_id"
+
+// AccountHeader selects the sub-account a request operates on
+const AccountHeader = "X-Account-ID"
+
+type AccountMiddleware struct {
+	accountRepo domain.AccountRepository
+}
+
+func NewAccountMiddleware(accountRepo domain.AccountRepository) *AccountMiddleware {
+	return &AccountMiddleware{accountRepo: accountRepo}
+}
+
+// Resolve puts the account selected by the X-Account-ID header into the context.
+// Without the header the user's primary account is used. Must run after Authenticate.
+func (m *AccountMiddleware) Resolve(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := GetUserID(r.Context())
+
+		var account *domain.Account
+		var err error
+		if header := r.Header.Get(AccountHeader); header != "" {
+			id, parseErr := strconv.ParseInt(header, 10, 64)
+			if parseErr != nil {
+				http.Error(w, `{"error":"invalid account id"}`, http.StatusBadRequest)
+				return
+			}
+			account, err = m.accountRepo.GetByID(r.Context(), domain.AccountID(id))
+			if err == nil && account.UserID != userID {
+				err = domain.ErrAccountNotFound
+			}
+		} else {
+			account, err = m.accountRepo.GetPrimaryByUserID(r.Context(), userID)
+		}
+
+		if err != nil {
+			if errors.Is(err, domain.ErrAccountNotFound) {
+				http.Error(w, `{"error":"account not found"}`, http.StatusNotFound)
+				return
+			}
+			http.Error(w, `{"error":"failed to resolve account"}`, http.StatusInternalServerError)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), AccountIDKey, account.ID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func GetAccountID(ctx context.Context) domain.AccountID {
+	accountID, _ := ctx.Value(AccountIDKey).(domain.AccountID)
+	return accountID
+}
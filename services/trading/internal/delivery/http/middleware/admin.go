@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+
+	"trading/internal/domain"
+)
+
+type AdminMiddleware struct {
+	userRepo domain.UserRepository
+}
+
+func NewAdminMiddleware(userRepo domain.UserRepository) *AdminMiddleware {
+	return &AdminMiddleware{userRepo: userRepo}
+}
+
+// RequireAdmin rejects requests from users without the admin flag. Must run after Authenticate.
+func (m *AdminMiddleware) RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := m.userRepo.GetByID(r.Context(), GetUserID(r.Context()))
+		if err != nil {
+			http.Error(w, `{"error":"failed to load user"}`, http.StatusInternalServerError)
+			return
+		}
+		if !user.IsAdmin {
+			http.Error(w, `{"error":"admin access required"}`, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
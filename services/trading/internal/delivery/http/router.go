@@ -17,19 +17,39 @@ type Router struct {
 }
 
 type RouterDeps struct {
-	AuthMiddleware   *middleware.AuthMiddleware
-	AuthHandler      *handler.AuthHandler
-	AccountHandler   *handler.AccountHandler
-	OrderHandler     *handler.OrderHandler
-	PositionHandler  *handler.PositionHandler
-	TradeHandler     *handler.TradeHandler
-	UserHandler      *handler.UserHandler
-	PriceHandler     *handler.PriceHandler
-	CandleHandler    *handler.CandleHandler
-	TickerHandler    *handler.TickerHandler
-	WebSocketHandler *handler.WebSocketHandler
-	UserRepo         domain.UserRepository
-	HealthChecker    func() error
+	AuthMiddleware      *middleware.AuthMiddleware
+	AccountMiddleware   *middleware.AccountMiddleware
+	AdminMiddleware     *middleware.AdminMiddleware
+	AuthHandler         *handler.AuthHandler
+	AccountHandler      *handler.AccountHandler
+	OrderHandler        *handler.OrderHandler
+	PositionHandler     *handler.PositionHandler
+	TradeHandler        *handler.TradeHandler
+	ExportHandler       *handler.ExportHandler
+	SeasonHandler       *handler.SeasonHandler
+	EquityHandler       *handler.EquityHandler
+	StatsHandler        *handler.StatsHandler
+	ReportHandler       *handler.ReportHandler
+	LeaderboardHandler  *handler.LeaderboardHandler
+	CompetitionHandler  *handler.CompetitionHandler
+	ChallengeHandler    *handler.ChallengeHandler
+	BacktestHandler     *handler.BacktestHandler
+	GridBotHandler      *handler.GridBotHandler
+	DCAHandler          *handler.DCAHandler
+	AlgoOrderHandler    *handler.AlgoOrderHandler
+	CopyTradingHandler  *handler.CopyTradingHandler
+	ScriptHandler       *handler.ScriptHandler
+	SignalHandler       *handler.SignalHandler
+	UserHandler         *handler.UserHandler
+	AlertHandler        *handler.AlertHandler
+	NotificationHandler *handler.NotificationHandler
+	WebhookHandler      *handler.WebhookHandler
+	PriceHandler        *handler.PriceHandler
+	CandleHandler       *handler.CandleHandler
+	TickerHandler       *handler.TickerHandler
+	WebSocketHandler    *handler.WebSocketHandler
+	UserRepo            domain.UserRepository
+	HealthChecker       func() error
 }
 
 func NewRouter(deps RouterDeps) *Router {
@@ -63,6 +83,21 @@ func NewRouter(deps RouterDeps) *Router {
 	if deps.TickerHandler != nil {
 		r.Get("/ticker24h", deps.TickerHandler.GetTicker24h)
 	}
+	if deps.LeaderboardHandler != nil {
+		r.Get("/leaderboard", deps.LeaderboardHandler.GetLeaderboard)
+	}
+	if deps.CopyTradingHandler != nil {
+		r.Get("/copy/leaders/{name}", deps.CopyTradingHandler.GetLeader)
+	}
+	if deps.SignalHandler != nil {
+		// Authenticated by the secret token in the path
+		r.Post("/signals/{token}", deps.SignalHandler.ReceiveSignal)
+	}
+	if deps.CompetitionHandler != nil {
+		r.Get("/competitions", deps.CompetitionHandler.GetCompetitions)
+		r.Get("/competitions/{id}", deps.CompetitionHandler.GetCompetition)
+		r.Get("/competitions/{id}/standings", deps.CompetitionHandler.GetStandings)
+	}
 
 	// Auth endpoints (no auth)
 	r.Post("/auth/register", deps.AuthHandler.Register)
@@ -80,24 +115,186 @@ func NewRouter(deps RouterDeps) *Router {
 			r.Get("/user/me", deps.UserHandler.GetMe)
 		}
 
-		// Account
-		r.Get("/account", deps.AccountHandler.GetAccount)
+		// Leaderboard profile
+		if deps.LeaderboardHandler != nil {
+			r.Get("/leaderboard/profile", deps.LeaderboardHandler.GetProfile)
+			r.Put("/leaderboard/profile", deps.LeaderboardHandler.UpdateProfile)
+		}
+
+		// Competitions
+		if deps.CompetitionHandler != nil {
+			r.Post("/competitions/{id}/join", deps.CompetitionHandler.JoinCompetition)
+
+			if deps.AdminMiddleware != nil {
+				r.With(deps.AdminMiddleware.RequireAdmin).Post("/admin/competitions", deps.CompetitionHandler.CreateCompetition)
+			}
+		}
+
+		// Challenges
+		if deps.ChallengeHandler != nil {
+			r.Post("/challenges", deps.ChallengeHandler.CreateChallenge)
+			r.Get("/challenges", deps.ChallengeHandler.GetChallenges)
+			r.Get("/challenges/{id}", deps.ChallengeHandler.GetChallenge)
+		}
+
+		// Backtests
+		if deps.BacktestHandler != nil {
+			r.Post("/backtests", deps.BacktestHandler.SubmitBacktest)
+			r.Get("/backtests", deps.BacktestHandler.GetBacktests)
+			r.Get("/backtests/options", deps.BacktestHandler.GetBacktestOptions)
+			r.Get("/backtests/{id}", deps.BacktestHandler.GetBacktest)
+		}
+
+		// Price alerts
+		if deps.AlertHandler != nil {
+			r.Post("/alerts", deps.AlertHandler.CreateAlert)
+			r.Get("/alerts", deps.AlertHandler.GetAlerts)
+			r.Get("/alerts/{id}", deps.AlertHandler.GetAlert)
+			r.Patch("/alerts/{id}", deps.AlertHandler.UpdateAlert)
+			r.Delete("/alerts/{id}", deps.AlertHandler.DeleteAlert)
+		}
+
+		// Notifications
+		if deps.NotificationHandler != nil {
+			r.Get("/notifications", deps.NotificationHandler.GetNotifications)
+			r.Post("/notifications/read-all", deps.NotificationHandler.MarkAllRead)
+			r.Post("/notifications/{id}/read", deps.NotificationHandler.MarkRead)
+		}
+
+		// Webhooks
+		if deps.WebhookHandler != nil {
+			r.Post("/webhooks", deps.WebhookHandler.CreateWebhook)
+			r.Get("/webhooks", deps.WebhookHandler.GetWebhooks)
+			r.Get("/webhooks/{id}", deps.WebhookHandler.GetWebhook)
+			r.Patch("/webhooks/{id}", deps.WebhookHandler.UpdateWebhook)
+			r.Delete("/webhooks/{id}", deps.WebhookHandler.DeleteWebhook)
+			r.Get("/webhooks/{id}/deliveries", deps.WebhookHandler.GetDeliveries)
+			r.Post("/webhooks/{id}/deliveries/{deliveryID}/redeliver", deps.WebhookHandler.Redeliver)
+		}
+
+		// Accounts (user level)
+		r.Get("/accounts", deps.AccountHandler.ListAccounts)
+		r.Post("/accounts", deps.AccountHandler.CreateAccount)
+		r.Post("/accounts/transfer", deps.AccountHandler.Transfer)
+
+		// Account scoped routes (X-Account-ID header, primary account by default)
+		r.Group(func(r chi.Router) {
+			r.Use(deps.AccountMiddleware.Resolve)
+
+			// Account
+			r.Get("/account", deps.AccountHandler.GetAccount)
+			r.Get("/account/ledger", deps.AccountHandler.GetLedger)
+			r.Post("/account/convert", deps.AccountHandler.Convert)
+
+			// Seasons
+			if deps.SeasonHandler != nil {
+				r.Post("/account/reset", deps.SeasonHandler.Reset)
+				r.Get("/account/seasons", deps.SeasonHandler.GetSeasons)
+				r.Get("/account/seasons/{season}", deps.SeasonHandler.GetSeason)
+				r.Get("/account/seasons/{season}/trades", deps.SeasonHandler.GetSeasonTrades)
+			}
+
+			// Equity curve
+			if deps.EquityHandler != nil {
+				r.Get("/account/equity", deps.EquityHandler.GetEquity)
+			}
+
+			// Performance statistics
+			if deps.StatsHandler != nil {
+				r.Get("/account/stats", deps.StatsHandler.GetStats)
+			}
+
+			// Realized PnL statement
+			if deps.ReportHandler != nil {
+				r.Get("/account/reports/pnl", deps.ReportHandler.GetRealizedPnL)
+			}
+
+			// Orders
+			r.Post("/orders", deps.OrderHandler.PlaceOrder)
+			r.Get("/orders", deps.OrderHandler.GetOrders)
+			r.Get("/orders/{id}", deps.OrderHandler.GetOrder)
+			r.Patch("/orders/{id}", deps.OrderHandler.UpdateOrder)
+			r.Delete("/orders/{id}", deps.OrderHandler.CancelOrder)
+
+			// Algo orders
+			if deps.AlgoOrderHandler != nil {
+				r.Post("/orders/algo", deps.AlgoOrderHandler.CreateAlgoOrder)
+				r.Get("/orders/algo/{id}", deps.AlgoOrderHandler.GetAlgoOrder)
+				r.Post("/orders/algo/{id}/cancel", deps.AlgoOrderHandler.CancelAlgoOrder)
+			}
+
+			// Spot holdings
+			r.Get("/spot/lots", deps.OrderHandler.GetSpotLots)
+
+			// Positions
+			r.Get("/positions", deps.PositionHandler.GetPositions)
+			r.Get("/positions/history", deps.PositionHandler.GetPositionHistory)
+			r.Get("/positions/{id}", deps.PositionHandler.GetPosition)
+			r.Post("/positions/{id}/close", deps.PositionHandler.ClosePosition)
+			r.Patch("/positions/{id}", deps.PositionHandler.UpdateTPSL)
+
+			// Trades
+			r.Get("/trades", deps.TradeHandler.GetTrades)
+
+			// History export
+			if deps.ExportHandler != nil {
+				r.Get("/export/{kind}", deps.ExportHandler.Export)
+			}
+
+			// Grid bots
+			if deps.GridBotHandler != nil {
+				r.Post("/bots/grid", deps.GridBotHandler.CreateGridBot)
+				r.Get("/bots/grid", deps.GridBotHandler.GetGridBots)
+				r.Get("/bots/grid/{id}", deps.GridBotHandler.GetGridBot)
+				r.Post("/bots/grid/{id}/pause", deps.GridBotHandler.PauseGridBot)
+				r.Post("/bots/grid/{id}/resume", deps.GridBotHandler.ResumeGridBot)
+				r.Post("/bots/grid/{id}/stop", deps.GridBotHandler.StopGridBot)
+			}
+
+			// DCA plans
+			if deps.DCAHandler != nil {
+				r.Post("/bots/dca", deps.DCAHandler.CreateDCAPlan)
+				r.Get("/bots/dca", deps.DCAHandler.GetDCAPlans)
+				r.Get("/bots/dca/{id}", deps.DCAHandler.GetDCAPlan)
+				r.Get("/bots/dca/{id}/executions", deps.DCAHandler.GetDCAExecutions)
+				r.Post("/bots/dca/{id}/pause", deps.DCAHandler.PauseDCAPlan)
+				r.Post("/bots/dca/{id}/resume", deps.DCAHandler.ResumeDCAPlan)
+				r.Post("/bots/dca/{id}/cancel", deps.DCAHandler.CancelDCAPlan)
+			}
 
-		// Orders
-		r.Post("/orders", deps.OrderHandler.PlaceOrder)
-		r.Get("/orders", deps.OrderHandler.GetOrders)
-		r.Get("/orders/{id}", deps.OrderHandler.GetOrder)
-		r.Patch("/orders/{id}", deps.OrderHandler.UpdateOrder)
-		r.Delete("/orders/{id}", deps.OrderHandler.CancelOrder)
+			// Copy trading
+			if deps.CopyTradingHandler != nil {
+				r.Post("/copy/follows", deps.CopyTradingHandler.Follow)
+				r.Get("/copy/follows", deps.CopyTradingHandler.GetFollows)
+				r.Get("/copy/follows/{id}", deps.CopyTradingHandler.GetFollow)
+				r.Get("/copy/follows/{id}/trades", deps.CopyTradingHandler.GetCopyTrades)
+				r.Post("/copy/follows/{id}/unfollow", deps.CopyTradingHandler.Unfollow)
+			}
 
-		// Positions
-		r.Get("/positions", deps.PositionHandler.GetPositions)
-		r.Get("/positions/{id}", deps.PositionHandler.GetPosition)
-		r.Post("/positions/{id}/close", deps.PositionHandler.ClosePosition)
-		r.Patch("/positions/{id}", deps.PositionHandler.UpdateTPSL)
+			// Strategy scripts
+			if deps.ScriptHandler != nil {
+				r.Post("/scripts", deps.ScriptHandler.CreateScript)
+				r.Get("/scripts", deps.ScriptHandler.GetScripts)
+				r.Get("/scripts/{id}", deps.ScriptHandler.GetScript)
+				r.Put("/scripts/{id}", deps.ScriptHandler.UpdateScript)
+				r.Delete("/scripts/{id}", deps.ScriptHandler.DeleteScript)
+				r.Post("/scripts/{id}/start", deps.ScriptHandler.StartScript)
+				r.Post("/scripts/{id}/stop", deps.ScriptHandler.StopScript)
+				r.Get("/scripts/{id}/logs", deps.ScriptHandler.GetScriptLogs)
+				r.Post("/scripts/{id}/backtest", deps.ScriptHandler.BacktestScript)
+			}
 
-		// Trades
-		r.Get("/trades", deps.TradeHandler.GetTrades)
+			// Signal webhooks
+			if deps.SignalHandler != nil {
+				r.Post("/signals/strategies", deps.SignalHandler.CreateSignalStrategy)
+				r.Get("/signals/strategies", deps.SignalHandler.GetSignalStrategies)
+				r.Get("/signals/strategies/{id}", deps.SignalHandler.GetSignalStrategy)
+				r.Patch("/signals/strategies/{id}", deps.SignalHandler.UpdateSignalStrategy)
+				r.Delete("/signals/strategies/{id}", deps.SignalHandler.DeleteSignalStrategy)
+				r.Post("/signals/strategies/{id}/rotate-token", deps.SignalHandler.RotateSignalToken)
+				r.Get("/signals/strategies/{id}/signals", deps.SignalHandler.GetSignals)
+			}
+		})
 	})
 
 	return &Router{r}
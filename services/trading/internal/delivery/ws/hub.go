@@ -17,6 +17,8 @@ const (
 	MessageTypePosition      MessageType = "position"
 	MessageTypePositionClose MessageType = "position_close"
 	MessageTypeTrade         MessageType = "trade"
+	MessageTypeAlert         MessageType = "alert"
+	MessageTypeChallenge     MessageType = "challenge"
 	MessageTypeError         MessageType = "error"
 	MessageTypePing          MessageType = "ping"
 	MessageTypePong          MessageType = "pong"
@@ -40,6 +42,7 @@ type PriceUpdate struct {
 // PositionUpdate represents a position update message
 type PositionUpdate struct {
 	ID            int64  `json:"id"`
+	AccountID     int64  `json:"account_id"`
 	Symbol        string `json:"symbol"`
 	Side          string `json:"side"`
 	Quantity      string `json:"quantity"`
@@ -49,6 +52,46 @@ type PositionUpdate struct {
 	Leverage      int    `json:"leverage"`
 }
 
+// NewPositionUpdate builds the position message data
+func NewPositionUpdate(position *domain.Position) PositionUpdate {
+	return PositionUpdate{
+		ID:            int64(position.ID),
+		AccountID:     int64(position.AccountID),
+		Symbol:        position.Symbol,
+		Side:          string(position.Side),
+		Quantity:      position.Quantity.String(),
+		EntryPrice:    position.EntryPrice.String(),
+		MarkPrice:     position.MarkPrice.String(),
+		UnrealizedPnL: position.UnrealizedPnL.String(),
+		Leverage:      position.Leverage,
+	}
+}
+
+// AlertNotification represents a triggered price alert message
+type AlertNotification struct {
+	NotificationID int64  `json:"notification_id"`
+	AlertID        int64  `json:"alert_id"`
+	Title          string `json:"title"`
+	Message        string `json:"message,omitempty"`
+}
+
+// ChallengeUpdate represents a challenge progress message
+type ChallengeUpdate struct {
+	ChallengeID int64                 `json:"challenge_id"`
+	AccountID   int64                 `json:"account_id"`
+	Status      string                `json:"status"`
+	Equity      string                `json:"equity"`
+	Rules       []ChallengeRuleUpdate `json:"rules"`
+}
+
+// ChallengeRuleUpdate represents the state of one challenge rule
+type ChallengeRuleUpdate struct {
+	Rule   string `json:"rule"`
+	Value  string `json:"value"`
+	Limit  string `json:"limit"`
+	Status string `json:"status"`
+}
+
 // Hub maintains the set of active clients and broadcasts messages
 type Hub struct {
 	// Registered clients by user ID
@@ -182,20 +225,9 @@ func (h *Hub) BroadcastPrices(prices map[string]*domain.Price) {
 
 // BroadcastPositionUpdate broadcasts position update to specific user
 func (h *Hub) BroadcastPositionUpdate(userID domain.UserID, position *domain.Position) {
-	update := PositionUpdate{
-		ID:            int64(position.ID),
-		Symbol:        position.Symbol,
-		Side:          string(position.Side),
-		Quantity:      position.Quantity.String(),
-		EntryPrice:    position.EntryPrice.String(),
-		MarkPrice:     position.MarkPrice.String(),
-		UnrealizedPnL: position.UnrealizedPnL.String(),
-		Leverage:      position.Leverage,
-	}
-
 	msg := Message{
 		Type:      MessageTypePosition,
-		Data:      update,
+		Data:      NewPositionUpdate(position),
 		Timestamp: time.Now(),
 	}
 
@@ -213,10 +245,11 @@ func (h *Hub) BroadcastPositionUpdate(userID domain.UserID, position *domain.Pos
 }
 
 // BroadcastPositionClose broadcasts position close event to specific user
-func (h *Hub) BroadcastPositionClose(userID domain.UserID, positionID domain.PositionID, pnl string) {
+func (h *Hub) BroadcastPositionClose(userID domain.UserID, accountID domain.AccountID, positionID domain.PositionID, pnl string) {
 	msg := Message{
 		Type: MessageTypePositionClose,
 		Data: map[string]interface{}{
+			"account_id":   int64(accountID),
 			"position_id":  int64(positionID),
 			"realized_pnl": pnl,
 		},
@@ -236,6 +269,73 @@ func (h *Hub) BroadcastPositionClose(userID domain.UserID, positionID domain.Pos
 	}
 }
 
+// BroadcastAlert sends a triggered price alert to specific user
+func (h *Hub) BroadcastAlert(userID domain.UserID, notification *domain.Notification) {
+	alert := AlertNotification{
+		NotificationID: int64(notification.ID),
+		Title:          notification.Title,
+		Message:        notification.Message,
+	}
+	if notification.AlertID != nil {
+		alert.AlertID = int64(*notification.AlertID)
+	}
+
+	msg := Message{
+		Type:      MessageTypeAlert,
+		Data:      alert,
+		Timestamp: time.Now(),
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		logger.Error("failed to marshal alert", "error", err)
+		return
+	}
+
+	select {
+	case h.userBroadcast <- userMessage{userID: userID, message: data}:
+	default:
+		logger.Warn("user broadcast channel full", "user_id", userID)
+	}
+}
+
+// BroadcastChallenge sends challenge progress to specific user
+func (h *Hub) BroadcastChallenge(userID domain.UserID, progress *domain.ChallengeProgress) {
+	update := ChallengeUpdate{
+		ChallengeID: int64(progress.ChallengeID),
+		AccountID:   int64(progress.AccountID),
+		Status:      string(progress.Status),
+		Equity:      progress.Equity.StringFixed(2),
+		Rules:       make([]ChallengeRuleUpdate, len(progress.Rules)),
+	}
+	for i, rule := range progress.Rules {
+		update.Rules[i] = ChallengeRuleUpdate{
+			Rule:   string(rule.Rule),
+			Value:  rule.Value.StringFixed(2),
+			Limit:  rule.Limit.StringFixed(2),
+			Status: rule.Status,
+		}
+	}
+
+	msg := Message{
+		Type:      MessageTypeChallenge,
+		Data:      update,
+		Timestamp: time.Now(),
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		logger.Error("failed to marshal challenge", "error", err)
+		return
+	}
+
+	select {
+	case h.userBroadcast <- userMessage{userID: userID, message: data}:
+	default:
+		logger.Warn("user broadcast channel full", "user_id", userID)
+	}
+}
+
 // ClientCount returns the number of connected clients
 func (h *Hub) ClientCount() int {
 	h.mu.RLock()
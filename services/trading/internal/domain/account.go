@@ -8,26 +8,73 @@ import (
 
 type AccountID int64
 
+// PrimaryAccountName is the name of the account created at registration
+const PrimaryAccountName = "main"
+
 type Account struct {
 	ID        AccountID
 	UserID    UserID
+	Name      string
 	Balance   decimal.Decimal // available balance (USDT)
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// Current season (run) of the account, bumped on every reset
+	Season                int
+	SeasonStartedAt       time.Time
+	SeasonStartingBalance decimal.Decimal
+
+	// Set on accounts dedicated to a competition or challenge; they cannot transfer or reset
+	CompetitionID *CompetitionID
+	ChallengeID   *ChallengeID
+
+	// Locked accounts accept no orders, e.g. after a failed challenge
+	LockedReason string
+}
+
+// IsCompetition reports whether the account belongs to a competition
+func (a *Account) IsCompetition() bool {
+	return a.CompetitionID != nil
+}
+
+// IsIsolated reports whether the account is dedicated to a competition or challenge
+func (a *Account) IsIsolated() bool {
+	return a.CompetitionID != nil || a.ChallengeID != nil
+}
+
+func (a *Account) IsLocked() bool {
+	return a.LockedReason != ""
+}
+
+// CurrentSeason returns the account's running season with the given stats
+func (a *Account) CurrentSeason(stats TradeSummary) AccountSeason {
+	return AccountSeason{
+		AccountID:       a.ID,
+		Season:          a.Season,
+		StartedAt:       a.SeasonStartedAt,
+		StartingBalance: a.SeasonStartingBalance,
+		FinalBalance:    a.Balance,
+		Stats:           stats,
+	}
 }
 
 // AccountSummary contains calculated account metrics
 type AccountSummary struct {
 	Balance       decimal.Decimal // available balance
-	Equity        decimal.Decimal // balance + unrealized PnL
+	Equity        decimal.Decimal // collateral value + unrealized PnL
 	UsedMargin    decimal.Decimal // total margin used by open positions
 	AvailableMargin decimal.Decimal // equity - used margin
 	UnrealizedPnL decimal.Decimal // sum of all positions' unrealized PnL
 	MarginRatio   decimal.Decimal // used margin / equity (for cross margin)
+
+	Collateral      []CollateralValue // per-asset collateral, USDT first
+	CollateralValue decimal.Decimal   // sum of Collateral values
 }
 
-// CalculateSummary computes account summary with given positions
-func (a *Account) CalculateSummary(positions []Position) AccountSummary {
+// CalculateSummary computes account summary with given positions.
+// collateral holds the valued wallet assets other than USDT; they count
+// towards equity after haircuts.
+func (a *Account) CalculateSummary(positions []Position, collateral []CollateralValue) AccountSummary {
 	unrealizedPnL := decimal.Zero
 	usedMargin := decimal.Zero
 
@@ -38,7 +85,21 @@ func (a *Account) CalculateSummary(positions []Position) AccountSummary {
 		}
 	}
 
-	equity := a.Balance.Add(unrealizedPnL)
+	assets := make([]CollateralValue, 0, len(collateral)+1)
+	assets = append(assets, CollateralValue{
+		Asset:   QuoteAsset,
+		Amount:  a.Balance,
+		Price:   decimal.NewFromInt(1),
+		Haircut: decimal.Zero,
+		Value:   a.Balance,
+	})
+	collateralValue := a.Balance
+	for _, c := range collateral {
+		assets = append(assets, c)
+		collateralValue = collateralValue.Add(c.Value)
+	}
+
+	equity := collateralValue.Add(unrealizedPnL)
 	availableMargin := equity.Sub(usedMargin)
 
 	var marginRatio decimal.Decimal
@@ -53,5 +114,7 @@ func (a *Account) CalculateSummary(positions []Position) AccountSummary {
 		AvailableMargin: availableMargin,
 		UnrealizedPnL:   unrealizedPnL,
 		MarginRatio:     marginRatio,
+		Collateral:      assets,
+		CollateralValue: collateralValue,
 	}
 }
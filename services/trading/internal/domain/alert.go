@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type AlertID int64
+
+type AlertCondition string
+
+const (
+	AlertConditionAbove         AlertCondition = "ABOVE"          // price at or above target
+	AlertConditionBelow         AlertCondition = "BELOW"          // price at or below target
+	AlertConditionCross         AlertCondition = "CROSS"          // price crosses target from the side it was on at creation
+	AlertConditionChangePercent AlertCondition = "CHANGE_PERCENT" // price moves by percent within window
+)
+
+// IsValid returns true if the condition is known
+func (c AlertCondition) IsValid() bool {
+	switch c {
+	case AlertConditionAbove, AlertConditionBelow, AlertConditionCross, AlertConditionChangePercent:
+		return true
+	}
+	return false
+}
+
+type AlertStatus string
+
+const (
+	AlertStatusActive    AlertStatus = "ACTIVE"
+	AlertStatusTriggered AlertStatus = "TRIGGERED"
+)
+
+// PriceAlert notifies the user once when its condition is met on the mid price.
+// A triggered alert can be re-armed by updating it.
+type PriceAlert struct {
+	ID             AlertID
+	UserID         UserID
+	Symbol         string
+	Condition      AlertCondition
+	TargetPrice    *decimal.Decimal // ABOVE, BELOW, CROSS
+	ReferencePrice *decimal.Decimal // CROSS: mid price when the alert was armed
+	ChangePercent  *decimal.Decimal // CHANGE_PERCENT, e.g., 3 = 3%
+	Window         time.Duration    // CHANGE_PERCENT lookback
+	Note           string
+	Status         AlertStatus
+	TriggeredPrice *decimal.Decimal
+	TriggeredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsActive returns true if the alert is still waiting for its condition
+func (a *PriceAlert) IsActive() bool {
+	return a.Status == AlertStatusActive
+}
+
+// CheckLevel evaluates a price level condition against the mid price
+func (a *PriceAlert) CheckLevel(mid decimal.Decimal) bool {
+	if a.TargetPrice == nil {
+		return false
+	}
+	target := *a.TargetPrice
+
+	switch a.Condition {
+	case AlertConditionAbove:
+		return mid.GreaterThanOrEqual(target)
+	case AlertConditionBelow:
+		return mid.LessThanOrEqual(target)
+	case AlertConditionCross:
+		if a.ReferencePrice == nil {
+			return false
+		}
+		if a.ReferencePrice.LessThan(target) {
+			return mid.GreaterThanOrEqual(target)
+		}
+		return mid.LessThanOrEqual(target)
+	}
+	return false
+}
+
+// CheckChange evaluates a CHANGE_PERCENT alert given the lowest and highest
+// mid prices seen within its window
+func (a *PriceAlert) CheckChange(mid, low, high decimal.Decimal) bool {
+	if a.Condition != AlertConditionChangePercent || a.ChangePercent == nil {
+		return false
+	}
+	hundred := decimal.NewFromInt(100)
+
+	if low.IsPositive() && mid.Sub(low).Div(low).Mul(hundred).GreaterThanOrEqual(*a.ChangePercent) {
+		return true
+	}
+	if high.IsPositive() && high.Sub(mid).Div(high).Mul(hundred).GreaterThanOrEqual(*a.ChangePercent) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,267 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Algo order bounds
+const (
+	MinAlgoSlices        = 2
+	MaxAlgoSlices        = 100
+	MinAlgoSliceInterval = 10 * time.Second
+	MaxAlgoDuration      = 24 * time.Hour
+	// AlgoQuantityPlaces is the precision of slice quantities and prices
+	AlgoQuantityPlaces = 8
+)
+
+// ScaleDistribution spaces the limit prices of a scale order
+type ScaleDistribution string
+
+const (
+	ScaleLinear    ScaleDistribution = "LINEAR"    // equal price steps
+	ScaleGeometric ScaleDistribution = "GEOMETRIC" // equal price ratios
+)
+
+// AlgoOrder is the execution schedule of an algo parent order; each slice is
+// executed as a child order of the parent. TWAP and VWAP slices are due at even
+// intervals over Duration. Iceberg and scale slices are all due at start and
+// rest at their limit price until filled, with no duration.
+type AlgoOrder struct {
+	OrderID         OrderID // the parent order
+	Duration        time.Duration
+	SliceCount      int
+	Dataset         string            // candle file of the VWAP volume profile
+	VisibleQuantity decimal.Decimal   // iceberg slice size
+	Distribution    ScaleDistribution // scale price spacing
+	ArrivalPrice    decimal.Decimal   // the price a single market order would have got at creation
+	StartAt         time.Time         // the first slice is due at start
+	Slices          []AlgoSlice
+	CreatedAt       time.Time
+}
+
+// Validate checks the duration and the number of slices
+func (a *AlgoOrder) Validate() error {
+	if a.SliceCount < MinAlgoSlices || a.SliceCount > MaxAlgoSlices {
+		return ErrInvalidAlgoOrder
+	}
+	if a.Duration > MaxAlgoDuration || a.Interval() < MinAlgoSliceInterval {
+		return ErrInvalidAlgoOrder
+	}
+	return nil
+}
+
+// Interval returns the time between slices
+func (a *AlgoOrder) Interval() time.Duration {
+	return a.Duration / time.Duration(a.SliceCount)
+}
+
+// EndAt returns when the schedule ends; a limit slice still resting then expires
+func (a *AlgoOrder) EndAt() time.Time {
+	return a.StartAt.Add(a.Duration)
+}
+
+// Schedule splits quantity into SliceCount slices in proportion to weights,
+// the last slice taking the rounding remainder. Every slice must get a
+// positive quantity.
+func (a *AlgoOrder) Schedule(quantity decimal.Decimal, weights []float64) error {
+	if len(weights) != a.SliceCount {
+		return ErrInvalidAlgoOrder
+	}
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 {
+			return ErrInvalidAlgoOrder
+		}
+		total += w
+	}
+	if total <= 0 {
+		return ErrInvalidAlgoOrder
+	}
+
+	a.Slices = make([]AlgoSlice, a.SliceCount)
+	remaining := quantity
+	for i := range a.Slices {
+		q := remaining
+		if i < a.SliceCount-1 {
+			q = quantity.Mul(decimal.NewFromFloat(weights[i] / total)).Truncate(AlgoQuantityPlaces)
+		}
+		if !q.IsPositive() {
+			return ErrInvalidAlgoOrder
+		}
+		remaining = remaining.Sub(q)
+		a.Slices[i] = AlgoSlice{
+			OrderID:  a.OrderID,
+			Index:    i,
+			DueAt:    a.StartAt.Add(time.Duration(i) * a.Interval()),
+			Quantity: q,
+			Status:   AlgoSliceScheduled,
+		}
+	}
+	if remaining.IsNegative() {
+		return ErrInvalidAlgoOrder
+	}
+	return nil
+}
+
+// Iceberg splits quantity into slices of the visible quantity resting at
+// price, the last one taking the rest. Only one slice is placed at a time: the
+// next replenishes the order once the previous one fills.
+func (a *AlgoOrder) Iceberg(quantity, visible, price decimal.Decimal) error {
+	if !visible.IsPositive() || !visible.LessThan(quantity) || !price.IsPositive() {
+		return ErrInvalidAlgoOrder
+	}
+	if visible.Exponent() < -AlgoQuantityPlaces || price.Exponent() < -AlgoQuantityPlaces {
+		return ErrInvalidAlgoOrder
+	}
+
+	a.VisibleQuantity = visible
+	a.Slices = nil
+	for remaining := quantity; remaining.IsPositive(); remaining = remaining.Sub(visible) {
+		if len(a.Slices) == MaxAlgoSlices {
+			return ErrInvalidAlgoOrder
+		}
+		a.Slices = append(a.Slices, AlgoSlice{
+			OrderID:    a.OrderID,
+			Index:      len(a.Slices),
+			DueAt:      a.StartAt,
+			Quantity:   decimal.Min(visible, remaining),
+			LimitPrice: price,
+			Status:     AlgoSliceScheduled,
+		})
+	}
+	a.SliceCount = len(a.Slices)
+	return nil
+}
+
+// Scale spreads quantity evenly over SliceCount limit prices from `from` to
+// `to`, both included, spaced by Distribution. All slices are placed at once.
+func (a *AlgoOrder) Scale(quantity, from, to decimal.Decimal) error {
+	if a.SliceCount < MinAlgoSlices || a.SliceCount > MaxAlgoSlices {
+		return ErrInvalidAlgoOrder
+	}
+	if !from.IsPositive() || !to.IsPositive() || from.Equal(to) {
+		return ErrInvalidAlgoOrder
+	}
+	if a.Distribution != ScaleLinear && a.Distribution != ScaleGeometric {
+		return ErrInvalidAlgoOrder
+	}
+
+	weights := make([]float64, a.SliceCount)
+	for i := range weights {
+		weights[i] = 1
+	}
+	if err := a.Schedule(quantity, weights); err != nil {
+		return err
+	}
+
+	last := a.SliceCount - 1
+	ratio := to.InexactFloat64() / from.InexactFloat64()
+	for i := range a.Slices {
+		price := from.Add(to.Sub(from).Mul(decimal.NewFromInt(int64(i))).Div(decimal.NewFromInt(int64(last))))
+		if a.Distribution == ScaleGeometric {
+			price = from.Mul(decimal.NewFromFloat(math.Pow(ratio, float64(i)/float64(last))))
+		}
+		if i == last {
+			price = to
+		}
+		a.Slices[i].LimitPrice = price.Round(AlgoQuantityPlaces)
+	}
+	return nil
+}
+
+// LimitRange returns the limit prices of the first and the last slice
+func (a *AlgoOrder) LimitRange() (decimal.Decimal, decimal.Decimal) {
+	if len(a.Slices) == 0 {
+		return decimal.Zero, decimal.Zero
+	}
+	return a.Slices[0].LimitPrice, a.Slices[len(a.Slices)-1].LimitPrice
+}
+
+// NextSlice returns the first slice not executed yet
+func (a *AlgoOrder) NextSlice() *AlgoSlice {
+	for i := range a.Slices {
+		if a.Slices[i].Status == AlgoSliceScheduled {
+			return &a.Slices[i]
+		}
+	}
+	return nil
+}
+
+// OpenSlice returns the slice whose limit order is resting, if any
+func (a *AlgoOrder) OpenSlice() *AlgoSlice {
+	for i := range a.Slices {
+		if a.Slices[i].Status == AlgoSliceOpen {
+			return &a.Slices[i]
+		}
+	}
+	return nil
+}
+
+// FilledQuantity returns the quantity executed by all slices
+func (a *AlgoOrder) FilledQuantity() decimal.Decimal {
+	filled := decimal.Zero
+	for _, s := range a.Slices {
+		filled = filled.Add(s.FilledQuantity)
+	}
+	return filled
+}
+
+// AveragePrice returns the quantity weighted fill price, zero before any fill
+func (a *AlgoOrder) AveragePrice() decimal.Decimal {
+	filled, cost := decimal.Zero, decimal.Zero
+	for _, s := range a.Slices {
+		filled = filled.Add(s.FilledQuantity)
+		cost = cost.Add(s.FilledQuantity.Mul(s.Price))
+	}
+	if !filled.IsPositive() {
+		return decimal.Zero
+	}
+	return cost.Div(filled)
+}
+
+// SlippageBps returns how much worse than the arrival price the average fill
+// is, in basis points; negative when it is better
+func (a *AlgoOrder) SlippageBps(side OrderSide) decimal.Decimal {
+	average := a.AveragePrice()
+	if average.IsZero() || !a.ArrivalPrice.IsPositive() {
+		return decimal.Zero
+	}
+	diff := average.Sub(a.ArrivalPrice)
+	if side == OrderSideSell {
+		diff = diff.Neg()
+	}
+	return diff.Div(a.ArrivalPrice).Mul(decimal.NewFromInt(10000)).Round(2)
+}
+
+type AlgoSliceStatus string
+
+const (
+	AlgoSliceScheduled AlgoSliceStatus = "SCHEDULED"
+	AlgoSliceOpen      AlgoSliceStatus = "OPEN" // limit order resting
+	AlgoSliceFilled    AlgoSliceStatus = "FILLED"
+	AlgoSliceExpired   AlgoSliceStatus = "EXPIRED" // limit not reached, quantity moved to the next slice
+	AlgoSliceFailed    AlgoSliceStatus = "FAILED"
+	AlgoSliceCancelled AlgoSliceStatus = "CANCELLED"
+)
+
+// AlgoSlice is one scheduled part of an algo order
+type AlgoSlice struct {
+	OrderID        OrderID // the parent order
+	Index          int
+	DueAt          time.Time
+	Quantity       decimal.Decimal // scheduled, plus what an expired slice moved over
+	LimitPrice     decimal.Decimal // iceberg and scale slices rest at their own limit
+	Status         AlgoSliceStatus
+	ChildID        *OrderID
+	FilledQuantity decimal.Decimal
+	Price          decimal.Decimal // fill price
+	Error          string
+}
+
+// Resolved returns true once the slice will not execute anymore
+func (s *AlgoSlice) Resolved() bool {
+	return s.Status != AlgoSliceScheduled && s.Status != AlgoSliceOpen
+}
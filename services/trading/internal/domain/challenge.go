@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ChallengeID int64
+
+type ChallengeStatus string
+
+const (
+	ChallengeStatusActive ChallengeStatus = "ACTIVE"
+	ChallengeStatusPassed ChallengeStatus = "PASSED"
+	ChallengeStatusFailed ChallengeStatus = "FAILED"
+)
+
+// ChallengeRule names a rule of an evaluation
+type ChallengeRule string
+
+const (
+	ChallengeRuleProfitTarget ChallengeRule = "profit_target"
+	ChallengeRuleDailyLoss    ChallengeRule = "max_daily_loss"
+	ChallengeRuleDrawdown     ChallengeRule = "max_drawdown"
+	ChallengeRuleTradingDays  ChallengeRule = "min_trading_days"
+	ChallengeRulePositionSize ChallengeRule = "max_position_size"
+)
+
+// Rule statuses: targets are PENDING until MET, limits are OK until BREACHED
+const (
+	ChallengeRuleStatusPending  = "PENDING"
+	ChallengeRuleStatusMet      = "MET"
+	ChallengeRuleStatusOK       = "OK"
+	ChallengeRuleStatusBreached = "BREACHED"
+)
+
+// ChallengeRules are the conditions of an evaluation. Percentages are
+// fractions of the starting balance.
+type ChallengeRules struct {
+	ProfitTarget    decimal.Decimal // equity gain needed to pass
+	MaxDailyLoss    decimal.Decimal // equity drop allowed from the start of the UTC day
+	MaxDrawdown     decimal.Decimal // equity drop allowed from the starting balance
+	MinTradingDays  int             // days with at least one trade needed to pass
+	MaxPositionSize decimal.Decimal // notional of a single position, USDT
+}
+
+// Validate checks every rule is set to a usable value
+func (r ChallengeRules) Validate() error {
+	one := decimal.NewFromInt(1)
+	if !r.ProfitTarget.IsPositive() ||
+		!r.MaxDailyLoss.IsPositive() || r.MaxDailyLoss.GreaterThan(one) ||
+		!r.MaxDrawdown.IsPositive() || r.MaxDrawdown.GreaterThan(one) ||
+		r.MinTradingDays < 0 || !r.MaxPositionSize.IsPositive() {
+		return ErrInvalidChallenge
+	}
+	return nil
+}
+
+// Challenge is a prop-firm style evaluation traded on a dedicated account.
+// A breached rule fails it; reaching the profit target on enough trading days passes it.
+type Challenge struct {
+	ID              ChallengeID
+	UserID          UserID
+	AccountID       AccountID
+	Status          ChallengeStatus
+	StartingBalance decimal.Decimal
+	Rules           ChallengeRules
+
+	// Daily loss is measured from the equity at the first evaluation of the UTC day
+	DayStartedOn   time.Time
+	DayStartEquity decimal.Decimal
+
+	TradingDays    int
+	LastTradingDay *time.Time
+
+	FailureReason string
+	CreatedAt     time.Time
+	EndedAt       *time.Time
+}
+
+// AccountName returns the name of the challenge's account
+func (c *Challenge) AccountName() string {
+	return fmt.Sprintf("challenge-%d", c.ID)
+}
+
+// ChallengeDay truncates a time to its UTC day
+func ChallengeDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// StartDay begins a new UTC day at the given equity if now falls after the current one.
+// Returns true if the day changed.
+func (c *Challenge) StartDay(equity decimal.Decimal, now time.Time) bool {
+	today := ChallengeDay(now)
+	if !today.After(c.DayStartedOn) {
+		return false
+	}
+	c.DayStartedOn = today
+	c.DayStartEquity = equity
+	return true
+}
+
+// RecordTrade counts the day of a trade as a trading day. Returns true if it is a new one.
+func (c *Challenge) RecordTrade(at time.Time) bool {
+	day := ChallengeDay(at)
+	if c.LastTradingDay != nil && !day.After(*c.LastTradingDay) {
+		return false
+	}
+	c.LastTradingDay = &day
+	c.TradingDays++
+	return true
+}
+
+// ChallengeRuleProgress is the state of one rule
+type ChallengeRuleProgress struct {
+	Rule   ChallengeRule
+	Value  decimal.Decimal
+	Limit  decimal.Decimal
+	Status string
+}
+
+// ChallengeProgress is the evaluation of a challenge at a point in time
+type ChallengeProgress struct {
+	ChallengeID ChallengeID
+	UserID      UserID
+	AccountID   AccountID
+	Status      ChallengeStatus
+	Equity      decimal.Decimal
+	Rules       []ChallengeRuleProgress
+	Breach      ChallengeRule // first breached rule, empty if none
+	Passed      bool          // every target met without a breach
+}
+
+// Evaluate checks the rules against the account's equity and the largest
+// open position notional
+func (c *Challenge) Evaluate(equity, largestPosition decimal.Decimal) ChallengeProgress {
+	progress := ChallengeProgress{
+		ChallengeID: c.ID,
+		UserID:      c.UserID,
+		AccountID:   c.AccountID,
+		Status:      c.Status,
+		Equity:      equity,
+	}
+
+	target := func(rule ChallengeRule, value, bound decimal.Decimal) bool {
+		status := ChallengeRuleStatusPending
+		if value.GreaterThanOrEqual(bound) {
+			status = ChallengeRuleStatusMet
+		}
+		progress.Rules = append(progress.Rules, ChallengeRuleProgress{Rule: rule, Value: value, Limit: bound, Status: status})
+		return status == ChallengeRuleStatusMet
+	}
+	limit := func(rule ChallengeRule, value, bound decimal.Decimal) {
+		status := ChallengeRuleStatusOK
+		if value.GreaterThan(bound) {
+			status = ChallengeRuleStatusBreached
+			if progress.Breach == "" {
+				progress.Breach = rule
+			}
+		}
+		progress.Rules = append(progress.Rules, ChallengeRuleProgress{Rule: rule, Value: value, Limit: bound, Status: status})
+	}
+
+	profitMet := target(ChallengeRuleProfitTarget,
+		equity.Sub(c.StartingBalance), c.Rules.ProfitTarget.Mul(c.StartingBalance))
+	limit(ChallengeRuleDailyLoss,
+		decimal.Max(c.DayStartEquity.Sub(equity), decimal.Zero), c.Rules.MaxDailyLoss.Mul(c.StartingBalance))
+	limit(ChallengeRuleDrawdown,
+		decimal.Max(c.StartingBalance.Sub(equity), decimal.Zero), c.Rules.MaxDrawdown.Mul(c.StartingBalance))
+	daysMet := target(ChallengeRuleTradingDays,
+		decimal.NewFromInt(int64(c.TradingDays)), decimal.NewFromInt(int64(c.Rules.MinTradingDays)))
+	limit(ChallengeRulePositionSize, largestPosition, c.Rules.MaxPositionSize)
+
+	progress.Passed = progress.Breach == "" && profitMet && daysMet
+	return progress
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CompetitionID int64
+
+type CompetitionStatus string
+
+const (
+	CompetitionStatusUpcoming CompetitionStatus = "UPCOMING"
+	CompetitionStatusActive   CompetitionStatus = "ACTIVE"
+	CompetitionStatusEnded    CompetitionStatus = "ENDED"    // trading closed, standings not frozen yet
+	CompetitionStatusFinished CompetitionStatus = "FINISHED" // standings frozen
+)
+
+// Competition is a trading event with its own rules. Every participant trades
+// on a dedicated account funded with the starting balance.
+type Competition struct {
+	ID              CompetitionID
+	Name            string
+	Description     string
+	StartsAt        time.Time
+	EndsAt          time.Time
+	AllowedSymbols  []string
+	MaxLeverage     int
+	StartingBalance decimal.Decimal
+	RankingMetric   LeaderboardMetric
+	CreatedBy       UserID
+	FinalizedAt     *time.Time
+	CreatedAt       time.Time
+}
+
+// Status returns the competition's phase at the given time
+func (c *Competition) Status(now time.Time) CompetitionStatus {
+	switch {
+	case c.FinalizedAt != nil:
+		return CompetitionStatusFinished
+	case now.Before(c.StartsAt):
+		return CompetitionStatusUpcoming
+	case now.Before(c.EndsAt):
+		return CompetitionStatusActive
+	default:
+		return CompetitionStatusEnded
+	}
+}
+
+func (c *Competition) IsSymbolAllowed(symbol string) bool {
+	for _, s := range c.AllowedSymbols {
+		if s == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckOrder validates an order placed on a competition account
+func (c *Competition) CheckOrder(symbol string, leverage int, now time.Time) error {
+	if c.Status(now) != CompetitionStatusActive {
+		return ErrCompetitionNotActive
+	}
+	if !c.IsSymbolAllowed(symbol) {
+		return ErrSymbolNotAllowed
+	}
+	if leverage > c.MaxLeverage {
+		return ErrInvalidLeverage
+	}
+	return nil
+}
+
+// AccountName returns the name of a participant's competition account
+func (c *Competition) AccountName() string {
+	return fmt.Sprintf("competition-%d", c.ID)
+}
+
+// CompetitionStanding is a participant's result. Standings are computed live
+// while the competition runs and stored once it is finalized.
+type CompetitionStanding struct {
+	CompetitionID CompetitionID
+	Rank          int
+	UserID        UserID
+	AccountID     AccountID
+	DisplayName   string
+	Equity        decimal.Decimal
+	PnL           decimal.Decimal // equity - starting balance
+	ROI           decimal.Decimal
+	RiskAdjusted  *decimal.Decimal
+	Trades        int // closing trades
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type FollowID int64
+
+type FollowStatus string
+
+const (
+	FollowStatusActive     FollowStatus = "ACTIVE"
+	FollowStatusStopped    FollowStatus = "STOPPED"    // a risk limit was hit
+	FollowStatusUnfollowed FollowStatus = "UNFOLLOWED" // final
+)
+
+// CopyMode decides how the size of an opening copy is derived from the leader's trade
+type CopyMode string
+
+const (
+	// CopyModeRatio copies the leader's quantity multiplied by the ratio
+	CopyModeRatio CopyMode = "RATIO"
+	// CopyModeFixed commits a fixed margin amount to every opening trade
+	CopyModeFixed CopyMode = "FIXED"
+)
+
+// MaxCopyRatio bounds the allocation ratio of a follow
+const MaxCopyRatio = 100
+
+// Follow mirrors the perpetual trades of a leader account onto a follower
+// account. Opens and adds are sized by the copy mode and capped by the risk
+// limits; reduces and closes shrink the follower's position by the same
+// fraction the leader's position shrank.
+type Follow struct {
+	ID                FollowID
+	FollowerUserID    UserID
+	FollowerAccountID AccountID
+	LeaderUserID      UserID
+	LeaderAccountID   AccountID
+	LeaderName        string // display name, read only
+	Mode              CopyMode
+	Ratio             decimal.Decimal // RATIO: follower quantity per leader quantity
+	Amount            decimal.Decimal // FIXED: margin per opening trade, USDT
+
+	// Risk limits
+	MaxLeverage         int              // 0 = the leader's leverage
+	MaxPositionNotional *decimal.Decimal // per symbol, at the copy price
+	MaxLoss             *decimal.Decimal // stops the follow once copied trades lost this much
+
+	Status       FollowStatus
+	StopReason   string
+	CopiedTrades int
+	RealizedPnL  decimal.Decimal // of copied reduces and closes
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate checks the copy mode and risk limits
+func (f *Follow) Validate() error {
+	switch f.Mode {
+	case CopyModeRatio:
+		if !f.Ratio.IsPositive() || f.Ratio.GreaterThan(decimal.NewFromInt(MaxCopyRatio)) {
+			return ErrInvalidFollow
+		}
+	case CopyModeFixed:
+		if !f.Amount.IsPositive() {
+			return ErrInvalidFollow
+		}
+	default:
+		return ErrInvalidFollow
+	}
+	if f.MaxLeverage < 0 {
+		return ErrInvalidFollow
+	}
+	if f.MaxPositionNotional != nil && !f.MaxPositionNotional.IsPositive() {
+		return ErrInvalidFollow
+	}
+	if f.MaxLoss != nil && !f.MaxLoss.IsPositive() {
+		return ErrInvalidFollow
+	}
+	return nil
+}
+
+// IsActive returns true if the leader's trades are being copied
+func (f *Follow) IsActive() bool {
+	return f.Status == FollowStatusActive
+}
+
+// Leverage returns the leverage of a copy, the leader's capped by MaxLeverage
+func (f *Follow) Leverage(leader int) int {
+	if f.MaxLeverage > 0 && leader > f.MaxLeverage {
+		return f.MaxLeverage
+	}
+	return leader
+}
+
+// OpenQuantity returns the size of a copied open or add before the notional cap
+func (f *Follow) OpenQuantity(leaderQuantity, price decimal.Decimal, leverage int) decimal.Decimal {
+	if f.Mode == CopyModeFixed {
+		return f.Amount.Mul(decimal.NewFromInt(int64(leverage))).Div(price)
+	}
+	return leaderQuantity.Mul(f.Ratio)
+}
+
+// RecordClose adds the PnL of a copied reduce or close and stops the follow
+// when the loss limit is reached. Returns true if the follow was stopped.
+func (f *Follow) RecordClose(pnl decimal.Decimal) bool {
+	f.RealizedPnL = f.RealizedPnL.Add(pnl)
+	if f.MaxLoss != nil && f.IsActive() && f.RealizedPnL.LessThanOrEqual(f.MaxLoss.Neg()) {
+		f.Status = FollowStatusStopped
+		f.StopReason = "max loss reached"
+		return true
+	}
+	return false
+}
+
+type CopyTradeID int64
+
+type CopyTradeStatus string
+
+const (
+	CopyTradeFilled  CopyTradeStatus = "FILLED"
+	CopyTradeFailed  CopyTradeStatus = "FAILED"
+	CopyTradeSkipped CopyTradeStatus = "SKIPPED" // nothing to copy, e.g. a risk limit left no room
+)
+
+// CopyTrade is an entry of a follow's audit trail: one leader trade and what
+// was done on the follower account for it
+type CopyTrade struct {
+	ID              CopyTradeID
+	FollowID        FollowID
+	LeaderTradeID   TradeID
+	LeaderTradeType TradeType
+	Symbol          string
+	Side            OrderSide // of the follower order
+	Status          CopyTradeStatus
+	OrderID         *OrderID
+	Quantity        decimal.Decimal
+	Price           decimal.Decimal
+	PnL             decimal.Decimal
+	Error           string
+	CreatedAt       time.Time
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cursor points at the last item of a page sorted by time then ID, newest first.
+// Clients get it as an opaque string and pass it back to fetch the next page.
+type Cursor struct {
+	Time time.Time
+	ID   int64
+}
+
+// Encode returns the opaque form of the cursor
+func (c Cursor) Encode() string {
+	raw := c.Time.UTC().Format(time.RFC3339Nano) + "|" + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes a cursor produced by Encode
+func ParseCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || n <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{Time: t, ID: n}, nil
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type DCAPlanID int64
+
+type DCAPlanStatus string
+
+const (
+	DCAPlanStatusActive    DCAPlanStatus = "ACTIVE"
+	DCAPlanStatusPaused    DCAPlanStatus = "PAUSED"
+	DCAPlanStatusCancelled DCAPlanStatus = "CANCELLED" // final
+)
+
+// DCAMissedRuns decides what happens to runs missed while the service was down
+type DCAMissedRuns string
+
+const (
+	// DCAMissedRunsSkip drops late runs and waits for the next one
+	DCAMissedRunsSkip DCAMissedRuns = "SKIP"
+	// DCAMissedRunsCatchUp executes late runs, up to MaxDCACatchUp of them
+	DCAMissedRunsCatchUp DCAMissedRuns = "CATCH_UP"
+)
+
+// DCA plan bounds
+const (
+	MinDCAInterval     = time.Minute
+	MaxDCASafetyOrders = 20
+	MaxDCACatchUp      = 10
+)
+
+// DCAPlan buys a fixed quote amount of a symbol on a schedule. Buys of the
+// current cycle are averaged: an optional take profit sells them once the price
+// is far enough above the average entry, and optional safety orders buy more
+// when the price drops below the last buy.
+type DCAPlan struct {
+	ID         DCAPlanID
+	UserID     UserID
+	AccountID  AccountID
+	Symbol     string
+	Amount     decimal.Decimal // quote per scheduled run, USDT
+	Leverage   int
+	Interval   time.Duration
+	NextRunAt  time.Time
+	MissedRuns DCAMissedRuns
+
+	TakeProfitPct   *decimal.Decimal // above the average entry
+	SafetyDropPct   *decimal.Decimal // below the last buy
+	SafetyAmount    decimal.Decimal  // quote per safety order
+	MaxSafetyOrders int
+
+	Status DCAPlanStatus
+
+	// Current cycle, reset by a take profit
+	Quantity     decimal.Decimal // base bought
+	Cost         decimal.Decimal // quote spent, fees included
+	LastBuyPrice decimal.Decimal
+	SafetyOrders int
+
+	Runs        int // scheduled buys executed
+	Cycles      int // cycles closed by a take profit
+	RealizedPnL decimal.Decimal
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate checks the amount, schedule and cycle settings
+func (p *DCAPlan) Validate() error {
+	if p.Symbol == "" || !p.Amount.IsPositive() || p.Leverage < 1 || p.Interval < MinDCAInterval {
+		return ErrInvalidDCAPlan
+	}
+	if p.MissedRuns != DCAMissedRunsSkip && p.MissedRuns != DCAMissedRunsCatchUp {
+		return ErrInvalidDCAPlan
+	}
+	if p.TakeProfitPct != nil && !p.TakeProfitPct.IsPositive() {
+		return ErrInvalidDCAPlan
+	}
+	if p.SafetyDropPct != nil {
+		if !p.SafetyDropPct.IsPositive() || p.SafetyDropPct.GreaterThanOrEqual(decimal.NewFromInt(100)) ||
+			!p.SafetyAmount.IsPositive() || p.MaxSafetyOrders < 1 || p.MaxSafetyOrders > MaxDCASafetyOrders {
+			return ErrInvalidDCAPlan
+		}
+	}
+	return nil
+}
+
+// DueRuns returns how many scheduled times are not later than now, and the
+// latest of them up to limit, oldest first
+func (p *DCAPlan) DueRuns(now time.Time, limit int) ([]time.Time, int) {
+	if p.NextRunAt.After(now) {
+		return nil, 0
+	}
+	total := int(now.Sub(p.NextRunAt)/p.Interval) + 1
+	latest := p.NextRunAt.Add(time.Duration(total-1) * p.Interval)
+
+	runs := make([]time.Time, min(total, limit))
+	for i := range runs {
+		runs[i] = latest.Add(-time.Duration(len(runs)-1-i) * p.Interval)
+	}
+	return runs, total
+}
+
+// Reschedule moves NextRunAt to the first scheduled time after now, keeping the
+// time of day of the original schedule
+func (p *DCAPlan) Reschedule(now time.Time) {
+	if p.NextRunAt.After(now) {
+		return
+	}
+	missed := now.Sub(p.NextRunAt)/p.Interval + 1
+	p.NextRunAt = p.NextRunAt.Add(missed * p.Interval)
+}
+
+// AverageEntry returns the average price of the current cycle, zero when empty
+func (p *DCAPlan) AverageEntry() decimal.Decimal {
+	if !p.Quantity.IsPositive() {
+		return decimal.Zero
+	}
+	return p.Cost.Div(p.Quantity)
+}
+
+// TakeProfitPrice returns the bid that closes the cycle
+func (p *DCAPlan) TakeProfitPrice() (decimal.Decimal, bool) {
+	if p.TakeProfitPct == nil || !p.Quantity.IsPositive() {
+		return decimal.Zero, false
+	}
+	return p.AverageEntry().Mul(percentFactor(*p.TakeProfitPct)), true
+}
+
+// SafetyPrice returns the ask that triggers the next safety order
+func (p *DCAPlan) SafetyPrice() (decimal.Decimal, bool) {
+	if p.SafetyDropPct == nil || !p.Quantity.IsPositive() || p.SafetyOrders >= p.MaxSafetyOrders {
+		return decimal.Zero, false
+	}
+	return p.LastBuyPrice.Mul(percentFactor(p.SafetyDropPct.Neg())), true
+}
+
+// RecordBuy adds a filled buy to the current cycle
+func (p *DCAPlan) RecordBuy(quantity, price, fee decimal.Decimal) {
+	p.Quantity = p.Quantity.Add(quantity)
+	p.Cost = p.Cost.Add(quantity.Mul(price)).Add(fee)
+	p.LastBuyPrice = price
+}
+
+// RecordTakeProfit realizes the cycle at the sell price and starts a new one
+func (p *DCAPlan) RecordTakeProfit(quantity, price, fee decimal.Decimal) {
+	p.RealizedPnL = p.RealizedPnL.Add(quantity.Mul(price)).Sub(fee).Sub(p.Cost)
+	p.Cycles++
+	p.Quantity = decimal.Zero
+	p.Cost = decimal.Zero
+	p.LastBuyPrice = decimal.Zero
+	p.SafetyOrders = 0
+}
+
+func percentFactor(pct decimal.Decimal) decimal.Decimal {
+	return decimal.NewFromInt(1).Add(pct.Div(decimal.NewFromInt(100)))
+}
+
+type DCAExecutionID int64
+
+// DCAExecutionKind is what triggered an execution
+type DCAExecutionKind string
+
+const (
+	DCAExecutionScheduled  DCAExecutionKind = "SCHEDULED"
+	DCAExecutionSafety     DCAExecutionKind = "SAFETY"
+	DCAExecutionTakeProfit DCAExecutionKind = "TAKE_PROFIT"
+)
+
+type DCAExecutionStatus string
+
+const (
+	DCAExecutionPending DCAExecutionStatus = "PENDING" // claimed; stays so if the service stopped before its order was recorded
+	DCAExecutionFilled  DCAExecutionStatus = "FILLED"
+	DCAExecutionFailed  DCAExecutionStatus = "FAILED"
+	DCAExecutionSkipped DCAExecutionStatus = "SKIPPED" // missed while the service was down
+)
+
+// DCAExecution is an entry of a plan's execution history
+type DCAExecution struct {
+	ID        DCAExecutionID
+	PlanID    DCAPlanID
+	Kind      DCAExecutionKind
+	Status    DCAExecutionStatus
+	RunAt     time.Time // scheduled time, or trigger time for price driven orders
+	OrderID   *OrderID
+	Quantity  decimal.Decimal
+	Price     decimal.Decimal
+	Error     string
+	CreatedAt time.Time
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type EquityResolution string
+
+const (
+	EquityResolutionMinute EquityResolution = "1m"
+	EquityResolutionHour   EquityResolution = "1h"
+	EquityResolutionDay    EquityResolution = "1d"
+)
+
+// EquityResolutions lists the snapshot resolutions, finest first
+var EquityResolutions = []EquityResolution{EquityResolutionMinute, EquityResolutionHour, EquityResolutionDay}
+
+// IsValid returns true if the resolution is known
+func (r EquityResolution) IsValid() bool {
+	switch r {
+	case EquityResolutionMinute, EquityResolutionHour, EquityResolutionDay:
+		return true
+	}
+	return false
+}
+
+// Duration returns the length of one bucket
+func (r EquityResolution) Duration() time.Duration {
+	switch r {
+	case EquityResolutionHour:
+		return time.Hour
+	case EquityResolutionDay:
+		return 24 * time.Hour
+	}
+	return time.Minute
+}
+
+// Bucket returns the start of the UTC bucket containing t
+func (r EquityResolution) Bucket(t time.Time) time.Time {
+	return t.UTC().Truncate(r.Duration())
+}
+
+// EquitySnapshot is the account state at the end of a time bucket.
+// Hourly and daily snapshots are rollups of the minute ones: they keep the
+// last values and the equity range seen within the bucket.
+type EquitySnapshot struct {
+	AccountID     AccountID
+	Resolution    EquityResolution
+	Time          time.Time // bucket start (UTC)
+	Balance       decimal.Decimal
+	Equity        decimal.Decimal
+	EquityLow     decimal.Decimal
+	EquityHigh    decimal.Decimal
+	UnrealizedPnL decimal.Decimal
+	UsedMargin    decimal.Decimal
+}
+
+// NewEquitySnapshot builds a minute snapshot from the account summary
+func NewEquitySnapshot(accountID AccountID, at time.Time, summary AccountSummary) EquitySnapshot {
+	return EquitySnapshot{
+		AccountID:     accountID,
+		Resolution:    EquityResolutionMinute,
+		Time:          EquityResolutionMinute.Bucket(at),
+		Balance:       summary.Balance,
+		Equity:        summary.Equity,
+		EquityLow:     summary.Equity,
+		EquityHigh:    summary.Equity,
+		UnrealizedPnL: summary.UnrealizedPnL,
+		UsedMargin:    summary.UsedMargin,
+	}
+}
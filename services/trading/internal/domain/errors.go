@@ -7,21 +7,33 @@ var (
 	ErrUserNotFound       = errors.New("user not found")
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidDisplayName = errors.New("display name must be 3-32 letters, digits, '_' or '-'")
+	ErrDisplayNameTaken   = errors.New("display name already taken")
+	ErrDisplayNameMissing = errors.New("display name is required to join leaderboards")
 
 	// Account errors
 	ErrAccountNotFound     = errors.New("account not found")
 	ErrInsufficientBalance = errors.New("insufficient balance")
 	ErrInsufficientMargin  = errors.New("insufficient margin")
+	ErrAccountNameTaken    = errors.New("account name already taken")
+	ErrInvalidAccountName  = errors.New("invalid account name")
+	ErrTooManyAccounts     = errors.New("account limit reached")
+	ErrInvalidTransfer     = errors.New("invalid transfer")
+	ErrIsolatedAccount     = errors.New("not allowed on a competition or challenge account")
+	ErrAccountLocked       = errors.New("account is locked")
 
 	// Order errors
-	ErrOrderNotFound      = errors.New("order not found")
-	ErrOrderNotPending    = errors.New("order is not pending")
-	ErrInvalidOrderSide   = errors.New("invalid order side")
-	ErrInvalidOrderType   = errors.New("invalid order type")
-	ErrInvalidQuantity    = errors.New("invalid quantity")
-	ErrInvalidLeverage    = errors.New("invalid leverage")
-	ErrInvalidPrice       = errors.New("invalid price")
-	ErrSymbolNotSupported = errors.New("symbol not supported")
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrOrderNotPending     = errors.New("order is not pending")
+	ErrInvalidOrderSide    = errors.New("invalid order side")
+	ErrInvalidOrderType    = errors.New("invalid order type")
+	ErrInvalidQuantity     = errors.New("invalid quantity")
+	ErrInvalidLeverage     = errors.New("invalid leverage")
+	ErrInvalidPrice        = errors.New("invalid price")
+	ErrSymbolNotSupported  = errors.New("symbol not supported")
+	ErrSpotStopsNotAllowed = errors.New("stop loss and take profit are not supported for spot orders")
+	ErrSpotLimitNotAllowed = errors.New("limit orders are not supported for spot instruments")
+	ErrInvalidOrderFilter  = errors.New("invalid order filter")
 
 	// Position errors
 	ErrPositionNotFound      = errors.New("position not found")
@@ -30,9 +42,110 @@ var (
 	ErrInvalidStopLoss       = errors.New("invalid stop loss")
 	ErrInvalidTakeProfit     = errors.New("invalid take profit")
 	ErrInvalidClosePercent   = errors.New("close percent must be between 1 and 100")
+	ErrInvalidPositionFilter = errors.New("invalid position filter")
 
 	// Trade errors
-	ErrTradeNotFound = errors.New("trade not found")
+	ErrTradeNotFound      = errors.New("trade not found")
+	ErrInvalidTradeFilter = errors.New("invalid trade filter")
+
+	// Spot errors
+	ErrSpotLotNotFound = errors.New("spot lot not found")
+
+	// Season errors
+	ErrSeasonNotFound = errors.New("season not found")
+
+	// Ledger errors
+	ErrInvalidLedgerEntryType = errors.New("invalid ledger entry type")
+
+	// Wallet errors
+	ErrInvalidAsset      = errors.New("invalid asset")
+	ErrInvalidConversion = errors.New("invalid conversion")
+
+	// Alert errors
+	ErrAlertNotFound        = errors.New("alert not found")
+	ErrInvalidAlert         = errors.New("invalid alert")
+	ErrTooManyAlerts        = errors.New("active alert limit reached")
+	ErrNotificationNotFound = errors.New("notification not found")
+
+	// Webhook errors
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrInvalidWebhook          = errors.New("invalid webhook")
+	ErrTooManyWebhooks         = errors.New("webhook limit reached")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+	// Report errors
+	ErrInvalidEquityResolution = errors.New("invalid resolution")
+	ErrInvalidTimeRange        = errors.New("invalid time range")
+	ErrInvalidReportPeriod     = errors.New("invalid report period")
+
+	// Leaderboard errors
+	ErrInvalidLeaderboardPeriod = errors.New("invalid leaderboard period")
+	ErrInvalidLeaderboardMetric = errors.New("invalid leaderboard metric")
+
+	// Competition errors
+	ErrCompetitionNotFound  = errors.New("competition not found")
+	ErrInvalidCompetition   = errors.New("invalid competition")
+	ErrCompetitionNotActive = errors.New("competition is not running")
+	ErrCompetitionEnded     = errors.New("competition has ended")
+	ErrAlreadyJoined        = errors.New("already joined this competition")
+	ErrSymbolNotAllowed     = errors.New("symbol not allowed in this competition")
+
+	// Challenge errors
+	ErrChallengeNotFound = errors.New("challenge not found")
+	ErrInvalidChallenge  = errors.New("invalid challenge rules")
+	ErrTooManyChallenges = errors.New("active challenge limit reached")
+
+	// Backtest errors
+	ErrInvalidCandles   = errors.New("invalid candle data")
+	ErrUnknownStrategy  = errors.New("unknown strategy")
+	ErrInvalidStrategy  = errors.New("invalid strategy parameters")
+	ErrInvalidBacktest  = errors.New("invalid backtest settings")
+	ErrBacktestNotFound = errors.New("backtest not found")
+	ErrTooManyBacktests = errors.New("backtest limit reached")
+	ErrDatasetNotFound  = errors.New("dataset not found")
+
+	// Grid bot errors
+	ErrGridBotNotFound  = errors.New("grid bot not found")
+	ErrInvalidGridBot   = errors.New("invalid grid bot settings")
+	ErrGridBotStopped   = errors.New("grid bot is stopped")
+	ErrTooManyGridBots  = errors.New("grid bot limit reached")
+	ErrPriceOutsideGrid = errors.New("price is outside the grid range")
+
+	// DCA errors
+	ErrDCAPlanNotFound  = errors.New("dca plan not found")
+	ErrInvalidDCAPlan   = errors.New("invalid dca plan settings")
+	ErrDCAPlanCancelled = errors.New("dca plan is cancelled")
+	ErrTooManyDCAPlans  = errors.New("dca plan limit reached")
+
+	// Copy trading errors
+	ErrLeaderNotFound   = errors.New("leader not found")
+	ErrFollowNotFound   = errors.New("follow not found")
+	ErrInvalidFollow    = errors.New("invalid follow settings")
+	ErrCannotFollowSelf = errors.New("cannot follow yourself")
+	ErrAlreadyFollowing = errors.New("already following this leader")
+	ErrFollowEnded      = errors.New("follow has ended")
+	ErrTooManyFollows   = errors.New("follow limit reached")
+
+	// Script errors
+	ErrScriptNotFound   = errors.New("script not found")
+	ErrInvalidScript    = errors.New("invalid script")
+	ErrScriptRunning    = errors.New("script is running")
+	ErrScriptSymbolBusy = errors.New("another script is running on this symbol")
+	ErrTooManyScripts   = errors.New("script limit reached")
+
+	// Signal errors
+	ErrSignalStrategyNotFound  = errors.New("signal strategy not found")
+	ErrInvalidSignalStrategy   = errors.New("invalid signal strategy settings")
+	ErrTooManySignalStrategies = errors.New("signal strategy limit reached")
+
+	// Algo order errors
+	ErrAlgoOrderNotFound    = errors.New("algo order not found")
+	ErrInvalidAlgoOrder     = errors.New("invalid algo order settings")
+	ErrAlgoOrderNotEditable = errors.New("algo orders cannot be edited")
+	ErrTooManyAlgoOrders    = errors.New("algo order limit reached")
+
+	// Pagination errors
+	ErrInvalidCursor = errors.New("invalid cursor")
 
 	// Price errors
 	ErrPriceNotAvailable = errors.New("price not available")
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTypeFill          EventType = "fill"           // market order filled or position closed by the user
+	EventTypeLiquidation   EventType = "liquidation"    // position liquidated
+	EventTypeStopLoss      EventType = "stop_loss"      // stop loss triggered
+	EventTypeTakeProfit    EventType = "take_profit"    // take profit triggered
+	EventTypeMarginWarning EventType = "margin_warning" // position close to liquidation
+	EventTypeOrderRejected EventType = "order_rejected" // resting limit order rejected when the market reached it
+)
+
+// EventTypes lists every account event type
+var EventTypes = []EventType{
+	EventTypeFill,
+	EventTypeLiquidation,
+	EventTypeStopLoss,
+	EventTypeTakeProfit,
+	EventTypeMarginWarning,
+	EventTypeOrderRejected,
+}
+
+// IsValid returns true if the event type is known
+func (t EventType) IsValid() bool {
+	for _, e := range EventTypes {
+		if t == e {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountEvent is a trading event of an account
+type AccountEvent struct {
+	Type      EventType
+	UserID    UserID
+	AccountID AccountID
+	Trade     *Trade    // nil for margin warnings and rejections
+	Position  *Position // nil for spot fills
+	Order     *Order    // the order filled or rejected, nil for closes and margin warnings
+	Timestamp time.Time
+}
+
+// EventPublisher delivers account events to subscribers. Publishing must not
+// fail the operation that produced the event.
+type EventPublisher interface {
+	Publish(ctx context.Context, event AccountEvent)
+}
+
+// EventPublishers fans events out to several publishers
+type EventPublishers []EventPublisher
+
+func (p EventPublishers) Publish(ctx context.Context, event AccountEvent) {
+	for _, publisher := range p {
+		publisher.Publish(ctx, event)
+	}
+}
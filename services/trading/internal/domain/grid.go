@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type GridBotID int64
+
+type GridBotStatus string
+
+const (
+	GridBotStatusActive  GridBotStatus = "ACTIVE"
+	GridBotStatusPaused  GridBotStatus = "PAUSED"  // orders cancelled, position kept
+	GridBotStatusStopped GridBotStatus = "STOPPED" // final
+)
+
+// Grid bounds
+const (
+	MinGrids = 2
+	MaxGrids = 100
+)
+
+// GridOrder is the limit order resting on a grid level
+type GridOrder struct {
+	Level   int // 0 is the lower bound, Grids the upper one
+	Side    OrderSide
+	OrderID OrderID
+}
+
+// GridBot trades a range with limit orders on evenly spaced price levels: a
+// filled buy is followed by a sell one level up and a filled sell by a buy one
+// level down, so every round trip earns one step.
+type GridBot struct {
+	ID         GridBotID
+	UserID     UserID
+	AccountID  AccountID
+	Symbol     string
+	LowerPrice decimal.Decimal
+	UpperPrice decimal.Decimal
+	Grids      int // intervals between LowerPrice and UpperPrice
+	Investment decimal.Decimal
+	Leverage   int
+	Quantity   decimal.Decimal // per order
+	Status     GridBotStatus
+	Orders     []GridOrder // resting orders, by level
+
+	// Results of the bot's own fills
+	BuyFills   int
+	SellFills  int
+	Rounds     int             // fills that closed an earlier fill one level away
+	GridProfit decimal.Decimal // Rounds * Quantity * step
+	Position   decimal.Decimal // signed base quantity: bought minus sold
+	Cost       decimal.Decimal // quote paid for buys minus quote received for sells
+	Fees       decimal.Decimal
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	StoppedAt *time.Time
+}
+
+// Validate checks the range and sizing settings
+func (b *GridBot) Validate() error {
+	if b.Symbol == "" || !b.LowerPrice.IsPositive() || !b.UpperPrice.GreaterThan(b.LowerPrice) ||
+		b.Grids < MinGrids || b.Grids > MaxGrids || !b.Investment.IsPositive() || b.Leverage < 1 {
+		return ErrInvalidGridBot
+	}
+	return nil
+}
+
+// Step is the distance between two levels
+func (b *GridBot) Step() decimal.Decimal {
+	return b.UpperPrice.Sub(b.LowerPrice).Div(decimal.NewFromInt(int64(b.Grids)))
+}
+
+// LevelPrice returns the price of level i
+func (b *GridBot) LevelPrice(i int) decimal.Decimal {
+	if i == b.Grids {
+		return b.UpperPrice
+	}
+	return b.LowerPrice.Add(b.Step().Mul(decimal.NewFromInt(int64(i)))).Round(8)
+}
+
+// NearestLevel returns the level closest to price
+func (b *GridBot) NearestLevel(price decimal.Decimal) int {
+	i := int(price.Sub(b.LowerPrice).Div(b.Step()).Round(0).IntPart())
+	return min(max(i, 0), b.Grids)
+}
+
+// Fill records a fill of one of the bot's grid orders. The quantity may fall
+// short of the bot's order quantity when the fill closed a smaller account position.
+func (b *GridBot) Fill(side OrderSide, quantity, price, fee decimal.Decimal) {
+	// A fill against the bot's position closes the fill one level away
+	if (side == OrderSideBuy && b.Position.IsNegative()) || (side == OrderSideSell && b.Position.IsPositive()) {
+		b.Rounds++
+		b.GridProfit = b.GridProfit.Add(quantity.Mul(b.Step()))
+	}
+	b.record(side, quantity, price, fee)
+}
+
+// CloseOut records the market order that closes the bot's position on stop
+func (b *GridBot) CloseOut(side OrderSide, quantity, price, fee decimal.Decimal) {
+	b.record(side, quantity, price, fee)
+}
+
+func (b *GridBot) record(side OrderSide, quantity, price, fee decimal.Decimal) {
+	signed := quantity
+	if side == OrderSideBuy {
+		b.BuyFills++
+	} else {
+		b.SellFills++
+		signed = quantity.Neg()
+	}
+	b.Position = b.Position.Add(signed)
+	b.Cost = b.Cost.Add(signed.Mul(price))
+	b.Fees = b.Fees.Add(fee)
+}
+
+// PnL returns the bot's total result at the mark price: grid profit plus the
+// unrealized result of the position, less fees
+func (b *GridBot) PnL(markPrice decimal.Decimal) decimal.Decimal {
+	return b.Position.Mul(markPrice).Sub(b.Cost).Sub(b.Fees)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type MarketType string
+
+const (
+	// MarketTypePerpetual instruments open leveraged positions
+	MarketTypePerpetual MarketType = "PERPETUAL"
+	// MarketTypeSpot instruments exchange wallet assets without leverage
+	MarketTypeSpot MarketType = "SPOT"
+)
+
+// Instrument is a tradable symbol and the market it is traded on
+type Instrument struct {
+	Symbol      string // e.g., "BTCUSDT" for perpetuals, "BTC/USDT" for spot
+	MarketType  MarketType
+	BaseAsset   Asset
+	QuoteAsset  Asset
+	PriceSymbol string // price feed symbol, e.g., "BTCUSDT"
+}
+
+// IsSpot returns true if orders on the instrument settle in wallet assets
+func (i Instrument) IsSpot() bool {
+	return i.MarketType == MarketTypeSpot
+}
+
+// NewPerpetualInstrument describes a USDT-margined perpetual like "BTCUSDT"
+func NewPerpetualInstrument(symbol string) Instrument {
+	return Instrument{
+		Symbol:      symbol,
+		MarketType:  MarketTypePerpetual,
+		BaseAsset:   Asset(strings.TrimSuffix(symbol, string(QuoteAsset))),
+		QuoteAsset:  QuoteAsset,
+		PriceSymbol: symbol,
+	}
+}
+
+// NewSpotInstrument parses a spot pair like "BTC/USDT". The base must be a
+// wallet asset and the quote must be USDT.
+func NewSpotInstrument(symbol string) (Instrument, error) {
+	base, quote, ok := strings.Cut(symbol, "/")
+	if !ok || Asset(quote) != QuoteAsset || Asset(base) == QuoteAsset || !Asset(base).IsValid() {
+		return Instrument{}, fmt.Errorf("invalid spot symbol %q", symbol)
+	}
+	return Instrument{
+		Symbol:      symbol,
+		MarketType:  MarketTypeSpot,
+		BaseAsset:   Asset(base),
+		QuoteAsset:  QuoteAsset,
+		PriceSymbol: base + quote,
+	}, nil
+}
+
+// NewInstruments builds the tradable instruments from perpetual and spot symbols
+func NewInstruments(perpetualSymbols, spotSymbols []string) ([]Instrument, error) {
+	instruments := make([]Instrument, 0, len(perpetualSymbols)+len(spotSymbols))
+	for _, s := range perpetualSymbols {
+		instruments = append(instruments, NewPerpetualInstrument(s))
+	}
+	for _, s := range spotSymbols {
+		instrument, err := NewSpotInstrument(s)
+		if err != nil {
+			return nil, err
+		}
+		instruments = append(instruments, instrument)
+	}
+	return instruments, nil
+}
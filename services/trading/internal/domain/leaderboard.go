@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// LeaderboardPeriod is the window of closing trades a leaderboard ranks
+type LeaderboardPeriod string
+
+const (
+	LeaderboardPeriodDaily   LeaderboardPeriod = "daily"
+	LeaderboardPeriodWeekly  LeaderboardPeriod = "weekly"
+	LeaderboardPeriodMonthly LeaderboardPeriod = "monthly"
+	LeaderboardPeriodAllTime LeaderboardPeriod = "all_time"
+)
+
+// LeaderboardPeriods lists all periods
+var LeaderboardPeriods = []LeaderboardPeriod{
+	LeaderboardPeriodDaily,
+	LeaderboardPeriodWeekly,
+	LeaderboardPeriodMonthly,
+	LeaderboardPeriodAllTime,
+}
+
+func (p LeaderboardPeriod) IsValid() bool {
+	switch p {
+	case LeaderboardPeriodDaily, LeaderboardPeriodWeekly, LeaderboardPeriodMonthly, LeaderboardPeriodAllTime:
+		return true
+	}
+	return false
+}
+
+// Start returns the beginning of the period containing now in UTC (weeks start
+// on Monday), or nil for all-time
+func (p LeaderboardPeriod) Start(now time.Time) *time.Time {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var start time.Time
+	switch p {
+	case LeaderboardPeriodDaily:
+		start = day
+	case LeaderboardPeriodWeekly:
+		offset := (int(day.Weekday()) + 6) % 7
+		start = day.AddDate(0, 0, -offset)
+	case LeaderboardPeriodMonthly:
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return nil
+	}
+	return &start
+}
+
+// LeaderboardMetric is what a leaderboard is ranked by
+type LeaderboardMetric string
+
+const (
+	LeaderboardMetricPnL          LeaderboardMetric = "pnl"
+	LeaderboardMetricROI          LeaderboardMetric = "roi"
+	LeaderboardMetricRiskAdjusted LeaderboardMetric = "risk_adjusted"
+)
+
+func (m LeaderboardMetric) IsValid() bool {
+	switch m {
+	case LeaderboardMetricPnL, LeaderboardMetricROI, LeaderboardMetricRiskAdjusted:
+		return true
+	}
+	return false
+}
+
+// TraderPerformance aggregates the closing trades of one trading account:
+// an opted-in user's primary account over a period, or a competition account
+type TraderPerformance struct {
+	UserID          UserID
+	AccountID       AccountID
+	DisplayName     string
+	StartingBalance decimal.Decimal // season starting balance of the account
+	Trades          int
+	Wins            int
+	RealizedPnL     decimal.Decimal // net of fees
+	PnLStdDev       decimal.Decimal // sample standard deviation of per-trade net PnL
+}
+
+// ROI returns realized PnL relative to the starting balance
+func (p *TraderPerformance) ROI() decimal.Decimal {
+	if !p.StartingBalance.IsPositive() {
+		return decimal.Zero
+	}
+	return p.RealizedPnL.Div(p.StartingBalance)
+}
+
+// RiskAdjusted returns the Sharpe ratio of the trade sequence: mean trade PnL
+// over its standard deviation, scaled by the square root of the trade count.
+// It is nil with fewer than two trades or no variance.
+func (p *TraderPerformance) RiskAdjusted() *decimal.Decimal {
+	if p.Trades < 2 || !p.PnLStdDev.IsPositive() {
+		return nil
+	}
+	n := decimal.NewFromInt(int64(p.Trades))
+	sqrtN := decimal.NewFromFloat(math.Sqrt(float64(p.Trades)))
+	v := p.RealizedPnL.Div(n).Div(p.PnLStdDev).Mul(sqrtN)
+	return &v
+}
+
+// LeaderboardEntry is one ranked trader
+type LeaderboardEntry struct {
+	Rank         int
+	DisplayName  string
+	RealizedPnL  decimal.Decimal
+	ROI          decimal.Decimal
+	RiskAdjusted *decimal.Decimal
+	Trades       int
+	WinRate      decimal.Decimal
+}
+
+// Leaderboard is a ranking of traders over a period
+type Leaderboard struct {
+	Period    LeaderboardPeriod
+	Metric    LeaderboardMetric
+	From      *time.Time // nil for all-time
+	UpdatedAt time.Time
+	Entries   []LeaderboardEntry
+}
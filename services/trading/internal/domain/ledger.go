@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type LedgerEntryID int64
+
+type LedgerEntryType string
+
+const (
+	LedgerEntryTypeInitialDeposit LedgerEntryType = "INITIAL_DEPOSIT"
+	LedgerEntryTypeRealizedPnL    LedgerEntryType = "REALIZED_PNL"
+	LedgerEntryTypeFee            LedgerEntryType = "FEE"
+	LedgerEntryTypeFunding        LedgerEntryType = "FUNDING"
+	LedgerEntryTypeLiquidation    LedgerEntryType = "LIQUIDATION"
+	LedgerEntryTypeAdjustment     LedgerEntryType = "ADJUSTMENT"
+	LedgerEntryTypeTransfer       LedgerEntryType = "TRANSFER"
+	LedgerEntryTypeConversion     LedgerEntryType = "CONVERSION"
+	LedgerEntryTypeSpotTrade      LedgerEntryType = "SPOT_TRADE"
+)
+
+// IsValid returns true if the entry type is known
+func (t LedgerEntryType) IsValid() bool {
+	switch t {
+	case LedgerEntryTypeInitialDeposit, LedgerEntryTypeRealizedPnL, LedgerEntryTypeFee,
+		LedgerEntryTypeFunding, LedgerEntryTypeLiquidation, LedgerEntryTypeAdjustment,
+		LedgerEntryTypeTransfer, LedgerEntryTypeConversion, LedgerEntryTypeSpotTrade:
+		return true
+	}
+	return false
+}
+
+// IsSettlement returns true for entries settling trading results. Settlements
+// may take the USDT balance below zero when positions are margined with other
+// wallet assets; the shortfall stays covered by that collateral.
+func (t LedgerEntryType) IsSettlement() bool {
+	switch t {
+	case LedgerEntryTypeRealizedPnL, LedgerEntryTypeFee, LedgerEntryTypeFunding, LedgerEntryTypeLiquidation:
+		return true
+	}
+	return false
+}
+
+// LedgerEntry is an append-only record of a single balance movement.
+// It is the account leg of a double-entry posting: the contra leg is always
+// the simulator's house account for the entry type, so it is implied rather
+// than stored. The sum of an account's entries in an asset equals its
+// balance of that asset.
+type LedgerEntry struct {
+	ID           LedgerEntryID
+	AccountID    AccountID
+	Type         LedgerEntryType
+	Asset        Asset           // empty means QuoteAsset
+	Amount       decimal.Decimal // signed: positive = credit, negative = debit
+	BalanceAfter decimal.Decimal // asset balance right after this entry
+	TradeID      *TradeID
+	PositionID   *PositionID
+	Description  string
+	CreatedAt    time.Time
+}
+
+// LedgerFilter narrows down ledger queries
+type LedgerFilter struct {
+	Types  []LedgerEntryType
+	Asset  Asset
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
+
+// NewTradeLedgerEntry builds the entry settling a trade's realized PnL
+func NewTradeLedgerEntry(accountID AccountID, entryType LedgerEntryType, trade *Trade) *LedgerEntry {
+	tradeID := trade.ID
+	positionID := trade.PositionID
+	return &LedgerEntry{
+		AccountID:  accountID,
+		Type:       entryType,
+		Amount:     trade.PnL,
+		TradeID:    &tradeID,
+		PositionID: &positionID,
+	}
+}
+
+// NewFeeLedgerEntry builds the entry debiting a trade's fee
+func NewFeeLedgerEntry(accountID AccountID, trade *Trade) *LedgerEntry {
+	tradeID := trade.ID
+	entry := &LedgerEntry{
+		AccountID: accountID,
+		Type:      LedgerEntryTypeFee,
+		Amount:    trade.Fee.Neg(),
+		TradeID:   &tradeID,
+	}
+	if trade.PositionID != 0 {
+		positionID := trade.PositionID
+		entry.PositionID = &positionID
+	}
+	return entry
+}
+
+// TradeLedgerEntries builds the entries settling a trade: its realized PnL
+// under entryType and its fee. Zero amounts get no entry.
+func TradeLedgerEntries(accountID AccountID, entryType LedgerEntryType, trade *Trade) []*LedgerEntry {
+	var entries []*LedgerEntry
+	if !trade.PnL.IsZero() {
+		entries = append(entries, NewTradeLedgerEntry(accountID, entryType, trade))
+	}
+	if !trade.Fee.IsZero() {
+		entries = append(entries, NewFeeLedgerEntry(accountID, trade))
+	}
+	return entries
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+type NotificationID int64
+
+type NotificationType string
+
+const (
+	NotificationTypePriceAlert NotificationType = "PRICE_ALERT"
+)
+
+// Notification is an entry in the user's inbox
+type Notification struct {
+	ID        NotificationID
+	UserID    UserID
+	Type      NotificationType
+	Title     string
+	Message   string
+	AlertID   *AlertID
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+// IsRead returns true if the user has seen the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
@@ -20,6 +20,11 @@ type OrderType string
 const (
 	OrderTypeMarket OrderType = "MARKET"
 	OrderTypeLimit  OrderType = "LIMIT"
+	// Algo order types are parents that execute through child orders
+	OrderTypeTWAP    OrderType = "TWAP"
+	OrderTypeVWAP    OrderType = "VWAP"
+	OrderTypeIceberg OrderType = "ICEBERG"
+	OrderTypeScale   OrderType = "SCALE"
 )
 
 type OrderStatus string
@@ -34,6 +39,7 @@ const (
 type Order struct {
 	ID         OrderID
 	UserID     UserID
+	AccountID  AccountID
 	Symbol     string
 	Side       OrderSide
 	Type       OrderType
@@ -43,11 +49,50 @@ type Order struct {
 	Leverage   int
 	StopLoss   *decimal.Decimal
 	TakeProfit *decimal.Decimal
+	ParentID   *OrderID // set on the child orders of an algo order
 	FilledAt   *time.Time
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 }
 
+// OrderFilter narrows down order queries. Results are newest first; Cursor continues
+// after the last order of the previous page, Offset is kept for older clients.
+type OrderFilter struct {
+	Symbol   string
+	Side     OrderSide
+	Type     OrderType
+	Status   OrderStatus
+	ParentID *OrderID // child orders of an algo order
+	From     *time.Time
+	To       *time.Time
+	Cursor   *Cursor
+	Limit    int
+	Offset   int
+}
+
+// Validate checks that the filter only uses known sides, types and statuses
+func (f *OrderFilter) Validate() error {
+	switch f.Side {
+	case "", OrderSideBuy, OrderSideSell:
+	default:
+		return ErrInvalidOrderFilter
+	}
+	switch f.Type {
+	case "", OrderTypeMarket, OrderTypeLimit, OrderTypeTWAP, OrderTypeVWAP, OrderTypeIceberg, OrderTypeScale:
+	default:
+		return ErrInvalidOrderFilter
+	}
+	switch f.Status {
+	case "", OrderStatusPending, OrderStatusFilled, OrderStatusCancelled, OrderStatusRejected:
+	default:
+		return ErrInvalidOrderFilter
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
+
 // IsBuy returns true if this is a buy order
 func (o *Order) IsBuy() bool {
 	return o.Side == OrderSideBuy
@@ -68,6 +113,21 @@ func (o *Order) IsLimit() bool {
 	return o.Type == OrderTypeLimit
 }
 
+// IsAlgo returns true if this is the parent of an algo execution
+func (o *Order) IsAlgo() bool {
+	switch o.Type {
+	case OrderTypeTWAP, OrderTypeVWAP, OrderTypeIceberg, OrderTypeScale:
+		return true
+	}
+	return false
+}
+
+// IsResting returns true for algo orders whose slices rest as limit orders
+// until filled instead of following a schedule
+func (o *Order) IsResting() bool {
+	return o.Type == OrderTypeIceberg || o.Type == OrderTypeScale
+}
+
 // IsPending returns true if order is pending
 func (o *Order) IsPending() bool {
 	return o.Status == OrderStatusPending
@@ -26,6 +26,7 @@ const (
 type Position struct {
 	ID               PositionID
 	UserID           UserID
+	AccountID        AccountID
 	Symbol           string
 	Side             PositionSide
 	Status           PositionStatus
@@ -46,6 +47,17 @@ type Position struct {
 	ClosedAt         *time.Time
 }
 
+// PositionHistoryFilter narrows down closed position queries
+type PositionHistoryFilter struct {
+	Symbol string
+	Side   PositionSide   // empty = both sides
+	Status PositionStatus // CLOSED or LIQUIDATED, empty = both
+	From   *time.Time     // closed at or after
+	To     *time.Time     // closed before
+	Cursor *Cursor        // last position of the previous page
+	Limit  int
+}
+
 // IsLong returns true if position is long
 func (p *Position) IsLong() bool {
 	return p.Side == PositionSideLong
@@ -84,6 +96,23 @@ func (p *Position) ShouldLiquidate(markPrice decimal.Decimal) bool {
 	return markPrice.GreaterThanOrEqual(p.LiquidationPrice)
 }
 
+// LiquidationProgress returns how far the mark price has moved from the entry
+// towards the liquidation price: 0 at entry (or in profit), 1 at liquidation
+func (p *Position) LiquidationProgress(markPrice decimal.Decimal) decimal.Decimal {
+	span := p.EntryPrice.Sub(p.LiquidationPrice).Abs()
+	if span.IsZero() {
+		return decimal.Zero
+	}
+	adverse := p.EntryPrice.Sub(markPrice)
+	if p.IsShort() {
+		adverse = adverse.Neg()
+	}
+	if adverse.IsNegative() {
+		return decimal.Zero
+	}
+	return adverse.Div(span)
+}
+
 // ShouldTriggerStopLoss checks if stop loss should be triggered
 func (p *Position) ShouldTriggerStopLoss(markPrice decimal.Decimal) bool {
 	if p.StopLoss == nil {
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -11,25 +12,76 @@ type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	GetByID(ctx context.Context, id UserID) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
+	// GetByDisplayName looks the display name up case-insensitively
+	GetByDisplayName(ctx context.Context, name string) (*User, error)
 	Update(ctx context.Context, user *User) error
 }
 
 // AccountRepository defines account persistence operations
 type AccountRepository interface {
 	Create(ctx context.Context, account *Account) error
+	// CreateFunded creates the account and posts deposit to it in one transaction
+	CreateFunded(ctx context.Context, account *Account, deposit *LedgerEntry) error
+	// CreateSubAccount creates the account unless the user already has limit accounts
+	// outside competitions and challenges or one with the same name in any case.
+	// The check and the insert are atomic.
+	CreateSubAccount(ctx context.Context, account *Account, limit int) error
 	GetByID(ctx context.Context, id AccountID) (*Account, error)
-	GetByUserID(ctx context.Context, userID UserID) (*Account, error)
-	Update(ctx context.Context, account *Account) error
-	UpdateBalance(ctx context.Context, id AccountID, delta decimal.Decimal) error
+	// GetPrimaryByUserID returns the account created at registration
+	GetPrimaryByUserID(ctx context.Context, userID UserID) (*Account, error)
+	ListByUserID(ctx context.Context, userID UserID) ([]Account, error)
+	ListAll(ctx context.Context) ([]Account, error)
+	ListByCompetitionID(ctx context.Context, competitionID CompetitionID) ([]Account, error)
+	// Lock stops the account from placing orders, recording why
+	Lock(ctx context.Context, id AccountID, reason string) error
+}
+
+// AccountSeasonRepository defines season archive operations
+type AccountSeasonRepository interface {
+	// Archive stores the finished season and starts the next one on the account,
+	// posting adjustment, if any, in the same transaction
+	Archive(ctx context.Context, season *AccountSeason, nextStartingBalance decimal.Decimal, adjustment *LedgerEntry) error
+	GetByAccountID(ctx context.Context, accountID AccountID) ([]AccountSeason, error)
+	GetByAccountIDAndSeason(ctx context.Context, accountID AccountID, season int) (*AccountSeason, error)
+}
+
+// LedgerRepository defines balance ledger operations.
+// Post is the only way an account balance changes.
+type LedgerRepository interface {
+	Post(ctx context.Context, entry *LedgerEntry) error
+	// PostBatch posts several entries atomically (transfer or conversion legs)
+	PostBatch(ctx context.Context, entries ...*LedgerEntry) error
+	GetByAccountID(ctx context.Context, accountID AccountID, filter LedgerFilter) ([]LedgerEntry, error)
+}
+
+// WalletRepository reads non-USDT asset balances.
+// Balances are changed only through LedgerRepository.
+type WalletRepository interface {
+	GetByAccountID(ctx context.Context, accountID AccountID) ([]AssetBalance, error)
+}
+
+// SpotLotRepository defines spot cost basis lot operations
+type SpotLotRepository interface {
+	Create(ctx context.Context, lot *SpotLot) error
+	// GetOpenByAccountIDAndAsset returns lots with remaining quantity, oldest first
+	GetOpenByAccountIDAndAsset(ctx context.Context, accountID AccountID, asset Asset) ([]SpotLot, error)
+	GetOpenByAccountID(ctx context.Context, accountID AccountID) ([]SpotLot, error)
+	Update(ctx context.Context, lot *SpotLot) error
+	// Settle saves a spot fill in one transaction; ErrOrderNotPending if the order
+	// was claimed meanwhile, ErrInsufficientBalance if a leg overdraws its asset
+	Settle(ctx context.Context, fill *SpotFill) error
 }
 
 // OrderRepository defines order persistence operations
 type OrderRepository interface {
 	Create(ctx context.Context, order *Order) error
 	GetByID(ctx context.Context, id OrderID) (*Order, error)
-	GetByUserID(ctx context.Context, userID UserID, limit, offset int) ([]Order, error)
-	GetPendingByUserID(ctx context.Context, userID UserID) ([]Order, error)
+	GetByAccountID(ctx context.Context, accountID AccountID, filter OrderFilter) ([]Order, error)
+	// GetByAccountIDAfter returns up to limit orders with IDs above afterID created in [from, to), by ID. Nil bounds are open-ended.
+	GetByAccountIDAfter(ctx context.Context, accountID AccountID, from, to *time.Time, afterID OrderID, limit int) ([]Order, error)
+	GetPendingByAccountID(ctx context.Context, accountID AccountID) ([]Order, error)
 	GetPendingBySymbol(ctx context.Context, symbol string) ([]Order, error)
+	// Update saves a pending order; ErrOrderNotPending once it was filled, cancelled or rejected
 	Update(ctx context.Context, order *Order) error
 	Delete(ctx context.Context, id OrderID) error
 }
@@ -38,11 +90,17 @@ type OrderRepository interface {
 type PositionRepository interface {
 	Create(ctx context.Context, position *Position) error
 	GetByID(ctx context.Context, id PositionID) (*Position, error)
-	GetByUserID(ctx context.Context, userID UserID) ([]Position, error)
-	GetOpenByUserID(ctx context.Context, userID UserID) ([]Position, error)
-	GetOpenByUserIDAndSymbol(ctx context.Context, userID UserID, symbol string) (*Position, error)
+	GetByAccountID(ctx context.Context, accountID AccountID) ([]Position, error)
+	GetOpenByAccountID(ctx context.Context, accountID AccountID) ([]Position, error)
+	GetOpenByAccountIDAndSymbol(ctx context.Context, accountID AccountID, symbol string) (*Position, error)
 	GetAllOpen(ctx context.Context) ([]Position, error)
 	GetOpenBySymbol(ctx context.Context, symbol string) ([]Position, error)
+	// GetClosedByAccountID returns positions closed or liquidated in [from, to), oldest close first. Nil bounds are open-ended.
+	GetClosedByAccountID(ctx context.Context, accountID AccountID, from, to *time.Time) ([]Position, error)
+	// GetByAccountIDAfter returns up to limit positions with IDs above afterID opened in [from, to), by ID. Nil bounds are open-ended.
+	GetByAccountIDAfter(ctx context.Context, accountID AccountID, from, to *time.Time, afterID PositionID, limit int) ([]Position, error)
+	// GetHistory returns up to filter.Limit closed or liquidated positions, latest close first
+	GetHistory(ctx context.Context, accountID AccountID, filter PositionHistoryFilter) ([]Position, error)
 	Update(ctx context.Context, position *Position) error
 	UpdatePnL(ctx context.Context, id PositionID, markPrice, unrealizedPnL decimal.Decimal) error
 }
@@ -51,8 +109,187 @@ type PositionRepository interface {
 type TradeRepository interface {
 	Create(ctx context.Context, trade *Trade) error
 	GetByID(ctx context.Context, id TradeID) (*Trade, error)
-	GetByUserID(ctx context.Context, userID UserID, limit, offset int) ([]Trade, error)
+	GetByAccountID(ctx context.Context, accountID AccountID, filter TradeFilter) ([]Trade, error)
 	GetByPositionID(ctx context.Context, positionID PositionID) ([]Trade, error)
+	GetByAccountIDBetween(ctx context.Context, accountID AccountID, from time.Time, to *time.Time, limit, offset int) ([]Trade, error)
+	// GetByAccountIDAfter returns up to limit trades with IDs above afterID created in [from, to), by ID. Nil bounds are open-ended.
+	GetByAccountIDAfter(ctx context.Context, accountID AccountID, from, to *time.Time, afterID TradeID, limit int) ([]Trade, error)
+	Summarize(ctx context.Context, accountID AccountID, from time.Time, to *time.Time) (*TradeSummary, error)
+	// GetByPositionIDs returns every trade of the positions, oldest first
+	GetByPositionIDs(ctx context.Context, positionIDs []PositionID) ([]Trade, error)
+}
+
+// PriceAlertRepository defines price alert persistence operations
+type PriceAlertRepository interface {
+	Create(ctx context.Context, alert *PriceAlert) error
+	GetByID(ctx context.Context, id AlertID) (*PriceAlert, error)
+	GetByUserID(ctx context.Context, userID UserID, status AlertStatus) ([]PriceAlert, error)
+	GetAllActive(ctx context.Context) ([]PriceAlert, error)
+	CountActiveByUserID(ctx context.Context, userID UserID) (int, error)
+	Update(ctx context.Context, alert *PriceAlert) error
+	// MarkTriggered moves an active alert to TRIGGERED; returns false if it was no longer active
+	MarkTriggered(ctx context.Context, alert *PriceAlert) (bool, error)
+	Delete(ctx context.Context, id AlertID) error
+}
+
+// NotificationRepository defines notification inbox operations
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *Notification) error
+	GetByUserID(ctx context.Context, userID UserID, unreadOnly bool, limit, offset int) ([]Notification, error)
+	CountUnread(ctx context.Context, userID UserID) (int, error)
+	MarkRead(ctx context.Context, userID UserID, id NotificationID) error
+	MarkAllRead(ctx context.Context, userID UserID) error
+}
+
+// WebhookRepository defines webhook subscription persistence operations
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	GetByID(ctx context.Context, id WebhookID) (*Webhook, error)
+	GetByUserID(ctx context.Context, userID UserID) ([]Webhook, error)
+	GetActiveByUserID(ctx context.Context, userID UserID) ([]Webhook, error)
+	Update(ctx context.Context, webhook *Webhook) error
+	Delete(ctx context.Context, id WebhookID) error
+}
+
+// WebhookDeliveryRepository defines the webhook delivery log, which is also the retry queue
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+	GetByID(ctx context.Context, id WebhookDeliveryID) (*WebhookDelivery, error)
+	GetByWebhookID(ctx context.Context, webhookID WebhookID, limit, offset int) ([]WebhookDelivery, error)
+	// GetDue returns pending deliveries whose next attempt is due, oldest first
+	GetDue(ctx context.Context, limit int) ([]WebhookDelivery, error)
+	Update(ctx context.Context, delivery *WebhookDelivery) error
+}
+
+// EquitySnapshotRepository defines equity curve persistence operations
+type EquitySnapshotRepository interface {
+	// Record stores a minute snapshot and folds it into its hourly and daily buckets
+	Record(ctx context.Context, snapshot *EquitySnapshot) error
+	GetByAccountID(ctx context.Context, accountID AccountID, resolution EquityResolution, from, to time.Time) ([]EquitySnapshot, error)
+	// DeleteBefore prunes snapshots of the resolution older than the given time
+	DeleteBefore(ctx context.Context, resolution EquityResolution, before time.Time) (int64, error)
+}
+
+// LeaderboardRepository aggregates trade history for leaderboards
+type LeaderboardRepository interface {
+	// GetPerformance returns opted-in users' results since from; nil means all time
+	GetPerformance(ctx context.Context, from *time.Time) ([]TraderPerformance, error)
+}
+
+// CompetitionRepository defines competition persistence operations
+type CompetitionRepository interface {
+	Create(ctx context.Context, competition *Competition) error
+	GetByID(ctx context.Context, id CompetitionID) (*Competition, error)
+	// List returns all competitions, latest start first
+	List(ctx context.Context) ([]Competition, error)
+	// GetUnfinalized returns competitions that ended before the given time without frozen standings
+	GetUnfinalized(ctx context.Context, endedBefore time.Time) ([]Competition, error)
+	// GetPerformance aggregates the closing trades of each competition account with trades
+	GetPerformance(ctx context.Context, id CompetitionID) ([]TraderPerformance, error)
+	// Finalize stores the standings and marks the competition finished atomically
+	Finalize(ctx context.Context, id CompetitionID, standings []CompetitionStanding, at time.Time) error
+	GetStandings(ctx context.Context, id CompetitionID) ([]CompetitionStanding, error)
+}
+
+// ChallengeRepository defines challenge persistence operations
+type ChallengeRepository interface {
+	Create(ctx context.Context, challenge *Challenge) error
+	GetByID(ctx context.Context, id ChallengeID) (*Challenge, error)
+	GetByAccountID(ctx context.Context, accountID AccountID) (*Challenge, error)
+	// ListByUserID returns the user's challenges, newest first
+	ListByUserID(ctx context.Context, userID UserID) ([]Challenge, error)
+	GetActive(ctx context.Context) ([]Challenge, error)
+	// UpdateProgress saves the day start equity and trading day count of an active challenge
+	UpdateProgress(ctx context.Context, challenge *Challenge) error
+	// End moves an active challenge to a final status. Returns false if it had already ended.
+	End(ctx context.Context, id ChallengeID, status ChallengeStatus, reason string, at time.Time) (bool, error)
+}
+
+// GridBotRepository defines grid bot persistence operations
+type GridBotRepository interface {
+	Create(ctx context.Context, bot *GridBot) error
+	GetByID(ctx context.Context, id GridBotID) (*GridBot, error)
+	// ListByAccountID returns the account's bots, newest first
+	ListByAccountID(ctx context.Context, accountID AccountID) ([]GridBot, error)
+	GetActive(ctx context.Context) ([]GridBot, error)
+	// Update saves the status, results and resting orders of a bot
+	Update(ctx context.Context, bot *GridBot) error
+}
+
+// DCARepository defines DCA plan and execution history persistence operations
+type DCARepository interface {
+	Create(ctx context.Context, plan *DCAPlan) error
+	GetByID(ctx context.Context, id DCAPlanID) (*DCAPlan, error)
+	// ListByAccountID returns the account's plans, newest first
+	ListByAccountID(ctx context.Context, accountID AccountID) ([]DCAPlan, error)
+	GetActive(ctx context.Context) ([]DCAPlan, error)
+	// Update saves the status, schedule and cycle of a plan
+	Update(ctx context.Context, plan *DCAPlan) error
+	// Claim saves the plan and creates the executions in one transaction, before their orders are placed
+	Claim(ctx context.Context, plan *DCAPlan, executions []*DCAExecution) error
+	// UpdateExecution saves the status and result of an execution
+	UpdateExecution(ctx context.Context, execution *DCAExecution) error
+	// ListExecutions returns the plan's executions, newest first
+	ListExecutions(ctx context.Context, planID DCAPlanID, limit int) ([]DCAExecution, error)
+}
+
+// AlgoOrderRepository defines algo order schedule persistence operations
+type AlgoOrderRepository interface {
+	// Create saves the schedule of a parent order with its slices
+	Create(ctx context.Context, algo *AlgoOrder) error
+	GetByOrderID(ctx context.Context, orderID OrderID) (*AlgoOrder, error)
+	// GetActive returns the algo orders whose parent is pending or that still have slices to resolve
+	GetActive(ctx context.Context) ([]AlgoOrder, error)
+	// UpdateSlice saves the quantity, status and result of a slice
+	UpdateSlice(ctx context.Context, slice *AlgoSlice) error
+}
+
+// FollowRepository defines copy trading follow and audit trail persistence operations
+type FollowRepository interface {
+	Create(ctx context.Context, follow *Follow) error
+	GetByID(ctx context.Context, id FollowID) (*Follow, error)
+	// ListByFollowerAccountID returns the account's follows, newest first
+	ListByFollowerAccountID(ctx context.Context, accountID AccountID) ([]Follow, error)
+	// GetActiveByLeaderAccountID returns the active follows copying the account
+	GetActiveByLeaderAccountID(ctx context.Context, accountID AccountID) ([]Follow, error)
+	// CountActiveByLeaderAccountID returns how many accounts copy the account
+	CountActiveByLeaderAccountID(ctx context.Context, accountID AccountID) (int, error)
+	// Update saves the status and results of a follow
+	Update(ctx context.Context, follow *Follow) error
+	CreateCopyTrade(ctx context.Context, trade *CopyTrade) error
+	// ListCopyTrades returns the follow's audit trail, newest first
+	ListCopyTrades(ctx context.Context, followID FollowID, limit int) ([]CopyTrade, error)
+}
+
+// ScriptRepository defines strategy script and script log persistence operations
+type ScriptRepository interface {
+	Create(ctx context.Context, script *Script) error
+	GetByID(ctx context.Context, id ScriptID) (*Script, error)
+	// ListByAccountID returns the account's scripts, newest first
+	ListByAccountID(ctx context.Context, accountID AccountID) ([]Script, error)
+	GetRunning(ctx context.Context) ([]Script, error)
+	// Update saves the source, settings, status, state and resting orders of a script
+	Update(ctx context.Context, script *Script) error
+	Delete(ctx context.Context, id ScriptID) error
+	// AppendLogs stores log lines and drops the script's oldest beyond keep
+	AppendLogs(ctx context.Context, id ScriptID, logs []ScriptLog, keep int) error
+	// ListLogs returns the script's latest log lines, newest first
+	ListLogs(ctx context.Context, id ScriptID, limit int) ([]ScriptLog, error)
+}
+
+// SignalStrategyRepository defines signal strategy and signal log persistence operations
+type SignalStrategyRepository interface {
+	Create(ctx context.Context, strategy *SignalStrategy) error
+	GetByID(ctx context.Context, id SignalStrategyID) (*SignalStrategy, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*SignalStrategy, error)
+	// ListByAccountID returns the account's strategies, newest first
+	ListByAccountID(ctx context.Context, accountID AccountID) ([]SignalStrategy, error)
+	// Update saves the name, token, template and active flag of a strategy
+	Update(ctx context.Context, strategy *SignalStrategy) error
+	Delete(ctx context.Context, id SignalStrategyID) error
+	CreateSignal(ctx context.Context, signal *Signal) error
+	// ListSignals returns the strategy's signal log, newest first
+	ListSignals(ctx context.Context, strategyID SignalStrategyID, limit int) ([]Signal, error)
 }
 
 // PriceCache provides in-memory price lookups
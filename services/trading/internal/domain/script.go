@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"time"
+)
+
+type ScriptID int64
+
+type ScriptStatus string
+
+const (
+	ScriptStatusStopped ScriptStatus = "STOPPED"
+	ScriptStatusRunning ScriptStatus = "RUNNING"
+	ScriptStatusError   ScriptStatus = "ERROR" // stopped by a failed callback
+)
+
+const (
+	// MaxScriptSize bounds the source of a script, in bytes
+	MaxScriptSize = 64 * 1024
+	// MaxScriptStateSize bounds the JSON encoded state a script keeps between callbacks
+	MaxScriptStateSize = 64 * 1024
+	// maxScriptNameLength bounds the name of a script
+	maxScriptNameLength = 64
+)
+
+// Script is a user written Starlark strategy trading one perpetual symbol.
+// While running it is called back on every price update and on every fill of
+// its account on the symbol; the state dict it is passed survives restarts.
+type Script struct {
+	ID        ScriptID
+	UserID    UserID
+	AccountID AccountID
+	Name      string
+	Symbol    string
+	Source    string
+	Params    map[string]float64 // read only inside the script
+
+	Status    ScriptStatus
+	LastError string
+	State     string    // JSON object
+	Orders    []OrderID // resting limit orders the script placed
+
+	StartedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate checks the settings of a script; the source is compiled separately
+func (s *Script) Validate() error {
+	if s.Name == "" || len(s.Name) > maxScriptNameLength {
+		return ErrInvalidScript
+	}
+	if s.Symbol == "" {
+		return ErrInvalidScript
+	}
+	if s.Source == "" || len(s.Source) > MaxScriptSize {
+		return ErrInvalidScript
+	}
+	return nil
+}
+
+// IsRunning returns true if the script receives callbacks
+func (s *Script) IsRunning() bool {
+	return s.Status == ScriptStatusRunning
+}
+
+// RemoveOrder forgets a resting order, returning false if it is not the script's
+func (s *Script) RemoveOrder(id OrderID) bool {
+	for i, o := range s.Orders {
+		if o == id {
+			s.Orders = append(s.Orders[:i], s.Orders[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type ScriptLogLevel string
+
+const (
+	ScriptLogInfo  ScriptLogLevel = "INFO"  // written by the script
+	ScriptLogError ScriptLogLevel = "ERROR" // failed callbacks and rejected orders
+)
+
+// ScriptLog is a line of a script's log
+type ScriptLog struct {
+	ID        int64
+	ScriptID  ScriptID
+	Level     ScriptLogLevel
+	Message   string
+	CreatedAt time.Time
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AccountSeason is an archived run of an account, closed by a reset
+type AccountSeason struct {
+	AccountID       AccountID
+	Season          int
+	StartedAt       time.Time
+	EndedAt         *time.Time // nil for the current season
+	StartingBalance decimal.Decimal
+	FinalBalance    decimal.Decimal
+	Stats           TradeSummary
+}
+
+// ROI returns the season's return on its starting balance
+func (s *AccountSeason) ROI() decimal.Decimal {
+	if !s.StartingBalance.IsPositive() {
+		return decimal.Zero
+	}
+	return s.FinalBalance.Sub(s.StartingBalance).Div(s.StartingBalance)
+}
+
+// TradeSummary aggregates a set of trades
+type TradeSummary struct {
+	TotalTrades   int
+	ClosedTrades  int // CLOSE, LIQUIDATE and SPOT_SELL trades
+	WinningTrades int
+	LosingTrades  int
+	Liquidations  int
+	RealizedPnL   decimal.Decimal
+	Volume        decimal.Decimal // sum of quantity * price
+}
+
+// WinRate returns the share of closed trades with positive PnL
+func (s TradeSummary) WinRate() decimal.Decimal {
+	if s.ClosedTrades == 0 {
+		return decimal.Zero
+	}
+	return decimal.NewFromInt(int64(s.WinningTrades)).Div(decimal.NewFromInt(int64(s.ClosedTrades)))
+}
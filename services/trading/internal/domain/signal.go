@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type SignalStrategyID int64
+
+type SignalID int64
+
+// MaxSignalPayloadSize bounds the body of a signal request
+const MaxSignalPayloadSize = 4 << 10
+
+const maxSignalStrategyNameLength = 64
+
+// SignalTemplate fills in what a strategy's signals leave out and bounds what
+// they may ask for
+type SignalTemplate struct {
+	Symbol        string           // when set, the only symbol signals may trade
+	Quantity      *decimal.Decimal // for signals without a quantity
+	MaxQuantity   *decimal.Decimal // larger signals are refused
+	Leverage      int              // for signals without a leverage, 0 = 1x
+	StopLossPct   *decimal.Decimal // stop distance from the price for opening signals without one
+	TakeProfitPct *decimal.Decimal // take profit distance from the price, likewise
+}
+
+// SignalStrategy receives trading signals from external alerts, e.g. charting
+// platforms, on a URL with a secret token, and trades them on an account
+type SignalStrategy struct {
+	ID        SignalStrategyID
+	UserID    UserID
+	AccountID AccountID
+	Name      string
+	Token     string // only set on creation and rotation; stored as TokenHash
+	TokenHash string // hex SHA-256 of the token
+	Template  SignalTemplate
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate checks the name and template
+func (s *SignalStrategy) Validate() error {
+	if s.Name == "" || len(s.Name) > maxSignalStrategyNameLength {
+		return ErrInvalidSignalStrategy
+	}
+	t := s.Template
+	if t.Quantity != nil && !t.Quantity.IsPositive() {
+		return ErrInvalidSignalStrategy
+	}
+	if t.MaxQuantity != nil && !t.MaxQuantity.IsPositive() {
+		return ErrInvalidSignalStrategy
+	}
+	if t.Quantity != nil && t.MaxQuantity != nil && t.Quantity.GreaterThan(*t.MaxQuantity) {
+		return ErrInvalidSignalStrategy
+	}
+	if t.Leverage < 0 {
+		return ErrInvalidSignalStrategy
+	}
+	hundred := decimal.NewFromInt(100)
+	for _, pct := range []*decimal.Decimal{t.StopLossPct, t.TakeProfitPct} {
+		if pct != nil && (!pct.IsPositive() || pct.GreaterThanOrEqual(hundred)) {
+			return ErrInvalidSignalStrategy
+		}
+	}
+	return nil
+}
+
+// SignalAction is what a signal asks for
+type SignalAction string
+
+const (
+	SignalActionBuy   SignalAction = "BUY"
+	SignalActionSell  SignalAction = "SELL"
+	SignalActionClose SignalAction = "CLOSE" // closes the open position on the symbol
+)
+
+type SignalStatus string
+
+const (
+	SignalExecuted SignalStatus = "EXECUTED"
+	SignalFailed   SignalStatus = "FAILED"
+	SignalSkipped  SignalStatus = "SKIPPED" // nothing to do, e.g. a close while flat
+)
+
+// Signal is an entry of a strategy's signal log: a received payload and what
+// was done for it
+type Signal struct {
+	ID         SignalID
+	StrategyID SignalStrategyID
+	Payload    string
+	Action     SignalAction
+	Symbol     string
+	Side       OrderSide
+	Status     SignalStatus
+	OrderID    *OrderID
+	Quantity   decimal.Decimal
+	Price      decimal.Decimal
+	Error      string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type SpotLotID int64
+
+// SpotLot is the quantity of an asset acquired by one spot buy. Lots are
+// consumed first-in first-out by spot sells, which realize PnL against
+// the lot's purchase price.
+type SpotLot struct {
+	ID        SpotLotID
+	AccountID AccountID
+	Asset     Asset
+	TradeID   TradeID
+	Quantity  decimal.Decimal // bought quantity
+	Remaining decimal.Decimal // quantity not sold yet
+	Price     decimal.Decimal // purchase price per unit
+	CreatedAt time.Time
+	ClosedAt  *time.Time
+}
+
+// SpotFill is everything a spot fill writes: the filled order, its trade, the
+// trade's ledger legs and the lots it opens or consumes. They are saved
+// together, so a leg the balance cannot cover leaves the order pending.
+type SpotFill struct {
+	Order    *Order
+	Trade    *Trade
+	Entries  []*LedgerEntry // trade ID is set once the trade is saved
+	Opened   *SpotLot       // lot of a buy; trade ID is set once the trade is saved
+	Consumed []SpotLot      // lots a sell took quantity from
+}
+
+// CostBasis returns the purchase cost of the remaining quantity
+func (l *SpotLot) CostBasis() decimal.Decimal {
+	return l.Remaining.Mul(l.Price)
+}
+
+// Consume sells up to quantity from the lot at price and returns the
+// consumed quantity and the realized PnL
+func (l *SpotLot) Consume(quantity, price decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	consumed := decimal.Min(quantity, l.Remaining)
+	l.Remaining = l.Remaining.Sub(consumed)
+	if l.Remaining.IsZero() {
+		now := time.Now()
+		l.ClosedAt = &now
+	}
+	return consumed, price.Sub(l.Price).Mul(consumed)
+}
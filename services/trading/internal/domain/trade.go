@@ -15,12 +15,15 @@ const (
 	TradeTypeClose     TradeType = "CLOSE"
 	TradeTypeAdd       TradeType = "ADD"       // adding to existing position
 	TradeTypeLiquidate TradeType = "LIQUIDATE"
+	TradeTypeSpotBuy   TradeType = "SPOT_BUY"  // spot purchase, opens a lot
+	TradeTypeSpotSell  TradeType = "SPOT_SELL" // spot sale, consumes lots
 )
 
 type Trade struct {
 	ID         TradeID
 	UserID     UserID
-	PositionID PositionID
+	AccountID  AccountID
+	PositionID PositionID // zero for spot trades
 	OrderID    OrderID
 	Symbol     string
 	Side       PositionSide
@@ -32,10 +35,42 @@ type Trade struct {
 	CreatedAt  time.Time
 }
 
+// TradeFilter narrows down trade queries. Results are newest first; Cursor continues
+// after the last trade of the previous page, Offset is kept for older clients.
+type TradeFilter struct {
+	Symbol string
+	Side   PositionSide
+	Type   TradeType
+	From   *time.Time
+	To     *time.Time
+	Cursor *Cursor
+	Limit  int
+	Offset int
+}
+
+// Validate checks that the filter only uses known sides and types
+func (f *TradeFilter) Validate() error {
+	switch f.Side {
+	case "", PositionSideLong, PositionSideShort:
+	default:
+		return ErrInvalidTradeFilter
+	}
+	switch f.Type {
+	case "", TradeTypeOpen, TradeTypeClose, TradeTypeAdd, TradeTypeLiquidate, TradeTypeSpotBuy, TradeTypeSpotSell:
+	default:
+		return ErrInvalidTradeFilter
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
+
 // TradeEvent represents a trade event to be published to Kafka
 type TradeEvent struct {
 	TradeID    int64     `json:"trade_id"`
 	UserID     int64     `json:"user_id"`
+	AccountID  int64     `json:"account_id"`
 	PositionID int64     `json:"position_id"`
 	OrderID    int64     `json:"order_id"`
 	Symbol     string    `json:"symbol"`
@@ -53,6 +88,7 @@ func (t *Trade) ToEvent() TradeEvent {
 	return TradeEvent{
 		TradeID:    int64(t.ID),
 		UserID:     int64(t.UserID),
+		AccountID:  int64(t.AccountID),
 		PositionID: int64(t.PositionID),
 		OrderID:    int64(t.OrderID),
 		Symbol:     t.Symbol,
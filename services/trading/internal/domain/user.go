@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"regexp"
 	"time"
 )
 
@@ -12,4 +13,20 @@ type User struct {
 	PasswordHash string
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+
+	// Public name shown on leaderboards; empty until the user sets one
+	DisplayName      string
+	LeaderboardOptIn bool
+
+	IsAdmin bool
+}
+
+var displayNamePattern = regexp.MustCompile(`^[A-Za-z0-9_\-]{3,32}$`)
+
+// ValidateDisplayName checks a leaderboard display name: 3-32 letters, digits, '_' or '-'
+func ValidateDisplayName(name string) error {
+	if !displayNamePattern.MatchString(name) {
+		return ErrInvalidDisplayName
+	}
+	return nil
 }
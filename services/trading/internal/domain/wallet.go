@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Asset is a wallet currency that can be held as collateral
+type Asset string
+
+const (
+	AssetUSDT Asset = "USDT"
+	AssetUSDC Asset = "USDC"
+	AssetBTC  Asset = "BTC"
+	AssetETH  Asset = "ETH"
+)
+
+// QuoteAsset is the asset positions are margined and settled in.
+// It is kept in Account.Balance; every other asset lives in the wallet.
+const QuoteAsset = AssetUSDT
+
+// WalletAssets lists the supported assets in display order
+var WalletAssets = []Asset{AssetUSDT, AssetUSDC, AssetBTC, AssetETH}
+
+// IsValid returns true if the asset is supported
+func (a Asset) IsValid() bool {
+	for _, asset := range WalletAssets {
+		if a == asset {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStable returns true for dollar stablecoins valued 1:1 against USDT
+func (a Asset) IsStable() bool {
+	return a == AssetUSDT || a == AssetUSDC
+}
+
+// Symbol returns the USDT market used to price the asset
+func (a Asset) Symbol() string {
+	return string(a) + string(QuoteAsset)
+}
+
+// AssetBalance is the amount of a non-quote asset held by an account
+type AssetBalance struct {
+	AccountID AccountID
+	Asset     Asset
+	Amount    decimal.Decimal
+	UpdatedAt time.Time
+}
+
+// CollateralValue is an asset holding valued in USDT for margin purposes
+type CollateralValue struct {
+	Asset   Asset
+	Amount  decimal.Decimal
+	Price   decimal.Decimal // USDT price of one unit
+	Haircut decimal.Decimal // fraction of market value ignored (e.g., 0.1 = 10%)
+	Value   decimal.Decimal // amount * price * (1 - haircut)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+type WebhookID int64
+
+type WebhookDeliveryID int64
+
+// Webhook is a user's HTTP endpoint subscribed to account events
+type Webhook struct {
+	ID        WebhookID
+	UserID    UserID
+	URL       string
+	Secret    string // HMAC-SHA256 key for payload signatures
+	Events    []EventType
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Subscribes returns true if the webhook receives the event type
+func (w *Webhook) Subscribes(eventType EventType) bool {
+	for _, e := range w.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "FAILED" // retries exhausted
+)
+
+// WebhookDelivery is one event sent to one webhook, retried until it
+// succeeds or runs out of attempts
+type WebhookDelivery struct {
+	ID             WebhookDeliveryID
+	WebhookID      WebhookID
+	EventType      EventType
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastStatusCode int
+	LastError      string
+	RedeliveryOf   *WebhookDeliveryID
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
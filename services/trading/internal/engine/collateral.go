@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+// assetPrecision matches the DECIMAL(20, 8) wallet columns
+const assetPrecision = 8
+
+// CollateralCalculator values wallet assets for margin and prices conversions
+type CollateralCalculator struct {
+	haircuts map[domain.Asset]decimal.Decimal
+}
+
+func NewCollateralCalculator(haircuts map[string]float64) *CollateralCalculator {
+	c := &CollateralCalculator{haircuts: make(map[domain.Asset]decimal.Decimal, len(haircuts))}
+	for asset, haircut := range haircuts {
+		c.haircuts[domain.Asset(asset)] = decimal.NewFromFloat(haircut)
+	}
+	return c
+}
+
+// Haircut returns the fraction of the asset's market value ignored as collateral
+func (c *CollateralCalculator) Haircut(asset domain.Asset) decimal.Decimal {
+	if asset == domain.QuoteAsset {
+		return decimal.Zero
+	}
+	return c.haircuts[asset]
+}
+
+// Value prices wallet balances at the bid and applies haircuts.
+// Assets without a price count as zero collateral.
+func (c *CollateralCalculator) Value(balances []domain.AssetBalance, prices domain.PriceCache) []domain.CollateralValue {
+	values := make([]domain.CollateralValue, 0, len(balances))
+	for _, b := range balances {
+		haircut := c.Haircut(b.Asset)
+		price, err := c.price(b.Asset, domain.OrderSideSell, prices)
+		if err != nil {
+			price = decimal.Zero
+		}
+		values = append(values, domain.CollateralValue{
+			Asset:   b.Asset,
+			Amount:  b.Amount,
+			Price:   price,
+			Haircut: haircut,
+			Value:   b.Amount.Mul(price).Mul(decimal.NewFromInt(1).Sub(haircut)),
+		})
+	}
+	return values
+}
+
+// Convert returns how much of `to` is received for amount of `from`.
+// The source asset is sold at the bid and the target bought at the ask.
+func (c *CollateralCalculator) Convert(from, to domain.Asset, amount decimal.Decimal, prices domain.PriceCache) (decimal.Decimal, error) {
+	sellPrice, err := c.price(from, domain.OrderSideSell, prices)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	buyPrice, err := c.price(to, domain.OrderSideBuy, prices)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return amount.Mul(sellPrice).Div(buyPrice).Truncate(assetPrecision), nil
+}
+
+// price returns the USDT price of one unit of the asset; stablecoins are 1:1
+func (c *CollateralCalculator) price(asset domain.Asset, side domain.OrderSide, prices domain.PriceCache) (decimal.Decimal, error) {
+	if asset.IsStable() {
+		return decimal.NewFromInt(1), nil
+	}
+	p, ok := prices.Get(asset.Symbol())
+	if !ok {
+		return decimal.Zero, domain.ErrPriceNotAvailable
+	}
+	if side == domain.OrderSideBuy {
+		return decimal.NewFromFloat(p.Ask), nil
+	}
+	return decimal.NewFromFloat(p.Bid), nil
+}
@@ -11,15 +11,17 @@ type Engine struct {
 	MarginCalc      *MarginCalculator
 	PnLCalc         *PnLCalculator
 	LiquidationCalc *LiquidationChecker
+	CollateralCalc  *CollateralCalculator
 	maxLeverage     int
 }
 
-func NewEngine(maxLeverage int, maintenanceRate float64) *Engine {
+func NewEngine(maxLeverage int, maintenanceRate float64, haircuts map[string]float64) *Engine {
 	marginCalc := NewMarginCalculator(maintenanceRate)
 	return &Engine{
 		MarginCalc:      marginCalc,
 		PnLCalc:         NewPnLCalculator(),
 		LiquidationCalc: NewLiquidationChecker(marginCalc),
+		CollateralCalc:  NewCollateralCalculator(haircuts),
 		maxLeverage:     maxLeverage,
 	}
 }
@@ -0,0 +1,272 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"trading/internal/domain"
+)
+
+type AlertInfo struct {
+	ID             int64   `json:"id"`
+	Symbol         string  `json:"symbol"`
+	Condition      string  `json:"condition"`
+	TargetPrice    *string `json:"target_price"`
+	ReferencePrice *string `json:"reference_price"`
+	ChangePercent  *string `json:"change_percent"`
+	Window         string  `json:"window"`
+	Status         string  `json:"status"`
+	TriggeredPrice *string `json:"triggered_price"`
+}
+
+type NotificationInfo struct {
+	ID      int64  `json:"id"`
+	Type    string `json:"type"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	AlertID *int64 `json:"alert_id"`
+	Read    bool   `json:"read"`
+}
+
+type NotificationsInfo struct {
+	Notifications []NotificationInfo `json:"notifications"`
+	Unread        int                `json:"unread"`
+}
+
+func createAlert(t *testing.T, token string, body map[string]interface{}) AlertInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", "/alerts", body, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var alert AlertInfo
+	parseResponse(t, resp, &alert)
+	return alert
+}
+
+func getNotifications(t *testing.T, token, query string) NotificationsInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", "/notifications"+query, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result NotificationsInfo
+	parseResponse(t, resp, &result)
+	return result
+}
+
+func processPrice(t *testing.T, symbol string, bid, ask float64) {
+	t.Helper()
+
+	err := priceProcessor.ProcessPrice(testCtx, &domain.Price{
+		Symbol:    symbol,
+		Bid:       bid,
+		Ask:       ask,
+		Timestamp: time.Now(),
+	})
+	require.NoError(t, err)
+}
+
+func TestAlert_CRUD(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("alert_crud"), "password123")
+	other := registerUser(t, uniqueEmail("alert_other"), "password123")
+
+	alert := createAlert(t, user.Token, map[string]interface{}{
+		"symbol":       "BTCUSDT",
+		"condition":    "CROSS",
+		"target_price": "52000",
+		"note":         "breakout",
+	})
+	assert.Equal(t, "ACTIVE", alert.Status)
+	require.NotNil(t, alert.ReferencePrice)
+	assert.Equal(t, "50005", *alert.ReferencePrice)
+
+	resp := makeRequest(t, "GET", "/alerts", nil, user.Token)
+	var alerts []AlertInfo
+	parseResponse(t, resp, &alerts)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, alert.ID, alerts[0].ID)
+
+	// Other users cannot see the alert
+	resp = makeRequest(t, "GET", fmt.Sprintf("/alerts/%d", alert.ID), nil, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = makeRequest(t, "PATCH", fmt.Sprintf("/alerts/%d", alert.ID), map[string]interface{}{
+		"target_price": "53000",
+	}, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var updated AlertInfo
+	parseResponse(t, resp, &updated)
+	assert.Equal(t, "53000", *updated.TargetPrice)
+
+	resp = makeRequest(t, "DELETE", fmt.Sprintf("/alerts/%d", alert.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, "GET", fmt.Sprintf("/alerts/%d", alert.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAlert_Validation(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("alert_invalid"), "password123")
+
+	tests := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{"unknown symbol", map[string]interface{}{"symbol": "DOGEUSDT", "condition": "ABOVE", "target_price": "1"}},
+		{"unknown condition", map[string]interface{}{"symbol": "BTCUSDT", "condition": "EQUALS", "target_price": "1"}},
+		{"missing target", map[string]interface{}{"symbol": "BTCUSDT", "condition": "ABOVE"}},
+		{"negative target", map[string]interface{}{"symbol": "BTCUSDT", "condition": "BELOW", "target_price": "-5"}},
+		{"missing window", map[string]interface{}{"symbol": "BTCUSDT", "condition": "CHANGE_PERCENT", "change_percent": "3"}},
+		{"window too long", map[string]interface{}{"symbol": "BTCUSDT", "condition": "CHANGE_PERCENT", "change_percent": "3", "window": "48h"}},
+		{"bad window", map[string]interface{}{"symbol": "BTCUSDT", "condition": "CHANGE_PERCENT", "change_percent": "3", "window": "soon"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := makeRequest(t, "POST", "/alerts", tt.body, user.Token)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestAlert_TriggersOnceAndNotifies(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("alert_trigger"), "password123")
+
+	above := createAlert(t, user.Token, map[string]interface{}{
+		"symbol":       "BTCUSDT",
+		"condition":    "ABOVE",
+		"target_price": "51000",
+		"note":         "take profit zone",
+	})
+	below := createAlert(t, user.Token, map[string]interface{}{
+		"symbol":       "BTCUSDT",
+		"condition":    "BELOW",
+		"target_price": "45000",
+	})
+
+	processPrice(t, "BTCUSDT", 50500, 50510)
+	assert.Empty(t, getNotifications(t, user.Token, "").Notifications)
+
+	processPrice(t, "BTCUSDT", 51000, 51010)
+	processPrice(t, "BTCUSDT", 51100, 51110) // already triggered, fires once
+
+	inbox := getNotifications(t, user.Token, "")
+	require.Len(t, inbox.Notifications, 1)
+	assert.Equal(t, 1, inbox.Unread)
+	assert.Equal(t, "PRICE_ALERT", inbox.Notifications[0].Type)
+	assert.Equal(t, "BTCUSDT is above 51000", inbox.Notifications[0].Title)
+	assert.Equal(t, "take profit zone", inbox.Notifications[0].Message)
+	require.NotNil(t, inbox.Notifications[0].AlertID)
+	assert.Equal(t, above.ID, *inbox.Notifications[0].AlertID)
+
+	resp := makeRequest(t, "GET", "/alerts?status=TRIGGERED", nil, user.Token)
+	var triggered []AlertInfo
+	parseResponse(t, resp, &triggered)
+	require.Len(t, triggered, 1)
+	assert.Equal(t, above.ID, triggered[0].ID)
+	assert.Equal(t, "51005", *triggered[0].TriggeredPrice)
+
+	resp = makeRequest(t, "GET", fmt.Sprintf("/alerts/%d", below.ID), nil, user.Token)
+	var active AlertInfo
+	parseResponse(t, resp, &active)
+	assert.Equal(t, "ACTIVE", active.Status)
+
+	// Reading
+	resp = makeRequest(t, "POST", fmt.Sprintf("/notifications/%d/read", inbox.Notifications[0].ID), nil, user.Token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	inbox = getNotifications(t, user.Token, "?unread=true")
+	assert.Empty(t, inbox.Notifications)
+	assert.Equal(t, 0, inbox.Unread)
+
+	// Re-arming a triggered alert
+	resp = makeRequest(t, "PATCH", fmt.Sprintf("/alerts/%d", above.ID), map[string]interface{}{
+		"target_price": "52000",
+	}, user.Token)
+	var rearmed AlertInfo
+	parseResponse(t, resp, &rearmed)
+	assert.Equal(t, "ACTIVE", rearmed.Status)
+	assert.Nil(t, rearmed.TriggeredPrice)
+
+	processPrice(t, "BTCUSDT", 52000, 52010)
+	assert.Len(t, getNotifications(t, user.Token, "").Notifications, 2)
+}
+
+func TestAlert_ChangePercent(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("ETHUSDT", 3000, 3002)
+
+	user := registerUser(t, uniqueEmail("alert_change"), "password123")
+
+	createAlert(t, user.Token, map[string]interface{}{
+		"symbol":         "ETHUSDT",
+		"condition":      "CHANGE_PERCENT",
+		"change_percent": "3",
+		"window":         "1h",
+	})
+
+	// 3001 -> 3060: +1.97%
+	processPrice(t, "ETHUSDT", 3000, 3002)
+	processPrice(t, "ETHUSDT", 3059, 3061)
+	assert.Empty(t, getNotifications(t, user.Token, "").Notifications)
+
+	// 3060 -> 2900: -5.23% from the window high
+	processPrice(t, "ETHUSDT", 2899, 2901)
+	inbox := getNotifications(t, user.Token, "")
+	require.Len(t, inbox.Notifications, 1)
+	assert.Equal(t, "ETHUSDT moved 3% in 1h0m0s", inbox.Notifications[0].Title)
+}
+
+func TestNotifications_MarkAllRead(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("SOLUSDT", 100, 100.1)
+
+	user := registerUser(t, uniqueEmail("notif_read_all"), "password123")
+	other := registerUser(t, uniqueEmail("notif_other"), "password123")
+
+	for _, target := range []string{"101", "102"} {
+		createAlert(t, user.Token, map[string]interface{}{
+			"symbol":       "SOLUSDT",
+			"condition":    "ABOVE",
+			"target_price": target,
+		})
+	}
+	processPrice(t, "SOLUSDT", 105, 105.1)
+
+	inbox := getNotifications(t, user.Token, "")
+	require.Len(t, inbox.Notifications, 2)
+	assert.Equal(t, 2, inbox.Unread)
+
+	// Other users cannot mark someone else's notification
+	resp := makeRequest(t, "POST", fmt.Sprintf("/notifications/%d/read", inbox.Notifications[0].ID), nil, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = makeRequest(t, "POST", "/notifications/read-all", nil, user.Token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	inbox = getNotifications(t, user.Token, "")
+	assert.Equal(t, 0, inbox.Unread)
+	for _, n := range inbox.Notifications {
+		assert.True(t, n.Read)
+	}
+}
@@ -0,0 +1,406 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type AlgoOrderInfo struct {
+	ID                int64           `json:"id"`
+	Type              string          `json:"type"`
+	Status            string          `json:"status"`
+	Quantity          string          `json:"quantity"`
+	Price             string          `json:"price"`
+	StartAt           string          `json:"start_at"`
+	EndAt             string          `json:"end_at"`
+	FilledQuantity    string          `json:"filled_quantity"`
+	RemainingQuantity string          `json:"remaining_quantity"`
+	AveragePrice      string          `json:"average_price"`
+	ArrivalPrice      string          `json:"arrival_price"`
+	SlippageBps       string          `json:"slippage_bps"`
+	VisibleQuantity   string          `json:"visible_quantity"`
+	PriceFrom         string          `json:"price_from"`
+	PriceTo           string          `json:"price_to"`
+	Distribution      string          `json:"distribution"`
+	Slices            []AlgoSliceInfo `json:"slices"`
+}
+
+type AlgoSliceInfo struct {
+	Index          int    `json:"index"`
+	DueAt          string `json:"due_at"`
+	Quantity       string `json:"quantity"`
+	LimitPrice     string `json:"limit_price"`
+	Status         string `json:"status"`
+	OrderID        *int64 `json:"order_id"`
+	FilledQuantity string `json:"filled_quantity"`
+	Price          string `json:"price"`
+	Error          string `json:"error"`
+}
+
+func createAlgoOrder(t *testing.T, token string, body map[string]interface{}) AlgoOrderInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", "/orders/algo", body, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var order AlgoOrderInfo
+	parseResponse(t, resp, &order)
+	return order
+}
+
+func getAlgoOrder(t *testing.T, token string, id int64) AlgoOrderInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", fmt.Sprintf("/orders/algo/%d", id), nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var order AlgoOrderInfo
+	parseResponse(t, resp, &order)
+	return order
+}
+
+func TestAlgo_TWAPExecutesSlices(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("twap"), "password123")
+
+	order := createAlgoOrder(t, user.Token, map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "BUY",
+		"type":     "TWAP",
+		"quantity": "0.04",
+		"leverage": 10,
+		"duration": "4m",
+		"slices":   4,
+	})
+	assert.Equal(t, "PENDING", order.Status)
+	assert.Equal(t, "50010", order.ArrivalPrice)
+	require.Len(t, order.Slices, 4)
+
+	// The first slice executes on creation
+	assert.Equal(t, "FILLED", order.Slices[0].Status)
+	assert.Equal(t, "0.01", order.Slices[0].Quantity)
+	assert.Equal(t, "50010", order.Slices[0].Price)
+	assert.Equal(t, "SCHEDULED", order.Slices[1].Status)
+	assert.Equal(t, "0.01", order.FilledQuantity)
+	assert.Equal(t, "0.03", order.RemainingQuantity)
+
+	start, err := time.Parse(time.RFC3339, order.StartAt)
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(time.Minute).UTC().Format("2006-01-02T15:04:05Z"), order.Slices[1].DueAt)
+
+	algoUseCase.RunDue(testCtx, start.Add(2*time.Minute))
+	order = getAlgoOrder(t, user.Token, order.ID)
+	assert.Equal(t, "FILLED", order.Slices[2].Status)
+	assert.Equal(t, "SCHEDULED", order.Slices[3].Status)
+	assert.Equal(t, "PENDING", order.Status)
+
+	priceCache.SetPrice("BTCUSDT", 51000, 51010)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	// Slices that fell due while the scheduler was down run at once
+	algoUseCase.RunDue(testCtx, start.Add(10*time.Minute))
+	order = getAlgoOrder(t, user.Token, order.ID)
+	assert.Equal(t, "FILLED", order.Status)
+	assert.Equal(t, "0.04", order.FilledQuantity)
+	assert.Equal(t, "0", order.RemainingQuantity)
+	assert.Equal(t, "50260", order.AveragePrice)
+	assert.Equal(t, "49.99", order.SlippageBps)
+
+	resp := makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "0.04", positions[0].Quantity)
+
+	// Children are listed under their parent
+	resp = makeRequest(t, "GET", fmt.Sprintf("/orders?parent_id=%d", order.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var children []OrderResponse
+	parseResponse(t, resp, &children)
+	require.Len(t, children, 4)
+	for _, child := range children {
+		assert.Equal(t, "MARKET", child.Type)
+		assert.Equal(t, "FILLED", child.Status)
+	}
+
+	resp = makeRequest(t, "GET", "/orders?type=TWAP", nil, user.Token)
+	var parents []OrderResponse
+	parseResponse(t, resp, &parents)
+	require.Len(t, parents, 1)
+	assert.Equal(t, order.ID, parents[0].ID)
+}
+
+func TestAlgo_LimitSlicesAndCancel(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("twap_limit"), "password123")
+
+	// Above the limit, slices rest as limit orders
+	order := createAlgoOrder(t, user.Token, map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "BUY",
+		"type":     "TWAP",
+		"quantity": "0.04",
+		"price":    "49000",
+		"leverage": 10,
+		"duration": "4m",
+		"slices":   4,
+	})
+	require.Len(t, order.Slices, 4)
+	assert.Equal(t, "OPEN", order.Slices[0].Status)
+	require.NotNil(t, order.Slices[0].OrderID)
+
+	start, err := time.Parse(time.RFC3339, order.StartAt)
+	require.NoError(t, err)
+
+	// The unfilled slice expires into the next one
+	algoUseCase.RunDue(testCtx, start.Add(time.Minute+time.Second))
+	order = getAlgoOrder(t, user.Token, order.ID)
+	assert.Equal(t, "EXPIRED", order.Slices[0].Status)
+	assert.Equal(t, "OPEN", order.Slices[1].Status)
+	assert.Equal(t, "0.02", order.Slices[1].Quantity)
+
+	// The resting slice fills once the market reaches the limit
+	processPrice(t, "BTCUSDT", 48990, 49000)
+	order = getAlgoOrder(t, user.Token, order.ID)
+	assert.Equal(t, "FILLED", order.Slices[1].Status)
+	assert.Equal(t, "0.02", order.FilledQuantity)
+	assert.Equal(t, "49000", order.AveragePrice)
+	assert.Equal(t, "-201.96", order.SlippageBps)
+
+	// Algo orders cannot be edited, only cancelled
+	resp := makeRequest(t, "PATCH", fmt.Sprintf("/orders/%d", order.ID), map[string]interface{}{"price": "48000"}, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = makeRequest(t, "POST", fmt.Sprintf("/orders/algo/%d/cancel", order.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &order)
+	assert.Equal(t, "CANCELLED", order.Status)
+	assert.Equal(t, "FILLED", order.Slices[1].Status)
+	assert.Equal(t, "CANCELLED", order.Slices[2].Status)
+	assert.Equal(t, "CANCELLED", order.Slices[3].Status)
+	assert.Equal(t, "0.02", order.FilledQuantity)
+
+	resp = makeRequest(t, "POST", fmt.Sprintf("/orders/algo/%d/cancel", order.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Cancelled slices do not run
+	algoUseCase.RunDue(testCtx, start.Add(10*time.Minute))
+	assert.Equal(t, "0.02", getAlgoOrder(t, user.Token, order.ID).FilledQuantity)
+
+	// Algo orders are private to their account
+	other := registerUser(t, uniqueEmail("twap_other"), "password123")
+	resp = makeRequest(t, "GET", fmt.Sprintf("/orders/algo/%d", order.ID), nil, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAlgo_VWAPFollowsVolumeProfile(t *testing.T) {
+	cleanupDatabase(t)
+
+	// Hourly candles of one day; the two hours after the current one trade
+	// three times the volume of the others
+	now := time.Now().UTC()
+	var csv strings.Builder
+	csv.WriteString("time,open,high,low,close,volume\n")
+	for h := 0; h < 24; h++ {
+		volume := 1
+		if h == (now.Hour()+1)%24 || h == (now.Hour()+2)%24 {
+			volume = 3
+		}
+		fmt.Fprintf(&csv, "2024-01-01T%02d:00:00Z,100,100,100,100,%d\n", h, volume)
+	}
+	require.NoError(t, os.MkdirAll("testdata/candles", 0o755))
+	path := filepath.Join("testdata/candles", "algo_vwap.csv")
+	require.NoError(t, os.WriteFile(path, []byte(csv.String()), 0o644))
+	defer os.Remove(path)
+
+	user := registerUser(t, uniqueEmail("vwap"), "password123")
+
+	order := createAlgoOrder(t, user.Token, map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "BUY",
+		"type":     "VWAP",
+		"quantity": "0.1",
+		"leverage": 10,
+		"duration": "2h",
+		"slices":   2,
+		"dataset":  "algo_vwap.csv",
+	})
+	require.Len(t, order.Slices, 2)
+	assert.Equal(t, "FILLED", order.Slices[0].Status)
+
+	first, second := decimal.RequireFromString(order.Slices[0].Quantity), decimal.RequireFromString(order.Slices[1].Quantity)
+	assert.True(t, second.GreaterThan(first), "slice quantities %s, %s", first, second)
+	assert.Equal(t, "0.1", first.Add(second).String())
+}
+
+func TestAlgo_IcebergReplenishesOnFill(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("iceberg"), "password123")
+
+	order := createAlgoOrder(t, user.Token, map[string]interface{}{
+		"symbol":           "BTCUSDT",
+		"side":             "BUY",
+		"type":             "ICEBERG",
+		"quantity":         "0.05",
+		"visible_quantity": "0.02",
+		"price":            "49000",
+		"leverage":         10,
+	})
+	assert.Equal(t, "PENDING", order.Status)
+	assert.Equal(t, "0.02", order.VisibleQuantity)
+	require.Len(t, order.Slices, 3)
+	assert.Equal(t, "0.01", order.Slices[2].Quantity)
+	assert.Equal(t, "49000", order.Slices[2].LimitPrice)
+
+	// Only the visible slice is on the book
+	assert.Equal(t, "OPEN", order.Slices[0].Status)
+	assert.Equal(t, "SCHEDULED", order.Slices[1].Status)
+
+	resp := makeRequest(t, "GET", fmt.Sprintf("/orders?parent_id=%d", order.ID), nil, user.Token)
+	var children []OrderResponse
+	parseResponse(t, resp, &children)
+	require.Len(t, children, 1)
+	assert.Equal(t, "LIMIT", children[0].Type)
+	assert.Equal(t, "0.02", children[0].Quantity)
+	assert.Equal(t, "49000", children[0].Price)
+
+	// Each fill replenishes the next slice until the whole quantity is done
+	processPrice(t, "BTCUSDT", 48990, 49000)
+	order = getAlgoOrder(t, user.Token, order.ID)
+	assert.Equal(t, "FILLED", order.Status)
+	assert.Equal(t, "0.05", order.FilledQuantity)
+	assert.Equal(t, "49000", order.AveragePrice)
+	for _, slice := range order.Slices {
+		assert.Equal(t, "FILLED", slice.Status)
+	}
+
+	resp = makeRequest(t, "GET", fmt.Sprintf("/orders?parent_id=%d", order.ID), nil, user.Token)
+	parseResponse(t, resp, &children)
+	assert.Len(t, children, 3)
+
+	resp = makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "0.05", positions[0].Quantity)
+}
+
+func TestAlgo_ScaleLadderAndCancel(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("scale"), "password123")
+
+	order := createAlgoOrder(t, user.Token, map[string]interface{}{
+		"symbol":     "BTCUSDT",
+		"side":       "BUY",
+		"type":       "SCALE",
+		"quantity":   "0.04",
+		"price_from": "50500",
+		"price_to":   "47500",
+		"slices":     4,
+		"leverage":   10,
+	})
+	assert.Equal(t, "LINEAR", order.Distribution)
+	assert.Equal(t, "50500", order.PriceFrom)
+	assert.Equal(t, "47500", order.PriceTo)
+	require.Len(t, order.Slices, 4)
+	for i, price := range []string{"50500", "49500", "48500", "47500"} {
+		assert.Equal(t, price, order.Slices[i].LimitPrice)
+		assert.Equal(t, "0.01", order.Slices[i].Quantity)
+	}
+
+	// The slice above the ask fills at market, the others rest
+	assert.Equal(t, "FILLED", order.Slices[0].Status)
+	assert.Equal(t, "50010", order.Slices[0].Price)
+	assert.Equal(t, "OPEN", order.Slices[1].Status)
+	assert.Equal(t, "OPEN", order.Slices[3].Status)
+
+	processPrice(t, "BTCUSDT", 49490, 49500)
+	order = getAlgoOrder(t, user.Token, order.ID)
+	assert.Equal(t, "PENDING", order.Status)
+	assert.Equal(t, "FILLED", order.Slices[1].Status)
+	assert.Equal(t, "OPEN", order.Slices[2].Status)
+	assert.Equal(t, "0.02", order.FilledQuantity)
+	assert.Equal(t, "49755", order.AveragePrice)
+
+	resp := makeRequest(t, "POST", fmt.Sprintf("/orders/algo/%d/cancel", order.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &order)
+	assert.Equal(t, "CANCELLED", order.Status)
+	assert.Equal(t, "CANCELLED", order.Slices[2].Status)
+	assert.Equal(t, "CANCELLED", order.Slices[3].Status)
+
+	// The resting limit orders are cancelled with the parent
+	resp = makeRequest(t, "GET", fmt.Sprintf("/orders?parent_id=%d&status=PENDING", order.ID), nil, user.Token)
+	var pending []OrderResponse
+	parseResponse(t, resp, &pending)
+	assert.Empty(t, pending)
+
+	// Geometric prices are spaced by a constant ratio
+	order = createAlgoOrder(t, user.Token, map[string]interface{}{
+		"symbol":       "BTCUSDT",
+		"side":         "BUY",
+		"type":         "SCALE",
+		"quantity":     "0.03",
+		"price_from":   "40000",
+		"price_to":     "10000",
+		"slices":       3,
+		"distribution": "GEOMETRIC",
+		"leverage":     10,
+	})
+	require.Len(t, order.Slices, 3)
+	assert.Equal(t, "40000", order.Slices[0].LimitPrice)
+	assert.Equal(t, "20000", order.Slices[1].LimitPrice)
+	assert.Equal(t, "10000", order.Slices[2].LimitPrice)
+}
+
+func TestAlgo_Validation(t *testing.T) {
+	user := registerUser(t, uniqueEmail("algo_invalid"), "password123")
+
+	cases := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{"one slice", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "TWAP", "quantity": "0.01", "leverage": 10, "duration": "1h", "slices": 1}},
+		{"short interval", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "TWAP", "quantity": "0.01", "leverage": 10, "duration": "30s", "slices": 10}},
+		{"long duration", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "TWAP", "quantity": "0.01", "leverage": 10, "duration": "48h", "slices": 10}},
+		{"bad duration", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "TWAP", "quantity": "0.01", "leverage": 10, "duration": "soon", "slices": 10}},
+		{"plain type", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "MARKET", "quantity": "0.01", "leverage": 10, "duration": "1h", "slices": 10}},
+		{"tiny slices", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "TWAP", "quantity": "0.00000001", "leverage": 10, "duration": "1h", "slices": 10}},
+		{"vwap without dataset", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "VWAP", "quantity": "0.01", "leverage": 10, "duration": "1h", "slices": 10}},
+		{"missing dataset", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "VWAP", "quantity": "0.01", "leverage": 10, "duration": "1h", "slices": 10, "dataset": "nope.csv"}},
+		{"spot limit", map[string]interface{}{"symbol": "BTC/USDT", "side": "BUY", "type": "TWAP", "quantity": "0.01", "price": "49000", "duration": "1h", "slices": 10}},
+		{"unknown symbol", map[string]interface{}{"symbol": "DOGEUSDT", "side": "BUY", "type": "TWAP", "quantity": "0.01", "leverage": 10, "duration": "1h", "slices": 10}},
+		{"iceberg without price", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "ICEBERG", "quantity": "0.1", "visible_quantity": "0.01", "leverage": 10}},
+		{"iceberg fully visible", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "ICEBERG", "quantity": "0.1", "visible_quantity": "0.1", "price": "49000", "leverage": 10}},
+		{"iceberg too many slices", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "ICEBERG", "quantity": "1", "visible_quantity": "0.001", "price": "49000", "leverage": 10}},
+		{"spot iceberg", map[string]interface{}{"symbol": "BTC/USDT", "side": "BUY", "type": "ICEBERG", "quantity": "0.1", "visible_quantity": "0.01", "price": "49000"}},
+		{"scale equal bounds", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "SCALE", "quantity": "0.1", "price_from": "49000", "price_to": "49000", "slices": 5, "leverage": 10}},
+		{"scale one slice", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "SCALE", "quantity": "0.1", "price_from": "49000", "price_to": "48000", "slices": 1, "leverage": 10}},
+		{"scale distribution", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "SCALE", "quantity": "0.1", "price_from": "49000", "price_to": "48000", "slices": 5, "distribution": "RANDOM", "leverage": 10}},
+		{"scale with price", map[string]interface{}{"symbol": "BTCUSDT", "side": "BUY", "type": "SCALE", "quantity": "0.1", "price": "49000", "price_from": "49000", "price_to": "48000", "slices": 5, "leverage": 10}},
+	}
+	for _, tc := range cases {
+		resp := makeRequest(t, "POST", "/orders/algo", tc.body, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, tc.name)
+	}
+}
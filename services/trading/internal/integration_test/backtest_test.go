@@ -0,0 +1,158 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type BacktestInfo struct {
+	ID       int64  `json:"id"`
+	Status   string `json:"status"`
+	Strategy string `json:"strategy"`
+	Symbol   string `json:"symbol"`
+	Error    string `json:"error"`
+	Report   *struct {
+		Candles     int    `json:"candles"`
+		FinalEquity string `json:"final_equity"`
+		Stats       struct {
+			TotalTrades    int    `json:"total_trades"`
+			ClosedTrades   int    `json:"closed_trades"`
+			LosingTrades   int    `json:"losing_trades"`
+			RealizedPnL    string `json:"realized_pnl"`
+			ReturnPct      string `json:"return_pct"`
+			MaxDrawdownPct string `json:"max_drawdown_pct"`
+		} `json:"stats"`
+		Trades []TradeResponse `json:"trades"`
+		Equity []struct {
+			Time     string `json:"time"`
+			Equity   string `json:"equity"`
+			Drawdown string `json:"drawdown"`
+		} `json:"equity"`
+	} `json:"report"`
+}
+
+// waitBacktest polls the backtest until it is no longer running
+func waitBacktest(t *testing.T, token string, id int64) BacktestInfo {
+	t.Helper()
+
+	var info BacktestInfo
+	require.Eventually(t, func() bool {
+		resp := makeRequest(t, "GET", fmt.Sprintf("/backtests/%d", id), nil, token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		parseResponse(t, resp, &info)
+		return info.Status != "RUNNING"
+	}, 5*time.Second, 20*time.Millisecond)
+	return info
+}
+
+func TestBacktest_StopLossInsideCandle(t *testing.T) {
+	user := registerUser(t, uniqueEmail("backtest"), "password123")
+
+	// The fast average crosses above the slow one on the third close (long at 110,
+	// stop at 104.5). The fourth bar dips to 100, so the stop fires inside it, and
+	// its close at 109 crosses back down into a short.
+	csv := "time,open,high,low,close\n" +
+		"1700000000,100,100,100,100\n" +
+		"1700003600,100,100,100,100\n" +
+		"1700007200,100,111,99,110\n" +
+		"1700010800,110,111,100,109\n"
+
+	resp := makeRequest(t, "POST", "/backtests", map[string]interface{}{
+		"strategy": "sma_cross",
+		"params":   map[string]float64{"fast": 1, "slow": 2, "stop_loss_pct": 5, "quantity": 1},
+		"symbol":   "btcusdt",
+		"csv":      csv,
+	}, user.Token)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	var submitted BacktestInfo
+	parseResponse(t, resp, &submitted)
+	assert.Equal(t, "BTCUSDT", submitted.Symbol)
+	assert.Nil(t, submitted.Report)
+
+	info := waitBacktest(t, user.Token, submitted.ID)
+	require.Equal(t, "DONE", info.Status, info.Error)
+	require.NotNil(t, info.Report)
+
+	report := info.Report
+	assert.Equal(t, 4, report.Candles)
+	require.Len(t, report.Trades, 3)
+	assert.Equal(t, "OPEN", report.Trades[0].Type)
+	assert.Equal(t, "LONG", report.Trades[0].Side)
+	assert.Equal(t, "110", report.Trades[0].Price)
+	assert.Equal(t, "CLOSE", report.Trades[1].Type)
+	assert.Equal(t, "104.5", report.Trades[1].Price)
+	assert.Equal(t, "-5.5", report.Trades[1].PnL)
+	assert.Equal(t, "SHORT", report.Trades[2].Side)
+	assert.Equal(t, "109", report.Trades[2].Price)
+
+	// The stop fired at the bar's low, a third of the way through it
+	assert.Equal(t, "2023-11-15T01:53:20Z", report.Trades[1].CreatedAt)
+
+	assert.Equal(t, 1, report.Stats.ClosedTrades)
+	assert.Equal(t, 1, report.Stats.LosingTrades)
+	assert.Equal(t, "-5.50", report.Stats.RealizedPnL)
+	assert.Equal(t, "9994.50", report.FinalEquity)
+	assert.Equal(t, "-0.06", report.Stats.ReturnPct)
+	assert.Equal(t, "0.06", report.Stats.MaxDrawdownPct)
+	require.Len(t, report.Equity, 4)
+	assert.Equal(t, "10000.00", report.Equity[0].Equity)
+	assert.Equal(t, "2023-11-15T02:13:20Z", report.Equity[3].Time)
+
+	// Listed without the report, and hidden from other users
+	resp = makeRequest(t, "GET", "/backtests", nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var list []BacktestInfo
+	parseResponse(t, resp, &list)
+	require.Len(t, list, 1)
+	assert.Nil(t, list[0].Report)
+
+	other := registerUser(t, uniqueEmail("backtest_other"), "password123")
+	resp = makeRequest(t, "GET", fmt.Sprintf("/backtests/%d", submitted.ID), nil, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestBacktest_Validation(t *testing.T) {
+	user := registerUser(t, uniqueEmail("backtest_invalid"), "password123")
+	csv := "1700000000,100,101,99,100\n1700003600,100,102,99,101\n"
+
+	resp := makeRequest(t, "GET", "/backtests/options", nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var options struct {
+		Strategies []string `json:"strategies"`
+		Datasets   []string `json:"datasets"`
+	}
+	parseResponse(t, resp, &options)
+	assert.Contains(t, options.Strategies, "buy_and_hold")
+	assert.Contains(t, options.Strategies, "sma_cross")
+
+	cases := []struct {
+		name   string
+		body   map[string]interface{}
+		status int
+	}{
+		{"unknown strategy", map[string]interface{}{"strategy": "martingale", "symbol": "BTCUSDT", "csv": csv}, http.StatusBadRequest},
+		{"bad params", map[string]interface{}{"strategy": "sma_cross", "symbol": "BTCUSDT", "csv": csv, "params": map[string]float64{"fast": 30, "slow": 10}}, http.StatusBadRequest},
+		{"no candles", map[string]interface{}{"strategy": "buy_and_hold", "symbol": "BTCUSDT"}, http.StatusBadRequest},
+		{"bad candle", map[string]interface{}{"strategy": "buy_and_hold", "symbol": "BTCUSDT", "csv": "1700000000,100,99,101,100\n"}, http.StatusBadRequest},
+		{"no symbol", map[string]interface{}{"strategy": "buy_and_hold", "csv": csv}, http.StatusBadRequest},
+		{"negative balance", map[string]interface{}{"strategy": "buy_and_hold", "symbol": "BTCUSDT", "csv": csv, "starting_balance": "-5"}, http.StatusBadRequest},
+		{"missing dataset", map[string]interface{}{"strategy": "buy_and_hold", "symbol": "BTCUSDT", "dataset": "nope.csv"}, http.StatusNotFound},
+		{"dataset path", map[string]interface{}{"strategy": "buy_and_hold", "symbol": "BTCUSDT", "dataset": "../secrets.csv"}, http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		resp := makeRequest(t, "POST", "/backtests", tc.body, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, tc.status, resp.StatusCode, tc.name)
+	}
+
+	resp = makeRequest(t, "GET", "/backtests/999999", nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,241 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ChallengeInfo struct {
+	ID              int64  `json:"id"`
+	AccountID       int64  `json:"account_id"`
+	Status          string `json:"status"`
+	StartingBalance string `json:"starting_balance"`
+	Rules           struct {
+		ProfitTarget    string `json:"profit_target"`
+		MaxDailyLoss    string `json:"max_daily_loss"`
+		MaxDrawdown     string `json:"max_drawdown"`
+		MinTradingDays  int    `json:"min_trading_days"`
+		MaxPositionSize string `json:"max_position_size"`
+	} `json:"rules"`
+	TradingDays   int     `json:"trading_days"`
+	FailureReason string  `json:"failure_reason"`
+	Equity        *string `json:"equity"`
+	Progress      []struct {
+		Rule   string `json:"rule"`
+		Value  string `json:"value"`
+		Limit  string `json:"limit"`
+		Status string `json:"status"`
+	} `json:"progress"`
+	EndedAt *string `json:"ended_at"`
+}
+
+type ChallengeAccountInfo struct {
+	ID           int64  `json:"id"`
+	Balance      string `json:"balance"`
+	ChallengeID  *int64 `json:"challenge_id"`
+	LockedReason string `json:"locked_reason"`
+}
+
+func createChallenge(t *testing.T, token string, body map[string]interface{}) ChallengeInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", "/challenges", body, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var challenge ChallengeInfo
+	parseResponse(t, resp, &challenge)
+	return challenge
+}
+
+func getChallenge(t *testing.T, token string, id int64) ChallengeInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", fmt.Sprintf("/challenges/%d", id), nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var challenge ChallengeInfo
+	parseResponse(t, resp, &challenge)
+	return challenge
+}
+
+func placeChallengeOrder(t *testing.T, token string, accountID int64, side, quantity string) int {
+	t.Helper()
+
+	resp := makeRequestWithHeaders(t, "POST", "/orders", map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     side,
+		"type":     "MARKET",
+		"quantity": quantity,
+		"leverage": 10,
+	}, token, accountHeader(accountID))
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestChallenge_Create(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("challenge_create"), "password123")
+	other := registerUser(t, uniqueEmail("challenge_other"), "password123")
+
+	challenge := createChallenge(t, user.Token, map[string]interface{}{
+		"starting_balance": "5000",
+		"profit_target":    "0.08",
+		"min_trading_days": 2,
+	})
+	assert.Equal(t, "ACTIVE", challenge.Status)
+	assert.Equal(t, "5000.00", challenge.StartingBalance)
+	assert.Equal(t, "0.08", challenge.Rules.ProfitTarget)
+	assert.Equal(t, "0.05", challenge.Rules.MaxDailyLoss)
+	assert.Equal(t, "0.1", challenge.Rules.MaxDrawdown)
+	assert.Equal(t, 2, challenge.Rules.MinTradingDays)
+	assert.Equal(t, "25000.00", challenge.Rules.MaxPositionSize)
+
+	// The challenge trades on its own funded account
+	resp := makeRequestWithHeaders(t, "GET", "/account", nil, user.Token, accountHeader(challenge.AccountID))
+	var account ChallengeAccountInfo
+	parseResponse(t, resp, &account)
+	assert.Equal(t, "5000.00", account.Balance)
+	require.NotNil(t, account.ChallengeID)
+	assert.Equal(t, challenge.ID, *account.ChallengeID)
+
+	resp = makeRequest(t, "GET", "/account", nil, user.Token)
+	var main ChallengeAccountInfo
+	parseResponse(t, resp, &main)
+
+	resp = makeRequest(t, "POST", "/accounts/transfer", map[string]interface{}{
+		"from_account_id": main.ID,
+		"to_account_id":   challenge.AccountID,
+		"amount":          "100",
+	}, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// Progress against each rule
+	progress := getChallenge(t, user.Token, challenge.ID)
+	require.NotNil(t, progress.Equity)
+	assert.Equal(t, "5000.00", *progress.Equity)
+	require.Len(t, progress.Progress, 5)
+	assert.Equal(t, "profit_target", progress.Progress[0].Rule)
+	assert.Equal(t, "400.00", progress.Progress[0].Limit)
+	assert.Equal(t, "PENDING", progress.Progress[0].Status)
+	assert.Equal(t, "max_daily_loss", progress.Progress[1].Rule)
+	assert.Equal(t, "OK", progress.Progress[1].Status)
+
+	resp = makeRequest(t, "GET", fmt.Sprintf("/challenges/%d", challenge.ID), nil, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = makeRequest(t, "GET", "/challenges", nil, user.Token)
+	var list []ChallengeInfo
+	parseResponse(t, resp, &list)
+	require.Len(t, list, 1)
+	assert.Equal(t, challenge.ID, list[0].ID)
+
+	invalid := []map[string]interface{}{
+		{"profit_target": "-0.1"},
+		{"max_daily_loss": "1.5"},
+		{"max_drawdown": "abc"},
+		{"min_trading_days": -1},
+		{"starting_balance": "-100"},
+	}
+	for _, body := range invalid {
+		resp = makeRequest(t, "POST", "/challenges", body, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, body)
+	}
+}
+
+func TestChallenge_FailOnDailyLoss(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("challenge_loss"), "password123")
+	challenge := createChallenge(t, user.Token, map[string]interface{}{
+		"max_position_size": "100000",
+	})
+
+	require.Equal(t, http.StatusCreated, placeChallengeOrder(t, user.Token, challenge.AccountID, "BUY", "1"))
+	assert.Equal(t, 1, getChallenge(t, user.Token, challenge.ID).TradingDays)
+
+	// Long 1 BTC at 50010, mark 49405: -605 against a 500 daily loss limit
+	processPrice(t, "BTCUSDT", 49400, 49410)
+
+	failed := getChallenge(t, user.Token, challenge.ID)
+	assert.Equal(t, "FAILED", failed.Status)
+	assert.Equal(t, "max_daily_loss", failed.FailureReason)
+	assert.NotNil(t, failed.EndedAt)
+
+	// Positions were closed and the account locked
+	resp := makeRequestWithHeaders(t, "GET", "/positions", nil, user.Token, accountHeader(challenge.AccountID))
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	assert.Empty(t, positions)
+
+	resp = makeRequestWithHeaders(t, "GET", "/account", nil, user.Token, accountHeader(challenge.AccountID))
+	var account ChallengeAccountInfo
+	parseResponse(t, resp, &account)
+	assert.Equal(t, "challenge failed: max_daily_loss", account.LockedReason)
+
+	assert.Equal(t, http.StatusForbidden, placeChallengeOrder(t, user.Token, challenge.AccountID, "BUY", "0.01"))
+
+	// The user's main account keeps trading
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.01", 10)
+}
+
+func TestChallenge_FailOnPositionSize(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("challenge_size"), "password123")
+	challenge := createChallenge(t, user.Token, map[string]interface{}{
+		"max_position_size": "1000",
+	})
+
+	// 0.05 BTC at 50010 is a 2500.50 position, checked on the fill
+	require.Equal(t, http.StatusCreated, placeChallengeOrder(t, user.Token, challenge.AccountID, "BUY", "0.05"))
+
+	failed := getChallenge(t, user.Token, challenge.ID)
+	assert.Equal(t, "FAILED", failed.Status)
+	assert.Equal(t, "max_position_size", failed.FailureReason)
+
+	resp := makeRequestWithHeaders(t, "GET", "/positions", nil, user.Token, accountHeader(challenge.AccountID))
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	assert.Empty(t, positions)
+}
+
+func TestChallenge_Pass(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("challenge_pass"), "password123")
+	challenge := createChallenge(t, user.Token, map[string]interface{}{
+		"profit_target":     "0.01",
+		"min_trading_days":  1,
+		"max_position_size": "100000",
+	})
+
+	require.Equal(t, http.StatusCreated, placeChallengeOrder(t, user.Token, challenge.AccountID, "BUY", "1"))
+
+	// Still below the 100 target
+	processPrice(t, "BTCUSDT", 50050, 50060)
+	assert.Equal(t, "ACTIVE", getChallenge(t, user.Token, challenge.ID).Status)
+
+	// Mark 50205: +195 unrealized
+	processPrice(t, "BTCUSDT", 50200, 50210)
+
+	passed := getChallenge(t, user.Token, challenge.ID)
+	assert.Equal(t, "PASSED", passed.Status)
+	assert.Empty(t, passed.FailureReason)
+
+	resp := makeRequestWithHeaders(t, "GET", "/account", nil, user.Token, accountHeader(challenge.AccountID))
+	var account ChallengeAccountInfo
+	parseResponse(t, resp, &account)
+	assert.Equal(t, "challenge passed", account.LockedReason)
+
+	assert.Equal(t, http.StatusForbidden, placeChallengeOrder(t, user.Token, challenge.AccountID, "SELL", "0.01"))
+}
@@ -0,0 +1,319 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CompetitionInfo struct {
+	ID              int64    `json:"id"`
+	Name            string   `json:"name"`
+	Status          string   `json:"status"`
+	AllowedSymbols  []string `json:"allowed_symbols"`
+	MaxLeverage     int      `json:"max_leverage"`
+	StartingBalance string   `json:"starting_balance"`
+	RankingMetric   string   `json:"ranking_metric"`
+	FinalizedAt     *string  `json:"finalized_at"`
+}
+
+type CompetitionStandings struct {
+	Status    string `json:"status"`
+	Final     bool   `json:"final"`
+	Standings []struct {
+		Rank        int    `json:"rank"`
+		DisplayName string `json:"display_name"`
+		Equity      string `json:"equity"`
+		PnL         string `json:"pnl"`
+		ROI         string `json:"roi"`
+		Trades      int    `json:"trades"`
+	} `json:"standings"`
+}
+
+type CompetitionAccountInfo struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	Balance       string `json:"balance"`
+	CompetitionID *int64 `json:"competition_id"`
+}
+
+// registerAdmin registers a user and grants the admin flag
+func registerAdmin(t *testing.T) *testUser {
+	t.Helper()
+
+	admin := registerUser(t, uniqueEmail("admin"), "password123")
+	_, err := testDB.Exec("UPDATE users SET is_admin = TRUE WHERE id = $1", admin.UserID)
+	require.NoError(t, err)
+	return admin
+}
+
+func createCompetition(t *testing.T, token string, startsAt, endsAt time.Time) CompetitionInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", "/admin/competitions", map[string]interface{}{
+		"name":             "BTC sprint",
+		"starts_at":        startsAt.UTC().Format(time.RFC3339),
+		"ends_at":          endsAt.UTC().Format(time.RFC3339),
+		"allowed_symbols":  []string{"BTCUSDT"},
+		"max_leverage":     5,
+		"starting_balance": "1000",
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var competition CompetitionInfo
+	parseResponse(t, resp, &competition)
+	return competition
+}
+
+func joinCompetition(t *testing.T, token string, id int64) CompetitionAccountInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", fmt.Sprintf("/competitions/%d/join", id), nil, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var account CompetitionAccountInfo
+	parseResponse(t, resp, &account)
+	return account
+}
+
+func getStandings(t *testing.T, id int64) CompetitionStandings {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", fmt.Sprintf("/competitions/%d/standings", id), nil, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var standings CompetitionStandings
+	parseResponse(t, resp, &standings)
+	return standings
+}
+
+func TestCompetition_Create(t *testing.T) {
+	cleanupDatabase(t)
+
+	admin := registerAdmin(t)
+	user := registerUser(t, uniqueEmail("comp_user"), "password123")
+	now := time.Now()
+
+	body := map[string]interface{}{
+		"name":             "Weekly cup",
+		"starts_at":        now.Add(time.Hour).UTC().Format(time.RFC3339),
+		"ends_at":          now.Add(2 * time.Hour).UTC().Format(time.RFC3339),
+		"max_leverage":     10,
+		"starting_balance": "5000",
+		"ranking_metric":   "roi",
+	}
+
+	resp := makeRequest(t, "POST", "/admin/competitions", body, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, "POST", "/admin/competitions", body, "")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = makeRequest(t, "POST", "/admin/competitions", body, admin.Token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var competition CompetitionInfo
+	parseResponse(t, resp, &competition)
+	assert.Equal(t, "UPCOMING", competition.Status)
+	assert.Equal(t, "roi", competition.RankingMetric)
+	assert.Equal(t, "5000.00", competition.StartingBalance)
+	// No symbol list allows every instrument
+	assert.Len(t, competition.AllowedSymbols, len(testInstruments()))
+
+	invalid := []map[string]interface{}{
+		{"ends_at": now.Add(-time.Hour).UTC().Format(time.RFC3339)},
+		{"max_leverage": 0},
+		{"starting_balance": "0"},
+		{"allowed_symbols": []string{"DOGEUSDT"}},
+		{"ranking_metric": "volume"},
+		{"name": ""},
+	}
+	for _, override := range invalid {
+		req := make(map[string]interface{}, len(body))
+		for k, v := range body {
+			req[k] = v
+		}
+		for k, v := range override {
+			req[k] = v
+		}
+		resp = makeRequest(t, "POST", "/admin/competitions", req, admin.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, override)
+	}
+
+	resp = makeRequest(t, "GET", "/competitions", nil, "")
+	var list []CompetitionInfo
+	parseResponse(t, resp, &list)
+	require.Len(t, list, 1)
+	assert.Equal(t, competition.ID, list[0].ID)
+
+	resp = makeRequest(t, "GET", "/competitions/999999", nil, "")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCompetition_JoinAndTrade(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	admin := registerAdmin(t)
+	user := registerUser(t, uniqueEmail("comp_trader"), "password123")
+	competition := createCompetition(t, admin.Token, time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+	assert.Equal(t, "ACTIVE", competition.Status)
+
+	account := joinCompetition(t, user.Token, competition.ID)
+	require.NotNil(t, account.CompetitionID)
+	assert.Equal(t, competition.ID, *account.CompetitionID)
+	assert.Equal(t, "1000.00", account.Balance)
+
+	resp := makeRequest(t, "POST", fmt.Sprintf("/competitions/%d/join", competition.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	order := func(symbol string, leverage int) int {
+		resp := makeRequestWithHeaders(t, "POST", "/orders", map[string]interface{}{
+			"symbol":   symbol,
+			"side":     "BUY",
+			"type":     "MARKET",
+			"quantity": "0.05",
+			"leverage": leverage,
+		}, user.Token, accountHeader(account.ID))
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusForbidden, order("ETHUSDT", 5))
+	assert.Equal(t, http.StatusBadRequest, order("BTCUSDT", 10))
+	assert.Equal(t, http.StatusCreated, order("BTCUSDT", 5))
+
+	// The main account is unaffected and cannot fund the competition account
+	resp = makeRequest(t, "GET", "/account", nil, user.Token)
+	var main CompetitionAccountInfo
+	parseResponse(t, resp, &main)
+	assert.Equal(t, "10000.00", main.Balance)
+
+	resp = makeRequest(t, "POST", "/accounts/transfer", map[string]interface{}{
+		"from_account_id": main.ID,
+		"to_account_id":   account.ID,
+		"amount":          "100",
+	}, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequestWithHeaders(t, "POST", "/account/reset", nil, user.Token, accountHeader(account.ID))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// Competitions that have not started reject orders
+	upcoming := createCompetition(t, admin.Token, time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+	early := joinCompetition(t, user.Token, upcoming.ID)
+	resp = makeRequestWithHeaders(t, "POST", "/orders", map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "BUY",
+		"type":     "MARKET",
+		"quantity": "0.01",
+		"leverage": 1,
+	}, user.Token, accountHeader(early.ID))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// Competition accounts are listed with the user's other accounts
+	resp = makeRequest(t, "GET", "/accounts", nil, user.Token)
+	var accounts []CompetitionAccountInfo
+	parseResponse(t, resp, &accounts)
+	assert.Len(t, accounts, 3)
+	assert.Nil(t, accounts[0].CompetitionID)
+}
+
+func TestCompetition_Finalize(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	admin := registerAdmin(t)
+	alice := registerUser(t, uniqueEmail("comp_alice"), "password123")
+	bob := registerUser(t, uniqueEmail("comp_bob"), "password123")
+	joinLeaderboard(t, alice.Token, "alice")
+
+	competition := createCompetition(t, admin.Token, time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+	aliceAccount := joinCompetition(t, alice.Token, competition.ID)
+	joinCompetition(t, bob.Token, competition.ID)
+
+	resp := makeRequestWithHeaders(t, "POST", "/orders", map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "BUY",
+		"type":     "MARKET",
+		"quantity": "0.05",
+		"leverage": 5,
+	}, alice.Token, accountHeader(aliceAccount.ID))
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// Alice long 0.05 BTC 50010 -> 51000: +49.50 unrealized
+	priceCache.SetPrice("BTCUSDT", 51000, 51010)
+
+	standings := getStandings(t, competition.ID)
+	assert.Equal(t, "ACTIVE", standings.Status)
+	assert.False(t, standings.Final)
+	require.Len(t, standings.Standings, 2)
+	assert.Equal(t, "alice", standings.Standings[0].DisplayName)
+	assert.Equal(t, "1049.50", standings.Standings[0].Equity)
+	assert.Equal(t, "49.50", standings.Standings[0].PnL)
+	assert.Equal(t, fmt.Sprintf("trader-%d", bob.UserID), standings.Standings[1].DisplayName)
+	assert.Equal(t, "0.00", standings.Standings[1].PnL)
+
+	// End the competition
+	_, err := testDB.Exec("UPDATE competitions SET ends_at = NOW() - INTERVAL '1 second' WHERE id = $1", competition.ID)
+	require.NoError(t, err)
+
+	resp = makeRequestWithHeaders(t, "POST", "/orders", map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "SELL",
+		"type":     "MARKET",
+		"quantity": "0.05",
+		"leverage": 5,
+	}, alice.Token, accountHeader(aliceAccount.ID))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// Balances cannot be moved by converting either
+	resp = makeRequestWithHeaders(t, "POST", "/account/convert", map[string]interface{}{
+		"from":   "USDT",
+		"to":     "BTC",
+		"amount": "100",
+	}, alice.Token, accountHeader(aliceAccount.ID))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = makeRequest(t, "POST", fmt.Sprintf("/competitions/%d/join", competition.ID), nil, admin.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	compUseCase.FinalizeDue(context.Background(), time.Now())
+
+	standings = getStandings(t, competition.ID)
+	assert.Equal(t, "FINISHED", standings.Status)
+	assert.True(t, standings.Final)
+	require.Len(t, standings.Standings, 2)
+	assert.Equal(t, 1, standings.Standings[0].Rank)
+	assert.Equal(t, "alice", standings.Standings[0].DisplayName)
+	assert.Equal(t, "49.50", standings.Standings[0].PnL)
+	assert.Equal(t, "0.0495", standings.Standings[0].ROI)
+	assert.Equal(t, 1, standings.Standings[0].Trades)
+
+	// Positions were closed at the end
+	resp = makeRequestWithHeaders(t, "GET", "/positions", nil, alice.Token, accountHeader(aliceAccount.ID))
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	assert.Empty(t, positions)
+
+	// Frozen standings ignore later prices
+	priceCache.SetPrice("BTCUSDT", 60000, 60010)
+	standings = getStandings(t, competition.ID)
+	assert.Equal(t, "49.50", standings.Standings[0].PnL)
+}
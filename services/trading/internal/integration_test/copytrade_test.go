@@ -0,0 +1,247 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FollowInfo struct {
+	ID           int64  `json:"id"`
+	Leader       string `json:"leader"`
+	Mode         string `json:"mode"`
+	MaxLeverage  int    `json:"max_leverage"`
+	Status       string `json:"status"`
+	StopReason   string `json:"stop_reason"`
+	CopiedTrades int    `json:"copied_trades"`
+	RealizedPnL  string `json:"realized_pnl"`
+}
+
+type CopyTradeInfo struct {
+	LeaderTradeType string `json:"leader_trade_type"`
+	Side            string `json:"side"`
+	Status          string `json:"status"`
+	OrderID         *int64 `json:"order_id"`
+	Quantity        string `json:"quantity"`
+	Price           string `json:"price"`
+	PnL             string `json:"pnl"`
+	Error           string `json:"error"`
+}
+
+func follow(t *testing.T, token string, body map[string]interface{}) FollowInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", "/copy/follows", body, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var f FollowInfo
+	parseResponse(t, resp, &f)
+	return f
+}
+
+func getFollow(t *testing.T, token string, id int64) FollowInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", fmt.Sprintf("/copy/follows/%d", id), nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var f FollowInfo
+	parseResponse(t, resp, &f)
+	return f
+}
+
+func getCopyTrades(t *testing.T, token string, id int64) []CopyTradeInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", fmt.Sprintf("/copy/follows/%d/trades", id), nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var trades []CopyTradeInfo
+	parseResponse(t, resp, &trades)
+	return trades
+}
+
+func getOpenPositions(t *testing.T, token string) []PositionResponse {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", "/positions", nil, token)
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	return positions
+}
+
+func TestCopyTrading_MirrorsLeaderPositions(t *testing.T) {
+	cleanupDatabase(t)
+
+	leader := registerUser(t, uniqueEmail("copy_leader"), "password123")
+	joinLeaderboard(t, leader.Token, "copy_leader")
+	follower := registerUser(t, uniqueEmail("copy_follower"), "password123")
+
+	f := follow(t, follower.Token, map[string]interface{}{
+		"leader":       "copy_leader",
+		"ratio":        "0.5",
+		"max_leverage": 5,
+	})
+	assert.Equal(t, "copy_leader", f.Leader)
+	assert.Equal(t, "RATIO", f.Mode)
+	assert.Equal(t, "ACTIVE", f.Status)
+
+	// The open is copied at half the size with the leverage capped
+	placeMarketOrder(t, leader.Token, "BTCUSDT", "BUY", "0.2", 10)
+	copyUseCase.Wait()
+	positions := getOpenPositions(t, follower.Token)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "LONG", positions[0].Side)
+	assert.Equal(t, "0.1", positions[0].Quantity)
+	assert.Equal(t, 5, positions[0].Leverage)
+
+	// A quarter of the leader's position is closed, so a quarter of the follower's
+	leaderPositions := getOpenPositions(t, leader.Token)
+	require.Len(t, leaderPositions, 1)
+	resp := makeRequest(t, "POST", fmt.Sprintf("/positions/%d/close", leaderPositions[0].ID),
+		map[string]interface{}{"quantity": "0.05"}, leader.Token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	copyUseCase.Wait()
+
+	positions = getOpenPositions(t, follower.Token)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "0.075", positions[0].Quantity)
+
+	// Selling the rest closes the follower's position too
+	placeMarketOrder(t, leader.Token, "BTCUSDT", "SELL", "0.15", 10)
+	copyUseCase.Wait()
+	assert.Empty(t, getOpenPositions(t, follower.Token))
+
+	trades := getCopyTrades(t, follower.Token, f.ID)
+	require.Len(t, trades, 3)
+	assert.Equal(t, "CLOSE", trades[0].LeaderTradeType)
+	assert.Equal(t, "SELL", trades[0].Side)
+	assert.Equal(t, "0.075", trades[0].Quantity)
+	assert.Equal(t, "0.025", trades[1].Quantity)
+	assert.Equal(t, "-0.25", trades[1].PnL)
+	assert.Equal(t, "OPEN", trades[2].LeaderTradeType)
+	assert.Equal(t, "BUY", trades[2].Side)
+	assert.Equal(t, "FILLED", trades[2].Status)
+	assert.Equal(t, "50010", trades[2].Price)
+	assert.NotNil(t, trades[2].OrderID)
+
+	f = getFollow(t, follower.Token, f.ID)
+	assert.Equal(t, 3, f.CopiedTrades)
+	assert.Equal(t, "-1.00", f.RealizedPnL)
+
+	// The follower's copies are not mirrored back onto the leader
+	assert.Empty(t, getOpenPositions(t, leader.Token))
+
+	resp = makeRequest(t, "GET", "/copy/leaders/copy_leader", nil, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var profile struct {
+		DisplayName string    `json:"display_name"`
+		Followers   int       `json:"followers"`
+		Stats       StatsInfo `json:"stats"`
+	}
+	parseResponse(t, resp, &profile)
+	assert.Equal(t, "copy_leader", profile.DisplayName)
+	assert.Equal(t, 1, profile.Followers)
+	assert.Equal(t, 1, profile.Stats.Positions)
+}
+
+func TestCopyTrading_RiskLimitsAndUnfollow(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("ETHUSDT", 3000, 3002)
+
+	leader := registerUser(t, uniqueEmail("copy_risk_leader"), "password123")
+	joinLeaderboard(t, leader.Token, "risk_leader")
+	follower := registerUser(t, uniqueEmail("copy_risk_follower"), "password123")
+
+	f := follow(t, follower.Token, map[string]interface{}{
+		"leader":                "risk_leader",
+		"mode":                  "FIXED",
+		"amount":                "100",
+		"max_position_notional": "1500",
+		"max_loss":              "5",
+	})
+
+	resp := makeRequest(t, "POST", "/copy/follows", map[string]interface{}{"leader": "risk_leader", "ratio": "1"}, follower.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// 100 USDT at 20x would be 0.666 ETH; the notional cap allows 1500 USDT
+	placeMarketOrder(t, leader.Token, "ETHUSDT", "BUY", "1", 20)
+	copyUseCase.Wait()
+	positions := getOpenPositions(t, follower.Token)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "0.499666", positions[0].Quantity)
+	assert.Equal(t, 20, positions[0].Leverage)
+
+	// No room is left for the add
+	placeMarketOrder(t, leader.Token, "ETHUSDT", "BUY", "1", 20)
+	copyUseCase.Wait()
+	trades := getCopyTrades(t, follower.Token, f.ID)
+	require.Len(t, trades, 2)
+	assert.Equal(t, "SKIPPED", trades[0].Status)
+	assert.Equal(t, "max position notional reached", trades[0].Error)
+
+	// Closing at a loss beyond max_loss stops the follow
+	priceCache.SetPrice("ETHUSDT", 2980, 2982)
+	closeOpenPosition(t, leader.Token, "ETHUSDT")
+	copyUseCase.Wait()
+	assert.Empty(t, getOpenPositions(t, follower.Token))
+
+	f = getFollow(t, follower.Token, f.ID)
+	assert.Equal(t, "STOPPED", f.Status)
+	assert.Equal(t, "max loss reached", f.StopReason)
+	assert.Equal(t, "-10.99", f.RealizedPnL)
+
+	placeMarketOrder(t, leader.Token, "ETHUSDT", "BUY", "1", 20)
+	copyUseCase.Wait()
+	assert.Empty(t, getOpenPositions(t, follower.Token))
+
+	resp = makeRequest(t, "POST", fmt.Sprintf("/copy/follows/%d/unfollow", f.ID), nil, follower.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &f)
+	assert.Equal(t, "UNFOLLOWED", f.Status)
+
+	resp = makeRequest(t, "POST", fmt.Sprintf("/copy/follows/%d/unfollow", f.ID), nil, follower.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Follows are private to their account
+	resp = makeRequest(t, "GET", fmt.Sprintf("/copy/follows/%d", f.ID), nil, leader.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCopyTrading_Validation(t *testing.T) {
+	cleanupDatabase(t)
+
+	leader := registerUser(t, uniqueEmail("copy_valid_leader"), "password123")
+	joinLeaderboard(t, leader.Token, "valid_leader")
+	private := registerUser(t, uniqueEmail("copy_private"), "password123")
+	resp := makeRequest(t, "PUT", "/leaderboard/profile", map[string]interface{}{"display_name": "private_trader"}, private.Token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cases := []struct {
+		name   string
+		token  string
+		body   map[string]interface{}
+		status int
+	}{
+		{"self", leader.Token, map[string]interface{}{"leader": "valid_leader", "ratio": "1"}, http.StatusBadRequest},
+		{"unknown leader", private.Token, map[string]interface{}{"leader": "nobody_here", "ratio": "1"}, http.StatusNotFound},
+		{"not opted in", leader.Token, map[string]interface{}{"leader": "private_trader", "ratio": "1"}, http.StatusNotFound},
+		{"zero ratio", private.Token, map[string]interface{}{"leader": "valid_leader", "ratio": "0"}, http.StatusBadRequest},
+		{"fixed without amount", private.Token, map[string]interface{}{"leader": "valid_leader", "mode": "FIXED"}, http.StatusBadRequest},
+		{"unknown mode", private.Token, map[string]interface{}{"leader": "valid_leader", "mode": "MIRROR", "ratio": "1"}, http.StatusBadRequest},
+		{"negative max loss", private.Token, map[string]interface{}{"leader": "valid_leader", "ratio": "1", "max_loss": "-5"}, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		resp := makeRequest(t, "POST", "/copy/follows", tc.body, tc.token)
+		resp.Body.Close()
+		assert.Equal(t, tc.status, resp.StatusCode, tc.name)
+	}
+}
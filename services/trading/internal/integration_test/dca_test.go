@@ -0,0 +1,206 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type DCAPlanInfo struct {
+	ID           int64  `json:"id"`
+	Status       string `json:"status"`
+	Interval     string `json:"interval"`
+	NextRunAt    string `json:"next_run_at"`
+	MissedRuns   string `json:"missed_runs"`
+	Quantity     string `json:"quantity"`
+	AverageEntry string `json:"average_entry"`
+	SafetyOrders int    `json:"safety_orders"`
+	Runs         int    `json:"runs"`
+	Cycles       int    `json:"cycles"`
+	RealizedPnL  string `json:"realized_pnl"`
+}
+
+type DCAExecutionInfo struct {
+	Kind     string `json:"kind"`
+	Status   string `json:"status"`
+	RunAt    string `json:"run_at"`
+	OrderID  *int64 `json:"order_id"`
+	Quantity string `json:"quantity"`
+	Price    string `json:"price"`
+	Error    string `json:"error"`
+}
+
+func createDCAPlan(t *testing.T, token string, body map[string]interface{}) DCAPlanInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", "/bots/dca", body, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var plan DCAPlanInfo
+	parseResponse(t, resp, &plan)
+	return plan
+}
+
+func getDCAPlan(t *testing.T, token string, id int64) DCAPlanInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", fmt.Sprintf("/bots/dca/%d", id), nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var plan DCAPlanInfo
+	parseResponse(t, resp, &plan)
+	return plan
+}
+
+func TestDCA_CycleWithSafetyOrderAndTakeProfit(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("dca"), "password123")
+
+	plan := createDCAPlan(t, user.Token, map[string]interface{}{
+		"symbol":            "BTCUSDT",
+		"amount":            "100",
+		"interval":          "1h",
+		"take_profit_pct":   "2",
+		"safety_drop_pct":   "5",
+		"max_safety_orders": 1,
+	})
+	assert.Equal(t, "ACTIVE", plan.Status)
+	assert.Equal(t, "1h0m0s", plan.Interval)
+	assert.Equal(t, "SKIP", plan.MissedRuns)
+
+	// Without start_at the first run is due right away
+	start, err := time.Parse(time.RFC3339, plan.NextRunAt)
+	require.NoError(t, err)
+
+	dcaUseCase.RunDue(testCtx, start)
+	plan = getDCAPlan(t, user.Token, plan.ID)
+	assert.Equal(t, 1, plan.Runs)
+	assert.Equal(t, "0.001999", plan.Quantity)
+	assert.Equal(t, start.Add(time.Hour).UTC().Format("2006-01-02T15:04:05Z"), plan.NextRunAt)
+
+	// A 5% drop below the last buy triggers the safety order
+	priceCache.SetPrice("BTCUSDT", 47000, 47010)
+	dcaUseCase.RunDue(testCtx, start.Add(time.Second))
+	plan = getDCAPlan(t, user.Token, plan.ID)
+	assert.Equal(t, 1, plan.SafetyOrders)
+	assert.Equal(t, "0.004126", plan.Quantity)
+	assert.Equal(t, "48463.47", plan.AverageEntry)
+
+	// The take profit sells the cycle 2% above the average entry (49432.74)
+	priceCache.SetPrice("BTCUSDT", 49500, 49510)
+	dcaUseCase.RunDue(testCtx, start.Add(2*time.Second))
+	plan = getDCAPlan(t, user.Token, plan.ID)
+	assert.Equal(t, 1, plan.Cycles)
+	assert.Equal(t, "ACTIVE", plan.Status) // the filled sell resumes the plan it paused to claim
+	assert.Equal(t, "0", plan.Quantity)
+	assert.Equal(t, 0, plan.SafetyOrders)
+	assert.Equal(t, "4.28", plan.RealizedPnL)
+
+	resp := makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	assert.Empty(t, positions)
+
+	// Five hourly runs fell due during a downtime; SKIP records them and moves on
+	dcaUseCase.RunDue(testCtx, start.Add(5*time.Hour+30*time.Minute))
+	plan = getDCAPlan(t, user.Token, plan.ID)
+	assert.Equal(t, 1, plan.Runs)
+	assert.Equal(t, start.Add(6*time.Hour).UTC().Format("2006-01-02T15:04:05Z"), plan.NextRunAt)
+
+	resp = makeRequest(t, "GET", fmt.Sprintf("/bots/dca/%d/executions", plan.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var executions []DCAExecutionInfo
+	parseResponse(t, resp, &executions)
+	require.Len(t, executions, 4)
+	assert.Equal(t, "SKIPPED", executions[0].Status)
+	assert.Equal(t, "5 missed run(s) skipped", executions[0].Error)
+	assert.Equal(t, start.Add(5*time.Hour).UTC().Format("2006-01-02T15:04:05Z"), executions[0].RunAt)
+	assert.Equal(t, "TAKE_PROFIT", executions[1].Kind)
+	assert.Equal(t, "FILLED", executions[1].Status)
+	assert.Equal(t, "49500", executions[1].Price)
+	assert.Equal(t, "SAFETY", executions[2].Kind)
+	assert.Equal(t, "47010", executions[2].Price)
+	assert.Equal(t, "SCHEDULED", executions[3].Kind)
+	assert.Equal(t, "FILLED", executions[3].Status)
+	assert.NotNil(t, executions[3].OrderID)
+}
+
+func TestDCA_CatchUpAndLifecycle(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("dca_catchup"), "password123")
+
+	plan := createDCAPlan(t, user.Token, map[string]interface{}{
+		"symbol":      "BTCUSDT",
+		"amount":      "50",
+		"interval":    "1h",
+		"missed_runs": "CATCH_UP",
+	})
+	start, err := time.Parse(time.RFC3339, plan.NextRunAt)
+	require.NoError(t, err)
+
+	// The first run and three more came due while the scheduler was down
+	dcaUseCase.RunDue(testCtx, start.Add(3*time.Hour+30*time.Minute))
+	plan = getDCAPlan(t, user.Token, plan.ID)
+	assert.Equal(t, 4, plan.Runs)
+	assert.Equal(t, "0.003996", plan.Quantity)
+	assert.Equal(t, start.Add(4*time.Hour).UTC().Format("2006-01-02T15:04:05Z"), plan.NextRunAt)
+
+	// Paused plans do not run
+	resp := makeRequest(t, "POST", fmt.Sprintf("/bots/dca/%d/pause", plan.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &plan)
+	assert.Equal(t, "PAUSED", plan.Status)
+
+	dcaUseCase.RunDue(testCtx, start.Add(4*time.Hour))
+	assert.Equal(t, 4, getDCAPlan(t, user.Token, plan.ID).Runs)
+
+	resp = makeRequest(t, "POST", fmt.Sprintf("/bots/dca/%d/resume", plan.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &plan)
+	assert.Equal(t, "ACTIVE", plan.Status)
+
+	resp = makeRequest(t, "POST", fmt.Sprintf("/bots/dca/%d/cancel", plan.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &plan)
+	assert.Equal(t, "CANCELLED", plan.Status)
+
+	resp = makeRequest(t, "POST", fmt.Sprintf("/bots/dca/%d/resume", plan.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Plans are private to their account
+	other := registerUser(t, uniqueEmail("dca_other"), "password123")
+	resp = makeRequest(t, "GET", fmt.Sprintf("/bots/dca/%d", plan.ID), nil, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestDCA_Validation(t *testing.T) {
+	user := registerUser(t, uniqueEmail("dca_invalid"), "password123")
+
+	cases := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{"short interval", map[string]interface{}{"symbol": "BTCUSDT", "amount": "50", "interval": "30s"}},
+		{"bad interval", map[string]interface{}{"symbol": "BTCUSDT", "amount": "50", "interval": "daily"}},
+		{"zero amount", map[string]interface{}{"symbol": "BTCUSDT", "amount": "0", "interval": "1h"}},
+		{"unknown policy", map[string]interface{}{"symbol": "BTCUSDT", "amount": "50", "interval": "1h", "missed_runs": "NEVER"}},
+		{"unknown symbol", map[string]interface{}{"symbol": "DOGEUSDT", "amount": "50", "interval": "1h"}},
+		{"leveraged spot", map[string]interface{}{"symbol": "BTC/USDT", "amount": "50", "interval": "1h", "leverage": 2}},
+		{"negative take profit", map[string]interface{}{"symbol": "BTCUSDT", "amount": "50", "interval": "1h", "take_profit_pct": "-1"}},
+		{"safety without count", map[string]interface{}{"symbol": "BTCUSDT", "amount": "50", "interval": "1h", "safety_drop_pct": "5"}},
+	}
+	for _, tc := range cases {
+		resp := makeRequest(t, "POST", "/bots/dca", tc.body, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, tc.name)
+	}
+}
@@ -0,0 +1,170 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type EquityPoint struct {
+	Time          string `json:"time"`
+	Balance       string `json:"balance"`
+	Equity        string `json:"equity"`
+	EquityLow     string `json:"equity_low"`
+	EquityHigh    string `json:"equity_high"`
+	UnrealizedPnL string `json:"unrealized_pnl"`
+	UsedMargin    string `json:"used_margin"`
+	Drawdown      string `json:"drawdown"`
+}
+
+type EquityCurve struct {
+	Resolution  string        `json:"resolution"`
+	MaxDrawdown string        `json:"max_drawdown"`
+	Points      []EquityPoint `json:"points"`
+}
+
+func getEquityCurve(t *testing.T, token, resolution string, from, to time.Time, headers map[string]string) EquityCurve {
+	t.Helper()
+
+	path := fmt.Sprintf("/account/equity?resolution=%s&from=%s&to=%s",
+		resolution, url.QueryEscape(from.Format(time.RFC3339)), url.QueryEscape(to.Format(time.RFC3339)))
+	resp := makeRequestWithHeaders(t, "GET", path, nil, token, headers)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var curve EquityCurve
+	parseResponse(t, resp, &curve)
+	return curve
+}
+
+func snapshotEquity(t *testing.T, at time.Time) {
+	t.Helper()
+
+	_, err := equityUseCase.Snapshot(testCtx, at)
+	require.NoError(t, err)
+}
+
+func TestEquity_SnapshotsAndRollups(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("equity_curve"), "password123")
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	snapshotEquity(t, base.Add(5*time.Second))
+	before := getWalletAccount(t, user.Token)
+
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.1", 10)
+	processPrice(t, "BTCUSDT", 49000, 49010)
+	snapshotEquity(t, base.Add(time.Minute))
+	low := getWalletAccount(t, user.Token)
+
+	processPrice(t, "BTCUSDT", 50500, 50510)
+	snapshotEquity(t, base.Add(2*time.Minute))
+	high := getWalletAccount(t, user.Token)
+
+	// Recording the same minute again keeps a single point
+	snapshotEquity(t, base.Add(2*time.Minute+30*time.Second))
+
+	snapshotEquity(t, base.Add(time.Hour))
+
+	curve := getEquityCurve(t, user.Token, "1m", base, base.Add(3*time.Minute), nil)
+	assert.Equal(t, "1m", curve.Resolution)
+	require.Len(t, curve.Points, 3)
+	assert.Equal(t, "2024-01-15T10:00:00Z", curve.Points[0].Time)
+	assert.Equal(t, "2024-01-15T10:02:00Z", curve.Points[2].Time)
+	assert.Equal(t, before.Equity, curve.Points[0].Equity)
+	assert.Equal(t, "0.00", curve.Points[0].UsedMargin)
+	assert.Equal(t, low.Equity, curve.Points[1].Equity)
+	assert.Equal(t, high.Equity, curve.Points[2].Equity)
+	assert.NotEqual(t, "0.00", curve.Points[1].UsedMargin)
+	assert.NotEqual(t, "0.00", curve.Points[1].UnrealizedPnL)
+
+	// Drawdown is measured from the running peak
+	assert.Equal(t, "0.0000", curve.Points[0].Drawdown)
+	assert.Equal(t, "0.0000", curve.Points[2].Drawdown)
+	startEquity := decimal.RequireFromString(before.Equity)
+	lowEquity := decimal.RequireFromString(low.Equity)
+	expectedDD := startEquity.Sub(lowEquity).Div(startEquity).StringFixed(4)
+	assert.Equal(t, expectedDD, curve.Points[1].Drawdown)
+	assert.Equal(t, expectedDD, curve.MaxDrawdown)
+
+	// The hourly rollup spans the minutes of its hour
+	curve = getEquityCurve(t, user.Token, "1h", base, base.Add(2*time.Hour), nil)
+	require.Len(t, curve.Points, 2)
+	first := curve.Points[0]
+	assert.Equal(t, "2024-01-15T10:00:00Z", first.Time)
+	assert.Equal(t, high.Equity, first.Equity)
+	assert.Equal(t, low.Equity, first.EquityLow)
+	assert.Equal(t, high.Equity, first.EquityHigh)
+	assert.Equal(t, "2024-01-15T11:00:00Z", curve.Points[1].Time)
+
+	curve = getEquityCurve(t, user.Token, "1d", base.Add(-time.Hour), base.Add(time.Hour), nil)
+	require.Len(t, curve.Points, 1)
+	assert.Equal(t, "2024-01-15T00:00:00Z", curve.Points[0].Time)
+	assert.Equal(t, low.Equity, curve.Points[0].EquityLow)
+}
+
+func TestEquity_PerAccount(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("equity_accounts"), "password123")
+	sub := createSubAccount(t, user.Token, "equity")
+	fundSubAccount(t, user.Token, sub.ID, "5000")
+	base := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	snapshotEquity(t, base)
+
+	resp := makeRequestWithHeaders(t, "POST", "/orders", map[string]interface{}{
+		"symbol":   "ETHUSDT",
+		"side":     "BUY",
+		"type":     "MARKET",
+		"quantity": "1",
+		"leverage": 10,
+	}, user.Token, accountHeader(sub.ID))
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	snapshotEquity(t, base.Add(time.Minute))
+
+	primary := getEquityCurve(t, user.Token, "1m", base, base.Add(time.Hour), nil)
+	require.Len(t, primary.Points, 2)
+	assert.Equal(t, "0.00", primary.Points[1].UsedMargin)
+
+	subCurve := getEquityCurve(t, user.Token, "1m", base, base.Add(time.Hour), accountHeader(sub.ID))
+	require.Len(t, subCurve.Points, 2)
+	assert.NotEqual(t, "0.00", subCurve.Points[1].UsedMargin)
+}
+
+func TestEquity_InvalidParams(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("equity_invalid"), "password123")
+	now := time.Now().UTC()
+
+	tests := []string{
+		"/account/equity?resolution=5m",
+		"/account/equity?from=yesterday",
+		// 1m allows at most 1500 points
+		fmt.Sprintf("/account/equity?resolution=1m&from=%s", url.QueryEscape(now.Add(-48*time.Hour).Format(time.RFC3339))),
+		fmt.Sprintf("/account/equity?from=%s&to=%s",
+			url.QueryEscape(now.Format(time.RFC3339)), url.QueryEscape(now.Add(-time.Hour).Format(time.RFC3339))),
+	}
+	for _, path := range tests {
+		resp := makeRequest(t, "GET", path, nil, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, path)
+	}
+
+	// Defaults to hourly over the last 30 days
+	resp := makeRequest(t, "GET", "/account/equity", nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var curve EquityCurve
+	parseResponse(t, resp, &curve)
+	assert.Equal(t, "1h", curve.Resolution)
+	assert.Empty(t, curve.Points)
+}
@@ -0,0 +1,125 @@
+package integration_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportHistory(t *testing.T, token, kind, query string, headers map[string]string) (*http.Response, []byte) {
+	t.Helper()
+
+	resp := makeRequestWithHeaders(t, "GET", "/export/"+kind+query, nil, token, headers)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func TestExport_CSV(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("export_csv"), "password123")
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.1", 10)
+	placeMarketOrder(t, user.Token, "BTCUSDT", "SELL", "0.1", 10)
+	placeMarketOrder(t, user.Token, "ETHUSDT", "BUY", "1", 5)
+
+	resp, body := exportHistory(t, user.Token, "trades", "?format=csv", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/csv")
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, []string{"id", "position_id", "order_id", "symbol", "side", "type", "quantity", "price", "pnl", "fee", "created_at"}, records[0])
+	// Oldest first
+	assert.Equal(t, "BTCUSDT", records[1][3])
+	assert.Equal(t, "OPEN", records[1][5])
+	assert.Equal(t, "CLOSE", records[2][5])
+	assert.Equal(t, "ETHUSDT", records[3][3])
+
+	// CSV is the default format
+	resp, body = exportHistory(t, user.Token, "positions", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	records, err = csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "CLOSED", records[1][3])
+	assert.NotEmpty(t, records[1][12], "closed_at")
+	assert.Equal(t, "OPEN", records[2][3])
+	assert.Empty(t, records[2][12], "closed_at")
+}
+
+func TestExport_JSON(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("export_json"), "password123")
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.1", 10)
+	placeMarketOrder(t, user.Token, "BTCUSDT", "SELL", "0.1", 10)
+
+	resp, body := exportHistory(t, user.Token, "orders", "?format=json", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var orders []struct {
+		ID       int64   `json:"id"`
+		Side     string  `json:"side"`
+		Status   string  `json:"status"`
+		Quantity string  `json:"quantity"`
+		FilledAt *string `json:"filled_at"`
+	}
+	require.NoError(t, json.Unmarshal(body, &orders))
+	require.Len(t, orders, 2)
+	assert.Equal(t, "BUY", orders[0].Side)
+	assert.Equal(t, "SELL", orders[1].Side)
+	assert.Equal(t, "FILLED", orders[0].Status)
+	assert.NotNil(t, orders[0].FilledAt)
+
+	// Date range filter
+	future := url.QueryEscape(time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	resp, body = exportHistory(t, user.Token, "trades", "?format=json&from="+future, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var trades []map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &trades))
+	assert.Empty(t, trades)
+
+	past := url.QueryEscape(time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	resp, body = exportHistory(t, user.Token, "trades", "?format=json&from="+past+"&to="+future, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.Unmarshal(body, &trades))
+	assert.Len(t, trades, 2)
+
+	// Exports are scoped to the selected account
+	sub := createSubAccount(t, user.Token, "empty")
+	resp, body = exportHistory(t, user.Token, "trades", "?format=json", accountHeader(sub.ID))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.Unmarshal(body, &trades))
+	assert.Empty(t, trades)
+}
+
+func TestExport_Validation(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("export_invalid"), "password123")
+
+	resp, _ := exportHistory(t, user.Token, "trades", "?format=xml", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = exportHistory(t, user.Token, "trades", "?from=yesterday", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = exportHistory(t, user.Token, "ledger", "", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, _ = exportHistory(t, "", "trades", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
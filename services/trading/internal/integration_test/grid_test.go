@@ -0,0 +1,206 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type GridBotInfo struct {
+	ID       int64  `json:"id"`
+	Symbol   string `json:"symbol"`
+	Quantity string `json:"quantity"`
+	Status   string `json:"status"`
+	Orders   []struct {
+		Level   int    `json:"level"`
+		Price   string `json:"price"`
+		Side    string `json:"side"`
+		OrderID int64  `json:"order_id"`
+	} `json:"orders"`
+	BuyFills   int     `json:"buy_fills"`
+	SellFills  int     `json:"sell_fills"`
+	Rounds     int     `json:"rounds"`
+	GridProfit string  `json:"grid_profit"`
+	Position   string  `json:"position"`
+	MarkPrice  *string `json:"mark_price"`
+	PnL        string  `json:"pnl"`
+	StoppedAt  *string `json:"stopped_at"`
+}
+
+// gridLevels returns the resting orders as "SIDE@price"
+func gridLevels(bot GridBotInfo) []string {
+	levels := make([]string, len(bot.Orders))
+	for i, o := range bot.Orders {
+		levels[i] = o.Side + "@" + o.Price
+	}
+	return levels
+}
+
+func getGridBot(t *testing.T, token string, id int64) GridBotInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", fmt.Sprintf("/bots/grid/%d", id), nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var bot GridBotInfo
+	parseResponse(t, resp, &bot)
+	return bot
+}
+
+func TestGridBot_Lifecycle(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("grid"), "password123")
+
+	// Levels 49000, 49500, 50000, 50500, 51000; the mid 50005 is nearest to 50000
+	resp := makeRequest(t, "POST", "/bots/grid", map[string]interface{}{
+		"symbol":      "BTCUSDT",
+		"lower_price": "49000",
+		"upper_price": "51000",
+		"grids":       4,
+		"investment":  "1000",
+	}, user.Token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var bot GridBotInfo
+	parseResponse(t, resp, &bot)
+	assert.Equal(t, "ACTIVE", bot.Status)
+	assert.Equal(t, "0.004999", bot.Quantity)
+	assert.Equal(t, []string{"BUY@49000", "BUY@49500", "SELL@50500", "SELL@51000"}, gridLevels(bot))
+
+	resp = makeRequest(t, "GET", "/orders?status=PENDING", nil, user.Token)
+	var orders []OrderResponse
+	parseResponse(t, resp, &orders)
+	assert.Len(t, orders, 4)
+
+	// The ask drops through 49500: the buy fills and a sell goes up one level
+	processPrice(t, "BTCUSDT", 49400, 49410)
+	bot = getGridBot(t, user.Token, bot.ID)
+	assert.Equal(t, 1, bot.BuyFills)
+	assert.Equal(t, "0.004999", bot.Position)
+	assert.Equal(t, []string{"BUY@49000", "SELL@50000", "SELL@50500", "SELL@51000"}, gridLevels(bot))
+
+	// The bid comes back to 50000 and closes the round
+	processPrice(t, "BTCUSDT", 50000, 50010)
+	bot = getGridBot(t, user.Token, bot.ID)
+	assert.Equal(t, 1, bot.SellFills)
+	assert.Equal(t, 1, bot.Rounds)
+	assert.Equal(t, "2.50", bot.GridProfit)
+	assert.Equal(t, "0", bot.Position)
+	assert.Equal(t, "2.50", bot.PnL)
+	assert.Equal(t, []string{"BUY@49000", "BUY@49500", "SELL@50500", "SELL@51000"}, gridLevels(bot))
+
+	resp = makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	assert.Empty(t, positions)
+
+	// Pausing cancels the orders, resuming lays them out around the new price
+	resp = makeRequest(t, "POST", fmt.Sprintf("/bots/grid/%d/pause", bot.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &bot)
+	assert.Equal(t, "PAUSED", bot.Status)
+	assert.Empty(t, bot.Orders)
+
+	// Paused bots do not trade
+	processPrice(t, "BTCUSDT", 50500, 50510)
+	assert.Equal(t, 1, getGridBot(t, user.Token, bot.ID).SellFills)
+
+	resp = makeRequest(t, "POST", fmt.Sprintf("/bots/grid/%d/resume", bot.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &bot)
+	assert.Equal(t, "ACTIVE", bot.Status)
+	assert.Equal(t, []string{"BUY@49000", "BUY@49500", "BUY@50000", "SELL@51000"}, gridLevels(bot))
+
+	// The sell at 51000 opens a short that the stop closes at market
+	processPrice(t, "BTCUSDT", 51000, 51010)
+	bot = getGridBot(t, user.Token, bot.ID)
+	assert.Equal(t, "-0.004999", bot.Position)
+	assert.Equal(t, 1, bot.Rounds)
+
+	resp = makeRequest(t, "POST", fmt.Sprintf("/bots/grid/%d/stop", bot.ID), map[string]interface{}{
+		"close_position": true,
+	}, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &bot)
+	assert.Equal(t, "STOPPED", bot.Status)
+	assert.NotNil(t, bot.StoppedAt)
+	assert.Equal(t, "0", bot.Position)
+	assert.Empty(t, bot.Orders)
+	assert.Equal(t, "2.45", bot.PnL)
+
+	resp = makeRequest(t, "GET", "/positions", nil, user.Token)
+	parseResponse(t, resp, &positions)
+	assert.Empty(t, positions)
+
+	resp = makeRequest(t, "GET", "/orders?status=PENDING", nil, user.Token)
+	parseResponse(t, resp, &orders)
+	assert.Empty(t, orders)
+
+	// Stopped bots cannot be restarted
+	resp = makeRequest(t, "POST", fmt.Sprintf("/bots/grid/%d/resume", bot.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = makeRequest(t, "GET", "/bots/grid", nil, user.Token)
+	var bots []GridBotInfo
+	parseResponse(t, resp, &bots)
+	require.Len(t, bots, 1)
+	assert.Equal(t, 1, bots[0].Rounds)
+}
+
+func TestGridBot_Validation(t *testing.T) {
+	user := registerUser(t, uniqueEmail("grid_invalid"), "password123")
+
+	valid := func(overrides map[string]interface{}) map[string]interface{} {
+		body := map[string]interface{}{
+			"symbol":      "BTCUSDT",
+			"lower_price": "49000",
+			"upper_price": "51000",
+			"grids":       4,
+			"investment":  "1000",
+		}
+		for k, v := range overrides {
+			body[k] = v
+		}
+		return body
+	}
+
+	cases := []struct {
+		name   string
+		body   map[string]interface{}
+		status int
+	}{
+		{"one grid", valid(map[string]interface{}{"grids": 1}), http.StatusBadRequest},
+		{"inverted range", valid(map[string]interface{}{"lower_price": "51000", "upper_price": "49000"}), http.StatusBadRequest},
+		{"price above range", valid(map[string]interface{}{"lower_price": "40000", "upper_price": "45000"}), http.StatusBadRequest},
+		{"unknown symbol", valid(map[string]interface{}{"symbol": "DOGEUSDT"}), http.StatusBadRequest},
+		{"bad investment", valid(map[string]interface{}{"investment": "lots"}), http.StatusBadRequest},
+		{"over margin", valid(map[string]interface{}{"investment": "20000"}), http.StatusUnprocessableEntity},
+	}
+	for _, tc := range cases {
+		resp := makeRequest(t, "POST", "/bots/grid", tc.body, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, tc.status, resp.StatusCode, tc.name)
+	}
+
+	resp := makeRequest(t, "POST", "/bots/grid", valid(nil), user.Token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var bot GridBotInfo
+	parseResponse(t, resp, &bot)
+
+	// Bots are private to their account
+	other := registerUser(t, uniqueEmail("grid_other"), "password123")
+	resp = makeRequest(t, "GET", fmt.Sprintf("/bots/grid/%d", bot.ID), nil, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = makeRequest(t, "POST", fmt.Sprintf("/bots/grid/%d/stop", bot.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &bot)
+	assert.Equal(t, "STOPPED", bot.Status)
+}
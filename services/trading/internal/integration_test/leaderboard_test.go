@@ -0,0 +1,189 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type LeaderboardInfo struct {
+	Period    string  `json:"period"`
+	Metric    string  `json:"metric"`
+	From      *string `json:"from"`
+	UpdatedAt string  `json:"updated_at"`
+	Entries   []struct {
+		Rank         int     `json:"rank"`
+		DisplayName  string  `json:"display_name"`
+		RealizedPnL  string  `json:"realized_pnl"`
+		ROI          string  `json:"roi"`
+		RiskAdjusted *string `json:"risk_adjusted"`
+		Trades       int     `json:"trades"`
+		WinRate      string  `json:"win_rate"`
+	} `json:"entries"`
+}
+
+// getLeaderboard fetches a leaderboard without authentication
+func getLeaderboard(t *testing.T, query string) LeaderboardInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", "/leaderboard"+query, nil, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var board LeaderboardInfo
+	parseResponse(t, resp, &board)
+	return board
+}
+
+func joinLeaderboard(t *testing.T, token, name string) {
+	t.Helper()
+
+	resp := makeRequest(t, "PUT", "/leaderboard/profile", map[string]interface{}{
+		"display_name": name,
+		"opt_in":       true,
+	}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestLeaderboard_Profile(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("board_profile"), "password123")
+
+	resp := makeRequest(t, "GET", "/leaderboard/profile", nil, user.Token)
+	var profile struct {
+		DisplayName string `json:"display_name"`
+		OptIn       bool   `json:"opt_in"`
+	}
+	parseResponse(t, resp, &profile)
+	assert.Equal(t, "", profile.DisplayName)
+	assert.False(t, profile.OptIn)
+
+	// Opting in needs a display name
+	resp = makeRequest(t, "PUT", "/leaderboard/profile", map[string]interface{}{"opt_in": true}, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for _, name := range []string{"ab", "has space", "this_name_is_far_too_long_for_a_board"} {
+		resp = makeRequest(t, "PUT", "/leaderboard/profile", map[string]interface{}{"display_name": name}, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, name)
+	}
+
+	joinLeaderboard(t, user.Token, "Satoshi")
+
+	resp = makeRequest(t, "GET", "/leaderboard/profile", nil, user.Token)
+	parseResponse(t, resp, &profile)
+	assert.Equal(t, "Satoshi", profile.DisplayName)
+	assert.True(t, profile.OptIn)
+
+	// Opting out keeps the name
+	resp = makeRequest(t, "PUT", "/leaderboard/profile", map[string]interface{}{"opt_in": false}, user.Token)
+	parseResponse(t, resp, &profile)
+	assert.Equal(t, "Satoshi", profile.DisplayName)
+	assert.False(t, profile.OptIn)
+
+	// Names are unique regardless of case
+	other := registerUser(t, uniqueEmail("board_profile_other"), "password123")
+	resp = makeRequest(t, "PUT", "/leaderboard/profile", map[string]interface{}{"display_name": "satoshi"}, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = makeRequest(t, "GET", "/leaderboard/profile", nil, "")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestLeaderboard_Ranking(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+	defer priceCache.SetPrice("ETHUSDT", 3000, 3002)
+
+	alice := registerUser(t, uniqueEmail("board_alice"), "password123")
+	bob := registerUser(t, uniqueEmail("board_bob"), "password123")
+	carol := registerUser(t, uniqueEmail("board_carol"), "password123")
+	joinLeaderboard(t, alice.Token, "alice")
+	joinLeaderboard(t, bob.Token, "bob")
+
+	// Alice +99, Carol +198: long BTC 50010 -> 51000
+	placeMarketOrder(t, alice.Token, "BTCUSDT", "BUY", "0.1", 10)
+	placeMarketOrder(t, carol.Token, "BTCUSDT", "BUY", "0.2", 10)
+	priceCache.SetPrice("BTCUSDT", 51000, 51010)
+	closeOpenPosition(t, alice.Token, "BTCUSDT")
+	closeOpenPosition(t, carol.Token, "BTCUSDT")
+
+	// Alice +48 long 3002 -> 3050, Bob -52 short 3000 -> 3052
+	placeMarketOrder(t, alice.Token, "ETHUSDT", "BUY", "1", 10)
+	placeMarketOrder(t, bob.Token, "ETHUSDT", "SELL", "1", 10)
+	priceCache.SetPrice("ETHUSDT", 3050, 3052)
+	closeOpenPosition(t, alice.Token, "ETHUSDT")
+	closeOpenPosition(t, bob.Token, "ETHUSDT")
+
+	board := getLeaderboard(t, "?period=daily&metric=pnl")
+	assert.Equal(t, "daily", board.Period)
+	assert.Equal(t, "pnl", board.Metric)
+	require.NotNil(t, board.From)
+
+	// Carol has not opted in
+	require.Len(t, board.Entries, 2)
+	assert.Equal(t, 1, board.Entries[0].Rank)
+	assert.Equal(t, "alice", board.Entries[0].DisplayName)
+	assert.Equal(t, "147.00", board.Entries[0].RealizedPnL)
+	assert.Equal(t, "0.0147", board.Entries[0].ROI)
+	assert.Equal(t, 2, board.Entries[0].Trades)
+	assert.Equal(t, "1.0000", board.Entries[0].WinRate)
+	assert.Equal(t, "bob", board.Entries[1].DisplayName)
+	assert.Equal(t, "-52.00", board.Entries[1].RealizedPnL)
+	assert.Equal(t, "-0.0052", board.Entries[1].ROI)
+	assert.Equal(t, "0.0000", board.Entries[1].WinRate)
+
+	board = getLeaderboard(t, "?period=all_time&metric=roi")
+	assert.Nil(t, board.From)
+	require.Len(t, board.Entries, 2)
+	assert.Equal(t, "alice", board.Entries[0].DisplayName)
+
+	// A single trade has no risk-adjusted score
+	board = getLeaderboard(t, "?period=weekly&metric=risk_adjusted")
+	require.Len(t, board.Entries, 1)
+	assert.Equal(t, "alice", board.Entries[0].DisplayName)
+	require.NotNil(t, board.Entries[0].RiskAdjusted)
+	assert.Equal(t, "2.8824", *board.Entries[0].RiskAdjusted) // mean 73.5 / stddev 36.06 * sqrt(2)
+
+	// Joining shows at once
+	joinLeaderboard(t, carol.Token, "carol")
+	board = getLeaderboard(t, "?period=monthly")
+	require.Len(t, board.Entries, 3)
+	assert.Equal(t, "carol", board.Entries[0].DisplayName)
+	assert.Equal(t, "198.00", board.Entries[0].RealizedPnL)
+
+	// Other trades are served from the cache until the next refresh
+	placeMarketOrder(t, bob.Token, "ETHUSDT", "BUY", "1", 10)
+	priceCache.SetPrice("ETHUSDT", 3500, 3502)
+	closeOpenPosition(t, bob.Token, "ETHUSDT")
+
+	board = getLeaderboard(t, "?period=monthly")
+	assert.Equal(t, "carol", board.Entries[0].DisplayName)
+
+	require.NoError(t, boardUseCase.Refresh(context.Background(), time.Now()))
+	board = getLeaderboard(t, "?period=monthly")
+	assert.Equal(t, "bob", board.Entries[0].DisplayName)
+	assert.Equal(t, "396.00", board.Entries[0].RealizedPnL) // -52 + (3500 - 3052)
+	assert.Equal(t, 2, board.Entries[0].Trades)
+}
+
+func TestLeaderboard_InvalidParams(t *testing.T) {
+	for _, query := range []string{"?period=yearly", "?metric=volume", "?limit=0", "?limit=abc"} {
+		resp := makeRequest(t, "GET", "/leaderboard"+query, nil, "")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+
+	// Defaults to the weekly PnL board
+	board := getLeaderboard(t, "")
+	assert.Equal(t, "weekly", board.Period)
+	assert.Equal(t, "pnl", board.Metric)
+}
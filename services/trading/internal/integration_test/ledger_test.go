@@ -0,0 +1,106 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type LedgerEntryInfo struct {
+	ID           int64  `json:"id"`
+	Type         string `json:"type"`
+	Amount       string `json:"amount"`
+	BalanceAfter string `json:"balance_after"`
+	TradeID      *int64 `json:"trade_id"`
+	PositionID   *int64 `json:"position_id"`
+}
+
+func getLedger(t *testing.T, token, query string) []LedgerEntryInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", "/account/ledger"+query, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var entries []LedgerEntryInfo
+	parseResponse(t, resp, &entries)
+	return entries
+}
+
+func TestLedger_InitialDeposit(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("ledger_initial"), "password123")
+
+	entries := getLedger(t, user.Token, "")
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, "INITIAL_DEPOSIT", entries[0].Type)
+	assert.Equal(t, "10000", entries[0].Amount)
+	assert.Equal(t, "10000", entries[0].BalanceAfter)
+	assert.Nil(t, entries[0].TradeID)
+}
+
+func TestLedger_RealizedPnLOnClose(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("ledger_close"), "password123")
+
+	orderBody := map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "BUY",
+		"type":     "MARKET",
+		"quantity": "0.1",
+		"leverage": 10,
+	}
+	orderResp := makeRequest(t, "POST", "/orders", orderBody, user.Token)
+	orderResp.Body.Close()
+	require.Equal(t, http.StatusCreated, orderResp.StatusCode)
+
+	posResp := makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []struct {
+		ID int64 `json:"id"`
+	}
+	parseResponse(t, posResp, &positions)
+	require.Len(t, positions, 1)
+
+	closeResp := makeRequest(t, "POST", fmt.Sprintf("/positions/%d/close", positions[0].ID), nil, user.Token)
+	closeResp.Body.Close()
+	require.Equal(t, http.StatusOK, closeResp.StatusCode)
+
+	entries := getLedger(t, user.Token, "?type=REALIZED_PNL")
+	require.Len(t, entries, 1)
+
+	// Long opens at ask 50010 and closes at bid 50000: (50000 - 50010) * 0.1 = -1
+	assert.Equal(t, "-1", entries[0].Amount)
+	assert.Equal(t, "9999", entries[0].BalanceAfter)
+	require.NotNil(t, entries[0].TradeID)
+	require.NotNil(t, entries[0].PositionID)
+	assert.Equal(t, positions[0].ID, *entries[0].PositionID)
+
+	// Ledger balance matches the account balance
+	accountResp := makeRequest(t, "GET", "/account", nil, user.Token)
+	var info AccountInfo
+	parseResponse(t, accountResp, &info)
+	assert.Equal(t, "9999.00", info.Balance)
+
+	all := getLedger(t, user.Token, "")
+	assert.Len(t, all, 2)
+}
+
+func TestLedger_InvalidType(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("ledger_invalid"), "password123")
+
+	resp := makeRequest(t, "GET", "/account/ledger?type=BONUS", nil, user.Token)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var result map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Contains(t, result["error"], "invalid ledger entry type")
+}
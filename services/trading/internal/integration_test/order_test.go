@@ -4,10 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"trading/internal/domain"
 )
 
 type OrderResponse struct {
@@ -278,6 +282,134 @@ func TestPlaceOrder_LimitOrder(t *testing.T) {
 	assert.Equal(t, "45000", order.Price)
 }
 
+func placeLimitOrder(t *testing.T, token, quantity, price string, leverage int) OrderResponse {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", "/orders", map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "BUY",
+		"type":     "LIMIT",
+		"quantity": quantity,
+		"price":    price,
+		"leverage": leverage,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var order OrderResponse
+	parseResponse(t, resp, &order)
+	require.Equal(t, "PENDING", order.Status)
+	return order
+}
+
+func TestLimitOrder_FillsWhenPriceReached(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("limit_fill"), "password123")
+	order := placeLimitOrder(t, user.Token, "0.1", "49000", 10)
+
+	// The ask has not reached the limit yet
+	processPrice(t, "BTCUSDT", 49100, 49110)
+	resp := makeRequest(t, "GET", "/orders?status=PENDING", nil, user.Token)
+	var orders []OrderResponse
+	parseResponse(t, resp, &orders)
+	require.Len(t, orders, 1)
+
+	processPrice(t, "BTCUSDT", 48990, 49000)
+	resp = makeRequest(t, "GET", "/orders", nil, user.Token)
+	parseResponse(t, resp, &orders)
+	require.Len(t, orders, 1)
+	assert.Equal(t, order.ID, orders[0].ID)
+	assert.Equal(t, "FILLED", orders[0].Status)
+
+	// Filled at the limit, not at the market
+	resp = makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "LONG", positions[0].Side)
+	assert.Equal(t, "0.1", positions[0].Quantity)
+	assert.Equal(t, "49000", positions[0].EntryPrice)
+}
+
+func TestLimitOrder_RejectedWithoutMarginAtFill(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	// Each order needs 7350 of margin at the limit; resting orders hold none,
+	// so both are accepted but only one fits the balance once filled
+	user := registerUser(t, uniqueEmail("limit_reject"), "password123")
+	placeLimitOrder(t, user.Token, "0.15", "49000", 1)
+	placeLimitOrder(t, user.Token, "0.15", "49000", 1)
+
+	processPrice(t, "BTCUSDT", 48990, 49000)
+
+	resp := makeRequest(t, "GET", "/orders", nil, user.Token)
+	var orders []OrderResponse
+	parseResponse(t, resp, &orders)
+	require.Len(t, orders, 2)
+	statuses := []string{orders[0].Status, orders[1].Status}
+	assert.ElementsMatch(t, []string{"FILLED", "REJECTED"}, statuses)
+
+	resp = makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "0.15", positions[0].Quantity)
+}
+
+func TestCancelOrder_RacingFill(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("cancel_race"), "password123")
+
+	// Each round the cancel and the fill race for the order; exactly one wins
+	const rounds = 10
+	fills := 0
+	for i := 0; i < rounds; i++ {
+		order := placeLimitOrder(t, user.Token, "0.01", "49000", 10)
+
+		var wg sync.WaitGroup
+		var fillErr error
+		var cancelStatus int
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, fillErr = orderUseCase.FillOrder(testCtx, domain.OrderID(order.ID))
+		}()
+		go func() {
+			defer wg.Done()
+			resp := makeRequest(t, "DELETE", fmt.Sprintf("/orders/%d", order.ID), nil, user.Token)
+			resp.Body.Close()
+			cancelStatus = resp.StatusCode
+		}()
+		wg.Wait()
+
+		resp := makeRequest(t, "GET", fmt.Sprintf("/orders/%d", order.ID), nil, user.Token)
+		var stored OrderResponse
+		parseResponse(t, resp, &stored)
+
+		if fillErr == nil {
+			fills++
+			assert.Equal(t, http.StatusBadRequest, cancelStatus)
+			assert.Equal(t, "FILLED", stored.Status)
+		} else {
+			assert.ErrorIs(t, fillErr, domain.ErrOrderNotPending)
+			assert.Equal(t, http.StatusOK, cancelStatus)
+			assert.Equal(t, "CANCELLED", stored.Status)
+		}
+	}
+
+	// Only the fills that won opened or added to the position
+	positions := getOpenPositions(t, user.Token)
+	if fills == 0 {
+		assert.Empty(t, positions)
+	} else {
+		require.Len(t, positions, 1)
+		assert.Equal(t, decimal.NewFromFloat(0.01).Mul(decimal.NewFromInt(int64(fills))).String(), positions[0].Quantity)
+	}
+}
+
 func TestCancelOrder_Pending(t *testing.T) {
 	cleanupDatabase(t)
 
@@ -0,0 +1,119 @@
+package integration_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getPage fetches one page of a list endpoint and returns the next page cursor
+func getPage(t *testing.T, token, path string, out interface{}) string {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", path, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode, path)
+	next := resp.Header.Get("X-Next-Cursor")
+	parseResponse(t, resp, out)
+	return next
+}
+
+func TestPagination_TradesCursor(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("page_trades"), "password123")
+	for i := 0; i < 2; i++ {
+		placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.01", 10)
+		placeMarketOrder(t, user.Token, "BTCUSDT", "SELL", "0.01", 10)
+	}
+	placeMarketOrder(t, user.Token, "ETHUSDT", "SELL", "1", 5)
+
+	// 5 trades in pages of 2, newest first without gaps or duplicates
+	var ids []int64
+	path := "/trades?limit=2"
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+		var trades []TradeResponse
+		next := getPage(t, user.Token, path, &trades)
+		for _, tr := range trades {
+			ids = append(ids, tr.ID)
+		}
+		if next == "" {
+			break
+		}
+		// A trade arriving between pages does not shift the next one
+		if pages == 0 {
+			placeMarketOrder(t, user.Token, "ETHUSDT", "BUY", "1", 5)
+		}
+		path = "/trades?limit=2&cursor=" + next
+	}
+	require.Len(t, ids, 5)
+	for i := 1; i < len(ids); i++ {
+		assert.Less(t, ids[i], ids[i-1])
+	}
+
+	var trades []TradeResponse
+	getPage(t, user.Token, "/trades?symbol=ETHUSDT", &trades)
+	assert.Len(t, trades, 2)
+	getPage(t, user.Token, "/trades?type=CLOSE", &trades)
+	assert.Len(t, trades, 3)
+	getPage(t, user.Token, "/trades?side=SHORT&type=OPEN", &trades)
+	require.Len(t, trades, 1)
+	assert.Equal(t, "ETHUSDT", trades[0].Symbol)
+
+	for _, query := range []string{"?side=BUY", "?type=FUNDING", "?cursor=bogus", "?to=tomorrow"} {
+		resp := makeRequest(t, "GET", "/trades"+query, nil, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestPagination_OrdersFilters(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("page_orders"), "password123")
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.01", 10)
+	placeMarketOrder(t, user.Token, "ETHUSDT", "BUY", "1", 5)
+
+	resp := makeRequest(t, "POST", "/orders", map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "BUY",
+		"type":     "LIMIT",
+		"quantity": "0.01",
+		"price":    "40000",
+		"leverage": 10,
+	}, user.Token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	var orders []OrderResponse
+	next := getPage(t, user.Token, "/orders?limit=2", &orders)
+	require.Len(t, orders, 2)
+	assert.Equal(t, "LIMIT", orders[0].Type)
+	require.NotEmpty(t, next)
+
+	getPage(t, user.Token, "/orders?limit=2&cursor="+next, &orders)
+	require.Len(t, orders, 1)
+	assert.Equal(t, "MARKET", orders[0].Type)
+
+	next = getPage(t, user.Token, "/orders?status=PENDING", &orders)
+	require.Len(t, orders, 1)
+	assert.Equal(t, "40000", orders[0].Price)
+	assert.Empty(t, next)
+
+	getPage(t, user.Token, "/orders?symbol=BTCUSDT&type=MARKET", &orders)
+	assert.Len(t, orders, 1)
+
+	// Offset pagination keeps working
+	getPage(t, user.Token, "/orders?limit=1&offset=2", &orders)
+	require.Len(t, orders, 1)
+	assert.Equal(t, "BTCUSDT", orders[0].Symbol)
+	assert.Equal(t, "MARKET", orders[0].Type)
+
+	for _, query := range []string{"?status=DONE", "?type=STOP", "?side=LONG", "?cursor=bogus"} {
+		resp := makeRequest(t, "GET", "/orders"+query, nil, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
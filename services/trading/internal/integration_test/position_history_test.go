@@ -0,0 +1,115 @@
+package integration_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type PositionHistoryInfo struct {
+	Positions []struct {
+		Position       PositionResponse `json:"position"`
+		Trades         []TradeResponse  `json:"trades"`
+		RealizedPnL    string           `json:"realized_pnl"`
+		Fees           string           `json:"fees"`
+		ROE            string           `json:"roe"`
+		HoldingTimeSec int64            `json:"holding_time_sec"`
+		ClosedAt       string           `json:"closed_at"`
+	} `json:"positions"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+func getPositionHistory(t *testing.T, token, query string) PositionHistoryInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", "/positions/history"+query, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var history PositionHistoryInfo
+	parseResponse(t, resp, &history)
+	return history
+}
+
+func TestPositionHistory_Enriched(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("pos_history"), "password123")
+
+	// LONG 0.1 BTC @ 50010, closed @ 51000: PnL 99 on 500.1 margin
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.1", 10)
+	priceCache.SetPrice("BTCUSDT", 51000, 51010)
+	placeMarketOrder(t, user.Token, "BTCUSDT", "SELL", "0.1", 10)
+
+	// SHORT 1 ETH @ 3000, closed @ 3002
+	placeMarketOrder(t, user.Token, "ETHUSDT", "SELL", "1", 5)
+	placeMarketOrder(t, user.Token, "ETHUSDT", "BUY", "1", 5)
+
+	// Open positions are not part of the history
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.01", 10)
+
+	history := getPositionHistory(t, user.Token, "")
+	require.Len(t, history.Positions, 2)
+	assert.Nil(t, history.NextCursor)
+
+	// Latest close first
+	eth := history.Positions[0]
+	assert.Equal(t, "ETHUSDT", eth.Position.Symbol)
+	assert.Equal(t, "SHORT", eth.Position.Side)
+	assert.Equal(t, "-2.00", eth.RealizedPnL)
+
+	btc := history.Positions[1]
+	assert.Equal(t, "CLOSED", btc.Position.Status)
+	require.Len(t, btc.Trades, 2)
+	assert.Equal(t, "OPEN", btc.Trades[0].Type)
+	assert.Equal(t, "CLOSE", btc.Trades[1].Type)
+	assert.Equal(t, "99.00", btc.RealizedPnL)
+	assert.Equal(t, "19.80", btc.ROE)
+	assert.Equal(t, "0.00", btc.Fees)
+	assert.GreaterOrEqual(t, btc.HoldingTimeSec, int64(0))
+	assert.NotEmpty(t, btc.ClosedAt)
+}
+
+func TestPositionHistory_FiltersAndCursor(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("pos_history_page"), "password123")
+	for i := 0; i < 3; i++ {
+		placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.01", 10)
+		placeMarketOrder(t, user.Token, "BTCUSDT", "SELL", "0.01", 10)
+	}
+	placeMarketOrder(t, user.Token, "ETHUSDT", "SELL", "1", 5)
+	placeMarketOrder(t, user.Token, "ETHUSDT", "BUY", "1", 5)
+
+	// Walk all pages of two
+	var ids []int64
+	query := "?limit=2"
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+		page := getPositionHistory(t, user.Token, query)
+		for _, p := range page.Positions {
+			ids = append(ids, p.Position.ID)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		query = "?limit=2&cursor=" + *page.NextCursor
+	}
+	require.Len(t, ids, 4)
+	for i := 1; i < len(ids); i++ {
+		assert.Less(t, ids[i], ids[i-1], "pages must not overlap")
+	}
+
+	assert.Len(t, getPositionHistory(t, user.Token, "?symbol=BTCUSDT").Positions, 3)
+	assert.Len(t, getPositionHistory(t, user.Token, "?side=SHORT").Positions, 1)
+	assert.Len(t, getPositionHistory(t, user.Token, "?status=CLOSED").Positions, 4)
+	assert.Empty(t, getPositionHistory(t, user.Token, "?status=LIQUIDATED").Positions)
+
+	for _, query := range []string{"?status=OPEN", "?side=BUY", "?cursor=bogus", "?from=yesterday"} {
+		resp := makeRequest(t, "GET", "/positions/history"+query, nil, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
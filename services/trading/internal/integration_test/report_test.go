@@ -0,0 +1,148 @@
+package integration_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type PnLAmountsInfo struct {
+	RealizedPnL string `json:"realized_pnl"`
+	Fees        string `json:"fees"`
+	Funding     string `json:"funding"`
+	NetPnL      string `json:"net_pnl"`
+	Lots        int    `json:"lots"`
+}
+
+type PnLReportInfo struct {
+	Period  string         `json:"period"`
+	Total   PnLAmountsInfo `json:"total"`
+	Periods []struct {
+		Start string `json:"start"`
+		PnLAmountsInfo
+		BySymbol []struct {
+			Symbol string `json:"symbol"`
+			PnLAmountsInfo
+		} `json:"by_symbol"`
+	} `json:"periods"`
+	Lots []struct {
+		Symbol         string `json:"symbol"`
+		Side           string `json:"side"`
+		Quantity       string `json:"quantity"`
+		EntryPrice     string `json:"entry_price"`
+		ExitPrice      string `json:"exit_price"`
+		HoldingTimeSec int64  `json:"holding_time_sec"`
+		PnL            string `json:"pnl"`
+	} `json:"lots"`
+}
+
+func getPnLReport(t *testing.T, token, query string) PnLReportInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", "/account/reports/pnl"+query, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var report PnLReportInfo
+	parseResponse(t, resp, &report)
+	return report
+}
+
+func TestReport_RealizedPnLFIFO(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("report_fifo"), "password123")
+
+	// Two lots: 0.1 @ 50010 and 0.1 @ 51010
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.1", 10)
+	priceCache.SetPrice("BTCUSDT", 51000, 51010)
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.1", 10)
+
+	// Closing 0.15 @ 52000 consumes the first lot and half of the second
+	priceCache.SetPrice("BTCUSDT", 52000, 52010)
+	placeMarketOrder(t, user.Token, "BTCUSDT", "SELL", "0.15", 10)
+
+	report := getPnLReport(t, user.Token, "?period=day")
+	assert.Equal(t, "day", report.Period)
+	require.Len(t, report.Lots, 2)
+	assert.Equal(t, "0.1", report.Lots[0].Quantity)
+	assert.Equal(t, "50010", report.Lots[0].EntryPrice)
+	assert.Equal(t, "52000", report.Lots[0].ExitPrice)
+	assert.Equal(t, "199.00", report.Lots[0].PnL)
+	assert.Equal(t, "LONG", report.Lots[0].Side)
+	assert.GreaterOrEqual(t, report.Lots[0].HoldingTimeSec, int64(0))
+	assert.Equal(t, "0.05", report.Lots[1].Quantity)
+	assert.Equal(t, "51010", report.Lots[1].EntryPrice)
+	assert.Equal(t, "49.50", report.Lots[1].PnL)
+
+	// The rest of the second lot
+	placeMarketOrder(t, user.Token, "BTCUSDT", "SELL", "0.05", 10)
+
+	report = getPnLReport(t, user.Token, "?period=month")
+	require.Len(t, report.Lots, 3)
+	assert.Equal(t, "51010", report.Lots[2].EntryPrice)
+	assert.Equal(t, "298.00", report.Total.RealizedPnL)
+	assert.Equal(t, "298.00", report.Total.NetPnL)
+	assert.Equal(t, "0.00", report.Total.Funding)
+	assert.Equal(t, 3, report.Total.Lots)
+
+	now := time.Now().UTC()
+	require.Len(t, report.Periods, 1)
+	assert.Equal(t, time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02T15:04:05Z"), report.Periods[0].Start)
+	require.Len(t, report.Periods[0].BySymbol, 1)
+	assert.Equal(t, "BTCUSDT", report.Periods[0].BySymbol[0].Symbol)
+	assert.Equal(t, "298.00", report.Periods[0].BySymbol[0].RealizedPnL)
+
+	// Nothing closed in a future range
+	future := url.QueryEscape(now.Add(time.Hour).Format(time.RFC3339))
+	report = getPnLReport(t, user.Token, "?period=year&from="+future)
+	assert.Empty(t, report.Lots)
+	assert.Empty(t, report.Periods)
+	assert.Equal(t, "0.00", report.Total.RealizedPnL)
+}
+
+func TestReport_RealizedPnLCSV(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("report_csv"), "password123")
+	placeMarketOrder(t, user.Token, "ETHUSDT", "SELL", "1", 5)
+	placeMarketOrder(t, user.Token, "ETHUSDT", "BUY", "1", 5)
+
+	readCSV := func(query string) [][]string {
+		resp := makeRequest(t, "GET", "/account/reports/pnl"+query, nil, user.Token)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "text/csv")
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+		require.NoError(t, err)
+		return records
+	}
+
+	// Short 1 ETH @ 3000, covered @ 3002
+	records := readCSV("?format=csv")
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"period_start", "symbol", "realized_pnl", "fees", "funding", "net_pnl", "lots"}, records[0])
+	assert.Equal(t, "ETHUSDT", records[1][1])
+	assert.Equal(t, "-2.00", records[1][2])
+
+	records = readCSV("?format=csv&view=lots")
+	require.Len(t, records, 2)
+	assert.Equal(t, "SHORT", records[1][2])
+	assert.Equal(t, "-2.00", records[1][9])
+
+	for _, query := range []string{"?period=week", "?format=xml", "?view=trades", "?from=bad"} {
+		resp := makeRequest(t, "GET", "/account/reports/pnl"+query, nil, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
@@ -0,0 +1,263 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ScriptInfo struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	Symbol    string             `json:"symbol"`
+	Params    map[string]float64 `json:"params"`
+	Status    string             `json:"status"`
+	LastError string             `json:"last_error"`
+	State     json.RawMessage    `json:"state"`
+	OrderIDs  []int64            `json:"order_ids"`
+	StartedAt *string            `json:"started_at"`
+}
+
+type ScriptLogInfo struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+func createScript(t *testing.T, token, name, source string, params map[string]float64) ScriptInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", "/scripts", map[string]interface{}{
+		"name":   name,
+		"symbol": "BTCUSDT",
+		"source": source,
+		"params": params,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var script ScriptInfo
+	parseResponse(t, resp, &script)
+	return script
+}
+
+func getScript(t *testing.T, token string, id int64) ScriptInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", fmt.Sprintf("/scripts/%d", id), nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var script ScriptInfo
+	parseResponse(t, resp, &script)
+	return script
+}
+
+func getScriptLogs(t *testing.T, token string, id int64) []ScriptLogInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", fmt.Sprintf("/scripts/%d/logs", id), nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var logs []ScriptLogInfo
+	parseResponse(t, resp, &logs)
+	return logs
+}
+
+func TestScript_Lifecycle(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("script"), "password123")
+
+	source := `
+def on_price(state, price):
+    if price.ask < params["below"] and not state.get("ordered"):
+        trade.buy(params["quantity"])
+        state["ordered"] = trade.buy(params["quantity"], price=params["limit"])
+        log("bought at", price.ask)
+
+def on_fill(state, fill):
+    state["fills"] = state.get("fills", []) + [fill.price]
+`
+	script := createScript(t, user.Token, "dip buyer", source, map[string]float64{"below": 49500, "quantity": 0.01, "limit": 48000})
+	assert.Equal(t, "STOPPED", script.Status)
+	assert.JSONEq(t, `{}`, string(script.State))
+
+	// Stopped scripts do not trade
+	processPrice(t, "BTCUSDT", 49000, 49010)
+	assert.JSONEq(t, `{}`, string(getScript(t, user.Token, script.ID).State))
+
+	resp := makeRequest(t, "POST", fmt.Sprintf("/scripts/%d/start", script.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &script)
+	assert.Equal(t, "RUNNING", script.Status)
+	assert.NotNil(t, script.StartedAt)
+
+	// The market buy fills at once and reaches on_fill; the limit order rests
+	processPrice(t, "BTCUSDT", 49000, 49010)
+	script = getScript(t, user.Token, script.ID)
+	require.Len(t, script.OrderIDs, 1)
+	assert.JSONEq(t, fmt.Sprintf(`{"ordered": %d, "fills": [49010]}`, script.OrderIDs[0]), string(script.State))
+
+	// The ask crossing the limit fills it at its price
+	processPrice(t, "BTCUSDT", 47900, 47910)
+	script = getScript(t, user.Token, script.ID)
+	assert.Empty(t, script.OrderIDs)
+	assert.Contains(t, string(script.State), `"fills":[49010,48000]`)
+
+	resp = makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "0.02", positions[0].Quantity)
+
+	logs := getScriptLogs(t, user.Token, script.ID)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "INFO", logs[0].Level)
+	assert.Equal(t, "bought at 49010.0", logs[0].Message)
+
+	// Running scripts cannot be changed or deleted
+	resp = makeRequest(t, "PUT", fmt.Sprintf("/scripts/%d", script.ID), map[string]interface{}{"reset_state": true}, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp = makeRequest(t, "DELETE", fmt.Sprintf("/scripts/%d", script.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Stopping keeps the position and the state
+	resp = makeRequest(t, "POST", fmt.Sprintf("/scripts/%d/stop", script.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &script)
+	assert.Equal(t, "STOPPED", script.Status)
+	assert.Contains(t, string(script.State), `"fills":[49010,48000]`)
+
+	resp = makeRequest(t, "PUT", fmt.Sprintf("/scripts/%d", script.ID), map[string]interface{}{"reset_state": true}, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	parseResponse(t, resp, &script)
+	assert.JSONEq(t, `{}`, string(script.State))
+
+	resp = makeRequest(t, "DELETE", fmt.Sprintf("/scripts/%d", script.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestScript_FailureStopsScript(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("script_fail"), "password123")
+
+	source := `
+def on_price(state, price):
+    trade.buy(0.01, price=40000)
+    while True:
+        pass
+`
+	script := createScript(t, user.Token, "spinner", source, nil)
+
+	resp := makeRequest(t, "POST", fmt.Sprintf("/scripts/%d/start", script.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Only one script of the account runs on a symbol
+	other := createScript(t, user.Token, "other", "def on_fill(state, fill):\n    pass\n", nil)
+	resp = makeRequest(t, "POST", fmt.Sprintf("/scripts/%d/start", other.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// The step budget stops the script and its resting order is cancelled
+	processPrice(t, "BTCUSDT", 50000, 50010)
+	script = getScript(t, user.Token, script.ID)
+	assert.Equal(t, "ERROR", script.Status)
+	assert.Contains(t, script.LastError, "too many steps")
+	assert.Empty(t, script.OrderIDs)
+
+	resp = makeRequest(t, "GET", "/orders?status=PENDING", nil, user.Token)
+	var orders []OrderResponse
+	parseResponse(t, resp, &orders)
+	assert.Empty(t, orders)
+
+	logs := getScriptLogs(t, user.Token, script.ID)
+	require.NotEmpty(t, logs)
+	assert.Equal(t, "ERROR", logs[0].Level)
+
+	// The symbol is free again
+	resp = makeRequest(t, "POST", fmt.Sprintf("/scripts/%d/start", other.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = makeRequest(t, "POST", fmt.Sprintf("/scripts/%d/stop", other.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestScript_Validation(t *testing.T) {
+	user := registerUser(t, uniqueEmail("script_invalid"), "password123")
+
+	cases := []struct {
+		name   string
+		body   map[string]interface{}
+		status int
+	}{
+		{"syntax error", map[string]interface{}{"name": "a", "symbol": "BTCUSDT", "source": "def on_price(state, price)\n"}, http.StatusBadRequest},
+		{"undefined name", map[string]interface{}{"name": "a", "symbol": "BTCUSDT", "source": "def on_price(state, price):\n    requests.get()\n"}, http.StatusBadRequest},
+		{"empty source", map[string]interface{}{"name": "a", "symbol": "BTCUSDT", "source": ""}, http.StatusBadRequest},
+		{"missing name", map[string]interface{}{"symbol": "BTCUSDT", "source": "x = 1\n"}, http.StatusBadRequest},
+		{"unknown symbol", map[string]interface{}{"name": "a", "symbol": "DOGEUSDT", "source": "x = 1\n"}, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := makeRequest(t, "POST", "/scripts", tc.body, user.Token)
+			resp.Body.Close()
+			assert.Equal(t, tc.status, resp.StatusCode)
+		})
+	}
+
+	// Compiles, but defines no callback: starting it fails and it stays stopped
+	script := createScript(t, user.Token, "no callbacks", "x = 1\n", nil)
+	resp := makeRequest(t, "POST", fmt.Sprintf("/scripts/%d/start", script.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "STOPPED", getScript(t, user.Token, script.ID).Status)
+
+	other := registerUser(t, uniqueEmail("script_other"), "password123")
+	resp = makeRequest(t, "GET", fmt.Sprintf("/scripts/%d", script.ID), nil, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestScript_Backtest(t *testing.T) {
+	user := registerUser(t, uniqueEmail("script_backtest"), "password123")
+
+	source := `
+def on_price(state, price):
+    if not trade.position():
+        trade.buy(params["quantity"])
+        # Limit orders are not available in backtests and are rejected
+        trade.sell(params["quantity"], price=price.bid * 2)
+
+def on_fill(state, fill):
+    state["fills"] = state.get("fills", 0) + 1
+`
+	script := createScript(t, user.Token, "hodl", source, map[string]float64{"quantity": 1})
+
+	csv := "time,open,high,low,close\n" +
+		"1700000000,100,100,100,100\n" +
+		"1700003600,100,111,99,110\n"
+	resp := makeRequest(t, "POST", fmt.Sprintf("/scripts/%d/backtest", script.ID), map[string]interface{}{"csv": csv}, user.Token)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	var submitted BacktestInfo
+	parseResponse(t, resp, &submitted)
+	assert.Equal(t, "script:hodl", submitted.Strategy)
+	assert.Equal(t, "BTCUSDT", submitted.Symbol)
+
+	info := waitBacktest(t, user.Token, submitted.ID)
+	require.Equal(t, "DONE", info.Status, info.Error)
+	require.NotNil(t, info.Report)
+	require.Len(t, info.Report.Trades, 1)
+	assert.Equal(t, "100", info.Report.Trades[0].Price)
+	assert.Equal(t, "10010.00", info.Report.FinalEquity)
+
+	// Backtests do not touch the script
+	assert.JSONEq(t, `{}`, string(getScript(t, user.Token, script.ID).State))
+}
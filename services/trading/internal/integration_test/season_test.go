@@ -0,0 +1,192 @@
+package integration_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type SeasonInfo struct {
+	Season          int     `json:"season"`
+	Current         bool    `json:"current"`
+	EndedAt         *string `json:"ended_at"`
+	StartingBalance string  `json:"starting_balance"`
+	FinalBalance    string  `json:"final_balance"`
+	RealizedPnL     string  `json:"realized_pnl"`
+	TotalTrades     int     `json:"total_trades"`
+	ClosedTrades    int     `json:"closed_trades"`
+	LosingTrades    int     `json:"losing_trades"`
+}
+
+func TestReset_ClosesEverythingAndArchivesSeason(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("reset"), "password123")
+
+	// Open a position and leave a pending limit order
+	marketOrder := map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "BUY",
+		"type":     "MARKET",
+		"quantity": "0.1",
+		"leverage": 10,
+	}
+	resp := makeRequest(t, "POST", "/orders", marketOrder, user.Token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	limitOrder := map[string]interface{}{
+		"symbol":   "ETHUSDT",
+		"side":     "BUY",
+		"type":     "LIMIT",
+		"quantity": "1",
+		"price":    "2500",
+		"leverage": 5,
+	}
+	resp = makeRequest(t, "POST", "/orders", limitOrder, user.Token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// Reset
+	resetResp := makeRequest(t, "POST", "/account/reset", nil, user.Token)
+	require.Equal(t, http.StatusOK, resetResp.StatusCode)
+
+	var archived SeasonInfo
+	parseResponse(t, resetResp, &archived)
+
+	assert.Equal(t, 1, archived.Season)
+	assert.False(t, archived.Current)
+	assert.NotNil(t, archived.EndedAt)
+	assert.Equal(t, "10000.00", archived.StartingBalance)
+	// Long closed at bid: (50000 - 50010) * 0.1 = -1
+	assert.Equal(t, "9999.00", archived.FinalBalance)
+	assert.Equal(t, "-1.00", archived.RealizedPnL)
+	assert.Equal(t, 2, archived.TotalTrades)
+	assert.Equal(t, 1, archived.ClosedTrades)
+	assert.Equal(t, 1, archived.LosingTrades)
+
+	// Account is back to the initial balance with nothing open
+	accountResp := makeRequest(t, "GET", "/account", nil, user.Token)
+	var info struct {
+		Balance string `json:"balance"`
+		Season  int    `json:"season"`
+	}
+	parseResponse(t, accountResp, &info)
+	assert.Equal(t, "10000.00", info.Balance)
+	assert.Equal(t, 2, info.Season)
+
+	posResp := makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []map[string]interface{}
+	parseResponse(t, posResp, &positions)
+	assert.Empty(t, positions)
+
+	ordersResp := makeRequest(t, "GET", "/orders", nil, user.Token)
+	var orders []struct {
+		Status string `json:"status"`
+	}
+	parseResponse(t, ordersResp, &orders)
+	for _, o := range orders {
+		assert.NotEqual(t, "PENDING", o.Status)
+	}
+
+	// The balance restore is recorded in the ledger
+	adjustments := getLedger(t, user.Token, "?type=ADJUSTMENT")
+	require.Len(t, adjustments, 1)
+	assert.Equal(t, "1", adjustments[0].Amount)
+}
+
+func TestReset_StopsGridBot(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("reset_grid"), "password123")
+
+	resp := makeRequest(t, "POST", "/bots/grid", map[string]interface{}{
+		"symbol":      "BTCUSDT",
+		"lower_price": "49000",
+		"upper_price": "51000",
+		"grids":       4,
+		"investment":  "1000",
+	}, user.Token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var bot GridBotInfo
+	parseResponse(t, resp, &bot)
+	require.Equal(t, "ACTIVE", bot.Status)
+
+	// A buy level fills, so the bot holds a position when the season ends
+	processPrice(t, "BTCUSDT", 49400, 49410)
+	require.Equal(t, "0.004999", getGridBot(t, user.Token, bot.ID).Position)
+
+	resetResp := makeRequest(t, "POST", "/account/reset", nil, user.Token)
+	resetResp.Body.Close()
+	require.Equal(t, http.StatusOK, resetResp.StatusCode)
+
+	bot = getGridBot(t, user.Token, bot.ID)
+	assert.Equal(t, "STOPPED", bot.Status)
+	assert.NotNil(t, bot.StoppedAt)
+	assert.Empty(t, bot.Orders)
+	assert.Empty(t, getOpenPositions(t, user.Token))
+
+	// The stopped grid does not trade the new season
+	processPrice(t, "BTCUSDT", 48900, 48910)
+	processPrice(t, "BTCUSDT", 51100, 51110)
+	resp = makeRequest(t, "GET", "/orders?status=PENDING", nil, user.Token)
+	var orders []OrderResponse
+	parseResponse(t, resp, &orders)
+	assert.Empty(t, orders)
+	assert.Empty(t, getOpenPositions(t, user.Token))
+}
+
+func TestSeasons_TradesAreSeparated(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("seasons"), "password123")
+
+	order := map[string]interface{}{
+		"symbol":   "SOLUSDT",
+		"side":     "SELL",
+		"type":     "MARKET",
+		"quantity": "10",
+		"leverage": 5,
+	}
+	resp := makeRequest(t, "POST", "/orders", order, user.Token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resetResp := makeRequest(t, "POST", "/account/reset", nil, user.Token)
+	resetResp.Body.Close()
+	require.Equal(t, http.StatusOK, resetResp.StatusCode)
+
+	seasonsResp := makeRequest(t, "GET", "/account/seasons", nil, user.Token)
+	require.Equal(t, http.StatusOK, seasonsResp.StatusCode)
+
+	var seasons []SeasonInfo
+	parseResponse(t, seasonsResp, &seasons)
+	require.Len(t, seasons, 2)
+	assert.Equal(t, 2, seasons[0].Season)
+	assert.True(t, seasons[0].Current)
+	assert.Equal(t, 0, seasons[0].TotalTrades)
+	assert.Equal(t, 1, seasons[1].Season)
+	assert.False(t, seasons[1].Current)
+
+	// Past season keeps its trades (open + close)
+	oldResp := makeRequest(t, "GET", "/account/seasons/1/trades", nil, user.Token)
+	require.Equal(t, http.StatusOK, oldResp.StatusCode)
+	var oldTrades []TradeResponse
+	parseResponse(t, oldResp, &oldTrades)
+	assert.Len(t, oldTrades, 2)
+
+	// Current season starts empty
+	curResp := makeRequest(t, "GET", "/account/seasons/2/trades", nil, user.Token)
+	require.Equal(t, http.StatusOK, curResp.StatusCode)
+	var curTrades []TradeResponse
+	parseResponse(t, curResp, &curTrades)
+	assert.Empty(t, curTrades)
+
+	// Unknown season
+	missingResp := makeRequest(t, "GET", "/account/seasons/7", nil, user.Token)
+	missingResp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, missingResp.StatusCode)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 
 	"trading/internal/auth"
+	"trading/internal/backtest"
 	httpdelivery "trading/internal/delivery/http"
 	"trading/internal/delivery/http/handler"
 	"trading/internal/delivery/http/middleware"
@@ -27,9 +28,26 @@ import (
 	"trading/internal/logger"
 	"trading/internal/repository/postgres"
 	accountuc "trading/internal/usecase/account"
+	alertuc "trading/internal/usecase/alert"
+	algouc "trading/internal/usecase/algo"
 	authuc "trading/internal/usecase/auth"
+	backtestuc "trading/internal/usecase/backtest"
+	challengeuc "trading/internal/usecase/challenge"
+	competitionuc "trading/internal/usecase/competition"
+	copyuc "trading/internal/usecase/copytrade"
+	dcauc "trading/internal/usecase/dca"
+	equityuc "trading/internal/usecase/equity"
+	griduc "trading/internal/usecase/grid"
+	leaderboarduc "trading/internal/usecase/leaderboard"
 	orderuc "trading/internal/usecase/order"
 	positionuc "trading/internal/usecase/position"
+	priceuc "trading/internal/usecase/price"
+	reportuc "trading/internal/usecase/report"
+	scriptuc "trading/internal/usecase/script"
+	seasonuc "trading/internal/usecase/season"
+	signaluc "trading/internal/usecase/signal"
+	statsuc "trading/internal/usecase/stats"
+	webhookuc "trading/internal/usecase/webhook"
 	"trading/migrations"
 )
 
@@ -39,8 +57,23 @@ const (
 	testInitialBalance  = 10000.0
 	testMaxLeverage     = 100
 	testMaintenanceRate = 0.005
+	testWebhookTimeout  = 5 * time.Second
+	testWebhookAttempts = 3
 )
 
+var testCollateralHaircuts = map[string]float64{"USDC": 0.01, "BTC": 0.1, "ETH": 0.15}
+
+func testInstruments() []domain.Instrument {
+	instruments, err := domain.NewInstruments(
+		[]string{"BTCUSDT", "ETHUSDT", "SOLUSDT"},
+		[]string{"BTC/USDT", "ETH/USDT"},
+	)
+	if err != nil {
+		panic(err)
+	}
+	return instruments
+}
+
 var (
 	testDB     *sql.DB
 	testServer *httptest.Server
@@ -49,11 +82,29 @@ var (
 	testCancel context.CancelFunc
 
 	// Repositories
-	userRepo     *postgres.UserRepository
-	accountRepo  *postgres.AccountRepository
-	orderRepo    *postgres.OrderRepository
-	positionRepo *postgres.PositionRepository
-	tradeRepo    *postgres.TradeRepository
+	userRepo      *postgres.UserRepository
+	accountRepo   *postgres.AccountRepository
+	orderRepo     *postgres.OrderRepository
+	positionRepo  *postgres.PositionRepository
+	tradeRepo     *postgres.TradeRepository
+	ledgerRepo    *postgres.LedgerRepository
+	seasonRepo    *postgres.AccountSeasonRepository
+	walletRepo    *postgres.WalletRepository
+	spotLotRepo   *postgres.SpotLotRepository
+	alertRepo     *postgres.PriceAlertRepository
+	notifRepo     *postgres.NotificationRepository
+	webhookRepo   *postgres.WebhookRepository
+	deliveryRepo  *postgres.WebhookDeliveryRepository
+	equityRepo    *postgres.EquitySnapshotRepository
+	boardRepo     *postgres.LeaderboardRepository
+	compRepo      *postgres.CompetitionRepository
+	challengeRepo *postgres.ChallengeRepository
+	gridRepo      *postgres.GridBotRepository
+	dcaRepo       *postgres.DCARepository
+	algoRepo      *postgres.AlgoOrderRepository
+	followRepo    *postgres.FollowRepository
+	scriptRepo    *postgres.ScriptRepository
+	signalRepo    *postgres.SignalStrategyRepository
 
 	// Services
 	jwtService *auth.JWTService
@@ -61,10 +112,29 @@ var (
 	priceCache *MockPriceCache
 
 	// Use cases
-	authUseCase     *authuc.UseCase
-	accountUseCase  *accountuc.UseCase
-	orderUseCase    *orderuc.UseCase
-	positionUseCase *positionuc.UseCase
+	authUseCase      *authuc.UseCase
+	accountUseCase   *accountuc.UseCase
+	orderUseCase     *orderuc.UseCase
+	positionUseCase  *positionuc.UseCase
+	seasonUseCase    *seasonuc.UseCase
+	alertUseCase     *alertuc.UseCase
+	webhookUseCase   *webhookuc.UseCase
+	equityUseCase    *equityuc.UseCase
+	statsUseCase     *statsuc.UseCase
+	reportUseCase    *reportuc.UseCase
+	boardUseCase     *leaderboarduc.UseCase
+	compUseCase      *competitionuc.UseCase
+	challengeUseCase *challengeuc.UseCase
+	backtestUseCase  *backtestuc.UseCase
+	gridUseCase      *griduc.UseCase
+	dcaUseCase       *dcauc.UseCase
+	algoUseCase      *algouc.UseCase
+	copyUseCase      *copyuc.UseCase
+	scriptUseCase    *scriptuc.UseCase
+	signalUseCase    *signaluc.UseCase
+
+	// Price processor (fed directly by tests, no Kafka)
+	priceProcessor *priceuc.Processor
 )
 
 // MockPriceCache implements domain.PriceCache for testing
@@ -221,32 +291,110 @@ func setupTestInfrastructure() error {
 	orderRepo = postgres.NewOrderRepository(db)
 	positionRepo = postgres.NewPositionRepository(db)
 	tradeRepo = postgres.NewTradeRepository(db)
+	ledgerRepo = postgres.NewLedgerRepository(db)
+	seasonRepo = postgres.NewAccountSeasonRepository(db)
+	walletRepo = postgres.NewWalletRepository(db)
+	spotLotRepo = postgres.NewSpotLotRepository(db)
+	alertRepo = postgres.NewPriceAlertRepository(db)
+	notifRepo = postgres.NewNotificationRepository(db)
+	webhookRepo = postgres.NewWebhookRepository(db)
+	deliveryRepo = postgres.NewWebhookDeliveryRepository(db)
+	equityRepo = postgres.NewEquitySnapshotRepository(db)
+	boardRepo = postgres.NewLeaderboardRepository(db)
+	compRepo = postgres.NewCompetitionRepository(db)
+	challengeRepo = postgres.NewChallengeRepository(db)
+	gridRepo = postgres.NewGridBotRepository(db)
+	dcaRepo = postgres.NewDCARepository(db)
+	algoRepo = postgres.NewAlgoOrderRepository(db)
+	followRepo = postgres.NewFollowRepository(db)
+	scriptRepo = postgres.NewScriptRepository(db)
+	signalRepo = postgres.NewSignalStrategyRepository(db)
 
 	// Create services
 	jwtService = auth.NewJWTService(testJWTSecret, testJWTExpiry)
-	eng = engine.NewEngine(testMaxLeverage, testMaintenanceRate)
+	eng = engine.NewEngine(testMaxLeverage, testMaintenanceRate, testCollateralHaircuts)
 	priceCache = NewMockPriceCache()
 
 	// Create use cases
 	authUseCase = authuc.NewUseCase(userRepo, accountRepo, jwtService, testInitialBalance)
-	accountUseCase = accountuc.NewUseCase(accountRepo, positionRepo)
-	orderUseCase = orderuc.NewUseCase(
-		orderRepo,
-		positionRepo,
+	webhookUseCase = webhookuc.NewUseCase(webhookRepo, deliveryRepo, testWebhookTimeout, testWebhookAttempts, time.Minute, true)
+	accountUseCase = accountuc.NewUseCase(
 		accountRepo,
-		tradeRepo,
+		positionRepo,
+		ledgerRepo,
+		walletRepo,
+		spotLotRepo,
+		compRepo,
 		priceCache,
 		eng,
-		[]string{"BTCUSDT", "ETHUSDT", "SOLUSDT"},
+		testInitialBalance,
 	)
+	statsUseCase = statsuc.NewUseCase(accountRepo, seasonRepo, positionRepo, tradeRepo)
+	copyUseCase = copyuc.NewUseCase(followRepo, userRepo, accountRepo, positionRepo, statsUseCase)
+	backtestUseCase = backtestuc.NewUseCase(backtest.New(eng), "testdata/candles", 2, 10000)
+	// Scripts see every price in tests
+	scriptUseCase = scriptuc.NewUseCase(scriptRepo, accountRepo, orderRepo, positionRepo, backtestUseCase, 100000, time.Second, 0)
 	positionUseCase = positionuc.NewUseCase(
 		positionRepo,
 		accountRepo,
 		tradeRepo,
 		orderRepo,
+		ledgerRepo,
 		priceCache,
 		eng,
+		domain.EventPublishers{webhookUseCase, copyUseCase, scriptUseCase},
 	)
+	challengeUseCase = challengeuc.NewUseCase(challengeRepo, accountRepo, orderRepo, positionRepo, positionUseCase, accountUseCase)
+	gridUseCase = griduc.NewUseCase(gridRepo, accountRepo, accountUseCase, priceCache)
+	algoUseCase = algouc.NewUseCase(algoRepo, orderRepo, priceCache, "testdata/candles")
+	orderUseCase = orderuc.NewUseCase(
+		orderRepo,
+		positionRepo,
+		accountRepo,
+		tradeRepo,
+		ledgerRepo,
+		walletRepo,
+		spotLotRepo,
+		compRepo,
+		priceCache,
+		eng,
+		testInstruments(),
+		domain.EventPublishers{webhookUseCase, challengeUseCase, copyUseCase, scriptUseCase, gridUseCase, algoUseCase},
+	)
+	copyUseCase.SetTrading(orderUseCase, positionUseCase)
+	go copyUseCase.Start(testCtx)
+	scriptUseCase.SetTrading(accountUseCase, orderUseCase, positionUseCase)
+	gridUseCase.SetTrading(orderUseCase)
+	algoUseCase.SetTrading(orderUseCase)
+	equityUseCase = equityuc.NewUseCase(accountRepo, equityRepo, accountUseCase)
+	reportUseCase = reportuc.NewUseCase(tradeRepo, ledgerRepo, positionRepo)
+	boardUseCase = leaderboarduc.NewUseCase(userRepo, boardRepo)
+	compUseCase = competitionuc.NewUseCase(
+		compRepo,
+		accountRepo,
+		userRepo,
+		orderUseCase,
+		positionUseCase,
+		accountUseCase,
+		eng,
+		testInstruments(),
+	)
+	dcaUseCase = dcauc.NewUseCase(dcaRepo, positionRepo, orderUseCase, priceCache)
+	seasonUseCase = seasonuc.NewUseCase(
+		accountRepo,
+		seasonRepo,
+		tradeRepo,
+		orderUseCase,
+		positionUseCase,
+		accountUseCase,
+		gridUseCase,
+		dcaUseCase,
+		scriptUseCase,
+		testInitialBalance,
+	)
+	signalUseCase = signaluc.NewUseCase(signalRepo, positionRepo, priceCache, orderUseCase, positionUseCase)
+	alertUseCase = alertuc.NewUseCase(alertRepo, notifRepo, priceCache, testInstruments())
+	priceProcessor = priceuc.NewProcessor(positionRepo, priceCache, eng, nil, positionUseCase, nil, alertUseCase, challengeUseCase, orderUseCase, scriptUseCase, domain.EventPublishers{webhookUseCase, copyUseCase, scriptUseCase})
 
 	// Create handlers
 	authHandler := handler.NewAuthHandler(authUseCase)
@@ -254,19 +402,59 @@ func setupTestInfrastructure() error {
 	orderHandler := handler.NewOrderHandler(orderUseCase)
 	positionHandler := handler.NewPositionHandler(positionUseCase)
 	tradeHandler := handler.NewTradeHandler(tradeRepo)
+	exportHandler := handler.NewExportHandler(tradeRepo, orderRepo, positionRepo)
+	seasonHandler := handler.NewSeasonHandler(seasonUseCase)
+	equityHandler := handler.NewEquityHandler(equityUseCase)
+	statsHandler := handler.NewStatsHandler(statsUseCase)
+	reportHandler := handler.NewReportHandler(reportUseCase)
+	leaderboardHandler := handler.NewLeaderboardHandler(boardUseCase)
+	competitionHandler := handler.NewCompetitionHandler(compUseCase)
+	challengeHandler := handler.NewChallengeHandler(challengeUseCase)
+	backtestHandler := handler.NewBacktestHandler(backtestUseCase)
+	gridBotHandler := handler.NewGridBotHandler(gridUseCase)
+	dcaHandler := handler.NewDCAHandler(dcaUseCase)
+	algoOrderHandler := handler.NewAlgoOrderHandler(algoUseCase)
+	copyTradingHandler := handler.NewCopyTradingHandler(copyUseCase)
+	scriptHandler := handler.NewScriptHandler(scriptUseCase)
+	signalHandler := handler.NewSignalHandler(signalUseCase)
+	alertHandler := handler.NewAlertHandler(alertUseCase)
+	notificationHandler := handler.NewNotificationHandler(alertUseCase)
+	webhookHandler := handler.NewWebhookHandler(webhookUseCase)
 
 	// Create middleware
 	authMiddleware := middleware.NewAuthMiddleware(jwtService)
+	accountMiddleware := middleware.NewAccountMiddleware(accountRepo)
+	adminMiddleware := middleware.NewAdminMiddleware(userRepo)
 
 	// Create router
 	testRouter = httpdelivery.NewRouter(httpdelivery.RouterDeps{
-		AuthMiddleware:  authMiddleware,
-		AuthHandler:     authHandler,
-		AccountHandler:  accountHandler,
-		OrderHandler:    orderHandler,
-		PositionHandler: positionHandler,
-		TradeHandler:    tradeHandler,
-		HealthChecker:   func() error { return testDB.Ping() },
+		AuthMiddleware:      authMiddleware,
+		AccountMiddleware:   accountMiddleware,
+		AdminMiddleware:     adminMiddleware,
+		AuthHandler:         authHandler,
+		AccountHandler:      accountHandler,
+		OrderHandler:        orderHandler,
+		PositionHandler:     positionHandler,
+		TradeHandler:        tradeHandler,
+		ExportHandler:       exportHandler,
+		SeasonHandler:       seasonHandler,
+		EquityHandler:       equityHandler,
+		StatsHandler:        statsHandler,
+		ReportHandler:       reportHandler,
+		LeaderboardHandler:  leaderboardHandler,
+		CompetitionHandler:  competitionHandler,
+		ChallengeHandler:    challengeHandler,
+		BacktestHandler:     backtestHandler,
+		GridBotHandler:      gridBotHandler,
+		DCAHandler:          dcaHandler,
+		AlgoOrderHandler:    algoOrderHandler,
+		CopyTradingHandler:  copyTradingHandler,
+		ScriptHandler:       scriptHandler,
+		SignalHandler:       signalHandler,
+		AlertHandler:        alertHandler,
+		NotificationHandler: notificationHandler,
+		WebhookHandler:      webhookHandler,
+		HealthChecker:       func() error { return testDB.Ping() },
 	})
 
 	// Create test server
@@ -344,6 +532,11 @@ func loginUser(t *testing.T, email, password string) string {
 
 func makeRequest(t *testing.T, method, path string, body interface{}, token string) *http.Response {
 	t.Helper()
+	return makeRequestWithHeaders(t, method, path, body, token, nil)
+}
+
+func makeRequestWithHeaders(t *testing.T, method, path string, body interface{}, token string, headers map[string]string) *http.Response {
+	t.Helper()
 
 	var reqBody io.Reader
 	if body != nil {
@@ -363,6 +556,9 @@ func makeRequest(t *testing.T, method, path string, body interface{}, token stri
 	if token != "" {
 		req.Header.Set("Authorization", "Bearer "+token)
 	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
 
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(req)
@@ -399,7 +595,7 @@ func parseErrorResponse(t *testing.T, resp *http.Response) string {
 func cleanupDatabase(t *testing.T) {
 	t.Helper()
 
-	tables := []string{"trades", "positions", "orders", "accounts", "users"}
+	tables := []string{"spot_lots", "account_assets", "account_seasons", "equity_snapshots", "ledger_entries", "trades", "positions", "script_logs", "scripts", "signals", "signal_strategies", "grid_bot_orders", "grid_bots", "dca_executions", "dca_plans", "copy_trades", "follows", "algo_slices", "algo_orders", "orders", "competition_standings", "accounts", "challenges", "competitions", "notifications", "price_alerts", "webhook_deliveries", "webhooks", "users"}
 	for _, table := range tables {
 		_, err := testDB.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE", table))
 		if err != nil {
@@ -0,0 +1,202 @@
+package integration_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type SignalStrategyInfo struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Token    string `json:"token"`
+	URL      string `json:"url"`
+	Template struct {
+		Symbol      string  `json:"symbol"`
+		MaxQuantity *string `json:"max_quantity"`
+		Leverage    int     `json:"leverage"`
+		StopLossPct *string `json:"stop_loss_pct"`
+	} `json:"template"`
+	Active bool `json:"active"`
+}
+
+type SignalInfo struct {
+	Action   string `json:"action"`
+	Symbol   string `json:"symbol"`
+	Side     string `json:"side"`
+	Status   string `json:"status"`
+	OrderID  *int64 `json:"order_id"`
+	Quantity string `json:"quantity"`
+	Price    string `json:"price"`
+	Error    string `json:"error"`
+}
+
+// sendSignal posts a raw payload to a signal URL, without authentication
+func sendSignal(t *testing.T, url, payload string) (int, SignalInfo) {
+	t.Helper()
+
+	resp, err := http.Post(testServer.URL+url, "application/json", bytes.NewBufferString(payload))
+	require.NoError(t, err)
+
+	var signal SignalInfo
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusUnprocessableEntity {
+		parseResponse(t, resp, &signal)
+	} else {
+		resp.Body.Close()
+	}
+	return resp.StatusCode, signal
+}
+
+func TestSignal_Webhook(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("signal"), "password123")
+
+	resp := makeRequest(t, "POST", "/signals/strategies", map[string]interface{}{
+		"name": "tv breakout",
+		"template": map[string]interface{}{
+			"symbol":        "btcusdt",
+			"max_quantity":  "0.1",
+			"stop_loss_pct": "5",
+		},
+	}, user.Token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var strategy SignalStrategyInfo
+	parseResponse(t, resp, &strategy)
+	require.NotEmpty(t, strategy.Token)
+	assert.Equal(t, "/signals/"+strategy.Token, strategy.URL)
+	assert.Equal(t, "BTCUSDT", strategy.Template.Symbol)
+	assert.True(t, strategy.Active)
+
+	// The token is only shown once
+	resp = makeRequest(t, "GET", fmt.Sprintf("/signals/strategies/%d", strategy.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var fetched SignalStrategyInfo
+	parseResponse(t, resp, &fetched)
+	assert.Empty(t, fetched.Token)
+	assert.Empty(t, fetched.URL)
+
+	// Charting tickers are normalized; the template adds a stop 5% below the ask
+	status, signal := sendSignal(t, strategy.URL, `{"symbol":"BINANCE:BTCUSDT.P","action":"buy","qty":"0.01","leverage":5,"sl":""}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "EXECUTED", signal.Status)
+	assert.Equal(t, "BUY", signal.Side)
+	assert.Equal(t, "50010", signal.Price)
+	assert.NotNil(t, signal.OrderID)
+
+	resp = makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []PositionResponse
+	parseResponse(t, resp, &positions)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "0.01", positions[0].Quantity)
+	assert.Equal(t, 5, positions[0].Leverage)
+	require.NotNil(t, positions[0].StopLoss)
+	assert.Equal(t, "47509.5", *positions[0].StopLoss)
+
+	// Refused signals are logged as failed
+	status, signal = sendSignal(t, strategy.URL, `{"action":"buy","qty":1}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "FAILED", signal.Status)
+	assert.Contains(t, signal.Error, "max")
+
+	status, signal = sendSignal(t, strategy.URL, `{"symbol":"ETHUSDT","action":"buy","qty":0.01}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Contains(t, signal.Error, "only trades BTCUSDT")
+
+	status, _ = sendSignal(t, strategy.URL, `not json`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+
+	// Close falls back to the template symbol and sells at the bid
+	status, signal = sendSignal(t, strategy.URL, `{"action":"close"}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "EXECUTED", signal.Status)
+	assert.Equal(t, "SELL", signal.Side)
+	assert.Equal(t, "0.01", signal.Quantity)
+	assert.Equal(t, "50000", signal.Price)
+
+	status, signal = sendSignal(t, strategy.URL, `{"action":"close"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "SKIPPED", signal.Status)
+
+	// Paused strategies skip their signals
+	resp = makeRequest(t, "PATCH", fmt.Sprintf("/signals/strategies/%d", strategy.ID), map[string]interface{}{"active": false}, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	status, signal = sendSignal(t, strategy.URL, `{"action":"buy","qty":"0.01"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "SKIPPED", signal.Status)
+	assert.Equal(t, "strategy is paused", signal.Error)
+
+	resp = makeRequest(t, "GET", fmt.Sprintf("/signals/strategies/%d/signals", strategy.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var log []SignalInfo
+	parseResponse(t, resp, &log)
+	statuses := make([]string, len(log))
+	for i, s := range log {
+		statuses[i] = s.Status
+	}
+	assert.Equal(t, []string{"SKIPPED", "SKIPPED", "EXECUTED", "FAILED", "FAILED", "FAILED", "EXECUTED"}, statuses)
+
+	// Rotating the token retires the old URL
+	resp = makeRequest(t, "POST", fmt.Sprintf("/signals/strategies/%d/rotate-token", strategy.ID), nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var rotated SignalStrategyInfo
+	parseResponse(t, resp, &rotated)
+	require.NotEmpty(t, rotated.Token)
+	assert.NotEqual(t, strategy.Token, rotated.Token)
+
+	status, _ = sendSignal(t, strategy.URL, `{"action":"buy","qty":"0.01"}`)
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = sendSignal(t, rotated.URL, `{"action":"buy","qty":"0.01"}`)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestSignal_Validation(t *testing.T) {
+	user := registerUser(t, uniqueEmail("signal_invalid"), "password123")
+
+	cases := []struct {
+		name   string
+		body   map[string]interface{}
+		status int
+	}{
+		{"missing name", map[string]interface{}{}, http.StatusBadRequest},
+		{"unknown symbol", map[string]interface{}{"name": "a", "template": map[string]interface{}{"symbol": "DOGEUSDT"}}, http.StatusBadRequest},
+		{"quantity above max", map[string]interface{}{"name": "a", "template": map[string]interface{}{"quantity": "1", "max_quantity": "0.5"}}, http.StatusBadRequest},
+		{"stop loss pct too large", map[string]interface{}{"name": "a", "template": map[string]interface{}{"stop_loss_pct": "100"}}, http.StatusBadRequest},
+		{"invalid decimal", map[string]interface{}{"name": "a", "template": map[string]interface{}{"quantity": "abc"}}, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := makeRequest(t, "POST", "/signals/strategies", tc.body, user.Token)
+			resp.Body.Close()
+			assert.Equal(t, tc.status, resp.StatusCode)
+		})
+	}
+
+	resp := makeRequest(t, "POST", "/signals/strategies", map[string]interface{}{"name": "no template"}, user.Token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var strategy SignalStrategyInfo
+	parseResponse(t, resp, &strategy)
+
+	// Signals need a symbol and quantity when the template has none
+	status, signal := sendSignal(t, strategy.URL, `{"action":"buy"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "symbol is required", signal.Error)
+	status, signal = sendSignal(t, strategy.URL, `{"symbol":"BTCUSDT","action":"buy"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "qty is required", signal.Error)
+	status, signal = sendSignal(t, strategy.URL, `{"symbol":"BTCUSDT","action":"hold"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Contains(t, signal.Error, "action")
+
+	other := registerUser(t, uniqueEmail("signal_other"), "password123")
+	resp = makeRequest(t, "GET", fmt.Sprintf("/signals/strategies/%d", strategy.ID), nil, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
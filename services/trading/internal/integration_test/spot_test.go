@@ -0,0 +1,175 @@
+package integration_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type SpotLotInfo struct {
+	Asset     string `json:"asset"`
+	Quantity  string `json:"quantity"`
+	Remaining string `json:"remaining"`
+	Price     string `json:"price"`
+	CostBasis string `json:"cost_basis"`
+}
+
+func placeSpotOrder(t *testing.T, token, side, quantity string) *http.Response {
+	t.Helper()
+
+	body := map[string]interface{}{
+		"symbol":   "BTC/USDT",
+		"side":     side,
+		"type":     "MARKET",
+		"quantity": quantity,
+	}
+	return makeRequest(t, "POST", "/orders", body, token)
+}
+
+func getSpotLots(t *testing.T, token string) []SpotLotInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", "/spot/lots", nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var lots []SpotLotInfo
+	parseResponse(t, resp, &lots)
+	return lots
+}
+
+func TestSpot_BuySettlesInWallet(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("spot_buy"), "password123")
+
+	// Bought at the ask: 0.1 * 50010 = 5001
+	resp := placeSpotOrder(t, user.Token, "BUY", "0.1")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var order OrderResponse
+	parseResponse(t, resp, &order)
+	assert.Equal(t, "FILLED", order.Status)
+	assert.Equal(t, 1, order.Leverage)
+
+	info := getWalletAccount(t, user.Token)
+	assert.Equal(t, "4999.00", info.Balance)
+	require.Len(t, info.Collateral, 2)
+	assert.Equal(t, "BTC", info.Collateral[1].Asset)
+	assert.Equal(t, "0.1", info.Collateral[1].Amount)
+
+	// No derivatives position is opened
+	posResp := makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []map[string]interface{}
+	parseResponse(t, posResp, &positions)
+	assert.Empty(t, positions)
+
+	tradesResp := makeRequest(t, "GET", "/trades", nil, user.Token)
+	var trades []TradeResponse
+	parseResponse(t, tradesResp, &trades)
+	require.Len(t, trades, 1)
+	assert.Equal(t, "SPOT_BUY", trades[0].Type)
+
+	lots := getSpotLots(t, user.Token)
+	require.Len(t, lots, 1)
+	assert.Equal(t, "0.1", lots[0].Remaining)
+	assert.Equal(t, "50010", lots[0].Price)
+	assert.Equal(t, "5001.00", lots[0].CostBasis)
+}
+
+func TestSpot_SellConsumesLotsFIFO(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("spot_fifo"), "password123")
+
+	resp := placeSpotOrder(t, user.Token, "BUY", "0.05") // lot at 50010
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	priceCache.SetPrice("BTCUSDT", 51000, 51010)
+	resp = placeSpotOrder(t, user.Token, "BUY", "0.05") // lot at 51010
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// Sold at the bid 52000:
+	// 0.05 from the first lot: (52000 - 50010) * 0.05 = 99.5
+	// 0.025 from the second lot: (52000 - 51010) * 0.025 = 24.75
+	priceCache.SetPrice("BTCUSDT", 52000, 52010)
+	resp = placeSpotOrder(t, user.Token, "SELL", "0.075")
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	tradesResp := makeRequest(t, "GET", "/trades", nil, user.Token)
+	var trades []TradeResponse
+	parseResponse(t, tradesResp, &trades)
+	require.Len(t, trades, 3)
+	assert.Equal(t, "SPOT_SELL", trades[0].Type)
+	assert.Equal(t, "124.25", trades[0].PnL)
+
+	lots := getSpotLots(t, user.Token)
+	require.Len(t, lots, 1)
+	assert.Equal(t, "51010", lots[0].Price)
+	assert.Equal(t, "0.025", lots[0].Remaining)
+
+	// 10000 - 2500.5 - 2550.5 + 0.075 * 52000
+	info := getWalletAccount(t, user.Token)
+	assert.Equal(t, "8849.00", info.Balance)
+}
+
+func TestSpot_Validation(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("spot_invalid"), "password123")
+
+	tests := []struct {
+		name     string
+		body     map[string]interface{}
+		expected int
+	}{
+		{
+			name: "leverage",
+			body: map[string]interface{}{
+				"symbol": "BTC/USDT", "side": "BUY", "type": "MARKET", "quantity": "0.1", "leverage": 10,
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name: "stop loss",
+			body: map[string]interface{}{
+				"symbol": "BTC/USDT", "side": "BUY", "type": "MARKET", "quantity": "0.1", "stop_loss": "45000",
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name: "limit",
+			body: map[string]interface{}{
+				"symbol": "BTC/USDT", "side": "BUY", "type": "LIMIT", "quantity": "0.1", "price": "45000",
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name: "sell more than held",
+			body: map[string]interface{}{
+				"symbol": "BTC/USDT", "side": "SELL", "type": "MARKET", "quantity": "0.1",
+			},
+			expected: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "buy more than balance",
+			body: map[string]interface{}{
+				"symbol": "BTC/USDT", "side": "BUY", "type": "MARKET", "quantity": "1",
+			},
+			expected: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := makeRequest(t, "POST", "/orders", tt.body, user.Token)
+			resp.Body.Close()
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type PerformanceInfo struct {
+	Positions    int     `json:"positions"`
+	Wins         int     `json:"wins"`
+	Losses       int     `json:"losses"`
+	Liquidations int     `json:"liquidations"`
+	WinRate      string  `json:"win_rate"`
+	NetPnL       string  `json:"net_pnl"`
+	GrossProfit  string  `json:"gross_profit"`
+	GrossLoss    string  `json:"gross_loss"`
+	ProfitFactor *string `json:"profit_factor"`
+	AvgWin       string  `json:"avg_win"`
+	AvgLoss      string  `json:"avg_loss"`
+	LargestWin   string  `json:"largest_win"`
+	LargestLoss  string  `json:"largest_loss"`
+	Expectancy   string  `json:"expectancy"`
+}
+
+type StatsInfo struct {
+	PerformanceInfo
+	MaxDrawdown        string  `json:"max_drawdown"`
+	MaxDrawdownPercent string  `json:"max_drawdown_percent"`
+	Sharpe             *string `json:"sharpe"`
+	Sortino            *string `json:"sortino"`
+	LongestWinStreak   int     `json:"longest_win_streak"`
+	LongestLossStreak  int     `json:"longest_loss_streak"`
+	BySymbol           []struct {
+		Symbol string `json:"symbol"`
+		PerformanceInfo
+	} `json:"by_symbol"`
+	BySide []struct {
+		Side string `json:"side"`
+		PerformanceInfo
+	} `json:"by_side"`
+}
+
+func getStats(t *testing.T, token, query string) StatsInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", "/account/stats"+query, nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var stats StatsInfo
+	parseResponse(t, resp, &stats)
+	return stats
+}
+
+// closeOpenPosition closes the user's open position in the symbol at the current price
+func closeOpenPosition(t *testing.T, token, symbol string) {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", "/positions", nil, token)
+	var positions []struct {
+		ID     int64  `json:"id"`
+		Symbol string `json:"symbol"`
+	}
+	parseResponse(t, resp, &positions)
+
+	for _, p := range positions {
+		if p.Symbol == symbol {
+			resp := makeRequest(t, "POST", fmt.Sprintf("/positions/%d/close", p.ID), nil, token)
+			resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			return
+		}
+	}
+	t.Fatalf("no open %s position", symbol)
+}
+
+func TestStats_Performance(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+	defer priceCache.SetPrice("ETHUSDT", 3000, 3002)
+	defer priceCache.SetPrice("SOLUSDT", 100, 100.1)
+
+	user := registerUser(t, uniqueEmail("stats_perf"), "password123")
+
+	// Win: long 0.1 BTC 50010 -> 51000 = +99
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.1", 10)
+	priceCache.SetPrice("BTCUSDT", 51000, 51010)
+	closeOpenPosition(t, user.Token, "BTCUSDT")
+
+	// Loss: short 1 ETH 3000 -> 3102 = -102
+	placeMarketOrder(t, user.Token, "ETHUSDT", "SELL", "1", 10)
+	priceCache.SetPrice("ETHUSDT", 3100, 3102)
+	closeOpenPosition(t, user.Token, "ETHUSDT")
+
+	// Win: long 10 SOL 100.1 -> 105 = +49
+	placeMarketOrder(t, user.Token, "SOLUSDT", "BUY", "10", 5)
+	priceCache.SetPrice("SOLUSDT", 105, 105.1)
+	closeOpenPosition(t, user.Token, "SOLUSDT")
+
+	// Still open positions are not counted
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.01", 10)
+
+	stats := getStats(t, user.Token, "")
+	assert.Equal(t, 3, stats.Positions)
+	assert.Equal(t, 2, stats.Wins)
+	assert.Equal(t, 1, stats.Losses)
+	assert.Equal(t, 0, stats.Liquidations)
+	assert.Equal(t, "0.6667", stats.WinRate)
+	assert.Equal(t, "46.00", stats.NetPnL)
+	assert.Equal(t, "148.00", stats.GrossProfit)
+	assert.Equal(t, "-102.00", stats.GrossLoss)
+	require.NotNil(t, stats.ProfitFactor)
+	assert.Equal(t, "1.4510", *stats.ProfitFactor)
+	assert.Equal(t, "74.00", stats.AvgWin)
+	assert.Equal(t, "-102.00", stats.AvgLoss)
+	assert.Equal(t, "99.00", stats.LargestWin)
+	assert.Equal(t, "-102.00", stats.LargestLoss)
+	assert.Equal(t, "15.33", stats.Expectancy)
+
+	// Cumulative PnL 99 -> -3 -> 46
+	assert.Equal(t, "102.00", stats.MaxDrawdown)
+	assert.Equal(t, "0.0101", stats.MaxDrawdownPercent) // 102 / (10000 + 99)
+	assert.Equal(t, 1, stats.LongestWinStreak)
+	assert.Equal(t, 1, stats.LongestLossStreak)
+
+	// All closes fall on one day, so there are no daily returns to compare
+	assert.Nil(t, stats.Sharpe)
+	assert.Nil(t, stats.Sortino)
+
+	require.Len(t, stats.BySymbol, 3)
+	assert.Equal(t, "BTCUSDT", stats.BySymbol[0].Symbol)
+	assert.Equal(t, "99.00", stats.BySymbol[0].NetPnL)
+	assert.Equal(t, "ETHUSDT", stats.BySymbol[1].Symbol)
+	assert.Equal(t, "0.0000", stats.BySymbol[1].WinRate)
+	assert.Nil(t, stats.BySymbol[0].ProfitFactor)
+
+	require.Len(t, stats.BySide, 2)
+	assert.Equal(t, "LONG", stats.BySide[0].Side)
+	assert.Equal(t, 2, stats.BySide[0].Positions)
+	assert.Equal(t, "148.00", stats.BySide[0].NetPnL)
+	assert.Equal(t, "SHORT", stats.BySide[1].Side)
+	assert.Equal(t, "-102.00", stats.BySide[1].NetPnL)
+}
+
+func TestStats_DateRange(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("ETHUSDT", 3000, 3002)
+
+	user := registerUser(t, uniqueEmail("stats_range"), "password123")
+
+	placeMarketOrder(t, user.Token, "ETHUSDT", "BUY", "1", 10)
+	priceCache.SetPrice("ETHUSDT", 3050, 3052)
+	closeOpenPosition(t, user.Token, "ETHUSDT")
+
+	now := time.Now().UTC()
+	past := url.QueryEscape(now.Add(-time.Hour).Format(time.RFC3339))
+	future := url.QueryEscape(now.Add(time.Hour).Format(time.RFC3339))
+
+	stats := getStats(t, user.Token, "?from="+past+"&to="+future)
+	assert.Equal(t, 1, stats.Positions)
+	assert.Equal(t, "48.00", stats.NetPnL)
+
+	stats = getStats(t, user.Token, "?from="+future)
+	assert.Equal(t, 0, stats.Positions)
+	assert.Equal(t, "0.00", stats.NetPnL)
+	assert.Empty(t, stats.BySymbol)
+
+	stats = getStats(t, user.Token, "?to="+past)
+	assert.Equal(t, 0, stats.Positions)
+
+	// Other accounts have their own stats
+	other := registerUser(t, uniqueEmail("stats_range_other"), "password123")
+	assert.Equal(t, 0, getStats(t, other.Token, "").Positions)
+
+	for _, query := range []string{"?from=" + future + "&to=" + past, "?from=today"} {
+		resp := makeRequest(t, "GET", "/account/stats"+query, nil, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestStats_Liquidation(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("stats_liq"), "password123")
+
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.1", 100)
+	processPrice(t, "BTCUSDT", 49000, 49010)
+
+	stats := getStats(t, user.Token, "")
+	assert.Equal(t, 1, stats.Positions)
+	assert.Equal(t, 1, stats.Liquidations)
+	assert.Equal(t, 1, stats.Losses)
+	assert.Equal(t, 1, stats.LongestLossStreak)
+	require.NotNil(t, stats.ProfitFactor)
+	assert.Equal(t, "0.0000", *stats.ProfitFactor)
+	assert.True(t, stats.NetPnL[0] == '-')
+}
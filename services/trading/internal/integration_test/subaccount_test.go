@@ -0,0 +1,194 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type SubAccountInfo struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Balance string `json:"balance"`
+}
+
+func createSubAccount(t *testing.T, token, name string) SubAccountInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", "/accounts", map[string]string{"name": name}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var info SubAccountInfo
+	parseResponse(t, resp, &info)
+	return info
+}
+
+// fundSubAccount transfers amount from the user's primary account to the sub-account
+func fundSubAccount(t *testing.T, token string, subID int64, amount string) {
+	t.Helper()
+
+	listResp := makeRequest(t, "GET", "/accounts", nil, token)
+	var accounts []SubAccountInfo
+	parseResponse(t, listResp, &accounts)
+	require.NotEmpty(t, accounts)
+
+	resp := makeRequest(t, "POST", "/accounts/transfer", map[string]interface{}{
+		"from_account_id": accounts[0].ID,
+		"to_account_id":   subID,
+		"amount":          amount,
+	}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func accountHeader(id int64) map[string]string {
+	return map[string]string{"X-Account-ID": fmt.Sprintf("%d", id)}
+}
+
+func TestSubAccounts_CreateAndList(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("sub_list"), "password123")
+
+	sub := createSubAccount(t, user.Token, "scalping")
+	assert.Equal(t, "scalping", sub.Name)
+	// Sub-accounts start empty, they are funded by transfers
+	assert.Equal(t, "0.00", sub.Balance)
+
+	resp := makeRequest(t, "GET", "/accounts", nil, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var accounts []SubAccountInfo
+	parseResponse(t, resp, &accounts)
+	require.Len(t, accounts, 2)
+	assert.Equal(t, "main", accounts[0].Name)
+	assert.Equal(t, "scalping", accounts[1].Name)
+
+	// Duplicate name
+	dupResp := makeRequest(t, "POST", "/accounts", map[string]string{"name": "Scalping"}, user.Token)
+	dupResp.Body.Close()
+	assert.Equal(t, http.StatusConflict, dupResp.StatusCode)
+}
+
+func TestSubAccounts_PositionsAreIsolated(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("sub_isolated"), "password123")
+	sub := createSubAccount(t, user.Token, "swing")
+	fundSubAccount(t, user.Token, sub.ID, "5000")
+
+	order := map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "BUY",
+		"type":     "MARKET",
+		"quantity": "0.1",
+		"leverage": 10,
+	}
+	resp := makeRequestWithHeaders(t, "POST", "/orders", order, user.Token, accountHeader(sub.ID))
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// Sub-account sees the position
+	subResp := makeRequestWithHeaders(t, "GET", "/positions", nil, user.Token, accountHeader(sub.ID))
+	var subPositions []map[string]interface{}
+	parseResponse(t, subResp, &subPositions)
+	assert.Len(t, subPositions, 1)
+
+	// Primary account (no header) does not
+	mainResp := makeRequest(t, "GET", "/positions", nil, user.Token)
+	var mainPositions []map[string]interface{}
+	parseResponse(t, mainResp, &mainPositions)
+	assert.Empty(t, mainPositions)
+
+	// The same symbol can be opened in the primary account independently
+	resp = makeRequest(t, "POST", "/orders", order, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestSubAccounts_ForeignAccountRejected(t *testing.T) {
+	cleanupDatabase(t)
+
+	owner := registerUser(t, uniqueEmail("sub_owner"), "password123")
+	other := registerUser(t, uniqueEmail("sub_other"), "password123")
+	sub := createSubAccount(t, owner.Token, "private")
+
+	resp := makeRequestWithHeaders(t, "GET", "/account", nil, other.Token, accountHeader(sub.ID))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	badResp := makeRequestWithHeaders(t, "GET", "/account", nil, owner.Token, map[string]string{"X-Account-ID": "abc"})
+	badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode)
+}
+
+func TestSubAccounts_Transfer(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("sub_transfer"), "password123")
+	sub := createSubAccount(t, user.Token, "bots")
+
+	listResp := makeRequest(t, "GET", "/accounts", nil, user.Token)
+	var accounts []SubAccountInfo
+	parseResponse(t, listResp, &accounts)
+	mainID := accounts[0].ID
+
+	transfer := map[string]interface{}{
+		"from_account_id": mainID,
+		"to_account_id":   sub.ID,
+		"amount":          "2500",
+	}
+	resp := makeRequest(t, "POST", "/accounts/transfer", transfer, user.Token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	mainInfo := makeRequest(t, "GET", "/account", nil, user.Token)
+	var main AccountInfo
+	parseResponse(t, mainInfo, &main)
+	assert.Equal(t, "7500.00", main.Balance)
+
+	subInfo := makeRequestWithHeaders(t, "GET", "/account", nil, user.Token, accountHeader(sub.ID))
+	var subAccount AccountInfo
+	parseResponse(t, subInfo, &subAccount)
+	assert.Equal(t, "2500.00", subAccount.Balance)
+
+	// Both legs are in the ledgers
+	entries := getLedger(t, user.Token, "?type=TRANSFER")
+	require.Len(t, entries, 1)
+	assert.Equal(t, "-2500", entries[0].Amount)
+
+	// Cannot move more than the free margin
+	transfer["amount"] = "100000"
+	overResp := makeRequest(t, "POST", "/accounts/transfer", transfer, user.Token)
+	overResp.Body.Close()
+	assert.Equal(t, http.StatusUnprocessableEntity, overResp.StatusCode)
+}
+
+func TestSubAccounts_LimitAndReset(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("sub_limit"), "password123")
+
+	// The primary account counts towards the limit of 10
+	var last SubAccountInfo
+	for i := 1; i < 10; i++ {
+		last = createSubAccount(t, user.Token, fmt.Sprintf("sub%d", i))
+	}
+	resp := makeRequest(t, "POST", "/accounts", map[string]string{"name": "one-too-many"}, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	// Resetting a sub-account does not mint the initial balance
+	fundSubAccount(t, user.Token, last.ID, "1000")
+	resetResp := makeRequestWithHeaders(t, "POST", "/account/reset", nil, user.Token, accountHeader(last.ID))
+	resetResp.Body.Close()
+	require.Equal(t, http.StatusOK, resetResp.StatusCode)
+
+	infoResp := makeRequestWithHeaders(t, "GET", "/account", nil, user.Token, accountHeader(last.ID))
+	var info AccountInfo
+	parseResponse(t, infoResp, &info)
+	assert.Equal(t, "1000.00", info.Balance)
+}
@@ -0,0 +1,188 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CollateralInfo struct {
+	Asset   string `json:"asset"`
+	Amount  string `json:"amount"`
+	Price   string `json:"price"`
+	Haircut string `json:"haircut"`
+	Value   string `json:"value"`
+}
+
+type WalletAccountInfo struct {
+	Balance         string           `json:"balance"`
+	Equity          string           `json:"equity"`
+	AvailableMargin string           `json:"available_margin"`
+	CollateralValue string           `json:"collateral_value"`
+	Collateral      []CollateralInfo `json:"collateral"`
+}
+
+func convert(t *testing.T, token, from, to, amount string) *http.Response {
+	t.Helper()
+
+	body := map[string]interface{}{
+		"from":   from,
+		"to":     to,
+		"amount": amount,
+	}
+	return makeRequest(t, "POST", "/account/convert", body, token)
+}
+
+func getWalletAccount(t *testing.T, token string) WalletAccountInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", "/account", nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var info WalletAccountInfo
+	parseResponse(t, resp, &info)
+	return info
+}
+
+func TestConvert_USDTToBTC(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("convert"), "password123")
+
+	// BTC is bought at the ask: 5001 / 50010 = 0.1
+	resp := convert(t, user.Token, "USDT", "BTC", "5001")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Received string `json:"received"`
+	}
+	parseResponse(t, resp, &result)
+	assert.Equal(t, "0.1", result.Received)
+
+	info := getWalletAccount(t, user.Token)
+	assert.Equal(t, "4999.00", info.Balance)
+	require.Len(t, info.Collateral, 2)
+	assert.Equal(t, "USDT", info.Collateral[0].Asset)
+	assert.Equal(t, "4999.00", info.Collateral[0].Value)
+
+	// BTC is valued at the bid with a 10% haircut: 0.1 * 50000 * 0.9 = 4500
+	btc := info.Collateral[1]
+	assert.Equal(t, "BTC", btc.Asset)
+	assert.Equal(t, "0.1", btc.Amount)
+	assert.Equal(t, "50000", btc.Price)
+	assert.Equal(t, "0.1", btc.Haircut)
+	assert.Equal(t, "4500.00", btc.Value)
+	assert.Equal(t, "9499.00", info.CollateralValue)
+	assert.Equal(t, "9499.00", info.Equity)
+
+	// Both legs are in the ledger
+	btcEntries := getLedger(t, user.Token, "?asset=BTC")
+	require.Len(t, btcEntries, 1)
+	assert.Equal(t, "CONVERSION", btcEntries[0].Type)
+	assert.Equal(t, "0.1", btcEntries[0].Amount)
+	assert.Equal(t, "0.1", btcEntries[0].BalanceAfter)
+
+	usdtEntries := getLedger(t, user.Token, "?asset=USDT&type=CONVERSION")
+	require.Len(t, usdtEntries, 1)
+	assert.Equal(t, "-5001", usdtEntries[0].Amount)
+}
+
+func TestConvert_Validation(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("convert_invalid"), "password123")
+
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		amount   string
+		expected int
+	}{
+		{"unknown asset", "USDT", "DOGE", "100", http.StatusBadRequest},
+		{"same asset", "USDT", "USDT", "100", http.StatusBadRequest},
+		{"non-positive amount", "USDT", "USDC", "0", http.StatusBadRequest},
+		{"more than held", "BTC", "USDT", "1", http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := convert(t, user.Token, tt.from, tt.to, tt.amount)
+			resp.Body.Close()
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+}
+
+func TestCollateral_MarginsPositionsAndSettlesInUSDT(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("collateral"), "password123")
+
+	resp := convert(t, user.Token, "USDT", "USDC", "10000")
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// USDC carries a 1% haircut
+	info := getWalletAccount(t, user.Token)
+	assert.Equal(t, "0.00", info.Balance)
+	assert.Equal(t, "9900.00", info.AvailableMargin)
+
+	// The position is margined by USDC alone
+	order := map[string]interface{}{
+		"symbol":   "BTCUSDT",
+		"side":     "BUY",
+		"type":     "MARKET",
+		"quantity": "0.1",
+		"leverage": 10,
+	}
+	orderResp := makeRequest(t, "POST", "/orders", order, user.Token)
+	orderResp.Body.Close()
+	require.Equal(t, http.StatusCreated, orderResp.StatusCode)
+
+	posResp := makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []struct {
+		ID int64 `json:"id"`
+	}
+	parseResponse(t, posResp, &positions)
+	require.Len(t, positions, 1)
+
+	closeResp := makeRequest(t, "POST", fmt.Sprintf("/positions/%d/close", positions[0].ID), nil, user.Token)
+	closeResp.Body.Close()
+	require.Equal(t, http.StatusOK, closeResp.StatusCode)
+
+	// The loss is settled in USDT even though the balance was empty
+	info = getWalletAccount(t, user.Token)
+	assert.Equal(t, "-1.00", info.Balance)
+	assert.Equal(t, "9899.00", info.Equity)
+
+	// A negative USDT balance cannot be converted away
+	resp = convert(t, user.Token, "USDT", "BTC", "1")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestReset_SellsWalletAssets(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("reset_wallet"), "password123")
+
+	resp := convert(t, user.Token, "USDT", "BTC", "5001")
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resetResp := makeRequest(t, "POST", "/account/reset", nil, user.Token)
+	require.Equal(t, http.StatusOK, resetResp.StatusCode)
+
+	// BTC is sold back at the bid: 4999 + 0.1 * 50000
+	var archived SeasonInfo
+	parseResponse(t, resetResp, &archived)
+	assert.Equal(t, "9999.00", archived.FinalBalance)
+
+	info := getWalletAccount(t, user.Token)
+	assert.Equal(t, "10000.00", info.Balance)
+	assert.Len(t, info.Collateral, 1)
+}
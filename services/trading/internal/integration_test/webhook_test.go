@@ -0,0 +1,380 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"trading/internal/domain"
+	webhookuc "trading/internal/usecase/webhook"
+)
+
+type WebhookInfo struct {
+	ID     int64    `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+	Secret string   `json:"secret"`
+}
+
+type WebhookDeliveryInfo struct {
+	ID             int64           `json:"id"`
+	Event          string          `json:"event"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *string         `json:"next_attempt_at"`
+	LastStatusCode int             `json:"last_status_code"`
+	RedeliveryOf   *int64          `json:"redelivery_of"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+type receivedWebhook struct {
+	Header http.Header
+	Body   []byte
+}
+
+// webhookReceiver records requests and answers with a configurable status
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	received []receivedWebhook
+}
+
+func newWebhookReceiver(t *testing.T) *webhookReceiver {
+	t.Helper()
+
+	rcv := &webhookReceiver{status: http.StatusOK}
+	rcv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rcv.mu.Lock()
+		rcv.received = append(rcv.received, receivedWebhook{Header: r.Header.Clone(), Body: body})
+		status := rcv.status
+		rcv.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(rcv.Close)
+	return rcv
+}
+
+func (r *webhookReceiver) setStatus(status int) {
+	r.mu.Lock()
+	r.status = status
+	r.mu.Unlock()
+}
+
+func (r *webhookReceiver) requests() []receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedWebhook(nil), r.received...)
+}
+
+func createWebhook(t *testing.T, token, url string, events []string) WebhookInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", "/webhooks", map[string]interface{}{
+		"url":    url,
+		"events": events,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var webhook WebhookInfo
+	parseResponse(t, resp, &webhook)
+	return webhook
+}
+
+func getDeliveries(t *testing.T, token string, webhookID int64) []WebhookDeliveryInfo {
+	t.Helper()
+
+	resp := makeRequest(t, "GET", fmt.Sprintf("/webhooks/%d/deliveries", webhookID), nil, token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var deliveries []WebhookDeliveryInfo
+	parseResponse(t, resp, &deliveries)
+	return deliveries
+}
+
+func deliverWebhooks(t *testing.T) int {
+	t.Helper()
+
+	n, err := webhookUseCase.DeliverDue(testCtx)
+	require.NoError(t, err)
+	return n
+}
+
+func placeMarketOrder(t *testing.T, token, symbol, side, quantity string, leverage int) {
+	t.Helper()
+
+	resp := makeRequest(t, "POST", "/orders", map[string]interface{}{
+		"symbol":   symbol,
+		"side":     side,
+		"type":     "MARKET",
+		"quantity": quantity,
+		"leverage": leverage,
+	}, token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestWebhook_CRUDAndValidation(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("webhook_crud"), "password123")
+	other := registerUser(t, uniqueEmail("webhook_other"), "password123")
+
+	// No filter subscribes to every event
+	webhook := createWebhook(t, user.Token, "https://example.com/hook", nil)
+	assert.Len(t, webhook.Secret, 64)
+	assert.Equal(t, []string{"fill", "liquidation", "stop_loss", "take_profit", "margin_warning"}, webhook.Events)
+	assert.True(t, webhook.Active)
+
+	// The secret is shown only once
+	resp := makeRequest(t, "GET", "/webhooks", nil, user.Token)
+	var webhooks []WebhookInfo
+	parseResponse(t, resp, &webhooks)
+	require.Len(t, webhooks, 1)
+	assert.Empty(t, webhooks[0].Secret)
+
+	resp = makeRequest(t, "GET", fmt.Sprintf("/webhooks/%d", webhook.ID), nil, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = makeRequest(t, "PATCH", fmt.Sprintf("/webhooks/%d", webhook.ID), map[string]interface{}{
+		"events": []string{"liquidation", "liquidation", "stop_loss"},
+		"active": false,
+	}, user.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var updated WebhookInfo
+	parseResponse(t, resp, &updated)
+	assert.Equal(t, []string{"liquidation", "stop_loss"}, updated.Events)
+	assert.False(t, updated.Active)
+
+	invalid := []map[string]interface{}{
+		{"url": "ftp://example.com/hook"},
+		{"url": "not a url"},
+		{"url": "https://example.com/hook", "events": []string{"deposit"}},
+	}
+	for _, body := range invalid {
+		resp := makeRequest(t, "POST", "/webhooks", body, user.Token)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, body)
+	}
+
+	resp = makeRequest(t, "DELETE", fmt.Sprintf("/webhooks/%d", webhook.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = makeRequest(t, "GET", fmt.Sprintf("/webhooks/%d", webhook.ID), nil, user.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestWebhook_PrivateTargetsRejected(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("webhook_private"), "password123")
+
+	// The shared use case allows the loopback receivers of these tests
+	uc := webhookuc.NewUseCase(webhookRepo, deliveryRepo, testWebhookTimeout, testWebhookAttempts, time.Minute, false)
+	for _, target := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://[::1]/hook",
+		"http://10.0.0.5/hook",
+		"http://192.168.1.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://0.0.0.0/hook",
+	} {
+		_, err := uc.CreateWebhook(context.Background(), webhookuc.CreateWebhookInput{
+			UserID: domain.UserID(user.UserID),
+			URL:    target,
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidWebhook, target)
+	}
+}
+
+func TestWebhook_RedirectNotFollowed(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("webhook_redirect"), "password123")
+	target := newWebhookReceiver(t)
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	t.Cleanup(redirect.Close)
+	webhook := createWebhook(t, user.Token, redirect.URL, []string{"fill"})
+
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.01", 5)
+	assert.Equal(t, 1, deliverWebhooks(t))
+
+	deliveries := getDeliveries(t, user.Token, webhook.ID)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, http.StatusTemporaryRedirect, deliveries[0].LastStatusCode)
+	assert.Equal(t, "PENDING", deliveries[0].Status)
+	assert.Empty(t, target.requests())
+}
+
+func TestWebhook_FillDeliveredSigned(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("webhook_fill"), "password123")
+	rcv := newWebhookReceiver(t)
+	webhook := createWebhook(t, user.Token, rcv.URL, []string{"fill"})
+
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.1", 10)
+	assert.Equal(t, 1, deliverWebhooks(t))
+
+	requests := rcv.requests()
+	require.Len(t, requests, 1)
+	req := requests[0]
+	assert.Equal(t, "fill", req.Header.Get(webhookuc.EventHeader))
+
+	// Receivers verify the signature with the secret from creation
+	timestamp := req.Header.Get(webhookuc.TimestampHeader)
+	expected := "sha256=" + webhookuc.Sign(webhook.Secret, timestamp, req.Body)
+	assert.Equal(t, expected, req.Header.Get(webhookuc.SignatureHeader))
+
+	var payload struct {
+		Event string `json:"event"`
+		Trade struct {
+			Type     string `json:"type"`
+			Symbol   string `json:"symbol"`
+			Quantity string `json:"quantity"`
+			Price    string `json:"price"`
+		} `json:"trade"`
+		Position struct {
+			Symbol     string `json:"symbol"`
+			Side       string `json:"side"`
+			EntryPrice string `json:"entry_price"`
+			Status     string `json:"status"`
+		} `json:"position"`
+	}
+	require.NoError(t, json.Unmarshal(req.Body, &payload))
+	assert.Equal(t, "fill", payload.Event)
+	assert.Equal(t, "OPEN", payload.Trade.Type)
+	assert.Equal(t, "BTCUSDT", payload.Trade.Symbol)
+	assert.Equal(t, "0.1", payload.Trade.Quantity)
+	assert.Equal(t, "50010", payload.Trade.Price)
+	assert.Equal(t, "LONG", payload.Position.Side)
+	assert.Equal(t, "OPEN", payload.Position.Status)
+
+	deliveries := getDeliveries(t, user.Token, webhook.ID)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "SUCCEEDED", deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, 200, deliveries[0].LastStatusCode)
+	assert.JSONEq(t, string(req.Body), string(deliveries[0].Payload))
+
+	// Closing the position is a fill too
+	resp := makeRequest(t, "GET", "/positions", nil, user.Token)
+	var positions []map[string]interface{}
+	parseResponse(t, resp, &positions)
+	require.Len(t, positions, 1)
+	resp = makeRequest(t, "POST", fmt.Sprintf("/positions/%d/close", int64(positions[0]["id"].(float64))), nil, user.Token)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Equal(t, 1, deliverWebhooks(t))
+	requests = rcv.requests()
+	require.Len(t, requests, 2)
+	assert.Contains(t, string(requests[1].Body), `"type":"CLOSE"`)
+}
+
+func TestWebhook_EventFilter(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("webhook_filter"), "password123")
+	rcv := newWebhookReceiver(t)
+	webhook := createWebhook(t, user.Token, rcv.URL, []string{"liquidation"})
+
+	placeMarketOrder(t, user.Token, "ETHUSDT", "BUY", "1", 10)
+	assert.Equal(t, 0, deliverWebhooks(t))
+	assert.Empty(t, rcv.requests())
+	assert.Empty(t, getDeliveries(t, user.Token, webhook.ID))
+}
+
+func TestWebhook_RetryAndRedeliver(t *testing.T) {
+	cleanupDatabase(t)
+
+	user := registerUser(t, uniqueEmail("webhook_retry"), "password123")
+	rcv := newWebhookReceiver(t)
+	rcv.setStatus(http.StatusInternalServerError)
+	webhook := createWebhook(t, user.Token, rcv.URL, []string{"fill"})
+
+	placeMarketOrder(t, user.Token, "SOLUSDT", "SELL", "10", 5)
+	assert.Equal(t, 1, deliverWebhooks(t))
+
+	// The retry is scheduled with backoff, so nothing is due right away
+	assert.Equal(t, 0, deliverWebhooks(t))
+
+	deliveries := getDeliveries(t, user.Token, webhook.ID)
+	require.Len(t, deliveries, 1)
+	failed := deliveries[0]
+	assert.Equal(t, "PENDING", failed.Status)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, 500, failed.LastStatusCode)
+	assert.NotNil(t, failed.NextAttemptAt)
+
+	rcv.setStatus(http.StatusNoContent)
+	resp := makeRequest(t, "POST", fmt.Sprintf("/webhooks/%d/deliveries/%d/redeliver", webhook.ID, failed.ID), nil, user.Token)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	var redelivery WebhookDeliveryInfo
+	parseResponse(t, resp, &redelivery)
+	require.NotNil(t, redelivery.RedeliveryOf)
+	assert.Equal(t, failed.ID, *redelivery.RedeliveryOf)
+
+	assert.Equal(t, 1, deliverWebhooks(t))
+
+	deliveries = getDeliveries(t, user.Token, webhook.ID)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, redelivery.ID, deliveries[0].ID)
+	assert.Equal(t, "SUCCEEDED", deliveries[0].Status)
+	assert.Equal(t, "PENDING", deliveries[1].Status)
+
+	requests := rcv.requests()
+	require.Len(t, requests, 2)
+	assert.Equal(t, string(requests[0].Body), string(requests[1].Body))
+
+	// Deliveries of other users' webhooks cannot be redelivered
+	other := registerUser(t, uniqueEmail("webhook_retry_other"), "password123")
+	resp = makeRequest(t, "POST", fmt.Sprintf("/webhooks/%d/deliveries/%d/redeliver", webhook.ID, failed.ID), nil, other.Token)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestWebhook_MarginWarning(t *testing.T) {
+	cleanupDatabase(t)
+	defer priceCache.SetPrice("BTCUSDT", 50000, 50010)
+
+	user := registerUser(t, uniqueEmail("webhook_margin"), "password123")
+	rcv := newWebhookReceiver(t)
+	createWebhook(t, user.Token, rcv.URL, []string{"margin_warning"})
+
+	// Entry 50010, liquidation 50010 * 0.995 = 49759.95
+	placeMarketOrder(t, user.Token, "BTCUSDT", "BUY", "0.1", 100)
+
+	processPrice(t, "BTCUSDT", 49900, 49910) // 40% of the way, no warning
+	assert.Equal(t, 0, deliverWebhooks(t))
+
+	processPrice(t, "BTCUSDT", 49800, 49810) // 82%
+	processPrice(t, "BTCUSDT", 49790, 49800) // still warned, fires once
+	assert.Equal(t, 1, deliverWebhooks(t))
+
+	// Recovering below the reset level arms the warning again
+	processPrice(t, "BTCUSDT", 49990, 50000)
+	processPrice(t, "BTCUSDT", 49800, 49810)
+	assert.Equal(t, 1, deliverWebhooks(t))
+
+	requests := rcv.requests()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "margin_warning", requests[0].Header.Get(webhookuc.EventHeader))
+	assert.True(t, strings.Contains(string(requests[0].Body), `"liquidation_price":"49759.95"`))
+	assert.NotContains(t, string(requests[0].Body), `"trade"`)
+}
@@ -120,6 +120,24 @@ func (p *TradeProducer) PublishTrade(ctx context.Context, trade *domain.Trade) e
 	return nil
 }
 
+// Publish forwards fills made by users to the trades topic in the background.
+// Implements domain.EventPublisher; closes triggered by the price processor
+// are published with PublishTrade directly.
+func (p *TradeProducer) Publish(ctx context.Context, event domain.AccountEvent) {
+	if event.Type != domain.EventTypeFill || event.Trade == nil {
+		return
+	}
+
+	trade := *event.Trade
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// Errors are logged by PublishTrade
+		_ = p.PublishTrade(sendCtx, &trade)
+	}()
+}
+
 func (p *TradeProducer) WaitForCompletion(timeout time.Duration) {
 	deadline := time.Now().Add(timeout)
 
@@ -106,6 +106,15 @@ var (
 		[]string{"topic", "partition"},
 	)
 
+	WebhookDeliveries = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "trading",
+			Name:      "webhook_deliveries_total",
+			Help:      "Total number of webhook delivery attempts",
+		},
+		[]string{"event", "result"},
+	)
+
 	DBConnectionsOpen = promauto.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "trading",
@@ -141,6 +150,10 @@ func RecordLiquidation(symbol string) {
 	Liquidations.WithLabelValues(symbol).Inc()
 }
 
+func RecordWebhookDelivery(event, result string) {
+	WebhookDeliveries.WithLabelValues(event, result).Inc()
+}
+
 func RecordPriceUpdate(symbol string) {
 	PriceUpdates.WithLabelValues(symbol).Inc()
 }
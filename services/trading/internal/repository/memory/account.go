@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"trading/internal/domain"
+)
+
+type AccountRepository struct {
+	s *Store
+}
+
+func NewAccountRepository(s *Store) *AccountRepository {
+	return &AccountRepository{s: s}
+}
+
+func (r *AccountRepository) Create(ctx context.Context, account *domain.Account) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.create(account)
+	return nil
+}
+
+// CreateFunded inserts the account and posts its initial deposit atomically
+func (r *AccountRepository) CreateFunded(ctx context.Context, account *domain.Account, deposit *domain.LedgerEntry) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.create(account)
+	deposit.AccountID = account.ID
+	if err := r.s.post(deposit); err != nil {
+		delete(r.s.accounts, account.ID)
+		return err
+	}
+	account.Balance = deposit.BalanceAfter
+	return nil
+}
+
+func (r *AccountRepository) CreateSubAccount(ctx context.Context, account *domain.Account, limit int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	count := 0
+	for _, a := range r.s.accounts {
+		if a.UserID != account.UserID {
+			continue
+		}
+		if strings.EqualFold(a.Name, account.Name) {
+			return domain.ErrAccountNameTaken
+		}
+		if !a.IsIsolated() {
+			count++
+		}
+	}
+	if count >= limit {
+		return domain.ErrTooManyAccounts
+	}
+
+	r.create(account)
+	return nil
+}
+
+// create stores the account with the store locked
+func (r *AccountRepository) create(account *domain.Account) {
+	now := r.s.now()
+	r.s.nextAccountID++
+	account.ID = r.s.nextAccountID
+	if account.Season == 0 {
+		account.Season = 1
+	}
+	account.SeasonStartedAt = now
+	account.CreatedAt = now
+	account.UpdatedAt = now
+
+	stored := *account
+	r.s.accounts[account.ID] = &stored
+}
+
+func (r *AccountRepository) GetByID(ctx context.Context, id domain.AccountID) (*domain.Account, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	account, ok := r.s.accounts[id]
+	if !ok {
+		return nil, domain.ErrAccountNotFound
+	}
+	result := *account
+	return &result, nil
+}
+
+func (r *AccountRepository) GetPrimaryByUserID(ctx context.Context, userID domain.UserID) (*domain.Account, error) {
+	accounts, err := r.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, domain.ErrAccountNotFound
+	}
+	return &accounts[0], nil
+}
+
+func (r *AccountRepository) ListByUserID(ctx context.Context, userID domain.UserID) ([]domain.Account, error) {
+	return r.list(func(a *domain.Account) bool { return a.UserID == userID }), nil
+}
+
+func (r *AccountRepository) ListAll(ctx context.Context) ([]domain.Account, error) {
+	return r.list(func(a *domain.Account) bool { return true }), nil
+}
+
+func (r *AccountRepository) ListByCompetitionID(ctx context.Context, competitionID domain.CompetitionID) ([]domain.Account, error) {
+	return r.list(func(a *domain.Account) bool {
+		return a.CompetitionID != nil && *a.CompetitionID == competitionID
+	}), nil
+}
+
+func (r *AccountRepository) Lock(ctx context.Context, id domain.AccountID, reason string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	account, ok := r.s.accounts[id]
+	if !ok {
+		return domain.ErrAccountNotFound
+	}
+	account.LockedReason = reason
+	account.UpdatedAt = r.s.now()
+	return nil
+}
+
+// list returns the matching accounts by ID
+func (r *AccountRepository) list(match func(*domain.Account) bool) []domain.Account {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var accounts []domain.Account
+	for _, a := range r.s.accounts {
+		if match(a) {
+			accounts = append(accounts, *a)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+	return accounts
+}
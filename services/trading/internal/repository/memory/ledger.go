@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"trading/internal/domain"
+)
+
+type LedgerRepository struct {
+	s *Store
+}
+
+func NewLedgerRepository(s *Store) *LedgerRepository {
+	return &LedgerRepository{s: s}
+}
+
+// Post applies entry.Amount to the account balance of entry.Asset and appends the entry
+func (r *LedgerRepository) Post(ctx context.Context, entry *domain.LedgerEntry) error {
+	return r.PostBatch(ctx, entry)
+}
+
+// PostBatch posts several entries atomically: nothing is applied if one of them fails
+func (r *LedgerRepository) PostBatch(ctx context.Context, entries ...*domain.LedgerEntry) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.post(entries...)
+}
+
+// post applies the entries with the store locked
+func (s *Store) post(entries ...*domain.LedgerEntry) error {
+	// Check every leg against the balances the earlier legs leave behind
+	type key struct {
+		account domain.AccountID
+		asset   domain.Asset
+	}
+	pending := make(map[key]*domain.LedgerEntry)
+	for _, entry := range entries {
+		if entry.Asset == "" {
+			entry.Asset = domain.QuoteAsset
+		}
+		account, ok := s.accounts[entry.AccountID]
+		if !ok {
+			return domain.ErrAccountNotFound
+		}
+
+		k := key{entry.AccountID, entry.Asset}
+		balance := s.balance(account, entry.Asset)
+		if prev, ok := pending[k]; ok {
+			balance = prev.BalanceAfter
+		}
+		entry.BalanceAfter = balance.Add(entry.Amount)
+		if entry.BalanceAfter.IsNegative() && (entry.Asset != domain.QuoteAsset || !entry.Type.IsSettlement()) {
+			return domain.ErrInsufficientBalance
+		}
+		pending[k] = entry
+	}
+
+	now := s.now()
+	for _, entry := range entries {
+		account := s.accounts[entry.AccountID]
+		if entry.Asset == domain.QuoteAsset {
+			account.Balance = entry.BalanceAfter
+			account.UpdatedAt = now
+		} else {
+			assets, ok := s.assets[entry.AccountID]
+			if !ok {
+				assets = make(map[domain.Asset]*domain.AssetBalance)
+				s.assets[entry.AccountID] = assets
+			}
+			assets[entry.Asset] = &domain.AssetBalance{
+				AccountID: entry.AccountID,
+				Asset:     entry.Asset,
+				Amount:    entry.BalanceAfter,
+				UpdatedAt: now,
+			}
+		}
+
+		entry.ID = domain.LedgerEntryID(len(s.ledger) + 1)
+		entry.CreatedAt = now
+		s.ledger = append(s.ledger, *entry)
+	}
+	return nil
+}
+
+func (r *LedgerRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID, filter domain.LedgerFilter) ([]domain.LedgerEntry, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	types := make(map[domain.LedgerEntryType]bool, len(filter.Types))
+	for _, t := range filter.Types {
+		types[t] = true
+	}
+
+	var entries []domain.LedgerEntry
+	for _, e := range r.s.ledger {
+		if e.AccountID != accountID || (len(types) > 0 && !types[e.Type]) {
+			continue
+		}
+		if (filter.Asset != "" && e.Asset != filter.Asset) || !inRange(e.CreatedAt, filter.From, filter.To) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	newestFirst(entries, func(e *domain.LedgerEntry) (time.Time, int64) { return e.CreatedAt, int64(e.ID) })
+	return page(entries, filter.Limit, filter.Offset), nil
+}
+
+type WalletRepository struct {
+	s *Store
+}
+
+func NewWalletRepository(s *Store) *WalletRepository {
+	return &WalletRepository{s: s}
+}
+
+// GetByAccountID returns the positive non-USDT balances by asset
+func (r *WalletRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.AssetBalance, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var balances []domain.AssetBalance
+	for _, b := range r.s.assets[accountID] {
+		if b.Amount.IsPositive() {
+			balances = append(balances, *b)
+		}
+	}
+	sort.Slice(balances, func(i, j int) bool { return balances[i].Asset < balances[j].Asset })
+	return balances, nil
+}
@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"trading/internal/domain"
+)
+
+type OrderRepository struct {
+	s *Store
+}
+
+func NewOrderRepository(s *Store) *OrderRepository {
+	return &OrderRepository{s: s}
+}
+
+func (r *OrderRepository) Create(ctx context.Context, order *domain.Order) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := r.s.now()
+	r.s.nextOrderID++
+	order.ID = r.s.nextOrderID
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	r.s.orders = append(r.s.orders, *order)
+	return nil
+}
+
+func (r *OrderRepository) GetByID(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i, ok := r.s.orderIndex(id)
+	if !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+	order := r.s.orders[i]
+	return &order, nil
+}
+
+func (r *OrderRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID, filter domain.OrderFilter) ([]domain.Order, error) {
+	orders := r.list(func(o *domain.Order) bool {
+		return o.AccountID == accountID &&
+			(filter.Symbol == "" || o.Symbol == filter.Symbol) &&
+			(filter.Side == "" || o.Side == filter.Side) &&
+			(filter.Type == "" || o.Type == filter.Type) &&
+			(filter.Status == "" || o.Status == filter.Status) &&
+			(filter.ParentID == nil || (o.ParentID != nil && *o.ParentID == *filter.ParentID)) &&
+			inRange(o.CreatedAt, filter.From, filter.To) &&
+			beforeCursor(o.CreatedAt, int64(o.ID), filter.Cursor)
+	})
+	newestFirst(orders, func(o *domain.Order) (time.Time, int64) { return o.CreatedAt, int64(o.ID) })
+	return page(orders, filter.Limit, filter.Offset), nil
+}
+
+func (r *OrderRepository) GetByAccountIDAfter(ctx context.Context, accountID domain.AccountID, from, to *time.Time, afterID domain.OrderID, limit int) ([]domain.Order, error) {
+	orders := r.list(func(o *domain.Order) bool {
+		return o.AccountID == accountID && o.ID > afterID && inRange(o.CreatedAt, from, to)
+	})
+	return page(orders, limit, 0), nil
+}
+
+func (r *OrderRepository) GetPendingByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.Order, error) {
+	return r.list(func(o *domain.Order) bool {
+		return o.AccountID == accountID && o.Status == domain.OrderStatusPending
+	}), nil
+}
+
+func (r *OrderRepository) GetPendingBySymbol(ctx context.Context, symbol string) ([]domain.Order, error) {
+	return r.list(func(o *domain.Order) bool {
+		return o.Symbol == symbol && o.Status == domain.OrderStatusPending
+	}), nil
+}
+
+// Update saves a pending order; a fill time set by the caller is replaced with the store clock
+func (r *OrderRepository) Update(ctx context.Context, order *domain.Order) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, err := r.s.pendingOrder(order.ID)
+	if err != nil {
+		return err
+	}
+	r.s.updateOrder(stored, order)
+	return nil
+}
+
+// pendingOrder finds an order that can still be updated, with the store locked
+func (s *Store) pendingOrder(id domain.OrderID) (*domain.Order, error) {
+	i, ok := s.orderIndex(id)
+	if !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+	if !s.orders[i].IsPending() {
+		return nil, domain.ErrOrderNotPending
+	}
+	return &s.orders[i], nil
+}
+
+// updateOrder copies the order onto its stored copy, with the store locked
+func (s *Store) updateOrder(stored, order *domain.Order) {
+	now := s.now()
+	if order.FilledAt != nil && stored.FilledAt == nil {
+		order.FilledAt = &now
+	}
+	stored.Status = order.Status
+	stored.FilledAt = order.FilledAt
+	stored.Quantity = order.Quantity
+	stored.Price = order.Price
+	stored.StopLoss = order.StopLoss
+	stored.TakeProfit = order.TakeProfit
+	stored.UpdatedAt = now
+	order.UpdatedAt = now
+}
+
+func (r *OrderRepository) Delete(ctx context.Context, id domain.OrderID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i, ok := r.s.orderIndex(id)
+	if !ok {
+		return domain.ErrOrderNotFound
+	}
+	r.s.orders = append(r.s.orders[:i], r.s.orders[i+1:]...)
+	return nil
+}
+
+// orderIndex finds an order; orders are kept sorted by ID
+func (s *Store) orderIndex(id domain.OrderID) (int, bool) {
+	i := sort.Search(len(s.orders), func(i int) bool { return s.orders[i].ID >= id })
+	return i, i < len(s.orders) && s.orders[i].ID == id
+}
+
+// list returns the matching orders by ID
+func (r *OrderRepository) list(match func(*domain.Order) bool) []domain.Order {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var orders []domain.Order
+	for i := range r.s.orders {
+		if match(&r.s.orders[i]) {
+			orders = append(orders, r.s.orders[i])
+		}
+	}
+	return orders
+}
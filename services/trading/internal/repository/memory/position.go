@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+type PositionRepository struct {
+	s *Store
+}
+
+func NewPositionRepository(s *Store) *PositionRepository {
+	return &PositionRepository{s: s}
+}
+
+func (r *PositionRepository) Create(ctx context.Context, position *domain.Position) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := r.s.now()
+	position.ID = domain.PositionID(len(r.s.positions) + 1)
+	position.CreatedAt = now
+	position.UpdatedAt = now
+	r.s.positions = append(r.s.positions, *position)
+	return nil
+}
+
+func (r *PositionRepository) GetByID(ctx context.Context, id domain.PositionID) (*domain.Position, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := int(id) - 1
+	if i < 0 || i >= len(r.s.positions) {
+		return nil, domain.ErrPositionNotFound
+	}
+	position := r.s.positions[i]
+	return &position, nil
+}
+
+func (r *PositionRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.Position, error) {
+	positions := r.list(func(p *domain.Position) bool { return p.AccountID == accountID })
+	newestFirst(positions, func(p *domain.Position) (time.Time, int64) { return p.CreatedAt, int64(p.ID) })
+	return positions, nil
+}
+
+func (r *PositionRepository) GetOpenByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.Position, error) {
+	positions := r.list(func(p *domain.Position) bool { return p.AccountID == accountID && p.IsOpen() })
+	newestFirst(positions, func(p *domain.Position) (time.Time, int64) { return p.CreatedAt, int64(p.ID) })
+	return positions, nil
+}
+
+func (r *PositionRepository) GetOpenByAccountIDAndSymbol(ctx context.Context, accountID domain.AccountID, symbol string) (*domain.Position, error) {
+	positions := r.list(func(p *domain.Position) bool {
+		return p.AccountID == accountID && p.Symbol == symbol && p.IsOpen()
+	})
+	if len(positions) == 0 {
+		return nil, domain.ErrPositionNotFound
+	}
+	return &positions[0], nil
+}
+
+func (r *PositionRepository) GetAllOpen(ctx context.Context) ([]domain.Position, error) {
+	return r.list(func(p *domain.Position) bool { return p.IsOpen() }), nil
+}
+
+func (r *PositionRepository) GetOpenBySymbol(ctx context.Context, symbol string) ([]domain.Position, error) {
+	return r.list(func(p *domain.Position) bool { return p.Symbol == symbol && p.IsOpen() }), nil
+}
+
+func (r *PositionRepository) GetClosedByAccountID(ctx context.Context, accountID domain.AccountID, from, to *time.Time) ([]domain.Position, error) {
+	positions := r.list(func(p *domain.Position) bool {
+		return p.AccountID == accountID && !p.IsOpen() && p.ClosedAt != nil && inRange(*p.ClosedAt, from, to)
+	})
+	newestFirst(positions, func(p *domain.Position) (time.Time, int64) { return *p.ClosedAt, int64(p.ID) })
+	for i, j := 0, len(positions)-1; i < j; i, j = i+1, j-1 {
+		positions[i], positions[j] = positions[j], positions[i]
+	}
+	return positions, nil
+}
+
+func (r *PositionRepository) GetByAccountIDAfter(ctx context.Context, accountID domain.AccountID, from, to *time.Time, afterID domain.PositionID, limit int) ([]domain.Position, error) {
+	positions := r.list(func(p *domain.Position) bool {
+		return p.AccountID == accountID && p.ID > afterID && inRange(p.CreatedAt, from, to)
+	})
+	return page(positions, limit, 0), nil
+}
+
+func (r *PositionRepository) GetHistory(ctx context.Context, accountID domain.AccountID, filter domain.PositionHistoryFilter) ([]domain.Position, error) {
+	positions := r.list(func(p *domain.Position) bool {
+		return p.AccountID == accountID && !p.IsOpen() && p.ClosedAt != nil &&
+			(filter.Symbol == "" || p.Symbol == filter.Symbol) &&
+			(filter.Side == "" || p.Side == filter.Side) &&
+			(filter.Status == "" || p.Status == filter.Status) &&
+			inRange(*p.ClosedAt, filter.From, filter.To) &&
+			beforeCursor(*p.ClosedAt, int64(p.ID), filter.Cursor)
+	})
+	newestFirst(positions, func(p *domain.Position) (time.Time, int64) { return *p.ClosedAt, int64(p.ID) })
+	return page(positions, filter.Limit, 0), nil
+}
+
+// Update saves the position; a close time set by the caller is replaced with the store clock
+func (r *PositionRepository) Update(ctx context.Context, position *domain.Position) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := int(position.ID) - 1
+	if i < 0 || i >= len(r.s.positions) {
+		return domain.ErrPositionNotFound
+	}
+	stored := &r.s.positions[i]
+	if position.ClosedAt != nil && stored.ClosedAt == nil {
+		now := r.s.now()
+		position.ClosedAt = &now
+	}
+
+	stored.Status = position.Status
+	stored.Quantity = position.Quantity
+	stored.EntryPrice = position.EntryPrice
+	stored.InitialMargin = position.InitialMargin
+	stored.MarkPrice = position.MarkPrice
+	stored.UnrealizedPnL = position.UnrealizedPnL
+	stored.RealizedPnL = position.RealizedPnL
+	stored.LiquidationPrice = position.LiquidationPrice
+	stored.StopLoss = position.StopLoss
+	stored.TakeProfit = position.TakeProfit
+	stored.SLClosePercent = position.SLClosePercent
+	stored.TPClosePercent = position.TPClosePercent
+	stored.ClosedAt = position.ClosedAt
+	return nil
+}
+
+func (r *PositionRepository) UpdatePnL(ctx context.Context, id domain.PositionID, markPrice, unrealizedPnL decimal.Decimal) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := int(id) - 1
+	if i < 0 || i >= len(r.s.positions) || !r.s.positions[i].IsOpen() {
+		return domain.ErrPositionNotFound
+	}
+	r.s.positions[i].MarkPrice = markPrice
+	r.s.positions[i].UnrealizedPnL = unrealizedPnL
+	return nil
+}
+
+// list returns the matching positions by ID
+func (r *PositionRepository) list(match func(*domain.Position) bool) []domain.Position {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var positions []domain.Position
+	for i := range r.s.positions {
+		if match(&r.s.positions[i]) {
+			positions = append(positions, r.s.positions[i])
+		}
+	}
+	return positions
+}
@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"trading/internal/domain"
+)
+
+type SpotLotRepository struct {
+	s *Store
+}
+
+func NewSpotLotRepository(s *Store) *SpotLotRepository {
+	return &SpotLotRepository{s: s}
+}
+
+func (r *SpotLotRepository) Create(ctx context.Context, lot *domain.SpotLot) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.createSpotLot(lot)
+	return nil
+}
+
+// createSpotLot stores the lot with the store locked
+func (s *Store) createSpotLot(lot *domain.SpotLot) {
+	lot.ID = domain.SpotLotID(len(s.spotLots) + 1)
+	lot.CreatedAt = s.now()
+	s.spotLots = append(s.spotLots, *lot)
+}
+
+func (r *SpotLotRepository) GetOpenByAccountIDAndAsset(ctx context.Context, accountID domain.AccountID, asset domain.Asset) ([]domain.SpotLot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var lots []domain.SpotLot
+	for _, l := range r.s.spotLots {
+		if l.AccountID == accountID && l.Asset == asset && l.Remaining.IsPositive() {
+			lots = append(lots, l)
+		}
+	}
+	return lots, nil
+}
+
+func (r *SpotLotRepository) GetOpenByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.SpotLot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var lots []domain.SpotLot
+	for _, l := range r.s.spotLots {
+		if l.AccountID == accountID && l.Remaining.IsPositive() {
+			lots = append(lots, l)
+		}
+	}
+	sort.SliceStable(lots, func(i, j int) bool { return lots[i].Asset < lots[j].Asset })
+	return lots, nil
+}
+
+func (r *SpotLotRepository) Update(ctx context.Context, lot *domain.SpotLot) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := int(lot.ID) - 1
+	if i < 0 || i >= len(r.s.spotLots) {
+		return domain.ErrSpotLotNotFound
+	}
+	r.s.spotLots[i].Remaining = lot.Remaining
+	r.s.spotLots[i].ClosedAt = lot.ClosedAt
+	return nil
+}
+
+// Settle claims the order and writes the trade, its ledger legs and the lots
+// atomically: the legs are checked before anything is stored
+func (r *SpotLotRepository) Settle(ctx context.Context, fill *domain.SpotFill) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, err := r.s.pendingOrder(fill.Order.ID)
+	if err != nil {
+		return err
+	}
+	for _, lot := range fill.Consumed {
+		if i := int(lot.ID) - 1; i < 0 || i >= len(r.s.spotLots) {
+			return domain.ErrSpotLotNotFound
+		}
+	}
+
+	// The trade gets the next ID, the legs reference it before it is stored
+	tradeID := domain.TradeID(len(r.s.trades) + 1)
+	for _, entry := range fill.Entries {
+		entry.TradeID = &tradeID
+	}
+	if err := r.s.post(fill.Entries...); err != nil {
+		return err
+	}
+
+	r.s.updateOrder(stored, fill.Order)
+	r.s.createTrade(fill.Trade)
+	if fill.Opened != nil {
+		fill.Opened.TradeID = fill.Trade.ID
+		r.s.createSpotLot(fill.Opened)
+	}
+	for _, lot := range fill.Consumed {
+		r.s.spotLots[lot.ID-1].Remaining = lot.Remaining
+		r.s.spotLots[lot.ID-1].ClosedAt = lot.ClosedAt
+	}
+	return nil
+}
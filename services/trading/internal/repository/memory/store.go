@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+// Store is an in-memory database for simulations such as backtests.
+// Repositories built on the same store share its tables, and every timestamp
+// a database would set comes from the store clock, so a replay can run on
+// simulated time.
+type Store struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	accounts  map[domain.AccountID]*domain.Account
+	assets    map[domain.AccountID]map[domain.Asset]*domain.AssetBalance
+	ledger    []domain.LedgerEntry
+	spotLots  []domain.SpotLot
+	orders    []domain.Order
+	positions []domain.Position
+	trades    []domain.Trade
+
+	nextAccountID domain.AccountID
+	nextOrderID   domain.OrderID
+}
+
+// NewStore creates an empty store. A nil clock uses the wall clock.
+func NewStore(now func() time.Time) *Store {
+	if now == nil {
+		now = time.Now
+	}
+	return &Store{
+		now:      now,
+		accounts: make(map[domain.AccountID]*domain.Account),
+		assets:   make(map[domain.AccountID]map[domain.Asset]*domain.AssetBalance),
+	}
+}
+
+// inRange reports whether t is in [from, to); nil bounds are open-ended
+func inRange(t time.Time, from, to *time.Time) bool {
+	return (from == nil || !t.Before(*from)) && (to == nil || t.Before(*to))
+}
+
+// beforeCursor reports whether an item sorts after the cursor in a newest first list
+func beforeCursor(t time.Time, id int64, c *domain.Cursor) bool {
+	if c == nil {
+		return true
+	}
+	return t.Before(c.Time) || (t.Equal(c.Time) && id < c.ID)
+}
+
+// newestFirst sorts by time then ID, both descending
+func newestFirst[T any](items []T, key func(*T) (time.Time, int64)) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ti, idi := key(&items[i])
+		tj, idj := key(&items[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return idi > idj
+	})
+}
+
+// page applies offset and limit; a limit of zero or less returns everything after the offset
+func page[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// balance returns the account's balance of an asset, zero if it never held any
+func (s *Store) balance(account *domain.Account, asset domain.Asset) decimal.Decimal {
+	if asset == domain.QuoteAsset {
+		return account.Balance
+	}
+	if b, ok := s.assets[account.ID][asset]; ok {
+		return b.Amount
+	}
+	return decimal.Zero
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"trading/internal/domain"
+)
+
+type TradeRepository struct {
+	s *Store
+}
+
+func NewTradeRepository(s *Store) *TradeRepository {
+	return &TradeRepository{s: s}
+}
+
+func (r *TradeRepository) Create(ctx context.Context, trade *domain.Trade) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.createTrade(trade)
+	return nil
+}
+
+// createTrade stores the trade with the store locked
+func (s *Store) createTrade(trade *domain.Trade) {
+	trade.ID = domain.TradeID(len(s.trades) + 1)
+	trade.CreatedAt = s.now()
+	s.trades = append(s.trades, *trade)
+}
+
+func (r *TradeRepository) GetByID(ctx context.Context, id domain.TradeID) (*domain.Trade, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	i := int(id) - 1
+	if i < 0 || i >= len(r.s.trades) {
+		return nil, domain.ErrTradeNotFound
+	}
+	trade := r.s.trades[i]
+	return &trade, nil
+}
+
+func (r *TradeRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID, filter domain.TradeFilter) ([]domain.Trade, error) {
+	trades := r.list(func(t *domain.Trade) bool {
+		return t.AccountID == accountID &&
+			(filter.Symbol == "" || t.Symbol == filter.Symbol) &&
+			(filter.Side == "" || t.Side == filter.Side) &&
+			(filter.Type == "" || t.Type == filter.Type) &&
+			inRange(t.CreatedAt, filter.From, filter.To) &&
+			beforeCursor(t.CreatedAt, int64(t.ID), filter.Cursor)
+	})
+	newestFirst(trades, func(t *domain.Trade) (time.Time, int64) { return t.CreatedAt, int64(t.ID) })
+	return page(trades, filter.Limit, filter.Offset), nil
+}
+
+func (r *TradeRepository) GetByPositionID(ctx context.Context, positionID domain.PositionID) ([]domain.Trade, error) {
+	return r.list(func(t *domain.Trade) bool { return t.PositionID == positionID }), nil
+}
+
+func (r *TradeRepository) GetByAccountIDBetween(ctx context.Context, accountID domain.AccountID, from time.Time, to *time.Time, limit, offset int) ([]domain.Trade, error) {
+	trades := r.list(func(t *domain.Trade) bool {
+		return t.AccountID == accountID && inRange(t.CreatedAt, &from, to)
+	})
+	newestFirst(trades, func(t *domain.Trade) (time.Time, int64) { return t.CreatedAt, int64(t.ID) })
+	return page(trades, limit, offset), nil
+}
+
+func (r *TradeRepository) GetByAccountIDAfter(ctx context.Context, accountID domain.AccountID, from, to *time.Time, afterID domain.TradeID, limit int) ([]domain.Trade, error) {
+	trades := r.list(func(t *domain.Trade) bool {
+		return t.AccountID == accountID && t.ID > afterID && inRange(t.CreatedAt, from, to)
+	})
+	return page(trades, limit, 0), nil
+}
+
+// Summarize aggregates trades created in [from, to). A nil to is open-ended.
+func (r *TradeRepository) Summarize(ctx context.Context, accountID domain.AccountID, from time.Time, to *time.Time) (*domain.TradeSummary, error) {
+	trades := r.list(func(t *domain.Trade) bool {
+		return t.AccountID == accountID && inRange(t.CreatedAt, &from, to)
+	})
+
+	s := &domain.TradeSummary{}
+	for _, t := range trades {
+		s.TotalTrades++
+		s.RealizedPnL = s.RealizedPnL.Add(t.PnL)
+		s.Volume = s.Volume.Add(t.Quantity.Mul(t.Price))
+		switch t.Type {
+		case domain.TradeTypeClose, domain.TradeTypeLiquidate, domain.TradeTypeSpotSell:
+			s.ClosedTrades++
+			if t.PnL.IsPositive() {
+				s.WinningTrades++
+			} else if t.PnL.IsNegative() {
+				s.LosingTrades++
+			}
+		}
+		if t.Type == domain.TradeTypeLiquidate {
+			s.Liquidations++
+		}
+	}
+	return s, nil
+}
+
+func (r *TradeRepository) GetByPositionIDs(ctx context.Context, positionIDs []domain.PositionID) ([]domain.Trade, error) {
+	ids := make(map[domain.PositionID]bool, len(positionIDs))
+	for _, id := range positionIDs {
+		ids[id] = true
+	}
+	return r.list(func(t *domain.Trade) bool { return ids[t.PositionID] }), nil
+}
+
+// list returns the matching trades by ID
+func (r *TradeRepository) list(match func(*domain.Trade) bool) []domain.Trade {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var trades []domain.Trade
+	for i := range r.s.trades {
+		if match(&r.s.trades[i]) {
+			trades = append(trades, r.s.trades[i])
+		}
+	}
+	return trades
+}
@@ -5,8 +5,6 @@ import (
 	"database/sql"
 	"errors"
 
-	"github.com/shopspring/decimal"
-
 	"trading/internal/domain"
 )
 
@@ -19,25 +17,93 @@ func NewAccountRepository(db *DB) *AccountRepository {
 }
 
 func (r *AccountRepository) Create(ctx context.Context, account *domain.Account) error {
-	query := `
-		INSERT INTO accounts (user_id, balance, created_at, updated_at)
-		VALUES ($1, $2, NOW(), NOW())
-		RETURNING id, created_at, updated_at`
+	return insertAccount(ctx, r.db, account)
+}
+
+// CreateFunded inserts the account and posts its initial deposit in one transaction
+func (r *AccountRepository) CreateFunded(ctx context.Context, account *domain.Account, deposit *domain.LedgerEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertAccount(ctx, tx, account); err != nil {
+		return err
+	}
+	deposit.AccountID = account.ID
+	if err := postEntry(ctx, tx, deposit); err != nil {
+		return err
+	}
 
-	return r.db.QueryRowContext(ctx, query, account.UserID, account.Balance).
-		Scan(&account.ID, &account.CreatedAt, &account.UpdatedAt)
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	account.Balance = deposit.BalanceAfter
+	return nil
+}
+
+// CreateSubAccount locks the user row, so concurrent creations are checked one after another
+func (r *AccountRepository) CreateSubAccount(ctx context.Context, account *domain.Account, limit int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, account.UserID); err != nil {
+		return err
+	}
+
+	var count, taken int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FILTER (WHERE competition_id IS NULL AND challenge_id IS NULL),
+			   COUNT(*) FILTER (WHERE LOWER(name) = LOWER($2))
+		FROM accounts
+		WHERE user_id = $1`,
+		account.UserID, account.Name,
+	).Scan(&count, &taken)
+	if err != nil {
+		return err
+	}
+	if taken > 0 {
+		return domain.ErrAccountNameTaken
+	}
+	if count >= limit {
+		return domain.ErrTooManyAccounts
+	}
+
+	if err := insertAccount(ctx, tx, account); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertAccount(ctx context.Context, q queryRower, account *domain.Account) error {
+	query := `
+		INSERT INTO accounts (user_id, name, balance, season_starting_balance, competition_id, challenge_id,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		RETURNING id, season, season_started_at, created_at, updated_at`
+
+	return q.QueryRowContext(ctx, query,
+		account.UserID, account.Name, account.Balance, account.SeasonStartingBalance, account.CompetitionID, account.ChallengeID,
+	).
+		Scan(&account.ID, &account.Season, &account.SeasonStartedAt, &account.CreatedAt, &account.UpdatedAt)
 }
 
 func (r *AccountRepository) GetByID(ctx context.Context, id domain.AccountID) (*domain.Account, error) {
 	query := `
-		SELECT id, user_id, balance, created_at, updated_at
+		SELECT id, user_id, name, balance, season, season_started_at, season_starting_balance,
+			   competition_id, challenge_id, COALESCE(locked_reason, ''), created_at, updated_at
 		FROM accounts
 		WHERE id = $1`
 
 	account := &domain.Account{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&account.ID, &account.UserID, &account.Balance,
-		&account.CreatedAt, &account.UpdatedAt,
+		&account.ID, &account.UserID, &account.Name, &account.Balance,
+		&account.Season, &account.SeasonStartedAt, &account.SeasonStartingBalance,
+		&account.CompetitionID, &account.ChallengeID, &account.LockedReason, &account.CreatedAt, &account.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -48,16 +114,20 @@ func (r *AccountRepository) GetByID(ctx context.Context, id domain.AccountID) (*
 	return account, nil
 }
 
-func (r *AccountRepository) GetByUserID(ctx context.Context, userID domain.UserID) (*domain.Account, error) {
+func (r *AccountRepository) GetPrimaryByUserID(ctx context.Context, userID domain.UserID) (*domain.Account, error) {
 	query := `
-		SELECT id, user_id, balance, created_at, updated_at
+		SELECT id, user_id, name, balance, season, season_started_at, season_starting_balance,
+			   competition_id, challenge_id, COALESCE(locked_reason, ''), created_at, updated_at
 		FROM accounts
-		WHERE user_id = $1`
+		WHERE user_id = $1
+		ORDER BY id ASC
+		LIMIT 1`
 
 	account := &domain.Account{}
 	err := r.db.QueryRowContext(ctx, query, userID).Scan(
-		&account.ID, &account.UserID, &account.Balance,
-		&account.CreatedAt, &account.UpdatedAt,
+		&account.ID, &account.UserID, &account.Name, &account.Balance,
+		&account.Season, &account.SeasonStartedAt, &account.SeasonStartingBalance,
+		&account.CompetitionID, &account.ChallengeID, &account.LockedReason, &account.CreatedAt, &account.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -68,48 +138,108 @@ func (r *AccountRepository) GetByUserID(ctx context.Context, userID domain.UserI
 	return account, nil
 }
 
-func (r *AccountRepository) Update(ctx context.Context, account *domain.Account) error {
+func (r *AccountRepository) ListByUserID(ctx context.Context, userID domain.UserID) ([]domain.Account, error) {
 	query := `
-		UPDATE accounts
-		SET balance = $1
-		WHERE id = $2`
+		SELECT id, user_id, name, balance, season, season_started_at, season_starting_balance,
+			   competition_id, challenge_id, COALESCE(locked_reason, ''), created_at, updated_at
+		FROM accounts
+		WHERE user_id = $1
+		ORDER BY id ASC`
 
-	result, err := r.db.ExecContext(ctx, query, account.Balance, account.ID)
+	rows, err := r.db.QueryContext(ctx, query, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
+
+	var accounts []domain.Account
+	for rows.Next() {
+		var a domain.Account
+		err := rows.Scan(
+			&a.ID, &a.UserID, &a.Name, &a.Balance,
+			&a.Season, &a.SeasonStartedAt, &a.SeasonStartingBalance,
+			&a.CompetitionID, &a.ChallengeID, &a.LockedReason, &a.CreatedAt, &a.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, rows.Err()
+}
 
-	rows, err := result.RowsAffected()
+func (r *AccountRepository) ListAll(ctx context.Context) ([]domain.Account, error) {
+	query := `
+		SELECT id, user_id, name, balance, season, season_started_at, season_starting_balance,
+			   competition_id, challenge_id, COALESCE(locked_reason, ''), created_at, updated_at
+		FROM accounts
+		ORDER BY id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if rows == 0 {
-		return domain.ErrAccountNotFound
+	defer rows.Close()
+
+	var accounts []domain.Account
+	for rows.Next() {
+		var a domain.Account
+		err := rows.Scan(
+			&a.ID, &a.UserID, &a.Name, &a.Balance,
+			&a.Season, &a.SeasonStartedAt, &a.SeasonStartingBalance,
+			&a.CompetitionID, &a.ChallengeID, &a.LockedReason, &a.CreatedAt, &a.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, a)
 	}
-	return nil
+	return accounts, rows.Err()
 }
 
-func (r *AccountRepository) UpdateBalance(ctx context.Context, id domain.AccountID, delta decimal.Decimal) error {
+func (r *AccountRepository) ListByCompetitionID(ctx context.Context, competitionID domain.CompetitionID) ([]domain.Account, error) {
 	query := `
-		UPDATE accounts
-		SET balance = balance + $1
-		WHERE id = $2
-		RETURNING balance`
+		SELECT id, user_id, name, balance, season, season_started_at, season_starting_balance,
+			   competition_id, challenge_id, COALESCE(locked_reason, ''), created_at, updated_at
+		FROM accounts
+		WHERE competition_id = $1
+		ORDER BY id ASC`
 
-	var newBalance decimal.Decimal
-	err := r.db.QueryRowContext(ctx, query, delta, id).Scan(&newBalance)
+	rows, err := r.db.QueryContext(ctx, query, competitionID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return domain.ErrAccountNotFound
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []domain.Account
+	for rows.Next() {
+		var a domain.Account
+		err := rows.Scan(
+			&a.ID, &a.UserID, &a.Name, &a.Balance,
+			&a.Season, &a.SeasonStartedAt, &a.SeasonStartingBalance,
+			&a.CompetitionID, &a.ChallengeID, &a.LockedReason, &a.CreatedAt, &a.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
 		}
-		return err
+		accounts = append(accounts, a)
 	}
+	return accounts, rows.Err()
+}
 
-	if newBalance.IsNegative() {
-		// Rollback the change
-		_, _ = r.db.ExecContext(ctx, `UPDATE accounts SET balance = balance - $1 WHERE id = $2`, delta, id)
-		return domain.ErrInsufficientBalance
-	}
+func (r *AccountRepository) Lock(ctx context.Context, id domain.AccountID, reason string) error {
+	query := `UPDATE accounts SET locked_reason = $1, updated_at = NOW() WHERE id = $2`
 
+	result, err := r.db.ExecContext(ctx, query, reason, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrAccountNotFound
+	}
 	return nil
 }
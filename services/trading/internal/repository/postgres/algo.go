@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"trading/internal/domain"
+)
+
+type AlgoOrderRepository struct {
+	db *DB
+}
+
+func NewAlgoOrderRepository(db *DB) *AlgoOrderRepository {
+	return &AlgoOrderRepository{db: db}
+}
+
+const algoOrderColumns = `a.order_id, a.duration_seconds, a.slices, a.dataset, a.visible_quantity, a.distribution, a.arrival_price, a.start_at, a.created_at`
+
+func (r *AlgoOrderRepository) Create(ctx context.Context, a *domain.AlgoOrder) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO algo_orders (order_id, duration_seconds, slices, dataset, visible_quantity, distribution, arrival_price, start_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING created_at`,
+		a.OrderID, int64(a.Duration/time.Second), a.SliceCount, a.Dataset, a.VisibleQuantity, a.Distribution, a.ArrivalPrice, a.StartAt,
+	).Scan(&a.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO algo_slices (order_id, slice, due_at, quantity, limit_price, status)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for _, s := range a.Slices {
+		if _, err := tx.ExecContext(ctx, query, a.OrderID, s.Index, s.DueAt, s.Quantity, s.LimitPrice, s.Status); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *AlgoOrderRepository) GetByOrderID(ctx context.Context, orderID domain.OrderID) (*domain.AlgoOrder, error) {
+	query := `SELECT ` + algoOrderColumns + ` FROM algo_orders a WHERE a.order_id = $1`
+
+	algos, err := r.query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(algos) == 0 {
+		return nil, domain.ErrAlgoOrderNotFound
+	}
+	return &algos[0], nil
+}
+
+func (r *AlgoOrderRepository) GetActive(ctx context.Context) ([]domain.AlgoOrder, error) {
+	query := `
+		SELECT ` + algoOrderColumns + `
+		FROM algo_orders a
+		JOIN orders o ON o.id = a.order_id
+		WHERE o.status = 'PENDING'
+		   OR EXISTS (SELECT 1 FROM algo_slices s WHERE s.order_id = a.order_id AND s.status IN ('SCHEDULED', 'OPEN'))
+		ORDER BY a.order_id ASC`
+
+	return r.query(ctx, query)
+}
+
+func (r *AlgoOrderRepository) UpdateSlice(ctx context.Context, s *domain.AlgoSlice) error {
+	query := `
+		UPDATE algo_slices
+		SET quantity = $1, status = $2, child_id = $3, filled_quantity = $4, price = $5, error = $6
+		WHERE order_id = $7 AND slice = $8`
+
+	result, err := r.db.ExecContext(ctx, query,
+		s.Quantity, s.Status, s.ChildID, s.FilledQuantity, s.Price, s.Error, s.OrderID, s.Index,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrAlgoOrderNotFound
+	}
+	return nil
+}
+
+// query scans algo orders, then loads their slices
+func (r *AlgoOrderRepository) query(ctx context.Context, query string, args ...interface{}) ([]domain.AlgoOrder, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var algos []domain.AlgoOrder
+	for rows.Next() {
+		var a domain.AlgoOrder
+		var seconds int64
+		err := rows.Scan(
+			&a.OrderID, &seconds, &a.SliceCount, &a.Dataset, &a.VisibleQuantity, &a.Distribution,
+			&a.ArrivalPrice, &a.StartAt, &a.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		a.Duration = time.Duration(seconds) * time.Second
+		algos = append(algos, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range algos {
+		if algos[i].Slices, err = r.slices(ctx, algos[i].OrderID); err != nil {
+			return nil, err
+		}
+	}
+	return algos, nil
+}
+
+func (r *AlgoOrderRepository) slices(ctx context.Context, orderID domain.OrderID) ([]domain.AlgoSlice, error) {
+	query := `
+		SELECT order_id, slice, due_at, quantity, limit_price, status, child_id, filled_quantity, price, error
+		FROM algo_slices
+		WHERE order_id = $1
+		ORDER BY slice ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slices []domain.AlgoSlice
+	for rows.Next() {
+		var s domain.AlgoSlice
+		err := rows.Scan(
+			&s.OrderID, &s.Index, &s.DueAt, &s.Quantity, &s.LimitPrice, &s.Status, &s.ChildID,
+			&s.FilledQuantity, &s.Price, &s.Error,
+		)
+		if err != nil {
+			return nil, err
+		}
+		slices = append(slices, s)
+	}
+	return slices, rows.Err()
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"trading/internal/domain"
+)
+
+type ChallengeRepository struct {
+	db *DB
+}
+
+func NewChallengeRepository(db *DB) *ChallengeRepository {
+	return &ChallengeRepository{db: db}
+}
+
+func (r *ChallengeRepository) Create(ctx context.Context, c *domain.Challenge) error {
+	query := `
+		INSERT INTO challenges (user_id, status, starting_balance, profit_target, max_daily_loss, max_drawdown,
+			min_trading_days, max_position_size, day_started_on, day_start_equity, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		RETURNING id, created_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		c.UserID, c.Status, c.StartingBalance, c.Rules.ProfitTarget, c.Rules.MaxDailyLoss, c.Rules.MaxDrawdown,
+		c.Rules.MinTradingDays, c.Rules.MaxPositionSize, c.DayStartedOn, c.DayStartEquity,
+	).Scan(&c.ID, &c.CreatedAt)
+}
+
+func (r *ChallengeRepository) GetByID(ctx context.Context, id domain.ChallengeID) (*domain.Challenge, error) {
+	query := `
+		SELECT c.id, c.user_id, COALESCE(a.id, 0), c.status, c.starting_balance,
+			   c.profit_target, c.max_daily_loss, c.max_drawdown, c.min_trading_days, c.max_position_size,
+			   c.day_started_on, c.day_start_equity, c.trading_days, c.last_trading_day,
+			   c.failure_reason, c.created_at, c.ended_at
+		FROM challenges c
+		LEFT JOIN accounts a ON a.challenge_id = c.id
+		WHERE c.id = $1`
+
+	return r.scanChallenge(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *ChallengeRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID) (*domain.Challenge, error) {
+	query := `
+		SELECT c.id, c.user_id, COALESCE(a.id, 0), c.status, c.starting_balance,
+			   c.profit_target, c.max_daily_loss, c.max_drawdown, c.min_trading_days, c.max_position_size,
+			   c.day_started_on, c.day_start_equity, c.trading_days, c.last_trading_day,
+			   c.failure_reason, c.created_at, c.ended_at
+		FROM challenges c
+		JOIN accounts a ON a.challenge_id = c.id
+		WHERE a.id = $1`
+
+	return r.scanChallenge(r.db.QueryRowContext(ctx, query, accountID))
+}
+
+func (r *ChallengeRepository) ListByUserID(ctx context.Context, userID domain.UserID) ([]domain.Challenge, error) {
+	query := `
+		SELECT c.id, c.user_id, COALESCE(a.id, 0), c.status, c.starting_balance,
+			   c.profit_target, c.max_daily_loss, c.max_drawdown, c.min_trading_days, c.max_position_size,
+			   c.day_started_on, c.day_start_equity, c.trading_days, c.last_trading_day,
+			   c.failure_reason, c.created_at, c.ended_at
+		FROM challenges c
+		LEFT JOIN accounts a ON a.challenge_id = c.id
+		WHERE c.user_id = $1
+		ORDER BY c.id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanChallenges(rows)
+}
+
+func (r *ChallengeRepository) GetActive(ctx context.Context) ([]domain.Challenge, error) {
+	query := `
+		SELECT c.id, c.user_id, COALESCE(a.id, 0), c.status, c.starting_balance,
+			   c.profit_target, c.max_daily_loss, c.max_drawdown, c.min_trading_days, c.max_position_size,
+			   c.day_started_on, c.day_start_equity, c.trading_days, c.last_trading_day,
+			   c.failure_reason, c.created_at, c.ended_at
+		FROM challenges c
+		JOIN accounts a ON a.challenge_id = c.id
+		WHERE c.status = 'ACTIVE'
+		ORDER BY c.id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanChallenges(rows)
+}
+
+func (r *ChallengeRepository) UpdateProgress(ctx context.Context, c *domain.Challenge) error {
+	query := `
+		UPDATE challenges
+		SET day_started_on = $1, day_start_equity = $2, trading_days = $3, last_trading_day = $4
+		WHERE id = $5 AND status = 'ACTIVE'`
+
+	_, err := r.db.ExecContext(ctx, query,
+		c.DayStartedOn, c.DayStartEquity, c.TradingDays, c.LastTradingDay, c.ID,
+	)
+	return err
+}
+
+func (r *ChallengeRepository) End(ctx context.Context, id domain.ChallengeID, status domain.ChallengeStatus, reason string, at time.Time) (bool, error) {
+	query := `
+		UPDATE challenges
+		SET status = $1, failure_reason = $2, ended_at = $3
+		WHERE id = $4 AND status = 'ACTIVE'`
+
+	result, err := r.db.ExecContext(ctx, query, status, reason, at, id)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *ChallengeRepository) scanChallenge(row *sql.Row) (*domain.Challenge, error) {
+	var c domain.Challenge
+	err := row.Scan(
+		&c.ID, &c.UserID, &c.AccountID, &c.Status, &c.StartingBalance,
+		&c.Rules.ProfitTarget, &c.Rules.MaxDailyLoss, &c.Rules.MaxDrawdown, &c.Rules.MinTradingDays, &c.Rules.MaxPositionSize,
+		&c.DayStartedOn, &c.DayStartEquity, &c.TradingDays, &c.LastTradingDay,
+		&c.FailureReason, &c.CreatedAt, &c.EndedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrChallengeNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *ChallengeRepository) scanChallenges(rows *sql.Rows) ([]domain.Challenge, error) {
+	var challenges []domain.Challenge
+	for rows.Next() {
+		var c domain.Challenge
+		err := rows.Scan(
+			&c.ID, &c.UserID, &c.AccountID, &c.Status, &c.StartingBalance,
+			&c.Rules.ProfitTarget, &c.Rules.MaxDailyLoss, &c.Rules.MaxDrawdown, &c.Rules.MinTradingDays, &c.Rules.MaxPositionSize,
+			&c.DayStartedOn, &c.DayStartEquity, &c.TradingDays, &c.LastTradingDay,
+			&c.FailureReason, &c.CreatedAt, &c.EndedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		challenges = append(challenges, c)
+	}
+	return challenges, rows.Err()
+}
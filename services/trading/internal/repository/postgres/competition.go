@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+type CompetitionRepository struct {
+	db *DB
+}
+
+func NewCompetitionRepository(db *DB) *CompetitionRepository {
+	return &CompetitionRepository{db: db}
+}
+
+func (r *CompetitionRepository) Create(ctx context.Context, c *domain.Competition) error {
+	query := `
+		INSERT INTO competitions (name, description, starts_at, ends_at, allowed_symbols, max_leverage,
+			starting_balance, ranking_metric, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		RETURNING id, created_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		c.Name, c.Description, c.StartsAt, c.EndsAt, pq.Array(c.AllowedSymbols), c.MaxLeverage,
+		c.StartingBalance, c.RankingMetric, c.CreatedBy,
+	).Scan(&c.ID, &c.CreatedAt)
+}
+
+func (r *CompetitionRepository) GetByID(ctx context.Context, id domain.CompetitionID) (*domain.Competition, error) {
+	query := `
+		SELECT id, name, description, starts_at, ends_at, allowed_symbols, max_leverage,
+			   starting_balance, ranking_metric, created_by, finalized_at, created_at
+		FROM competitions
+		WHERE id = $1`
+
+	var c domain.Competition
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&c.ID, &c.Name, &c.Description, &c.StartsAt, &c.EndsAt, pq.Array(&c.AllowedSymbols), &c.MaxLeverage,
+		&c.StartingBalance, &c.RankingMetric, &c.CreatedBy, &c.FinalizedAt, &c.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrCompetitionNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *CompetitionRepository) List(ctx context.Context) ([]domain.Competition, error) {
+	query := `
+		SELECT id, name, description, starts_at, ends_at, allowed_symbols, max_leverage,
+			   starting_balance, ranking_metric, created_by, finalized_at, created_at
+		FROM competitions
+		ORDER BY starts_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanCompetitions(rows)
+}
+
+func (r *CompetitionRepository) GetUnfinalized(ctx context.Context, endedBefore time.Time) ([]domain.Competition, error) {
+	query := `
+		SELECT id, name, description, starts_at, ends_at, allowed_symbols, max_leverage,
+			   starting_balance, ranking_metric, created_by, finalized_at, created_at
+		FROM competitions
+		WHERE finalized_at IS NULL AND ends_at <= $1
+		ORDER BY ends_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, endedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanCompetitions(rows)
+}
+
+func (r *CompetitionRepository) GetPerformance(ctx context.Context, id domain.CompetitionID) ([]domain.TraderPerformance, error) {
+	query := `
+		SELECT a.user_id, a.id, COALESCE(u.display_name, 'trader-' || u.id), a.season_starting_balance,
+			   COUNT(*),
+			   COUNT(*) FILTER (WHERE t.pnl - t.fee > 0),
+			   SUM(t.pnl - t.fee),
+			   COALESCE(STDDEV_SAMP(t.pnl - t.fee), 0)
+		FROM trades t
+		JOIN accounts a ON a.id = t.account_id
+		JOIN users u ON u.id = a.user_id
+		WHERE a.competition_id = $1 AND t.type IN ('CLOSE', 'LIQUIDATE', 'SPOT_SELL')
+		GROUP BY a.user_id, a.id, u.display_name, u.id, a.season_starting_balance`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.TraderPerformance
+	for rows.Next() {
+		var p domain.TraderPerformance
+		err := rows.Scan(
+			&p.UserID, &p.AccountID, &p.DisplayName, &p.StartingBalance,
+			&p.Trades, &p.Wins, &p.RealizedPnL, &p.PnLStdDev,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, rows.Err()
+}
+
+func (r *CompetitionRepository) Finalize(ctx context.Context, id domain.CompetitionID, standings []domain.CompetitionStanding, at time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the competition so concurrent finalizers cannot both write standings
+	result, err := tx.ExecContext(ctx,
+		`UPDATE competitions SET finalized_at = $1 WHERE id = $2 AND finalized_at IS NULL`,
+		at, id,
+	)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO competition_standings (competition_id, rank, user_id, account_id, display_name,
+			equity, pnl, roi, risk_adjusted, trades)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	for _, s := range standings {
+		_, err := tx.ExecContext(ctx, query,
+			id, s.Rank, s.UserID, s.AccountID, s.DisplayName,
+			s.Equity, s.PnL, s.ROI, s.RiskAdjusted, s.Trades,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *CompetitionRepository) GetStandings(ctx context.Context, id domain.CompetitionID) ([]domain.CompetitionStanding, error) {
+	query := `
+		SELECT competition_id, rank, user_id, account_id, display_name,
+			   equity, pnl, roi, risk_adjusted, trades
+		FROM competition_standings
+		WHERE competition_id = $1
+		ORDER BY rank ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var standings []domain.CompetitionStanding
+	for rows.Next() {
+		var s domain.CompetitionStanding
+		var riskAdjusted decimal.NullDecimal
+		err := rows.Scan(
+			&s.CompetitionID, &s.Rank, &s.UserID, &s.AccountID, &s.DisplayName,
+			&s.Equity, &s.PnL, &s.ROI, &riskAdjusted, &s.Trades,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if riskAdjusted.Valid {
+			s.RiskAdjusted = &riskAdjusted.Decimal
+		}
+		standings = append(standings, s)
+	}
+	return standings, rows.Err()
+}
+
+func (r *CompetitionRepository) scanCompetitions(rows *sql.Rows) ([]domain.Competition, error) {
+	var competitions []domain.Competition
+	for rows.Next() {
+		var c domain.Competition
+		err := rows.Scan(
+			&c.ID, &c.Name, &c.Description, &c.StartsAt, &c.EndsAt, pq.Array(&c.AllowedSymbols), &c.MaxLeverage,
+			&c.StartingBalance, &c.RankingMetric, &c.CreatedBy, &c.FinalizedAt, &c.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		competitions = append(competitions, c)
+	}
+	return competitions, rows.Err()
+}
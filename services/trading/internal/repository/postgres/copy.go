@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"trading/internal/domain"
+)
+
+type FollowRepository struct {
+	db *DB
+}
+
+func NewFollowRepository(db *DB) *FollowRepository {
+	return &FollowRepository{db: db}
+}
+
+const followColumns = `
+	f.id, f.follower_user_id, f.follower_account_id, f.leader_user_id, f.leader_account_id, COALESCE(u.display_name, ''),
+	f.mode, f.ratio, f.amount, f.max_leverage, f.max_position_notional, f.max_loss, f.status, f.stop_reason,
+	f.copied_trades, f.realized_pnl, f.created_at, f.updated_at`
+
+// followTable joins the leader's display name
+const followTable = `follows f JOIN users u ON u.id = f.leader_user_id`
+
+func (r *FollowRepository) Create(ctx context.Context, f *domain.Follow) error {
+	query := `
+		INSERT INTO follows (follower_user_id, follower_account_id, leader_user_id, leader_account_id, mode,
+			ratio, amount, max_leverage, max_position_notional, max_loss, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		f.FollowerUserID, f.FollowerAccountID, f.LeaderUserID, f.LeaderAccountID, f.Mode,
+		f.Ratio, f.Amount, f.MaxLeverage, f.MaxPositionNotional, f.MaxLoss, f.Status,
+	).Scan(&f.ID, &f.CreatedAt, &f.UpdatedAt)
+}
+
+func (r *FollowRepository) GetByID(ctx context.Context, id domain.FollowID) (*domain.Follow, error) {
+	query := `SELECT ` + followColumns + ` FROM ` + followTable + ` WHERE f.id = $1`
+
+	f, err := r.scanFollow(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrFollowNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (r *FollowRepository) ListByFollowerAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.Follow, error) {
+	query := `SELECT ` + followColumns + ` FROM ` + followTable + ` WHERE f.follower_account_id = $1 ORDER BY f.id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanFollows(rows)
+}
+
+func (r *FollowRepository) GetActiveByLeaderAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.Follow, error) {
+	query := `SELECT ` + followColumns + ` FROM ` + followTable + ` WHERE f.leader_account_id = $1 AND f.status = 'ACTIVE' ORDER BY f.id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanFollows(rows)
+}
+
+func (r *FollowRepository) CountActiveByLeaderAccountID(ctx context.Context, accountID domain.AccountID) (int, error) {
+	query := `SELECT COUNT(*) FROM follows WHERE leader_account_id = $1 AND status = 'ACTIVE'`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, accountID).Scan(&count)
+	return count, err
+}
+
+func (r *FollowRepository) Update(ctx context.Context, f *domain.Follow) error {
+	query := `
+		UPDATE follows
+		SET status = $1, stop_reason = $2, copied_trades = $3, realized_pnl = $4, updated_at = NOW()
+		WHERE id = $5
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		f.Status, f.StopReason, f.CopiedTrades, f.RealizedPnL, f.ID,
+	).Scan(&f.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrFollowNotFound
+	}
+	return err
+}
+
+func (r *FollowRepository) CreateCopyTrade(ctx context.Context, t *domain.CopyTrade) error {
+	query := `
+		INSERT INTO copy_trades (follow_id, leader_trade_id, leader_trade_type, symbol, side, status, order_id,
+			quantity, price, pnl, error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+		RETURNING id, created_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		t.FollowID, t.LeaderTradeID, t.LeaderTradeType, t.Symbol, t.Side, t.Status, t.OrderID,
+		t.Quantity, t.Price, t.PnL, t.Error,
+	).Scan(&t.ID, &t.CreatedAt)
+}
+
+func (r *FollowRepository) ListCopyTrades(ctx context.Context, followID domain.FollowID, limit int) ([]domain.CopyTrade, error) {
+	query := `
+		SELECT id, follow_id, leader_trade_id, leader_trade_type, symbol, side, status, order_id,
+			quantity, price, pnl, error, created_at
+		FROM copy_trades
+		WHERE follow_id = $1
+		ORDER BY id DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, followID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trades []domain.CopyTrade
+	for rows.Next() {
+		var t domain.CopyTrade
+		err := rows.Scan(
+			&t.ID, &t.FollowID, &t.LeaderTradeID, &t.LeaderTradeType, &t.Symbol, &t.Side, &t.Status, &t.OrderID,
+			&t.Quantity, &t.Price, &t.PnL, &t.Error, &t.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, t)
+	}
+	return trades, rows.Err()
+}
+
+func (r *FollowRepository) scanFollow(row *sql.Row) (*domain.Follow, error) {
+	var f domain.Follow
+	err := row.Scan(
+		&f.ID, &f.FollowerUserID, &f.FollowerAccountID, &f.LeaderUserID, &f.LeaderAccountID, &f.LeaderName,
+		&f.Mode, &f.Ratio, &f.Amount, &f.MaxLeverage, &f.MaxPositionNotional, &f.MaxLoss, &f.Status, &f.StopReason,
+		&f.CopiedTrades, &f.RealizedPnL, &f.CreatedAt, &f.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *FollowRepository) scanFollows(rows *sql.Rows) ([]domain.Follow, error) {
+	var follows []domain.Follow
+	for rows.Next() {
+		var f domain.Follow
+		err := rows.Scan(
+			&f.ID, &f.FollowerUserID, &f.FollowerAccountID, &f.LeaderUserID, &f.LeaderAccountID, &f.LeaderName,
+			&f.Mode, &f.Ratio, &f.Amount, &f.MaxLeverage, &f.MaxPositionNotional, &f.MaxLoss, &f.Status, &f.StopReason,
+			&f.CopiedTrades, &f.RealizedPnL, &f.CreatedAt, &f.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		follows = append(follows, f)
+	}
+	return follows, rows.Err()
+}
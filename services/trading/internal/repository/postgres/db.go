@@ -16,6 +16,11 @@ type DB struct {
 	*sql.DB
 }
 
+// queryRower is a *DB or a *sql.Tx, so a statement can run on its own or inside a transaction
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
 func NewDB(cfg *config.DatabaseConfig) (*DB, error) {
 	db, err := sql.Open("postgres", cfg.DSN())
 	if err != nil {
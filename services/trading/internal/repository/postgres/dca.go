@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"trading/internal/domain"
+)
+
+type DCARepository struct {
+	db *DB
+}
+
+func NewDCARepository(db *DB) *DCARepository {
+	return &DCARepository{db: db}
+}
+
+const dcaPlanColumns = `
+	id, user_id, account_id, symbol, amount, leverage, interval_seconds, next_run_at, missed_runs,
+	take_profit_pct, safety_drop_pct, safety_amount, max_safety_orders, status, quantity, cost,
+	last_buy_price, safety_orders, runs, cycles, realized_pnl, created_at, updated_at`
+
+func (r *DCARepository) Create(ctx context.Context, p *domain.DCAPlan) error {
+	query := `
+		INSERT INTO dca_plans (user_id, account_id, symbol, amount, leverage, interval_seconds, next_run_at,
+			missed_runs, take_profit_pct, safety_drop_pct, safety_amount, max_safety_orders, status,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		p.UserID, p.AccountID, p.Symbol, p.Amount, p.Leverage, int64(p.Interval/time.Second), p.NextRunAt,
+		p.MissedRuns, p.TakeProfitPct, p.SafetyDropPct, p.SafetyAmount, p.MaxSafetyOrders, p.Status,
+	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+}
+
+func (r *DCARepository) GetByID(ctx context.Context, id domain.DCAPlanID) (*domain.DCAPlan, error) {
+	query := `SELECT ` + dcaPlanColumns + ` FROM dca_plans WHERE id = $1`
+
+	p, err := r.scanPlan(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrDCAPlanNotFound
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *DCARepository) ListByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.DCAPlan, error) {
+	query := `SELECT ` + dcaPlanColumns + ` FROM dca_plans WHERE account_id = $1 ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPlans(rows)
+}
+
+func (r *DCARepository) GetActive(ctx context.Context) ([]domain.DCAPlan, error) {
+	query := `SELECT ` + dcaPlanColumns + ` FROM dca_plans WHERE status = 'ACTIVE' ORDER BY next_run_at ASC, id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPlans(rows)
+}
+
+func (r *DCARepository) Update(ctx context.Context, p *domain.DCAPlan) error {
+	return updateDCAPlan(ctx, r.db, p)
+}
+
+// Claim saves the plan and creates the executions in one transaction
+func (r *DCARepository) Claim(ctx context.Context, p *domain.DCAPlan, executions []*domain.DCAExecution) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateDCAPlan(ctx, tx, p); err != nil {
+		return err
+	}
+	for _, e := range executions {
+		if err := createDCAExecution(ctx, tx, e); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *DCARepository) UpdateExecution(ctx context.Context, e *domain.DCAExecution) error {
+	query := `
+		UPDATE dca_executions
+		SET status = $1, order_id = $2, quantity = $3, price = $4, error = $5
+		WHERE id = $6`
+
+	_, err := r.db.ExecContext(ctx, query, e.Status, e.OrderID, e.Quantity, e.Price, e.Error, e.ID)
+	return err
+}
+
+func updateDCAPlan(ctx context.Context, q queryRower, p *domain.DCAPlan) error {
+	query := `
+		UPDATE dca_plans
+		SET next_run_at = $1, status = $2, quantity = $3, cost = $4, last_buy_price = $5,
+			safety_orders = $6, runs = $7, cycles = $8, realized_pnl = $9, updated_at = NOW()
+		WHERE id = $10
+		RETURNING updated_at`
+
+	err := q.QueryRowContext(ctx, query,
+		p.NextRunAt, p.Status, p.Quantity, p.Cost, p.LastBuyPrice,
+		p.SafetyOrders, p.Runs, p.Cycles, p.RealizedPnL, p.ID,
+	).Scan(&p.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrDCAPlanNotFound
+	}
+	return err
+}
+
+func createDCAExecution(ctx context.Context, q queryRower, e *domain.DCAExecution) error {
+	query := `
+		INSERT INTO dca_executions (plan_id, kind, status, run_at, order_id, quantity, price, error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING id, created_at`
+
+	return q.QueryRowContext(ctx, query,
+		e.PlanID, e.Kind, e.Status, e.RunAt, e.OrderID, e.Quantity, e.Price, e.Error,
+	).Scan(&e.ID, &e.CreatedAt)
+}
+
+func (r *DCARepository) ListExecutions(ctx context.Context, planID domain.DCAPlanID, limit int) ([]domain.DCAExecution, error) {
+	query := `
+		SELECT id, plan_id, kind, status, run_at, order_id, quantity, price, error, created_at
+		FROM dca_executions
+		WHERE plan_id = $1
+		ORDER BY id DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, planID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var executions []domain.DCAExecution
+	for rows.Next() {
+		var e domain.DCAExecution
+		err := rows.Scan(&e.ID, &e.PlanID, &e.Kind, &e.Status, &e.RunAt, &e.OrderID, &e.Quantity, &e.Price, &e.Error, &e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		executions = append(executions, e)
+	}
+	return executions, rows.Err()
+}
+
+func (r *DCARepository) scanPlan(row *sql.Row) (*domain.DCAPlan, error) {
+	var p domain.DCAPlan
+	var intervalSeconds int64
+	err := row.Scan(
+		&p.ID, &p.UserID, &p.AccountID, &p.Symbol, &p.Amount, &p.Leverage, &intervalSeconds, &p.NextRunAt, &p.MissedRuns,
+		&p.TakeProfitPct, &p.SafetyDropPct, &p.SafetyAmount, &p.MaxSafetyOrders, &p.Status, &p.Quantity, &p.Cost,
+		&p.LastBuyPrice, &p.SafetyOrders, &p.Runs, &p.Cycles, &p.RealizedPnL, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.Interval = time.Duration(intervalSeconds) * time.Second
+	return &p, nil
+}
+
+func (r *DCARepository) scanPlans(rows *sql.Rows) ([]domain.DCAPlan, error) {
+	var plans []domain.DCAPlan
+	for rows.Next() {
+		var p domain.DCAPlan
+		var intervalSeconds int64
+		err := rows.Scan(
+			&p.ID, &p.UserID, &p.AccountID, &p.Symbol, &p.Amount, &p.Leverage, &intervalSeconds, &p.NextRunAt, &p.MissedRuns,
+			&p.TakeProfitPct, &p.SafetyDropPct, &p.SafetyAmount, &p.MaxSafetyOrders, &p.Status, &p.Quantity, &p.Cost,
+			&p.LastBuyPrice, &p.SafetyOrders, &p.Runs, &p.Cycles, &p.RealizedPnL, &p.CreatedAt, &p.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		p.Interval = time.Duration(intervalSeconds) * time.Second
+		plans = append(plans, p)
+	}
+	return plans, rows.Err()
+}
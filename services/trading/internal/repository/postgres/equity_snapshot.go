@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"trading/internal/domain"
+)
+
+type EquitySnapshotRepository struct {
+	db *DB
+}
+
+func NewEquitySnapshotRepository(db *DB) *EquitySnapshotRepository {
+	return &EquitySnapshotRepository{db: db}
+}
+
+// Record upserts the minute snapshot together with its hourly and daily rollups
+// in one statement. Rollups take the latest values and widen the equity range.
+func (r *EquitySnapshotRepository) Record(ctx context.Context, snapshot *domain.EquitySnapshot) error {
+	query := `
+		INSERT INTO equity_snapshots (account_id, resolution, bucket_time, balance, equity,
+			equity_low, equity_high, unrealized_pnl, used_margin)
+		VALUES
+			($1, '1m', $2, $5, $6, $7, $8, $9, $10),
+			($1, '1h', $3, $5, $6, $7, $8, $9, $10),
+			($1, '1d', $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (account_id, resolution, bucket_time) DO UPDATE SET
+			balance = EXCLUDED.balance,
+			equity = EXCLUDED.equity,
+			equity_low = LEAST(equity_snapshots.equity_low, EXCLUDED.equity_low),
+			equity_high = GREATEST(equity_snapshots.equity_high, EXCLUDED.equity_high),
+			unrealized_pnl = EXCLUDED.unrealized_pnl,
+			used_margin = EXCLUDED.used_margin`
+
+	_, err := r.db.ExecContext(ctx, query,
+		snapshot.AccountID,
+		domain.EquityResolutionMinute.Bucket(snapshot.Time),
+		domain.EquityResolutionHour.Bucket(snapshot.Time),
+		domain.EquityResolutionDay.Bucket(snapshot.Time),
+		snapshot.Balance,
+		snapshot.Equity,
+		snapshot.EquityLow,
+		snapshot.EquityHigh,
+		snapshot.UnrealizedPnL,
+		snapshot.UsedMargin,
+	)
+	return err
+}
+
+func (r *EquitySnapshotRepository) GetByAccountID(
+	ctx context.Context,
+	accountID domain.AccountID,
+	resolution domain.EquityResolution,
+	from, to time.Time,
+) ([]domain.EquitySnapshot, error) {
+	query := `
+		SELECT account_id, resolution, bucket_time, balance, equity, equity_low, equity_high,
+			   unrealized_pnl, used_margin
+		FROM equity_snapshots
+		WHERE account_id = $1 AND resolution = $2 AND bucket_time >= $3 AND bucket_time <= $4
+		ORDER BY bucket_time ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, resolution, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanSnapshots(rows)
+}
+
+func (r *EquitySnapshotRepository) DeleteBefore(ctx context.Context, resolution domain.EquityResolution, before time.Time) (int64, error) {
+	query := `DELETE FROM equity_snapshots WHERE resolution = $1 AND bucket_time < $2`
+
+	result, err := r.db.ExecContext(ctx, query, resolution, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *EquitySnapshotRepository) scanSnapshots(rows *sql.Rows) ([]domain.EquitySnapshot, error) {
+	var snapshots []domain.EquitySnapshot
+	for rows.Next() {
+		var s domain.EquitySnapshot
+		err := rows.Scan(
+			&s.AccountID, &s.Resolution, &s.Time, &s.Balance, &s.Equity, &s.EquityLow, &s.EquityHigh,
+			&s.UnrealizedPnL, &s.UsedMargin,
+		)
+		if err != nil {
+			return nil, err
+		}
+		s.Time = s.Time.UTC()
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, rows.Err()
+}
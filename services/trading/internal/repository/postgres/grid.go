@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"trading/internal/domain"
+)
+
+type GridBotRepository struct {
+	db *DB
+}
+
+func NewGridBotRepository(db *DB) *GridBotRepository {
+	return &GridBotRepository{db: db}
+}
+
+const gridBotColumns = `
+	id, user_id, account_id, symbol, lower_price, upper_price, grids, investment, leverage, quantity,
+	status, buy_fills, sell_fills, rounds, grid_profit, position, cost, fees, created_at, updated_at, stopped_at`
+
+func (r *GridBotRepository) Create(ctx context.Context, b *domain.GridBot) error {
+	query := `
+		INSERT INTO grid_bots (user_id, account_id, symbol, lower_price, upper_price, grids, investment,
+			leverage, quantity, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		b.UserID, b.AccountID, b.Symbol, b.LowerPrice, b.UpperPrice, b.Grids, b.Investment,
+		b.Leverage, b.Quantity, b.Status,
+	).Scan(&b.ID, &b.CreatedAt, &b.UpdatedAt)
+}
+
+func (r *GridBotRepository) GetByID(ctx context.Context, id domain.GridBotID) (*domain.GridBot, error) {
+	query := `SELECT ` + gridBotColumns + ` FROM grid_bots WHERE id = $1`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bots, err := r.scanBots(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(bots) == 0 {
+		return nil, domain.ErrGridBotNotFound
+	}
+	return &bots[0], nil
+}
+
+func (r *GridBotRepository) ListByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.GridBot, error) {
+	query := `SELECT ` + gridBotColumns + ` FROM grid_bots WHERE account_id = $1 ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanBots(ctx, rows)
+}
+
+func (r *GridBotRepository) GetActive(ctx context.Context) ([]domain.GridBot, error) {
+	query := `SELECT ` + gridBotColumns + ` FROM grid_bots WHERE status = 'ACTIVE' ORDER BY id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanBots(ctx, rows)
+}
+
+// Update saves the bot row and replaces its resting orders in one transaction
+func (r *GridBotRepository) Update(ctx context.Context, b *domain.GridBot) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE grid_bots
+		SET status = $1, buy_fills = $2, sell_fills = $3, rounds = $4, grid_profit = $5,
+			position = $6, cost = $7, fees = $8, stopped_at = $9, updated_at = NOW()
+		WHERE id = $10
+		RETURNING updated_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		b.Status, b.BuyFills, b.SellFills, b.Rounds, b.GridProfit,
+		b.Position, b.Cost, b.Fees, b.StoppedAt, b.ID,
+	).Scan(&b.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrGridBotNotFound
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM grid_bot_orders WHERE bot_id = $1`, b.ID); err != nil {
+		return err
+	}
+	for _, o := range b.Orders {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO grid_bot_orders (bot_id, level, side, order_id) VALUES ($1, $2, $3, $4)`,
+			b.ID, o.Level, o.Side, o.OrderID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// scanBots reads bot rows and attaches their resting orders
+func (r *GridBotRepository) scanBots(ctx context.Context, rows *sql.Rows) ([]domain.GridBot, error) {
+	var bots []domain.GridBot
+	for rows.Next() {
+		var b domain.GridBot
+		err := rows.Scan(
+			&b.ID, &b.UserID, &b.AccountID, &b.Symbol, &b.LowerPrice, &b.UpperPrice, &b.Grids, &b.Investment,
+			&b.Leverage, &b.Quantity, &b.Status, &b.BuyFills, &b.SellFills, &b.Rounds, &b.GridProfit,
+			&b.Position, &b.Cost, &b.Fees, &b.CreatedAt, &b.UpdatedAt, &b.StoppedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		bots = append(bots, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(bots) == 0 {
+		return bots, nil
+	}
+
+	ids := make([]int64, len(bots))
+	index := make(map[domain.GridBotID]int, len(bots))
+	for i := range bots {
+		ids[i] = int64(bots[i].ID)
+		index[bots[i].ID] = i
+	}
+
+	orderRows, err := r.db.QueryContext(ctx, `
+		SELECT bot_id, level, side, order_id
+		FROM grid_bot_orders
+		WHERE bot_id = ANY($1)
+		ORDER BY bot_id, level`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer orderRows.Close()
+
+	for orderRows.Next() {
+		var botID domain.GridBotID
+		var o domain.GridOrder
+		if err := orderRows.Scan(&botID, &o.Level, &o.Side, &o.OrderID); err != nil {
+			return nil, err
+		}
+		b := &bots[index[botID]]
+		b.Orders = append(b.Orders, o)
+	}
+	return bots, orderRows.Err()
+}
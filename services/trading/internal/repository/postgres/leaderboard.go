@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"trading/internal/domain"
+)
+
+type LeaderboardRepository struct {
+	db *DB
+}
+
+func NewLeaderboardRepository(db *DB) *LeaderboardRepository {
+	return &LeaderboardRepository{db: db}
+}
+
+// GetPerformance aggregates the closing trades made since from (all time if
+// nil) on the primary accounts of users who opted in to leaderboards
+func (r *LeaderboardRepository) GetPerformance(ctx context.Context, from *time.Time) ([]domain.TraderPerformance, error) {
+	query := `
+		SELECT u.id, a.id, u.display_name, a.season_starting_balance,
+			   COUNT(*),
+			   COUNT(*) FILTER (WHERE t.pnl - t.fee > 0),
+			   SUM(t.pnl - t.fee),
+			   COALESCE(STDDEV_SAMP(t.pnl - t.fee), 0)
+		FROM trades t
+		JOIN accounts a ON a.id = t.account_id AND a.name = $1
+		JOIN users u ON u.id = a.user_id
+		WHERE u.leaderboard_opt_in AND u.display_name IS NOT NULL
+		  AND t.type IN ('CLOSE', 'LIQUIDATE', 'SPOT_SELL')
+		  AND ($2::timestamptz IS NULL OR t.created_at >= $2)
+		GROUP BY u.id, a.id, u.display_name, a.season_starting_balance`
+
+	rows, err := r.db.QueryContext(ctx, query, domain.PrimaryAccountName, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPerformance(rows)
+}
+
+func (r *LeaderboardRepository) scanPerformance(rows *sql.Rows) ([]domain.TraderPerformance, error) {
+	var result []domain.TraderPerformance
+	for rows.Next() {
+		var p domain.TraderPerformance
+		err := rows.Scan(
+			&p.UserID, &p.AccountID, &p.DisplayName, &p.StartingBalance,
+			&p.Trades, &p.Wins, &p.RealizedPnL, &p.PnLStdDev,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, rows.Err()
+}
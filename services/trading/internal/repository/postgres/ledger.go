@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+type LedgerRepository struct {
+	db *DB
+}
+
+func NewLedgerRepository(db *DB) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// Post applies entry.Amount to the account balance of entry.Asset and appends the entry
+// in a single transaction. The account row is locked so concurrent postings
+// always observe each other's balance_after.
+func (r *LedgerRepository) Post(ctx context.Context, entry *domain.LedgerEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := postEntry(ctx, tx, entry); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// PostBatch posts several entries in one transaction. Entries are applied in
+// account id order so concurrent batches over the same accounts cannot deadlock.
+func (r *LedgerRepository) PostBatch(ctx context.Context, entries ...*domain.LedgerEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	ordered := make([]*domain.LedgerEntry, len(entries))
+	copy(ordered, entries)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].AccountID < ordered[j].AccountID
+	})
+
+	for _, entry := range ordered {
+		if err := postEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// postEntry applies one entry inside the caller's transaction
+func postEntry(ctx context.Context, tx *sql.Tx, entry *domain.LedgerEntry) error {
+	if entry.Asset == "" {
+		entry.Asset = domain.QuoteAsset
+	}
+
+	// The account row is locked for every asset, it serializes all postings of the account
+	var balance decimal.Decimal
+	err := tx.QueryRowContext(ctx, `SELECT balance FROM accounts WHERE id = $1 FOR UPDATE`, entry.AccountID).
+		Scan(&balance)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrAccountNotFound
+		}
+		return err
+	}
+
+	if entry.Asset != domain.QuoteAsset {
+		err := tx.QueryRowContext(ctx,
+			`SELECT amount FROM account_assets WHERE account_id = $1 AND asset = $2`,
+			entry.AccountID, entry.Asset,
+		).Scan(&balance)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			balance = decimal.Zero
+		}
+	}
+
+	newBalance := balance.Add(entry.Amount)
+	if newBalance.IsNegative() && (entry.Asset != domain.QuoteAsset || !entry.Type.IsSettlement()) {
+		return domain.ErrInsufficientBalance
+	}
+
+	if entry.Asset == domain.QuoteAsset {
+		_, err = tx.ExecContext(ctx, `UPDATE accounts SET balance = $1 WHERE id = $2`, newBalance, entry.AccountID)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO account_assets (account_id, asset, amount, updated_at)
+			VALUES ($1, $2, $3, NOW())
+			ON CONFLICT (account_id, asset) DO UPDATE SET amount = EXCLUDED.amount, updated_at = NOW()`,
+			entry.AccountID, entry.Asset, newBalance,
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO ledger_entries (
+			account_id, type, asset, amount, balance_after, trade_id, position_id, description, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING id, created_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		entry.AccountID, entry.Type, entry.Asset, entry.Amount, newBalance,
+		entry.TradeID, entry.PositionID, entry.Description,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	entry.BalanceAfter = newBalance
+	return nil
+}
+
+func (r *LedgerRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID, filter domain.LedgerFilter) ([]domain.LedgerEntry, error) {
+	conditions := []string{"account_id = $1"}
+	args := []interface{}{accountID}
+
+	if len(filter.Types) > 0 {
+		placeholders := make([]string, len(filter.Types))
+		for i, t := range filter.Types {
+			args = append(args, t)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "type IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.Asset != "" {
+		args = append(args, filter.Asset)
+		conditions = append(conditions, fmt.Sprintf("asset = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT id, account_id, type, asset, amount, balance_after, trade_id, position_id, description, created_at
+		FROM ledger_entries
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`,
+		strings.Join(conditions, " AND "), len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []domain.LedgerEntry
+	for rows.Next() {
+		var e domain.LedgerEntry
+		err := rows.Scan(
+			&e.ID, &e.AccountID, &e.Type, &e.Asset, &e.Amount, &e.BalanceAfter,
+			&e.TradeID, &e.PositionID, &e.Description, &e.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"trading/internal/domain"
+)
+
+type NotificationRepository struct {
+	db *DB
+}
+
+func NewNotificationRepository(db *DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, n *domain.Notification) error {
+	query := `
+		INSERT INTO notifications (user_id, type, title, message, alert_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, created_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		n.UserID, n.Type, n.Title, n.Message, n.AlertID,
+	).Scan(&n.ID, &n.CreatedAt)
+}
+
+func (r *NotificationRepository) GetByUserID(ctx context.Context, userID domain.UserID, unreadOnly bool, limit, offset int) ([]domain.Notification, error) {
+	query := `
+		SELECT id, user_id, type, title, message, alert_id, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY id DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanNotifications(rows)
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID domain.UserID) (int, error) {
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID domain.UserID, id domain.NotificationID) error {
+	query := `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID domain.UserID) error {
+	query := `UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+func (r *NotificationRepository) scanNotifications(rows *sql.Rows) ([]domain.Notification, error) {
+	var notifications []domain.Notification
+	for rows.Next() {
+		var n domain.Notification
+		err := rows.Scan(
+			&n.ID, &n.UserID, &n.Type, &n.Title, &n.Message, &n.AlertID, &n.ReadAt, &n.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
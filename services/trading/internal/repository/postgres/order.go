@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"trading/internal/domain"
 )
@@ -18,28 +21,28 @@ func NewOrderRepository(db *DB) *OrderRepository {
 
 func (r *OrderRepository) Create(ctx context.Context, order *domain.Order) error {
 	query := `
-		INSERT INTO orders (user_id, symbol, side, type, status, quantity, price, leverage, stop_loss, take_profit, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW())
+		INSERT INTO orders (user_id, account_id, symbol, side, type, status, quantity, price, leverage, stop_loss, take_profit, parent_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), NOW())
 		RETURNING id, created_at, updated_at`
 
 	return r.db.QueryRowContext(ctx, query,
-		order.UserID, order.Symbol, order.Side, order.Type, order.Status,
-		order.Quantity, order.Price, order.Leverage, order.StopLoss, order.TakeProfit,
+		order.UserID, order.AccountID, order.Symbol, order.Side, order.Type, order.Status,
+		order.Quantity, order.Price, order.Leverage, order.StopLoss, order.TakeProfit, order.ParentID,
 	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
 }
 
 func (r *OrderRepository) GetByID(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
 	query := `
-		SELECT id, user_id, symbol, side, type, status, quantity, price, leverage,
-			   stop_loss, take_profit, filled_at, created_at, updated_at
+		SELECT id, user_id, account_id, symbol, side, type, status, quantity, price, leverage,
+			   stop_loss, take_profit, parent_id, filled_at, created_at, updated_at
 		FROM orders
 		WHERE id = $1`
 
 	order := &domain.Order{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&order.ID, &order.UserID, &order.Symbol, &order.Side, &order.Type,
+		&order.ID, &order.UserID, &order.AccountID, &order.Symbol, &order.Side, &order.Type,
 		&order.Status, &order.Quantity, &order.Price, &order.Leverage,
-		&order.StopLoss, &order.TakeProfit, &order.FilledAt,
+		&order.StopLoss, &order.TakeProfit, &order.ParentID, &order.FilledAt,
 		&order.CreatedAt, &order.UpdatedAt,
 	)
 	if err != nil {
@@ -51,16 +54,74 @@ func (r *OrderRepository) GetByID(ctx context.Context, id domain.OrderID) (*doma
 	return order, nil
 }
 
-func (r *OrderRepository) GetByUserID(ctx context.Context, userID domain.UserID, limit, offset int) ([]domain.Order, error) {
+func (r *OrderRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID, filter domain.OrderFilter) ([]domain.Order, error) {
+	conditions := []string{"account_id = $1"}
+	args := []interface{}{accountID}
+
+	if filter.Symbol != "" {
+		args = append(args, filter.Symbol)
+		conditions = append(conditions, fmt.Sprintf("symbol = $%d", len(args)))
+	}
+	if filter.Side != "" {
+		args = append(args, filter.Side)
+		conditions = append(conditions, fmt.Sprintf("side = $%d", len(args)))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.ParentID != nil {
+		args = append(args, *filter.ParentID)
+		conditions = append(conditions, fmt.Sprintf("parent_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.Time, filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT id, user_id, account_id, symbol, side, type, status, quantity, price, leverage,
+			   stop_loss, take_profit, parent_id, filled_at, created_at, updated_at
+		FROM orders
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`,
+		strings.Join(conditions, " AND "), len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanOrders(rows)
+}
+
+func (r *OrderRepository) GetByAccountIDAfter(ctx context.Context, accountID domain.AccountID, from, to *time.Time, afterID domain.OrderID, limit int) ([]domain.Order, error) {
 	query := `
-		SELECT id, user_id, symbol, side, type, status, quantity, price, leverage,
-			   stop_loss, take_profit, filled_at, created_at, updated_at
+		SELECT id, user_id, account_id, symbol, side, type, status, quantity, price, leverage,
+			   stop_loss, take_profit, parent_id, filled_at, created_at, updated_at
 		FROM orders
-		WHERE user_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3`
+		WHERE account_id = $1 AND id > $2
+		  AND ($3::timestamptz IS NULL OR created_at >= $3)
+		  AND ($4::timestamptz IS NULL OR created_at < $4)
+		ORDER BY id ASC
+		LIMIT $5`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, accountID, afterID, from, to, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -69,15 +130,15 @@ func (r *OrderRepository) GetByUserID(ctx context.Context, userID domain.UserID,
 	return r.scanOrders(rows)
 }
 
-func (r *OrderRepository) GetPendingByUserID(ctx context.Context, userID domain.UserID) ([]domain.Order, error) {
+func (r *OrderRepository) GetPendingByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.Order, error) {
 	query := `
-		SELECT id, user_id, symbol, side, type, status, quantity, price, leverage,
-			   stop_loss, take_profit, filled_at, created_at, updated_at
+		SELECT id, user_id, account_id, symbol, side, type, status, quantity, price, leverage,
+			   stop_loss, take_profit, parent_id, filled_at, created_at, updated_at
 		FROM orders
-		WHERE user_id = $1 AND status = 'PENDING'
+		WHERE account_id = $1 AND status = 'PENDING'
 		ORDER BY created_at ASC`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.QueryContext(ctx, query, accountID)
 	if err != nil {
 		return nil, err
 	}
@@ -88,8 +149,8 @@ func (r *OrderRepository) GetPendingByUserID(ctx context.Context, userID domain.
 
 func (r *OrderRepository) GetPendingBySymbol(ctx context.Context, symbol string) ([]domain.Order, error) {
 	query := `
-		SELECT id, user_id, symbol, side, type, status, quantity, price, leverage,
-			   stop_loss, take_profit, filled_at, created_at, updated_at
+		SELECT id, user_id, account_id, symbol, side, type, status, quantity, price, leverage,
+			   stop_loss, take_profit, parent_id, filled_at, created_at, updated_at
 		FROM orders
 		WHERE symbol = $1 AND status = 'PENDING'
 		ORDER BY created_at ASC`
@@ -103,29 +164,37 @@ func (r *OrderRepository) GetPendingBySymbol(ctx context.Context, symbol string)
 	return r.scanOrders(rows)
 }
 
+// Update saves a pending order. The status check makes filling, cancelling
+// and amending claim the order: of two racing updates only the first applies.
 func (r *OrderRepository) Update(ctx context.Context, order *domain.Order) error {
+	err := updateOrder(ctx, r.db, order)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM orders WHERE id = $1)`, order.ID).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrOrderNotPending
+	}
+	return domain.ErrOrderNotFound
+}
+
+// updateOrder saves the order if it is still pending; sql.ErrNoRows otherwise
+func updateOrder(ctx context.Context, q queryRower, order *domain.Order) error {
 	query := `
 		UPDATE orders
 		SET status = $1, filled_at = $2, quantity = $3, price = $4,
 		    stop_loss = $5, take_profit = $6, updated_at = NOW()
-		WHERE id = $7`
+		WHERE id = $7 AND status = 'PENDING'
+		RETURNING updated_at`
 
-	result, err := r.db.ExecContext(ctx, query,
+	return q.QueryRowContext(ctx, query,
 		order.Status, order.FilledAt, order.Quantity, order.Price,
 		order.StopLoss, order.TakeProfit, order.ID,
-	)
-	if err != nil {
-		return err
-	}
-
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rows == 0 {
-		return domain.ErrOrderNotFound
-	}
-	return nil
+	).Scan(&order.UpdatedAt)
 }
 
 func (r *OrderRepository) Delete(ctx context.Context, id domain.OrderID) error {
@@ -151,9 +220,9 @@ func (r *OrderRepository) scanOrders(rows *sql.Rows) ([]domain.Order, error) {
 	for rows.Next() {
 		var order domain.Order
 		err := rows.Scan(
-			&order.ID, &order.UserID, &order.Symbol, &order.Side, &order.Type,
+			&order.ID, &order.UserID, &order.AccountID, &order.Symbol, &order.Side, &order.Type,
 			&order.Status, &order.Quantity, &order.Price, &order.Leverage,
-			&order.StopLoss, &order.TakeProfit, &order.FilledAt,
+			&order.StopLoss, &order.TakeProfit, &order.ParentID, &order.FilledAt,
 			&order.CreatedAt, &order.UpdatedAt,
 		)
 		if err != nil {
@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/shopspring/decimal"
 
@@ -21,15 +24,15 @@ func NewPositionRepository(db *DB) *PositionRepository {
 func (r *PositionRepository) Create(ctx context.Context, position *domain.Position) error {
 	query := `
 		INSERT INTO positions (
-			user_id, symbol, side, status, quantity, entry_price, leverage,
+			user_id, account_id, symbol, side, status, quantity, entry_price, leverage,
 			initial_margin, mark_price, unrealized_pnl, realized_pnl,
 			liquidation_price, stop_loss, take_profit, sl_close_percent, tp_close_percent,
 			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, NOW(), NOW())
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, NOW(), NOW())
 		RETURNING id, created_at, updated_at`
 
 	return r.db.QueryRowContext(ctx, query,
-		position.UserID, position.Symbol, position.Side, position.Status,
+		position.UserID, position.AccountID, position.Symbol, position.Side, position.Status,
 		position.Quantity, position.EntryPrice, position.Leverage,
 		position.InitialMargin, position.MarkPrice, position.UnrealizedPnL,
 		position.RealizedPnL, position.LiquidationPrice,
@@ -40,7 +43,7 @@ func (r *PositionRepository) Create(ctx context.Context, position *domain.Positi
 
 func (r *PositionRepository) GetByID(ctx context.Context, id domain.PositionID) (*domain.Position, error) {
 	query := `
-		SELECT id, user_id, symbol, side, status, quantity, entry_price, leverage,
+		SELECT id, user_id, account_id, symbol, side, status, quantity, entry_price, leverage,
 			   initial_margin, mark_price, unrealized_pnl, realized_pnl,
 			   liquidation_price, stop_loss, take_profit, sl_close_percent, tp_close_percent,
 			   created_at, updated_at, closed_at
@@ -49,7 +52,7 @@ func (r *PositionRepository) GetByID(ctx context.Context, id domain.PositionID)
 
 	position := &domain.Position{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&position.ID, &position.UserID, &position.Symbol, &position.Side,
+		&position.ID, &position.UserID, &position.AccountID, &position.Symbol, &position.Side,
 		&position.Status, &position.Quantity, &position.EntryPrice, &position.Leverage,
 		&position.InitialMargin, &position.MarkPrice, &position.UnrealizedPnL,
 		&position.RealizedPnL, &position.LiquidationPrice,
@@ -66,17 +69,17 @@ func (r *PositionRepository) GetByID(ctx context.Context, id domain.PositionID)
 	return position, nil
 }
 
-func (r *PositionRepository) GetByUserID(ctx context.Context, userID domain.UserID) ([]domain.Position, error) {
+func (r *PositionRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.Position, error) {
 	query := `
-		SELECT id, user_id, symbol, side, status, quantity, entry_price, leverage,
+		SELECT id, user_id, account_id, symbol, side, status, quantity, entry_price, leverage,
 			   initial_margin, mark_price, unrealized_pnl, realized_pnl,
 			   liquidation_price, stop_loss, take_profit, sl_close_percent, tp_close_percent,
 			   created_at, updated_at, closed_at
 		FROM positions
-		WHERE user_id = $1
+		WHERE account_id = $1
 		ORDER BY created_at DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.QueryContext(ctx, query, accountID)
 	if err != nil {
 		return nil, err
 	}
@@ -85,17 +88,17 @@ func (r *PositionRepository) GetByUserID(ctx context.Context, userID domain.User
 	return r.scanPositions(rows)
 }
 
-func (r *PositionRepository) GetOpenByUserID(ctx context.Context, userID domain.UserID) ([]domain.Position, error) {
+func (r *PositionRepository) GetOpenByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.Position, error) {
 	query := `
-		SELECT id, user_id, symbol, side, status, quantity, entry_price, leverage,
+		SELECT id, user_id, account_id, symbol, side, status, quantity, entry_price, leverage,
 			   initial_margin, mark_price, unrealized_pnl, realized_pnl,
 			   liquidation_price, stop_loss, take_profit, sl_close_percent, tp_close_percent,
 			   created_at, updated_at, closed_at
 		FROM positions
-		WHERE user_id = $1 AND status = 'OPEN'
+		WHERE account_id = $1 AND status = 'OPEN'
 		ORDER BY created_at DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.QueryContext(ctx, query, accountID)
 	if err != nil {
 		return nil, err
 	}
@@ -104,18 +107,18 @@ func (r *PositionRepository) GetOpenByUserID(ctx context.Context, userID domain.
 	return r.scanPositions(rows)
 }
 
-func (r *PositionRepository) GetOpenByUserIDAndSymbol(ctx context.Context, userID domain.UserID, symbol string) (*domain.Position, error) {
+func (r *PositionRepository) GetOpenByAccountIDAndSymbol(ctx context.Context, accountID domain.AccountID, symbol string) (*domain.Position, error) {
 	query := `
-		SELECT id, user_id, symbol, side, status, quantity, entry_price, leverage,
+		SELECT id, user_id, account_id, symbol, side, status, quantity, entry_price, leverage,
 			   initial_margin, mark_price, unrealized_pnl, realized_pnl,
 			   liquidation_price, stop_loss, take_profit, sl_close_percent, tp_close_percent,
 			   created_at, updated_at, closed_at
 		FROM positions
-		WHERE user_id = $1 AND symbol = $2 AND status = 'OPEN'`
+		WHERE account_id = $1 AND symbol = $2 AND status = 'OPEN'`
 
 	position := &domain.Position{}
-	err := r.db.QueryRowContext(ctx, query, userID, symbol).Scan(
-		&position.ID, &position.UserID, &position.Symbol, &position.Side,
+	err := r.db.QueryRowContext(ctx, query, accountID, symbol).Scan(
+		&position.ID, &position.UserID, &position.AccountID, &position.Symbol, &position.Side,
 		&position.Status, &position.Quantity, &position.EntryPrice, &position.Leverage,
 		&position.InitialMargin, &position.MarkPrice, &position.UnrealizedPnL,
 		&position.RealizedPnL, &position.LiquidationPrice,
@@ -134,7 +137,7 @@ func (r *PositionRepository) GetOpenByUserIDAndSymbol(ctx context.Context, userI
 
 func (r *PositionRepository) GetAllOpen(ctx context.Context) ([]domain.Position, error) {
 	query := `
-		SELECT id, user_id, symbol, side, status, quantity, entry_price, leverage,
+		SELECT id, user_id, account_id, symbol, side, status, quantity, entry_price, leverage,
 			   initial_margin, mark_price, unrealized_pnl, realized_pnl,
 			   liquidation_price, stop_loss, take_profit, sl_close_percent, tp_close_percent,
 			   created_at, updated_at, closed_at
@@ -152,7 +155,7 @@ func (r *PositionRepository) GetAllOpen(ctx context.Context) ([]domain.Position,
 
 func (r *PositionRepository) GetOpenBySymbol(ctx context.Context, symbol string) ([]domain.Position, error) {
 	query := `
-		SELECT id, user_id, symbol, side, status, quantity, entry_price, leverage,
+		SELECT id, user_id, account_id, symbol, side, status, quantity, entry_price, leverage,
 			   initial_margin, mark_price, unrealized_pnl, realized_pnl,
 			   liquidation_price, stop_loss, take_profit, sl_close_percent, tp_close_percent,
 			   created_at, updated_at, closed_at
@@ -168,6 +171,99 @@ func (r *PositionRepository) GetOpenBySymbol(ctx context.Context, symbol string)
 	return r.scanPositions(rows)
 }
 
+func (r *PositionRepository) GetClosedByAccountID(ctx context.Context, accountID domain.AccountID, from, to *time.Time) ([]domain.Position, error) {
+	query := `
+		SELECT id, user_id, account_id, symbol, side, status, quantity, entry_price, leverage,
+			   initial_margin, mark_price, unrealized_pnl, realized_pnl,
+			   liquidation_price, stop_loss, take_profit, sl_close_percent, tp_close_percent,
+			   created_at, updated_at, closed_at
+		FROM positions
+		WHERE account_id = $1 AND status != 'OPEN' AND closed_at IS NOT NULL
+		  AND ($2::timestamptz IS NULL OR closed_at >= $2)
+		  AND ($3::timestamptz IS NULL OR closed_at < $3)
+		ORDER BY closed_at ASC, id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPositions(rows)
+}
+
+func (r *PositionRepository) GetByAccountIDAfter(ctx context.Context, accountID domain.AccountID, from, to *time.Time, afterID domain.PositionID, limit int) ([]domain.Position, error) {
+	query := `
+		SELECT id, user_id, account_id, symbol, side, status, quantity, entry_price, leverage,
+			   initial_margin, mark_price, unrealized_pnl, realized_pnl,
+			   liquidation_price, stop_loss, take_profit, sl_close_percent, tp_close_percent,
+			   created_at, updated_at, closed_at
+		FROM positions
+		WHERE account_id = $1 AND id > $2
+		  AND ($3::timestamptz IS NULL OR created_at >= $3)
+		  AND ($4::timestamptz IS NULL OR created_at < $4)
+		ORDER BY id ASC
+		LIMIT $5`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, afterID, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPositions(rows)
+}
+
+func (r *PositionRepository) GetHistory(ctx context.Context, accountID domain.AccountID, filter domain.PositionHistoryFilter) ([]domain.Position, error) {
+	conditions := []string{"account_id = $1", "status != 'OPEN'", "closed_at IS NOT NULL"}
+	args := []interface{}{accountID}
+
+	if filter.Symbol != "" {
+		args = append(args, filter.Symbol)
+		conditions = append(conditions, fmt.Sprintf("symbol = $%d", len(args)))
+	}
+	if filter.Side != "" {
+		args = append(args, filter.Side)
+		conditions = append(conditions, fmt.Sprintf("side = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("closed_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("closed_at < $%d", len(args)))
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.Time, filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(closed_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`
+		SELECT id, user_id, account_id, symbol, side, status, quantity, entry_price, leverage,
+			   initial_margin, mark_price, unrealized_pnl, realized_pnl,
+			   liquidation_price, stop_loss, take_profit, sl_close_percent, tp_close_percent,
+			   created_at, updated_at, closed_at
+		FROM positions
+		WHERE %s
+		ORDER BY closed_at DESC, id DESC
+		LIMIT $%d`,
+		strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPositions(rows)
+}
+
 func (r *PositionRepository) Update(ctx context.Context, position *domain.Position) error {
 	query := `
 		UPDATE positions
@@ -224,7 +320,7 @@ func (r *PositionRepository) scanPositions(rows *sql.Rows) ([]domain.Position, e
 	for rows.Next() {
 		var p domain.Position
 		err := rows.Scan(
-			&p.ID, &p.UserID, &p.Symbol, &p.Side,
+			&p.ID, &p.UserID, &p.AccountID, &p.Symbol, &p.Side,
 			&p.Status, &p.Quantity, &p.EntryPrice, &p.Leverage,
 			&p.InitialMargin, &p.MarkPrice, &p.UnrealizedPnL,
 			&p.RealizedPnL, &p.LiquidationPrice,
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"trading/internal/domain"
+)
+
+type PriceAlertRepository struct {
+	db *DB
+}
+
+func NewPriceAlertRepository(db *DB) *PriceAlertRepository {
+	return &PriceAlertRepository{db: db}
+}
+
+func (r *PriceAlertRepository) Create(ctx context.Context, alert *domain.PriceAlert) error {
+	query := `
+		INSERT INTO price_alerts (user_id, symbol, condition, target_price, reference_price, change_percent, window_seconds, note, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		alert.UserID, alert.Symbol, alert.Condition, alert.TargetPrice, alert.ReferencePrice,
+		alert.ChangePercent, int64(alert.Window/time.Second), alert.Note, alert.Status,
+	).Scan(&alert.ID, &alert.CreatedAt, &alert.UpdatedAt)
+}
+
+func (r *PriceAlertRepository) GetByID(ctx context.Context, id domain.AlertID) (*domain.PriceAlert, error) {
+	query := `
+		SELECT id, user_id, symbol, condition, target_price, reference_price, change_percent, window_seconds,
+		       note, status, triggered_price, triggered_at, created_at, updated_at
+		FROM price_alerts
+		WHERE id = $1`
+
+	alert, err := r.scanAlert(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAlertNotFound
+		}
+		return nil, err
+	}
+	return alert, nil
+}
+
+// GetByUserID returns the user's alerts, newest first. Empty status returns all.
+func (r *PriceAlertRepository) GetByUserID(ctx context.Context, userID domain.UserID, status domain.AlertStatus) ([]domain.PriceAlert, error) {
+	query := `
+		SELECT id, user_id, symbol, condition, target_price, reference_price, change_percent, window_seconds,
+		       note, status, triggered_price, triggered_at, created_at, updated_at
+		FROM price_alerts
+		WHERE user_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanAlerts(rows)
+}
+
+func (r *PriceAlertRepository) GetAllActive(ctx context.Context) ([]domain.PriceAlert, error) {
+	query := `
+		SELECT id, user_id, symbol, condition, target_price, reference_price, change_percent, window_seconds,
+		       note, status, triggered_price, triggered_at, created_at, updated_at
+		FROM price_alerts
+		WHERE status = 'ACTIVE'
+		ORDER BY id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanAlerts(rows)
+}
+
+func (r *PriceAlertRepository) CountActiveByUserID(ctx context.Context, userID domain.UserID) (int, error) {
+	query := `SELECT COUNT(*) FROM price_alerts WHERE user_id = $1 AND status = 'ACTIVE'`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *PriceAlertRepository) Update(ctx context.Context, alert *domain.PriceAlert) error {
+	query := `
+		UPDATE price_alerts
+		SET condition = $1, target_price = $2, reference_price = $3, change_percent = $4, window_seconds = $5,
+		    note = $6, status = $7, triggered_price = $8, triggered_at = $9, updated_at = NOW()
+		WHERE id = $10
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		alert.Condition, alert.TargetPrice, alert.ReferencePrice, alert.ChangePercent,
+		int64(alert.Window/time.Second), alert.Note, alert.Status, alert.TriggeredPrice,
+		alert.TriggeredAt, alert.ID,
+	).Scan(&alert.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrAlertNotFound
+	}
+	return err
+}
+
+// MarkTriggered moves an active alert to TRIGGERED. The status condition
+// makes concurrent evaluations fire the alert only once.
+func (r *PriceAlertRepository) MarkTriggered(ctx context.Context, alert *domain.PriceAlert) (bool, error) {
+	query := `
+		UPDATE price_alerts
+		SET status = 'TRIGGERED', triggered_price = $1, triggered_at = $2, updated_at = NOW()
+		WHERE id = $3 AND status = 'ACTIVE'`
+
+	result, err := r.db.ExecContext(ctx, query, alert.TriggeredPrice, alert.TriggeredAt, alert.ID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *PriceAlertRepository) Delete(ctx context.Context, id domain.AlertID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM price_alerts WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrAlertNotFound
+	}
+	return nil
+}
+
+func (r *PriceAlertRepository) scanAlert(row *sql.Row) (*domain.PriceAlert, error) {
+	var a domain.PriceAlert
+	var windowSeconds int64
+	err := row.Scan(
+		&a.ID, &a.UserID, &a.Symbol, &a.Condition, &a.TargetPrice, &a.ReferencePrice,
+		&a.ChangePercent, &windowSeconds, &a.Note, &a.Status, &a.TriggeredPrice,
+		&a.TriggeredAt, &a.CreatedAt, &a.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	a.Window = time.Duration(windowSeconds) * time.Second
+	return &a, nil
+}
+
+func (r *PriceAlertRepository) scanAlerts(rows *sql.Rows) ([]domain.PriceAlert, error) {
+	var alerts []domain.PriceAlert
+	for rows.Next() {
+		var a domain.PriceAlert
+		var windowSeconds int64
+		err := rows.Scan(
+			&a.ID, &a.UserID, &a.Symbol, &a.Condition, &a.TargetPrice, &a.ReferencePrice,
+			&a.ChangePercent, &windowSeconds, &a.Note, &a.Status, &a.TriggeredPrice,
+			&a.TriggeredAt, &a.CreatedAt, &a.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		a.Window = time.Duration(windowSeconds) * time.Second
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"trading/internal/domain"
+)
+
+type ScriptRepository struct {
+	db *DB
+}
+
+func NewScriptRepository(db *DB) *ScriptRepository {
+	return &ScriptRepository{db: db}
+}
+
+const scriptColumns = `
+	id, user_id, account_id, name, symbol, source, params, status, last_error, state, order_ids,
+	started_at, created_at, updated_at`
+
+func (r *ScriptRepository) Create(ctx context.Context, s *domain.Script) error {
+	params, err := json.Marshal(s.Params)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO scripts (user_id, account_id, name, symbol, source, params, status, state, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		s.UserID, s.AccountID, s.Name, s.Symbol, s.Source, string(params), s.Status, s.State,
+	).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+}
+
+func (r *ScriptRepository) GetByID(ctx context.Context, id domain.ScriptID) (*domain.Script, error) {
+	query := `SELECT ` + scriptColumns + ` FROM scripts WHERE id = $1`
+
+	s, err := r.scanScript(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrScriptNotFound
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *ScriptRepository) ListByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.Script, error) {
+	query := `SELECT ` + scriptColumns + ` FROM scripts WHERE account_id = $1 ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanScripts(rows)
+}
+
+func (r *ScriptRepository) GetRunning(ctx context.Context) ([]domain.Script, error) {
+	query := `SELECT ` + scriptColumns + ` FROM scripts WHERE status = 'RUNNING' ORDER BY id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanScripts(rows)
+}
+
+func (r *ScriptRepository) Update(ctx context.Context, s *domain.Script) error {
+	params, err := json.Marshal(s.Params)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE scripts
+		SET name = $1, source = $2, params = $3, status = $4, last_error = $5, state = $6, order_ids = $7,
+			started_at = $8, updated_at = NOW()
+		WHERE id = $9
+		RETURNING updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		s.Name, s.Source, string(params), s.Status, s.LastError, s.State, pq.Array(orderIDs(s.Orders)),
+		s.StartedAt, s.ID,
+	).Scan(&s.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrScriptNotFound
+	}
+	return err
+}
+
+func (r *ScriptRepository) Delete(ctx context.Context, id domain.ScriptID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM scripts WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrScriptNotFound
+	}
+	return nil
+}
+
+// AppendLogs inserts the lines and prunes older ones in one transaction
+func (r *ScriptRepository) AppendLogs(ctx context.Context, id domain.ScriptID, logs []domain.ScriptLog, keep int) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, l := range logs {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO script_logs (script_id, level, message, created_at) VALUES ($1, $2, $3, NOW())`,
+			id, l.Level, l.Message,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM script_logs
+		WHERE script_id = $1 AND id <= (
+			SELECT id FROM script_logs WHERE script_id = $1 ORDER BY id DESC OFFSET $2 LIMIT 1
+		)`, id, keep)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ScriptRepository) ListLogs(ctx context.Context, id domain.ScriptID, limit int) ([]domain.ScriptLog, error) {
+	query := `
+		SELECT id, script_id, level, message, created_at
+		FROM script_logs
+		WHERE script_id = $1
+		ORDER BY id DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []domain.ScriptLog
+	for rows.Next() {
+		var l domain.ScriptLog
+		if err := rows.Scan(&l.ID, &l.ScriptID, &l.Level, &l.Message, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		logs = append(logs, l)
+	}
+	return logs, rows.Err()
+}
+
+func (r *ScriptRepository) scanScript(row *sql.Row) (*domain.Script, error) {
+	var s domain.Script
+	var params []byte
+	var orders []int64
+	err := row.Scan(
+		&s.ID, &s.UserID, &s.AccountID, &s.Name, &s.Symbol, &s.Source, &params, &s.Status, &s.LastError,
+		&s.State, pq.Array(&orders), &s.StartedAt, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeScript(&s, params, orders); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *ScriptRepository) scanScripts(rows *sql.Rows) ([]domain.Script, error) {
+	var scripts []domain.Script
+	for rows.Next() {
+		var s domain.Script
+		var params []byte
+		var orders []int64
+		err := rows.Scan(
+			&s.ID, &s.UserID, &s.AccountID, &s.Name, &s.Symbol, &s.Source, &params, &s.Status, &s.LastError,
+			&s.State, pq.Array(&orders), &s.StartedAt, &s.CreatedAt, &s.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := decodeScript(&s, params, orders); err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, s)
+	}
+	return scripts, rows.Err()
+}
+
+// decodeScript fills in the columns stored as JSON and arrays
+func decodeScript(s *domain.Script, params []byte, orders []int64) error {
+	if err := json.Unmarshal(params, &s.Params); err != nil {
+		return err
+	}
+	for _, id := range orders {
+		s.Orders = append(s.Orders, domain.OrderID(id))
+	}
+	return nil
+}
+
+func orderIDs(orders []domain.OrderID) []int64 {
+	ids := make([]int64, len(orders))
+	for i, id := range orders {
+		ids[i] = int64(id)
+	}
+	return ids
+}
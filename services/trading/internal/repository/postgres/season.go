@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+type AccountSeasonRepository struct {
+	db *DB
+}
+
+func NewAccountSeasonRepository(db *DB) *AccountSeasonRepository {
+	return &AccountSeasonRepository{db: db}
+}
+
+// Archive inserts the finished season, moves the account to the next one and posts
+// the balance adjustment in a single transaction. season.EndedAt is set to the new season's start.
+func (r *AccountSeasonRepository) Archive(
+	ctx context.Context,
+	season *domain.AccountSeason,
+	nextStartingBalance decimal.Decimal,
+	adjustment *domain.LedgerEntry,
+) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var endedAt time.Time
+	err = tx.QueryRowContext(ctx, `
+		UPDATE accounts
+		SET season = season + 1, season_started_at = NOW(), season_starting_balance = $1
+		WHERE id = $2 AND season = $3
+		RETURNING season_started_at`,
+		nextStartingBalance, season.AccountID, season.Season,
+	).Scan(&endedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrSeasonNotFound
+		}
+		return err
+	}
+
+	query := `
+		INSERT INTO account_seasons (
+			account_id, season, started_at, ended_at, starting_balance, final_balance,
+			realized_pnl, volume, total_trades, closed_trades, winning_trades, losing_trades, liquidations
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	_, err = tx.ExecContext(ctx, query,
+		season.AccountID, season.Season, season.StartedAt, endedAt,
+		season.StartingBalance, season.FinalBalance,
+		season.Stats.RealizedPnL, season.Stats.Volume, season.Stats.TotalTrades,
+		season.Stats.ClosedTrades, season.Stats.WinningTrades, season.Stats.LosingTrades,
+		season.Stats.Liquidations,
+	)
+	if err != nil {
+		return err
+	}
+
+	if adjustment != nil {
+		if err := postEntry(ctx, tx, adjustment); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	season.EndedAt = &endedAt
+	return nil
+}
+
+func (r *AccountSeasonRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.AccountSeason, error) {
+	query := `
+		SELECT account_id, season, started_at, ended_at, starting_balance, final_balance,
+			   realized_pnl, volume, total_trades, closed_trades, winning_trades, losing_trades, liquidations
+		FROM account_seasons
+		WHERE account_id = $1
+		ORDER BY season DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanSeasons(rows)
+}
+
+func (r *AccountSeasonRepository) GetByAccountIDAndSeason(ctx context.Context, accountID domain.AccountID, season int) (*domain.AccountSeason, error) {
+	query := `
+		SELECT account_id, season, started_at, ended_at, starting_balance, final_balance,
+			   realized_pnl, volume, total_trades, closed_trades, winning_trades, losing_trades, liquidations
+		FROM account_seasons
+		WHERE account_id = $1 AND season = $2`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, season)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seasons, err := r.scanSeasons(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(seasons) == 0 {
+		return nil, domain.ErrSeasonNotFound
+	}
+	return &seasons[0], nil
+}
+
+func (r *AccountSeasonRepository) scanSeasons(rows *sql.Rows) ([]domain.AccountSeason, error) {
+	var seasons []domain.AccountSeason
+	for rows.Next() {
+		var s domain.AccountSeason
+		var endedAt time.Time
+		err := rows.Scan(
+			&s.AccountID, &s.Season, &s.StartedAt, &endedAt,
+			&s.StartingBalance, &s.FinalBalance,
+			&s.Stats.RealizedPnL, &s.Stats.Volume, &s.Stats.TotalTrades,
+			&s.Stats.ClosedTrades, &s.Stats.WinningTrades, &s.Stats.LosingTrades,
+			&s.Stats.Liquidations,
+		)
+		if err != nil {
+			return nil, err
+		}
+		s.EndedAt = &endedAt
+		seasons = append(seasons, s)
+	}
+	return seasons, rows.Err()
+}
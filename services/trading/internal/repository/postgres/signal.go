@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"trading/internal/domain"
+)
+
+type SignalStrategyRepository struct {
+	db *DB
+}
+
+func NewSignalStrategyRepository(db *DB) *SignalStrategyRepository {
+	return &SignalStrategyRepository{db: db}
+}
+
+const signalStrategyColumns = `
+	id, user_id, account_id, name, token_hash, symbol, quantity, max_quantity, leverage,
+	stop_loss_pct, take_profit_pct, active, created_at, updated_at`
+
+func (r *SignalStrategyRepository) Create(ctx context.Context, s *domain.SignalStrategy) error {
+	t := s.Template
+	query := `
+		INSERT INTO signal_strategies (user_id, account_id, name, token_hash, symbol, quantity, max_quantity, leverage,
+			stop_loss_pct, take_profit_pct, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		s.UserID, s.AccountID, s.Name, s.TokenHash, t.Symbol, t.Quantity, t.MaxQuantity, t.Leverage,
+		t.StopLossPct, t.TakeProfitPct, s.Active,
+	).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+}
+
+func (r *SignalStrategyRepository) GetByID(ctx context.Context, id domain.SignalStrategyID) (*domain.SignalStrategy, error) {
+	query := `SELECT ` + signalStrategyColumns + ` FROM signal_strategies WHERE id = $1`
+
+	s, err := r.scanStrategy(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSignalStrategyNotFound
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *SignalStrategyRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.SignalStrategy, error) {
+	query := `SELECT ` + signalStrategyColumns + ` FROM signal_strategies WHERE token_hash = $1`
+
+	s, err := r.scanStrategy(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSignalStrategyNotFound
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *SignalStrategyRepository) ListByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.SignalStrategy, error) {
+	query := `SELECT ` + signalStrategyColumns + ` FROM signal_strategies WHERE account_id = $1 ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var strategies []domain.SignalStrategy
+	for rows.Next() {
+		var s domain.SignalStrategy
+		err := rows.Scan(
+			&s.ID, &s.UserID, &s.AccountID, &s.Name, &s.TokenHash, &s.Template.Symbol, &s.Template.Quantity,
+			&s.Template.MaxQuantity, &s.Template.Leverage, &s.Template.StopLossPct, &s.Template.TakeProfitPct,
+			&s.Active, &s.CreatedAt, &s.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		strategies = append(strategies, s)
+	}
+	return strategies, rows.Err()
+}
+
+func (r *SignalStrategyRepository) Update(ctx context.Context, s *domain.SignalStrategy) error {
+	t := s.Template
+	query := `
+		UPDATE signal_strategies
+		SET name = $1, token_hash = $2, symbol = $3, quantity = $4, max_quantity = $5, leverage = $6,
+			stop_loss_pct = $7, take_profit_pct = $8, active = $9, updated_at = NOW()
+		WHERE id = $10
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		s.Name, s.TokenHash, t.Symbol, t.Quantity, t.MaxQuantity, t.Leverage,
+		t.StopLossPct, t.TakeProfitPct, s.Active, s.ID,
+	).Scan(&s.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrSignalStrategyNotFound
+	}
+	return err
+}
+
+func (r *SignalStrategyRepository) Delete(ctx context.Context, id domain.SignalStrategyID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM signal_strategies WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrSignalStrategyNotFound
+	}
+	return nil
+}
+
+func (r *SignalStrategyRepository) CreateSignal(ctx context.Context, s *domain.Signal) error {
+	query := `
+		INSERT INTO signals (strategy_id, payload, action, symbol, side, status, order_id, quantity, price, error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		RETURNING id, created_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		s.StrategyID, s.Payload, s.Action, s.Symbol, s.Side, s.Status, s.OrderID, s.Quantity, s.Price, s.Error,
+	).Scan(&s.ID, &s.CreatedAt)
+}
+
+func (r *SignalStrategyRepository) ListSignals(ctx context.Context, strategyID domain.SignalStrategyID, limit int) ([]domain.Signal, error) {
+	query := `
+		SELECT id, strategy_id, payload, action, symbol, side, status, order_id, quantity, price, error, created_at
+		FROM signals
+		WHERE strategy_id = $1
+		ORDER BY id DESC
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, strategyID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var signals []domain.Signal
+	for rows.Next() {
+		var s domain.Signal
+		err := rows.Scan(
+			&s.ID, &s.StrategyID, &s.Payload, &s.Action, &s.Symbol, &s.Side, &s.Status, &s.OrderID,
+			&s.Quantity, &s.Price, &s.Error, &s.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		signals = append(signals, s)
+	}
+	return signals, rows.Err()
+}
+
+func (r *SignalStrategyRepository) scanStrategy(row *sql.Row) (*domain.SignalStrategy, error) {
+	var s domain.SignalStrategy
+	err := row.Scan(
+		&s.ID, &s.UserID, &s.AccountID, &s.Name, &s.TokenHash, &s.Template.Symbol, &s.Template.Quantity,
+		&s.Template.MaxQuantity, &s.Template.Leverage, &s.Template.StopLossPct, &s.Template.TakeProfitPct,
+		&s.Active, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"trading/internal/domain"
+)
+
+type SpotLotRepository struct {
+	db *DB
+}
+
+func NewSpotLotRepository(db *DB) *SpotLotRepository {
+	return &SpotLotRepository{db: db}
+}
+
+func (r *SpotLotRepository) Create(ctx context.Context, lot *domain.SpotLot) error {
+	return insertSpotLot(ctx, r.db, lot)
+}
+
+func insertSpotLot(ctx context.Context, q queryRower, lot *domain.SpotLot) error {
+	query := `
+		INSERT INTO spot_lots (account_id, asset, trade_id, quantity, remaining, price, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id, created_at`
+
+	return q.QueryRowContext(ctx, query,
+		lot.AccountID, lot.Asset, lot.TradeID, lot.Quantity, lot.Remaining, lot.Price,
+	).Scan(&lot.ID, &lot.CreatedAt)
+}
+
+func (r *SpotLotRepository) GetOpenByAccountIDAndAsset(ctx context.Context, accountID domain.AccountID, asset domain.Asset) ([]domain.SpotLot, error) {
+	query := `
+		SELECT id, account_id, asset, trade_id, quantity, remaining, price, created_at, closed_at
+		FROM spot_lots
+		WHERE account_id = $1 AND asset = $2 AND remaining > 0
+		ORDER BY id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, asset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanLots(rows)
+}
+
+func (r *SpotLotRepository) GetOpenByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.SpotLot, error) {
+	query := `
+		SELECT id, account_id, asset, trade_id, quantity, remaining, price, created_at, closed_at
+		FROM spot_lots
+		WHERE account_id = $1 AND remaining > 0
+		ORDER BY asset, id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanLots(rows)
+}
+
+func (r *SpotLotRepository) Update(ctx context.Context, lot *domain.SpotLot) error {
+	query := `
+		UPDATE spot_lots
+		SET remaining = $1, closed_at = $2
+		WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, lot.Remaining, lot.ClosedAt, lot.ID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrSpotLotNotFound
+	}
+	return nil
+}
+
+// Settle claims the order and writes the trade, its ledger legs and the lots
+// in one transaction
+func (r *SpotLotRepository) Settle(ctx context.Context, fill *domain.SpotFill) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateOrder(ctx, tx, fill.Order); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrOrderNotPending
+		}
+		return err
+	}
+	if err := insertTrade(ctx, tx, fill.Trade); err != nil {
+		return err
+	}
+
+	tradeID := fill.Trade.ID
+	for _, entry := range fill.Entries {
+		entry.TradeID = &tradeID
+		if err := postEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+	}
+
+	if fill.Opened != nil {
+		fill.Opened.TradeID = tradeID
+		if err := insertSpotLot(ctx, tx, fill.Opened); err != nil {
+			return err
+		}
+	}
+	for _, lot := range fill.Consumed {
+		_, err := tx.ExecContext(ctx, `UPDATE spot_lots SET remaining = $1, closed_at = $2 WHERE id = $3`,
+			lot.Remaining, lot.ClosedAt, lot.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *SpotLotRepository) scanLots(rows *sql.Rows) ([]domain.SpotLot, error) {
+	var lots []domain.SpotLot
+	for rows.Next() {
+		var l domain.SpotLot
+		err := rows.Scan(
+			&l.ID, &l.AccountID, &l.Asset, &l.TradeID,
+			&l.Quantity, &l.Remaining, &l.Price, &l.CreatedAt, &l.ClosedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		lots = append(lots, l)
+	}
+	return lots, rows.Err()
+}
@@ -4,6 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
 
 	"trading/internal/domain"
 )
@@ -17,15 +22,19 @@ func NewTradeRepository(db *DB) *TradeRepository {
 }
 
 func (r *TradeRepository) Create(ctx context.Context, trade *domain.Trade) error {
+	return insertTrade(ctx, r.db, trade)
+}
+
+func insertTrade(ctx context.Context, q queryRower, trade *domain.Trade) error {
 	query := `
 		INSERT INTO trades (
-			user_id, position_id, order_id, symbol, side, type,
+			user_id, account_id, position_id, order_id, symbol, side, type,
 			quantity, price, pnl, fee, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		) VALUES ($1, $2, NULLIF($3::bigint, 0), $4, $5, $6, $7, $8, $9, $10, $11, NOW())
 		RETURNING id, created_at`
 
-	return r.db.QueryRowContext(ctx, query,
-		trade.UserID, trade.PositionID, trade.OrderID, trade.Symbol,
+	return q.QueryRowContext(ctx, query,
+		trade.UserID, trade.AccountID, trade.PositionID, trade.OrderID, trade.Symbol,
 		trade.Side, trade.Type, trade.Quantity, trade.Price,
 		trade.PnL, trade.Fee,
 	).Scan(&trade.ID, &trade.CreatedAt)
@@ -33,14 +42,14 @@ func (r *TradeRepository) Create(ctx context.Context, trade *domain.Trade) error
 
 func (r *TradeRepository) GetByID(ctx context.Context, id domain.TradeID) (*domain.Trade, error) {
 	query := `
-		SELECT id, user_id, position_id, order_id, symbol, side, type,
+		SELECT id, user_id, account_id, COALESCE(position_id, 0), order_id, symbol, side, type,
 			   quantity, price, pnl, fee, created_at
 		FROM trades
 		WHERE id = $1`
 
 	trade := &domain.Trade{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&trade.ID, &trade.UserID, &trade.PositionID, &trade.OrderID,
+		&trade.ID, &trade.UserID, &trade.AccountID, &trade.PositionID, &trade.OrderID,
 		&trade.Symbol, &trade.Side, &trade.Type,
 		&trade.Quantity, &trade.Price, &trade.PnL, &trade.Fee,
 		&trade.CreatedAt,
@@ -54,16 +63,46 @@ func (r *TradeRepository) GetByID(ctx context.Context, id domain.TradeID) (*doma
 	return trade, nil
 }
 
-func (r *TradeRepository) GetByUserID(ctx context.Context, userID domain.UserID, limit, offset int) ([]domain.Trade, error) {
-	query := `
-		SELECT id, user_id, position_id, order_id, symbol, side, type,
+func (r *TradeRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID, filter domain.TradeFilter) ([]domain.Trade, error) {
+	conditions := []string{"account_id = $1"}
+	args := []interface{}{accountID}
+
+	if filter.Symbol != "" {
+		args = append(args, filter.Symbol)
+		conditions = append(conditions, fmt.Sprintf("symbol = $%d", len(args)))
+	}
+	if filter.Side != "" {
+		args = append(args, filter.Side)
+		conditions = append(conditions, fmt.Sprintf("side = $%d", len(args)))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.Time, filter.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+		SELECT id, user_id, account_id, COALESCE(position_id, 0), order_id, symbol, side, type,
 			   quantity, price, pnl, fee, created_at
 		FROM trades
-		WHERE user_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3`
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`,
+		strings.Join(conditions, " AND "), len(args)-1, len(args))
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +113,7 @@ func (r *TradeRepository) GetByUserID(ctx context.Context, userID domain.UserID,
 
 func (r *TradeRepository) GetByPositionID(ctx context.Context, positionID domain.PositionID) ([]domain.Trade, error) {
 	query := `
-		SELECT id, user_id, position_id, order_id, symbol, side, type,
+		SELECT id, user_id, account_id, COALESCE(position_id, 0), order_id, symbol, side, type,
 			   quantity, price, pnl, fee, created_at
 		FROM trades
 		WHERE position_id = $1
@@ -89,12 +128,104 @@ func (r *TradeRepository) GetByPositionID(ctx context.Context, positionID domain
 	return r.scanTrades(rows)
 }
 
+// GetByAccountIDBetween returns trades created in [from, to), newest first. A nil to is open-ended.
+func (r *TradeRepository) GetByAccountIDBetween(ctx context.Context, accountID domain.AccountID, from time.Time, to *time.Time, limit, offset int) ([]domain.Trade, error) {
+	query := `
+		SELECT id, user_id, account_id, COALESCE(position_id, 0), order_id, symbol, side, type,
+			   quantity, price, pnl, fee, created_at
+		FROM trades
+		WHERE account_id = $1 AND created_at >= $2
+		  AND ($3::timestamptz IS NULL OR created_at < $3)
+		ORDER BY created_at DESC
+		LIMIT $4 OFFSET $5`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, from, to, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTrades(rows)
+}
+
+func (r *TradeRepository) GetByAccountIDAfter(ctx context.Context, accountID domain.AccountID, from, to *time.Time, afterID domain.TradeID, limit int) ([]domain.Trade, error) {
+	query := `
+		SELECT id, user_id, account_id, COALESCE(position_id, 0), order_id, symbol, side, type,
+			   quantity, price, pnl, fee, created_at
+		FROM trades
+		WHERE account_id = $1 AND id > $2
+		  AND ($3::timestamptz IS NULL OR created_at >= $3)
+		  AND ($4::timestamptz IS NULL OR created_at < $4)
+		ORDER BY id ASC
+		LIMIT $5`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, afterID, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTrades(rows)
+}
+
+// Summarize aggregates trades created in [from, to). A nil to is open-ended.
+func (r *TradeRepository) Summarize(ctx context.Context, accountID domain.AccountID, from time.Time, to *time.Time) (*domain.TradeSummary, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE type IN ('CLOSE', 'LIQUIDATE', 'SPOT_SELL')),
+			COUNT(*) FILTER (WHERE type IN ('CLOSE', 'LIQUIDATE', 'SPOT_SELL') AND pnl > 0),
+			COUNT(*) FILTER (WHERE type IN ('CLOSE', 'LIQUIDATE', 'SPOT_SELL') AND pnl < 0),
+			COUNT(*) FILTER (WHERE type = 'LIQUIDATE'),
+			COALESCE(SUM(pnl), 0),
+			COALESCE(SUM(quantity * price), 0)
+		FROM trades
+		WHERE account_id = $1 AND created_at >= $2
+		  AND ($3::timestamptz IS NULL OR created_at < $3)`
+
+	s := &domain.TradeSummary{}
+	err := r.db.QueryRowContext(ctx, query, accountID, from, to).Scan(
+		&s.TotalTrades, &s.ClosedTrades, &s.WinningTrades, &s.LosingTrades,
+		&s.Liquidations, &s.RealizedPnL, &s.Volume,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *TradeRepository) GetByPositionIDs(ctx context.Context, positionIDs []domain.PositionID) ([]domain.Trade, error) {
+	if len(positionIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, len(positionIDs))
+	for i, id := range positionIDs {
+		ids[i] = int64(id)
+	}
+
+	query := `
+		SELECT id, user_id, account_id, COALESCE(position_id, 0), order_id, symbol, side, type,
+			   quantity, price, pnl, fee, created_at
+		FROM trades
+		WHERE position_id = ANY($1)
+		ORDER BY created_at ASC, id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanTrades(rows)
+}
+
 func (r *TradeRepository) scanTrades(rows *sql.Rows) ([]domain.Trade, error) {
 	var trades []domain.Trade
 	for rows.Next() {
 		var t domain.Trade
 		err := rows.Scan(
-			&t.ID, &t.UserID, &t.PositionID, &t.OrderID,
+			&t.ID, &t.UserID, &t.AccountID, &t.PositionID, &t.OrderID,
 			&t.Symbol, &t.Side, &t.Type,
 			&t.Quantity, &t.Price, &t.PnL, &t.Fee,
 			&t.CreatedAt,
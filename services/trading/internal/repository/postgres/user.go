@@ -28,7 +28,8 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 
 func (r *UserRepository) GetByID(ctx context.Context, id domain.UserID) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, created_at, updated_at
+		SELECT id, email, password_hash, created_at, updated_at,
+			   COALESCE(display_name, ''), leaderboard_opt_in, is_admin
 		FROM users
 		WHERE id = $1`
 
@@ -36,6 +37,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id domain.UserID) (*domain
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&user.ID, &user.Email, &user.PasswordHash,
 		&user.CreatedAt, &user.UpdatedAt,
+		&user.DisplayName, &user.LeaderboardOptIn, &user.IsAdmin,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -48,7 +50,8 @@ func (r *UserRepository) GetByID(ctx context.Context, id domain.UserID) (*domain
 
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, created_at, updated_at
+		SELECT id, email, password_hash, created_at, updated_at,
+			   COALESCE(display_name, ''), leaderboard_opt_in, is_admin
 		FROM users
 		WHERE email = $1`
 
@@ -56,6 +59,29 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	err := r.db.QueryRowContext(ctx, query, email).Scan(
 		&user.ID, &user.Email, &user.PasswordHash,
 		&user.CreatedAt, &user.UpdatedAt,
+		&user.DisplayName, &user.LeaderboardOptIn, &user.IsAdmin,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *UserRepository) GetByDisplayName(ctx context.Context, name string) (*domain.User, error) {
+	query := `
+		SELECT id, email, password_hash, created_at, updated_at,
+			   COALESCE(display_name, ''), leaderboard_opt_in, is_admin
+		FROM users
+		WHERE LOWER(display_name) = LOWER($1)`
+
+	user := &domain.User{}
+	err := r.db.QueryRowContext(ctx, query, name).Scan(
+		&user.ID, &user.Email, &user.PasswordHash,
+		&user.CreatedAt, &user.UpdatedAt,
+		&user.DisplayName, &user.LeaderboardOptIn, &user.IsAdmin,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -69,10 +95,13 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users
-		SET email = $1, password_hash = $2
-		WHERE id = $3`
+		SET email = $1, password_hash = $2, display_name = NULLIF($3, ''),
+			leaderboard_opt_in = $4, updated_at = NOW()
+		WHERE id = $5`
 
-	result, err := r.db.ExecContext(ctx, query, user.Email, user.PasswordHash, user.ID)
+	result, err := r.db.ExecContext(ctx, query,
+		user.Email, user.PasswordHash, user.DisplayName, user.LeaderboardOptIn, user.ID,
+	)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+
+	"trading/internal/domain"
+)
+
+type WalletRepository struct {
+	db *DB
+}
+
+func NewWalletRepository(db *DB) *WalletRepository {
+	return &WalletRepository{db: db}
+}
+
+// GetByAccountID returns the account's non-zero asset balances
+func (r *WalletRepository) GetByAccountID(ctx context.Context, accountID domain.AccountID) ([]domain.AssetBalance, error) {
+	query := `
+		SELECT account_id, asset, amount, updated_at
+		FROM account_assets
+		WHERE account_id = $1 AND amount > 0
+		ORDER BY asset`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var balances []domain.AssetBalance
+	for rows.Next() {
+		var b domain.AssetBalance
+		if err := rows.Scan(&b.AccountID, &b.Asset, &b.Amount, &b.UpdatedAt); err != nil {
+			return nil, err
+		}
+		balances = append(balances, b)
+	}
+	return balances, rows.Err()
+}
@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"trading/internal/domain"
+)
+
+type WebhookRepository struct {
+	db *DB
+}
+
+func NewWebhookRepository(db *DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	query := `
+		INSERT INTO webhooks (user_id, url, secret, events, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		webhook.UserID, webhook.URL, webhook.Secret, pq.Array(eventNames(webhook.Events)), webhook.Active,
+	).Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt)
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, id domain.WebhookID) (*domain.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, events, active, created_at, updated_at
+		FROM webhooks
+		WHERE id = $1`
+
+	var w domain.Webhook
+	var events []string
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&w.ID, &w.UserID, &w.URL, &w.Secret, pq.Array(&events), &w.Active, &w.CreatedAt, &w.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	w.Events = eventTypes(events)
+	return &w, nil
+}
+
+func (r *WebhookRepository) GetByUserID(ctx context.Context, userID domain.UserID) ([]domain.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, events, active, created_at, updated_at
+		FROM webhooks
+		WHERE user_id = $1
+		ORDER BY id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanWebhooks(rows)
+}
+
+func (r *WebhookRepository) GetActiveByUserID(ctx context.Context, userID domain.UserID) ([]domain.Webhook, error) {
+	query := `
+		SELECT id, user_id, url, secret, events, active, created_at, updated_at
+		FROM webhooks
+		WHERE user_id = $1 AND active
+		ORDER BY id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanWebhooks(rows)
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET url = $1, events = $2, active = $3, updated_at = NOW()
+		WHERE id = $4
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		webhook.URL, pq.Array(eventNames(webhook.Events)), webhook.Active, webhook.ID,
+	).Scan(&webhook.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrWebhookNotFound
+	}
+	return err
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id domain.WebhookID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) scanWebhooks(rows *sql.Rows) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	for rows.Next() {
+		var w domain.Webhook
+		var events []string
+		err := rows.Scan(
+			&w.ID, &w.UserID, &w.URL, &w.Secret, pq.Array(&events), &w.Active, &w.CreatedAt, &w.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		w.Events = eventTypes(events)
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+func eventNames(events []domain.EventType) []string {
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = string(e)
+	}
+	return names
+}
+
+func eventTypes(names []string) []domain.EventType {
+	events := make([]domain.EventType, len(names))
+	for i, n := range names {
+		events[i] = domain.EventType(n)
+	}
+	return events
+}
+
+type WebhookDeliveryRepository struct {
+	db *DB
+}
+
+func NewWebhookDeliveryRepository(db *DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, d *domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_type, payload, status, attempts, next_attempt_at, redelivery_of, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING id, created_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		d.WebhookID, d.EventType, string(d.Payload), d.Status, d.Attempts, d.NextAttemptAt, d.RedeliveryOf,
+	).Scan(&d.ID, &d.CreatedAt)
+}
+
+func (r *WebhookDeliveryRepository) GetByID(ctx context.Context, id domain.WebhookDeliveryID) (*domain.WebhookDelivery, error) {
+	query := `
+		SELECT id, webhook_id, event_type, payload, status, attempts, next_attempt_at,
+		       last_status_code, last_error, redelivery_of, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE id = $1`
+
+	var d domain.WebhookDelivery
+	var payload string
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&d.ID, &d.WebhookID, &d.EventType, &payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.LastStatusCode, &d.LastError, &d.RedeliveryOf, &d.CreatedAt, &d.DeliveredAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	d.Payload = []byte(payload)
+	return &d, nil
+}
+
+func (r *WebhookDeliveryRepository) GetByWebhookID(ctx context.Context, webhookID domain.WebhookID, limit, offset int) ([]domain.WebhookDelivery, error) {
+	query := `
+		SELECT id, webhook_id, event_type, payload, status, attempts, next_attempt_at,
+		       last_status_code, last_error, redelivery_of, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY id DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, webhookID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanDeliveries(rows)
+}
+
+func (r *WebhookDeliveryRepository) GetDue(ctx context.Context, limit int) ([]domain.WebhookDelivery, error) {
+	query := `
+		SELECT id, webhook_id, event_type, payload, status, attempts, next_attempt_at,
+		       last_status_code, last_error, redelivery_of, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+		ORDER BY next_attempt_at ASC, id ASC
+		LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanDeliveries(rows)
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, d *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_status_code = $4, last_error = $5, delivered_at = $6
+		WHERE id = $7`
+
+	result, err := r.db.ExecContext(ctx, query,
+		d.Status, d.Attempts, d.NextAttemptAt, d.LastStatusCode, d.LastError, d.DeliveredAt, d.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrWebhookDeliveryNotFound
+	}
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) scanDeliveries(rows *sql.Rows) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		var payload string
+		err := rows.Scan(
+			&d.ID, &d.WebhookID, &d.EventType, &payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+			&d.LastStatusCode, &d.LastError, &d.RedeliveryOf, &d.CreatedAt, &d.DeliveredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		d.Payload = []byte(payload)
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,164 @@
+package script
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/backtest"
+	"trading/internal/domain"
+)
+
+// maxFillRounds bounds how often fills may trigger on_fill in a row on one
+// candle, since on_fill can place orders that fill again
+const maxFillRounds = 10
+
+// Strategy runs a script as a backtest strategy. On every candle the script
+// sees the fills since its last callback, then on_price with the close as
+// both bid and ask, then the fills of the orders it placed. Only market
+// orders are available; log output is discarded.
+type Strategy struct {
+	program *Program
+	params  map[string]float64
+	budget  Budget
+
+	instance *Instance
+	state    string
+	seen     int // trades already passed to on_fill
+}
+
+// NewStrategy returns a backtest strategy running the program
+func NewStrategy(program *Program, params map[string]float64, budget Budget) *Strategy {
+	return &Strategy{
+		program: program,
+		params:  params,
+		budget:  budget,
+		state:   "{}",
+	}
+}
+
+// OnCandle implements backtest.Strategy
+func (s *Strategy) OnCandle(ctx context.Context, t *backtest.Trader, c backtest.Candle) error {
+	if s.instance == nil {
+		instance, _, err := Load(ctx, s.program, s.params, &backtestBroker{trader: t}, s.budget)
+		if err != nil {
+			return err
+		}
+		s.instance = instance
+	}
+
+	if err := s.fills(ctx, t); err != nil {
+		return err
+	}
+	state, _, err := s.instance.OnPrice(ctx, s.state, Tick{
+		Symbol: t.Symbol(),
+		Bid:    c.Close,
+		Ask:    c.Close,
+		Time:   c.Time,
+	})
+	if err != nil {
+		return err
+	}
+	s.state = state
+	return s.fills(ctx, t)
+}
+
+// fills passes the trades not seen yet to on_fill
+func (s *Strategy) fills(ctx context.Context, t *backtest.Trader) error {
+	for round := 0; round < maxFillRounds; round++ {
+		trades, err := t.Trades(ctx)
+		if err != nil {
+			return err
+		}
+		if len(trades) == s.seen {
+			return nil
+		}
+		fresh := trades[s.seen:]
+		s.seen = len(trades)
+		for i := range fresh {
+			state, _, err := s.instance.OnFill(ctx, s.state, FillFromTrade(&fresh[i]))
+			if err != nil {
+				return err
+			}
+			s.state = state
+		}
+	}
+	return nil
+}
+
+// FillFromTrade describes a trade the way on_fill receives it
+func FillFromTrade(trade *domain.Trade) Fill {
+	// Opens and adds trade in the direction of the position, the rest against it
+	side := domain.OrderSideBuy
+	opening := trade.Type == domain.TradeTypeOpen || trade.Type == domain.TradeTypeAdd
+	if (trade.Side == domain.PositionSideShort) == opening {
+		side = domain.OrderSideSell
+	}
+	return Fill{
+		OrderID:  trade.OrderID,
+		TradeID:  trade.ID,
+		Type:     trade.Type,
+		Side:     side,
+		Quantity: trade.Quantity.InexactFloat64(),
+		Price:    trade.Price.InexactFloat64(),
+		Fee:      trade.Fee.InexactFloat64(),
+		PnL:      trade.PnL.InexactFloat64(),
+		Time:     trade.CreatedAt,
+	}
+}
+
+// backtestBroker trades the backtest account through the Trader
+type backtestBroker struct {
+	trader *backtest.Trader
+}
+
+func (b *backtestBroker) Balance(ctx context.Context) (Balance, error) {
+	summary, err := b.trader.Summary(ctx)
+	if err != nil {
+		return Balance{}, err
+	}
+	return Balance{
+		Balance:         summary.Balance,
+		Equity:          summary.Equity,
+		AvailableMargin: summary.AvailableMargin,
+	}, nil
+}
+
+func (b *backtestBroker) Position(ctx context.Context) (*domain.Position, error) {
+	return b.trader.Position(ctx)
+}
+
+func (b *backtestBroker) Orders(ctx context.Context) ([]domain.Order, error) {
+	return nil, nil
+}
+
+func (b *backtestBroker) Place(ctx context.Context, order Order) (domain.OrderID, error) {
+	if order.Price != nil {
+		return 0, ErrNotSupported
+	}
+	return b.trader.Place(ctx, order.Side, order.Quantity, backtest.OrderOptions{
+		Leverage:   order.Leverage,
+		StopLoss:   order.StopLoss,
+		TakeProfit: order.TakeProfit,
+	})
+}
+
+func (b *backtestBroker) Cancel(ctx context.Context, id domain.OrderID) error {
+	return ErrNotSupported
+}
+
+func (b *backtestBroker) Close(ctx context.Context, quantity *decimal.Decimal) (bool, error) {
+	position, err := b.trader.Position(ctx)
+	if err != nil || position == nil {
+		return false, err
+	}
+	if quantity == nil || quantity.GreaterThanOrEqual(position.Quantity) {
+		return true, b.trader.Close(ctx)
+	}
+	side := domain.OrderSideSell
+	if position.IsShort() {
+		side = domain.OrderSideBuy
+	}
+	_, err = b.trader.Place(ctx, side, *quantity, backtest.OrderOptions{Leverage: position.Leverage})
+	return err == nil, err
+}
@@ -0,0 +1,267 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.starlark.net/lib/math"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+
+	"trading/internal/domain"
+)
+
+const (
+	// maxLogsPerCall caps the lines one callback may log; the rest are dropped
+	maxLogsPerCall = 50
+	// maxLogLength truncates long log lines
+	maxLogLength = 500
+)
+
+// fileOptions enables the dialect features scripts may use. Recursion stays
+// disabled; loops are bounded by the step budget.
+var fileOptions = &syntax.FileOptions{Set: true, While: true, TopLevelControl: true}
+
+// Budget limits the work of a single callback
+type Budget struct {
+	MaxSteps uint64        // Starlark execution steps
+	Timeout  time.Duration // wall time, including calls into the broker
+}
+
+// Program is a compiled script
+type Program struct {
+	name string
+	prog *starlark.Program
+}
+
+// Compile parses and resolves a script. Syntax errors and references to
+// undefined names are reported as domain.ErrInvalidScript.
+func Compile(name, source string) (*Program, error) {
+	if source == "" || len(source) > domain.MaxScriptSize {
+		return nil, domain.ErrInvalidScript
+	}
+	_, prog, err := starlark.SourceProgramOptions(fileOptions, name, source, predeclared.Has)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidScript, err)
+	}
+	return &Program{name: name, prog: prog}, nil
+}
+
+// predeclared lists the names a script can use besides the Starlark built-ins;
+// the values are bound per instance
+var predeclared = starlark.StringDict{
+	"trade":  starlark.None,
+	"params": starlark.None,
+	"log":    starlark.None,
+	"math":   starlark.None,
+}
+
+// Tick is the price update passed to on_price
+type Tick struct {
+	Symbol string
+	Bid    float64
+	Ask    float64
+	Time   time.Time
+}
+
+// Fill is a trade of the script's account passed to on_fill
+type Fill struct {
+	OrderID  domain.OrderID
+	TradeID  domain.TradeID
+	Type     domain.TradeType
+	Side     domain.OrderSide
+	Quantity float64
+	Price    float64
+	Fee      float64
+	PnL      float64
+	Time     time.Time
+}
+
+// Log is a line logged by a script or about it
+type Log struct {
+	Level   domain.ScriptLogLevel
+	Message string
+}
+
+// Instance is a loaded script bound to a broker. It is not safe for
+// concurrent use; callers serialize callbacks.
+type Instance struct {
+	name    string
+	broker  Broker
+	budget  Budget
+	globals starlark.StringDict
+	onPrice starlark.Callable
+	onFill  starlark.Callable
+
+	logs    []Log
+	dropped int
+}
+
+// Load runs the top level of the program, which must define on_price,
+// on_fill or both as functions taking (state, event). Globals are frozen
+// afterwards; anything that must change between callbacks lives in state.
+func Load(ctx context.Context, program *Program, params map[string]float64, broker Broker, budget Budget) (*Instance, []Log, error) {
+	in := &Instance{
+		name:   program.name,
+		broker: broker,
+		budget: budget,
+	}
+
+	frozen := starlark.NewDict(len(params))
+	for k, v := range params {
+		if err := frozen.SetKey(starlark.String(k), starlark.Float(v)); err != nil {
+			return nil, nil, err
+		}
+	}
+	frozen.Freeze()
+
+	env := starlark.StringDict{
+		"trade":  in.tradeModule(),
+		"params": frozen,
+		"log":    starlark.NewBuiltin("log", in.logBuiltin),
+		"math":   math.Module,
+	}
+
+	err := in.exec(ctx, func(thread *starlark.Thread) error {
+		var err error
+		in.globals, err = program.prog.Init(thread, env)
+		return err
+	})
+	if err != nil {
+		return nil, in.flush(), fmt.Errorf("%w: %v", domain.ErrInvalidScript, err)
+	}
+	in.globals.Freeze()
+
+	in.onPrice, err = callback(in.globals, "on_price")
+	if err != nil {
+		return nil, in.flush(), err
+	}
+	in.onFill, err = callback(in.globals, "on_fill")
+	if err != nil {
+		return nil, in.flush(), err
+	}
+	if in.onPrice == nil && in.onFill == nil {
+		return nil, in.flush(), fmt.Errorf("%w: define on_price(state, price) or on_fill(state, fill)", domain.ErrInvalidScript)
+	}
+	return in, in.flush(), nil
+}
+
+// callback returns a global function taking two arguments, or nil if undefined
+func callback(globals starlark.StringDict, name string) (starlark.Callable, error) {
+	v, ok := globals[name]
+	if !ok {
+		return nil, nil
+	}
+	fn, ok := v.(*starlark.Function)
+	if !ok || fn.NumParams() != 2 {
+		return nil, fmt.Errorf("%w: %s must be a function taking (state, event)", domain.ErrInvalidScript, name)
+	}
+	return fn, nil
+}
+
+// OnPrice calls on_price with the decoded state and returns the state to keep.
+// A script without on_price keeps its state unchanged.
+func (in *Instance) OnPrice(ctx context.Context, state string, tick Tick) (string, []Log, error) {
+	if in.onPrice == nil {
+		return state, nil, nil
+	}
+	return in.call(ctx, in.onPrice, state, tickValue(tick))
+}
+
+// OnFill calls on_fill with the decoded state and returns the state to keep
+func (in *Instance) OnFill(ctx context.Context, state string, fill Fill) (string, []Log, error) {
+	if in.onFill == nil {
+		return state, nil, nil
+	}
+	return in.call(ctx, in.onFill, state, fillValue(fill))
+}
+
+func (in *Instance) call(ctx context.Context, fn starlark.Callable, state string, event starlark.Value) (string, []Log, error) {
+	dict, err := decodeState(state)
+	if err != nil {
+		return state, nil, err
+	}
+
+	err = in.exec(ctx, func(thread *starlark.Thread) error {
+		_, err := starlark.Call(thread, fn, starlark.Tuple{dict, event}, nil)
+		return err
+	})
+	if err != nil {
+		return state, in.flush(), err
+	}
+
+	next, err := encodeState(dict)
+	if err != nil {
+		return state, in.flush(), err
+	}
+	return next, in.flush(), nil
+}
+
+// exec runs f on a fresh thread under the budget
+func (in *Instance) exec(ctx context.Context, f func(thread *starlark.Thread) error) error {
+	thread := &starlark.Thread{
+		Name:  in.name,
+		Print: func(_ *starlark.Thread, msg string) { in.log(domain.ScriptLogInfo, msg) },
+		Load: func(*starlark.Thread, string) (starlark.StringDict, error) {
+			return nil, errors.New("load is not available in scripts")
+		},
+	}
+	thread.SetLocal(contextKey, ctx)
+	thread.SetMaxExecutionSteps(in.budget.MaxSteps)
+
+	timer := time.AfterFunc(in.budget.Timeout, func() { thread.Cancel("time budget exceeded") })
+	defer timer.Stop()
+
+	err := f(thread)
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return errors.New(evalErr.Backtrace())
+	}
+	return err
+}
+
+// log buffers a line for the caller to store
+func (in *Instance) log(level domain.ScriptLogLevel, msg string) {
+	if len(in.logs) >= maxLogsPerCall {
+		in.dropped++
+		return
+	}
+	if len(msg) > maxLogLength {
+		msg = msg[:maxLogLength] + "..."
+	}
+	in.logs = append(in.logs, Log{Level: level, Message: msg})
+}
+
+// flush returns and clears the lines logged since the last flush
+func (in *Instance) flush() []Log {
+	logs := in.logs
+	if in.dropped > 0 {
+		logs = append(logs, Log{
+			Level:   domain.ScriptLogError,
+			Message: fmt.Sprintf("%d log lines dropped", in.dropped),
+		})
+	}
+	in.logs = nil
+	in.dropped = 0
+	return logs
+}
+
+// logBuiltin implements log(*args), which joins its arguments like print
+func (in *Instance) logBuiltin(_ *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if len(kwargs) > 0 {
+		return nil, errors.New("log: unexpected keyword arguments")
+	}
+	parts := make([]string, len(args))
+	for i, a := range args {
+		if s, ok := a.(starlark.String); ok {
+			parts[i] = string(s)
+		} else {
+			parts[i] = a.String()
+		}
+	}
+	in.log(domain.ScriptLogInfo, strings.Join(parts, " "))
+	return starlark.None, nil
+}
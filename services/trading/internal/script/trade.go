@@ -0,0 +1,275 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+
+	"trading/internal/domain"
+)
+
+// quantityPlaces is the precision of script order quantities
+const quantityPlaces = 6
+
+// contextKey is the thread local holding the context of the running callback
+const contextKey = "context"
+
+// Broker is the restricted trading API a script gets, bound to one account
+// and symbol
+type Broker interface {
+	Balance(ctx context.Context) (Balance, error)
+	// Position returns the open position on the symbol, or nil when flat
+	Position(ctx context.Context) (*domain.Position, error)
+	// Orders returns the resting limit orders the script placed
+	Orders(ctx context.Context) ([]domain.Order, error)
+	Place(ctx context.Context, order Order) (domain.OrderID, error)
+	// Cancel cancels a resting order the script placed
+	Cancel(ctx context.Context, id domain.OrderID) error
+	// Close closes quantity of the open position, all of it when nil. Returns
+	// false when flat.
+	Close(ctx context.Context, quantity *decimal.Decimal) (bool, error)
+}
+
+// Balance is what trade.balance() reports
+type Balance struct {
+	Balance         decimal.Decimal
+	Equity          decimal.Decimal
+	AvailableMargin decimal.Decimal
+}
+
+// Order is an order a script places; a nil Price makes it a market order
+type Order struct {
+	Side       domain.OrderSide
+	Quantity   decimal.Decimal
+	Price      *decimal.Decimal
+	Leverage   int
+	StopLoss   *decimal.Decimal
+	TakeProfit *decimal.Decimal
+}
+
+// IsRejection reports whether the engine or the broker refused an order. A
+// rejected order is logged and reported to the script as None; any other
+// error fails the callback.
+func IsRejection(err error) bool {
+	for _, target := range []error{
+		domain.ErrInsufficientMargin,
+		domain.ErrInsufficientBalance,
+		domain.ErrInvalidQuantity,
+		domain.ErrInvalidLeverage,
+		domain.ErrInvalidPrice,
+		domain.ErrInvalidStopLoss,
+		domain.ErrInvalidTakeProfit,
+		domain.ErrPositionNotFound,
+		domain.ErrPositionNotOpen,
+		domain.ErrOrderNotFound,
+		domain.ErrOrderNotPending,
+		domain.ErrPriceNotAvailable,
+		domain.ErrAccountLocked,
+		ErrNotSupported,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNotSupported is returned by brokers for operations they cannot perform,
+// e.g. limit orders in a backtest
+var ErrNotSupported = errors.New("not supported")
+
+// tradeModule builds the trade module bound to the instance's broker
+func (in *Instance) tradeModule() *starlarkstruct.Module {
+	return &starlarkstruct.Module{
+		Name: "trade",
+		Members: starlark.StringDict{
+			"balance":  starlark.NewBuiltin("trade.balance", in.balance),
+			"position": starlark.NewBuiltin("trade.position", in.position),
+			"orders":   starlark.NewBuiltin("trade.orders", in.orders),
+			"buy":      starlark.NewBuiltin("trade.buy", in.place(domain.OrderSideBuy)),
+			"sell":     starlark.NewBuiltin("trade.sell", in.place(domain.OrderSideSell)),
+			"cancel":   starlark.NewBuiltin("trade.cancel", in.cancel),
+			"close":    starlark.NewBuiltin("trade.close", in.close),
+		},
+	}
+}
+
+// balance implements trade.balance()
+func (in *Instance) balance(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	balance, err := in.broker.Balance(threadContext(thread))
+	if err != nil {
+		return nil, err
+	}
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"balance":          decimalValue(balance.Balance),
+		"equity":           decimalValue(balance.Equity),
+		"available_margin": decimalValue(balance.AvailableMargin),
+	}), nil
+}
+
+// position implements trade.position(), None when flat
+func (in *Instance) position(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	p, err := in.broker.Position(threadContext(thread))
+	if err != nil || p == nil {
+		return starlark.None, err
+	}
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"side":              starlark.String(p.Side),
+		"quantity":          decimalValue(p.Quantity),
+		"entry_price":       decimalValue(p.EntryPrice),
+		"leverage":          starlark.MakeInt(p.Leverage),
+		"unrealized_pnl":    decimalValue(p.UnrealizedPnL),
+		"liquidation_price": decimalValue(p.LiquidationPrice),
+	}), nil
+}
+
+// orders implements trade.orders()
+func (in *Instance) orders(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	orders, err := in.broker.Orders(threadContext(thread))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]starlark.Value, len(orders))
+	for i, o := range orders {
+		list[i] = starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+			"id":       starlark.MakeInt64(int64(o.ID)),
+			"side":     starlark.String(o.Side),
+			"price":    decimalValue(o.Price),
+			"quantity": decimalValue(o.Quantity),
+		})
+	}
+	return starlark.NewList(list), nil
+}
+
+// place implements trade.buy and trade.sell:
+// (quantity, price=None, leverage=1, stop_loss=None, take_profit=None).
+// Returns the order ID, or None if the order was rejected.
+func (in *Instance) place(side domain.OrderSide) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var quantity, price, stopLoss, takeProfit starlark.Value = nil, starlark.None, starlark.None, starlark.None
+		leverage := 1
+		err := starlark.UnpackArgs(b.Name(), args, kwargs,
+			"quantity", &quantity,
+			"price?", &price,
+			"leverage?", &leverage,
+			"stop_loss?", &stopLoss,
+			"take_profit?", &takeProfit,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		order := Order{Side: side, Leverage: leverage}
+		q, err := toDecimal(b.Name(), "quantity", quantity)
+		if err != nil {
+			return nil, err
+		}
+		order.Quantity = q.Truncate(quantityPlaces)
+		if order.Price, err = optionalDecimal(b.Name(), "price", price); err != nil {
+			return nil, err
+		}
+		if order.StopLoss, err = optionalDecimal(b.Name(), "stop_loss", stopLoss); err != nil {
+			return nil, err
+		}
+		if order.TakeProfit, err = optionalDecimal(b.Name(), "take_profit", takeProfit); err != nil {
+			return nil, err
+		}
+
+		id, err := in.broker.Place(threadContext(thread), order)
+		if err != nil {
+			return in.rejected(b, err)
+		}
+		return starlark.MakeInt64(int64(id)), nil
+	}
+}
+
+// cancel implements trade.cancel(order_id), False if the order is gone
+func (in *Instance) cancel(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var id int64
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "order_id", &id); err != nil {
+		return nil, err
+	}
+	if err := in.broker.Cancel(threadContext(thread), domain.OrderID(id)); err != nil {
+		if _, err := in.rejected(b, err); err != nil {
+			return nil, err
+		}
+		return starlark.False, nil
+	}
+	return starlark.True, nil
+}
+
+// close implements trade.close(quantity=None), False when there was nothing to close
+func (in *Instance) close(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var quantity starlark.Value = starlark.None
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "quantity?", &quantity); err != nil {
+		return nil, err
+	}
+	q, err := optionalDecimal(b.Name(), "quantity", quantity)
+	if err != nil {
+		return nil, err
+	}
+	if q != nil {
+		truncated := q.Truncate(quantityPlaces)
+		q = &truncated
+	}
+	closed, err := in.broker.Close(threadContext(thread), q)
+	if err != nil {
+		if _, err := in.rejected(b, err); err != nil {
+			return nil, err
+		}
+		return starlark.False, nil
+	}
+	return starlark.Bool(closed), nil
+}
+
+// rejected logs a refused order and returns None; other errors fail the call
+func (in *Instance) rejected(b *starlark.Builtin, err error) (starlark.Value, error) {
+	if !IsRejection(err) {
+		return nil, err
+	}
+	in.log(domain.ScriptLogError, fmt.Sprintf("%s rejected: %v", b.Name(), err))
+	return starlark.None, nil
+}
+
+func threadContext(thread *starlark.Thread) context.Context {
+	if ctx, ok := thread.Local(contextKey).(context.Context); ok {
+		return ctx
+	}
+	return context.Background()
+}
+
+func toDecimal(fn, name string, v starlark.Value) (decimal.Decimal, error) {
+	f, ok := starlark.AsFloat(v)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%s: %s must be a number, got %s", fn, name, v.Type())
+	}
+	return decimal.NewFromFloat(f), nil
+}
+
+func optionalDecimal(fn, name string, v starlark.Value) (*decimal.Decimal, error) {
+	if v == starlark.None {
+		return nil, nil
+	}
+	d, err := toDecimal(fn, name, v)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func decimalValue(d decimal.Decimal) starlark.Float {
+	return starlark.Float(d.InexactFloat64())
+}
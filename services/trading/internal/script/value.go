@@ -0,0 +1,182 @@
+package script
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+
+	"trading/internal/domain"
+)
+
+// maxStateDepth bounds the nesting of the state dict
+const maxStateDepth = 16
+
+func tickValue(t Tick) starlark.Value {
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"symbol": starlark.String(t.Symbol),
+		"bid":    starlark.Float(t.Bid),
+		"ask":    starlark.Float(t.Ask),
+		"mid":    starlark.Float((t.Bid + t.Ask) / 2),
+		"time":   starlark.String(t.Time.UTC().Format(time.RFC3339)),
+	})
+}
+
+func fillValue(f Fill) starlark.Value {
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"order_id": starlark.MakeInt64(int64(f.OrderID)),
+		"trade_id": starlark.MakeInt64(int64(f.TradeID)),
+		"type":     starlark.String(f.Type),
+		"side":     starlark.String(f.Side),
+		"quantity": starlark.Float(f.Quantity),
+		"price":    starlark.Float(f.Price),
+		"fee":      starlark.Float(f.Fee),
+		"pnl":      starlark.Float(f.PnL),
+		"time":     starlark.String(f.Time.UTC().Format(time.RFC3339)),
+	})
+}
+
+// decodeState turns the stored JSON object into the dict a callback mutates
+func decodeState(state string) (*starlark.Dict, error) {
+	if state == "" {
+		return starlark.NewDict(0), nil
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(state)))
+	dec.UseNumber()
+	var raw map[string]interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode state: %w", err)
+	}
+	v, err := fromJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*starlark.Dict), nil
+}
+
+// encodeState stores the dict as a JSON object. Only None, bools, numbers,
+// strings, lists, tuples and dicts with string keys can be kept.
+func encodeState(dict *starlark.Dict) (string, error) {
+	raw, err := toJSON(dict, 0)
+	if err != nil {
+		return "", fmt.Errorf("state: %w", err)
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return "", fmt.Errorf("state: %w", err)
+	}
+	if len(data) > domain.MaxScriptStateSize {
+		return "", fmt.Errorf("state: larger than %d bytes", domain.MaxScriptStateSize)
+	}
+	return string(data), nil
+}
+
+func fromJSON(v interface{}) (starlark.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return starlark.None, nil
+	case bool:
+		return starlark.Bool(v), nil
+	case string:
+		return starlark.String(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return starlark.MakeInt64(i), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return starlark.Float(f), nil
+	case []interface{}:
+		list := make([]starlark.Value, len(v))
+		for i, item := range v {
+			value, err := fromJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return starlark.NewList(list), nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		dict := starlark.NewDict(len(v))
+		for _, k := range keys {
+			value, err := fromJSON(v[k])
+			if err != nil {
+				return nil, err
+			}
+			if err := dict.SetKey(starlark.String(k), value); err != nil {
+				return nil, err
+			}
+		}
+		return dict, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON value %T", v)
+}
+
+func toJSON(v starlark.Value, depth int) (interface{}, error) {
+	if depth > maxStateDepth {
+		return nil, fmt.Errorf("nested deeper than %d levels", maxStateDepth)
+	}
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.Int:
+		i, ok := v.Int64()
+		if !ok {
+			return nil, fmt.Errorf("integer %s is too large", v)
+		}
+		return i, nil
+	case starlark.Float:
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("cannot store %s", v)
+		}
+		return f, nil
+	case starlark.String:
+		return string(v), nil
+	case *starlark.List:
+		return sequenceToJSON(v, depth)
+	case starlark.Tuple:
+		return sequenceToJSON(v, depth)
+	case *starlark.Dict:
+		obj := make(map[string]interface{}, v.Len())
+		for _, item := range v.Items() {
+			k, ok := item[0].(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("dict keys must be strings, got %s", item[0].Type())
+			}
+			value, err := toJSON(item[1], depth+1)
+			if err != nil {
+				return nil, err
+			}
+			obj[string(k)] = value
+		}
+		return obj, nil
+	}
+	return nil, fmt.Errorf("cannot store a %s", v.Type())
+}
+
+func sequenceToJSON(seq starlark.Indexable, depth int) (interface{}, error) {
+	list := make([]interface{}, seq.Len())
+	for i := range list {
+		value, err := toJSON(seq.Index(i), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = value
+	}
+	return list, nil
+}
@@ -2,50 +2,543 @@ package account
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
 
 	"trading/internal/domain"
+	"trading/internal/engine"
+	"trading/internal/logger"
 )
 
+// maxAccountsPerUser caps the accounts of a user outside competitions and challenges
+const maxAccountsPerUser = 10
+
 type UseCase struct {
-	accountRepo  domain.AccountRepository
-	positionRepo domain.PositionRepository
+	accountRepo    domain.AccountRepository
+	positionRepo   domain.PositionRepository
+	ledgerRepo     domain.LedgerRepository
+	walletRepo     domain.WalletRepository
+	spotLotRepo    domain.SpotLotRepository
+	compRepo       domain.CompetitionRepository
+	priceCache     domain.PriceCache
+	engine         *engine.Engine
+	initialBalance decimal.Decimal
 }
 
-func NewUseCase(accountRepo domain.AccountRepository, positionRepo domain.PositionRepository) *UseCase {
+func NewUseCase(
+	accountRepo domain.AccountRepository,
+	positionRepo domain.PositionRepository,
+	ledgerRepo domain.LedgerRepository,
+	walletRepo domain.WalletRepository,
+	spotLotRepo domain.SpotLotRepository,
+	compRepo domain.CompetitionRepository,
+	priceCache domain.PriceCache,
+	eng *engine.Engine,
+	initialBalance float64,
+) *UseCase {
 	return &UseCase{
-		accountRepo:  accountRepo,
-		positionRepo: positionRepo,
+		accountRepo:    accountRepo,
+		positionRepo:   positionRepo,
+		ledgerRepo:     ledgerRepo,
+		walletRepo:     walletRepo,
+		spotLotRepo:    spotLotRepo,
+		compRepo:       compRepo,
+		priceCache:     priceCache,
+		engine:         eng,
+		initialBalance: decimal.NewFromFloat(initialBalance),
 	}
 }
 
 type AccountInfo struct {
-	Balance         string `json:"balance"`
-	Equity          string `json:"equity"`
-	UsedMargin      string `json:"used_margin"`
-	AvailableMargin string `json:"available_margin"`
-	UnrealizedPnL   string `json:"unrealized_pnl"`
-	MarginRatio     string `json:"margin_ratio"`
+	ID              int64            `json:"id"`
+	Name            string           `json:"name"`
+	Balance         string           `json:"balance"`
+	Equity          string           `json:"equity"`
+	UsedMargin      string           `json:"used_margin"`
+	AvailableMargin string           `json:"available_margin"`
+	UnrealizedPnL   string           `json:"unrealized_pnl"`
+	MarginRatio     string           `json:"margin_ratio"`
+	CollateralValue string           `json:"collateral_value"`
+	Collateral      []CollateralInfo `json:"collateral"`
+	Season          int              `json:"season"`
+	CompetitionID   *int64           `json:"competition_id,omitempty"`
+	ChallengeID     *int64           `json:"challenge_id,omitempty"`
+	LockedReason    string           `json:"locked_reason,omitempty"`
 }
 
-func (uc *UseCase) GetAccountInfo(ctx context.Context, userID domain.UserID) (*AccountInfo, error) {
-	account, err := uc.accountRepo.GetByUserID(ctx, userID)
+// CollateralInfo is a wallet asset valued in USDT
+type CollateralInfo struct {
+	Asset   string `json:"asset"`
+	Amount  string `json:"amount"`
+	Price   string `json:"price"`
+	Haircut string `json:"haircut"`
+	Value   string `json:"value"`
+}
+
+func (uc *UseCase) GetAccountInfo(ctx context.Context, accountID domain.AccountID) (*AccountInfo, error) {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
+	return uc.accountInfo(ctx, account)
+}
 
-	positions, err := uc.positionRepo.GetOpenByUserID(ctx, userID)
+// ListAccounts returns all accounts of the user, primary first
+func (uc *UseCase) ListAccounts(ctx context.Context, userID domain.UserID) ([]AccountInfo, error) {
+	accounts, err := uc.accountRepo.ListByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	summary := account.CalculateSummary(positions)
+	result := make([]AccountInfo, len(accounts))
+	for i := range accounts {
+		info, err := uc.accountInfo(ctx, &accounts[i])
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *info
+	}
+	return result, nil
+}
+
+// CreateAccount opens a new empty sub-account; it is funded by transfers from the user's other accounts
+func (uc *UseCase) CreateAccount(ctx context.Context, userID domain.UserID, name string) (*AccountInfo, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 50 {
+		return nil, domain.ErrInvalidAccountName
+	}
+
+	account := &domain.Account{
+		UserID:                userID,
+		Name:                  name,
+		Balance:               decimal.Zero,
+		SeasonStartingBalance: decimal.Zero,
+	}
+	if err := uc.accountRepo.CreateSubAccount(ctx, account, maxAccountsPerUser); err != nil {
+		return nil, err
+	}
+
+	logger.Info("account created", "user_id", userID, "account_id", account.ID, "name", name)
+
+	return uc.accountInfo(ctx, account)
+}
+
+// CreateCompetitionAccount opens the user's dedicated account for a competition,
+// funded with the competition's starting balance
+func (uc *UseCase) CreateCompetitionAccount(ctx context.Context, userID domain.UserID, competition *domain.Competition) (*AccountInfo, error) {
+	account := &domain.Account{
+		UserID:                userID,
+		Name:                  competition.AccountName(),
+		Balance:               decimal.Zero,
+		SeasonStartingBalance: competition.StartingBalance,
+		CompetitionID:         &competition.ID,
+	}
+	if err := uc.fund(ctx, account); err != nil {
+		return nil, err
+	}
+
+	logger.Info("competition account created",
+		"user_id", userID,
+		"account_id", account.ID,
+		"competition_id", competition.ID,
+	)
+
+	return uc.accountInfo(ctx, account)
+}
+
+// CreateChallengeAccount opens the account a challenge is traded on,
+// funded with the challenge's starting balance
+func (uc *UseCase) CreateChallengeAccount(ctx context.Context, userID domain.UserID, challenge *domain.Challenge) (*domain.Account, error) {
+	account := &domain.Account{
+		UserID:                userID,
+		Name:                  challenge.AccountName(),
+		Balance:               decimal.Zero,
+		SeasonStartingBalance: challenge.StartingBalance,
+		ChallengeID:           &challenge.ID,
+	}
+	if err := uc.fund(ctx, account); err != nil {
+		return nil, err
+	}
+
+	logger.Info("challenge account created",
+		"user_id", userID,
+		"account_id", account.ID,
+		"challenge_id", challenge.ID,
+	)
+
+	return account, nil
+}
+
+// fund creates the account together with its starting balance as the initial deposit
+func (uc *UseCase) fund(ctx context.Context, account *domain.Account) error {
+	return uc.accountRepo.CreateFunded(ctx, account, &domain.LedgerEntry{
+		Type:        domain.LedgerEntryTypeInitialDeposit,
+		Amount:      account.SeasonStartingBalance,
+		Description: "initial deposit",
+	})
+}
+
+type TransferInput struct {
+	UserID        domain.UserID
+	FromAccountID domain.AccountID
+	ToAccountID   domain.AccountID
+	Amount        decimal.Decimal
+}
+
+// Transfer moves balance between two accounts of the same user.
+// Only free margin of the source account can be moved; competition and challenge accounts are isolated.
+func (uc *UseCase) Transfer(ctx context.Context, input TransferInput) error {
+	if input.FromAccountID == input.ToAccountID || !input.Amount.IsPositive() {
+		return domain.ErrInvalidTransfer
+	}
+
+	from, err := uc.userAccount(ctx, input.UserID, input.FromAccountID)
+	if err != nil {
+		return err
+	}
+	to, err := uc.userAccount(ctx, input.UserID, input.ToAccountID)
+	if err != nil {
+		return err
+	}
+	if from.IsIsolated() || to.IsIsolated() {
+		return domain.ErrIsolatedAccount
+	}
+
+	summary, err := uc.Summary(ctx, from)
+	if err != nil {
+		return err
+	}
+	if summary.AvailableMargin.LessThan(input.Amount) {
+		return domain.ErrInsufficientMargin
+	}
+
+	out := &domain.LedgerEntry{
+		AccountID:   from.ID,
+		Type:        domain.LedgerEntryTypeTransfer,
+		Amount:      input.Amount.Neg(),
+		Description: fmt.Sprintf("transfer to %s", to.Name),
+	}
+	in := &domain.LedgerEntry{
+		AccountID:   to.ID,
+		Type:        domain.LedgerEntryTypeTransfer,
+		Amount:      input.Amount,
+		Description: fmt.Sprintf("transfer from %s", from.Name),
+	}
+	if err := uc.ledgerRepo.PostBatch(ctx, out, in); err != nil {
+		return err
+	}
+
+	logger.Info("transfer completed",
+		"user_id", input.UserID,
+		"from_account_id", from.ID,
+		"to_account_id", to.ID,
+		"amount", input.Amount,
+	)
+
+	return nil
+}
+
+// userAccount loads an account and checks it belongs to the user
+func (uc *UseCase) userAccount(ctx context.Context, userID domain.UserID, accountID domain.AccountID) (*domain.Account, error) {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.UserID != userID {
+		return nil, domain.ErrAccountNotFound
+	}
+	return account, nil
+}
+
+// Summary computes the account's margin summary with its wallet valued as collateral
+func (uc *UseCase) Summary(ctx context.Context, account *domain.Account) (*domain.AccountSummary, error) {
+	positions, err := uc.positionRepo.GetOpenByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := uc.walletRepo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := account.CalculateSummary(positions, uc.engine.CollateralCalc.Value(balances, uc.priceCache))
+	return &summary, nil
+}
+
+func (uc *UseCase) accountInfo(ctx context.Context, account *domain.Account) (*AccountInfo, error) {
+	summary, err := uc.Summary(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	collateral := make([]CollateralInfo, len(summary.Collateral))
+	for i, c := range summary.Collateral {
+		collateral[i] = CollateralInfo{
+			Asset:   string(c.Asset),
+			Amount:  c.Amount.String(),
+			Price:   c.Price.String(),
+			Haircut: c.Haircut.String(),
+			Value:   c.Value.StringFixed(2),
+		}
+	}
+
+	var competitionID, challengeID *int64
+	if account.CompetitionID != nil {
+		id := int64(*account.CompetitionID)
+		competitionID = &id
+	}
+	if account.ChallengeID != nil {
+		id := int64(*account.ChallengeID)
+		challengeID = &id
+	}
 
 	return &AccountInfo{
+		ID:              int64(account.ID),
+		Name:            account.Name,
 		Balance:         summary.Balance.StringFixed(2),
 		Equity:          summary.Equity.StringFixed(2),
 		UsedMargin:      summary.UsedMargin.StringFixed(2),
 		AvailableMargin: summary.AvailableMargin.StringFixed(2),
 		UnrealizedPnL:   summary.UnrealizedPnL.StringFixed(2),
 		MarginRatio:     summary.MarginRatio.StringFixed(4),
+		CollateralValue: summary.CollateralValue.StringFixed(2),
+		Collateral:      collateral,
+		Season:          account.Season,
+		CompetitionID:   competitionID,
+		ChallengeID:     challengeID,
+		LockedReason:    account.LockedReason,
 	}, nil
 }
+
+type ConvertInput struct {
+	AccountID domain.AccountID
+	From      domain.Asset
+	To        domain.Asset
+	Amount    decimal.Decimal // in units of From
+}
+
+type ConvertOutput struct {
+	From     domain.Asset
+	To       domain.Asset
+	Amount   decimal.Decimal
+	Received decimal.Decimal
+}
+
+// Convert exchanges one wallet asset for another at current prices.
+// A conversion may not reduce available margin below zero. Locked accounts cannot
+// convert, and competition accounts only while the competition runs and in its symbols.
+func (uc *UseCase) Convert(ctx context.Context, input ConvertInput) (*ConvertOutput, error) {
+	if !input.From.IsValid() || !input.To.IsValid() {
+		return nil, domain.ErrInvalidAsset
+	}
+	if input.From == input.To || !input.Amount.IsPositive() {
+		return nil, domain.ErrInvalidConversion
+	}
+
+	account, err := uc.accountRepo.GetByID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkConversion(ctx, account, input); err != nil {
+		return nil, err
+	}
+
+	return uc.convert(ctx, account, input)
+}
+
+// checkConversion applies the rules orders of the account are placed under
+func (uc *UseCase) checkConversion(ctx context.Context, account *domain.Account, input ConvertInput) error {
+	if account.IsLocked() {
+		return domain.ErrAccountLocked
+	}
+	if !account.IsCompetition() {
+		return nil
+	}
+	competition, err := uc.compRepo.GetByID(ctx, *account.CompetitionID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, asset := range []domain.Asset{input.From, input.To} {
+		if asset == domain.QuoteAsset {
+			continue
+		}
+		if err := competition.CheckOrder(asset.Symbol(), 1, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convert runs a validated conversion. Settlements use it directly so that
+// locked and finished accounts can still be converted to USDT.
+func (uc *UseCase) convert(ctx context.Context, account *domain.Account, input ConvertInput) (*ConvertOutput, error) {
+	received, err := uc.engine.CollateralCalc.Convert(input.From, input.To, input.Amount, uc.priceCache)
+	if err != nil {
+		return nil, err
+	}
+	if !received.IsPositive() {
+		return nil, domain.ErrInvalidConversion
+	}
+
+	positions, err := uc.positionRepo.GetOpenByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := uc.walletRepo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Value the wallet as it would look after the conversion
+	after := *account
+	afterBalances := applyConversion(&after, balances, input.From, input.Amount.Neg())
+	afterBalances = applyConversion(&after, afterBalances, input.To, received)
+
+	calc := uc.engine.CollateralCalc
+	before := account.CalculateSummary(positions, calc.Value(balances, uc.priceCache))
+	afterSummary := after.CalculateSummary(positions, calc.Value(afterBalances, uc.priceCache))
+	if afterSummary.AvailableMargin.IsNegative() && afterSummary.AvailableMargin.LessThan(before.AvailableMargin) {
+		return nil, domain.ErrInsufficientMargin
+	}
+
+	description := fmt.Sprintf("convert %s %s to %s %s", input.Amount, input.From, received, input.To)
+	err = uc.ledgerRepo.PostBatch(ctx,
+		&domain.LedgerEntry{
+			AccountID:   account.ID,
+			Type:        domain.LedgerEntryTypeConversion,
+			Asset:       input.From,
+			Amount:      input.Amount.Neg(),
+			Description: description,
+		},
+		&domain.LedgerEntry{
+			AccountID:   account.ID,
+			Type:        domain.LedgerEntryTypeConversion,
+			Asset:       input.To,
+			Amount:      received,
+			Description: description,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.From != domain.QuoteAsset {
+		if err := uc.releaseLots(ctx, account.ID, input.From, input.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Info("assets converted",
+		"account_id", account.ID,
+		"from", input.From,
+		"to", input.To,
+		"amount", input.Amount,
+		"received", received,
+	)
+
+	return &ConvertOutput{
+		From:     input.From,
+		To:       input.To,
+		Amount:   input.Amount,
+		Received: received,
+	}, nil
+}
+
+// ConvertAllToQuote sells every non-USDT wallet asset for USDT at current prices
+func (uc *UseCase) ConvertAllToQuote(ctx context.Context, accountID domain.AccountID) error {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	balances, err := uc.walletRepo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range balances {
+		if !b.Amount.IsPositive() {
+			continue
+		}
+		_, err := uc.convert(ctx, account, ConvertInput{
+			AccountID: accountID,
+			From:      b.Asset,
+			To:        domain.QuoteAsset,
+			Amount:    b.Amount,
+		})
+		if err != nil {
+			return fmt.Errorf("convert %s: %w", b.Asset, err)
+		}
+	}
+	return nil
+}
+
+// releaseLots removes converted quantity from the spot cost basis lots, oldest first
+func (uc *UseCase) releaseLots(ctx context.Context, accountID domain.AccountID, asset domain.Asset, quantity decimal.Decimal) error {
+	lots, err := uc.spotLotRepo.GetOpenByAccountIDAndAsset(ctx, accountID, asset)
+	if err != nil {
+		return err
+	}
+
+	for i := range lots {
+		if !quantity.IsPositive() {
+			break
+		}
+		consumed, _ := lots[i].Consume(quantity, lots[i].Price)
+		quantity = quantity.Sub(consumed)
+		if err := uc.spotLotRepo.Update(ctx, &lots[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyConversion returns the wallet with delta applied to asset.
+// USDT changes are applied to the account balance instead.
+func applyConversion(account *domain.Account, balances []domain.AssetBalance, asset domain.Asset, delta decimal.Decimal) []domain.AssetBalance {
+	if asset == domain.QuoteAsset {
+		account.Balance = account.Balance.Add(delta)
+		return balances
+	}
+
+	result := make([]domain.AssetBalance, 0, len(balances)+1)
+	found := false
+	for _, b := range balances {
+		if b.Asset == asset {
+			b.Amount = b.Amount.Add(delta)
+			found = true
+		}
+		result = append(result, b)
+	}
+	if !found {
+		result = append(result, domain.AssetBalance{AccountID: account.ID, Asset: asset, Amount: delta})
+	}
+	return result
+}
+
+// GetLedger returns the account's balance history, newest first
+func (uc *UseCase) GetLedger(ctx context.Context, accountID domain.AccountID, filter domain.LedgerFilter) ([]domain.LedgerEntry, error) {
+	for _, t := range filter.Types {
+		if !t.IsValid() {
+			return nil, domain.ErrInvalidLedgerEntryType
+		}
+	}
+	if filter.Asset != "" && !filter.Asset.IsValid() {
+		return nil, domain.ErrInvalidAsset
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	return uc.ledgerRepo.GetByAccountID(ctx, accountID, filter)
+}
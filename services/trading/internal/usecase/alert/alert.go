@@ -0,0 +1,419 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+)
+
+const (
+	maxActiveAlerts = 50
+	maxNoteLength   = 255
+	maxWindow       = 24 * time.Hour
+	minWindow       = time.Minute
+	samplePeriod    = 10 * time.Second // price history resolution for CHANGE_PERCENT
+)
+
+// pricePoint aggregates the prices of one sample period
+type pricePoint struct {
+	low   decimal.Decimal
+	high  decimal.Decimal
+	start time.Time
+	end   time.Time
+}
+
+type UseCase struct {
+	alertRepo        domain.PriceAlertRepository
+	notificationRepo domain.NotificationRepository
+	priceCache       domain.PriceCache
+	symbols          map[string]bool
+
+	// Active alerts by symbol, reloaded lazily after any change
+	mu      sync.Mutex
+	loaded  bool
+	active  map[string][]domain.PriceAlert
+	history map[string][]pricePoint
+}
+
+func NewUseCase(
+	alertRepo domain.PriceAlertRepository,
+	notificationRepo domain.NotificationRepository,
+	priceCache domain.PriceCache,
+	instruments []domain.Instrument,
+) *UseCase {
+	symbols := make(map[string]bool, len(instruments))
+	for _, inst := range instruments {
+		symbols[inst.PriceSymbol] = true
+	}
+	return &UseCase{
+		alertRepo:        alertRepo,
+		notificationRepo: notificationRepo,
+		priceCache:       priceCache,
+		symbols:          symbols,
+		active:           make(map[string][]domain.PriceAlert),
+		history:          make(map[string][]pricePoint),
+	}
+}
+
+type CreateAlertInput struct {
+	UserID        domain.UserID
+	Symbol        string
+	Condition     domain.AlertCondition
+	TargetPrice   *decimal.Decimal
+	ChangePercent *decimal.Decimal
+	Window        time.Duration
+	Note          string
+}
+
+type UpdateAlertInput struct {
+	TargetPrice   *decimal.Decimal
+	ChangePercent *decimal.Decimal
+	Window        *time.Duration
+	Note          *string
+}
+
+func (uc *UseCase) CreateAlert(ctx context.Context, input CreateAlertInput) (*domain.PriceAlert, error) {
+	if !uc.symbols[input.Symbol] {
+		return nil, domain.ErrSymbolNotSupported
+	}
+
+	count, err := uc.alertRepo.CountActiveByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxActiveAlerts {
+		return nil, domain.ErrTooManyAlerts
+	}
+
+	alert := &domain.PriceAlert{
+		UserID:        input.UserID,
+		Symbol:        input.Symbol,
+		Condition:     input.Condition,
+		TargetPrice:   input.TargetPrice,
+		ChangePercent: input.ChangePercent,
+		Window:        input.Window,
+		Note:          input.Note,
+		Status:        domain.AlertStatusActive,
+	}
+	if err := uc.arm(alert); err != nil {
+		return nil, err
+	}
+
+	if err := uc.alertRepo.Create(ctx, alert); err != nil {
+		return nil, err
+	}
+	uc.invalidate()
+
+	logger.Info("price alert created",
+		"alert_id", alert.ID,
+		"user_id", alert.UserID,
+		"symbol", alert.Symbol,
+		"condition", alert.Condition,
+	)
+
+	return alert, nil
+}
+
+func (uc *UseCase) GetAlert(ctx context.Context, userID domain.UserID, alertID domain.AlertID) (*domain.PriceAlert, error) {
+	alert, err := uc.alertRepo.GetByID(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if alert.UserID != userID {
+		return nil, domain.ErrAlertNotFound
+	}
+
+	return alert, nil
+}
+
+func (uc *UseCase) GetAlerts(ctx context.Context, userID domain.UserID, status domain.AlertStatus) ([]domain.PriceAlert, error) {
+	if status != "" && status != domain.AlertStatusActive && status != domain.AlertStatusTriggered {
+		return nil, domain.ErrInvalidAlert
+	}
+	return uc.alertRepo.GetByUserID(ctx, userID, status)
+}
+
+// UpdateAlert changes the alert parameters and re-arms it, so a triggered
+// alert becomes active again
+func (uc *UseCase) UpdateAlert(ctx context.Context, userID domain.UserID, alertID domain.AlertID, input UpdateAlertInput) (*domain.PriceAlert, error) {
+	alert, err := uc.GetAlert(ctx, userID, alertID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !alert.IsActive() {
+		count, err := uc.alertRepo.CountActiveByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= maxActiveAlerts {
+			return nil, domain.ErrTooManyAlerts
+		}
+	}
+
+	if input.TargetPrice != nil {
+		alert.TargetPrice = input.TargetPrice
+	}
+	if input.ChangePercent != nil {
+		alert.ChangePercent = input.ChangePercent
+	}
+	if input.Window != nil {
+		alert.Window = *input.Window
+	}
+	if input.Note != nil {
+		alert.Note = *input.Note
+	}
+
+	alert.Status = domain.AlertStatusActive
+	alert.TriggeredPrice = nil
+	alert.TriggeredAt = nil
+	if err := uc.arm(alert); err != nil {
+		return nil, err
+	}
+
+	if err := uc.alertRepo.Update(ctx, alert); err != nil {
+		return nil, err
+	}
+	uc.invalidate()
+
+	return alert, nil
+}
+
+func (uc *UseCase) DeleteAlert(ctx context.Context, userID domain.UserID, alertID domain.AlertID) error {
+	if _, err := uc.GetAlert(ctx, userID, alertID); err != nil {
+		return err
+	}
+
+	if err := uc.alertRepo.Delete(ctx, alertID); err != nil {
+		return err
+	}
+	uc.invalidate()
+
+	return nil
+}
+
+// arm validates the alert parameters and captures the reference price of CROSS alerts
+func (uc *UseCase) arm(alert *domain.PriceAlert) error {
+	if len(alert.Note) > maxNoteLength {
+		return domain.ErrInvalidAlert
+	}
+
+	switch alert.Condition {
+	case domain.AlertConditionAbove, domain.AlertConditionBelow, domain.AlertConditionCross:
+		if alert.TargetPrice == nil || !alert.TargetPrice.IsPositive() {
+			return domain.ErrInvalidAlert
+		}
+		alert.ChangePercent = nil
+		alert.Window = 0
+	case domain.AlertConditionChangePercent:
+		if alert.ChangePercent == nil || !alert.ChangePercent.IsPositive() {
+			return domain.ErrInvalidAlert
+		}
+		if alert.Window < minWindow || alert.Window > maxWindow {
+			return domain.ErrInvalidAlert
+		}
+		alert.TargetPrice = nil
+	default:
+		return domain.ErrInvalidAlert
+	}
+
+	alert.ReferencePrice = nil
+	if alert.Condition == domain.AlertConditionCross {
+		price, ok := uc.priceCache.Get(alert.Symbol)
+		if !ok {
+			return domain.ErrPriceNotAvailable
+		}
+		ref := decimal.NewFromFloat(price.Mid())
+		if ref.Equal(*alert.TargetPrice) {
+			return domain.ErrInvalidAlert
+		}
+		alert.ReferencePrice = &ref
+	}
+
+	return nil
+}
+
+func (uc *UseCase) invalidate() {
+	uc.mu.Lock()
+	uc.loaded = false
+	uc.mu.Unlock()
+}
+
+// Evaluate checks the symbol's active alerts against a new price. Triggered
+// alerts are deactivated and a notification is stored for each; the
+// notifications are returned for real-time delivery.
+func (uc *UseCase) Evaluate(ctx context.Context, price *domain.Price) ([]domain.Notification, error) {
+	mid := decimal.NewFromFloat(price.Mid())
+	now := time.Now()
+
+	triggered, err := uc.match(ctx, price.Symbol, mid, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications []domain.Notification
+	for i := range triggered {
+		alert := &triggered[i]
+		alert.TriggeredPrice = &mid
+		alert.TriggeredAt = &now
+
+		ok, err := uc.alertRepo.MarkTriggered(ctx, alert)
+		if err != nil {
+			logger.Error("failed to trigger alert", "alert_id", alert.ID, "error", err)
+			continue
+		}
+		if !ok {
+			continue // deleted, updated or triggered concurrently
+		}
+
+		alertID := alert.ID
+		notification := domain.Notification{
+			UserID:  alert.UserID,
+			Type:    domain.NotificationTypePriceAlert,
+			Title:   alertTitle(alert),
+			Message: alert.Note,
+			AlertID: &alertID,
+		}
+		if err := uc.notificationRepo.Create(ctx, &notification); err != nil {
+			logger.Error("failed to store notification", "alert_id", alert.ID, "error", err)
+			continue
+		}
+
+		logger.Info("price alert triggered",
+			"alert_id", alert.ID,
+			"user_id", alert.UserID,
+			"symbol", alert.Symbol,
+			"price", mid,
+		)
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+// match records the price in the symbol history and returns the alerts whose
+// condition is met, removing them from the active set
+func (uc *UseCase) match(ctx context.Context, symbol string, mid decimal.Decimal, now time.Time) ([]domain.PriceAlert, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	history := uc.history[symbol]
+	if n := len(history); n > 0 && now.Sub(history[n-1].start) < samplePeriod {
+		last := &history[n-1]
+		last.low = decimal.Min(last.low, mid)
+		last.high = decimal.Max(last.high, mid)
+		last.end = now
+	} else {
+		history = append(history, pricePoint{low: mid, high: mid, start: now, end: now})
+	}
+	for len(history) > 0 && now.Sub(history[0].end) > maxWindow {
+		history = history[1:]
+	}
+	uc.history[symbol] = history
+
+	if !uc.loaded {
+		alerts, err := uc.alertRepo.GetAllActive(ctx)
+		if err != nil {
+			return nil, err
+		}
+		uc.active = make(map[string][]domain.PriceAlert)
+		for _, a := range alerts {
+			uc.active[a.Symbol] = append(uc.active[a.Symbol], a)
+		}
+		uc.loaded = true
+	}
+
+	alerts := uc.active[symbol]
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+
+	var triggered, remaining []domain.PriceAlert
+	for _, a := range alerts {
+		var hit bool
+		if a.Condition == domain.AlertConditionChangePercent {
+			// Moves before the alert was armed do not count
+			since := now.Add(-a.Window)
+			if a.UpdatedAt.After(since) {
+				since = a.UpdatedAt
+			}
+			low, high := priceRange(history, since, mid)
+			hit = a.CheckChange(mid, low, high)
+		} else {
+			hit = a.CheckLevel(mid)
+		}
+
+		if hit {
+			triggered = append(triggered, a)
+		} else {
+			remaining = append(remaining, a)
+		}
+	}
+	uc.active[symbol] = remaining
+
+	return triggered, nil
+}
+
+// priceRange returns the lowest and highest price since the given time, including
+// the current one. Precision is one sample period.
+func priceRange(history []pricePoint, since time.Time, current decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	low, high := current, current
+	for _, p := range history {
+		if p.end.Before(since) {
+			continue
+		}
+		low = decimal.Min(low, p.low)
+		high = decimal.Max(high, p.high)
+	}
+	return low, high
+}
+
+func alertTitle(alert *domain.PriceAlert) string {
+	switch alert.Condition {
+	case domain.AlertConditionAbove:
+		return fmt.Sprintf("%s is above %s", alert.Symbol, alert.TargetPrice)
+	case domain.AlertConditionBelow:
+		return fmt.Sprintf("%s is below %s", alert.Symbol, alert.TargetPrice)
+	case domain.AlertConditionCross:
+		return fmt.Sprintf("%s crossed %s", alert.Symbol, alert.TargetPrice)
+	}
+	return fmt.Sprintf("%s moved %s%% in %s", alert.Symbol, alert.ChangePercent, alert.Window)
+}
+
+// GetNotifications returns the user's inbox, newest first, and the unread count
+func (uc *UseCase) GetNotifications(ctx context.Context, userID domain.UserID, unreadOnly bool, limit, offset int) ([]domain.Notification, int, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	notifications, err := uc.notificationRepo.GetByUserID(ctx, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	unread, err := uc.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, unread, nil
+}
+
+func (uc *UseCase) MarkNotificationRead(ctx context.Context, userID domain.UserID, id domain.NotificationID) error {
+	return uc.notificationRepo.MarkRead(ctx, userID, id)
+}
+
+func (uc *UseCase) MarkAllNotificationsRead(ctx context.Context, userID domain.UserID) error {
+	return uc.notificationRepo.MarkAllRead(ctx, userID)
+}
@@ -0,0 +1,571 @@
+package algo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/backtest"
+	"trading/internal/domain"
+	"trading/internal/logger"
+	orderuc "trading/internal/usecase/order"
+)
+
+const (
+	// runInterval is how often due slices are executed; resting limit slices
+	// are also caught up here with fills their events were not delivered for
+	runInterval = 5 * time.Second
+	// maxActivePerAccount caps the pending algo orders of an account
+	maxActivePerAccount = 10
+)
+
+type UseCase struct {
+	algoRepo   domain.AlgoOrderRepository
+	orderRepo  domain.OrderRepository
+	orderUC    *orderuc.UseCase
+	priceCache domain.PriceCache
+	dataDir    string // candle files for VWAP volume profiles
+
+	// mu serializes the scheduler, slice events and cancellations from the API
+	mu sync.Mutex
+}
+
+func NewUseCase(
+	algoRepo domain.AlgoOrderRepository,
+	orderRepo domain.OrderRepository,
+	priceCache domain.PriceCache,
+	dataDir string,
+) *UseCase {
+	return &UseCase{
+		algoRepo:   algoRepo,
+		orderRepo:  orderRepo,
+		priceCache: priceCache,
+		dataDir:    dataDir,
+	}
+}
+
+// SetTrading provides the use case slices are placed with. It publishes their
+// fills to this use case, so it is constructed after it.
+func (uc *UseCase) SetTrading(orderUC *orderuc.UseCase) {
+	uc.orderUC = orderUC
+}
+
+// Start runs the scheduler until the context is cancelled
+func (uc *UseCase) Start(ctx context.Context) {
+	logger.Info("algo order scheduler started")
+
+	ticker := time.NewTicker(runInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("algo order scheduler stopping")
+			return
+		case now := <-ticker.C:
+			uc.RunDue(ctx, now)
+		}
+	}
+}
+
+// Execution is an algo order: the parent order and its schedule
+type Execution struct {
+	Order *domain.Order
+	Algo  *domain.AlgoOrder
+}
+
+type CreateInput struct {
+	AccountID    domain.AccountID
+	Symbol       string
+	Side         domain.OrderSide
+	Type         domain.OrderType // TWAP, VWAP, ICEBERG or SCALE
+	Quantity     decimal.Decimal
+	Price        decimal.Decimal // the ICEBERG limit; optional on TWAP and VWAP, whose slices rest while the market is beyond it
+	Leverage     int
+	Duration     time.Duration            // TWAP and VWAP
+	Slices       int                      // TWAP, VWAP and SCALE
+	Dataset      string                   // VWAP only: candle file whose time of day volume shapes the slices
+	Visible      decimal.Decimal          // ICEBERG only: quantity shown at a time
+	PriceFrom    decimal.Decimal          // SCALE only: limit of the first slice
+	PriceTo      decimal.Decimal          // SCALE only: limit of the last slice
+	Distribution domain.ScaleDistribution // SCALE only, LINEAR by default
+}
+
+// Create places the parent order and its slices, and executes what is due
+// right away. TWAP slices are equal; VWAP slices follow the volume the
+// dataset's candles traded at the same time of day. An iceberg places its
+// first visible slice, a scale order all of its limit orders.
+func (uc *UseCase) Create(ctx context.Context, input CreateInput) (*Execution, error) {
+	start := time.Now().UTC().Truncate(time.Second)
+	algo := &domain.AlgoOrder{
+		SliceCount: input.Slices,
+		StartAt:    start,
+	}
+
+	instrument, ok := uc.orderUC.Instrument(input.Symbol)
+	if !ok {
+		return nil, domain.ErrSymbolNotSupported
+	}
+	// Resting limit slices are filled as perpetual orders only
+	resting := input.Type == domain.OrderTypeIceberg || input.Type == domain.OrderTypeScale
+	if instrument.IsSpot() && (input.Price.IsPositive() || resting) {
+		return nil, fmt.Errorf("%w: limit prices are only supported on perpetuals", domain.ErrInvalidAlgoOrder)
+	}
+
+	if err := uc.schedule(input, algo); err != nil {
+		return nil, err
+	}
+
+	price, ok := uc.priceCache.Get(instrument.PriceSymbol)
+	if !ok {
+		return nil, domain.ErrPriceNotAvailable
+	}
+	algo.ArrivalPrice = decimal.NewFromFloat(price.Ask)
+	if input.Side == domain.OrderSideSell {
+		algo.ArrivalPrice = decimal.NewFromFloat(price.Bid)
+	}
+
+	pending, err := uc.orderUC.GetPendingOrders(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	active := 0
+	for i := range pending {
+		if pending[i].IsAlgo() {
+			active++
+		}
+	}
+	if active >= maxActivePerAccount {
+		return nil, domain.ErrTooManyAlgoOrders
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	parent, err := uc.orderUC.PlaceParentOrder(ctx, orderuc.PlaceOrderInput{
+		AccountID: input.AccountID,
+		Symbol:    input.Symbol,
+		Side:      input.Side,
+		Type:      input.Type,
+		Quantity:  input.Quantity,
+		Price:     input.Price,
+		Leverage:  input.Leverage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	algo.OrderID = parent.ID
+	for i := range algo.Slices {
+		algo.Slices[i].OrderID = parent.ID
+	}
+	if err := uc.algoRepo.Create(ctx, algo); err != nil {
+		return nil, err
+	}
+
+	logger.Info("algo order created",
+		"order_id", parent.ID,
+		"type", parent.Type,
+		"symbol", parent.Symbol,
+		"quantity", parent.Quantity,
+		"duration", algo.Duration,
+		"slices", algo.SliceCount,
+	)
+
+	if err := uc.advance(ctx, parent, algo, start); err != nil {
+		return nil, err
+	}
+	return &Execution{Order: parent, Algo: algo}, nil
+}
+
+// schedule splits the input quantity into the slices of its order type
+func (uc *UseCase) schedule(input CreateInput, algo *domain.AlgoOrder) error {
+	switch input.Type {
+	case domain.OrderTypeIceberg:
+		return algo.Iceberg(input.Quantity, input.Visible, input.Price)
+	case domain.OrderTypeScale:
+		if !input.Price.IsZero() {
+			return fmt.Errorf("%w: scale orders take price_from and price_to", domain.ErrInvalidAlgoOrder)
+		}
+		algo.Distribution = input.Distribution
+		if algo.Distribution == "" {
+			algo.Distribution = domain.ScaleLinear
+		}
+		if err := algo.Scale(input.Quantity, input.PriceFrom, input.PriceTo); err != nil {
+			return fmt.Errorf("%w: scale orders need 2-100 slices, distinct positive bounds and a LINEAR or GEOMETRIC distribution", err)
+		}
+		return nil
+	}
+
+	algo.Duration = input.Duration.Truncate(time.Second)
+	if err := algo.Validate(); err != nil {
+		return err
+	}
+	weights, err := uc.weights(input, algo)
+	if err != nil {
+		return err
+	}
+	if err := algo.Schedule(input.Quantity, weights); err != nil {
+		return fmt.Errorf("%w: every slice needs a positive quantity", err)
+	}
+	return nil
+}
+
+// weights returns the relative size of each TWAP or VWAP slice
+func (uc *UseCase) weights(input CreateInput, algo *domain.AlgoOrder) ([]float64, error) {
+	switch input.Type {
+	case domain.OrderTypeTWAP:
+		weights := make([]float64, algo.SliceCount)
+		for i := range weights {
+			weights[i] = 1
+		}
+		return weights, nil
+	case domain.OrderTypeVWAP:
+		if input.Dataset == "" {
+			return nil, fmt.Errorf("%w: dataset is required for VWAP", domain.ErrInvalidAlgoOrder)
+		}
+		candles, err := backtest.LoadDataset(uc.dataDir, input.Dataset)
+		if err != nil {
+			return nil, err
+		}
+		algo.Dataset = input.Dataset
+		weights := volumeWeights(candles, algo.StartAt, algo.Interval(), algo.SliceCount)
+		total := 0.0
+		for _, w := range weights {
+			total += w
+		}
+		if total <= 0 {
+			return nil, fmt.Errorf("%w: the dataset has no volume at these times of day", domain.ErrInvalidAlgoOrder)
+		}
+		return weights, nil
+	default:
+		return nil, domain.ErrInvalidOrderType
+	}
+}
+
+// Get returns an algo order of the account
+func (uc *UseCase) Get(ctx context.Context, accountID domain.AccountID, orderID domain.OrderID) (*Execution, error) {
+	parent, err := uc.orderUC.GetOrder(ctx, accountID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !parent.IsAlgo() {
+		return nil, domain.ErrAlgoOrderNotFound
+	}
+	algo, err := uc.algoRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return &Execution{Order: parent, Algo: algo}, nil
+}
+
+// Cancel cancels the parent order and the slices not executed yet, including
+// a resting limit slice. Filled slices are kept.
+func (uc *UseCase) Cancel(ctx context.Context, accountID domain.AccountID, orderID domain.OrderID) (*Execution, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	execution, err := uc.Get(ctx, accountID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.orderUC.CancelOrder(ctx, accountID, orderID); err != nil {
+		return nil, err
+	}
+	execution.Order.Status = domain.OrderStatusCancelled
+	if err := uc.cancelSlices(ctx, execution.Order, execution.Algo); err != nil {
+		return nil, err
+	}
+	return execution, nil
+}
+
+// Publish advances the algo order of a resting limit slice that filled or
+// was rejected. Slices placed at market are recorded by execute, which runs
+// under the lock, so their fills are not waited on here.
+func (uc *UseCase) Publish(ctx context.Context, event domain.AccountEvent) {
+	if event.Type != domain.EventTypeFill && event.Type != domain.EventTypeOrderRejected {
+		return
+	}
+	if event.Order == nil || event.Order.ParentID == nil || !event.Order.IsLimit() {
+		return
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	parent, err := uc.orderRepo.GetByID(ctx, *event.Order.ParentID)
+	if err != nil {
+		logger.Error("failed to get algo order", "order_id", *event.Order.ParentID, "error", err)
+		return
+	}
+	algo, err := uc.algoRepo.GetByOrderID(ctx, parent.ID)
+	if err == nil {
+		err = uc.advance(ctx, parent, algo, time.Now())
+	}
+	if err != nil {
+		logger.Error("failed to run algo order", "order_id", parent.ID, "error", err)
+	}
+}
+
+// RunDue executes the slices due at now. Slices that fell due while the
+// service was down are executed at once.
+func (uc *UseCase) RunDue(ctx context.Context, now time.Time) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	algos, err := uc.algoRepo.GetActive(ctx)
+	if err != nil {
+		logger.Error("failed to get algo orders", "error", err)
+		return
+	}
+
+	for i := range algos {
+		algo := &algos[i]
+		parent, err := uc.orderRepo.GetByID(ctx, algo.OrderID)
+		if err == nil {
+			err = uc.advance(ctx, parent, algo, now)
+		}
+		if err != nil {
+			logger.Error("failed to run algo order", "order_id", algo.OrderID, "error", err)
+		}
+	}
+}
+
+// advance moves the schedule to now, iceberg and scale orders through
+// advanceResting. A resting TWAP or VWAP limit slice expires when the
+// next slice is due, which takes over its quantity; the last one expires at
+// the end of the schedule. The parent is FILLED once the slices executed its
+// whole quantity, CANCELLED if they ended short of it.
+func (uc *UseCase) advance(ctx context.Context, parent *domain.Order, algo *domain.AlgoOrder, now time.Time) error {
+	// Cancelled through the orders API
+	if !parent.IsPending() {
+		return uc.cancelSlices(ctx, parent, algo)
+	}
+	if parent.IsResting() {
+		return uc.advanceResting(ctx, parent, algo, now)
+	}
+
+	if open := algo.OpenSlice(); open != nil {
+		if err := uc.sync(ctx, parent, open); err != nil {
+			return err
+		}
+	}
+
+	for {
+		slice := algo.NextSlice()
+		if slice == nil || slice.DueAt.After(now) {
+			break
+		}
+		if open := algo.OpenSlice(); open != nil {
+			if err := uc.expire(ctx, parent, open, slice); err != nil {
+				return err
+			}
+		}
+		if err := uc.execute(ctx, parent, slice); err != nil {
+			return err
+		}
+	}
+
+	if algo.NextSlice() != nil {
+		return nil
+	}
+	if open := algo.OpenSlice(); open != nil {
+		if now.Before(algo.EndAt()) {
+			return nil
+		}
+		if err := uc.expire(ctx, parent, open, nil); err != nil {
+			return err
+		}
+	}
+	return uc.finish(ctx, parent, algo, now)
+}
+
+// advanceResting records the resting slices of an iceberg or scale order that
+// filled and places the next ones: a scale order places all of
+// its slices at once, an iceberg the next slice once the previous one filled.
+// An iceberg whose slice fails is cancelled. The parent is FILLED once every
+// slice filled, CANCELLED if one failed.
+func (uc *UseCase) advanceResting(ctx context.Context, parent *domain.Order, algo *domain.AlgoOrder, now time.Time) error {
+	for i := range algo.Slices {
+		if algo.Slices[i].Status == domain.AlgoSliceOpen {
+			if err := uc.sync(ctx, parent, &algo.Slices[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	iceberg := parent.Type == domain.OrderTypeIceberg
+	for {
+		slice := algo.NextSlice()
+		if slice == nil || (iceberg && algo.OpenSlice() != nil) {
+			break
+		}
+		if iceberg && slice.Index > 0 && algo.Slices[slice.Index-1].Status == domain.AlgoSliceFailed {
+			if err := uc.cancelSlices(ctx, parent, algo); err != nil {
+				return err
+			}
+			break
+		}
+		if err := uc.execute(ctx, parent, slice); err != nil {
+			return err
+		}
+	}
+
+	if algo.NextSlice() != nil || algo.OpenSlice() != nil {
+		return nil
+	}
+	return uc.finish(ctx, parent, algo, now)
+}
+
+// execute places a slice as a child order: at market, or as a resting limit
+// order while the market is beyond the slice's limit. Rejected orders are
+// recorded as failed slices, not returned.
+func (uc *UseCase) execute(ctx context.Context, parent *domain.Order, slice *domain.AlgoSlice) error {
+	input := orderuc.PlaceOrderInput{
+		AccountID: parent.AccountID,
+		Symbol:    parent.Symbol,
+		Side:      parent.Side,
+		Type:      domain.OrderTypeMarket,
+		Quantity:  slice.Quantity,
+		Leverage:  parent.Leverage,
+		ParentID:  &parent.ID,
+	}
+	if limit := sliceLimit(parent, slice); limit.IsPositive() && !uc.reached(parent, limit) {
+		input.Type = domain.OrderTypeLimit
+		input.Price = limit
+	}
+
+	output, err := uc.orderUC.PlaceOrder(ctx, input)
+	switch {
+	case err != nil:
+		slice.Status = domain.AlgoSliceFailed
+		slice.Error = err.Error()
+		logger.Info("algo slice failed", "order_id", parent.ID, "slice", slice.Index, "error", err)
+	case output.Trade != nil:
+		slice.ChildID = &output.Order.ID
+		recordFill(slice, output.Trade.Quantity, output.Trade.Price)
+	default:
+		slice.ChildID = &output.Order.ID
+		slice.Status = domain.AlgoSliceOpen
+	}
+	return uc.algoRepo.UpdateSlice(ctx, slice)
+}
+
+// sync records the outcome of the resting limit slice's order: filled at its
+// limit by the price stream, or rejected or cancelled there or outside the algo
+func (uc *UseCase) sync(ctx context.Context, parent *domain.Order, slice *domain.AlgoSlice) error {
+	child, err := uc.orderRepo.GetByID(ctx, *slice.ChildID)
+	if err != nil {
+		return err
+	}
+
+	switch child.Status {
+	case domain.OrderStatusFilled:
+		recordFill(slice, child.Quantity, child.Price)
+	case domain.OrderStatusRejected, domain.OrderStatusCancelled:
+		slice.Status = domain.AlgoSliceFailed
+		slice.Error = "limit order " + strings.ToLower(string(child.Status))
+		logger.Info("algo slice failed", "order_id", parent.ID, "slice", slice.Index, "error", slice.Error)
+	default:
+		return nil
+	}
+	return uc.algoRepo.UpdateSlice(ctx, slice)
+}
+
+// expire cancels the resting limit slice and moves its quantity to next
+func (uc *UseCase) expire(ctx context.Context, parent *domain.Order, open, next *domain.AlgoSlice) error {
+	if err := uc.cancelChild(ctx, parent, open); err != nil {
+		return err
+	}
+	open.Status = domain.AlgoSliceExpired
+	open.Error = "limit price not reached"
+	if next != nil {
+		next.Quantity = next.Quantity.Add(open.Quantity)
+	}
+	return uc.algoRepo.UpdateSlice(ctx, open)
+}
+
+// cancelSlices cancels the slices not executed yet
+func (uc *UseCase) cancelSlices(ctx context.Context, parent *domain.Order, algo *domain.AlgoOrder) error {
+	for i := range algo.Slices {
+		slice := &algo.Slices[i]
+		if slice.Resolved() {
+			continue
+		}
+		if slice.Status == domain.AlgoSliceOpen {
+			if err := uc.cancelChild(ctx, parent, slice); err != nil {
+				return err
+			}
+		}
+		slice.Status = domain.AlgoSliceCancelled
+		if err := uc.algoRepo.UpdateSlice(ctx, slice); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cancelChild cancels the slice's resting order unless it is already done
+func (uc *UseCase) cancelChild(ctx context.Context, parent *domain.Order, slice *domain.AlgoSlice) error {
+	if slice.ChildID == nil {
+		return nil
+	}
+	err := uc.orderUC.CancelOrder(ctx, parent.AccountID, *slice.ChildID)
+	if err != nil && !errors.Is(err, domain.ErrOrderNotPending) {
+		return err
+	}
+	return nil
+}
+
+// finish closes the parent once every slice is resolved
+func (uc *UseCase) finish(ctx context.Context, parent *domain.Order, algo *domain.AlgoOrder, now time.Time) error {
+	parent.Status = domain.OrderStatusCancelled
+	if algo.FilledQuantity().GreaterThanOrEqual(parent.Quantity) {
+		parent.Status = domain.OrderStatusFilled
+		parent.FilledAt = &now
+	}
+	if err := uc.orderRepo.Update(ctx, parent); err != nil {
+		return err
+	}
+
+	logger.Info("algo order finished",
+		"order_id", parent.ID,
+		"status", parent.Status,
+		"filled", algo.FilledQuantity(),
+		"average_price", algo.AveragePrice(),
+	)
+	return nil
+}
+
+// sliceLimit returns the price a slice rests at: its own limit on iceberg and
+// scale orders, the parent's optional limit on TWAP and VWAP
+func sliceLimit(parent *domain.Order, slice *domain.AlgoSlice) decimal.Decimal {
+	if slice.LimitPrice.IsPositive() {
+		return slice.LimitPrice
+	}
+	return parent.Price
+}
+
+// reached reports whether the market is at or better than limit
+func (uc *UseCase) reached(parent *domain.Order, limit decimal.Decimal) bool {
+	instrument, _ := uc.orderUC.Instrument(parent.Symbol)
+	price, ok := uc.priceCache.Get(instrument.PriceSymbol)
+	if !ok {
+		return false
+	}
+	if parent.IsBuy() {
+		return decimal.NewFromFloat(price.Ask).LessThanOrEqual(limit)
+	}
+	return decimal.NewFromFloat(price.Bid).GreaterThanOrEqual(limit)
+}
+
+func recordFill(slice *domain.AlgoSlice, quantity, price decimal.Decimal) {
+	slice.Status = domain.AlgoSliceFilled
+	slice.Error = ""
+	slice.FilledQuantity = quantity
+	slice.Price = price
+}
@@ -0,0 +1,64 @@
+package algo
+
+import (
+	"time"
+
+	"trading/internal/backtest"
+)
+
+const minutesPerDay = 24 * 60
+
+// volumeWeights weighs each slice by the volume the candles traded at its
+// time of day: slice i covers [start + i*interval, start + (i+1)*interval).
+// Candle volume is spread evenly over the bar, whose length is the smallest
+// gap between candles, and summed by minute of day over all days (UTC).
+func volumeWeights(candles []backtest.Candle, start time.Time, interval time.Duration, slices int) []float64 {
+	var profile [minutesPerDay]float64
+
+	minutes := barMinutes(candles)
+	for _, c := range candles {
+		perMinute := c.Volume / float64(minutes)
+		first := minuteOfDay(c.Time)
+		for m := 0; m < minutes; m++ {
+			profile[(first+m)%minutesPerDay] += perMinute
+		}
+	}
+
+	weights := make([]float64, slices)
+	for i := range weights {
+		from := start.Add(time.Duration(i) * interval)
+		weights[i] = profileVolume(&profile, from, from.Add(interval))
+	}
+	return weights
+}
+
+// profileVolume integrates the per minute profile over [from, to)
+func profileVolume(profile *[minutesPerDay]float64, from, to time.Time) float64 {
+	total := 0.0
+	for t := from; t.Before(to); {
+		next := t.Truncate(time.Minute).Add(time.Minute)
+		if next.After(to) {
+			next = to
+		}
+		total += profile[minuteOfDay(t)] * next.Sub(t).Minutes()
+		t = next
+	}
+	return total
+}
+
+// barMinutes returns the bar length of the candles in minutes, a day for a
+// single candle
+func barMinutes(candles []backtest.Candle) int {
+	bar := 24 * time.Hour
+	for i := 1; i < len(candles); i++ {
+		if gap := candles[i].Time.Sub(candles[i-1].Time); gap > 0 && gap < bar {
+			bar = gap
+		}
+	}
+	return max(int(bar/time.Minute), 1)
+}
+
+func minuteOfDay(t time.Time) int {
+	t = t.UTC()
+	return t.Hour()*60 + t.Minute()
+}
@@ -82,12 +82,19 @@ func (uc *UseCase) Register(ctx context.Context, input RegisterInput) (*AuthOutp
 		return nil, err
 	}
 
-	// Create account with initial balance
+	// Create account and fund it with the initial deposit
 	account := &domain.Account{
-		UserID:  user.ID,
-		Balance: uc.initialBalance,
-	}
-	if err := uc.accountRepo.Create(ctx, account); err != nil {
+		UserID:                user.ID,
+		Name:                  domain.PrimaryAccountName,
+		Balance:               decimal.Zero,
+		SeasonStartingBalance: uc.initialBalance,
+	}
+	err = uc.accountRepo.CreateFunded(ctx, account, &domain.LedgerEntry{
+		Type:        domain.LedgerEntryTypeInitialDeposit,
+		Amount:      uc.initialBalance,
+		Description: "initial deposit",
+	})
+	if err != nil {
 		return nil, err
 	}
 
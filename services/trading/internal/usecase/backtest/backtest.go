@@ -0,0 +1,244 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/backtest"
+	"trading/internal/domain"
+	"trading/internal/logger"
+)
+
+const (
+	maxActiveJobs   = 2                // queued or running backtests per user
+	maxFinishedJobs = 20               // finished backtests kept per user, oldest dropped first
+	jobTimeout      = 10 * time.Minute // a run is cancelled after this
+)
+
+var defaultStartingBalance = decimal.NewFromInt(10000)
+
+type JobStatus string
+
+const (
+	JobStatusRunning JobStatus = "RUNNING" // also while waiting for a free slot
+	JobStatusDone    JobStatus = "DONE"
+	JobStatusFailed  JobStatus = "FAILED"
+)
+
+// Job is a submitted backtest. Jobs live in memory and are lost on restart.
+type Job struct {
+	ID         int64
+	UserID     domain.UserID
+	Status     JobStatus
+	Strategy   string
+	Params     map[string]float64
+	Symbol     string
+	Dataset    string // empty for inline candles
+	Report     *backtest.Report
+	Error      string
+	CreatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+type UseCase struct {
+	backtester *backtest.Backtester
+	dataDir    string
+	maxCandles int
+	slots      chan struct{}
+
+	mu     sync.Mutex
+	jobs   map[int64]*Job
+	nextID int64
+}
+
+func NewUseCase(backtester *backtest.Backtester, dataDir string, maxRunning, maxCandles int) *UseCase {
+	return &UseCase{
+		backtester: backtester,
+		dataDir:    dataDir,
+		maxCandles: maxCandles,
+		slots:      make(chan struct{}, maxRunning),
+		jobs:       make(map[int64]*Job),
+	}
+}
+
+// SubmitInput describes a backtest; candles come from a stored dataset or inline CSV
+type SubmitInput struct {
+	UserID          domain.UserID
+	Strategy        string
+	Params          map[string]float64
+	Symbol          string
+	Dataset         string
+	CSV             string
+	StartingBalance decimal.Decimal // defaults to 10000
+	Spread          float64
+}
+
+// Submit validates the backtest and starts it in the background
+func (uc *UseCase) Submit(ctx context.Context, input SubmitInput) (*Job, error) {
+	strategy, err := backtest.NewStrategy(input.Strategy, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	return uc.SubmitStrategy(ctx, input, strategy)
+}
+
+// SubmitStrategy starts a backtest of a strategy built by the caller, e.g. a
+// user script; input.Strategy only names it in the job
+func (uc *UseCase) SubmitStrategy(ctx context.Context, input SubmitInput, strategy backtest.Strategy) (*Job, error) {
+	var candles []backtest.Candle
+	var err error
+	switch {
+	case input.Dataset != "" && input.CSV != "":
+		return nil, fmt.Errorf("%w: either dataset or csv, not both", domain.ErrInvalidBacktest)
+	case input.Dataset != "":
+		candles, err = backtest.LoadDataset(uc.dataDir, input.Dataset)
+	case input.CSV != "":
+		candles, err = backtest.ReadCSV(strings.NewReader(input.CSV))
+	default:
+		return nil, fmt.Errorf("%w: dataset or csv is required", domain.ErrInvalidBacktest)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) > uc.maxCandles {
+		return nil, fmt.Errorf("%w: more than %d candles", domain.ErrInvalidBacktest, uc.maxCandles)
+	}
+
+	if input.StartingBalance.IsZero() {
+		input.StartingBalance = defaultStartingBalance
+	}
+	run := backtest.Input{
+		Symbol:          strings.ToUpper(input.Symbol),
+		Candles:         candles,
+		Strategy:        strategy,
+		StartingBalance: input.StartingBalance,
+		Spread:          input.Spread,
+	}
+	if err := run.Validate(); err != nil {
+		return nil, err
+	}
+
+	uc.mu.Lock()
+	active := 0
+	for _, j := range uc.jobs {
+		if j.UserID == input.UserID && j.Status == JobStatusRunning {
+			active++
+		}
+	}
+	if active >= maxActiveJobs {
+		uc.mu.Unlock()
+		return nil, domain.ErrTooManyBacktests
+	}
+	uc.nextID++
+	job := &Job{
+		ID:        uc.nextID,
+		UserID:    input.UserID,
+		Status:    JobStatusRunning,
+		Strategy:  input.Strategy,
+		Params:    input.Params,
+		Symbol:    run.Symbol,
+		Dataset:   input.Dataset,
+		CreatedAt: time.Now(),
+	}
+	uc.jobs[job.ID] = job
+	uc.prune(input.UserID)
+	snapshot := *job
+	uc.mu.Unlock()
+
+	go uc.run(job, run)
+
+	return &snapshot, nil
+}
+
+func (uc *UseCase) run(job *Job, input backtest.Input) {
+	ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+	defer cancel()
+
+	select {
+	case uc.slots <- struct{}{}:
+		defer func() { <-uc.slots }()
+	case <-ctx.Done():
+		uc.finish(job, nil, ctx.Err())
+		return
+	}
+
+	report, err := uc.backtester.Run(ctx, input)
+	uc.finish(job, report, err)
+}
+
+func (uc *UseCase) finish(job *Job, report *backtest.Report, err error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := time.Now()
+	job.FinishedAt = &now
+	if err != nil {
+		logger.Warn("backtest failed", "job_id", job.ID, "error", err)
+		job.Status = JobStatusFailed
+		job.Error = err.Error()
+		return
+	}
+	job.Status = JobStatusDone
+	job.Report = report
+}
+
+// prune drops the oldest finished jobs of the user beyond maxFinishedJobs
+func (uc *UseCase) prune(userID domain.UserID) {
+	var finished []*Job
+	for _, j := range uc.jobs {
+		if j.UserID == userID && j.Status != JobStatusRunning {
+			finished = append(finished, j)
+		}
+	}
+	if len(finished) <= maxFinishedJobs {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].ID < finished[j].ID })
+	for _, j := range finished[:len(finished)-maxFinishedJobs] {
+		delete(uc.jobs, j.ID)
+	}
+}
+
+// Get returns a job of the user
+func (uc *UseCase) Get(ctx context.Context, userID domain.UserID, id int64) (*Job, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	job, ok := uc.jobs[id]
+	if !ok || job.UserID != userID {
+		return nil, domain.ErrBacktestNotFound
+	}
+	snapshot := *job
+	return &snapshot, nil
+}
+
+// List returns the jobs of the user, newest first
+func (uc *UseCase) List(ctx context.Context, userID domain.UserID) []Job {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	jobs := make([]Job, 0)
+	for _, j := range uc.jobs {
+		if j.UserID == userID {
+			jobs = append(jobs, *j)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID > jobs[j].ID })
+	return jobs
+}
+
+// Strategies lists the strategies a backtest can run
+func (uc *UseCase) Strategies() []string {
+	return backtest.StrategyNames()
+}
+
+// Datasets lists the stored candle files
+func (uc *UseCase) Datasets() ([]string, error) {
+	return backtest.Datasets(uc.dataDir)
+}
@@ -0,0 +1,489 @@
+package challenge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	"trading/internal/metrics"
+	accountuc "trading/internal/usecase/account"
+	positionuc "trading/internal/usecase/position"
+)
+
+// maxActiveChallenges caps the evaluations a user may run at once
+const maxActiveChallenges = 3
+
+// sweepInterval is how often every active challenge is evaluated, besides the
+// ticks of the symbols its account holds
+const sweepInterval = 30 * time.Second
+
+// Lock reasons of ended challenge accounts; they also record the outcome until
+// the account is settled and the challenge marked ended
+const (
+	lockReasonPassed = "challenge passed"
+	lockReasonFailed = "challenge failed: "
+)
+
+// Defaults for rules left out of a new challenge
+var (
+	defaultStartingBalance = decimal.NewFromInt(10000)
+	defaultProfitTarget    = decimal.NewFromFloat(0.10)
+	defaultMaxDailyLoss    = decimal.NewFromFloat(0.05)
+	defaultMaxDrawdown     = decimal.NewFromFloat(0.10)
+	defaultMinTradingDays  = 4
+	defaultPositionFactor  = decimal.NewFromInt(5) // max position size as a multiple of the starting balance
+)
+
+type UseCase struct {
+	challengeRepo domain.ChallengeRepository
+	accountRepo   domain.AccountRepository
+	orderRepo     domain.OrderRepository
+	positionRepo  domain.PositionRepository
+	positionUC    *positionuc.UseCase
+	accountUC     *accountuc.UseCase
+
+	// active caches the accounts of active challenges, so price ticks query
+	// nothing while no challenge runs; nil until first loaded. Starts and ends
+	// update it and every sweep reloads it.
+	activeMu sync.Mutex
+	active   map[domain.AccountID]bool
+}
+
+func NewUseCase(
+	challengeRepo domain.ChallengeRepository,
+	accountRepo domain.AccountRepository,
+	orderRepo domain.OrderRepository,
+	positionRepo domain.PositionRepository,
+	positionUC *positionuc.UseCase,
+	accountUC *accountuc.UseCase,
+) *UseCase {
+	return &UseCase{
+		challengeRepo: challengeRepo,
+		accountRepo:   accountRepo,
+		orderRepo:     orderRepo,
+		positionRepo:  positionRepo,
+		positionUC:    positionUC,
+		accountUC:     accountUC,
+	}
+}
+
+// Start evaluates every active challenge on a timer until the context is
+// cancelled, which starts the day of accounts without open positions and
+// retries ends that failed to settle
+func (uc *UseCase) Start(ctx context.Context) {
+	logger.Info("challenge sweeper started")
+
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("challenge sweeper stopping")
+			return
+		case now := <-ticker.C:
+			uc.Evaluate(ctx, now)
+		}
+	}
+}
+
+// CreateInput describes a new challenge; zero values take the defaults
+type CreateInput struct {
+	UserID          domain.UserID
+	StartingBalance decimal.Decimal
+	ProfitTarget    decimal.Decimal
+	MaxDailyLoss    decimal.Decimal
+	MaxDrawdown     decimal.Decimal
+	MinTradingDays  *int
+	MaxPositionSize decimal.Decimal
+}
+
+// Create starts a challenge on a new account funded with the starting balance
+func (uc *UseCase) Create(ctx context.Context, input CreateInput) (*domain.Challenge, error) {
+	if input.StartingBalance.IsZero() {
+		input.StartingBalance = defaultStartingBalance
+	}
+	if !input.StartingBalance.IsPositive() {
+		return nil, domain.ErrInvalidChallenge
+	}
+
+	rules := domain.ChallengeRules{
+		ProfitTarget:    input.ProfitTarget,
+		MaxDailyLoss:    input.MaxDailyLoss,
+		MaxDrawdown:     input.MaxDrawdown,
+		MinTradingDays:  defaultMinTradingDays,
+		MaxPositionSize: input.MaxPositionSize,
+	}
+	if rules.ProfitTarget.IsZero() {
+		rules.ProfitTarget = defaultProfitTarget
+	}
+	if rules.MaxDailyLoss.IsZero() {
+		rules.MaxDailyLoss = defaultMaxDailyLoss
+	}
+	if rules.MaxDrawdown.IsZero() {
+		rules.MaxDrawdown = defaultMaxDrawdown
+	}
+	if input.MinTradingDays != nil {
+		rules.MinTradingDays = *input.MinTradingDays
+	}
+	if rules.MaxPositionSize.IsZero() {
+		rules.MaxPositionSize = input.StartingBalance.Mul(defaultPositionFactor)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.challengeRepo.ListByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	active := 0
+	for _, c := range existing {
+		if c.Status == domain.ChallengeStatusActive {
+			active++
+		}
+	}
+	if active >= maxActiveChallenges {
+		return nil, domain.ErrTooManyChallenges
+	}
+
+	now := time.Now()
+	challenge := &domain.Challenge{
+		UserID:          input.UserID,
+		Status:          domain.ChallengeStatusActive,
+		StartingBalance: input.StartingBalance,
+		Rules:           rules,
+		DayStartedOn:    domain.ChallengeDay(now),
+		DayStartEquity:  input.StartingBalance,
+	}
+	if err := uc.challengeRepo.Create(ctx, challenge); err != nil {
+		return nil, err
+	}
+
+	account, err := uc.accountUC.CreateChallengeAccount(ctx, input.UserID, challenge)
+	if err != nil {
+		return nil, err
+	}
+	challenge.AccountID = account.ID
+	uc.markActive(account.ID, true)
+
+	logger.Info("challenge started",
+		"challenge_id", challenge.ID,
+		"user_id", input.UserID,
+		"account_id", account.ID,
+		"starting_balance", challenge.StartingBalance,
+	)
+
+	return challenge, nil
+}
+
+// List returns the user's challenges, newest first
+func (uc *UseCase) List(ctx context.Context, userID domain.UserID) ([]domain.Challenge, error) {
+	return uc.challengeRepo.ListByUserID(ctx, userID)
+}
+
+// GetProgress returns a challenge of the user with its rules evaluated at current prices
+func (uc *UseCase) GetProgress(ctx context.Context, userID domain.UserID, id domain.ChallengeID) (*domain.ChallengeProgress, *domain.Challenge, error) {
+	challenge, err := uc.challengeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge.UserID != userID {
+		return nil, nil, domain.ErrChallengeNotFound
+	}
+
+	account, err := uc.accountRepo.GetByID(ctx, challenge.AccountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	equity, largest, err := uc.exposure(ctx, account)
+	if err != nil {
+		return nil, nil, err
+	}
+	progress := challenge.Evaluate(equity, largest)
+	return &progress, challenge, nil
+}
+
+// Evaluate checks every active challenge at current prices, ending the ones
+// that breached a rule or met their targets. Returns the progress of each
+// evaluated challenge.
+func (uc *UseCase) Evaluate(ctx context.Context, now time.Time) []domain.ChallengeProgress {
+	challenges, err := uc.challengeRepo.GetActive(ctx)
+	if err != nil {
+		logger.Error("failed to get active challenges", "error", err)
+		return nil
+	}
+	uc.cacheActive(challenges)
+	return uc.evaluateAll(ctx, challenges, now)
+}
+
+// EvaluateSymbol checks the active challenges whose accounts hold an open
+// position in the symbol, the only ones a tick of it moves. Called on every
+// price tick, so the active challenges are read only when one of their
+// accounts holds the symbol.
+func (uc *UseCase) EvaluateSymbol(ctx context.Context, symbol string, now time.Time) []domain.ChallengeProgress {
+	accounts, err := uc.activeAccounts(ctx)
+	if err != nil {
+		logger.Error("failed to get active challenges", "error", err)
+		return nil
+	}
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	positions, err := uc.positionRepo.GetOpenBySymbol(ctx, symbol)
+	if err != nil {
+		logger.Error("failed to get positions", "symbol", symbol, "error", err)
+		return nil
+	}
+	holding := make(map[domain.AccountID]bool)
+	for _, p := range positions {
+		if accounts[p.AccountID] {
+			holding[p.AccountID] = true
+		}
+	}
+	if len(holding) == 0 {
+		return nil
+	}
+
+	challenges, err := uc.challengeRepo.GetActive(ctx)
+	if err != nil {
+		logger.Error("failed to get active challenges", "error", err)
+		return nil
+	}
+	affected := challenges[:0]
+	for _, c := range challenges {
+		if holding[c.AccountID] {
+			affected = append(affected, c)
+		}
+	}
+	return uc.evaluateAll(ctx, affected, now)
+}
+
+// activeAccounts returns the accounts of active challenges, loading them on first use
+func (uc *UseCase) activeAccounts(ctx context.Context) (map[domain.AccountID]bool, error) {
+	uc.activeMu.Lock()
+	defer uc.activeMu.Unlock()
+
+	if uc.active == nil {
+		challenges, err := uc.challengeRepo.GetActive(ctx)
+		if err != nil {
+			return nil, err
+		}
+		uc.active = activeSet(challenges)
+	}
+	return maps.Clone(uc.active), nil
+}
+
+// cacheActive replaces the cached accounts with those of the challenges
+func (uc *UseCase) cacheActive(challenges []domain.Challenge) {
+	uc.activeMu.Lock()
+	defer uc.activeMu.Unlock()
+	uc.active = activeSet(challenges)
+}
+
+// markActive records a started or ended challenge in a loaded cache
+func (uc *UseCase) markActive(accountID domain.AccountID, active bool) {
+	uc.activeMu.Lock()
+	defer uc.activeMu.Unlock()
+
+	if uc.active == nil {
+		return
+	}
+	if active {
+		uc.active[accountID] = true
+	} else {
+		delete(uc.active, accountID)
+	}
+}
+
+func activeSet(challenges []domain.Challenge) map[domain.AccountID]bool {
+	set := make(map[domain.AccountID]bool, len(challenges))
+	for _, c := range challenges {
+		set[c.AccountID] = true
+	}
+	return set
+}
+
+func (uc *UseCase) evaluateAll(ctx context.Context, challenges []domain.Challenge, now time.Time) []domain.ChallengeProgress {
+	result := make([]domain.ChallengeProgress, 0, len(challenges))
+	for i := range challenges {
+		progress, err := uc.evaluate(ctx, &challenges[i], now, false)
+		if err != nil {
+			logger.Error("failed to evaluate challenge", "challenge_id", challenges[i].ID, "error", err)
+			continue
+		}
+		result = append(result, *progress)
+	}
+	return result
+}
+
+// Publish evaluates the challenge of the account a trade was made on, counting
+// the trading day. It implements domain.EventPublisher so fills are checked at once.
+func (uc *UseCase) Publish(ctx context.Context, event domain.AccountEvent) {
+	if event.Trade == nil {
+		return
+	}
+
+	challenge, err := uc.challengeRepo.GetByAccountID(ctx, event.AccountID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrChallengeNotFound) {
+			logger.Error("failed to get challenge", "account_id", event.AccountID, "error", err)
+		}
+		return
+	}
+	if challenge.Status != domain.ChallengeStatusActive {
+		return
+	}
+
+	if _, err := uc.evaluate(ctx, challenge, event.Timestamp, true); err != nil {
+		logger.Error("failed to evaluate challenge", "challenge_id", challenge.ID, "error", err)
+	}
+}
+
+func (uc *UseCase) evaluate(ctx context.Context, challenge *domain.Challenge, now time.Time, traded bool) (*domain.ChallengeProgress, error) {
+	account, err := uc.accountRepo.GetByID(ctx, challenge.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	equity, largest, err := uc.exposure(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	// A locked account of an active challenge is one whose end failed to
+	// settle; finish it with the outcome recorded in the lock
+	if status, reason, ok := lockedOutcome(account.LockedReason); ok {
+		if err := uc.end(ctx, challenge, status, reason, now); err != nil {
+			return nil, err
+		}
+		progress := challenge.Evaluate(equity, largest)
+		progress.Status = status
+		return &progress, nil
+	}
+
+	// Evaluate the day change first so a new day starts from the current equity
+	changed := challenge.StartDay(equity, now)
+	if traded && challenge.RecordTrade(now) {
+		changed = true
+	}
+	if changed {
+		if err := uc.challengeRepo.UpdateProgress(ctx, challenge); err != nil {
+			return nil, err
+		}
+	}
+
+	progress := challenge.Evaluate(equity, largest)
+	switch {
+	case progress.Breach != "":
+		if err := uc.end(ctx, challenge, domain.ChallengeStatusFailed, string(progress.Breach), now); err != nil {
+			return nil, err
+		}
+		progress.Status = domain.ChallengeStatusFailed
+	case progress.Passed:
+		if err := uc.end(ctx, challenge, domain.ChallengeStatusPassed, "", now); err != nil {
+			return nil, err
+		}
+		progress.Status = domain.ChallengeStatusPassed
+	}
+	return &progress, nil
+}
+
+// exposure returns the account's equity and the notional of its largest open position
+func (uc *UseCase) exposure(ctx context.Context, account *domain.Account) (decimal.Decimal, decimal.Decimal, error) {
+	summary, err := uc.accountUC.Summary(ctx, account)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+
+	positions, err := uc.positionRepo.GetOpenByAccountID(ctx, account.ID)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	largest := decimal.Zero
+	for _, p := range positions {
+		largest = decimal.Max(largest, p.Quantity.Mul(p.EntryPrice))
+	}
+
+	return summary.Equity, largest, nil
+}
+
+// end finishes the challenge: the account is locked, its pending orders are
+// cancelled and it is settled at current prices before the challenge is marked
+// ended. A failed step leaves the challenge active with the outcome in the
+// lock, so the next evaluation retries it.
+func (uc *UseCase) end(ctx context.Context, challenge *domain.Challenge, status domain.ChallengeStatus, reason string, now time.Time) error {
+	if err := uc.accountRepo.Lock(ctx, challenge.AccountID, lockReason(status, reason)); err != nil {
+		return err
+	}
+
+	orders, err := uc.orderRepo.GetPendingByAccountID(ctx, challenge.AccountID)
+	if err != nil {
+		return err
+	}
+	for i := range orders {
+		orders[i].Status = domain.OrderStatusCancelled
+		err := uc.orderRepo.Update(ctx, &orders[i])
+		if errors.Is(err, domain.ErrOrderNotPending) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("cancel order %d: %w", orders[i].ID, err)
+		}
+		metrics.RecordOrderCancelled(orders[i].Symbol)
+	}
+
+	if _, err := uc.positionUC.CloseAllPositions(ctx, challenge.AccountID, "challenge_end"); err != nil {
+		return fmt.Errorf("close positions: %w", err)
+	}
+	if err := uc.accountUC.ConvertAllToQuote(ctx, challenge.AccountID); err != nil {
+		return fmt.Errorf("convert wallet: %w", err)
+	}
+
+	// Only the first caller ends it when a fill and a tick race
+	ended, err := uc.challengeRepo.End(ctx, challenge.ID, status, reason, now)
+	if err != nil {
+		return err
+	}
+	uc.markActive(challenge.AccountID, false)
+	if !ended {
+		return nil
+	}
+	challenge.Status = status
+	challenge.FailureReason = reason
+
+	logger.Info("challenge ended",
+		"challenge_id", challenge.ID,
+		"user_id", challenge.UserID,
+		"status", status,
+		"reason", reason,
+	)
+
+	return nil
+}
+
+func lockReason(status domain.ChallengeStatus, reason string) string {
+	if status == domain.ChallengeStatusFailed {
+		return lockReasonFailed + reason
+	}
+	return lockReasonPassed
+}
+
+// lockedOutcome returns the outcome recorded in the lock reason of a challenge account
+func lockedOutcome(lockReason string) (domain.ChallengeStatus, string, bool) {
+	switch {
+	case lockReason == lockReasonPassed:
+		return domain.ChallengeStatusPassed, "", true
+	case strings.HasPrefix(lockReason, lockReasonFailed):
+		return domain.ChallengeStatusFailed, strings.TrimPrefix(lockReason, lockReasonFailed), true
+	}
+	return "", "", false
+}
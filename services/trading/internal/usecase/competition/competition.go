@@ -0,0 +1,361 @@
+package competition
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/engine"
+	"trading/internal/logger"
+	accountuc "trading/internal/usecase/account"
+	orderuc "trading/internal/usecase/order"
+	positionuc "trading/internal/usecase/position"
+)
+
+// finalizeInterval is how often ended competitions are looked up and finalized
+const finalizeInterval = 30 * time.Second
+
+type UseCase struct {
+	competitionRepo domain.CompetitionRepository
+	accountRepo     domain.AccountRepository
+	userRepo        domain.UserRepository
+	orderUC         *orderuc.UseCase
+	positionUC      *positionuc.UseCase
+	accountUC       *accountuc.UseCase
+	engine          *engine.Engine
+	instruments     map[string]domain.Instrument
+}
+
+func NewUseCase(
+	competitionRepo domain.CompetitionRepository,
+	accountRepo domain.AccountRepository,
+	userRepo domain.UserRepository,
+	orderUC *orderuc.UseCase,
+	positionUC *positionuc.UseCase,
+	accountUC *accountuc.UseCase,
+	eng *engine.Engine,
+	instruments []domain.Instrument,
+) *UseCase {
+	bySymbol := make(map[string]domain.Instrument)
+	for _, i := range instruments {
+		bySymbol[i.Symbol] = i
+	}
+	return &UseCase{
+		competitionRepo: competitionRepo,
+		accountRepo:     accountRepo,
+		userRepo:        userRepo,
+		orderUC:         orderUC,
+		positionUC:      positionUC,
+		accountUC:       accountUC,
+		engine:          eng,
+		instruments:     bySymbol,
+	}
+}
+
+// Start finalizes ended competitions until the context is cancelled
+func (uc *UseCase) Start(ctx context.Context) {
+	logger.Info("competition finalizer started")
+
+	ticker := time.NewTicker(finalizeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("competition finalizer stopping")
+			return
+		case now := <-ticker.C:
+			uc.FinalizeDue(ctx, now)
+		}
+	}
+}
+
+type CreateInput struct {
+	CreatedBy       domain.UserID
+	Name            string
+	Description     string
+	StartsAt        time.Time
+	EndsAt          time.Time
+	AllowedSymbols  []string // empty allows every supported symbol
+	MaxLeverage     int
+	StartingBalance decimal.Decimal
+	RankingMetric   domain.LeaderboardMetric // default pnl
+}
+
+// Create schedules a new competition
+func (uc *UseCase) Create(ctx context.Context, input CreateInput) (*domain.Competition, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" || len(input.Name) > 100 {
+		return nil, fmt.Errorf("%w: name must be 1-100 characters", domain.ErrInvalidCompetition)
+	}
+	if !input.EndsAt.After(input.StartsAt) || !input.EndsAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at and in the future", domain.ErrInvalidCompetition)
+	}
+	if !uc.engine.ValidateLeverage(input.MaxLeverage) {
+		return nil, fmt.Errorf("%w: max_leverage out of range", domain.ErrInvalidCompetition)
+	}
+	if !input.StartingBalance.IsPositive() {
+		return nil, fmt.Errorf("%w: starting_balance must be positive", domain.ErrInvalidCompetition)
+	}
+	if input.RankingMetric == "" {
+		input.RankingMetric = domain.LeaderboardMetricPnL
+	}
+	if !input.RankingMetric.IsValid() {
+		return nil, domain.ErrInvalidLeaderboardMetric
+	}
+
+	symbols := input.AllowedSymbols
+	if len(symbols) == 0 {
+		for symbol := range uc.instruments {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+	}
+	for _, symbol := range symbols {
+		if _, ok := uc.instruments[symbol]; !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrSymbolNotSupported, symbol)
+		}
+	}
+
+	competition := &domain.Competition{
+		Name:            input.Name,
+		Description:     input.Description,
+		StartsAt:        input.StartsAt,
+		EndsAt:          input.EndsAt,
+		AllowedSymbols:  symbols,
+		MaxLeverage:     input.MaxLeverage,
+		StartingBalance: input.StartingBalance,
+		RankingMetric:   input.RankingMetric,
+		CreatedBy:       input.CreatedBy,
+	}
+	if err := uc.competitionRepo.Create(ctx, competition); err != nil {
+		return nil, err
+	}
+
+	logger.Info("competition created",
+		"competition_id", competition.ID,
+		"name", competition.Name,
+		"starts_at", competition.StartsAt,
+		"ends_at", competition.EndsAt,
+	)
+
+	return competition, nil
+}
+
+func (uc *UseCase) List(ctx context.Context) ([]domain.Competition, error) {
+	return uc.competitionRepo.List(ctx)
+}
+
+func (uc *UseCase) Get(ctx context.Context, id domain.CompetitionID) (*domain.Competition, error) {
+	return uc.competitionRepo.GetByID(ctx, id)
+}
+
+// Join opens the user's dedicated account for the competition. Users may join
+// until the competition ends, once.
+func (uc *UseCase) Join(ctx context.Context, userID domain.UserID, id domain.CompetitionID) (*accountuc.AccountInfo, error) {
+	competition, err := uc.competitionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch competition.Status(time.Now()) {
+	case domain.CompetitionStatusEnded, domain.CompetitionStatusFinished:
+		return nil, domain.ErrCompetitionEnded
+	}
+
+	accounts, err := uc.accountRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range accounts {
+		if a.CompetitionID != nil && *a.CompetitionID == id {
+			return nil, domain.ErrAlreadyJoined
+		}
+	}
+
+	return uc.accountUC.CreateCompetitionAccount(ctx, userID, competition)
+}
+
+// GetStandings ranks the participants by the competition's metric. Standings
+// are computed live until the competition is finalized and frozen afterwards.
+func (uc *UseCase) GetStandings(ctx context.Context, id domain.CompetitionID) (*domain.Competition, []domain.CompetitionStanding, error) {
+	competition, err := uc.competitionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if competition.FinalizedAt != nil {
+		standings, err := uc.competitionRepo.GetStandings(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		return competition, standings, nil
+	}
+
+	standings, err := uc.liveStandings(ctx, competition)
+	if err != nil {
+		return nil, nil, err
+	}
+	return competition, standings, nil
+}
+
+// FinalizeDue finalizes every competition that has ended by now. Failures are
+// logged and retried on the next run.
+func (uc *UseCase) FinalizeDue(ctx context.Context, now time.Time) {
+	competitions, err := uc.competitionRepo.GetUnfinalized(ctx, now)
+	if err != nil {
+		logger.Error("failed to get ended competitions", "error", err)
+		return
+	}
+
+	for i := range competitions {
+		if err := uc.finalize(ctx, &competitions[i], now); err != nil {
+			logger.Error("failed to finalize competition", "competition_id", competitions[i].ID, "error", err)
+		}
+	}
+}
+
+// finalize settles every participant's account at current prices and freezes the standings
+func (uc *UseCase) finalize(ctx context.Context, competition *domain.Competition, now time.Time) error {
+	accounts, err := uc.accountRepo.ListByCompetitionID(ctx, competition.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range accounts {
+		orders, err := uc.orderUC.GetPendingOrders(ctx, a.ID)
+		if err != nil {
+			return err
+		}
+		for _, o := range orders {
+			if err := uc.orderUC.CancelOrder(ctx, a.ID, o.ID); err != nil {
+				return err
+			}
+		}
+		if _, err := uc.positionUC.CloseAllPositions(ctx, a.ID, "competition_end"); err != nil {
+			return fmt.Errorf("close positions of account %d: %w", a.ID, err)
+		}
+		if err := uc.accountUC.ConvertAllToQuote(ctx, a.ID); err != nil {
+			return fmt.Errorf("convert wallet of account %d: %w", a.ID, err)
+		}
+	}
+
+	standings, err := uc.liveStandings(ctx, competition)
+	if err != nil {
+		return err
+	}
+	if err := uc.competitionRepo.Finalize(ctx, competition.ID, standings, now); err != nil {
+		return err
+	}
+
+	logger.Info("competition finalized",
+		"competition_id", competition.ID,
+		"participants", len(standings),
+	)
+
+	return nil
+}
+
+// liveStandings values every participant's account at current prices
+func (uc *UseCase) liveStandings(ctx context.Context, competition *domain.Competition) ([]domain.CompetitionStanding, error) {
+	accounts, err := uc.accountRepo.ListByCompetitionID(ctx, competition.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	performance, err := uc.competitionRepo.GetPerformance(ctx, competition.ID)
+	if err != nil {
+		return nil, err
+	}
+	byAccount := make(map[domain.AccountID]*domain.TraderPerformance, len(performance))
+	for i := range performance {
+		byAccount[performance[i].AccountID] = &performance[i]
+	}
+
+	standings := make([]domain.CompetitionStanding, 0, len(accounts))
+	for i := range accounts {
+		account := &accounts[i]
+
+		summary, err := uc.accountUC.Summary(ctx, account)
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := uc.displayName(ctx, account.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		s := domain.CompetitionStanding{
+			CompetitionID: competition.ID,
+			UserID:        account.UserID,
+			AccountID:     account.ID,
+			DisplayName:   name,
+			Equity:        summary.Equity,
+			PnL:           summary.Equity.Sub(competition.StartingBalance),
+		}
+		s.ROI = s.PnL.Div(competition.StartingBalance)
+		if p, ok := byAccount[account.ID]; ok {
+			s.Trades = p.Trades
+			s.RiskAdjusted = p.RiskAdjusted()
+		}
+		standings = append(standings, s)
+	}
+
+	rank(standings, competition.RankingMetric)
+	return standings, nil
+}
+
+func (uc *UseCase) displayName(ctx context.Context, userID domain.UserID) (string, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return fmt.Sprintf("trader-%d", userID), nil
+		}
+		return "", err
+	}
+	if user.DisplayName == "" {
+		return fmt.Sprintf("trader-%d", userID), nil
+	}
+	return user.DisplayName, nil
+}
+
+// rank orders standings by the metric, highest first, and numbers them.
+// Participants without a score rank last; ties go to more trades, then by name.
+func rank(standings []domain.CompetitionStanding, metric domain.LeaderboardMetric) {
+	score := func(s *domain.CompetitionStanding) *decimal.Decimal {
+		switch metric {
+		case domain.LeaderboardMetricROI:
+			return &s.ROI
+		case domain.LeaderboardMetricRiskAdjusted:
+			return s.RiskAdjusted
+		default:
+			return &s.PnL
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := score(&standings[i]), score(&standings[j])
+		if (a == nil) != (b == nil) {
+			return a != nil
+		}
+		if a != nil {
+			if c := a.Cmp(*b); c != 0 {
+				return c > 0
+			}
+		}
+		if standings[i].Trades != standings[j].Trades {
+			return standings[i].Trades > standings[j].Trades
+		}
+		return standings[i].DisplayName < standings[j].DisplayName
+	})
+
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+}
@@ -0,0 +1,287 @@
+package copytrade
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	orderuc "trading/internal/usecase/order"
+	positionuc "trading/internal/usecase/position"
+	statsuc "trading/internal/usecase/stats"
+)
+
+const (
+	// maxFollowsPerAccount caps the active follows of a follower account
+	maxFollowsPerAccount = 10
+	// quantityPlaces is the precision of copied order quantities
+	quantityPlaces = 6
+	// mirrorWorkers is how many leaders' trades are mirrored at the same time
+	mirrorWorkers = 4
+	// mirrorQueueSize bounds the leader trades waiting for each worker
+	mirrorQueueSize = 256
+)
+
+// copiedKey marks the context of orders placed for followers. Their fills are
+// not mirrored again, so follows never chain or loop.
+type copiedKey struct{}
+
+type UseCase struct {
+	followRepo   domain.FollowRepository
+	userRepo     domain.UserRepository
+	accountRepo  domain.AccountRepository
+	positionRepo domain.PositionRepository
+	statsUC      *statsuc.UseCase
+	orderUC      *orderuc.UseCase
+	positionUC   *positionuc.UseCase
+
+	// mu serializes follow changes from the API
+	mu sync.Mutex
+
+	// Leader trades are mirrored by workers, off the leader's order path. The
+	// trades of a leader always go to the same worker, so they are copied in
+	// order; different leaders are copied in parallel.
+	queues []chan domain.AccountEvent
+
+	// pending counts the queued leader trades for Wait; once the workers
+	// stopped, trades are no longer queued
+	pendingMu sync.Mutex
+	idle      *sync.Cond
+	pending   int
+	stopped   bool
+
+	// leaders serializes mirroring and unfollows per leader account. A lock
+	// is dropped once nothing holds or waits for it, so only the leaders
+	// being copied right now have one.
+	leadersMu sync.Mutex
+	leaders   map[domain.AccountID]*leaderLock
+}
+
+// leaderLock is the lock of a leader account and the number of its users
+type leaderLock struct {
+	sync.Mutex
+	users int
+}
+
+func NewUseCase(
+	followRepo domain.FollowRepository,
+	userRepo domain.UserRepository,
+	accountRepo domain.AccountRepository,
+	positionRepo domain.PositionRepository,
+	statsUC *statsuc.UseCase,
+) *UseCase {
+	queues := make([]chan domain.AccountEvent, mirrorWorkers)
+	for i := range queues {
+		queues[i] = make(chan domain.AccountEvent, mirrorQueueSize)
+	}
+
+	uc := &UseCase{
+		followRepo:   followRepo,
+		userRepo:     userRepo,
+		accountRepo:  accountRepo,
+		positionRepo: positionRepo,
+		statsUC:      statsUC,
+		queues:       queues,
+		leaders:      make(map[domain.AccountID]*leaderLock),
+	}
+	uc.idle = sync.NewCond(&uc.pendingMu)
+	return uc
+}
+
+// SetTrading provides the use cases copies are executed with. They publish
+// their fills to this use case, so they are constructed after it.
+func (uc *UseCase) SetTrading(orderUC *orderuc.UseCase, positionUC *positionuc.UseCase) {
+	uc.orderUC = orderUC
+	uc.positionUC = positionUC
+}
+
+type FollowInput struct {
+	UserID              domain.UserID
+	AccountID           domain.AccountID
+	Leader              string // display name
+	Mode                domain.CopyMode
+	Ratio               decimal.Decimal
+	Amount              decimal.Decimal
+	MaxLeverage         int
+	MaxPositionNotional *decimal.Decimal
+	MaxLoss             *decimal.Decimal
+}
+
+// Follow starts copying the primary account of a leader onto the account.
+// Leaders are traders who opted in to the leaderboard.
+func (uc *UseCase) Follow(ctx context.Context, input FollowInput) (*domain.Follow, error) {
+	follow := &domain.Follow{
+		FollowerUserID:      input.UserID,
+		FollowerAccountID:   input.AccountID,
+		Mode:                input.Mode,
+		Ratio:               input.Ratio,
+		Amount:              input.Amount,
+		MaxLeverage:         input.MaxLeverage,
+		MaxPositionNotional: input.MaxPositionNotional,
+		MaxLoss:             input.MaxLoss,
+		Status:              domain.FollowStatusActive,
+	}
+	if follow.Mode == "" {
+		follow.Mode = domain.CopyModeRatio
+	}
+	if err := follow.Validate(); err != nil {
+		return nil, err
+	}
+
+	leader, err := uc.getLeader(ctx, input.Leader)
+	if err != nil {
+		return nil, err
+	}
+	if leader.ID == input.UserID {
+		return nil, domain.ErrCannotFollowSelf
+	}
+	leaderAccount, err := uc.accountRepo.GetPrimaryByUserID(ctx, leader.ID)
+	if err != nil {
+		return nil, err
+	}
+	follow.LeaderUserID = leader.ID
+	follow.LeaderAccountID = leaderAccount.ID
+	follow.LeaderName = leader.DisplayName
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	existing, err := uc.followRepo.ListByFollowerAccountID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	active := 0
+	for _, f := range existing {
+		if !f.IsActive() {
+			continue
+		}
+		if f.LeaderAccountID == follow.LeaderAccountID {
+			return nil, domain.ErrAlreadyFollowing
+		}
+		active++
+	}
+	if active >= maxFollowsPerAccount {
+		return nil, domain.ErrTooManyFollows
+	}
+
+	if err := uc.followRepo.Create(ctx, follow); err != nil {
+		return nil, err
+	}
+
+	logger.Info("follow started",
+		"follow_id", follow.ID,
+		"follower_account_id", follow.FollowerAccountID,
+		"leader_account_id", follow.LeaderAccountID,
+		"mode", follow.Mode,
+	)
+
+	return follow, nil
+}
+
+// Get returns a follow of the account
+func (uc *UseCase) Get(ctx context.Context, accountID domain.AccountID, id domain.FollowID) (*domain.Follow, error) {
+	follow, err := uc.followRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if follow.FollowerAccountID != accountID {
+		return nil, domain.ErrFollowNotFound
+	}
+	return follow, nil
+}
+
+// List returns the follows of the account, newest first
+func (uc *UseCase) List(ctx context.Context, accountID domain.AccountID) ([]domain.Follow, error) {
+	return uc.followRepo.ListByFollowerAccountID(ctx, accountID)
+}
+
+// CopyTrades returns the follow's audit trail, newest first
+func (uc *UseCase) CopyTrades(ctx context.Context, accountID domain.AccountID, id domain.FollowID, limit int) ([]domain.CopyTrade, error) {
+	if _, err := uc.Get(ctx, accountID, id); err != nil {
+		return nil, err
+	}
+	return uc.followRepo.ListCopyTrades(ctx, id, limit)
+}
+
+// Unfollow stops copying for good. Copied positions stay open and are managed
+// by the follower from then on.
+func (uc *UseCase) Unfollow(ctx context.Context, accountID domain.AccountID, id domain.FollowID) (*domain.Follow, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	follow, err := uc.Get(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// A copy in flight saves the follow too; read it again once it is done
+	unlock := uc.lockLeader(follow.LeaderAccountID)
+	defer unlock()
+	if follow, err = uc.Get(ctx, accountID, id); err != nil {
+		return nil, err
+	}
+	if follow.Status == domain.FollowStatusUnfollowed {
+		return nil, domain.ErrFollowEnded
+	}
+
+	follow.Status = domain.FollowStatusUnfollowed
+	if err := uc.followRepo.Update(ctx, follow); err != nil {
+		return nil, err
+	}
+
+	logger.Info("follow ended", "follow_id", follow.ID)
+	return follow, nil
+}
+
+// Leader is the public copy trading profile of a trader
+type Leader struct {
+	DisplayName string
+	Followers   int // active follows
+	Stats       *statsuc.Stats
+}
+
+// GetLeader returns the follower count and the performance of the leader's
+// primary account over the range
+func (uc *UseCase) GetLeader(ctx context.Context, name string, input statsuc.Input) (*Leader, error) {
+	leader, err := uc.getLeader(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	account, err := uc.accountRepo.GetPrimaryByUserID(ctx, leader.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	followers, err := uc.followRepo.CountActiveByLeaderAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := uc.statsUC.GetStats(ctx, account.ID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Leader{
+		DisplayName: leader.DisplayName,
+		Followers:   followers,
+		Stats:       stats,
+	}, nil
+}
+
+// getLeader finds an opted-in trader by display name
+func (uc *UseCase) getLeader(ctx context.Context, name string) (*domain.User, error) {
+	user, err := uc.userRepo.GetByDisplayName(ctx, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrLeaderNotFound
+		}
+		return nil, err
+	}
+	if !user.LeaderboardOptIn {
+		return nil, domain.ErrLeaderNotFound
+	}
+	return user, nil
+}
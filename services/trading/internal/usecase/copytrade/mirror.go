@@ -0,0 +1,301 @@
+package copytrade
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	orderuc "trading/internal/usecase/order"
+	positionuc "trading/internal/usecase/position"
+)
+
+// Publish queues a perpetual trade of a leader account to be mirrored onto
+// its followers. It implements domain.EventPublisher, so copies are driven by
+// the same fill, close and liquidation events webhooks receive. Copies run on
+// the workers of Start and never hold up or fail the leader's operation;
+// failed ones are recorded in the audit trail.
+func (uc *UseCase) Publish(ctx context.Context, event domain.AccountEvent) {
+	if event.Trade == nil || event.Position == nil || ctx.Value(copiedKey{}) != nil {
+		return
+	}
+	if uc.orderUC == nil || uc.positionUC == nil {
+		return
+	}
+	switch event.Trade.Type {
+	case domain.TradeTypeOpen, domain.TradeTypeAdd, domain.TradeTypeClose, domain.TradeTypeLiquidate:
+	default:
+		return
+	}
+
+	// The leader's operation goes on with its trade and position
+	trade, position := *event.Trade, *event.Position
+	event.Trade, event.Position = &trade, &position
+
+	uc.pendingMu.Lock()
+	defer uc.pendingMu.Unlock()
+	if uc.stopped {
+		logger.Warn("copy trading stopped, leader trade not mirrored",
+			"account_id", event.AccountID,
+			"trade_id", trade.ID,
+		)
+		return
+	}
+	select {
+	case uc.queues[int64(event.AccountID)%int64(len(uc.queues))] <- event:
+		uc.pending++
+	default:
+		logger.Error("copy queue full, leader trade not mirrored",
+			"account_id", event.AccountID,
+			"trade_id", trade.ID,
+		)
+	}
+}
+
+// Start runs the mirroring workers until the context is cancelled. Leader
+// trades still queued then are dropped, and later ones are not queued.
+func (uc *UseCase) Start(ctx context.Context) {
+	logger.Info("copy trading workers started", "workers", len(uc.queues))
+
+	// Fills of the copies are not mirrored again
+	ctx = context.WithValue(ctx, copiedKey{}, true)
+
+	var wg sync.WaitGroup
+	for _, queue := range uc.queues {
+		wg.Add(1)
+		go func(queue <-chan domain.AccountEvent) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event := <-queue:
+					uc.mirrorAll(ctx, event)
+					uc.done(1)
+				}
+			}
+		}(queue)
+	}
+	wg.Wait()
+
+	uc.pendingMu.Lock()
+	uc.stopped = true
+	dropped := 0
+	for _, queue := range uc.queues {
+		for len(queue) > 0 {
+			<-queue
+			dropped++
+		}
+	}
+	uc.pendingMu.Unlock()
+	uc.done(dropped)
+
+	logger.Info("copy trading workers stopped", "dropped", dropped)
+}
+
+// Wait blocks until the queued leader trades are mirrored, or dropped when
+// the workers stop
+func (uc *UseCase) Wait() {
+	uc.pendingMu.Lock()
+	defer uc.pendingMu.Unlock()
+	for uc.pending > 0 {
+		uc.idle.Wait()
+	}
+}
+
+// done removes n handled leader trades from the pending count
+func (uc *UseCase) done(n int) {
+	uc.pendingMu.Lock()
+	defer uc.pendingMu.Unlock()
+	uc.pending -= n
+	if uc.pending == 0 {
+		uc.idle.Broadcast()
+	}
+}
+
+// mirrorAll copies a leader trade onto the leader's active follows
+func (uc *UseCase) mirrorAll(ctx context.Context, event domain.AccountEvent) {
+	unlock := uc.lockLeader(event.AccountID)
+	defer unlock()
+
+	follows, err := uc.followRepo.GetActiveByLeaderAccountID(ctx, event.AccountID)
+	if err != nil {
+		logger.Error("failed to get follows", "account_id", event.AccountID, "error", err)
+		return
+	}
+	for i := range follows {
+		uc.mirror(ctx, &follows[i], event)
+	}
+}
+
+// lockLeader locks a leader account and returns its unlock. The lock is
+// removed when its last user unlocks it.
+func (uc *UseCase) lockLeader(accountID domain.AccountID) func() {
+	uc.leadersMu.Lock()
+	lock, ok := uc.leaders[accountID]
+	if !ok {
+		lock = &leaderLock{}
+		uc.leaders[accountID] = lock
+	}
+	lock.users++
+	uc.leadersMu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		uc.leadersMu.Lock()
+		defer uc.leadersMu.Unlock()
+		lock.users--
+		if lock.users == 0 {
+			delete(uc.leaders, accountID)
+		}
+	}
+}
+
+// mirror copies one leader trade onto a follower and records the outcome
+func (uc *UseCase) mirror(ctx context.Context, follow *domain.Follow, event domain.AccountEvent) {
+	trade := event.Trade
+	record := &domain.CopyTrade{
+		FollowID:        follow.ID,
+		LeaderTradeID:   trade.ID,
+		LeaderTradeType: trade.Type,
+		Symbol:          trade.Symbol,
+	}
+
+	var err error
+	if trade.Type == domain.TradeTypeOpen || trade.Type == domain.TradeTypeAdd {
+		err = uc.copyOpen(ctx, follow, event, record)
+	} else {
+		err = uc.copyReduce(ctx, follow, event, record)
+	}
+	if err != nil {
+		record.Status = domain.CopyTradeFailed
+		record.Error = err.Error()
+		logger.Warn("copy trade failed",
+			"follow_id", follow.ID,
+			"leader_trade_id", trade.ID,
+			"error", err,
+		)
+	}
+
+	if err := uc.followRepo.CreateCopyTrade(ctx, record); err != nil {
+		logger.Error("failed to record copy trade", "follow_id", follow.ID, "error", err)
+	}
+	if record.Status != domain.CopyTradeFilled {
+		return
+	}
+
+	follow.CopiedTrades++
+	if follow.RecordClose(record.PnL) {
+		logger.Info("follow stopped", "follow_id", follow.ID, "reason", follow.StopReason)
+	}
+	if err := uc.followRepo.Update(ctx, follow); err != nil {
+		logger.Error("failed to update follow", "follow_id", follow.ID, "error", err)
+	}
+}
+
+// copyOpen opens or adds to the follower's position on the leader's side,
+// sized by the copy mode and capped by the leverage and notional limits
+func (uc *UseCase) copyOpen(ctx context.Context, follow *domain.Follow, event domain.AccountEvent, record *domain.CopyTrade) error {
+	trade := event.Trade
+	record.Side = domain.OrderSideBuy
+	if trade.Side == domain.PositionSideShort {
+		record.Side = domain.OrderSideSell
+	}
+
+	position, err := uc.positionRepo.GetOpenByAccountIDAndSymbol(ctx, follow.FollowerAccountID, trade.Symbol)
+	if err != nil && !errors.Is(err, domain.ErrPositionNotFound) {
+		return err
+	}
+	if position != nil && position.Side != trade.Side {
+		record.Status = domain.CopyTradeSkipped
+		record.Error = "opposite position open"
+		return nil
+	}
+
+	leverage := follow.Leverage(event.Position.Leverage)
+	quantity := follow.OpenQuantity(trade.Quantity, trade.Price, leverage)
+	if follow.MaxPositionNotional != nil {
+		room := *follow.MaxPositionNotional
+		if position != nil {
+			room = room.Sub(position.Quantity.Mul(trade.Price))
+		}
+		quantity = decimal.Min(quantity, room.Div(trade.Price))
+	}
+	quantity = quantity.Truncate(quantityPlaces)
+	if !quantity.IsPositive() {
+		record.Status = domain.CopyTradeSkipped
+		record.Error = "max position notional reached"
+		return nil
+	}
+
+	output, err := uc.orderUC.PlaceOrder(ctx, orderuc.PlaceOrderInput{
+		AccountID: follow.FollowerAccountID,
+		Symbol:    trade.Symbol,
+		Side:      record.Side,
+		Type:      domain.OrderTypeMarket,
+		Quantity:  quantity,
+		Leverage:  leverage,
+	})
+	if err != nil {
+		return err
+	}
+
+	record.Status = domain.CopyTradeFilled
+	record.OrderID = &output.Order.ID
+	record.Quantity = output.Trade.Quantity
+	record.Price = output.Trade.Price
+	return nil
+}
+
+// copyReduce shrinks the follower's position by the fraction the leader's
+// position shrank; a full close or liquidation closes it entirely
+func (uc *UseCase) copyReduce(ctx context.Context, follow *domain.Follow, event domain.AccountEvent, record *domain.CopyTrade) error {
+	trade := event.Trade
+
+	position, err := uc.positionRepo.GetOpenByAccountIDAndSymbol(ctx, follow.FollowerAccountID, trade.Symbol)
+	if err != nil && !errors.Is(err, domain.ErrPositionNotFound) {
+		return err
+	}
+	if position == nil || position.Side != trade.Side {
+		record.Status = domain.CopyTradeSkipped
+		record.Error = "no position to reduce"
+		return nil
+	}
+	record.Side = domain.OrderSideSell
+	if position.IsShort() {
+		record.Side = domain.OrderSideBuy
+	}
+
+	// The leader's position is reported after the trade
+	input := positionuc.ClosePositionInput{
+		AccountID:  follow.FollowerAccountID,
+		PositionID: position.ID,
+	}
+	if event.Position.IsOpen() {
+		before := event.Position.Quantity.Add(trade.Quantity)
+		quantity := position.Quantity.Mul(trade.Quantity).Div(before).Truncate(quantityPlaces)
+		if !quantity.IsPositive() {
+			record.Status = domain.CopyTradeSkipped
+			record.Error = "quantity too small"
+			return nil
+		}
+		input.Quantity = &quantity
+	}
+
+	closeTrade, err := uc.positionUC.ClosePosition(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	record.Status = domain.CopyTradeFilled
+	record.OrderID = &closeTrade.OrderID
+	record.Quantity = closeTrade.Quantity
+	record.Price = closeTrade.Price
+	record.PnL = closeTrade.PnL
+	return nil
+}
@@ -0,0 +1,482 @@
+package dca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	orderuc "trading/internal/usecase/order"
+)
+
+const (
+	// runInterval is how often due runs, take profits and safety orders are checked
+	runInterval = 10 * time.Second
+	// lateAfter is how late a run may start before it counts as missed
+	lateAfter = time.Minute
+	// maxPlansPerAccount caps the active and paused plans of an account
+	maxPlansPerAccount = 10
+	// quantityPlaces is the precision of order quantities bought for an amount
+	quantityPlaces = 6
+)
+
+type UseCase struct {
+	dcaRepo      domain.DCARepository
+	positionRepo domain.PositionRepository
+	orderUC      *orderuc.UseCase
+	priceCache   domain.PriceCache
+
+	// mu serializes the scheduler and plan changes from the API
+	mu sync.Mutex
+}
+
+func NewUseCase(
+	dcaRepo domain.DCARepository,
+	positionRepo domain.PositionRepository,
+	orderUC *orderuc.UseCase,
+	priceCache domain.PriceCache,
+) *UseCase {
+	return &UseCase{
+		dcaRepo:      dcaRepo,
+		positionRepo: positionRepo,
+		orderUC:      orderUC,
+		priceCache:   priceCache,
+	}
+}
+
+// Start runs the scheduler until the context is cancelled
+func (uc *UseCase) Start(ctx context.Context) {
+	logger.Info("dca scheduler started")
+
+	ticker := time.NewTicker(runInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("dca scheduler stopping")
+			return
+		case now := <-ticker.C:
+			uc.RunDue(ctx, now)
+		}
+	}
+}
+
+type CreateInput struct {
+	UserID     domain.UserID
+	AccountID  domain.AccountID
+	Symbol     string
+	Amount     decimal.Decimal
+	Leverage   int           // perpetuals only, defaults to 1
+	Interval   time.Duration // time between runs
+	StartAt    time.Time     // first run; a past time is moved forward by whole intervals
+	MissedRuns domain.DCAMissedRuns
+
+	TakeProfitPct   *decimal.Decimal
+	SafetyDropPct   *decimal.Decimal
+	SafetyAmount    decimal.Decimal // defaults to Amount
+	MaxSafetyOrders int
+}
+
+// Create schedules a plan. Without StartAt the first run is due immediately.
+func (uc *UseCase) Create(ctx context.Context, input CreateInput) (*domain.DCAPlan, error) {
+	now := time.Now()
+	plan := &domain.DCAPlan{
+		UserID:          input.UserID,
+		AccountID:       input.AccountID,
+		Symbol:          input.Symbol,
+		Amount:          input.Amount,
+		Leverage:        input.Leverage,
+		Interval:        input.Interval,
+		NextRunAt:       input.StartAt,
+		MissedRuns:      input.MissedRuns,
+		TakeProfitPct:   input.TakeProfitPct,
+		SafetyDropPct:   input.SafetyDropPct,
+		SafetyAmount:    input.SafetyAmount,
+		MaxSafetyOrders: input.MaxSafetyOrders,
+		Status:          domain.DCAPlanStatusActive,
+	}
+	if plan.Leverage == 0 {
+		plan.Leverage = 1
+	}
+	if plan.MissedRuns == "" {
+		plan.MissedRuns = domain.DCAMissedRunsSkip
+	}
+	if plan.SafetyDropPct != nil && plan.SafetyAmount.IsZero() {
+		plan.SafetyAmount = plan.Amount
+	}
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+
+	instrument, ok := uc.orderUC.Instrument(plan.Symbol)
+	if !ok {
+		return nil, domain.ErrSymbolNotSupported
+	}
+	if instrument.IsSpot() && plan.Leverage != 1 {
+		return nil, domain.ErrInvalidDCAPlan
+	}
+
+	if plan.NextRunAt.IsZero() {
+		plan.NextRunAt = now
+	} else if plan.NextRunAt.Before(now) {
+		plan.Reschedule(now)
+	}
+	plan.NextRunAt = plan.NextRunAt.UTC().Truncate(time.Second)
+
+	existing, err := uc.dcaRepo.ListByAccountID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	open := 0
+	for _, p := range existing {
+		if p.Status != domain.DCAPlanStatusCancelled {
+			open++
+		}
+	}
+	if open >= maxPlansPerAccount {
+		return nil, domain.ErrTooManyDCAPlans
+	}
+
+	if err := uc.dcaRepo.Create(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	logger.Info("dca plan created",
+		"plan_id", plan.ID,
+		"account_id", plan.AccountID,
+		"symbol", plan.Symbol,
+		"amount", plan.Amount,
+		"interval", plan.Interval,
+	)
+
+	return plan, nil
+}
+
+// Get returns a plan of the account
+func (uc *UseCase) Get(ctx context.Context, accountID domain.AccountID, id domain.DCAPlanID) (*domain.DCAPlan, error) {
+	plan, err := uc.dcaRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if plan.AccountID != accountID {
+		return nil, domain.ErrDCAPlanNotFound
+	}
+	return plan, nil
+}
+
+// List returns the plans of the account, newest first
+func (uc *UseCase) List(ctx context.Context, accountID domain.AccountID) ([]domain.DCAPlan, error) {
+	return uc.dcaRepo.ListByAccountID(ctx, accountID)
+}
+
+// Executions returns the plan's execution history, newest first
+func (uc *UseCase) Executions(ctx context.Context, accountID domain.AccountID, id domain.DCAPlanID, limit int) ([]domain.DCAExecution, error) {
+	if _, err := uc.Get(ctx, accountID, id); err != nil {
+		return nil, err
+	}
+	return uc.dcaRepo.ListExecutions(ctx, id, limit)
+}
+
+// Pause stops scheduled runs, take profits and safety orders; the cycle is kept
+func (uc *UseCase) Pause(ctx context.Context, accountID domain.AccountID, id domain.DCAPlanID) (*domain.DCAPlan, error) {
+	return uc.setStatus(ctx, accountID, id, domain.DCAPlanStatusPaused)
+}
+
+// Resume restarts a paused plan from its next scheduled time; runs that fell
+// due while paused are not executed
+func (uc *UseCase) Resume(ctx context.Context, accountID domain.AccountID, id domain.DCAPlanID) (*domain.DCAPlan, error) {
+	return uc.setStatus(ctx, accountID, id, domain.DCAPlanStatusActive)
+}
+
+// Cancel ends the plan for good; bought assets and positions are kept
+func (uc *UseCase) Cancel(ctx context.Context, accountID domain.AccountID, id domain.DCAPlanID) (*domain.DCAPlan, error) {
+	return uc.setStatus(ctx, accountID, id, domain.DCAPlanStatusCancelled)
+}
+
+func (uc *UseCase) setStatus(ctx context.Context, accountID domain.AccountID, id domain.DCAPlanID, status domain.DCAPlanStatus) (*domain.DCAPlan, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	plan, err := uc.Get(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Status == domain.DCAPlanStatusCancelled {
+		return nil, domain.ErrDCAPlanCancelled
+	}
+	if plan.Status == status {
+		return plan, nil
+	}
+
+	if status == domain.DCAPlanStatusActive {
+		plan.Reschedule(time.Now())
+	}
+	plan.Status = status
+	if err := uc.dcaRepo.Update(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// RunDue executes the scheduled runs due at now and the take profits and
+// safety orders the current prices trigger
+func (uc *UseCase) RunDue(ctx context.Context, now time.Time) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	plans, err := uc.dcaRepo.GetActive(ctx)
+	if err != nil {
+		logger.Error("failed to get dca plans", "error", err)
+		return
+	}
+
+	for i := range plans {
+		plan := &plans[i]
+		if err := uc.run(ctx, plan, now); err != nil {
+			logger.Error("failed to run dca plan", "plan_id", plan.ID, "error", err)
+		}
+	}
+}
+
+// run executes what is due on the plan and saves it. The plan is saved even
+// when a step failed, so the buys recorded before the failure are kept.
+func (uc *UseCase) run(ctx context.Context, plan *domain.DCAPlan, now time.Time) error {
+	changed, err := uc.runSteps(ctx, plan, now)
+	if !changed {
+		return err
+	}
+	if updateErr := uc.dcaRepo.Update(ctx, plan); updateErr != nil {
+		return errors.Join(err, updateErr)
+	}
+	return err
+}
+
+// runSteps reports whether the plan changed since it was last saved
+func (uc *UseCase) runSteps(ctx context.Context, plan *domain.DCAPlan, now time.Time) (bool, error) {
+	changed := false
+
+	if !plan.NextRunAt.After(now) {
+		claimed, err := uc.runScheduled(ctx, plan, now)
+		if err != nil {
+			return claimed, err
+		}
+		changed = true
+	}
+
+	instrument, ok := uc.orderUC.Instrument(plan.Symbol)
+	if !ok {
+		return changed, domain.ErrSymbolNotSupported
+	}
+	if price, ok := uc.priceCache.Get(instrument.PriceSymbol); ok {
+		triggered, err := uc.runTriggers(ctx, plan, price, now)
+		changed = changed || triggered
+		if err != nil {
+			return changed, err
+		}
+	}
+	return changed, nil
+}
+
+// runScheduled executes the due runs under the plan's missed run policy. The
+// latest run is on time when it is less than lateAfter old; SKIP executes only
+// an on time run, CATCH_UP the latest MaxDCACatchUp runs. The rest are recorded
+// as one skipped execution. The runs are claimed with the next run time before
+// their orders are placed, so a failure in between never buys a run twice.
+// It reports whether the runs were claimed.
+func (uc *UseCase) runScheduled(ctx context.Context, plan *domain.DCAPlan, now time.Time) (bool, error) {
+	runs, total := plan.DueRuns(now, domain.MaxDCACatchUp)
+	if total == 0 {
+		return false, nil
+	}
+	latest := runs[len(runs)-1]
+
+	var execute []time.Time
+	switch {
+	case plan.MissedRuns == domain.DCAMissedRunsCatchUp:
+		execute = runs
+	case now.Sub(latest) < lateAfter:
+		execute = runs[len(runs)-1:]
+	}
+
+	var executions []*domain.DCAExecution
+	if skipped := total - len(execute); skipped > 0 {
+		executions = append(executions, &domain.DCAExecution{
+			PlanID: plan.ID,
+			Kind:   domain.DCAExecutionScheduled,
+			Status: domain.DCAExecutionSkipped,
+			RunAt:  latest.Add(-time.Duration(len(execute)) * plan.Interval),
+			Error:  fmt.Sprintf("%d missed run(s) skipped", skipped),
+		})
+	}
+	pending := make([]*domain.DCAExecution, len(execute))
+	for i, at := range execute {
+		pending[i] = &domain.DCAExecution{
+			PlanID: plan.ID,
+			Kind:   domain.DCAExecutionScheduled,
+			Status: domain.DCAExecutionPending,
+			RunAt:  at,
+		}
+	}
+
+	plan.Runs += len(execute)
+	plan.Reschedule(now)
+	if err := uc.dcaRepo.Claim(ctx, plan, append(executions, pending...)); err != nil {
+		return false, err
+	}
+
+	for _, execution := range pending {
+		if err := uc.buy(ctx, plan, execution, plan.Amount); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// runTriggers places the take profit or the next safety order when the price
+// has reached it. It reports whether one was claimed.
+func (uc *UseCase) runTriggers(ctx context.Context, plan *domain.DCAPlan, price *domain.Price, now time.Time) (bool, error) {
+	bid := decimal.NewFromFloat(price.Bid)
+	ask := decimal.NewFromFloat(price.Ask)
+
+	if target, ok := plan.TakeProfitPrice(); ok && bid.GreaterThanOrEqual(target) {
+		return uc.takeProfit(ctx, plan, now)
+	}
+	if target, ok := plan.SafetyPrice(); ok && ask.LessThanOrEqual(target) {
+		// A failed safety order still uses up its slot, so it is not retried
+		// every tick; the slot is claimed before the order is placed
+		plan.SafetyOrders++
+		execution := &domain.DCAExecution{
+			PlanID: plan.ID,
+			Kind:   domain.DCAExecutionSafety,
+			Status: domain.DCAExecutionPending,
+			RunAt:  now,
+		}
+		if err := uc.dcaRepo.Claim(ctx, plan, []*domain.DCAExecution{execution}); err != nil {
+			return false, err
+		}
+		return true, uc.buy(ctx, plan, execution, plan.SafetyAmount)
+	}
+	return false, nil
+}
+
+// buy spends amount of quote at market for the claimed execution and records
+// its result. Order rejections are recorded as failed executions, not returned.
+func (uc *UseCase) buy(ctx context.Context, plan *domain.DCAPlan, execution *domain.DCAExecution, amount decimal.Decimal) error {
+	output, err := uc.placeBuy(ctx, plan, amount)
+	if err != nil {
+		execution.Status = domain.DCAExecutionFailed
+		execution.Error = err.Error()
+		logger.Info("dca buy failed", "plan_id", plan.ID, "kind", execution.Kind, "error", err)
+	} else {
+		execution.Status = domain.DCAExecutionFilled
+		execution.OrderID = &output.Order.ID
+		execution.Quantity = output.Trade.Quantity
+		execution.Price = output.Trade.Price
+		plan.RecordBuy(output.Trade.Quantity, output.Trade.Price, output.Trade.Fee)
+	}
+
+	return uc.dcaRepo.UpdateExecution(ctx, execution)
+}
+
+func (uc *UseCase) placeBuy(ctx context.Context, plan *domain.DCAPlan, amount decimal.Decimal) (*orderuc.PlaceOrderOutput, error) {
+	instrument, _ := uc.orderUC.Instrument(plan.Symbol)
+	price, ok := uc.priceCache.Get(instrument.PriceSymbol)
+	if !ok {
+		return nil, domain.ErrPriceNotAvailable
+	}
+
+	// The amount is margin on perpetuals, so leverage scales the position
+	quantity := amount.Mul(decimal.NewFromInt(int64(plan.Leverage))).
+		Div(decimal.NewFromFloat(price.Ask)).Truncate(quantityPlaces)
+	if !quantity.IsPositive() {
+		return nil, domain.ErrInvalidQuantity
+	}
+
+	output, err := uc.orderUC.PlaceOrder(ctx, orderuc.PlaceOrderInput{
+		AccountID: plan.AccountID,
+		Symbol:    plan.Symbol,
+		Side:      domain.OrderSideBuy,
+		Type:      domain.OrderTypeMarket,
+		Quantity:  quantity,
+		Leverage:  plan.Leverage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if output.Trade == nil {
+		return nil, errors.New("market order was not filled")
+	}
+	return output, nil
+}
+
+// takeProfit sells the cycle's quantity at market. The sell is claimed by
+// pausing the plan, which a filled sell resumes: a failure in between never
+// sells the cycle twice, and a failed sell leaves the plan paused instead of
+// retrying on every tick. It reports whether the sell was claimed.
+func (uc *UseCase) takeProfit(ctx context.Context, plan *domain.DCAPlan, now time.Time) (bool, error) {
+	execution := &domain.DCAExecution{
+		PlanID: plan.ID,
+		Kind:   domain.DCAExecutionTakeProfit,
+		Status: domain.DCAExecutionPending,
+		RunAt:  now,
+	}
+	plan.Status = domain.DCAPlanStatusPaused
+	if err := uc.dcaRepo.Claim(ctx, plan, []*domain.DCAExecution{execution}); err != nil {
+		plan.Status = domain.DCAPlanStatusActive
+		return false, err
+	}
+
+	output, err := uc.placeTakeProfit(ctx, plan)
+	if err != nil {
+		execution.Status = domain.DCAExecutionFailed
+		execution.Error = err.Error()
+		logger.Info("dca take profit failed, plan paused", "plan_id", plan.ID, "error", err)
+	} else {
+		execution.Status = domain.DCAExecutionFilled
+		execution.OrderID = &output.Order.ID
+		execution.Quantity = output.Trade.Quantity
+		execution.Price = output.Trade.Price
+		plan.Status = domain.DCAPlanStatusActive
+		plan.RecordTakeProfit(output.Trade.Quantity, output.Trade.Price, output.Trade.Fee)
+	}
+
+	return true, uc.dcaRepo.UpdateExecution(ctx, execution)
+}
+
+// placeTakeProfit sells the cycle's quantity. On perpetuals the sell is capped
+// at the long position, so a position reduced outside the plan is not flipped.
+func (uc *UseCase) placeTakeProfit(ctx context.Context, plan *domain.DCAPlan) (*orderuc.PlaceOrderOutput, error) {
+	quantity := plan.Quantity
+	if instrument, _ := uc.orderUC.Instrument(plan.Symbol); !instrument.IsSpot() {
+		position, err := uc.positionRepo.GetOpenByAccountIDAndSymbol(ctx, plan.AccountID, plan.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		if !position.IsLong() {
+			return nil, domain.ErrPositionNotFound
+		}
+		quantity = decimal.Min(quantity, position.Quantity)
+	}
+
+	output, err := uc.orderUC.PlaceOrder(ctx, orderuc.PlaceOrderInput{
+		AccountID: plan.AccountID,
+		Symbol:    plan.Symbol,
+		Side:      domain.OrderSideSell,
+		Type:      domain.OrderTypeMarket,
+		Quantity:  quantity,
+		Leverage:  plan.Leverage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if output.Trade == nil {
+		return nil, errors.New("market order was not filled")
+	}
+	return output, nil
+}
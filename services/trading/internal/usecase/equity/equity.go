@@ -0,0 +1,193 @@
+package equity
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	accountuc "trading/internal/usecase/account"
+)
+
+const (
+	snapshotInterval = time.Minute
+	pruneInterval    = time.Hour
+
+	// maxPoints caps the number of buckets a single curve request may span
+	maxPoints = 1500
+)
+
+// retention is how long snapshots of each resolution are kept; daily ones are kept forever
+var retention = map[domain.EquityResolution]time.Duration{
+	domain.EquityResolutionMinute: 7 * 24 * time.Hour,
+	domain.EquityResolutionHour:   180 * 24 * time.Hour,
+}
+
+// defaultRange is the lookback used when the request has no from
+var defaultRange = map[domain.EquityResolution]time.Duration{
+	domain.EquityResolutionMinute: 24 * time.Hour,
+	domain.EquityResolutionHour:   30 * 24 * time.Hour,
+	domain.EquityResolutionDay:    365 * 24 * time.Hour,
+}
+
+type UseCase struct {
+	accountRepo  domain.AccountRepository
+	snapshotRepo domain.EquitySnapshotRepository
+	accountUC    *accountuc.UseCase
+}
+
+func NewUseCase(
+	accountRepo domain.AccountRepository,
+	snapshotRepo domain.EquitySnapshotRepository,
+	accountUC *accountuc.UseCase,
+) *UseCase {
+	return &UseCase{
+		accountRepo:  accountRepo,
+		snapshotRepo: snapshotRepo,
+		accountUC:    accountUC,
+	}
+}
+
+// Start snapshots all accounts every minute until the context is cancelled
+func (uc *UseCase) Start(ctx context.Context) {
+	logger.Info("equity snapshotter started")
+
+	ticker := time.NewTicker(snapshotInterval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("equity snapshotter stopping")
+			return
+		case now := <-ticker.C:
+			if _, err := uc.Snapshot(ctx, now); err != nil {
+				logger.Error("failed to snapshot equity", "error", err)
+			}
+			if now.Sub(lastPrune) >= pruneInterval {
+				uc.Prune(ctx, now)
+				lastPrune = now
+			}
+		}
+	}
+}
+
+// Snapshot records the current state of every account into the minute bucket
+// containing at, and returns how many accounts were recorded
+func (uc *UseCase) Snapshot(ctx context.Context, at time.Time) (int, error) {
+	accounts, err := uc.accountRepo.ListAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	recorded := 0
+	for i := range accounts {
+		account := &accounts[i]
+
+		summary, err := uc.accountUC.Summary(ctx, account)
+		if err != nil {
+			logger.Error("failed to get account summary", "account_id", account.ID, "error", err)
+			continue
+		}
+
+		snapshot := domain.NewEquitySnapshot(account.ID, at, *summary)
+		if err := uc.snapshotRepo.Record(ctx, &snapshot); err != nil {
+			logger.Error("failed to record equity snapshot", "account_id", account.ID, "error", err)
+			continue
+		}
+		recorded++
+	}
+
+	return recorded, nil
+}
+
+// Prune deletes snapshots past their resolution's retention
+func (uc *UseCase) Prune(ctx context.Context, now time.Time) {
+	for resolution, keep := range retention {
+		deleted, err := uc.snapshotRepo.DeleteBefore(ctx, resolution, now.Add(-keep))
+		if err != nil {
+			logger.Error("failed to prune equity snapshots", "resolution", resolution, "error", err)
+			continue
+		}
+		if deleted > 0 {
+			logger.Info("equity snapshots pruned", "resolution", resolution, "deleted", deleted)
+		}
+	}
+}
+
+type CurveInput struct {
+	Resolution domain.EquityResolution // empty = 1h
+	From       *time.Time
+	To         *time.Time
+}
+
+// EquityPoint is a snapshot with its drawdown from the running equity peak
+type EquityPoint struct {
+	domain.EquitySnapshot
+	Drawdown decimal.Decimal // (peak - equity) / peak
+}
+
+// Curve is the equity history of an account over a time range
+type Curve struct {
+	Resolution  domain.EquityResolution
+	From        time.Time
+	To          time.Time
+	Points      []EquityPoint
+	MaxDrawdown decimal.Decimal
+}
+
+// GetCurve returns the account's equity curve with drawdowns
+func (uc *UseCase) GetCurve(ctx context.Context, accountID domain.AccountID, input CurveInput) (*Curve, error) {
+	resolution := input.Resolution
+	if resolution == "" {
+		resolution = domain.EquityResolutionHour
+	}
+	if !resolution.IsValid() {
+		return nil, domain.ErrInvalidEquityResolution
+	}
+
+	to := time.Now().UTC()
+	if input.To != nil {
+		to = input.To.UTC()
+	}
+	from := to.Add(-defaultRange[resolution])
+	if input.From != nil {
+		from = input.From.UTC()
+	}
+	if from.After(to) || to.Sub(from) > time.Duration(maxPoints)*resolution.Duration() {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	snapshots, err := uc.snapshotRepo.GetByAccountID(ctx, accountID, resolution, resolution.Bucket(from), to)
+	if err != nil {
+		return nil, err
+	}
+
+	curve := &Curve{
+		Resolution:  resolution,
+		From:        from,
+		To:          to,
+		Points:      make([]EquityPoint, len(snapshots)),
+		MaxDrawdown: decimal.Zero,
+	}
+
+	peak := decimal.Zero
+	for i, s := range snapshots {
+		if s.Equity.GreaterThan(peak) {
+			peak = s.Equity
+		}
+		drawdown := decimal.Zero
+		if peak.IsPositive() {
+			drawdown = peak.Sub(s.Equity).Div(peak)
+		}
+		if drawdown.GreaterThan(curve.MaxDrawdown) {
+			curve.MaxDrawdown = drawdown
+		}
+		curve.Points[i] = EquityPoint{EquitySnapshot: s, Drawdown: drawdown}
+	}
+
+	return curve, nil
+}
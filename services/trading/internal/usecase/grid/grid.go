@@ -0,0 +1,496 @@
+package grid
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	accountuc "trading/internal/usecase/account"
+	orderuc "trading/internal/usecase/order"
+)
+
+// maxBotsPerAccount caps the active and paused bots of an account
+const maxBotsPerAccount = 5
+
+// quantityPlaces is the precision of the per order quantity
+const quantityPlaces = 6
+
+type UseCase struct {
+	gridRepo    domain.GridBotRepository
+	accountRepo domain.AccountRepository
+	accountUC   *accountuc.UseCase
+	orderUC     *orderuc.UseCase
+	priceCache  domain.PriceCache
+
+	// mu serializes bot changes between order events and the API
+	mu     sync.Mutex
+	loaded bool
+	active map[domain.GridBotID]*domain.GridBot
+}
+
+func NewUseCase(
+	gridRepo domain.GridBotRepository,
+	accountRepo domain.AccountRepository,
+	accountUC *accountuc.UseCase,
+	priceCache domain.PriceCache,
+) *UseCase {
+	return &UseCase{
+		gridRepo:    gridRepo,
+		accountRepo: accountRepo,
+		accountUC:   accountUC,
+		priceCache:  priceCache,
+		active:      make(map[domain.GridBotID]*domain.GridBot),
+	}
+}
+
+// SetTrading provides the use case bot orders are placed with. It publishes
+// their fills to this use case, so it is constructed after it.
+func (uc *UseCase) SetTrading(orderUC *orderuc.UseCase) {
+	uc.orderUC = orderUC
+}
+
+type CreateInput struct {
+	UserID     domain.UserID
+	AccountID  domain.AccountID
+	Symbol     string
+	LowerPrice decimal.Decimal
+	UpperPrice decimal.Decimal
+	Grids      int
+	Investment decimal.Decimal // margin committed to the grid, USDT
+	Leverage   int             // defaults to 1
+}
+
+// Summary is a bot with its results at the current mark price
+type Summary struct {
+	Bot           *domain.GridBot
+	MarkPrice     *decimal.Decimal // nil without a price
+	PnL           decimal.Decimal  // grid profit + unrealized PnL - fees
+	UnrealizedPnL decimal.Decimal  // result of the position beyond the grid profit
+	ROI           decimal.Decimal  // PnL / investment
+}
+
+// Create starts a bot: every level gets a limit order, buys below the current
+// price and sells above it, except the level nearest to the price. Each order
+// is sized so the grid uses investment * leverage of notional at the current price.
+func (uc *UseCase) Create(ctx context.Context, input CreateInput) (*Summary, error) {
+	bot := &domain.GridBot{
+		UserID:     input.UserID,
+		AccountID:  input.AccountID,
+		Symbol:     input.Symbol,
+		LowerPrice: input.LowerPrice,
+		UpperPrice: input.UpperPrice,
+		Grids:      input.Grids,
+		Investment: input.Investment,
+		Leverage:   input.Leverage,
+		Status:     domain.GridBotStatusActive,
+	}
+	if bot.Leverage == 0 {
+		bot.Leverage = 1
+	}
+	if err := bot.Validate(); err != nil {
+		return nil, err
+	}
+	// Grid orders are filled as perpetual orders only
+	if instrument, ok := uc.orderUC.Instrument(bot.Symbol); !ok || instrument.IsSpot() {
+		return nil, domain.ErrSymbolNotSupported
+	}
+
+	mark, err := uc.markPrice(bot)
+	if err != nil {
+		return nil, err
+	}
+	bot.Quantity = bot.Investment.Mul(decimal.NewFromInt(int64(bot.Leverage))).
+		Div(decimal.NewFromInt(int64(bot.Grids))).Div(mark).Truncate(quantityPlaces)
+	if !bot.Quantity.IsPositive() {
+		return nil, domain.ErrInvalidGridBot
+	}
+
+	account, err := uc.accountRepo.GetByID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := uc.accountUC.Summary(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	if summary.AvailableMargin.LessThan(bot.Investment) {
+		return nil, domain.ErrInsufficientMargin
+	}
+
+	existing, err := uc.gridRepo.ListByAccountID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	running := 0
+	for _, b := range existing {
+		if b.Status != domain.GridBotStatusStopped {
+			running++
+		}
+	}
+	if running >= maxBotsPerAccount {
+		return nil, domain.ErrTooManyGridBots
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if err := uc.load(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := uc.gridRepo.Create(ctx, bot); err != nil {
+		return nil, err
+	}
+	if err := uc.placeGrid(ctx, bot, mark); err != nil {
+		// Leave nothing behind: the bot is recorded as stopped
+		uc.cancelOrders(ctx, bot)
+		now := time.Now()
+		bot.Status = domain.GridBotStatusStopped
+		bot.StoppedAt = &now
+		if updateErr := uc.gridRepo.Update(ctx, bot); updateErr != nil {
+			logger.Error("failed to stop grid bot", "bot_id", bot.ID, "error", updateErr)
+		}
+		return nil, err
+	}
+	if err := uc.gridRepo.Update(ctx, bot); err != nil {
+		return nil, err
+	}
+	uc.active[bot.ID] = bot
+
+	logger.Info("grid bot started",
+		"bot_id", bot.ID,
+		"account_id", bot.AccountID,
+		"symbol", bot.Symbol,
+		"grids", bot.Grids,
+		"quantity", bot.Quantity,
+	)
+
+	return uc.summarize(bot), nil
+}
+
+// Get returns a bot of the account with its results
+func (uc *UseCase) Get(ctx context.Context, accountID domain.AccountID, id domain.GridBotID) (*Summary, error) {
+	bot, err := uc.gridRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if bot.AccountID != accountID {
+		return nil, domain.ErrGridBotNotFound
+	}
+	return uc.summarize(bot), nil
+}
+
+// List returns the bots of the account, newest first
+func (uc *UseCase) List(ctx context.Context, accountID domain.AccountID) ([]Summary, error) {
+	bots, err := uc.gridRepo.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]Summary, len(bots))
+	for i := range bots {
+		summaries[i] = *uc.summarize(&bots[i])
+	}
+	return summaries, nil
+}
+
+// Pause cancels the bot's orders and keeps its position
+func (uc *UseCase) Pause(ctx context.Context, accountID domain.AccountID, id domain.GridBotID) (*Summary, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	bot, err := uc.bot(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if bot.Status == domain.GridBotStatusStopped {
+		return nil, domain.ErrGridBotStopped
+	}
+	if bot.Status == domain.GridBotStatusPaused {
+		return uc.summarize(bot), nil
+	}
+
+	uc.cancelOrders(ctx, bot)
+	bot.Status = domain.GridBotStatusPaused
+	if err := uc.gridRepo.Update(ctx, bot); err != nil {
+		return nil, err
+	}
+	delete(uc.active, bot.ID)
+
+	return uc.summarize(bot), nil
+}
+
+// Resume lays the grid out again around the current price
+func (uc *UseCase) Resume(ctx context.Context, accountID domain.AccountID, id domain.GridBotID) (*Summary, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	bot, err := uc.bot(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if bot.Status == domain.GridBotStatusStopped {
+		return nil, domain.ErrGridBotStopped
+	}
+	if bot.Status == domain.GridBotStatusActive {
+		return uc.summarize(bot), nil
+	}
+
+	mark, err := uc.markPrice(bot)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.placeGrid(ctx, bot, mark); err != nil {
+		uc.cancelOrders(ctx, bot)
+		return nil, err
+	}
+	bot.Status = domain.GridBotStatusActive
+	if err := uc.gridRepo.Update(ctx, bot); err != nil {
+		return nil, err
+	}
+	uc.active[bot.ID] = bot
+
+	return uc.summarize(bot), nil
+}
+
+// Stop cancels the bot's orders for good. With closePosition the position the
+// bot built is closed at market.
+func (uc *UseCase) Stop(ctx context.Context, accountID domain.AccountID, id domain.GridBotID, closePosition bool) (*Summary, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	bot, err := uc.bot(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if bot.Status == domain.GridBotStatusStopped {
+		return nil, domain.ErrGridBotStopped
+	}
+
+	uc.cancelOrders(ctx, bot)
+
+	if closePosition && !bot.Position.IsZero() {
+		side := domain.OrderSideSell
+		if bot.Position.IsNegative() {
+			side = domain.OrderSideBuy
+		}
+		output, err := uc.orderUC.PlaceOrder(ctx, orderuc.PlaceOrderInput{
+			AccountID: bot.AccountID,
+			Symbol:    bot.Symbol,
+			Side:      side,
+			Type:      domain.OrderTypeMarket,
+			Quantity:  bot.Position.Abs(),
+			Leverage:  bot.Leverage,
+		})
+		if err != nil {
+			// The orders are gone; keep the bot paused so the close can be retried
+			bot.Status = domain.GridBotStatusPaused
+			if updateErr := uc.gridRepo.Update(ctx, bot); updateErr != nil {
+				logger.Error("failed to pause grid bot", "bot_id", bot.ID, "error", updateErr)
+			}
+			delete(uc.active, bot.ID)
+			return nil, err
+		}
+		if output.Trade != nil {
+			bot.CloseOut(side, output.Trade.Quantity, output.Trade.Price, output.Trade.Fee)
+		}
+	}
+
+	now := time.Now()
+	bot.Status = domain.GridBotStatusStopped
+	bot.StoppedAt = &now
+	if err := uc.gridRepo.Update(ctx, bot); err != nil {
+		return nil, err
+	}
+	delete(uc.active, bot.ID)
+
+	logger.Info("grid bot stopped", "bot_id", bot.ID, "close_position", closePosition)
+
+	return uc.summarize(bot), nil
+}
+
+// Publish follows the fills and rejections of the bots' resting orders: a
+// filled level gets the opposite order one level away, a rejected one stays
+// empty. Only limit orders are bot orders, so the market close of Stop, which
+// runs under the lock, is never waited on here.
+func (uc *UseCase) Publish(ctx context.Context, event domain.AccountEvent) {
+	if event.Order == nil || !event.Order.IsLimit() {
+		return
+	}
+	if event.Type != domain.EventTypeFill && event.Type != domain.EventTypeOrderRejected {
+		return
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if err := uc.load(ctx); err != nil {
+		logger.Error("failed to load grid bots", "error", err)
+		return
+	}
+
+	for _, bot := range uc.active {
+		if bot.AccountID != event.AccountID || bot.Symbol != event.Order.Symbol {
+			continue
+		}
+		for _, o := range bot.Orders {
+			if o.OrderID == event.Order.ID {
+				uc.process(ctx, bot, o, event)
+				return
+			}
+		}
+	}
+}
+
+func (uc *UseCase) process(ctx context.Context, bot *domain.GridBot, o domain.GridOrder, event domain.AccountEvent) {
+	bot.Orders = removeLevel(bot.Orders, o.Level)
+
+	switch {
+	case event.Type == domain.EventTypeOrderRejected:
+		logger.Warn("grid order rejected", "bot_id", bot.ID, "order_id", o.OrderID, "level", o.Level)
+	case event.Trade != nil:
+		bot.Fill(o.Side, event.Trade.Quantity, event.Trade.Price, event.Trade.Fee)
+
+		// A counter order is a full step beyond the fill, so it cannot cross on the same price
+		counterLevel, counterSide := o.Level+1, domain.OrderSideSell
+		if o.Side == domain.OrderSideSell {
+			counterLevel, counterSide = o.Level-1, domain.OrderSideBuy
+		}
+		if counterLevel >= 0 && counterLevel <= bot.Grids {
+			if err := uc.place(ctx, bot, counterLevel, counterSide); err != nil {
+				logger.Error("failed to place grid order", "bot_id", bot.ID, "level", counterLevel, "error", err)
+			}
+		}
+	}
+
+	err := uc.gridRepo.Update(ctx, bot)
+	if errors.Is(err, domain.ErrGridBotNotFound) {
+		// Removed together with its account
+		delete(uc.active, bot.ID)
+	} else if err != nil {
+		logger.Error("failed to save grid bot", "bot_id", bot.ID, "error", err)
+	}
+}
+
+// load reads the active bots once; afterwards the map is kept up to date
+func (uc *UseCase) load(ctx context.Context) error {
+	if uc.loaded {
+		return nil
+	}
+	bots, err := uc.gridRepo.GetActive(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range bots {
+		uc.active[bots[i].ID] = &bots[i]
+	}
+	uc.loaded = true
+	return nil
+}
+
+// bot returns the account's bot, the live copy for active ones
+func (uc *UseCase) bot(ctx context.Context, accountID domain.AccountID, id domain.GridBotID) (*domain.GridBot, error) {
+	if err := uc.load(ctx); err != nil {
+		return nil, err
+	}
+	bot, ok := uc.active[id]
+	if !ok {
+		var err error
+		if bot, err = uc.gridRepo.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	if bot.AccountID != accountID {
+		return nil, domain.ErrGridBotNotFound
+	}
+	return bot, nil
+}
+
+func (uc *UseCase) markPrice(bot *domain.GridBot) (decimal.Decimal, error) {
+	price, ok := uc.priceCache.Get(bot.Symbol)
+	if !ok {
+		return decimal.Zero, domain.ErrPriceNotAvailable
+	}
+	mark := decimal.NewFromFloat(price.Mid())
+	if !mark.GreaterThan(bot.LowerPrice) || !mark.LessThan(bot.UpperPrice) {
+		return decimal.Zero, domain.ErrPriceOutsideGrid
+	}
+	return mark, nil
+}
+
+// placeGrid places buys below and sells above the level nearest to mark
+func (uc *UseCase) placeGrid(ctx context.Context, bot *domain.GridBot, mark decimal.Decimal) error {
+	skip := bot.NearestLevel(mark)
+	for i := 0; i <= bot.Grids; i++ {
+		if i == skip {
+			continue
+		}
+		side := domain.OrderSideBuy
+		if i > skip {
+			side = domain.OrderSideSell
+		}
+		if err := uc.place(ctx, bot, i, side); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *UseCase) place(ctx context.Context, bot *domain.GridBot, level int, side domain.OrderSide) error {
+	output, err := uc.orderUC.PlaceOrder(ctx, orderuc.PlaceOrderInput{
+		AccountID: bot.AccountID,
+		Symbol:    bot.Symbol,
+		Side:      side,
+		Type:      domain.OrderTypeLimit,
+		Quantity:  bot.Quantity,
+		Price:     bot.LevelPrice(level),
+		Leverage:  bot.Leverage,
+	})
+	if err != nil {
+		return err
+	}
+
+	bot.Orders = append(bot.Orders, domain.GridOrder{Level: level, Side: side, OrderID: output.Order.ID})
+	sort.Slice(bot.Orders, func(i, j int) bool { return bot.Orders[i].Level < bot.Orders[j].Level })
+	return nil
+}
+
+// cancelOrders cancels the bot's resting orders; ones already gone are skipped
+func (uc *UseCase) cancelOrders(ctx context.Context, bot *domain.GridBot) {
+	for _, o := range bot.Orders {
+		err := uc.orderUC.CancelOrder(ctx, bot.AccountID, o.OrderID)
+		if err != nil && !errors.Is(err, domain.ErrOrderNotPending) && !errors.Is(err, domain.ErrOrderNotFound) {
+			logger.Error("failed to cancel grid order", "bot_id", bot.ID, "order_id", o.OrderID, "error", err)
+		}
+	}
+	bot.Orders = nil
+}
+
+func (uc *UseCase) summarize(bot *domain.GridBot) *Summary {
+	copied := *bot
+	copied.Orders = append([]domain.GridOrder(nil), bot.Orders...)
+
+	summary := &Summary{Bot: &copied, PnL: bot.GridProfit.Sub(bot.Fees)}
+	if price, ok := uc.priceCache.Get(bot.Symbol); ok {
+		mark := decimal.NewFromFloat(price.Mid())
+		summary.MarkPrice = &mark
+		summary.PnL = bot.PnL(mark)
+	}
+	summary.UnrealizedPnL = summary.PnL.Sub(bot.GridProfit).Add(bot.Fees)
+	summary.ROI = summary.PnL.Div(bot.Investment)
+	return summary
+}
+
+func removeLevel(orders []domain.GridOrder, level int) []domain.GridOrder {
+	kept := orders[:0:0]
+	for _, o := range orders {
+		if o.Level != level {
+			kept = append(kept, o)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,288 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+)
+
+const (
+	// refreshInterval is how long a computed leaderboard is served before it is rebuilt
+	refreshInterval = time.Minute
+
+	defaultLimit = 100
+	maxLimit     = 500
+)
+
+// board is the cached result of one period, ranked by every metric
+type board struct {
+	from      *time.Time
+	updatedAt time.Time
+	ranked    map[domain.LeaderboardMetric][]domain.LeaderboardEntry
+}
+
+type UseCase struct {
+	userRepo        domain.UserRepository
+	leaderboardRepo domain.LeaderboardRepository
+
+	mu     sync.RWMutex
+	boards map[domain.LeaderboardPeriod]*board
+}
+
+func NewUseCase(
+	userRepo domain.UserRepository,
+	leaderboardRepo domain.LeaderboardRepository,
+) *UseCase {
+	return &UseCase{
+		userRepo:        userRepo,
+		leaderboardRepo: leaderboardRepo,
+		boards:          make(map[domain.LeaderboardPeriod]*board),
+	}
+}
+
+// Start rebuilds all leaderboards every refresh interval until the context is cancelled
+func (uc *UseCase) Start(ctx context.Context) {
+	logger.Info("leaderboard refresher started")
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("leaderboard refresher stopping")
+			return
+		case now := <-ticker.C:
+			if err := uc.Refresh(ctx, now); err != nil {
+				logger.Error("failed to refresh leaderboards", "error", err)
+			}
+		}
+	}
+}
+
+// Refresh rebuilds the leaderboards of every period
+func (uc *UseCase) Refresh(ctx context.Context, now time.Time) error {
+	for _, period := range domain.LeaderboardPeriods {
+		if _, err := uc.rebuild(ctx, period, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type Input struct {
+	Period domain.LeaderboardPeriod // default weekly
+	Metric domain.LeaderboardMetric // default pnl
+	Limit  int
+}
+
+// GetLeaderboard returns the top traders of the period ranked by the metric.
+// Boards are served from the cache and rebuilt once stale or when a new period begins.
+func (uc *UseCase) GetLeaderboard(ctx context.Context, input Input) (*domain.Leaderboard, error) {
+	if input.Period == "" {
+		input.Period = domain.LeaderboardPeriodWeekly
+	}
+	if input.Metric == "" {
+		input.Metric = domain.LeaderboardMetricPnL
+	}
+	if !input.Period.IsValid() {
+		return nil, domain.ErrInvalidLeaderboardPeriod
+	}
+	if !input.Metric.IsValid() {
+		return nil, domain.ErrInvalidLeaderboardMetric
+	}
+	if input.Limit <= 0 {
+		input.Limit = defaultLimit
+	}
+	if input.Limit > maxLimit {
+		input.Limit = maxLimit
+	}
+
+	now := time.Now()
+	b := uc.cached(input.Period, now)
+	if b == nil {
+		var err error
+		if b, err = uc.rebuild(ctx, input.Period, now); err != nil {
+			return nil, err
+		}
+	}
+
+	entries := b.ranked[input.Metric]
+	if len(entries) > input.Limit {
+		entries = entries[:input.Limit]
+	}
+
+	return &domain.Leaderboard{
+		Period:    input.Period,
+		Metric:    input.Metric,
+		From:      b.from,
+		UpdatedAt: b.updatedAt,
+		Entries:   entries,
+	}, nil
+}
+
+// Invalidate drops all cached boards so the next request rebuilds them
+func (uc *UseCase) Invalidate() {
+	uc.mu.Lock()
+	uc.boards = make(map[domain.LeaderboardPeriod]*board)
+	uc.mu.Unlock()
+}
+
+// cached returns the period's board if it is fresh and covers the current period
+func (uc *UseCase) cached(period domain.LeaderboardPeriod, now time.Time) *board {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	b, ok := uc.boards[period]
+	if !ok || now.Sub(b.updatedAt) >= refreshInterval {
+		return nil
+	}
+	if start := period.Start(now); start != nil && !start.Equal(*b.from) {
+		return nil
+	}
+	return b
+}
+
+func (uc *UseCase) rebuild(ctx context.Context, period domain.LeaderboardPeriod, now time.Time) (*board, error) {
+	from := period.Start(now)
+
+	performance, err := uc.leaderboardRepo.GetPerformance(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &board{
+		from:      from,
+		updatedAt: now,
+		ranked: map[domain.LeaderboardMetric][]domain.LeaderboardEntry{
+			domain.LeaderboardMetricPnL: rank(performance, func(p *domain.TraderPerformance) *decimal.Decimal {
+				return &p.RealizedPnL
+			}),
+			domain.LeaderboardMetricROI: rank(performance, func(p *domain.TraderPerformance) *decimal.Decimal {
+				roi := p.ROI()
+				return &roi
+			}),
+			domain.LeaderboardMetricRiskAdjusted: rank(performance, func(p *domain.TraderPerformance) *decimal.Decimal {
+				return p.RiskAdjusted()
+			}),
+		},
+	}
+
+	uc.mu.Lock()
+	uc.boards[period] = b
+	uc.mu.Unlock()
+
+	return b, nil
+}
+
+// rank orders traders by score, highest first; traders without a score are
+// left out. Ties go to the trader with more trades, then by name.
+func rank(performance []domain.TraderPerformance, score func(*domain.TraderPerformance) *decimal.Decimal) []domain.LeaderboardEntry {
+	type scored struct {
+		p     *domain.TraderPerformance
+		score decimal.Decimal
+	}
+
+	list := make([]scored, 0, len(performance))
+	for i := range performance {
+		if s := score(&performance[i]); s != nil {
+			list = append(list, scored{p: &performance[i], score: *s})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if c := list[i].score.Cmp(list[j].score); c != 0 {
+			return c > 0
+		}
+		if list[i].p.Trades != list[j].p.Trades {
+			return list[i].p.Trades > list[j].p.Trades
+		}
+		return list[i].p.DisplayName < list[j].p.DisplayName
+	})
+
+	entries := make([]domain.LeaderboardEntry, len(list))
+	for i, s := range list {
+		p := s.p
+		entries[i] = domain.LeaderboardEntry{
+			Rank:         i + 1,
+			DisplayName:  p.DisplayName,
+			RealizedPnL:  p.RealizedPnL,
+			ROI:          p.ROI(),
+			RiskAdjusted: p.RiskAdjusted(),
+			Trades:       p.Trades,
+			WinRate:      decimal.NewFromInt(int64(p.Wins)).Div(decimal.NewFromInt(int64(p.Trades))),
+		}
+	}
+	return entries
+}
+
+// Profile is a user's public leaderboard identity
+type Profile struct {
+	DisplayName string
+	OptIn       bool
+}
+
+// GetProfile returns the user's leaderboard profile
+func (uc *UseCase) GetProfile(ctx context.Context, userID domain.UserID) (*Profile, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &Profile{DisplayName: user.DisplayName, OptIn: user.LeaderboardOptIn}, nil
+}
+
+type UpdateProfileInput struct {
+	DisplayName *string
+	OptIn       *bool
+}
+
+// UpdateProfile sets the display name and leaderboard opt-in. Opting in
+// requires a display name. Cached boards are dropped so the change shows at once.
+func (uc *UseCase) UpdateProfile(ctx context.Context, userID domain.UserID, input UpdateProfileInput) (*Profile, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.DisplayName != nil {
+		name := *input.DisplayName
+		if err := domain.ValidateDisplayName(name); err != nil {
+			return nil, err
+		}
+
+		existing, err := uc.userRepo.GetByDisplayName(ctx, name)
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		if existing != nil && existing.ID != userID {
+			return nil, domain.ErrDisplayNameTaken
+		}
+		user.DisplayName = name
+	}
+	if input.OptIn != nil {
+		user.LeaderboardOptIn = *input.OptIn
+	}
+	if user.LeaderboardOptIn && user.DisplayName == "" {
+		return nil, domain.ErrDisplayNameMissing
+	}
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	uc.Invalidate()
+
+	logger.Info("leaderboard profile updated",
+		"user_id", userID,
+		"display_name", user.DisplayName,
+		"opt_in", user.LeaderboardOptIn,
+	)
+
+	return &Profile{DisplayName: user.DisplayName, OptIn: user.LeaderboardOptIn}, nil
+}
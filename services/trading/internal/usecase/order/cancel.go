@@ -17,28 +17,28 @@ type UpdateOrderInput struct {
 	TakeProfit *decimal.Decimal
 }
 
-func (uc *UseCase) GetOrder(ctx context.Context, userID domain.UserID, orderID domain.OrderID) (*domain.Order, error) {
+func (uc *UseCase) GetOrder(ctx context.Context, accountID domain.AccountID, orderID domain.OrderID) (*domain.Order, error) {
 	order, err := uc.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Verify ownership
-	if order.UserID != userID {
+	if order.AccountID != accountID {
 		return nil, domain.ErrOrderNotFound
 	}
 
 	return order, nil
 }
 
-func (uc *UseCase) CancelOrder(ctx context.Context, userID domain.UserID, orderID domain.OrderID) error {
+func (uc *UseCase) CancelOrder(ctx context.Context, accountID domain.AccountID, orderID domain.OrderID) error {
 	order, err := uc.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
 		return err
 	}
 
 	// Verify ownership
-	if order.UserID != userID {
+	if order.AccountID != accountID {
 		return domain.ErrOrderNotFound
 	}
 
@@ -59,27 +59,46 @@ func (uc *UseCase) CancelOrder(ctx context.Context, userID domain.UserID, orderI
 	return nil
 }
 
-func (uc *UseCase) GetOrders(ctx context.Context, userID domain.UserID, limit, offset int) ([]domain.Order, error) {
-	if limit <= 0 {
-		limit = 50
+// GetOrders returns a page of orders, newest first, and the cursor of the next page
+func (uc *UseCase) GetOrders(ctx context.Context, accountID domain.AccountID, filter domain.OrderFilter) ([]domain.Order, *domain.Cursor, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, nil, err
 	}
-	if limit > 100 {
-		limit = 100
+	if filter.Limit <= 0 {
+		filter.Limit = 50
 	}
-	return uc.orderRepo.GetByUserID(ctx, userID, limit, offset)
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	// One extra row tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	orders, err := uc.orderRepo.GetByAccountID(ctx, accountID, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *domain.Cursor
+	if len(orders) > limit {
+		orders = orders[:limit]
+		last := orders[limit-1]
+		next = &domain.Cursor{Time: last.CreatedAt, ID: int64(last.ID)}
+	}
+	return orders, next, nil
 }
 
-func (uc *UseCase) GetPendingOrders(ctx context.Context, userID domain.UserID) ([]domain.Order, error) {
-	return uc.orderRepo.GetPendingByUserID(ctx, userID)
+func (uc *UseCase) GetPendingOrders(ctx context.Context, accountID domain.AccountID) ([]domain.Order, error) {
+	return uc.orderRepo.GetPendingByAccountID(ctx, accountID)
 }
 
-func (uc *UseCase) UpdateOrder(ctx context.Context, userID domain.UserID, orderID domain.OrderID, input UpdateOrderInput) (*domain.Order, error) {
+func (uc *UseCase) UpdateOrder(ctx context.Context, accountID domain.AccountID, orderID domain.OrderID, input UpdateOrderInput) (*domain.Order, error) {
 	order, err := uc.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
 		return nil, err
 	}
 
-	if order.UserID != userID {
+	if order.AccountID != accountID {
 		return nil, domain.ErrOrderNotFound
 	}
 
@@ -87,6 +106,11 @@ func (uc *UseCase) UpdateOrder(ctx context.Context, userID domain.UserID, orderI
 		return nil, domain.ErrOrderNotPending
 	}
 
+	// Algo orders follow their schedule
+	if order.IsAlgo() {
+		return nil, domain.ErrAlgoOrderNotEditable
+	}
+
 	if input.Price != nil {
 		if !input.Price.IsPositive() {
 			return nil, domain.ErrInvalidPrice
@@ -101,6 +125,10 @@ func (uc *UseCase) UpdateOrder(ctx context.Context, userID domain.UserID, orderI
 		order.Quantity = *input.Quantity
 	}
 
+	if uc.instruments[order.Symbol].IsSpot() && (input.StopLoss != nil || input.TakeProfit != nil) {
+		return nil, domain.ErrSpotStopsNotAllowed
+	}
+
 	if input.StopLoss != nil {
 		order.StopLoss = input.StopLoss
 	}
@@ -0,0 +1,128 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	"trading/internal/metrics"
+)
+
+// MatchLimitOrders fills the pending limit orders of the price's symbol that
+// the market has reached, at their limit price: buys once the ask is at or
+// below the limit, sells once the bid is at or above it. Orders placed while
+// matching wait for the next price.
+func (uc *UseCase) MatchLimitOrders(ctx context.Context, price *domain.Price) {
+	orders, err := uc.orderRepo.GetPendingBySymbol(ctx, price.Symbol)
+	if err != nil {
+		logger.Error("failed to get pending orders", "symbol", price.Symbol, "error", err)
+		return
+	}
+
+	bid := decimal.NewFromFloat(price.Bid)
+	ask := decimal.NewFromFloat(price.Ask)
+	for i := range orders {
+		order := &orders[i]
+		if !order.IsLimit() {
+			continue
+		}
+		if (order.IsBuy() && ask.GreaterThan(order.Price)) || (order.IsSell() && bid.LessThan(order.Price)) {
+			continue
+		}
+
+		_, err := uc.FillOrder(ctx, order.ID)
+		if err != nil && !errors.Is(err, domain.ErrOrderNotPending) && !isRejection(err) {
+			logger.Error("failed to fill limit order", "order_id", order.ID, "error", err)
+		}
+	}
+}
+
+// FillOrder executes a pending perpetual limit order at its limit price. The
+// caller decides the market has reached the price. Resting orders hold no
+// margin, so the order is checked again like a new one; one that no longer
+// passes is rejected and reported to event subscribers.
+func (uc *UseCase) FillOrder(ctx context.Context, orderID domain.OrderID) (*PlaceOrderOutput, error) {
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !order.IsPending() || !order.IsLimit() {
+		return nil, domain.ErrOrderNotPending
+	}
+	instrument, ok := uc.instruments[order.Symbol]
+	if !ok || instrument.IsSpot() {
+		return nil, domain.ErrSymbolNotSupported
+	}
+
+	account, err := uc.accountRepo.GetByID(ctx, order.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	err = uc.checkAccount(ctx, account, PlaceOrderInput{Symbol: order.Symbol, Leverage: order.Leverage})
+	if err == nil {
+		err = uc.checkMargin(ctx, account, order.Quantity, order.Price, order.Leverage)
+	}
+	if err != nil {
+		if isRejection(err) {
+			uc.reject(ctx, order, err)
+		}
+		return nil, err
+	}
+
+	existingPosition, err := uc.positionRepo.GetOpenByAccountIDAndSymbol(ctx, account.ID, order.Symbol)
+	if err != nil && !errors.Is(err, domain.ErrPositionNotFound) {
+		return nil, err
+	}
+
+	output, err := uc.executeOrder(ctx, order, existingPosition, order.Price, account)
+	if err != nil {
+		return nil, err
+	}
+	uc.publishFill(ctx, output)
+	return output, nil
+}
+
+// isRejection reports whether a fill failed the checks of a new order rather
+// than on an error that may pass on the next price
+func isRejection(err error) bool {
+	return errors.Is(err, domain.ErrAccountLocked) ||
+		errors.Is(err, domain.ErrInsufficientMargin) ||
+		errors.Is(err, domain.ErrCompetitionNotActive) ||
+		errors.Is(err, domain.ErrSymbolNotAllowed) ||
+		errors.Is(err, domain.ErrInvalidLeverage)
+}
+
+// reject moves a resting order that failed its fill checks to REJECTED
+func (uc *UseCase) reject(ctx context.Context, order *domain.Order, reason error) {
+	order.Status = domain.OrderStatusRejected
+	if err := uc.orderRepo.Update(ctx, order); err != nil {
+		// Cancelled meanwhile
+		if !errors.Is(err, domain.ErrOrderNotPending) {
+			logger.Error("failed to reject order", "order_id", order.ID, "error", err)
+		}
+		return
+	}
+
+	metrics.RecordOrderCancelled(order.Symbol)
+	logger.Info("limit order rejected", "order_id", order.ID, "reason", reason)
+
+	if uc.events != nil {
+		uc.events.Publish(ctx, domain.AccountEvent{
+			Type:      domain.EventTypeOrderRejected,
+			UserID:    order.UserID,
+			AccountID: order.AccountID,
+			Order:     order,
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+// Instrument returns the trading settings of a supported symbol
+func (uc *UseCase) Instrument(symbol string) (domain.Instrument, bool) {
+	instrument, ok := uc.instruments[symbol]
+	return instrument, ok
+}
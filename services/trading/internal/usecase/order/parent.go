@@ -0,0 +1,74 @@
+package order
+
+import (
+	"context"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	"trading/internal/metrics"
+)
+
+// PlaceParentOrder creates the pending parent of an algo order. It is checked
+// like its child orders would be, with Price as an optional limit, but holds no
+// margin and never fills itself: the child orders placed with its ID as
+// ParentID do.
+func (uc *UseCase) PlaceParentOrder(ctx context.Context, input PlaceOrderInput) (*domain.Order, error) {
+	instrument, ok := uc.instruments[input.Symbol]
+	if !ok {
+		return nil, domain.ErrSymbolNotSupported
+	}
+	if instrument.IsSpot() && input.Leverage == 0 {
+		input.Leverage = 1
+	}
+
+	order := &domain.Order{Type: input.Type}
+	if !order.IsAlgo() {
+		return nil, domain.ErrInvalidOrderType
+	}
+	if input.Price.IsNegative() {
+		return nil, domain.ErrInvalidPrice
+	}
+	child := input
+	child.Type = domain.OrderTypeMarket
+	if input.Price.IsPositive() {
+		child.Type = domain.OrderTypeLimit
+	}
+	if err := uc.validateInput(child, instrument); err != nil {
+		return nil, err
+	}
+
+	account, err := uc.accountRepo.GetByID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkAccount(ctx, account, input); err != nil {
+		return nil, err
+	}
+
+	*order = domain.Order{
+		UserID:     account.UserID,
+		AccountID:  account.ID,
+		Symbol:     input.Symbol,
+		Side:       input.Side,
+		Type:       input.Type,
+		Status:     domain.OrderStatusPending,
+		Quantity:   input.Quantity,
+		Price:      input.Price,
+		Leverage:   input.Leverage,
+		StopLoss:   input.StopLoss,
+		TakeProfit: input.TakeProfit,
+	}
+	if err := uc.orderRepo.Create(ctx, order); err != nil {
+		return nil, err
+	}
+
+	metrics.RecordOrderPlaced(input.Symbol, string(input.Side), string(input.Type))
+	logger.Info("parent order placed",
+		"order_id", order.ID,
+		"type", order.Type,
+		"symbol", order.Symbol,
+		"quantity", order.Quantity,
+	)
+
+	return order, nil
+}
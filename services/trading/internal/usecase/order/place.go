@@ -14,7 +14,7 @@ import (
 )
 
 type PlaceOrderInput struct {
-	UserID     domain.UserID
+	AccountID  domain.AccountID
 	Symbol     string
 	Side       domain.OrderSide
 	Type       domain.OrderType
@@ -23,6 +23,7 @@ type PlaceOrderInput struct {
 	Leverage   int
 	StopLoss   *decimal.Decimal
 	TakeProfit *decimal.Decimal
+	ParentID   *domain.OrderID // places a child order of an algo order
 }
 
 type PlaceOrderOutput struct {
@@ -36,9 +37,14 @@ type UseCase struct {
 	positionRepo domain.PositionRepository
 	accountRepo  domain.AccountRepository
 	tradeRepo    domain.TradeRepository
+	ledgerRepo   domain.LedgerRepository
+	walletRepo   domain.WalletRepository
+	spotLotRepo  domain.SpotLotRepository
+	compRepo     domain.CompetitionRepository
 	priceCache   domain.PriceCache
 	engine       *engine.Engine
-	symbols      map[string]bool
+	instruments  map[string]domain.Instrument
+	events       domain.EventPublisher // optional
 }
 
 func NewUseCase(
@@ -46,31 +52,56 @@ func NewUseCase(
 	positionRepo domain.PositionRepository,
 	accountRepo domain.AccountRepository,
 	tradeRepo domain.TradeRepository,
+	ledgerRepo domain.LedgerRepository,
+	walletRepo domain.WalletRepository,
+	spotLotRepo domain.SpotLotRepository,
+	compRepo domain.CompetitionRepository,
 	priceCache domain.PriceCache,
 	eng *engine.Engine,
-	supportedSymbols []string,
+	instruments []domain.Instrument,
+	events domain.EventPublisher,
 ) *UseCase {
-	symbols := make(map[string]bool)
-	for _, s := range supportedSymbols {
-		symbols[s] = true
+	bySymbol := make(map[string]domain.Instrument)
+	for _, i := range instruments {
+		bySymbol[i.Symbol] = i
 	}
 	return &UseCase{
 		orderRepo:    orderRepo,
 		positionRepo: positionRepo,
 		accountRepo:  accountRepo,
 		tradeRepo:    tradeRepo,
+		ledgerRepo:   ledgerRepo,
+		walletRepo:   walletRepo,
+		spotLotRepo:  spotLotRepo,
+		compRepo:     compRepo,
 		priceCache:   priceCache,
 		engine:       eng,
-		symbols:      symbols,
+		instruments:  bySymbol,
+		events:       events,
 	}
 }
 
 func (uc *UseCase) PlaceOrder(ctx context.Context, input PlaceOrderInput) (*PlaceOrderOutput, error) {
+	instrument, ok := uc.instruments[input.Symbol]
+	if !ok {
+		return nil, domain.ErrSymbolNotSupported
+	}
+
+	// Spot orders are unleveraged
+	if instrument.IsSpot() && input.Leverage == 0 {
+		input.Leverage = 1
+	}
+
 	// Validate input
-	if err := uc.validateInput(input); err != nil {
+	if err := uc.validateInput(input, instrument); err != nil {
 		return nil, err
 	}
 
+	// The instrument's market decides how the order settles
+	if instrument.IsSpot() {
+		return uc.placeSpotOrder(ctx, input, instrument)
+	}
+
 	// Get current price
 	price, ok := uc.priceCache.Get(input.Symbol)
 	if !ok {
@@ -78,13 +109,16 @@ func (uc *UseCase) PlaceOrder(ctx context.Context, input PlaceOrderInput) (*Plac
 	}
 
 	// Get account
-	account, err := uc.accountRepo.GetByUserID(ctx, input.UserID)
+	account, err := uc.accountRepo.GetByID(ctx, input.AccountID)
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.checkAccount(ctx, account, input); err != nil {
+		return nil, err
+	}
 
 	// Get existing position for this symbol
-	existingPosition, err := uc.positionRepo.GetOpenByUserIDAndSymbol(ctx, input.UserID, input.Symbol)
+	existingPosition, err := uc.positionRepo.GetOpenByAccountIDAndSymbol(ctx, account.ID, input.Symbol)
 	if err != nil && !errors.Is(err, domain.ErrPositionNotFound) {
 		return nil, err
 	}
@@ -97,26 +131,15 @@ func (uc *UseCase) PlaceOrder(ctx context.Context, input PlaceOrderInput) (*Plac
 		executionPrice = input.Price
 	}
 
-	// Calculate required margin
-	requiredMargin := uc.engine.MarginCalc.CalculateRequiredMargin(input.Quantity, executionPrice, input.Leverage)
-
-	// Get open positions for margin calculation
-	openPositions, err := uc.positionRepo.GetOpenByUserID(ctx, input.UserID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Calculate available margin
-	summary := account.CalculateSummary(openPositions)
-
 	// Check if we have enough margin
-	if summary.AvailableMargin.LessThan(requiredMargin) {
-		return nil, domain.ErrInsufficientMargin
+	if err := uc.checkMargin(ctx, account, input.Quantity, executionPrice, input.Leverage); err != nil {
+		return nil, err
 	}
 
 	// Create order
 	order := &domain.Order{
-		UserID:     input.UserID,
+		UserID:     account.UserID,
+		AccountID:  account.ID,
 		Symbol:     input.Symbol,
 		Side:       input.Side,
 		Type:       input.Type,
@@ -126,6 +149,7 @@ func (uc *UseCase) PlaceOrder(ctx context.Context, input PlaceOrderInput) (*Plac
 		Leverage:   input.Leverage,
 		StopLoss:   input.StopLoss,
 		TakeProfit: input.TakeProfit,
+		ParentID:   input.ParentID,
 	}
 
 	if err := uc.orderRepo.Create(ctx, order); err != nil {
@@ -136,13 +160,75 @@ func (uc *UseCase) PlaceOrder(ctx context.Context, input PlaceOrderInput) (*Plac
 
 	// For market orders, execute immediately
 	if input.Type == domain.OrderTypeMarket {
-		return uc.executeOrder(ctx, order, existingPosition, executionPrice, account)
+		output, err := uc.executeOrder(ctx, order, existingPosition, executionPrice, account)
+		if err != nil {
+			return nil, err
+		}
+		uc.publishFill(ctx, output)
+		return output, nil
 	}
 
 	// Limit order stays pending
 	return &PlaceOrderOutput{Order: order}, nil
 }
 
+// checkAccount rejects orders on locked accounts and applies the rules of the
+// competition a competition account belongs to: its trading window, allowed
+// symbols and maximum leverage
+func (uc *UseCase) checkAccount(ctx context.Context, account *domain.Account, input PlaceOrderInput) error {
+	if account.IsLocked() {
+		return domain.ErrAccountLocked
+	}
+	if !account.IsCompetition() {
+		return nil
+	}
+	competition, err := uc.compRepo.GetByID(ctx, *account.CompetitionID)
+	if err != nil {
+		return err
+	}
+	return competition.CheckOrder(input.Symbol, input.Leverage, time.Now())
+}
+
+// checkMargin rejects an order whose margin at price exceeds the account's
+// available margin
+func (uc *UseCase) checkMargin(ctx context.Context, account *domain.Account, quantity, price decimal.Decimal, leverage int) error {
+	requiredMargin := uc.engine.MarginCalc.CalculateRequiredMargin(quantity, price, leverage)
+
+	// Get open positions for margin calculation
+	openPositions, err := uc.positionRepo.GetOpenByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	// Wallet assets count as collateral after haircuts
+	balances, err := uc.walletRepo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	summary := account.CalculateSummary(openPositions, uc.engine.CollateralCalc.Value(balances, uc.priceCache))
+	if summary.AvailableMargin.LessThan(requiredMargin) {
+		return domain.ErrInsufficientMargin
+	}
+	return nil
+}
+
+// publishFill reports a filled order to event subscribers
+func (uc *UseCase) publishFill(ctx context.Context, output *PlaceOrderOutput) {
+	if uc.events == nil || output.Trade == nil {
+		return
+	}
+	uc.events.Publish(ctx, domain.AccountEvent{
+		Type:      domain.EventTypeFill,
+		UserID:    output.Order.UserID,
+		AccountID: output.Order.AccountID,
+		Trade:     output.Trade,
+		Position:  output.Position,
+		Order:     output.Order,
+		Timestamp: time.Now(),
+	})
+}
+
 func (uc *UseCase) executeOrder(
 	ctx context.Context,
 	order *domain.Order,
@@ -150,6 +236,8 @@ func (uc *UseCase) executeOrder(
 	executionPrice decimal.Decimal,
 	account *domain.Account,
 ) (*PlaceOrderOutput, error) {
+	// Claim the order before touching positions: a racing cancel or fill
+	// leaves it no longer pending and this one fails
 	now := time.Now()
 	order.Status = domain.OrderStatusFilled
 	order.FilledAt = &now
@@ -189,6 +277,7 @@ func (uc *UseCase) openPosition(
 		order.StopLoss,
 		order.TakeProfit,
 	)
+	position.AccountID = order.AccountID
 
 	if err := uc.positionRepo.Create(ctx, position); err != nil {
 		return nil, err
@@ -196,6 +285,7 @@ func (uc *UseCase) openPosition(
 
 	trade := &domain.Trade{
 		UserID:     order.UserID,
+		AccountID:  order.AccountID,
 		PositionID: position.ID,
 		OrderID:    order.ID,
 		Symbol:     order.Symbol,
@@ -210,6 +300,9 @@ func (uc *UseCase) openPosition(
 	if err := uc.tradeRepo.Create(ctx, trade); err != nil {
 		return nil, err
 	}
+	if err := uc.settle(ctx, trade); err != nil {
+		return nil, err
+	}
 
 	metrics.RecordOrderFilled(order.Symbol, string(order.Side))
 	metrics.RecordPositionOpened(order.Symbol, string(position.Side))
@@ -245,6 +338,7 @@ func (uc *UseCase) addToPosition(
 
 	trade := &domain.Trade{
 		UserID:     order.UserID,
+		AccountID:  order.AccountID,
 		PositionID: position.ID,
 		OrderID:    order.ID,
 		Symbol:     order.Symbol,
@@ -259,6 +353,9 @@ func (uc *UseCase) addToPosition(
 	if err := uc.tradeRepo.Create(ctx, trade); err != nil {
 		return nil, err
 	}
+	if err := uc.settle(ctx, trade); err != nil {
+		return nil, err
+	}
 
 	metrics.RecordOrderFilled(order.Symbol, string(order.Side))
 
@@ -317,13 +414,9 @@ func (uc *UseCase) reducePosition(
 		return nil, err
 	}
 
-	// Credit only PnL to account (margin is virtual — never deducted on open)
-	if err := uc.accountRepo.UpdateBalance(ctx, account.ID, pnl); err != nil {
-		return nil, err
-	}
-
 	trade := &domain.Trade{
 		UserID:     order.UserID,
+		AccountID:  order.AccountID,
 		PositionID: position.ID,
 		OrderID:    order.ID,
 		Symbol:     order.Symbol,
@@ -339,6 +432,11 @@ func (uc *UseCase) reducePosition(
 		return nil, err
 	}
 
+	// Credit only PnL and fee to account (margin is virtual — never deducted on open)
+	if err := uc.settle(ctx, trade); err != nil {
+		return nil, err
+	}
+
 	metrics.RecordOrderFilled(order.Symbol, string(order.Side))
 	if position.Status == domain.PositionStatusClosed {
 		metrics.RecordPositionClosed(order.Symbol, string(position.Side), "user")
@@ -358,11 +456,7 @@ func (uc *UseCase) reducePosition(
 	}, nil
 }
 
-func (uc *UseCase) validateInput(input PlaceOrderInput) error {
-	if !uc.symbols[input.Symbol] {
-		return domain.ErrSymbolNotSupported
-	}
-
+func (uc *UseCase) validateInput(input PlaceOrderInput, instrument domain.Instrument) error {
 	if input.Side != domain.OrderSideBuy && input.Side != domain.OrderSideSell {
 		return domain.ErrInvalidOrderSide
 	}
@@ -383,5 +477,27 @@ func (uc *UseCase) validateInput(input PlaceOrderInput) error {
 		return domain.ErrInvalidPrice
 	}
 
+	if instrument.IsSpot() {
+		if input.Leverage != 1 {
+			return domain.ErrInvalidLeverage
+		}
+		if input.StopLoss != nil || input.TakeProfit != nil {
+			return domain.ErrSpotStopsNotAllowed
+		}
+		// Resting spot orders would need a balance hold, so spot trades at market only
+		if input.Type != domain.OrderTypeMarket {
+			return domain.ErrSpotLimitNotAllowed
+		}
+	}
+
 	return nil
 }
+
+// settle posts a perpetual trade's realized PnL and fee to the account ledger
+func (uc *UseCase) settle(ctx context.Context, trade *domain.Trade) error {
+	entries := domain.TradeLedgerEntries(trade.AccountID, domain.LedgerEntryTypeRealizedPnL, trade)
+	if len(entries) == 0 {
+		return nil
+	}
+	return uc.ledgerRepo.PostBatch(ctx, entries...)
+}
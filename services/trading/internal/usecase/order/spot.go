@@ -0,0 +1,226 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	"trading/internal/metrics"
+)
+
+// placeSpotOrder exchanges USDT for the base asset (BUY) or back (SELL) at market.
+// Spot orders have no leverage, position or liquidation: they settle
+// directly in wallet balances.
+func (uc *UseCase) placeSpotOrder(ctx context.Context, input PlaceOrderInput, instrument domain.Instrument) (*PlaceOrderOutput, error) {
+	price, ok := uc.priceCache.Get(instrument.PriceSymbol)
+	if !ok {
+		return nil, domain.ErrPriceNotAvailable
+	}
+
+	account, err := uc.accountRepo.GetByID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkAccount(ctx, account, input); err != nil {
+		return nil, err
+	}
+
+	executionPrice := uc.engine.GetExecutionPrice(price, input.Side)
+
+	if err := uc.checkSpotFunds(ctx, account, instrument, input.Side, input.Quantity, executionPrice); err != nil {
+		return nil, err
+	}
+
+	order := &domain.Order{
+		UserID:    account.UserID,
+		AccountID: account.ID,
+		Symbol:    input.Symbol,
+		Side:      input.Side,
+		Type:      input.Type,
+		Status:    domain.OrderStatusPending,
+		Quantity:  input.Quantity,
+		Price:     executionPrice,
+		Leverage:  1,
+		ParentID:  input.ParentID,
+	}
+
+	if err := uc.orderRepo.Create(ctx, order); err != nil {
+		return nil, err
+	}
+
+	metrics.RecordOrderPlaced(input.Symbol, string(input.Side), string(input.Type))
+
+	output, err := uc.executeSpotOrder(ctx, order, instrument, executionPrice)
+	if err != nil {
+		return nil, err
+	}
+	uc.publishFill(ctx, output)
+	return output, nil
+}
+
+// checkSpotFunds verifies the account holds what the order spends. A buy also
+// may not push available margin below zero, since the bought asset counts as
+// collateral only after its haircut.
+func (uc *UseCase) checkSpotFunds(
+	ctx context.Context,
+	account *domain.Account,
+	instrument domain.Instrument,
+	side domain.OrderSide,
+	quantity, executionPrice decimal.Decimal,
+) error {
+	balances, err := uc.walletRepo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	if side == domain.OrderSideSell {
+		held := decimal.Zero
+		for _, b := range balances {
+			if b.Asset == instrument.BaseAsset {
+				held = b.Amount
+			}
+		}
+		if held.LessThan(quantity) {
+			return domain.ErrInsufficientBalance
+		}
+		return nil
+	}
+
+	cost := quantity.Mul(executionPrice)
+	if account.Balance.LessThan(cost) {
+		return domain.ErrInsufficientBalance
+	}
+
+	openPositions, err := uc.positionRepo.GetOpenByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+	summary := account.CalculateSummary(openPositions, uc.engine.CollateralCalc.Value(balances, uc.priceCache))
+
+	bought := uc.engine.CollateralCalc.Value([]domain.AssetBalance{{Asset: instrument.BaseAsset, Amount: quantity}}, uc.priceCache)
+	if summary.AvailableMargin.LessThan(cost.Sub(bought[0].Value)) {
+		return domain.ErrInsufficientMargin
+	}
+	return nil
+}
+
+func (uc *UseCase) executeSpotOrder(
+	ctx context.Context,
+	order *domain.Order,
+	instrument domain.Instrument,
+	executionPrice decimal.Decimal,
+) (*PlaceOrderOutput, error) {
+	now := time.Now()
+	order.Status = domain.OrderStatusFilled
+	order.FilledAt = &now
+
+	trade := &domain.Trade{
+		UserID:    order.UserID,
+		AccountID: order.AccountID,
+		OrderID:   order.ID,
+		Symbol:    order.Symbol,
+		Side:      domain.PositionSideLong,
+		Quantity:  order.Quantity,
+		Price:     executionPrice,
+		PnL:       decimal.Zero,
+		Fee:       decimal.Zero,
+	}
+	fill := &domain.SpotFill{Order: order, Trade: trade}
+
+	// Sells realize PnL against the oldest lots first
+	quoteAmount := order.Quantity.Mul(executionPrice).Round(8)
+	baseAmount := order.Quantity
+	if order.IsBuy() {
+		trade.Type = domain.TradeTypeSpotBuy
+		quoteAmount = quoteAmount.Neg()
+		fill.Opened = &domain.SpotLot{
+			AccountID: order.AccountID,
+			Asset:     instrument.BaseAsset,
+			Quantity:  order.Quantity,
+			Remaining: order.Quantity,
+			Price:     executionPrice,
+		}
+	} else {
+		trade.Type = domain.TradeTypeSpotSell
+		baseAmount = baseAmount.Neg()
+
+		lots, err := uc.spotLotRepo.GetOpenByAccountIDAndAsset(ctx, order.AccountID, instrument.BaseAsset)
+		if err != nil {
+			return nil, err
+		}
+		remaining := order.Quantity
+		for i := range lots {
+			if !remaining.IsPositive() {
+				lots = lots[:i]
+				break
+			}
+			consumed, pnl := lots[i].Consume(remaining, executionPrice)
+			remaining = remaining.Sub(consumed)
+			trade.PnL = trade.PnL.Add(pnl)
+		}
+		fill.Consumed = lots
+		// Quantity not covered by lots (e.g., acquired by conversion) has no cost basis and realizes no PnL
+	}
+
+	description := fmt.Sprintf("spot %s %s %s @ %s", order.Side, order.Quantity, instrument.BaseAsset, executionPrice)
+	fill.Entries = []*domain.LedgerEntry{
+		{
+			AccountID:   order.AccountID,
+			Type:        domain.LedgerEntryTypeSpotTrade,
+			Asset:       instrument.QuoteAsset,
+			Amount:      quoteAmount,
+			Description: description,
+		},
+		{
+			AccountID:   order.AccountID,
+			Type:        domain.LedgerEntryTypeSpotTrade,
+			Asset:       instrument.BaseAsset,
+			Amount:      baseAmount,
+			Description: description,
+		},
+	}
+	if !trade.Fee.IsZero() {
+		fee := domain.NewFeeLedgerEntry(order.AccountID, trade)
+		fee.Asset = instrument.QuoteAsset
+		fill.Entries = append(fill.Entries, fee)
+	}
+
+	// The order, trade, legs and lots are written together: a leg the balance
+	// no longer covers records nothing, and the order is rejected instead
+	if err := uc.spotLotRepo.Settle(ctx, fill); err != nil {
+		if errors.Is(err, domain.ErrInsufficientBalance) {
+			order.Status = domain.OrderStatusRejected
+			order.FilledAt = nil
+			if err := uc.orderRepo.Update(ctx, order); err != nil {
+				logger.Error("failed to reject order", "order_id", order.ID, "error", err)
+			}
+		}
+		return nil, err
+	}
+
+	metrics.RecordOrderFilled(order.Symbol, string(order.Side))
+
+	logger.Info("spot order filled",
+		"order_id", order.ID,
+		"symbol", order.Symbol,
+		"side", order.Side,
+		"quantity", order.Quantity,
+		"price", executionPrice,
+		"pnl", trade.PnL,
+	)
+
+	return &PlaceOrderOutput{
+		Order: order,
+		Trade: trade,
+	}, nil
+}
+
+// GetSpotLots returns the account's open cost basis lots
+func (uc *UseCase) GetSpotLots(ctx context.Context, accountID domain.AccountID) ([]domain.SpotLot, error) {
+	return uc.spotLotRepo.GetOpenByAccountID(ctx, accountID)
+}
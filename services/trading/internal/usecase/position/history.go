@@ -0,0 +1,92 @@
+package position
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+// HistoryEntry is a closed or liquidated position with its trades and results
+type HistoryEntry struct {
+	Position    domain.Position
+	Trades      []domain.Trade  // oldest first
+	RealizedPnL decimal.Decimal // sum of closing trades, before fees
+	Fees        decimal.Decimal
+	Margin      decimal.Decimal // margin of all opening trades at the position leverage
+	ROE         decimal.Decimal // realized PnL / margin, percent
+	HoldingTime time.Duration
+}
+
+// History is a page of closed positions, latest close first
+type History struct {
+	Entries    []HistoryEntry
+	NextCursor *domain.Cursor // nil on the last page
+}
+
+// GetHistory returns a page of closed and liquidated positions enriched with their trades
+func (uc *UseCase) GetHistory(ctx context.Context, accountID domain.AccountID, filter domain.PositionHistoryFilter) (*History, error) {
+	if filter.Side != "" && filter.Side != domain.PositionSideLong && filter.Side != domain.PositionSideShort {
+		return nil, domain.ErrInvalidPositionFilter
+	}
+	if filter.Status != "" && filter.Status != domain.PositionStatusClosed && filter.Status != domain.PositionStatusLiquidated {
+		return nil, domain.ErrInvalidPositionFilter
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	// One extra row tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit = limit + 1
+	positions, err := uc.positionRepo.GetHistory(ctx, accountID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &History{Entries: make([]HistoryEntry, 0, len(positions))}
+	if len(positions) > limit {
+		positions = positions[:limit]
+		last := positions[len(positions)-1]
+		history.NextCursor = &domain.Cursor{Time: *last.ClosedAt, ID: int64(last.ID)}
+	}
+
+	for _, p := range positions {
+		trades, err := uc.tradeRepo.GetByPositionID(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		history.Entries = append(history.Entries, uc.historyEntry(p, trades))
+	}
+	return history, nil
+}
+
+func (uc *UseCase) historyEntry(p domain.Position, trades []domain.Trade) HistoryEntry {
+	entry := HistoryEntry{
+		Position:    p,
+		Trades:      trades,
+		RealizedPnL: decimal.Zero,
+		Fees:        decimal.Zero,
+		Margin:      decimal.Zero,
+	}
+	if p.ClosedAt != nil {
+		entry.HoldingTime = p.ClosedAt.Sub(p.CreatedAt)
+	}
+
+	leverage := decimal.NewFromInt(int64(p.Leverage))
+	for _, t := range trades {
+		entry.Fees = entry.Fees.Add(t.Fee)
+		switch t.Type {
+		case domain.TradeTypeOpen, domain.TradeTypeAdd:
+			if leverage.IsPositive() {
+				entry.Margin = entry.Margin.Add(t.Quantity.Mul(t.Price).Div(leverage))
+			}
+		case domain.TradeTypeClose, domain.TradeTypeLiquidate:
+			entry.RealizedPnL = entry.RealizedPnL.Add(t.PnL)
+		}
+	}
+	entry.ROE = uc.engine.PnLCalc.CalculateROE(entry.RealizedPnL, entry.Margin)
+	return entry
+}
@@ -17,8 +17,10 @@ type UseCase struct {
 	accountRepo  domain.AccountRepository
 	tradeRepo    domain.TradeRepository
 	orderRepo    domain.OrderRepository
+	ledgerRepo   domain.LedgerRepository
 	priceCache   domain.PriceCache
 	engine       *engine.Engine
+	events       domain.EventPublisher // optional
 }
 
 func NewUseCase(
@@ -26,30 +28,34 @@ func NewUseCase(
 	accountRepo domain.AccountRepository,
 	tradeRepo domain.TradeRepository,
 	orderRepo domain.OrderRepository,
+	ledgerRepo domain.LedgerRepository,
 	priceCache domain.PriceCache,
 	eng *engine.Engine,
+	events domain.EventPublisher,
 ) *UseCase {
 	return &UseCase{
 		positionRepo: positionRepo,
 		accountRepo:  accountRepo,
 		tradeRepo:    tradeRepo,
 		orderRepo:    orderRepo,
+		ledgerRepo:   ledgerRepo,
 		priceCache:   priceCache,
 		engine:       eng,
+		events:       events,
 	}
 }
 
-func (uc *UseCase) GetPositions(ctx context.Context, userID domain.UserID) ([]domain.Position, error) {
-	return uc.positionRepo.GetOpenByUserID(ctx, userID)
+func (uc *UseCase) GetPositions(ctx context.Context, accountID domain.AccountID) ([]domain.Position, error) {
+	return uc.positionRepo.GetOpenByAccountID(ctx, accountID)
 }
 
-func (uc *UseCase) GetPosition(ctx context.Context, userID domain.UserID, positionID domain.PositionID) (*domain.Position, error) {
+func (uc *UseCase) GetPosition(ctx context.Context, accountID domain.AccountID, positionID domain.PositionID) (*domain.Position, error) {
 	position, err := uc.positionRepo.GetByID(ctx, positionID)
 	if err != nil {
 		return nil, err
 	}
 
-	if position.UserID != userID {
+	if position.AccountID != accountID {
 		return nil, domain.ErrPositionNotFound
 	}
 
@@ -57,7 +63,7 @@ func (uc *UseCase) GetPosition(ctx context.Context, userID domain.UserID, positi
 }
 
 type ClosePositionInput struct {
-	UserID     domain.UserID
+	AccountID  domain.AccountID
 	PositionID domain.PositionID
 	Quantity   *decimal.Decimal // nil = full close
 }
@@ -68,7 +74,7 @@ func (uc *UseCase) ClosePosition(ctx context.Context, input ClosePositionInput)
 		return nil, err
 	}
 
-	if position.UserID != input.UserID {
+	if position.AccountID != input.AccountID {
 		return nil, domain.ErrPositionNotFound
 	}
 
@@ -82,20 +88,76 @@ func (uc *UseCase) ClosePosition(ctx context.Context, input ClosePositionInput)
 		return nil, domain.ErrPriceNotAvailable
 	}
 
-	// Determine close price (opposite side execution)
-	var closePrice decimal.Decimal
-	if position.IsLong() {
-		closePrice = decimal.NewFromFloat(price.Bid) // sell at bid
-	} else {
-		closePrice = decimal.NewFromFloat(price.Ask) // buy at ask
-	}
+	closePrice := marketClosePrice(position, price)
 
+	var trade *domain.Trade
 	// Partial close if quantity specified and less than position size
 	if input.Quantity != nil && input.Quantity.IsPositive() && input.Quantity.LessThan(position.Quantity) {
-		return uc.partialCloseAtPrice(ctx, position, closePrice, *input.Quantity, "user")
+		trade, err = uc.partialCloseAtPrice(ctx, position, closePrice, *input.Quantity, "user")
+	} else {
+		trade, err = uc.closePositionAtPrice(ctx, position, closePrice, "user")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publishFill(ctx, position, trade)
+	return trade, nil
+}
+
+// publishFill reports a user initiated close to event subscribers
+func (uc *UseCase) publishFill(ctx context.Context, position *domain.Position, trade *domain.Trade) {
+	if uc.events == nil {
+		return
+	}
+	uc.events.Publish(ctx, domain.AccountEvent{
+		Type:      domain.EventTypeFill,
+		UserID:    position.UserID,
+		AccountID: position.AccountID,
+		Trade:     trade,
+		Position:  position,
+		Timestamp: time.Now(),
+	})
+}
+
+// CloseAllPositions closes every open position of the account at current prices.
+// Prices are checked up front, so a missing price closes nothing. Positions are closed
+// one by one: on a later failure the ones already closed stay closed and a retry closes the rest.
+func (uc *UseCase) CloseAllPositions(ctx context.Context, accountID domain.AccountID, reason string) ([]domain.Trade, error) {
+	positions, err := uc.positionRepo.GetOpenByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]*domain.Price, len(positions))
+	for _, p := range positions {
+		price, ok := uc.priceCache.Get(p.Symbol)
+		if !ok {
+			return nil, domain.ErrPriceNotAvailable
+		}
+		prices[p.Symbol] = price
+	}
+
+	trades := make([]domain.Trade, 0, len(positions))
+	for i := range positions {
+		position := &positions[i]
+		trade, err := uc.closePositionAtPrice(ctx, position, marketClosePrice(position, prices[position.Symbol]), reason)
+		if err != nil {
+			return trades, err
+		}
+		uc.publishFill(ctx, position, trade)
+		trades = append(trades, *trade)
 	}
 
-	return uc.closePositionAtPrice(ctx, position, closePrice, "user")
+	return trades, nil
+}
+
+// marketClosePrice returns the execution price for closing at market (opposite side)
+func marketClosePrice(position *domain.Position, price *domain.Price) decimal.Decimal {
+	if position.IsLong() {
+		return decimal.NewFromFloat(price.Bid) // sell at bid
+	}
+	return decimal.NewFromFloat(price.Ask) // buy at ask
 }
 
 func (uc *UseCase) closePositionAtPrice(
@@ -108,7 +170,7 @@ func (uc *UseCase) closePositionAtPrice(
 	pnl := uc.engine.ClosePosition(position, closePrice)
 
 	// Get account
-	account, err := uc.accountRepo.GetByUserID(ctx, position.UserID)
+	account, err := uc.accountRepo.GetByID(ctx, position.AccountID)
 	if err != nil {
 		return nil, err
 	}
@@ -123,11 +185,6 @@ func (uc *UseCase) closePositionAtPrice(
 		return nil, err
 	}
 
-	// Credit only PnL to account (margin is virtual — never deducted on open)
-	if err := uc.accountRepo.UpdateBalance(ctx, account.ID, pnl); err != nil {
-		return nil, err
-	}
-
 	// Create a virtual order for the close
 	closeSide := domain.OrderSideSell
 	if position.IsShort() {
@@ -135,15 +192,16 @@ func (uc *UseCase) closePositionAtPrice(
 	}
 
 	order := &domain.Order{
-		UserID:   position.UserID,
-		Symbol:   position.Symbol,
-		Side:     closeSide,
-		Type:     domain.OrderTypeMarket,
-		Status:   domain.OrderStatusFilled,
-		Quantity: position.Quantity,
-		Price:    closePrice,
-		Leverage: position.Leverage,
-		FilledAt: &now,
+		UserID:    position.UserID,
+		AccountID: position.AccountID,
+		Symbol:    position.Symbol,
+		Side:      closeSide,
+		Type:      domain.OrderTypeMarket,
+		Status:    domain.OrderStatusFilled,
+		Quantity:  position.Quantity,
+		Price:     closePrice,
+		Leverage:  position.Leverage,
+		FilledAt:  &now,
 	}
 
 	if err := uc.orderRepo.Create(ctx, order); err != nil {
@@ -153,6 +211,7 @@ func (uc *UseCase) closePositionAtPrice(
 	// Create trade record
 	trade := &domain.Trade{
 		UserID:     position.UserID,
+		AccountID:  position.AccountID,
 		PositionID: position.ID,
 		OrderID:    order.ID,
 		Symbol:     position.Symbol,
@@ -168,6 +227,11 @@ func (uc *UseCase) closePositionAtPrice(
 		return nil, err
 	}
 
+	// Credit only PnL to account (margin is virtual — never deducted on open)
+	if err := uc.settlePnL(ctx, account.ID, domain.LedgerEntryTypeRealizedPnL, trade); err != nil {
+		return nil, err
+	}
+
 	metrics.RecordPositionClosed(position.Symbol, string(position.Side), reason)
 
 	logger.Info("position closed",
@@ -194,7 +258,7 @@ func (uc *UseCase) partialCloseAtPrice(
 	pnl := fullPnL.Mul(proportion)
 
 	// Get account
-	account, err := uc.accountRepo.GetByUserID(ctx, position.UserID)
+	account, err := uc.accountRepo.GetByID(ctx, position.AccountID)
 	if err != nil {
 		return nil, err
 	}
@@ -213,11 +277,6 @@ func (uc *UseCase) partialCloseAtPrice(
 		return nil, err
 	}
 
-	// Credit only PnL to account (margin is virtual)
-	if err := uc.accountRepo.UpdateBalance(ctx, account.ID, pnl); err != nil {
-		return nil, err
-	}
-
 	// Create a virtual order for the partial close
 	closeSide := domain.OrderSideSell
 	if position.IsShort() {
@@ -226,15 +285,16 @@ func (uc *UseCase) partialCloseAtPrice(
 
 	now := time.Now()
 	order := &domain.Order{
-		UserID:   position.UserID,
-		Symbol:   position.Symbol,
-		Side:     closeSide,
-		Type:     domain.OrderTypeMarket,
-		Status:   domain.OrderStatusFilled,
-		Quantity: quantity,
-		Price:    closePrice,
-		Leverage: position.Leverage,
-		FilledAt: &now,
+		UserID:    position.UserID,
+		AccountID: position.AccountID,
+		Symbol:    position.Symbol,
+		Side:      closeSide,
+		Type:      domain.OrderTypeMarket,
+		Status:    domain.OrderStatusFilled,
+		Quantity:  quantity,
+		Price:     closePrice,
+		Leverage:  position.Leverage,
+		FilledAt:  &now,
 	}
 
 	if err := uc.orderRepo.Create(ctx, order); err != nil {
@@ -244,6 +304,7 @@ func (uc *UseCase) partialCloseAtPrice(
 	// Create trade record
 	trade := &domain.Trade{
 		UserID:     position.UserID,
+		AccountID:  position.AccountID,
 		PositionID: position.ID,
 		OrderID:    order.ID,
 		Symbol:     position.Symbol,
@@ -259,6 +320,11 @@ func (uc *UseCase) partialCloseAtPrice(
 		return nil, err
 	}
 
+	// Credit only PnL to account (margin is virtual)
+	if err := uc.settlePnL(ctx, account.ID, domain.LedgerEntryTypeRealizedPnL, trade); err != nil {
+		return nil, err
+	}
+
 	logger.Info("position partially closed",
 		"position_id", position.ID,
 		"symbol", position.Symbol,
@@ -273,7 +339,7 @@ func (uc *UseCase) partialCloseAtPrice(
 }
 
 type UpdateTPSLInput struct {
-	UserID         domain.UserID
+	AccountID      domain.AccountID
 	PositionID     domain.PositionID
 	StopLoss       *decimal.Decimal
 	TakeProfit     *decimal.Decimal
@@ -287,7 +353,7 @@ func (uc *UseCase) UpdateTPSL(ctx context.Context, input UpdateTPSLInput) (*doma
 		return nil, err
 	}
 
-	if position.UserID != input.UserID {
+	if position.AccountID != input.AccountID {
 		return nil, domain.ErrPositionNotFound
 	}
 
@@ -346,7 +412,7 @@ func (uc *UseCase) Liquidate(ctx context.Context, position *domain.Position, liq
 		return nil, domain.ErrPositionNotOpen
 	}
 
-	account, err := uc.accountRepo.GetByUserID(ctx, position.UserID)
+	account, err := uc.accountRepo.GetByID(ctx, position.AccountID)
 	if err != nil {
 		return nil, err
 	}
@@ -375,15 +441,16 @@ func (uc *UseCase) Liquidate(ctx context.Context, position *domain.Position, liq
 	}
 
 	order := &domain.Order{
-		UserID:   position.UserID,
-		Symbol:   position.Symbol,
-		Side:     closeSide,
-		Type:     domain.OrderTypeMarket,
-		Status:   domain.OrderStatusFilled,
-		Quantity: position.Quantity,
-		Price:    liquidationPrice,
-		Leverage: position.Leverage,
-		FilledAt: &now,
+		UserID:    position.UserID,
+		AccountID: position.AccountID,
+		Symbol:    position.Symbol,
+		Side:      closeSide,
+		Type:      domain.OrderTypeMarket,
+		Status:    domain.OrderStatusFilled,
+		Quantity:  position.Quantity,
+		Price:     liquidationPrice,
+		Leverage:  position.Leverage,
+		FilledAt:  &now,
 	}
 
 	if err := uc.orderRepo.Create(ctx, order); err != nil {
@@ -392,6 +459,7 @@ func (uc *UseCase) Liquidate(ctx context.Context, position *domain.Position, liq
 
 	trade := &domain.Trade{
 		UserID:     position.UserID,
+		AccountID:  position.AccountID,
 		PositionID: position.ID,
 		OrderID:    order.ID,
 		Symbol:     position.Symbol,
@@ -411,7 +479,7 @@ func (uc *UseCase) Liquidate(ctx context.Context, position *domain.Position, liq
 	metrics.RecordPositionClosed(position.Symbol, string(position.Side), "liquidation")
 
 	// Deduct margin from account
-	if err := uc.accountRepo.UpdateBalance(ctx, account.ID, pnl); err != nil {
+	if err := uc.settlePnL(ctx, account.ID, domain.LedgerEntryTypeLiquidation, trade); err != nil {
 		logger.Error("failed to deduct liquidation loss", "error", err)
 	}
 
@@ -426,6 +494,15 @@ func (uc *UseCase) Liquidate(ctx context.Context, position *domain.Position, liq
 	return trade, nil
 }
 
+// settlePnL posts a trade's realized PnL and fee to the account ledger
+func (uc *UseCase) settlePnL(ctx context.Context, accountID domain.AccountID, entryType domain.LedgerEntryType, trade *domain.Trade) error {
+	entries := domain.TradeLedgerEntries(accountID, entryType, trade)
+	if len(entries) == 0 {
+		return nil
+	}
+	return uc.ledgerRepo.PostBatch(ctx, entries...)
+}
+
 // TriggerStopLoss closes position at stop loss price (fully or partially based on SLClosePercent)
 func (uc *UseCase) TriggerStopLoss(ctx context.Context, position *domain.Position) (*domain.Trade, error) {
 	if position.StopLoss == nil {
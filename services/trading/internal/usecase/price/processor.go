@@ -13,9 +13,19 @@ import (
 	"trading/internal/kafka"
 	"trading/internal/logger"
 	"trading/internal/metrics"
+	alertuc "trading/internal/usecase/alert"
+	challengeuc "trading/internal/usecase/challenge"
+	orderuc "trading/internal/usecase/order"
 	positionuc "trading/internal/usecase/position"
 )
 
+// PriceListener is called with every price after the processor is done with
+// it. Script strategies listen through it, since their backtests run on a
+// processor of their own.
+type PriceListener interface {
+	OnPrice(ctx context.Context, price *domain.Price)
+}
+
 type Processor struct {
 	positionRepo  domain.PositionRepository
 	priceCache    domain.PriceCache
@@ -23,12 +33,31 @@ type Processor struct {
 	tradeProducer *kafka.TradeProducer
 	positionUC    *positionuc.UseCase
 	wsHub         *ws.Hub
+	alertUC       *alertuc.UseCase
+	challengeUC   *challengeuc.UseCase
+	orderUC       *orderuc.UseCase
+	scripts       PriceListener
+	events        domain.EventPublisher
 
 	mu              sync.RWMutex
 	lastBroadcast   time.Time
 	broadcastPeriod time.Duration
+
+	// Last progress broadcast of each active challenge
+	challengeBroadcasts map[domain.ChallengeID]time.Time
+
+	// Positions already warned about, by symbol; rebuilt on every tick
+	warned map[string]map[domain.PositionID]bool
 }
 
+// challengeBroadcastPeriod throttles the progress stream of each challenge
+const challengeBroadcastPeriod = time.Second
+
+const (
+	marginWarningLevel = 0.8 // share of the way from entry to liquidation price
+	marginWarningReset = 0.7 // warn again only after recovering below this level
+)
+
 func NewProcessor(
 	positionRepo domain.PositionRepository,
 	priceCache domain.PriceCache,
@@ -36,6 +65,11 @@ func NewProcessor(
 	tradeProducer *kafka.TradeProducer,
 	positionUC *positionuc.UseCase,
 	wsHub *ws.Hub,
+	alertUC *alertuc.UseCase,
+	challengeUC *challengeuc.UseCase,
+	orderUC *orderuc.UseCase,
+	scripts PriceListener,
+	events domain.EventPublisher,
 ) *Processor {
 	return &Processor{
 		positionRepo:    positionRepo,
@@ -44,7 +78,15 @@ func NewProcessor(
 		tradeProducer:   tradeProducer,
 		positionUC:      positionUC,
 		wsHub:           wsHub,
+		alertUC:         alertUC,
+		challengeUC:     challengeUC,
+		orderUC:         orderUC,
+		scripts:         scripts,
+		events:          events,
 		broadcastPeriod: 100 * time.Millisecond, // Broadcast at most 10 times per second
+		warned:          make(map[string]map[domain.PositionID]bool),
+
+		challengeBroadcasts: make(map[domain.ChallengeID]time.Time),
 	}
 }
 
@@ -66,6 +108,34 @@ func (p *Processor) ProcessPrice(ctx context.Context, price *domain.Price) error
 		p.wsHub.BroadcastPrices(p.priceCache.GetAll())
 	}
 
+	if p.alertUC != nil {
+		p.processAlerts(ctx, price)
+	}
+
+	err := p.ProcessPositions(ctx, price)
+
+	// Resting limit orders fill after stops and liquidations have run on the price
+	if p.orderUC != nil {
+		p.orderUC.MatchLimitOrders(ctx, price)
+	}
+
+	// Scripts see the price once stops, liquidations and limit fills are done
+	if p.scripts != nil {
+		p.scripts.OnPrice(ctx, price)
+	}
+
+	// Challenge rules are checked after positions are marked to the new price
+	if p.challengeUC != nil {
+		p.processChallenges(ctx, price.Symbol)
+	}
+
+	return err
+}
+
+// ProcessPositions marks the open positions of the symbol to the price and fires
+// liquidations, stop losses and take profits. Backtests call it directly, without
+// the cache, alert and broadcast side effects of ProcessPrice.
+func (p *Processor) ProcessPositions(ctx context.Context, price *domain.Price) error {
 	// Get all open positions for this symbol
 	positions, err := p.positionRepo.GetOpenBySymbol(ctx, price.Symbol)
 	if err != nil {
@@ -81,9 +151,10 @@ func (p *Processor) ProcessPrice(ctx context.Context, price *domain.Price) error
 	markPrice := decimal.NewFromFloat(price.Mid())
 
 	// Process each position
+	warned := make(map[domain.PositionID]bool)
 	for i := range positions {
 		pos := &positions[i]
-		if err := p.processPosition(ctx, pos, markPrice); err != nil {
+		if err := p.processPosition(ctx, pos, markPrice, warned); err != nil {
 			logger.Error("failed to process position",
 				"position_id", pos.ID,
 				"error", err,
@@ -91,10 +162,62 @@ func (p *Processor) ProcessPrice(ctx context.Context, price *domain.Price) error
 		}
 	}
 
+	p.mu.Lock()
+	p.warned[price.Symbol] = warned
+	p.mu.Unlock()
+
 	return nil
 }
 
-func (p *Processor) processPosition(ctx context.Context, position *domain.Position, markPrice decimal.Decimal) error {
+func (p *Processor) processAlerts(ctx context.Context, price *domain.Price) {
+	notifications, err := p.alertUC.Evaluate(ctx, price)
+	if err != nil {
+		logger.Error("failed to evaluate alerts", "symbol", price.Symbol, "error", err)
+		return
+	}
+
+	// Deliver triggered alerts via WebSocket; they stay in the inbox either way
+	if p.wsHub != nil {
+		for i := range notifications {
+			p.wsHub.BroadcastAlert(notifications[i].UserID, &notifications[i])
+		}
+	}
+}
+
+func (p *Processor) processChallenges(ctx context.Context, symbol string) {
+	now := time.Now()
+	progress := p.challengeUC.EvaluateSymbol(ctx, symbol, now)
+
+	// Stream live progress against each rule, at most once per period for an
+	// active challenge; the final progress of an ended one always goes out
+	p.mu.Lock()
+	due := progress[:0]
+	for _, pr := range progress {
+		if pr.Status != domain.ChallengeStatusActive {
+			delete(p.challengeBroadcasts, pr.ChallengeID)
+			due = append(due, pr)
+			continue
+		}
+		if now.Sub(p.challengeBroadcasts[pr.ChallengeID]) >= challengeBroadcastPeriod {
+			p.challengeBroadcasts[pr.ChallengeID] = now
+			due = append(due, pr)
+		}
+	}
+	p.mu.Unlock()
+
+	if p.wsHub != nil {
+		for i := range due {
+			p.wsHub.BroadcastChallenge(due[i].UserID, &due[i])
+		}
+	}
+}
+
+func (p *Processor) processPosition(
+	ctx context.Context,
+	position *domain.Position,
+	markPrice decimal.Decimal,
+	warned map[domain.PositionID]bool,
+) error {
 	// Check triggers (liquidation, SL, TP)
 	triggers := p.engine.LiquidationCalc.CheckTriggers(position, markPrice)
 
@@ -121,9 +244,68 @@ func (p *Processor) processPosition(ctx context.Context, position *domain.Positi
 		p.wsHub.BroadcastPositionUpdate(position.UserID, position)
 	}
 
+	p.checkMarginWarning(ctx, position, markPrice, warned)
+
 	return nil
 }
 
+// checkMarginWarning publishes a margin warning once when the position gets
+// close to liquidation, and again only after it has recovered in between
+func (p *Processor) checkMarginWarning(
+	ctx context.Context,
+	position *domain.Position,
+	markPrice decimal.Decimal,
+	warned map[domain.PositionID]bool,
+) {
+	if p.events == nil {
+		return
+	}
+
+	p.mu.RLock()
+	wasWarned := p.warned[position.Symbol][position.ID]
+	p.mu.RUnlock()
+
+	progress := position.LiquidationProgress(markPrice)
+	if wasWarned {
+		if progress.GreaterThanOrEqual(decimal.NewFromFloat(marginWarningReset)) {
+			warned[position.ID] = true
+		}
+		return
+	}
+	if progress.LessThan(decimal.NewFromFloat(marginWarningLevel)) {
+		return
+	}
+
+	warned[position.ID] = true
+	logger.Warn("position close to liquidation",
+		"position_id", position.ID,
+		"mark_price", markPrice,
+		"liquidation_price", position.LiquidationPrice,
+	)
+	p.events.Publish(ctx, domain.AccountEvent{
+		Type:      domain.EventTypeMarginWarning,
+		UserID:    position.UserID,
+		AccountID: position.AccountID,
+		Position:  position,
+		Timestamp: time.Now(),
+	})
+}
+
+// publishClose reports a price triggered close to event subscribers
+func (p *Processor) publishClose(ctx context.Context, eventType domain.EventType, position *domain.Position, trade *domain.Trade) {
+	if p.events == nil || trade == nil {
+		return
+	}
+	p.events.Publish(ctx, domain.AccountEvent{
+		Type:      eventType,
+		UserID:    position.UserID,
+		AccountID: position.AccountID,
+		Trade:     trade,
+		Position:  position,
+		Timestamp: time.Now(),
+	})
+}
+
 func (p *Processor) handleLiquidation(ctx context.Context, position *domain.Position, liquidationPrice decimal.Decimal) error {
 	logger.Warn("liquidating position",
 		"position_id", position.ID,
@@ -143,9 +325,12 @@ func (p *Processor) handleLiquidation(ctx context.Context, position *domain.Posi
 		}
 	}
 
+	// Notify event subscribers
+	p.publishClose(ctx, domain.EventTypeLiquidation, position, trade)
+
 	// Broadcast position close via WebSocket
 	if p.wsHub != nil && trade != nil {
-		p.wsHub.BroadcastPositionClose(position.UserID, position.ID, trade.PnL.String())
+		p.wsHub.BroadcastPositionClose(position.UserID, position.AccountID, position.ID, trade.PnL.String())
 	}
 
 	return nil
@@ -170,9 +355,12 @@ func (p *Processor) handleStopLoss(ctx context.Context, position *domain.Positio
 		}
 	}
 
+	// Notify event subscribers
+	p.publishClose(ctx, domain.EventTypeStopLoss, position, trade)
+
 	// Broadcast position close via WebSocket
 	if p.wsHub != nil && trade != nil {
-		p.wsHub.BroadcastPositionClose(position.UserID, position.ID, trade.PnL.String())
+		p.wsHub.BroadcastPositionClose(position.UserID, position.AccountID, position.ID, trade.PnL.String())
 	}
 
 	return nil
@@ -197,9 +385,12 @@ func (p *Processor) handleTakeProfit(ctx context.Context, position *domain.Posit
 		}
 	}
 
+	// Notify event subscribers
+	p.publishClose(ctx, domain.EventTypeTakeProfit, position, trade)
+
 	// Broadcast position close via WebSocket
 	if p.wsHub != nil && trade != nil {
-		p.wsHub.BroadcastPositionClose(position.UserID, position.ID, trade.PnL.String())
+		p.wsHub.BroadcastPositionClose(position.UserID, position.AccountID, position.ID, trade.PnL.String())
 	}
 
 	return nil
@@ -0,0 +1,134 @@
+package report
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+// ClosedLot is a part of an opening trade matched to a closing trade
+type ClosedLot struct {
+	PositionID   domain.PositionID
+	Symbol       string
+	Side         domain.PositionSide
+	Quantity     decimal.Decimal
+	EntryTradeID domain.TradeID
+	EntryTime    time.Time
+	EntryPrice   decimal.Decimal
+	ExitTradeID  domain.TradeID
+	ExitTime     time.Time
+	ExitPrice    decimal.Decimal
+	PnL          decimal.Decimal // price difference times quantity, before fees
+	Liquidated   bool
+}
+
+// HoldingPeriod is the time between the opening and the closing trade
+func (l *ClosedLot) HoldingPeriod() time.Duration {
+	return l.ExitTime.Sub(l.EntryTime)
+}
+
+// openLot is the unmatched remainder of an OPEN or ADD trade
+type openLot struct {
+	tradeID  domain.TradeID
+	quantity decimal.Decimal
+	price    decimal.Decimal
+	time     time.Time
+}
+
+type positionLots struct {
+	side domain.PositionSide
+	lots []openLot
+}
+
+// Matcher matches closing trades to opening trades of the same position, first in first out.
+// Trades must be fed oldest first; spot trades are ignored.
+type Matcher struct {
+	positions map[domain.PositionID]*positionLots
+	symbols   map[domain.PositionID]string
+}
+
+func NewMatcher() *Matcher {
+	return &Matcher{
+		positions: make(map[domain.PositionID]*positionLots),
+		symbols:   make(map[domain.PositionID]string),
+	}
+}
+
+// Add feeds the next trade and returns the lots it closed
+func (m *Matcher) Add(t *domain.Trade) []ClosedLot {
+	if t.PositionID == 0 {
+		return nil
+	}
+	m.symbols[t.PositionID] = t.Symbol
+
+	switch t.Type {
+	case domain.TradeTypeOpen, domain.TradeTypeAdd:
+		pos, ok := m.positions[t.PositionID]
+		if !ok {
+			pos = &positionLots{side: t.Side}
+			m.positions[t.PositionID] = pos
+		}
+		pos.lots = append(pos.lots, openLot{
+			tradeID:  t.ID,
+			quantity: t.Quantity,
+			price:    t.Price,
+			time:     t.CreatedAt,
+		})
+		return nil
+
+	case domain.TradeTypeClose, domain.TradeTypeLiquidate:
+		pos, ok := m.positions[t.PositionID]
+		if !ok {
+			return nil
+		}
+		return m.close(t, pos)
+	}
+	return nil
+}
+
+// Symbol returns the symbol of a position seen in the fed trades
+func (m *Matcher) Symbol(positionID domain.PositionID) (string, bool) {
+	symbol, ok := m.symbols[positionID]
+	return symbol, ok
+}
+
+func (m *Matcher) close(t *domain.Trade, pos *positionLots) []ClosedLot {
+	var closed []ClosedLot
+	remaining := t.Quantity
+	for remaining.IsPositive() && len(pos.lots) > 0 {
+		lot := &pos.lots[0]
+		qty := decimal.Min(remaining, lot.quantity)
+
+		pnl := t.Price.Sub(lot.price).Mul(qty)
+		if pos.side == domain.PositionSideShort {
+			pnl = pnl.Neg()
+		}
+		closed = append(closed, ClosedLot{
+			PositionID:   t.PositionID,
+			Symbol:       t.Symbol,
+			Side:         pos.side,
+			Quantity:     qty,
+			EntryTradeID: lot.tradeID,
+			EntryTime:    lot.time,
+			EntryPrice:   lot.price,
+			ExitTradeID:  t.ID,
+			ExitTime:     t.CreatedAt,
+			ExitPrice:    t.Price,
+			PnL:          pnl,
+			Liquidated:   t.Type == domain.TradeTypeLiquidate,
+		})
+
+		remaining = remaining.Sub(qty)
+		lot.quantity = lot.quantity.Sub(qty)
+		if !lot.quantity.IsPositive() {
+			pos.lots = pos.lots[1:]
+		}
+	}
+
+	if len(pos.lots) == 0 {
+		delete(m.positions, t.PositionID)
+	}
+	return closed
+}
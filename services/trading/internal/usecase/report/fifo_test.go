@@ -0,0 +1,165 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"trading/internal/domain"
+)
+
+var t0 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testTrade(id, positionID int64, side domain.PositionSide, typ domain.TradeType, qty, price string, minutes int) *domain.Trade {
+	return &domain.Trade{
+		ID:         domain.TradeID(id),
+		PositionID: domain.PositionID(positionID),
+		Symbol:     "BTCUSDT",
+		Side:       side,
+		Type:       typ,
+		Quantity:   decimal.RequireFromString(qty),
+		Price:      decimal.RequireFromString(price),
+		CreatedAt:  t0.Add(time.Duration(minutes) * time.Minute),
+	}
+}
+
+// lot is the part of a ClosedLot the table cases assert on
+type lot struct {
+	position   int64
+	side       domain.PositionSide
+	entry      int64
+	exit       int64
+	quantity   string
+	pnl        string
+	liquidated bool
+}
+
+func TestMatcher(t *testing.T) {
+	long, short := domain.PositionSideLong, domain.PositionSideShort
+	open, add := domain.TradeTypeOpen, domain.TradeTypeAdd
+	closing, liquidate := domain.TradeTypeClose, domain.TradeTypeLiquidate
+
+	tests := []struct {
+		name   string
+		trades []*domain.Trade
+		want   []lot
+	}{
+		{
+			name: "empty series",
+		},
+		{
+			name: "full close",
+			trades: []*domain.Trade{
+				testTrade(1, 1, long, open, "1", "100", 0),
+				testTrade(2, 1, long, closing, "1", "110", 60),
+			},
+			want: []lot{{1, long, 1, 2, "1", "10", false}},
+		},
+		{
+			name: "partial closes consume the oldest lot first",
+			trades: []*domain.Trade{
+				testTrade(1, 1, long, open, "1", "100", 0),
+				testTrade(2, 1, long, add, "2", "130", 10),
+				testTrade(3, 1, long, closing, "0.5", "120", 20),
+				testTrade(4, 1, long, closing, "1.5", "90", 30),
+				testTrade(5, 1, long, closing, "1", "140", 40),
+			},
+			want: []lot{
+				{1, long, 1, 3, "0.5", "10", false},
+				{1, long, 1, 4, "0.5", "-5", false},
+				{1, long, 2, 4, "1", "-40", false},
+				{1, long, 2, 5, "1", "10", false},
+			},
+		},
+		{
+			name: "short pnl is inverted",
+			trades: []*domain.Trade{
+				testTrade(1, 1, short, open, "2", "100", 0),
+				testTrade(2, 1, short, closing, "2", "80", 5),
+			},
+			want: []lot{{1, short, 1, 2, "2", "40", false}},
+		},
+		{
+			name: "flip closes the long and opens a separate short",
+			trades: []*domain.Trade{
+				testTrade(1, 1, long, open, "1", "100", 0),
+				testTrade(2, 1, long, closing, "1", "105", 10),
+				testTrade(3, 2, short, open, "1", "105", 10),
+				testTrade(4, 2, short, closing, "1", "95", 20),
+			},
+			want: []lot{
+				{1, long, 1, 2, "1", "5", false},
+				{2, short, 3, 4, "1", "10", false},
+			},
+		},
+		{
+			name: "liquidation closes the rest",
+			trades: []*domain.Trade{
+				testTrade(1, 1, long, open, "1", "100", 0),
+				testTrade(2, 1, long, add, "1", "90", 10),
+				testTrade(3, 1, long, liquidate, "2", "80", 20),
+			},
+			want: []lot{
+				{1, long, 1, 3, "1", "-20", true},
+				{1, long, 2, 3, "1", "-10", true},
+			},
+		},
+		{
+			name: "close without a known opening trade is ignored",
+			trades: []*domain.Trade{
+				testTrade(1, 1, long, closing, "1", "100", 0),
+			},
+		},
+		{
+			name: "close beyond the open quantity matches what is open",
+			trades: []*domain.Trade{
+				testTrade(1, 1, long, open, "1", "100", 0),
+				testTrade(2, 1, long, closing, "3", "110", 10),
+				testTrade(3, 1, long, closing, "1", "120", 20),
+			},
+			want: []lot{{1, long, 1, 2, "1", "10", false}},
+		},
+		{
+			name: "spot trades are ignored",
+			trades: []*domain.Trade{
+				testTrade(1, 0, long, domain.TradeTypeSpotBuy, "1", "100", 0),
+				testTrade(2, 0, long, domain.TradeTypeSpotSell, "1", "110", 10),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatcher()
+			var got []ClosedLot
+			for _, trade := range tt.trades {
+				got = append(got, m.Add(trade)...)
+			}
+
+			require.Len(t, got, len(tt.want))
+			for i, w := range tt.want {
+				g := got[i]
+				assert.Equal(t, domain.PositionID(w.position), g.PositionID, "lot %d position", i)
+				assert.Equal(t, w.side, g.Side, "lot %d side", i)
+				assert.Equal(t, domain.TradeID(w.entry), g.EntryTradeID, "lot %d entry", i)
+				assert.Equal(t, domain.TradeID(w.exit), g.ExitTradeID, "lot %d exit", i)
+				assert.True(t, decimal.RequireFromString(w.quantity).Equal(g.Quantity), "lot %d quantity %s", i, g.Quantity)
+				assert.True(t, decimal.RequireFromString(w.pnl).Equal(g.PnL), "lot %d pnl %s", i, g.PnL)
+				assert.Equal(t, w.liquidated, g.Liquidated, "lot %d liquidated", i)
+			}
+		})
+	}
+}
+
+func TestClosedLot_HoldingPeriod(t *testing.T) {
+	m := NewMatcher()
+	m.Add(testTrade(1, 1, domain.PositionSideLong, domain.TradeTypeOpen, "1", "100", 0))
+	lots := m.Add(testTrade(2, 1, domain.PositionSideLong, domain.TradeTypeClose, "1", "100", 90))
+
+	require.Len(t, lots, 1)
+	assert.Equal(t, 90*time.Minute, lots[0].HoldingPeriod())
+	assert.Empty(t, m.positions, "fully closed positions are dropped")
+}
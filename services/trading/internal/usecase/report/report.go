@@ -0,0 +1,240 @@
+package report
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+// batchSize is the number of trades or ledger entries read per query
+const batchSize = 1000
+
+// Period is the length of a statement line
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodMonth Period = "month"
+	PeriodYear  Period = "year"
+)
+
+func (p Period) IsValid() bool {
+	switch p {
+	case PeriodDay, PeriodMonth, PeriodYear:
+		return true
+	}
+	return false
+}
+
+// Start truncates t to the beginning of its UTC period
+func (p Period) Start(t time.Time) time.Time {
+	t = t.UTC()
+	switch p {
+	case PeriodYear:
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	case PeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// Amounts are the realized results of a period or symbol
+type Amounts struct {
+	RealizedPnL decimal.Decimal // FIFO matched lots, before fees
+	Fees        decimal.Decimal // fees of opening and closing trades made in the period
+	Funding     decimal.Decimal // signed: positive = received
+	NetPnL      decimal.Decimal // realized PnL - fees + funding
+	Lots        int
+}
+
+func (a *Amounts) finish() {
+	a.NetPnL = a.RealizedPnL.Sub(a.Fees).Add(a.Funding)
+}
+
+func (a *Amounts) add(other Amounts) {
+	a.RealizedPnL = a.RealizedPnL.Add(other.RealizedPnL)
+	a.Fees = a.Fees.Add(other.Fees)
+	a.Funding = a.Funding.Add(other.Funding)
+	a.Lots += other.Lots
+}
+
+// SymbolAmounts is the part of a period traded in one symbol
+type SymbolAmounts struct {
+	Symbol string
+	Amounts
+}
+
+// PeriodStatement is one line of the statement
+type PeriodStatement struct {
+	Start    time.Time
+	BySymbol []SymbolAmounts
+	Amounts
+}
+
+// Statement is the realized PnL report of an account
+type Statement struct {
+	Period  Period
+	From    *time.Time
+	To      *time.Time
+	Periods []PeriodStatement // oldest first, periods without activity are omitted
+	Lots    []ClosedLot       // closed in the range, oldest first
+	Total   Amounts
+}
+
+type UseCase struct {
+	tradeRepo    domain.TradeRepository
+	ledgerRepo   domain.LedgerRepository
+	positionRepo domain.PositionRepository
+}
+
+func NewUseCase(
+	tradeRepo domain.TradeRepository,
+	ledgerRepo domain.LedgerRepository,
+	positionRepo domain.PositionRepository,
+) *UseCase {
+	return &UseCase{
+		tradeRepo:    tradeRepo,
+		ledgerRepo:   ledgerRepo,
+		positionRepo: positionRepo,
+	}
+}
+
+type Input struct {
+	Period Period
+	From   *time.Time // inclusive
+	To     *time.Time // exclusive
+}
+
+// GetRealizedPnL builds the realized PnL statement of the account. Lots count towards
+// the period they were closed in; the full trade history up to the end of the range is
+// replayed so positions opened before it are matched correctly.
+func (uc *UseCase) GetRealizedPnL(ctx context.Context, accountID domain.AccountID, input Input) (*Statement, error) {
+	if !input.Period.IsValid() {
+		return nil, domain.ErrInvalidReportPeriod
+	}
+	if input.From != nil && input.To != nil && !input.From.Before(*input.To) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	inRange := func(t time.Time) bool {
+		return (input.From == nil || !t.Before(*input.From)) && (input.To == nil || t.Before(*input.To))
+	}
+
+	statement := &Statement{Period: input.Period, From: input.From, To: input.To}
+	periods := make(map[time.Time]map[string]*Amounts)
+	amounts := func(at time.Time, symbol string) *Amounts {
+		start := input.Period.Start(at)
+		bySymbol, ok := periods[start]
+		if !ok {
+			bySymbol = make(map[string]*Amounts)
+			periods[start] = bySymbol
+		}
+		a, ok := bySymbol[symbol]
+		if !ok {
+			a = &Amounts{}
+			bySymbol[symbol] = a
+		}
+		return a
+	}
+
+	matcher := NewMatcher()
+	var afterID domain.TradeID
+	for {
+		trades, err := uc.tradeRepo.GetByAccountIDAfter(ctx, accountID, nil, input.To, afterID, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range trades {
+			t := &trades[i]
+			lots := matcher.Add(t)
+			if t.PositionID == 0 || !inRange(t.CreatedAt) {
+				continue
+			}
+
+			a := amounts(t.CreatedAt, t.Symbol)
+			a.Fees = a.Fees.Add(t.Fee)
+			for _, lot := range lots {
+				a.RealizedPnL = a.RealizedPnL.Add(lot.PnL)
+				a.Lots++
+			}
+			statement.Lots = append(statement.Lots, lots...)
+		}
+		if len(trades) < batchSize {
+			break
+		}
+		afterID = trades[len(trades)-1].ID
+	}
+
+	if err := uc.addFunding(ctx, accountID, input, matcher, amounts); err != nil {
+		return nil, err
+	}
+
+	starts := make([]time.Time, 0, len(periods))
+	for start := range periods {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	statement.Periods = make([]PeriodStatement, len(starts))
+	for i, start := range starts {
+		line := PeriodStatement{Start: start}
+		for symbol, a := range periods[start] {
+			a.finish()
+			line.BySymbol = append(line.BySymbol, SymbolAmounts{Symbol: symbol, Amounts: *a})
+			line.add(*a)
+		}
+		sort.Slice(line.BySymbol, func(i, j int) bool { return line.BySymbol[i].Symbol < line.BySymbol[j].Symbol })
+		line.finish()
+		statement.Total.add(line.Amounts)
+		statement.Periods[i] = line
+	}
+	statement.Total.finish()
+
+	return statement, nil
+}
+
+// addFunding attributes funding payments in the range to the symbol of their position
+func (uc *UseCase) addFunding(
+	ctx context.Context,
+	accountID domain.AccountID,
+	input Input,
+	matcher *Matcher,
+	amounts func(time.Time, string) *Amounts,
+) error {
+	filter := domain.LedgerFilter{
+		Types: []domain.LedgerEntryType{domain.LedgerEntryTypeFunding},
+		From:  input.From,
+		To:    input.To,
+		Limit: batchSize,
+	}
+	for {
+		entries, err := uc.ledgerRepo.GetByAccountID(ctx, accountID, filter)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			symbol := ""
+			if e.PositionID != nil {
+				var ok bool
+				if symbol, ok = matcher.Symbol(*e.PositionID); !ok {
+					position, err := uc.positionRepo.GetByID(ctx, *e.PositionID)
+					if err != nil {
+						return err
+					}
+					symbol = position.Symbol
+				}
+			}
+			a := amounts(e.CreatedAt, symbol)
+			a.Funding = a.Funding.Add(e.Amount)
+		}
+		if len(entries) < batchSize {
+			return nil
+		}
+		filter.Offset += batchSize
+	}
+}
@@ -0,0 +1,332 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	"trading/internal/script"
+	orderuc "trading/internal/usecase/order"
+	positionuc "trading/internal/usecase/position"
+)
+
+const (
+	// maxRestingOrders caps the resting limit orders of a script
+	maxRestingOrders = 20
+	// maxFillRounds bounds how often fills may trigger on_fill in a row, since
+	// on_fill can place orders that fill again
+	maxFillRounds = 10
+)
+
+// errTooManyOrders fails a callback that leaves too many orders resting
+var errTooManyOrders = errors.New("too many resting orders")
+
+// callbackKey marks the context of a running callback. Fills published while
+// it runs are queued instead of taking the lock the callback holds, and are
+// passed to on_fill once it returns.
+type callbackKey struct{}
+
+type fillQueue struct {
+	events []domain.AccountEvent
+}
+
+// runner is a running script with its loaded instance
+type runner struct {
+	script    *domain.Script
+	instance  *script.Instance
+	lastPrice time.Time
+}
+
+// OnPrice calls on_price of the running scripts on the price's symbol, at
+// most once per price interval per script. Their resting orders are filled by
+// the order use case before, and reach on_fill through Publish.
+func (uc *UseCase) OnPrice(ctx context.Context, price *domain.Price) {
+	if uc.orderUC == nil {
+		return
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if err := uc.load(ctx); err != nil {
+		logger.Error("failed to load scripts", "error", err)
+		return
+	}
+
+	queue := &fillQueue{}
+	ctx = context.WithValue(ctx, callbackKey{}, queue)
+
+	for _, r := range uc.running {
+		if r.script.Symbol != price.Symbol || price.Timestamp.Sub(r.lastPrice) < uc.priceInterval {
+			continue
+		}
+		r.lastPrice = price.Timestamp
+		state, logs, err := r.instance.OnPrice(ctx, r.script.State, script.Tick{
+			Symbol: price.Symbol,
+			Bid:    price.Bid,
+			Ask:    price.Ask,
+			Time:   price.Timestamp,
+		})
+		uc.finish(ctx, r, state, logs, err)
+		uc.drain(ctx, queue)
+	}
+}
+
+// Publish passes a trade of an account to on_fill of its running script on
+// the symbol. It implements domain.EventPublisher, so scripts see their own
+// fills as well as manual trades, stops and liquidations. A rejected limit
+// order of a script is dropped from its orders and logged.
+func (uc *UseCase) Publish(ctx context.Context, event domain.AccountEvent) {
+	if uc.orderUC == nil {
+		return
+	}
+	if event.Trade == nil && (event.Type != domain.EventTypeOrderRejected || event.Order == nil) {
+		return
+	}
+	if queue, ok := ctx.Value(callbackKey{}).(*fillQueue); ok {
+		queue.events = append(queue.events, event)
+		return
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if err := uc.load(ctx); err != nil {
+		logger.Error("failed to load scripts", "error", err)
+		return
+	}
+
+	queue := &fillQueue{events: []domain.AccountEvent{event}}
+	uc.drain(context.WithValue(ctx, callbackKey{}, queue), queue)
+}
+
+// drain calls on_fill for the queued events, including the fills of orders
+// placed from on_fill, for a bounded number of rounds
+func (uc *UseCase) drain(ctx context.Context, queue *fillQueue) {
+	for round := 0; round < maxFillRounds && len(queue.events) > 0; round++ {
+		events := queue.events
+		queue.events = nil
+		for _, event := range events {
+			if event.Trade == nil {
+				uc.rejected(ctx, event)
+				continue
+			}
+			for _, r := range uc.running {
+				if r.script.AccountID != event.AccountID || r.script.Symbol != event.Trade.Symbol {
+					continue
+				}
+				r.script.RemoveOrder(event.Trade.OrderID)
+				state, logs, err := r.instance.OnFill(ctx, r.script.State, script.FillFromTrade(event.Trade))
+				uc.finish(ctx, r, state, logs, err)
+			}
+		}
+	}
+	if len(queue.events) > 0 {
+		logger.Warn("script fills dropped", "count", len(queue.events))
+		queue.events = nil
+	}
+}
+
+// rejected drops a limit order rejected on fill from its script's orders
+func (uc *UseCase) rejected(ctx context.Context, event domain.AccountEvent) {
+	for _, r := range uc.running {
+		if r.script.AccountID != event.AccountID || !r.script.RemoveOrder(event.Order.ID) {
+			continue
+		}
+		uc.appendLogs(ctx, r.script, []script.Log{{
+			Level:   domain.ScriptLogError,
+			Message: fmt.Sprintf("limit order %d rejected", event.Order.ID),
+		}})
+		if err := uc.scriptRepo.Update(ctx, r.script); err != nil && !errors.Is(err, domain.ErrScriptNotFound) {
+			logger.Error("failed to save script", "script_id", r.script.ID, "error", err)
+		}
+	}
+}
+
+// finish stores the outcome of a callback. A failed callback stops the script
+// in the ERROR status and cancels its resting orders.
+func (uc *UseCase) finish(ctx context.Context, r *runner, state string, logs []script.Log, err error) {
+	s := r.script
+	if err != nil {
+		message := err.Error()
+		if len(message) > maxLastErrorLength {
+			message = message[:maxLastErrorLength]
+		}
+		logs = append(logs, script.Log{Level: domain.ScriptLogError, Message: message})
+		uc.cancelOrders(ctx, s)
+		s.Status = domain.ScriptStatusError
+		s.LastError = message
+		delete(uc.running, s.ID)
+		logger.Warn("script failed", "script_id", s.ID, "error", err)
+	} else {
+		s.State = state
+	}
+	uc.appendLogs(ctx, s, logs)
+
+	if err := uc.scriptRepo.Update(ctx, s); err != nil {
+		if errors.Is(err, domain.ErrScriptNotFound) {
+			// Removed together with its account
+			delete(uc.running, s.ID)
+			return
+		}
+		logger.Error("failed to save script", "script_id", s.ID, "error", err)
+	}
+}
+
+// instantiate compiles and loads the runner's script, running its top level.
+// ctx must carry the fill queue of the caller.
+func (uc *UseCase) instantiate(ctx context.Context, r *runner) ([]script.Log, error) {
+	program, err := script.Compile(r.script.Name, r.script.Source)
+	if err != nil {
+		return nil, err
+	}
+	instance, logs, err := script.Load(ctx, program, r.script.Params, &broker{uc: uc, script: r.script}, uc.budget)
+	if err != nil {
+		return logs, err
+	}
+	r.instance = instance
+	return logs, nil
+}
+
+// load starts the scripts that were running before a restart; scripts that
+// no longer load are stopped with an error
+func (uc *UseCase) load(ctx context.Context) error {
+	if uc.loaded {
+		return nil
+	}
+	scripts, err := uc.scriptRepo.GetRunning(ctx)
+	if err != nil {
+		return err
+	}
+	uc.loaded = true
+
+	queue := &fillQueue{}
+	ctx = context.WithValue(ctx, callbackKey{}, queue)
+	for i := range scripts {
+		r := &runner{script: &scripts[i]}
+		logs, err := uc.instantiate(ctx, r)
+		if err != nil {
+			uc.finish(ctx, r, "", logs, err)
+			continue
+		}
+		uc.running[r.script.ID] = r
+		uc.appendLogs(ctx, r.script, logs)
+	}
+	uc.drain(ctx, queue)
+	return nil
+}
+
+// broker is the trading API of a live script: its account, its symbol and
+// the limit orders it placed
+type broker struct {
+	uc     *UseCase
+	script *domain.Script
+}
+
+func (b *broker) Balance(ctx context.Context) (script.Balance, error) {
+	account, err := b.uc.accountRepo.GetByID(ctx, b.script.AccountID)
+	if err != nil {
+		return script.Balance{}, err
+	}
+	summary, err := b.uc.accountUC.Summary(ctx, account)
+	if err != nil {
+		return script.Balance{}, err
+	}
+	return script.Balance{
+		Balance:         summary.Balance,
+		Equity:          summary.Equity,
+		AvailableMargin: summary.AvailableMargin,
+	}, nil
+}
+
+func (b *broker) Position(ctx context.Context) (*domain.Position, error) {
+	position, err := b.uc.positionRepo.GetOpenByAccountIDAndSymbol(ctx, b.script.AccountID, b.script.Symbol)
+	if errors.Is(err, domain.ErrPositionNotFound) {
+		return nil, nil
+	}
+	return position, err
+}
+
+// Orders returns the script's resting orders and forgets the ones cancelled
+// outside the script
+func (b *broker) Orders(ctx context.Context) ([]domain.Order, error) {
+	var orders []domain.Order
+	ids := b.script.Orders[:0:0]
+	for _, id := range b.script.Orders {
+		order, err := b.uc.orderRepo.GetByID(ctx, id)
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if order.IsPending() {
+			orders = append(orders, *order)
+			ids = append(ids, id)
+		}
+	}
+	b.script.Orders = ids
+	return orders, nil
+}
+
+func (b *broker) Place(ctx context.Context, order script.Order) (domain.OrderID, error) {
+	input := orderuc.PlaceOrderInput{
+		AccountID:  b.script.AccountID,
+		Symbol:     b.script.Symbol,
+		Side:       order.Side,
+		Type:       domain.OrderTypeMarket,
+		Quantity:   order.Quantity,
+		Leverage:   order.Leverage,
+		StopLoss:   order.StopLoss,
+		TakeProfit: order.TakeProfit,
+	}
+	if order.Price != nil {
+		if len(b.script.Orders) >= maxRestingOrders {
+			if _, err := b.Orders(ctx); err != nil {
+				return 0, err
+			}
+		}
+		if len(b.script.Orders) >= maxRestingOrders {
+			return 0, errTooManyOrders
+		}
+		input.Type = domain.OrderTypeLimit
+		input.Price = *order.Price
+	}
+
+	output, err := b.uc.orderUC.PlaceOrder(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+	if output.Order.IsPending() {
+		b.script.Orders = append(b.script.Orders, output.Order.ID)
+	}
+	return output.Order.ID, nil
+}
+
+func (b *broker) Cancel(ctx context.Context, id domain.OrderID) error {
+	if !b.script.RemoveOrder(id) {
+		return domain.ErrOrderNotFound
+	}
+	return b.uc.orderUC.CancelOrder(ctx, b.script.AccountID, id)
+}
+
+func (b *broker) Close(ctx context.Context, quantity *decimal.Decimal) (bool, error) {
+	position, err := b.Position(ctx)
+	if err != nil || position == nil {
+		return false, err
+	}
+	if quantity != nil && quantity.GreaterThanOrEqual(position.Quantity) {
+		quantity = nil
+	}
+	_, err = b.uc.positionUC.ClosePosition(ctx, positionuc.ClosePositionInput{
+		AccountID:  b.script.AccountID,
+		PositionID: position.ID,
+		Quantity:   quantity,
+	})
+	return err == nil, err
+}
@@ -0,0 +1,371 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	"trading/internal/script"
+	accountuc "trading/internal/usecase/account"
+	backtestuc "trading/internal/usecase/backtest"
+	orderuc "trading/internal/usecase/order"
+	positionuc "trading/internal/usecase/position"
+)
+
+const (
+	// maxScriptsPerAccount caps the scripts of an account, running or not
+	maxScriptsPerAccount = 10
+	// maxLogLines is how many log lines are kept per script
+	maxLogLines = 500
+	// maxLastErrorLength truncates the error a failed script is stopped with
+	maxLastErrorLength = 2000
+)
+
+type UseCase struct {
+	scriptRepo   domain.ScriptRepository
+	accountRepo  domain.AccountRepository
+	orderRepo    domain.OrderRepository
+	positionRepo domain.PositionRepository
+	backtestUC   *backtestuc.UseCase
+	accountUC    *accountuc.UseCase
+	orderUC      *orderuc.UseCase
+	positionUC   *positionuc.UseCase
+
+	budget        script.Budget
+	priceInterval time.Duration
+
+	// mu serializes callbacks, fills and script changes from the API
+	mu      sync.Mutex
+	loaded  bool
+	running map[domain.ScriptID]*runner
+}
+
+func NewUseCase(
+	scriptRepo domain.ScriptRepository,
+	accountRepo domain.AccountRepository,
+	orderRepo domain.OrderRepository,
+	positionRepo domain.PositionRepository,
+	backtestUC *backtestuc.UseCase,
+	maxSteps int,
+	timeout time.Duration,
+	priceInterval time.Duration,
+) *UseCase {
+	return &UseCase{
+		scriptRepo:    scriptRepo,
+		accountRepo:   accountRepo,
+		orderRepo:     orderRepo,
+		positionRepo:  positionRepo,
+		backtestUC:    backtestUC,
+		budget:        script.Budget{MaxSteps: uint64(maxSteps), Timeout: timeout},
+		priceInterval: priceInterval,
+		running:       make(map[domain.ScriptID]*runner),
+	}
+}
+
+// SetTrading provides the use cases scripts trade with. They publish their
+// fills to this use case, so they are constructed after it.
+func (uc *UseCase) SetTrading(accountUC *accountuc.UseCase, orderUC *orderuc.UseCase, positionUC *positionuc.UseCase) {
+	uc.accountUC = accountUC
+	uc.orderUC = orderUC
+	uc.positionUC = positionUC
+}
+
+type CreateInput struct {
+	UserID    domain.UserID
+	AccountID domain.AccountID
+	Name      string
+	Symbol    string
+	Source    string
+	Params    map[string]float64
+}
+
+// Create stores a stopped script after checking that it compiles
+func (uc *UseCase) Create(ctx context.Context, input CreateInput) (*domain.Script, error) {
+	s := &domain.Script{
+		UserID:    input.UserID,
+		AccountID: input.AccountID,
+		Name:      strings.TrimSpace(input.Name),
+		Symbol:    strings.ToUpper(input.Symbol),
+		Source:    input.Source,
+		Params:    input.Params,
+		Status:    domain.ScriptStatusStopped,
+		State:     "{}",
+	}
+	if s.Params == nil {
+		s.Params = map[string]float64{}
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	// Scripts trade perpetuals only
+	if instrument, ok := uc.orderUC.Instrument(s.Symbol); !ok || instrument.IsSpot() {
+		return nil, domain.ErrSymbolNotSupported
+	}
+	if _, err := script.Compile(s.Name, s.Source); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.scriptRepo.ListByAccountID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxScriptsPerAccount {
+		return nil, domain.ErrTooManyScripts
+	}
+
+	if err := uc.scriptRepo.Create(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Get returns a script of the account, the live copy for running ones
+func (uc *UseCase) Get(ctx context.Context, accountID domain.AccountID, id domain.ScriptID) (*domain.Script, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	s, err := uc.script(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := *s
+	return &snapshot, nil
+}
+
+// List returns the scripts of the account, newest first
+func (uc *UseCase) List(ctx context.Context, accountID domain.AccountID) ([]domain.Script, error) {
+	return uc.scriptRepo.ListByAccountID(ctx, accountID)
+}
+
+type UpdateInput struct {
+	Name       *string
+	Source     *string
+	Params     map[string]float64 // nil keeps the parameters
+	ResetState bool
+}
+
+// Update changes a stopped script
+func (uc *UseCase) Update(ctx context.Context, accountID domain.AccountID, id domain.ScriptID, input UpdateInput) (*domain.Script, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	s, err := uc.script(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.IsRunning() {
+		return nil, domain.ErrScriptRunning
+	}
+
+	if input.Name != nil {
+		s.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Source != nil {
+		s.Source = *input.Source
+	}
+	if input.Params != nil {
+		s.Params = input.Params
+	}
+	if input.ResetState {
+		s.State = "{}"
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := script.Compile(s.Name, s.Source); err != nil {
+		return nil, err
+	}
+
+	if err := uc.scriptRepo.Update(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Delete removes a stopped script with its logs
+func (uc *UseCase) Delete(ctx context.Context, accountID domain.AccountID, id domain.ScriptID) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	s, err := uc.script(ctx, accountID, id)
+	if err != nil {
+		return err
+	}
+	if s.IsRunning() {
+		return domain.ErrScriptRunning
+	}
+	return uc.scriptRepo.Delete(ctx, s.ID)
+}
+
+// Start loads the script and begins calling it back. Its top level runs
+// once on start; a script that fails to load stays stopped. Only one script
+// of an account runs on a symbol.
+func (uc *UseCase) Start(ctx context.Context, accountID domain.AccountID, id domain.ScriptID) (*domain.Script, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if err := uc.load(ctx); err != nil {
+		return nil, err
+	}
+	s, err := uc.script(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.IsRunning() {
+		snapshot := *s
+		return &snapshot, nil
+	}
+	for _, r := range uc.running {
+		if r.script.AccountID == s.AccountID && r.script.Symbol == s.Symbol {
+			return nil, domain.ErrScriptSymbolBusy
+		}
+	}
+
+	// Fills of orders placed by the top level reach on_fill once it is running
+	queue := &fillQueue{}
+	ctx = context.WithValue(ctx, callbackKey{}, queue)
+
+	r := &runner{script: s}
+	logs, err := uc.instantiate(ctx, r)
+	uc.appendLogs(ctx, s, logs)
+	if err != nil {
+		uc.cancelOrders(ctx, s)
+		return nil, err
+	}
+
+	now := time.Now()
+	s.Status = domain.ScriptStatusRunning
+	s.LastError = ""
+	s.StartedAt = &now
+	if err := uc.scriptRepo.Update(ctx, s); err != nil {
+		return nil, err
+	}
+	uc.running[s.ID] = r
+	uc.drain(ctx, queue)
+
+	logger.Info("script started", "script_id", s.ID, "account_id", s.AccountID, "symbol", s.Symbol)
+
+	snapshot := *s
+	return &snapshot, nil
+}
+
+// Stop ends the callbacks and cancels the script's resting orders; positions stay open
+func (uc *UseCase) Stop(ctx context.Context, accountID domain.AccountID, id domain.ScriptID) (*domain.Script, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	s, err := uc.script(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !s.IsRunning() {
+		snapshot := *s
+		return &snapshot, nil
+	}
+
+	uc.cancelOrders(ctx, s)
+	s.Status = domain.ScriptStatusStopped
+	if err := uc.scriptRepo.Update(ctx, s); err != nil {
+		return nil, err
+	}
+	delete(uc.running, s.ID)
+
+	logger.Info("script stopped", "script_id", s.ID)
+
+	snapshot := *s
+	return &snapshot, nil
+}
+
+// Logs returns the script's latest log lines, newest first
+func (uc *UseCase) Logs(ctx context.Context, accountID domain.AccountID, id domain.ScriptID, limit int) ([]domain.ScriptLog, error) {
+	s, err := uc.scriptRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.AccountID != accountID {
+		return nil, domain.ErrScriptNotFound
+	}
+	return uc.scriptRepo.ListLogs(ctx, id, limit)
+}
+
+type BacktestInput struct {
+	UserID          domain.UserID
+	Dataset         string
+	CSV             string
+	StartingBalance decimal.Decimal
+	Spread          float64
+}
+
+// Backtest runs the script's current source and parameters over historical
+// candles as a backtest job, starting from an empty state
+func (uc *UseCase) Backtest(ctx context.Context, accountID domain.AccountID, id domain.ScriptID, input BacktestInput) (*backtestuc.Job, error) {
+	s, err := uc.scriptRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.AccountID != accountID {
+		return nil, domain.ErrScriptNotFound
+	}
+	program, err := script.Compile(s.Name, s.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.backtestUC.SubmitStrategy(ctx, backtestuc.SubmitInput{
+		UserID:          input.UserID,
+		Strategy:        fmt.Sprintf("script:%s", s.Name),
+		Params:          s.Params,
+		Symbol:          s.Symbol,
+		Dataset:         input.Dataset,
+		CSV:             input.CSV,
+		StartingBalance: input.StartingBalance,
+		Spread:          input.Spread,
+	}, script.NewStrategy(program, s.Params, uc.budget))
+}
+
+// script returns the account's script, the live copy for running ones
+func (uc *UseCase) script(ctx context.Context, accountID domain.AccountID, id domain.ScriptID) (*domain.Script, error) {
+	if r, ok := uc.running[id]; ok {
+		if r.script.AccountID != accountID {
+			return nil, domain.ErrScriptNotFound
+		}
+		return r.script, nil
+	}
+	s, err := uc.scriptRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.AccountID != accountID {
+		return nil, domain.ErrScriptNotFound
+	}
+	return s, nil
+}
+
+// cancelOrders cancels the resting orders of a script
+func (uc *UseCase) cancelOrders(ctx context.Context, s *domain.Script) {
+	for _, id := range s.Orders {
+		if err := uc.orderUC.CancelOrder(ctx, s.AccountID, id); err != nil && !script.IsRejection(err) {
+			logger.Error("failed to cancel script order", "script_id", s.ID, "order_id", id, "error", err)
+		}
+	}
+	s.Orders = nil
+}
+
+func (uc *UseCase) appendLogs(ctx context.Context, s *domain.Script, logs []script.Log) {
+	if len(logs) == 0 {
+		return
+	}
+	lines := make([]domain.ScriptLog, len(logs))
+	for i, l := range logs {
+		lines[i] = domain.ScriptLog{ScriptID: s.ID, Level: l.Level, Message: l.Message}
+	}
+	if err := uc.scriptRepo.AppendLogs(ctx, s.ID, lines, maxLogLines); err != nil {
+		logger.Error("failed to store script logs", "script_id", s.ID, "error", err)
+	}
+}
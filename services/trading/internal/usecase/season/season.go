@@ -0,0 +1,240 @@
+package season
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	accountuc "trading/internal/usecase/account"
+	dcauc "trading/internal/usecase/dca"
+	griduc "trading/internal/usecase/grid"
+	orderuc "trading/internal/usecase/order"
+	positionuc "trading/internal/usecase/position"
+	scriptuc "trading/internal/usecase/script"
+)
+
+type UseCase struct {
+	accountRepo    domain.AccountRepository
+	seasonRepo     domain.AccountSeasonRepository
+	tradeRepo      domain.TradeRepository
+	orderUC        *orderuc.UseCase
+	positionUC     *positionuc.UseCase
+	accountUC      *accountuc.UseCase
+	gridUC         *griduc.UseCase
+	dcaUC          *dcauc.UseCase
+	scriptUC       *scriptuc.UseCase
+	initialBalance decimal.Decimal
+}
+
+func NewUseCase(
+	accountRepo domain.AccountRepository,
+	seasonRepo domain.AccountSeasonRepository,
+	tradeRepo domain.TradeRepository,
+	orderUC *orderuc.UseCase,
+	positionUC *positionuc.UseCase,
+	accountUC *accountuc.UseCase,
+	gridUC *griduc.UseCase,
+	dcaUC *dcauc.UseCase,
+	scriptUC *scriptuc.UseCase,
+	initialBalance float64,
+) *UseCase {
+	return &UseCase{
+		accountRepo:    accountRepo,
+		seasonRepo:     seasonRepo,
+		tradeRepo:      tradeRepo,
+		orderUC:        orderUC,
+		positionUC:     positionUC,
+		accountUC:      accountUC,
+		gridUC:         gridUC,
+		dcaUC:          dcaUC,
+		scriptUC:       scriptUC,
+		initialBalance: decimal.NewFromFloat(initialBalance),
+	}
+}
+
+// Reset ends the current season: grid bots are stopped, DCA plans cancelled
+// and scripts stopped, pending orders are cancelled, open positions are closed
+// and wallet assets sold for USDT at current prices. The season is archived
+// with its final stats and the primary account's balance is brought back to
+// the initial balance. Returns the archived season. Competition and challenge
+// accounts cannot be reset.
+func (uc *UseCase) Reset(ctx context.Context, accountID domain.AccountID) (*domain.AccountSeason, error) {
+	current, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if current.IsIsolated() {
+		return nil, domain.ErrIsolatedAccount
+	}
+
+	// Automation would keep trading the new season on the old one's state
+	if err := uc.stopAutomation(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	orders, err := uc.orderUC.GetPendingOrders(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range orders {
+		if err := uc.orderUC.CancelOrder(ctx, accountID, o.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := uc.positionUC.CloseAllPositions(ctx, accountID, "reset"); err != nil {
+		return nil, err
+	}
+
+	if err := uc.accountUC.ConvertAllToQuote(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	// Reload: closing positions and conversions settled into the balance
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.tradeRepo.Summarize(ctx, account.ID, account.SeasonStartedAt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	season := account.CurrentSeason(*stats)
+
+	// Sub-accounts are only funded by transfers, so they keep their balance
+	next := account.Balance
+	var adjustment *domain.LedgerEntry
+	if account.Name == domain.PrimaryAccountName {
+		next = uc.initialBalance
+		if amount := uc.initialBalance.Sub(account.Balance); !amount.IsZero() {
+			adjustment = &domain.LedgerEntry{
+				AccountID:   account.ID,
+				Type:        domain.LedgerEntryTypeAdjustment,
+				Amount:      amount,
+				Description: "season reset",
+			}
+		}
+	}
+	if err := uc.seasonRepo.Archive(ctx, &season, next, adjustment); err != nil {
+		return nil, err
+	}
+
+	logger.Info("account reset",
+		"account_id", account.ID,
+		"season", season.Season,
+		"final_balance", season.FinalBalance,
+	)
+
+	return &season, nil
+}
+
+// stopAutomation stops the account's grid bots without closing their
+// positions, cancels its DCA plans and stops its scripts
+func (uc *UseCase) stopAutomation(ctx context.Context, accountID domain.AccountID) error {
+	bots, err := uc.gridUC.List(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	for _, b := range bots {
+		if b.Bot.Status == domain.GridBotStatusStopped {
+			continue
+		}
+		if _, err := uc.gridUC.Stop(ctx, accountID, b.Bot.ID, false); err != nil {
+			return fmt.Errorf("stop grid bot %d: %w", b.Bot.ID, err)
+		}
+	}
+
+	plans, err := uc.dcaUC.List(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	for _, p := range plans {
+		if p.Status == domain.DCAPlanStatusCancelled {
+			continue
+		}
+		if _, err := uc.dcaUC.Cancel(ctx, accountID, p.ID); err != nil {
+			return fmt.Errorf("cancel dca plan %d: %w", p.ID, err)
+		}
+	}
+
+	scripts, err := uc.scriptUC.List(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	for _, s := range scripts {
+		if !s.IsRunning() {
+			continue
+		}
+		if _, err := uc.scriptUC.Stop(ctx, accountID, s.ID); err != nil {
+			return fmt.Errorf("stop script %d: %w", s.ID, err)
+		}
+	}
+	return nil
+}
+
+// GetSeasons returns the current season followed by archived ones, newest first
+func (uc *UseCase) GetSeasons(ctx context.Context, accountID domain.AccountID) ([]domain.AccountSeason, error) {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := uc.currentSeason(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := uc.seasonRepo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]domain.AccountSeason{*current}, archived...), nil
+}
+
+// GetSeason returns a single season, live stats for the current one
+func (uc *UseCase) GetSeason(ctx context.Context, accountID domain.AccountID, season int) (*domain.AccountSeason, error) {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if season == account.Season {
+		return uc.currentSeason(ctx, account)
+	}
+	return uc.seasonRepo.GetByAccountIDAndSeason(ctx, account.ID, season)
+}
+
+// GetSeasonTrades returns the trades made during a season, newest first
+func (uc *UseCase) GetSeasonTrades(ctx context.Context, accountID domain.AccountID, season, limit, offset int) ([]domain.Trade, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	s, err := uc.GetSeason(ctx, accountID, season)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.tradeRepo.GetByAccountIDBetween(ctx, accountID, s.StartedAt, s.EndedAt, limit, offset)
+}
+
+func (uc *UseCase) currentSeason(ctx context.Context, account *domain.Account) (*domain.AccountSeason, error) {
+	stats, err := uc.tradeRepo.Summarize(ctx, account.ID, account.SeasonStartedAt, nil)
+	if err != nil {
+		return nil, err
+	}
+	season := account.CurrentSeason(*stats)
+	return &season, nil
+}
@@ -0,0 +1,261 @@
+package signal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	orderuc "trading/internal/usecase/order"
+	positionuc "trading/internal/usecase/position"
+)
+
+// payload is the JSON body of a signal, e.g.
+// {"symbol":"BTCUSDT","action":"buy","qty":"0.01","leverage":5,"sl":49000,"tp":52000}
+type payload struct {
+	Symbol   string `json:"symbol"`
+	Action   string `json:"action"`
+	Qty      number `json:"qty"`
+	Leverage number `json:"leverage"`
+	SL       number `json:"sl"`
+	TP       number `json:"tp"`
+}
+
+// number accepts a JSON number or a numeric string. Empty strings and null
+// leave it unset, since alert placeholders often render missing values so.
+type number struct {
+	value *decimal.Decimal
+}
+
+func (n *number) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(strings.Trim(string(data), `"`))
+	if s == "" || s == "null" {
+		return nil
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return fmt.Errorf("invalid number %s", data)
+	}
+	n.value = &d
+	return nil
+}
+
+// Receive executes a signal sent to a strategy's URL and logs it with its
+// outcome. Only an unknown token is an error; a signal that cannot be
+// executed is logged as FAILED.
+func (uc *UseCase) Receive(ctx context.Context, token string, body []byte) (*domain.Signal, error) {
+	strategy, err := uc.strategyRepo.GetByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	signal := &domain.Signal{
+		StrategyID: strategy.ID,
+		Payload:    strings.ReplaceAll(strings.ToValidUTF8(string(body), "�"), "\x00", ""),
+	}
+	if err := uc.execute(ctx, strategy, body, signal); err != nil {
+		signal.Status = domain.SignalFailed
+		signal.Error = err.Error()
+		logger.Warn("signal failed", "strategy_id", strategy.ID, "error", err)
+	}
+
+	if err := uc.strategyRepo.CreateSignal(ctx, signal); err != nil {
+		return nil, err
+	}
+	return signal, nil
+}
+
+// execute maps the signal onto an order or a close, filling in the template
+func (uc *UseCase) execute(ctx context.Context, strategy *domain.SignalStrategy, body []byte, signal *domain.Signal) error {
+	if !strategy.Active {
+		signal.Status = domain.SignalSkipped
+		signal.Error = "strategy is paused"
+		return nil
+	}
+
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return fmt.Errorf("invalid payload: %w", err)
+	}
+
+	action := domain.SignalAction(strings.ToUpper(strings.TrimSpace(p.Action)))
+	switch action {
+	case domain.SignalActionBuy, domain.SignalActionSell, domain.SignalActionClose:
+		signal.Action = action
+	default:
+		return errors.New("action must be buy, sell or close")
+	}
+
+	template := strategy.Template
+	symbol := normalizeSymbol(p.Symbol)
+	if symbol == "" {
+		symbol = template.Symbol
+	}
+	if symbol == "" {
+		return errors.New("symbol is required")
+	}
+	if template.Symbol != "" && symbol != template.Symbol {
+		return fmt.Errorf("strategy only trades %s", template.Symbol)
+	}
+	instrument, ok := uc.orderUC.Instrument(symbol)
+	if !ok {
+		return domain.ErrSymbolNotSupported
+	}
+	signal.Symbol = symbol
+
+	if action == domain.SignalActionClose {
+		return uc.close(ctx, strategy, p, signal)
+	}
+	return uc.place(ctx, strategy, instrument, p, signal)
+}
+
+// place sends a market order. Opening orders without a stop loss or take
+// profit get the template's, at its percentage from the current price.
+func (uc *UseCase) place(ctx context.Context, strategy *domain.SignalStrategy, instrument domain.Instrument, p payload, signal *domain.Signal) error {
+	template := strategy.Template
+	signal.Side = domain.OrderSideBuy
+	if signal.Action == domain.SignalActionSell {
+		signal.Side = domain.OrderSideSell
+	}
+
+	quantity := p.Qty.value
+	if quantity == nil {
+		quantity = template.Quantity
+	}
+	if quantity == nil {
+		return errors.New("qty is required")
+	}
+	if template.MaxQuantity != nil && quantity.GreaterThan(*template.MaxQuantity) {
+		return fmt.Errorf("qty above the strategy's max of %s", template.MaxQuantity)
+	}
+	signal.Quantity = *quantity
+
+	input := orderuc.PlaceOrderInput{
+		AccountID:  strategy.AccountID,
+		Symbol:     signal.Symbol,
+		Side:       signal.Side,
+		Type:       domain.OrderTypeMarket,
+		Quantity:   *quantity,
+		Leverage:   template.Leverage,
+		StopLoss:   p.SL.value,
+		TakeProfit: p.TP.value,
+	}
+	if p.Leverage.value != nil {
+		if !p.Leverage.value.IsInteger() {
+			return errors.New("leverage must be a whole number")
+		}
+		input.Leverage = int(p.Leverage.value.IntPart())
+	}
+	if input.Leverage == 0 {
+		input.Leverage = 1
+	}
+
+	if !instrument.IsSpot() && ((input.StopLoss == nil && template.StopLossPct != nil) || (input.TakeProfit == nil && template.TakeProfitPct != nil)) {
+		if err := uc.applyTemplateExits(ctx, strategy, &input); err != nil {
+			return err
+		}
+	}
+
+	output, err := uc.orderUC.PlaceOrder(ctx, input)
+	if err != nil {
+		return err
+	}
+	signal.Status = domain.SignalExecuted
+	signal.OrderID = &output.Order.ID
+	if output.Trade != nil {
+		signal.Price = output.Trade.Price
+	}
+	return nil
+}
+
+// applyTemplateExits sets the template's stop loss and take profit on an order
+// that opens or adds to a position; orders that reduce one get none
+func (uc *UseCase) applyTemplateExits(ctx context.Context, strategy *domain.SignalStrategy, input *orderuc.PlaceOrderInput) error {
+	position, err := uc.positionRepo.GetOpenByAccountIDAndSymbol(ctx, strategy.AccountID, input.Symbol)
+	if err != nil && !errors.Is(err, domain.ErrPositionNotFound) {
+		return err
+	}
+	long := input.Side == domain.OrderSideBuy
+	if position != nil && position.IsShort() == long {
+		return nil
+	}
+
+	price, ok := uc.priceCache.Get(input.Symbol)
+	if !ok {
+		return domain.ErrPriceNotAvailable
+	}
+	reference := decimal.NewFromFloat(price.Ask)
+	if !long {
+		reference = decimal.NewFromFloat(price.Bid)
+	}
+
+	// distance returns the price pct percent below the reference, or above it
+	distance := func(pct decimal.Decimal, below bool) *decimal.Decimal {
+		offset := reference.Mul(pct).Div(decimal.NewFromInt(100))
+		if below {
+			offset = offset.Neg()
+		}
+		p := reference.Add(offset).Round(8)
+		return &p
+	}
+	template := strategy.Template
+	if input.StopLoss == nil && template.StopLossPct != nil {
+		input.StopLoss = distance(*template.StopLossPct, long)
+	}
+	if input.TakeProfit == nil && template.TakeProfitPct != nil {
+		input.TakeProfit = distance(*template.TakeProfitPct, !long)
+	}
+	return nil
+}
+
+// close closes the open position on the symbol, all of it unless the signal
+// has a qty
+func (uc *UseCase) close(ctx context.Context, strategy *domain.SignalStrategy, p payload, signal *domain.Signal) error {
+	position, err := uc.positionRepo.GetOpenByAccountIDAndSymbol(ctx, strategy.AccountID, signal.Symbol)
+	if errors.Is(err, domain.ErrPositionNotFound) {
+		signal.Status = domain.SignalSkipped
+		signal.Error = "no open position"
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	signal.Side = domain.OrderSideSell
+	if position.IsShort() {
+		signal.Side = domain.OrderSideBuy
+	}
+	quantity := p.Qty.value
+	if quantity != nil && quantity.GreaterThanOrEqual(position.Quantity) {
+		quantity = nil
+	}
+
+	trade, err := uc.positionUC.ClosePosition(ctx, positionuc.ClosePositionInput{
+		AccountID:  strategy.AccountID,
+		PositionID: position.ID,
+		Quantity:   quantity,
+	})
+	if err != nil {
+		return err
+	}
+	signal.Status = domain.SignalExecuted
+	signal.OrderID = &trade.OrderID
+	signal.Quantity = trade.Quantity
+	signal.Price = trade.Price
+	return nil
+}
+
+// normalizeSymbol turns charting tickers such as "BINANCE:BTCUSDT.P" into
+// instrument symbols
+func normalizeSymbol(symbol string) string {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if i := strings.LastIndex(symbol, ":"); i >= 0 {
+		symbol = symbol[i+1:]
+	}
+	return strings.TrimSuffix(symbol, ".P")
+}
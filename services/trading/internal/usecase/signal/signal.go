@@ -0,0 +1,193 @@
+package signal
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	orderuc "trading/internal/usecase/order"
+	positionuc "trading/internal/usecase/position"
+)
+
+// maxStrategiesPerAccount caps the signal strategies of an account
+const maxStrategiesPerAccount = 10
+
+type UseCase struct {
+	strategyRepo domain.SignalStrategyRepository
+	positionRepo domain.PositionRepository
+	priceCache   domain.PriceCache
+	orderUC      *orderuc.UseCase
+	positionUC   *positionuc.UseCase
+}
+
+func NewUseCase(
+	strategyRepo domain.SignalStrategyRepository,
+	positionRepo domain.PositionRepository,
+	priceCache domain.PriceCache,
+	orderUC *orderuc.UseCase,
+	positionUC *positionuc.UseCase,
+) *UseCase {
+	return &UseCase{
+		strategyRepo: strategyRepo,
+		positionRepo: positionRepo,
+		priceCache:   priceCache,
+		orderUC:      orderUC,
+		positionUC:   positionUC,
+	}
+}
+
+type CreateInput struct {
+	UserID    domain.UserID
+	AccountID domain.AccountID
+	Name      string
+	Template  domain.SignalTemplate
+}
+
+// Create adds an active strategy with a fresh token, returned in Token only
+// this once
+func (uc *UseCase) Create(ctx context.Context, input CreateInput) (*domain.SignalStrategy, error) {
+	strategy := &domain.SignalStrategy{
+		UserID:    input.UserID,
+		AccountID: input.AccountID,
+		Name:      strings.TrimSpace(input.Name),
+		Template:  input.Template,
+		Active:    true,
+	}
+	if err := uc.validate(strategy); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.strategyRepo.ListByAccountID(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxStrategiesPerAccount {
+		return nil, domain.ErrTooManySignalStrategies
+	}
+
+	if err := setToken(strategy); err != nil {
+		return nil, err
+	}
+	if err := uc.strategyRepo.Create(ctx, strategy); err != nil {
+		return nil, err
+	}
+
+	logger.Info("signal strategy created", "strategy_id", strategy.ID, "account_id", strategy.AccountID)
+	return strategy, nil
+}
+
+// Get returns a strategy of the account
+func (uc *UseCase) Get(ctx context.Context, accountID domain.AccountID, id domain.SignalStrategyID) (*domain.SignalStrategy, error) {
+	strategy, err := uc.strategyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if strategy.AccountID != accountID {
+		return nil, domain.ErrSignalStrategyNotFound
+	}
+	return strategy, nil
+}
+
+// List returns the strategies of the account, newest first
+func (uc *UseCase) List(ctx context.Context, accountID domain.AccountID) ([]domain.SignalStrategy, error) {
+	return uc.strategyRepo.ListByAccountID(ctx, accountID)
+}
+
+type UpdateInput struct {
+	Name     *string
+	Template *domain.SignalTemplate // replaces the whole template
+	Active   *bool
+}
+
+// Update changes a strategy; paused strategies log their signals as skipped
+func (uc *UseCase) Update(ctx context.Context, accountID domain.AccountID, id domain.SignalStrategyID, input UpdateInput) (*domain.SignalStrategy, error) {
+	strategy, err := uc.Get(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		strategy.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Template != nil {
+		strategy.Template = *input.Template
+	}
+	if input.Active != nil {
+		strategy.Active = *input.Active
+	}
+	if err := uc.validate(strategy); err != nil {
+		return nil, err
+	}
+
+	if err := uc.strategyRepo.Update(ctx, strategy); err != nil {
+		return nil, err
+	}
+	return strategy, nil
+}
+
+// RotateToken replaces the strategy's token; the old URL stops working at once
+func (uc *UseCase) RotateToken(ctx context.Context, accountID domain.AccountID, id domain.SignalStrategyID) (*domain.SignalStrategy, error) {
+	strategy, err := uc.Get(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := setToken(strategy); err != nil {
+		return nil, err
+	}
+	if err := uc.strategyRepo.Update(ctx, strategy); err != nil {
+		return nil, err
+	}
+
+	logger.Info("signal token rotated", "strategy_id", strategy.ID)
+	return strategy, nil
+}
+
+// Delete removes a strategy with its signal log
+func (uc *UseCase) Delete(ctx context.Context, accountID domain.AccountID, id domain.SignalStrategyID) error {
+	if _, err := uc.Get(ctx, accountID, id); err != nil {
+		return err
+	}
+	return uc.strategyRepo.Delete(ctx, id)
+}
+
+// Signals returns the strategy's signal log, newest first
+func (uc *UseCase) Signals(ctx context.Context, accountID domain.AccountID, id domain.SignalStrategyID, limit int) ([]domain.Signal, error) {
+	if _, err := uc.Get(ctx, accountID, id); err != nil {
+		return nil, err
+	}
+	return uc.strategyRepo.ListSignals(ctx, id, limit)
+}
+
+// validate checks the strategy and that its template symbol can be traded
+func (uc *UseCase) validate(strategy *domain.SignalStrategy) error {
+	strategy.Template.Symbol = strings.ToUpper(strings.TrimSpace(strategy.Template.Symbol))
+	if err := strategy.Validate(); err != nil {
+		return err
+	}
+	if strategy.Template.Symbol != "" {
+		if _, ok := uc.orderUC.Instrument(strategy.Template.Symbol); !ok {
+			return domain.ErrSymbolNotSupported
+		}
+	}
+	return nil
+}
+
+// setToken gives the strategy a new random token
+func setToken(strategy *domain.SignalStrategy) error {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+	strategy.Token = hex.EncodeToString(b)
+	strategy.TokenHash = hashToken(strategy.Token)
+	return nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
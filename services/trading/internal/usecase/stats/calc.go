@@ -0,0 +1,282 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+// tradingDaysPerYear annualizes daily ratios; crypto markets never close
+const tradingDaysPerYear = 365
+
+// PositionResult is the outcome of one closed position
+type PositionResult struct {
+	PositionID domain.PositionID
+	Symbol     string
+	Side       domain.PositionSide
+	PnL        decimal.Decimal // realized PnL of all closing trades, net of all fees
+	Fees       decimal.Decimal
+	Liquidated bool
+	OpenedAt   time.Time
+	ClosedAt   time.Time
+}
+
+// Performance summarizes a set of position results
+type Performance struct {
+	Positions      int
+	Wins           int
+	Losses         int
+	Liquidations   int
+	WinRate        decimal.Decimal
+	NetPnL         decimal.Decimal
+	GrossProfit    decimal.Decimal
+	GrossLoss      decimal.Decimal // negative
+	Fees           decimal.Decimal
+	ProfitFactor   *decimal.Decimal // gross profit / |gross loss|, nil without losses
+	AvgWin         decimal.Decimal
+	AvgLoss        decimal.Decimal // negative
+	LargestWin     decimal.Decimal
+	LargestLoss    decimal.Decimal // negative
+	Expectancy     decimal.Decimal // average PnL per position
+	AvgHoldingTime time.Duration
+}
+
+// Breakdown is the performance of a subset of positions
+type Breakdown struct {
+	Key string
+	Performance
+}
+
+// Stats is the full performance report of an account
+type Stats struct {
+	From *time.Time
+	To   *time.Time
+	Performance
+
+	// MaxDrawdown is the largest fall of cumulative PnL from its peak;
+	// the percent is relative to the starting balance plus that peak
+	MaxDrawdown        decimal.Decimal
+	MaxDrawdownPercent decimal.Decimal
+
+	// Annualized from daily returns; nil with fewer than two days or no variance
+	Sharpe  *decimal.Decimal
+	Sortino *decimal.Decimal
+
+	LongestWinStreak  int
+	LongestLossStreak int
+
+	BySymbol []Breakdown
+	BySide   []Breakdown
+}
+
+// Calculate builds the report from results ordered by close time.
+// startingBalance is the equity the returns and drawdown are measured against.
+func Calculate(results []PositionResult, startingBalance decimal.Decimal) *Stats {
+	stats := &Stats{
+		Performance: summarize(results),
+		BySymbol:    breakdown(results, func(r PositionResult) string { return r.Symbol }),
+		BySide:      breakdown(results, func(r PositionResult) string { return string(r.Side) }),
+	}
+	stats.MaxDrawdown, stats.MaxDrawdownPercent = maxDrawdown(results, startingBalance)
+	stats.Sharpe, stats.Sortino = riskRatios(dailyReturns(results, startingBalance))
+	stats.LongestWinStreak, stats.LongestLossStreak = streaks(results)
+	return stats
+}
+
+func summarize(results []PositionResult) Performance {
+	p := Performance{
+		Positions:   len(results),
+		WinRate:     decimal.Zero,
+		NetPnL:      decimal.Zero,
+		GrossProfit: decimal.Zero,
+		GrossLoss:   decimal.Zero,
+		Fees:        decimal.Zero,
+		AvgWin:      decimal.Zero,
+		AvgLoss:     decimal.Zero,
+		LargestWin:  decimal.Zero,
+		LargestLoss: decimal.Zero,
+		Expectancy:  decimal.Zero,
+	}
+	if len(results) == 0 {
+		return p
+	}
+
+	var holding time.Duration
+	for _, r := range results {
+		p.NetPnL = p.NetPnL.Add(r.PnL)
+		p.Fees = p.Fees.Add(r.Fees)
+		holding += r.ClosedAt.Sub(r.OpenedAt)
+		if r.Liquidated {
+			p.Liquidations++
+		}
+
+		if r.PnL.IsPositive() {
+			p.Wins++
+			p.GrossProfit = p.GrossProfit.Add(r.PnL)
+			if r.PnL.GreaterThan(p.LargestWin) {
+				p.LargestWin = r.PnL
+			}
+		} else if r.PnL.IsNegative() {
+			p.Losses++
+			p.GrossLoss = p.GrossLoss.Add(r.PnL)
+			if r.PnL.LessThan(p.LargestLoss) {
+				p.LargestLoss = r.PnL
+			}
+		}
+	}
+
+	count := decimal.NewFromInt(int64(len(results)))
+	p.WinRate = decimal.NewFromInt(int64(p.Wins)).Div(count)
+	p.Expectancy = p.NetPnL.Div(count)
+	p.AvgHoldingTime = holding / time.Duration(len(results))
+
+	if p.Wins > 0 {
+		p.AvgWin = p.GrossProfit.Div(decimal.NewFromInt(int64(p.Wins)))
+	}
+	if p.Losses > 0 {
+		p.AvgLoss = p.GrossLoss.Div(decimal.NewFromInt(int64(p.Losses)))
+		profitFactor := p.GrossProfit.Div(p.GrossLoss.Abs())
+		p.ProfitFactor = &profitFactor
+	}
+
+	return p
+}
+
+// breakdown groups results by key, sorted by key
+func breakdown(results []PositionResult, key func(PositionResult) string) []Breakdown {
+	groups := make(map[string][]PositionResult)
+	for _, r := range results {
+		k := key(r)
+		groups[k] = append(groups[k], r)
+	}
+
+	breakdowns := make([]Breakdown, 0, len(groups))
+	for k, group := range groups {
+		breakdowns = append(breakdowns, Breakdown{Key: k, Performance: summarize(group)})
+	}
+	sort.Slice(breakdowns, func(i, j int) bool { return breakdowns[i].Key < breakdowns[j].Key })
+	return breakdowns
+}
+
+func maxDrawdown(results []PositionResult, startingBalance decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	maxDD := decimal.Zero
+	maxPercent := decimal.Zero
+
+	cumulative := decimal.Zero
+	peak := decimal.Zero
+	for _, r := range results {
+		cumulative = cumulative.Add(r.PnL)
+		if cumulative.GreaterThan(peak) {
+			peak = cumulative
+		}
+
+		dd := peak.Sub(cumulative)
+		if dd.GreaterThan(maxDD) {
+			maxDD = dd
+		}
+		if peakEquity := startingBalance.Add(peak); peakEquity.IsPositive() {
+			if percent := dd.Div(peakEquity); percent.GreaterThan(maxPercent) {
+				maxPercent = percent
+			}
+		}
+	}
+
+	return maxDD, maxPercent
+}
+
+// dailyReturns returns the PnL of each UTC day from the first close to the
+// last, relative to the equity at the start of that day. Days without closes
+// count as zero returns.
+func dailyReturns(results []PositionResult, startingBalance decimal.Decimal) []float64 {
+	if len(results) == 0 {
+		return nil
+	}
+
+	const day = 24 * time.Hour
+	first := results[0].ClosedAt.UTC().Truncate(day)
+	last := results[len(results)-1].ClosedAt.UTC().Truncate(day)
+
+	pnlByDay := make(map[time.Time]decimal.Decimal)
+	for _, r := range results {
+		d := r.ClosedAt.UTC().Truncate(day)
+		pnlByDay[d] = pnlByDay[d].Add(r.PnL)
+	}
+
+	var returns []float64
+	equity := startingBalance
+	for d := first; !d.After(last); d = d.Add(day) {
+		pnl := pnlByDay[d]
+		if equity.IsPositive() {
+			returns = append(returns, pnl.Div(equity).InexactFloat64())
+		} else {
+			returns = append(returns, 0)
+		}
+		equity = equity.Add(pnl)
+	}
+	return returns
+}
+
+// riskRatios returns the annualized Sharpe and Sortino ratios (risk-free rate 0)
+func riskRatios(returns []float64) (*decimal.Decimal, *decimal.Decimal) {
+	n := float64(len(returns))
+	if n < 2 {
+		return nil, nil
+	}
+
+	var sum float64
+	for _, r := range returns {
+		sum += r
+	}
+	mean := sum / n
+
+	var variance, downside float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+		if r < 0 {
+			downside += r * r
+		}
+	}
+	stdDev := math.Sqrt(variance / (n - 1))
+	downsideDev := math.Sqrt(downside / n)
+	annualize := math.Sqrt(tradingDaysPerYear)
+
+	var sharpe, sortino *decimal.Decimal
+	if stdDev > 0 {
+		v := decimal.NewFromFloat(mean / stdDev * annualize)
+		sharpe = &v
+	}
+	if downsideDev > 0 {
+		v := decimal.NewFromFloat(mean / downsideDev * annualize)
+		sortino = &v
+	}
+	return sharpe, sortino
+}
+
+// streaks returns the longest runs of consecutive wins and losses; breakeven
+// positions end both
+func streaks(results []PositionResult) (int, int) {
+	var longestWin, longestLoss, wins, losses int
+	for _, r := range results {
+		switch {
+		case r.PnL.IsPositive():
+			wins++
+			losses = 0
+		case r.PnL.IsNegative():
+			losses++
+			wins = 0
+		default:
+			wins, losses = 0, 0
+		}
+		if wins > longestWin {
+			longestWin = wins
+		}
+		if losses > longestLoss {
+			longestLoss = losses
+		}
+	}
+	return longestWin, longestLoss
+}
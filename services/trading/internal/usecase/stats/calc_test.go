@@ -0,0 +1,243 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"trading/internal/domain"
+)
+
+var day0 = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// closedOn builds results closed on consecutive hours of the given days after day0
+func closedOn(days []int, pnls ...string) []PositionResult {
+	results := make([]PositionResult, len(pnls))
+	for i, pnl := range pnls {
+		closedAt := day0.AddDate(0, 0, days[i]).Add(time.Duration(i) * time.Minute)
+		results[i] = PositionResult{
+			PositionID: domain.PositionID(i + 1),
+			Symbol:     "BTCUSDT",
+			Side:       domain.PositionSideLong,
+			PnL:        decimal.RequireFromString(pnl),
+			OpenedAt:   closedAt.Add(-time.Hour),
+			ClosedAt:   closedAt,
+		}
+	}
+	return results
+}
+
+func sameDay(pnls ...string) []PositionResult {
+	return closedOn(make([]int, len(pnls)), pnls...)
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	tests := []struct {
+		name        string
+		results     []PositionResult
+		start       string
+		wantDD      string
+		wantPercent decimal.Decimal
+	}{
+		{
+			name:        "empty series",
+			start:       "1000",
+			wantDD:      "0",
+			wantPercent: decimal.Zero,
+		},
+		{
+			name:        "only gains",
+			results:     sameDay("100", "50"),
+			start:       "1000",
+			wantDD:      "0",
+			wantPercent: decimal.Zero,
+		},
+		{
+			name:        "largest fall after a new peak",
+			results:     sameDay("100", "-50", "-30", "200", "-100"),
+			start:       "1000",
+			wantDD:      "100",
+			wantPercent: decimal.NewFromInt(100).Div(decimal.NewFromInt(1220)),
+		},
+		{
+			name:        "losses from the start",
+			results:     sameDay("-100", "-100", "50"),
+			start:       "1000",
+			wantDD:      "200",
+			wantPercent: decimal.RequireFromString("0.2"),
+		},
+		{
+			name:        "no starting balance has no percent",
+			results:     sameDay("-100"),
+			start:       "0",
+			wantDD:      "100",
+			wantPercent: decimal.Zero,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dd, percent := maxDrawdown(tt.results, decimal.RequireFromString(tt.start))
+			assert.True(t, decimal.RequireFromString(tt.wantDD).Equal(dd), "drawdown %s", dd)
+			assert.True(t, tt.wantPercent.Equal(percent), "percent %s", percent)
+		})
+	}
+}
+
+func TestDailyReturns(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []PositionResult
+		start   string
+		want    []float64
+	}{
+		{
+			name:  "empty series",
+			start: "1000",
+		},
+		{
+			name:    "closes on one day are summed",
+			results: sameDay("30", "-10"),
+			start:   "1000",
+			want:    []float64{0.02},
+		},
+		{
+			name:    "days without closes are zero returns",
+			results: closedOn([]int{0, 2}, "100", "-55"),
+			start:   "1000",
+			want:    []float64{0.1, 0, -0.05},
+		},
+		{
+			name:    "no equity gives zero returns",
+			results: closedOn([]int{0, 1}, "-100", "50"),
+			start:   "0",
+			want:    []float64{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dailyReturns(tt.results, decimal.RequireFromString(tt.start))
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.InDelta(t, tt.want[i], got[i], 1e-12, "day %d", i)
+			}
+		})
+	}
+}
+
+func TestRiskRatios(t *testing.T) {
+	tests := []struct {
+		name        string
+		returns     []float64
+		wantSharpe  *float64
+		wantSortino *float64
+	}{
+		{name: "empty series"},
+		{name: "single day", returns: []float64{0.1}},
+		{name: "no variance and no downside", returns: []float64{0.01, 0.01}},
+		{
+			name:        "mixed returns",
+			returns:     []float64{0.1, 0, -0.05},
+			wantSharpe:  ptr(4.16904693916396),
+			wantSortino: ptr(11.030261405182863),
+		},
+		{
+			name:        "zero mean",
+			returns:     []float64{0.01, -0.01},
+			wantSharpe:  ptr(0),
+			wantSortino: ptr(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sharpe, sortino := riskRatios(tt.returns)
+			assertRatio(t, "sharpe", tt.wantSharpe, sharpe)
+			assertRatio(t, "sortino", tt.wantSortino, sortino)
+		})
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []PositionResult
+		wantWin  int
+		wantLoss int
+	}{
+		{name: "empty series"},
+		{name: "breakeven ends both streaks", results: sameDay("1", "1", "0", "1", "-1", "-1", "-1", "1"), wantWin: 2, wantLoss: 3},
+		{name: "only wins", results: sameDay("5", "5", "5"), wantWin: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			win, loss := streaks(tt.results)
+			assert.Equal(t, tt.wantWin, win)
+			assert.Equal(t, tt.wantLoss, loss)
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	t.Run("empty series", func(t *testing.T) {
+		stats := Calculate(nil, decimal.NewFromInt(1000))
+
+		assert.Zero(t, stats.Positions)
+		assert.True(t, stats.NetPnL.IsZero())
+		assert.True(t, stats.WinRate.IsZero())
+		assert.Nil(t, stats.ProfitFactor)
+		assert.True(t, stats.MaxDrawdown.IsZero())
+		assert.Nil(t, stats.Sharpe)
+		assert.Nil(t, stats.Sortino)
+		assert.Empty(t, stats.BySymbol)
+		assert.Empty(t, stats.BySide)
+	})
+
+	t.Run("mixed results", func(t *testing.T) {
+		results := closedOn([]int{0, 1, 2}, "100", "-50", "30")
+		results[1].Symbol = "ETHUSDT"
+		results[1].Side = domain.PositionSideShort
+		results[1].Liquidated = true
+
+		stats := Calculate(results, decimal.NewFromInt(1000))
+
+		assert.Equal(t, 3, stats.Positions)
+		assert.Equal(t, 2, stats.Wins)
+		assert.Equal(t, 1, stats.Losses)
+		assert.Equal(t, 1, stats.Liquidations)
+		assert.True(t, decimal.NewFromInt(80).Equal(stats.NetPnL))
+		assert.True(t, decimal.NewFromInt(65).Equal(stats.AvgWin))
+		assert.True(t, decimal.NewFromInt(-50).Equal(stats.LargestLoss))
+		require.NotNil(t, stats.ProfitFactor)
+		assert.True(t, decimal.RequireFromString("2.6").Equal(*stats.ProfitFactor))
+		assert.True(t, decimal.NewFromInt(50).Equal(stats.MaxDrawdown))
+		assert.Equal(t, time.Hour, stats.AvgHoldingTime)
+		assert.NotNil(t, stats.Sharpe)
+		assert.NotNil(t, stats.Sortino)
+
+		require.Len(t, stats.BySymbol, 2)
+		assert.Equal(t, "BTCUSDT", stats.BySymbol[0].Key)
+		assert.Equal(t, 2, stats.BySymbol[0].Positions)
+		require.Len(t, stats.BySide, 2)
+		assert.Equal(t, string(domain.PositionSideLong), stats.BySide[0].Key)
+	})
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func assertRatio(t *testing.T, name string, want *float64, got *decimal.Decimal) {
+	t.Helper()
+	if want == nil {
+		assert.Nil(t, got, name)
+		return
+	}
+	require.NotNil(t, got, name)
+	assert.InDelta(t, *want, got.InexactFloat64(), 1e-9, name)
+}
@@ -0,0 +1,129 @@
+package stats
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"trading/internal/domain"
+)
+
+type UseCase struct {
+	accountRepo  domain.AccountRepository
+	seasonRepo   domain.AccountSeasonRepository
+	positionRepo domain.PositionRepository
+	tradeRepo    domain.TradeRepository
+}
+
+func NewUseCase(
+	accountRepo domain.AccountRepository,
+	seasonRepo domain.AccountSeasonRepository,
+	positionRepo domain.PositionRepository,
+	tradeRepo domain.TradeRepository,
+) *UseCase {
+	return &UseCase{
+		accountRepo:  accountRepo,
+		seasonRepo:   seasonRepo,
+		positionRepo: positionRepo,
+		tradeRepo:    tradeRepo,
+	}
+}
+
+type Input struct {
+	From *time.Time // by close time, inclusive
+	To   *time.Time // exclusive
+}
+
+// GetStats computes performance statistics over the positions closed in the range
+func (uc *UseCase) GetStats(ctx context.Context, accountID domain.AccountID, input Input) (*Stats, error) {
+	if input.From != nil && input.To != nil && !input.From.Before(*input.To) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := uc.positionRepo.GetClosedByAccountID(ctx, accountID, input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]domain.PositionID, len(positions))
+	for i, p := range positions {
+		ids[i] = p.ID
+	}
+	trades, err := uc.tradeRepo.GetByPositionIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	startingBalance, err := uc.startingBalance(ctx, account, input.From)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := Calculate(newResults(positions, trades), startingBalance)
+	stats.From = input.From
+	stats.To = input.To
+	return stats, nil
+}
+
+// startingBalance returns the starting balance of the season the range begins in,
+// the account's first season for a range without a start
+func (uc *UseCase) startingBalance(ctx context.Context, account *domain.Account, from *time.Time) (decimal.Decimal, error) {
+	if from != nil && !from.Before(account.SeasonStartedAt) {
+		return account.SeasonStartingBalance, nil
+	}
+
+	// Archived seasons come newest first; the last one still running at from contains it
+	seasons, err := uc.seasonRepo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	balance := account.SeasonStartingBalance
+	for _, s := range seasons {
+		if from != nil && s.EndedAt != nil && !from.Before(*s.EndedAt) {
+			break
+		}
+		balance = s.StartingBalance
+	}
+	return balance, nil
+}
+
+// newResults nets each position's trades into a single result: the PnL of its
+// closes, partial ones included, less the fees of every trade that opened, added
+// to or closed it. Positions without a close time are skipped.
+func newResults(positions []domain.Position, trades []domain.Trade) []PositionResult {
+	byPosition := make(map[domain.PositionID]*PositionResult, len(positions))
+	results := make([]PositionResult, 0, len(positions))
+	for _, p := range positions {
+		if p.ClosedAt == nil {
+			continue
+		}
+		results = append(results, PositionResult{
+			PositionID: p.ID,
+			Symbol:     p.Symbol,
+			Side:       p.Side,
+			Liquidated: p.Status == domain.PositionStatusLiquidated,
+			OpenedAt:   p.CreatedAt,
+			ClosedAt:   *p.ClosedAt,
+		})
+	}
+	for i := range results {
+		byPosition[results[i].PositionID] = &results[i]
+	}
+
+	for _, t := range trades {
+		result, ok := byPosition[t.PositionID]
+		if !ok {
+			continue
+		}
+		result.PnL = result.PnL.Add(t.PnL).Sub(t.Fee)
+		result.Fees = result.Fees.Add(t.Fee)
+	}
+
+	return results
+}
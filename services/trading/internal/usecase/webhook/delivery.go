@@ -0,0 +1,219 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"trading/internal/domain"
+	"trading/internal/logger"
+	"trading/internal/metrics"
+)
+
+const (
+	dueBatchSize       = 50
+	maxConcurrentSends = 8
+	pollInterval       = time.Second
+	maxBackoff         = time.Hour
+	maxErrorLength     = 500
+
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+type sender struct {
+	client      *http.Client
+	maxAttempts int
+	retryBase   time.Duration
+}
+
+func newSender(timeout time.Duration, maxAttempts int, retryBase time.Duration, allowPrivate bool) *sender {
+	return &sender{
+		client:      newClient(timeout, allowPrivate),
+		maxAttempts: maxAttempts,
+		retryBase:   retryBase,
+	}
+}
+
+// Sign returns the hex HMAC-SHA256 of "timestamp.payload" keyed by the webhook secret.
+// Receivers recompute it to verify the X-Webhook-Signature header ("sha256=<hex>").
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Start runs the delivery worker until the context is cancelled
+func (uc *UseCase) Start(ctx context.Context) {
+	logger.Info("webhook worker started")
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("webhook worker stopping")
+			return
+		case <-ticker.C:
+		case <-uc.wake:
+		}
+
+		if _, err := uc.DeliverDue(ctx); err != nil {
+			logger.Error("failed to deliver webhooks", "error", err)
+		}
+	}
+}
+
+// DeliverDue sends a batch of pending deliveries whose attempt is due and
+// returns how many were attempted
+func (uc *UseCase) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := uc.deliveryRepo.GetDue(ctx, dueBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Webhooks are loaded once per batch
+	webhooks := make(map[domain.WebhookID]*domain.Webhook)
+	for _, d := range deliveries {
+		if _, ok := webhooks[d.WebhookID]; ok {
+			continue
+		}
+		webhook, err := uc.webhookRepo.GetByID(ctx, d.WebhookID)
+		if err != nil && !errors.Is(err, domain.ErrWebhookNotFound) {
+			return 0, err
+		}
+		webhooks[d.WebhookID] = webhook // nil if deleted meanwhile, its deliveries go with it
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentSends)
+	attempted := 0
+	for i := range deliveries {
+		webhook := webhooks[deliveries[i].WebhookID]
+		if webhook == nil {
+			continue
+		}
+		attempted++
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(d *domain.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			uc.attempt(ctx, webhook, d)
+		}(&deliveries[i])
+	}
+	wg.Wait()
+
+	return attempted, nil
+}
+
+// attempt sends one delivery and records the outcome. Failed attempts are
+// retried with exponential backoff until the attempts run out.
+func (uc *UseCase) attempt(ctx context.Context, webhook *domain.Webhook, d *domain.WebhookDelivery) {
+	now := time.Now()
+	d.Attempts++
+
+	var statusCode int
+	var err error
+	if webhook.Active {
+		statusCode, err = uc.sender.send(ctx, webhook, d)
+	} else {
+		err = errors.New("webhook disabled")
+		d.Attempts = uc.sender.maxAttempts
+	}
+	d.LastStatusCode = statusCode
+
+	result := "success"
+	if err == nil {
+		d.Status = domain.WebhookDeliveryStatusSucceeded
+		d.DeliveredAt = &now
+		d.NextAttemptAt = nil
+		d.LastError = ""
+	} else {
+		d.LastError = err.Error()
+		if len(d.LastError) > maxErrorLength {
+			d.LastError = d.LastError[:maxErrorLength]
+		}
+		if d.Attempts >= uc.sender.maxAttempts {
+			result = "failed"
+			d.Status = domain.WebhookDeliveryStatusFailed
+			d.NextAttemptAt = nil
+		} else {
+			result = "retry"
+			next := now.Add(uc.sender.backoff(d.Attempts))
+			d.NextAttemptAt = &next
+		}
+	}
+
+	metrics.RecordWebhookDelivery(string(d.EventType), result)
+
+	if err := uc.deliveryRepo.Update(ctx, d); err != nil {
+		logger.Error("failed to update webhook delivery", "delivery_id", d.ID, "error", err)
+		return
+	}
+
+	if result != "success" {
+		logger.Warn("webhook delivery failed",
+			"delivery_id", d.ID,
+			"webhook_id", d.WebhookID,
+			"attempts", d.Attempts,
+			"status_code", statusCode,
+			"error", d.LastError,
+		)
+	}
+}
+
+// backoff returns the delay before the next attempt: base, 2×base, 4×base, ...
+func (s *sender) backoff(attempts int) time.Duration {
+	delay := s.retryBase
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
+
+// send POSTs the signed payload; any non-2xx response is an error
+func (s *sender) send(ctx context.Context, webhook *domain.Webhook, d *domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "trading-simulator-webhooks")
+	req.Header.Set(EventHeader, string(d.EventType))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(int64(d.ID), 10))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(webhook.Secret, timestamp, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
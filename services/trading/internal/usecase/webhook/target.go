@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"trading/internal/domain"
+)
+
+// errPrivateAddress is returned when a delivery would connect to a non-public address
+var errPrivateAddress = errors.New("webhook target is not a public address")
+
+// privatePrefixes are the ranges webhooks may not reach: this host, private,
+// shared, link-local, multicast, reserved and documentation networks, and the
+// IPv6 forms that embed an IPv4 address or reach one through a translator
+var privatePrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // this network
+	netip.MustParsePrefix("10.0.0.0/8"),      // private
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+	netip.MustParsePrefix("169.254.0.0/16"),  // link-local
+	netip.MustParsePrefix("172.16.0.0/12"),   // private
+	netip.MustParsePrefix("192.0.0.0/24"),    // protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("192.168.0.0/16"),  // private
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved and broadcast
+
+	netip.MustParsePrefix("::/96"),          // unspecified, loopback and IPv4-compatible
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use NAT64
+	netip.MustParsePrefix("100::/64"),       // discard
+	netip.MustParsePrefix("2001:db8::/32"),  // documentation
+	netip.MustParsePrefix("2002::/16"),      // 6to4
+	netip.MustParsePrefix("fc00::/7"),       // unique local
+	netip.MustParsePrefix("fe80::/10"),      // link-local
+	netip.MustParsePrefix("ff00::/8"),       // multicast
+}
+
+// isPrivate reports whether addr is in a range webhooks may not reach, or
+// users could probe the simulator's own network. IPv4-mapped IPv6 addresses
+// are checked as the IPv4 address they carry.
+func isPrivate(addr netip.Addr) bool {
+	if !addr.IsValid() {
+		return true
+	}
+	addr = addr.Unmap().WithZone("")
+	for _, prefix := range privatePrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateURL checks the URL is http(s) and, unless private targets are allowed,
+// that every address its host resolves to is public
+func (uc *UseCase) validateURL(ctx context.Context, raw string) error {
+	if raw == "" || len(raw) > maxURLLength {
+		return domain.ErrInvalidWebhook
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return domain.ErrInvalidWebhook
+	}
+	if uc.allowPrivate {
+		return nil
+	}
+
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil {
+		if isPrivate(addr) {
+			return domain.ErrInvalidWebhook
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return domain.ErrInvalidWebhook
+	}
+	for _, addr := range addrs {
+		if isPrivate(addr) {
+			return domain.ErrInvalidWebhook
+		}
+	}
+	return nil
+}
+
+// newClient returns the delivery client. Redirects are not followed, and unless
+// private targets are allowed every connection is checked again when it is dialed,
+// so a host re-pointed to a private address after validation is still refused.
+func newClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if addr, err := netip.ParseAddr(host); err != nil || isPrivate(addr) {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        maxConcurrentSends,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
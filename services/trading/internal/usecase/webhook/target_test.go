@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"trading/internal/domain"
+)
+
+func TestIsPrivate(t *testing.T) {
+	tests := []struct {
+		addr    string
+		private bool
+	}{
+		{"8.8.8.8", false},
+		{"1.1.1.1", false},
+		{"2606:4700:4700::1111", false},
+		{"0.0.0.0", true},
+		{"0.1.2.3", true},
+		{"10.0.0.1", true},
+		{"100.64.0.1", true},
+		{"100.127.255.255", true},
+		{"100.128.0.1", false},
+		{"127.0.0.1", true},
+		{"169.254.169.254", true},
+		{"172.16.0.1", true},
+		{"172.32.0.1", false},
+		{"192.0.0.1", true},
+		{"192.168.1.1", true},
+		{"198.18.0.1", true},
+		{"224.0.0.1", true},
+		{"255.255.255.255", true},
+		{"::", true},
+		{"::1", true},
+		{"::127.0.0.1", true},
+		{"::ffff:127.0.0.1", true},
+		{"::ffff:10.0.0.1", true},
+		{"::ffff:8.8.8.8", false},
+		{"64:ff9b::a00:1", true},
+		{"64:ff9b::808:808", true},
+		{"64:ff9b:1::1", true},
+		{"2002:7f00:1::", true},
+		{"fc00::1", true},
+		{"fd12:3456::1", true},
+		{"fe80::1", true},
+		{"fe80::1%eth0", true},
+		{"ff02::1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.private, isPrivate(netip.MustParseAddr(tt.addr)))
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.True(t, isPrivate(netip.Addr{}))
+	})
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"public ipv4", "https://8.8.8.8/hook", false},
+		{"public ipv4 with port", "http://8.8.8.8:8080/hook", false},
+		{"public ipv6", "https://[2606:4700:4700::1111]/hook", false},
+		{"empty", "", true},
+		{"too long", "https://8.8.8.8/" + strings.Repeat("a", maxURLLength), true},
+		{"unsupported scheme", "ftp://8.8.8.8/hook", true},
+		{"no host", "https:///hook", true},
+		{"loopback", "http://127.0.0.1/hook", true},
+		{"this network", "http://0.0.0.0/hook", true},
+		{"carrier-grade nat", "http://100.64.1.1/hook", true},
+		{"ipv6 loopback", "http://[::1]/hook", true},
+		{"ipv4-mapped loopback", "http://[::ffff:127.0.0.1]/hook", true},
+		{"nat64", "http://[64:ff9b::a9fe:a9fe]/hook", true},
+		{"localhost", "http://localhost/hook", true},
+	}
+
+	uc := &UseCase{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := uc.validateURL(context.Background(), tt.url)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidWebhook)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("private allowed", func(t *testing.T) {
+		uc := &UseCase{allowPrivate: true}
+		assert.NoError(t, uc.validateURL(context.Background(), "http://127.0.0.1/hook"))
+	})
+}
@@ -0,0 +1,348 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"trading/internal/delivery/ws"
+	"trading/internal/domain"
+	"trading/internal/logger"
+)
+
+const (
+	maxWebhooksPerUser = 10
+	maxURLLength       = 2048
+)
+
+type UseCase struct {
+	webhookRepo  domain.WebhookRepository
+	deliveryRepo domain.WebhookDeliveryRepository
+	sender       *sender
+	allowPrivate bool // deliver to loopback and private networks, for local setups
+
+	// wake signals the delivery worker that new deliveries are queued
+	wake chan struct{}
+}
+
+func NewUseCase(
+	webhookRepo domain.WebhookRepository,
+	deliveryRepo domain.WebhookDeliveryRepository,
+	timeout time.Duration,
+	maxAttempts int,
+	retryBase time.Duration,
+	allowPrivate bool,
+) *UseCase {
+	return &UseCase{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       newSender(timeout, maxAttempts, retryBase, allowPrivate),
+		allowPrivate: allowPrivate,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Payload is the JSON body POSTed to webhook endpoints
+type Payload struct {
+	Event     domain.EventType   `json:"event"`
+	UserID    int64              `json:"user_id"`
+	AccountID int64              `json:"account_id"`
+	Trade     *domain.TradeEvent `json:"trade,omitempty"`
+	Position  *PositionData      `json:"position,omitempty"`
+	Order     *OrderData         `json:"order,omitempty"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// OrderData is the order of a fill or rejection
+type OrderData struct {
+	ID       int64  `json:"id"`
+	Symbol   string `json:"symbol"`
+	Side     string `json:"side"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	Quantity string `json:"quantity"`
+	Price    string `json:"price"`
+}
+
+// PositionData extends the websocket position update with its lifecycle fields
+type PositionData struct {
+	ws.PositionUpdate
+	Status           string `json:"status"`
+	LiquidationPrice string `json:"liquidation_price"`
+	RealizedPnL      string `json:"realized_pnl"`
+}
+
+type CreateWebhookInput struct {
+	UserID domain.UserID
+	URL    string
+	Events []domain.EventType // empty = all events
+}
+
+type UpdateWebhookInput struct {
+	URL    *string
+	Events []domain.EventType // nil = unchanged
+	Active *bool
+}
+
+func (uc *UseCase) CreateWebhook(ctx context.Context, input CreateWebhookInput) (*domain.Webhook, error) {
+	if err := uc.validateURL(ctx, input.URL); err != nil {
+		return nil, err
+	}
+	events, err := normalizeEvents(input.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.webhookRepo.GetByUserID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxWebhooksPerUser {
+		return nil, domain.ErrTooManyWebhooks
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &domain.Webhook{
+		UserID: input.UserID,
+		URL:    input.URL,
+		Secret: secret,
+		Events: events,
+		Active: true,
+	}
+	if err := uc.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	logger.Info("webhook created", "webhook_id", webhook.ID, "user_id", webhook.UserID)
+
+	return webhook, nil
+}
+
+func (uc *UseCase) GetWebhook(ctx context.Context, userID domain.UserID, webhookID domain.WebhookID) (*domain.Webhook, error) {
+	webhook, err := uc.webhookRepo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership
+	if webhook.UserID != userID {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+func (uc *UseCase) GetWebhooks(ctx context.Context, userID domain.UserID) ([]domain.Webhook, error) {
+	return uc.webhookRepo.GetByUserID(ctx, userID)
+}
+
+func (uc *UseCase) UpdateWebhook(ctx context.Context, userID domain.UserID, webhookID domain.WebhookID, input UpdateWebhookInput) (*domain.Webhook, error) {
+	webhook, err := uc.GetWebhook(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		if err := uc.validateURL(ctx, *input.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *input.URL
+	}
+	if input.Events != nil {
+		events, err := normalizeEvents(input.Events)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Events = events
+	}
+	if input.Active != nil {
+		webhook.Active = *input.Active
+	}
+
+	if err := uc.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (uc *UseCase) DeleteWebhook(ctx context.Context, userID domain.UserID, webhookID domain.WebhookID) error {
+	if _, err := uc.GetWebhook(ctx, userID, webhookID); err != nil {
+		return err
+	}
+	return uc.webhookRepo.Delete(ctx, webhookID)
+}
+
+// GetDeliveries returns the webhook's delivery log, newest first
+func (uc *UseCase) GetDeliveries(ctx context.Context, userID domain.UserID, webhookID domain.WebhookID, limit, offset int) ([]domain.WebhookDelivery, error) {
+	if _, err := uc.GetWebhook(ctx, userID, webhookID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return uc.deliveryRepo.GetByWebhookID(ctx, webhookID, limit, offset)
+}
+
+// Redeliver queues a new delivery with the payload of an earlier one. The
+// original stays in the log unchanged.
+func (uc *UseCase) Redeliver(
+	ctx context.Context,
+	userID domain.UserID,
+	webhookID domain.WebhookID,
+	deliveryID domain.WebhookDeliveryID,
+) (*domain.WebhookDelivery, error) {
+	if _, err := uc.GetWebhook(ctx, userID, webhookID); err != nil {
+		return nil, err
+	}
+
+	original, err := uc.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.WebhookID != webhookID {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+
+	now := time.Now()
+	delivery := &domain.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        domain.WebhookDeliveryStatusPending,
+		NextAttemptAt: &now,
+		RedeliveryOf:  &original.ID,
+	}
+	if err := uc.deliveryRepo.Create(ctx, delivery); err != nil {
+		return nil, err
+	}
+	uc.notify()
+
+	return delivery, nil
+}
+
+// Publish queues a delivery of the event for each of the user's subscribed
+// webhooks. Implements domain.EventPublisher.
+func (uc *UseCase) Publish(ctx context.Context, event domain.AccountEvent) {
+	webhooks, err := uc.webhookRepo.GetActiveByUserID(ctx, event.UserID)
+	if err != nil {
+		logger.Error("failed to get webhooks", "user_id", event.UserID, "error", err)
+		return
+	}
+
+	var payload []byte
+	for i := range webhooks {
+		webhook := &webhooks[i]
+		if !webhook.Subscribes(event.Type) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(newPayload(event))
+			if err != nil {
+				logger.Error("failed to marshal webhook payload", "event", event.Type, "error", err)
+				return
+			}
+		}
+
+		now := time.Now()
+		delivery := &domain.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        domain.WebhookDeliveryStatusPending,
+			NextAttemptAt: &now,
+		}
+		if err := uc.deliveryRepo.Create(ctx, delivery); err != nil {
+			logger.Error("failed to queue webhook delivery", "webhook_id", webhook.ID, "error", err)
+			continue
+		}
+	}
+
+	if payload != nil {
+		uc.notify()
+	}
+}
+
+func (uc *UseCase) notify() {
+	select {
+	case uc.wake <- struct{}{}:
+	default:
+	}
+}
+
+func newPayload(event domain.AccountEvent) Payload {
+	timestamp := event.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	payload := Payload{
+		Event:     event.Type,
+		UserID:    int64(event.UserID),
+		AccountID: int64(event.AccountID),
+		Timestamp: timestamp.UTC(),
+	}
+	if event.Trade != nil {
+		trade := event.Trade.ToEvent()
+		payload.Trade = &trade
+	}
+	if event.Position != nil {
+		payload.Position = &PositionData{
+			PositionUpdate:   ws.NewPositionUpdate(event.Position),
+			Status:           string(event.Position.Status),
+			LiquidationPrice: event.Position.LiquidationPrice.String(),
+			RealizedPnL:      event.Position.RealizedPnL.String(),
+		}
+	}
+	if event.Order != nil {
+		payload.Order = &OrderData{
+			ID:       int64(event.Order.ID),
+			Symbol:   event.Order.Symbol,
+			Side:     string(event.Order.Side),
+			Type:     string(event.Order.Type),
+			Status:   string(event.Order.Status),
+			Quantity: event.Order.Quantity.String(),
+			Price:    event.Order.Price.String(),
+		}
+	}
+	return payload
+}
+
+// normalizeEvents validates the filter and removes duplicates; empty means all events
+func normalizeEvents(events []domain.EventType) ([]domain.EventType, error) {
+	if len(events) == 0 {
+		return append([]domain.EventType(nil), domain.EventTypes...), nil
+	}
+
+	seen := make(map[domain.EventType]bool, len(events))
+	result := make([]domain.EventType, 0, len(events))
+	for _, e := range events {
+		if !e.IsValid() {
+			return nil, domain.ErrInvalidWebhook
+		}
+		if !seen[e] {
+			seen[e] = true
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
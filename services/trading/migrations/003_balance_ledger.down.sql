@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS ledger_entries_append_only ON ledger_entries;
+DROP FUNCTION IF EXISTS prevent_ledger_update();
+
+DROP TABLE IF EXISTS ledger_entries;
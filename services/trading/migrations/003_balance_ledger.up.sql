@@ -0,0 +1,34 @@
+-- Ledger entries table (append-only history of every balance change)
+CREATE TABLE ledger_entries (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('INITIAL_DEPOSIT', 'REALIZED_PNL', 'FEE', 'FUNDING', 'LIQUIDATION', 'ADJUSTMENT')),
+    amount DECIMAL(20, 8) NOT NULL,
+    balance_after DECIMAL(20, 8) NOT NULL,
+    trade_id BIGINT REFERENCES trades(id),
+    position_id BIGINT REFERENCES positions(id),
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_ledger_entries_account_created ON ledger_entries(account_id, created_at DESC);
+CREATE INDEX idx_ledger_entries_trade_id ON ledger_entries(trade_id);
+CREATE INDEX idx_ledger_entries_position_id ON ledger_entries(position_id);
+
+-- Opening balance for accounts created before the ledger existed
+INSERT INTO ledger_entries (account_id, type, amount, balance_after, description, created_at)
+SELECT id, 'INITIAL_DEPOSIT', balance, balance, 'opening balance', created_at
+FROM accounts;
+
+-- Entries are never rewritten
+CREATE OR REPLACE FUNCTION prevent_ledger_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_entries_append_only
+    BEFORE UPDATE ON ledger_entries
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_ledger_update();
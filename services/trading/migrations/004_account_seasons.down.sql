@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS account_seasons;
+
+ALTER TABLE accounts DROP COLUMN season_starting_balance;
+ALTER TABLE accounts DROP COLUMN season_started_at;
+ALTER TABLE accounts DROP COLUMN season;
@@ -0,0 +1,29 @@
+-- Current season of each account
+ALTER TABLE accounts ADD COLUMN season INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE accounts ADD COLUMN season_started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+ALTER TABLE accounts ADD COLUMN season_starting_balance DECIMAL(20, 8) NOT NULL DEFAULT 0;
+
+-- Existing accounts are in their first season: starting balance is what they had before any PnL
+UPDATE accounts a
+SET season_started_at = a.created_at,
+    season_starting_balance = a.balance - COALESCE(
+        (SELECT SUM(t.pnl) FROM trades t WHERE t.user_id = a.user_id), 0);
+
+-- Archived seasons (one row per reset)
+CREATE TABLE account_seasons (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    season INTEGER NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    starting_balance DECIMAL(20, 8) NOT NULL,
+    final_balance DECIMAL(20, 8) NOT NULL,
+    realized_pnl DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    volume DECIMAL(30, 8) NOT NULL DEFAULT 0,
+    total_trades INTEGER NOT NULL DEFAULT 0,
+    closed_trades INTEGER NOT NULL DEFAULT 0,
+    winning_trades INTEGER NOT NULL DEFAULT 0,
+    losing_trades INTEGER NOT NULL DEFAULT 0,
+    liquidations INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (account_id, season)
+);
@@ -0,0 +1,20 @@
+ALTER TABLE ledger_entries DROP CONSTRAINT ledger_entries_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_type_check
+    CHECK (type IN ('INITIAL_DEPOSIT', 'REALIZED_PNL', 'FEE', 'FUNDING', 'LIQUIDATION', 'ADJUSTMENT'));
+
+DROP INDEX idx_positions_unique_open;
+CREATE UNIQUE INDEX idx_positions_unique_open
+    ON positions(user_id, symbol)
+    WHERE status = 'OPEN';
+
+DROP INDEX IF EXISTS idx_trades_account_created;
+DROP INDEX IF EXISTS idx_positions_account_status;
+DROP INDEX IF EXISTS idx_orders_account_status;
+
+ALTER TABLE trades DROP COLUMN account_id;
+ALTER TABLE positions DROP COLUMN account_id;
+ALTER TABLE orders DROP COLUMN account_id;
+
+ALTER TABLE accounts DROP CONSTRAINT accounts_user_id_name_key;
+ALTER TABLE accounts DROP COLUMN name;
+ALTER TABLE accounts ADD CONSTRAINT accounts_user_id_key UNIQUE (user_id);
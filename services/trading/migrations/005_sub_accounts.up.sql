@@ -0,0 +1,32 @@
+-- Allow several accounts per user, told apart by name
+ALTER TABLE accounts DROP CONSTRAINT accounts_user_id_key;
+ALTER TABLE accounts ADD COLUMN name VARCHAR(50) NOT NULL DEFAULT 'main';
+ALTER TABLE accounts ADD CONSTRAINT accounts_user_id_name_key UNIQUE (user_id, name);
+
+-- Orders, positions and trades belong to an account
+ALTER TABLE orders ADD COLUMN account_id BIGINT REFERENCES accounts(id) ON DELETE CASCADE;
+ALTER TABLE positions ADD COLUMN account_id BIGINT REFERENCES accounts(id) ON DELETE CASCADE;
+ALTER TABLE trades ADD COLUMN account_id BIGINT REFERENCES accounts(id) ON DELETE CASCADE;
+
+UPDATE orders o SET account_id = a.id FROM accounts a WHERE a.user_id = o.user_id;
+UPDATE positions p SET account_id = a.id FROM accounts a WHERE a.user_id = p.user_id;
+UPDATE trades t SET account_id = a.id FROM accounts a WHERE a.user_id = t.user_id;
+
+ALTER TABLE orders ALTER COLUMN account_id SET NOT NULL;
+ALTER TABLE positions ALTER COLUMN account_id SET NOT NULL;
+ALTER TABLE trades ALTER COLUMN account_id SET NOT NULL;
+
+CREATE INDEX idx_orders_account_status ON orders(account_id, status);
+CREATE INDEX idx_positions_account_status ON positions(account_id, status);
+CREATE INDEX idx_trades_account_created ON trades(account_id, created_at DESC);
+
+-- One open position per account per symbol
+DROP INDEX idx_positions_unique_open;
+CREATE UNIQUE INDEX idx_positions_unique_open
+    ON positions(account_id, symbol)
+    WHERE status = 'OPEN';
+
+-- Internal transfers between accounts
+ALTER TABLE ledger_entries DROP CONSTRAINT ledger_entries_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_type_check
+    CHECK (type IN ('INITIAL_DEPOSIT', 'REALIZED_PNL', 'FEE', 'FUNDING', 'LIQUIDATION', 'ADJUSTMENT', 'TRANSFER'));
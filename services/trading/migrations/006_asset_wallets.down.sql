@@ -0,0 +1,7 @@
+ALTER TABLE ledger_entries DROP CONSTRAINT ledger_entries_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_type_check
+    CHECK (type IN ('INITIAL_DEPOSIT', 'REALIZED_PNL', 'FEE', 'FUNDING', 'LIQUIDATION', 'ADJUSTMENT', 'TRANSFER'));
+
+ALTER TABLE ledger_entries DROP COLUMN asset;
+
+DROP TABLE IF EXISTS account_assets;
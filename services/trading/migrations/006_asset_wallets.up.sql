@@ -0,0 +1,16 @@
+-- Non-USDT wallet assets (USDT stays in accounts.balance)
+CREATE TABLE account_assets (
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    asset VARCHAR(10) NOT NULL,
+    amount DECIMAL(20, 8) NOT NULL DEFAULT 0 CHECK (amount >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (account_id, asset)
+);
+
+-- Every ledger entry moves exactly one asset
+ALTER TABLE ledger_entries ADD COLUMN asset VARCHAR(10) NOT NULL DEFAULT 'USDT';
+
+-- Conversions between wallet assets
+ALTER TABLE ledger_entries DROP CONSTRAINT ledger_entries_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_type_check
+    CHECK (type IN ('INITIAL_DEPOSIT', 'REALIZED_PNL', 'FEE', 'FUNDING', 'LIQUIDATION', 'ADJUSTMENT', 'TRANSFER', 'CONVERSION'));
@@ -0,0 +1,12 @@
+DELETE FROM ledger_entries WHERE type = 'SPOT_TRADE';
+ALTER TABLE ledger_entries DROP CONSTRAINT ledger_entries_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_type_check
+    CHECK (type IN ('INITIAL_DEPOSIT', 'REALIZED_PNL', 'FEE', 'FUNDING', 'LIQUIDATION', 'ADJUSTMENT', 'TRANSFER', 'CONVERSION'));
+
+DROP TABLE IF EXISTS spot_lots;
+
+DELETE FROM trades WHERE type IN ('SPOT_BUY', 'SPOT_SELL');
+ALTER TABLE trades DROP CONSTRAINT trades_type_check;
+ALTER TABLE trades ADD CONSTRAINT trades_type_check
+    CHECK (type IN ('OPEN', 'CLOSE', 'ADD', 'LIQUIDATE'));
+ALTER TABLE trades ALTER COLUMN position_id SET NOT NULL;
@@ -0,0 +1,25 @@
+-- Spot trades are not tied to a position
+ALTER TABLE trades ALTER COLUMN position_id DROP NOT NULL;
+ALTER TABLE trades DROP CONSTRAINT trades_type_check;
+ALTER TABLE trades ADD CONSTRAINT trades_type_check
+    CHECK (type IN ('OPEN', 'CLOSE', 'ADD', 'LIQUIDATE', 'SPOT_BUY', 'SPOT_SELL'));
+
+-- Cost basis lots of spot purchases, consumed FIFO by spot sales
+CREATE TABLE spot_lots (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    asset VARCHAR(10) NOT NULL,
+    trade_id BIGINT NOT NULL REFERENCES trades(id) ON DELETE CASCADE,
+    quantity DECIMAL(20, 8) NOT NULL,
+    remaining DECIMAL(20, 8) NOT NULL CHECK (remaining >= 0),
+    price DECIMAL(20, 8) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    closed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_spot_lots_open ON spot_lots(account_id, asset, id) WHERE remaining > 0;
+
+-- Spot trade legs in the ledger
+ALTER TABLE ledger_entries DROP CONSTRAINT ledger_entries_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_type_check
+    CHECK (type IN ('INITIAL_DEPOSIT', 'REALIZED_PNL', 'FEE', 'FUNDING', 'LIQUIDATION', 'ADJUSTMENT', 'TRANSFER', 'CONVERSION', 'SPOT_TRADE'));
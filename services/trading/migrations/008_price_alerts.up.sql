@@ -0,0 +1,35 @@
+-- Price alerts, evaluated on every price tick
+CREATE TABLE price_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    symbol VARCHAR(20) NOT NULL,
+    condition VARCHAR(20) NOT NULL CHECK (condition IN ('ABOVE', 'BELOW', 'CROSS', 'CHANGE_PERCENT')),
+    target_price DECIMAL(20, 8),
+    reference_price DECIMAL(20, 8),
+    change_percent DECIMAL(10, 4),
+    window_seconds INT NOT NULL DEFAULT 0,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'TRIGGERED')),
+    triggered_price DECIMAL(20, 8),
+    triggered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_price_alerts_user_id ON price_alerts(user_id, id DESC);
+CREATE INDEX idx_price_alerts_active ON price_alerts(symbol) WHERE status = 'ACTIVE';
+
+-- Notification inbox
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    alert_id BIGINT REFERENCES price_alerts(id) ON DELETE SET NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, id DESC);
+CREATE INDEX idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
@@ -0,0 +1,16 @@
+-- Equity curve: minute snapshots rolled up into hourly and daily buckets
+CREATE TABLE equity_snapshots (
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    resolution VARCHAR(4) NOT NULL CHECK (resolution IN ('1m', '1h', '1d')),
+    bucket_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    balance DECIMAL(20, 8) NOT NULL,
+    equity DECIMAL(20, 8) NOT NULL,
+    equity_low DECIMAL(20, 8) NOT NULL,
+    equity_high DECIMAL(20, 8) NOT NULL,
+    unrealized_pnl DECIMAL(20, 8) NOT NULL,
+    used_margin DECIMAL(20, 8) NOT NULL,
+    PRIMARY KEY (account_id, resolution, bucket_time)
+);
+
+-- Retention pruning by resolution
+CREATE INDEX idx_equity_snapshots_prune ON equity_snapshots(resolution, bucket_time);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_trades_type_created;
+DROP INDEX IF EXISTS idx_users_display_name;
+
+ALTER TABLE users DROP COLUMN IF EXISTS leaderboard_opt_in;
+ALTER TABLE users DROP COLUMN IF EXISTS display_name;
@@ -0,0 +1,8 @@
+-- Public leaderboard profile; users appear on leaderboards only after opting in
+ALTER TABLE users ADD COLUMN display_name VARCHAR(32);
+ALTER TABLE users ADD COLUMN leaderboard_opt_in BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX idx_users_display_name ON users(LOWER(display_name)) WHERE display_name IS NOT NULL;
+
+-- Leaderboards scan closing trades by time across all accounts
+CREATE INDEX idx_trades_type_created ON trades(type, created_at);
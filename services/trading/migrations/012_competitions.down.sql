@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS competition_standings;
+
+DROP INDEX IF EXISTS idx_accounts_competition_user;
+ALTER TABLE accounts DROP COLUMN IF EXISTS competition_id;
+
+DROP TABLE IF EXISTS competitions;
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
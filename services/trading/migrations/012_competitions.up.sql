@@ -0,0 +1,41 @@
+-- Admins manage competitions; granted directly in the database
+ALTER TABLE users ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE competitions (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    allowed_symbols TEXT[] NOT NULL,
+    max_leverage INT NOT NULL CHECK (max_leverage >= 1 AND max_leverage <= 125),
+    starting_balance DECIMAL(20, 8) NOT NULL CHECK (starting_balance > 0),
+    ranking_metric VARCHAR(20) NOT NULL CHECK (ranking_metric IN ('pnl', 'roi', 'risk_adjusted')),
+    created_by BIGINT NOT NULL REFERENCES users(id),
+    finalized_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_competitions_unfinalized ON competitions(ends_at) WHERE finalized_at IS NULL;
+
+-- Competition accounts are isolated accounts, one per participant
+ALTER TABLE accounts ADD COLUMN competition_id BIGINT REFERENCES competitions(id) ON DELETE CASCADE;
+CREATE UNIQUE INDEX idx_accounts_competition_user ON accounts(competition_id, user_id) WHERE competition_id IS NOT NULL;
+
+-- Final standings, written once when the competition ends
+CREATE TABLE competition_standings (
+    competition_id BIGINT NOT NULL REFERENCES competitions(id) ON DELETE CASCADE,
+    rank INT NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    display_name VARCHAR(32) NOT NULL,
+    equity DECIMAL(20, 8) NOT NULL,
+    pnl DECIMAL(20, 8) NOT NULL,
+    roi DECIMAL(20, 8) NOT NULL,
+    risk_adjusted DECIMAL(20, 8),
+    trades INT NOT NULL,
+    PRIMARY KEY (competition_id, user_id)
+);
+
+CREATE INDEX idx_competition_standings_rank ON competition_standings(competition_id, rank);
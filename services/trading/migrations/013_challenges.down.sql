@@ -0,0 +1,4 @@
+ALTER TABLE accounts DROP COLUMN IF EXISTS locked_reason;
+ALTER TABLE accounts DROP COLUMN IF EXISTS challenge_id;
+
+DROP TABLE IF EXISTS challenges;
@@ -0,0 +1,26 @@
+-- Prop-firm style evaluations: rules are checked on every fill and price tick
+CREATE TABLE challenges (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'PASSED', 'FAILED')),
+    starting_balance DECIMAL(20, 8) NOT NULL CHECK (starting_balance > 0),
+    profit_target DECIMAL(10, 4) NOT NULL CHECK (profit_target > 0),
+    max_daily_loss DECIMAL(10, 4) NOT NULL CHECK (max_daily_loss > 0),
+    max_drawdown DECIMAL(10, 4) NOT NULL CHECK (max_drawdown > 0),
+    min_trading_days INT NOT NULL CHECK (min_trading_days >= 0),
+    max_position_size DECIMAL(20, 8) NOT NULL CHECK (max_position_size > 0),
+    day_started_on DATE NOT NULL,
+    day_start_equity DECIMAL(20, 8) NOT NULL,
+    trading_days INT NOT NULL DEFAULT 0,
+    last_trading_day DATE,
+    failure_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    ended_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_challenges_user ON challenges(user_id, id DESC);
+CREATE INDEX idx_challenges_active ON challenges(id) WHERE status = 'ACTIVE';
+
+-- Each challenge trades on its own account; locked accounts accept no orders
+ALTER TABLE accounts ADD COLUMN challenge_id BIGINT UNIQUE REFERENCES challenges(id) ON DELETE CASCADE;
+ALTER TABLE accounts ADD COLUMN locked_reason TEXT;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_positions_account_closed;
+
+DROP INDEX IF EXISTS idx_orders_account_symbol_created;
+DROP INDEX IF EXISTS idx_orders_account_created;
+
+DROP INDEX IF EXISTS idx_trades_account_symbol_created;
+DROP INDEX IF EXISTS idx_trades_account_created;
+CREATE INDEX idx_trades_account_created ON trades(account_id, created_at DESC);
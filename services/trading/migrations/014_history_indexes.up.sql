@@ -0,0 +1,10 @@
+-- Keyset pagination of orders, trades and closed positions: newest first, id breaks ties
+DROP INDEX IF EXISTS idx_trades_account_created;
+CREATE INDEX idx_trades_account_created ON trades(account_id, created_at DESC, id DESC);
+CREATE INDEX idx_trades_account_symbol_created ON trades(account_id, symbol, created_at DESC, id DESC);
+
+CREATE INDEX idx_orders_account_created ON orders(account_id, created_at DESC, id DESC);
+CREATE INDEX idx_orders_account_symbol_created ON orders(account_id, symbol, created_at DESC, id DESC);
+
+CREATE INDEX idx_positions_account_closed ON positions(account_id, closed_at DESC, id DESC)
+    WHERE closed_at IS NOT NULL;
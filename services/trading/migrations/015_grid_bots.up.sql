@@ -0,0 +1,37 @@
+-- Grid bots trade a price range with limit orders on evenly spaced levels
+CREATE TABLE grid_bots (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    symbol VARCHAR(20) NOT NULL,
+    lower_price DECIMAL(20, 8) NOT NULL CHECK (lower_price > 0),
+    upper_price DECIMAL(20, 8) NOT NULL,
+    grids INT NOT NULL CHECK (grids >= 2),
+    investment DECIMAL(20, 8) NOT NULL CHECK (investment > 0),
+    leverage INT NOT NULL CHECK (leverage >= 1),
+    quantity DECIMAL(20, 8) NOT NULL CHECK (quantity > 0),
+    status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'PAUSED', 'STOPPED')),
+    buy_fills INT NOT NULL DEFAULT 0,
+    sell_fills INT NOT NULL DEFAULT 0,
+    rounds INT NOT NULL DEFAULT 0,
+    grid_profit DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    position DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    cost DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    fees DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    stopped_at TIMESTAMP WITH TIME ZONE,
+    CHECK (upper_price > lower_price)
+);
+
+CREATE INDEX idx_grid_bots_account ON grid_bots(account_id, id DESC);
+CREATE INDEX idx_grid_bots_active ON grid_bots(id) WHERE status = 'ACTIVE';
+
+-- The limit order resting on each level of a bot
+CREATE TABLE grid_bot_orders (
+    bot_id BIGINT NOT NULL REFERENCES grid_bots(id) ON DELETE CASCADE,
+    level INT NOT NULL,
+    side VARCHAR(4) NOT NULL CHECK (side IN ('BUY', 'SELL')),
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    PRIMARY KEY (bot_id, level)
+);
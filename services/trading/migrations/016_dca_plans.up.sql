@@ -0,0 +1,45 @@
+-- Recurring buys with optional take profit and safety orders
+CREATE TABLE dca_plans (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    symbol VARCHAR(20) NOT NULL,
+    amount DECIMAL(20, 8) NOT NULL CHECK (amount > 0),
+    leverage INT NOT NULL CHECK (leverage >= 1),
+    interval_seconds BIGINT NOT NULL CHECK (interval_seconds >= 60),
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    missed_runs VARCHAR(10) NOT NULL CHECK (missed_runs IN ('SKIP', 'CATCH_UP')),
+    take_profit_pct DECIMAL(10, 4),
+    safety_drop_pct DECIMAL(10, 4),
+    safety_amount DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    max_safety_orders INT NOT NULL DEFAULT 0,
+    status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'PAUSED', 'CANCELLED')),
+    quantity DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    cost DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    last_buy_price DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    safety_orders INT NOT NULL DEFAULT 0,
+    runs INT NOT NULL DEFAULT 0,
+    cycles INT NOT NULL DEFAULT 0,
+    realized_pnl DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_dca_plans_account ON dca_plans(account_id, id DESC);
+CREATE INDEX idx_dca_plans_active ON dca_plans(next_run_at) WHERE status = 'ACTIVE';
+
+-- Execution history: every scheduled, safety and take profit order, including failed and skipped runs
+CREATE TABLE dca_executions (
+    id BIGSERIAL PRIMARY KEY,
+    plan_id BIGINT NOT NULL REFERENCES dca_plans(id) ON DELETE CASCADE,
+    kind VARCHAR(12) NOT NULL CHECK (kind IN ('SCHEDULED', 'SAFETY', 'TAKE_PROFIT')),
+    status VARCHAR(10) NOT NULL CHECK (status IN ('FILLED', 'FAILED', 'SKIPPED')),
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    order_id BIGINT REFERENCES orders(id) ON DELETE SET NULL,
+    quantity DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    price DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_dca_executions_plan ON dca_executions(plan_id, id DESC);
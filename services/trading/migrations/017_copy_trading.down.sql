@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS copy_trades;
+DROP TABLE IF EXISTS follows;
@@ -0,0 +1,42 @@
+-- Copy trading: follower accounts mirroring the perpetual trades of a leader account
+CREATE TABLE follows (
+    id BIGSERIAL PRIMARY KEY,
+    follower_user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    follower_account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    leader_user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    leader_account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    mode VARCHAR(10) NOT NULL CHECK (mode IN ('RATIO', 'FIXED')),
+    ratio DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    amount DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    max_leverage INT NOT NULL DEFAULT 0 CHECK (max_leverage >= 0),
+    max_position_notional DECIMAL(20, 8),
+    max_loss DECIMAL(20, 8),
+    status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'STOPPED', 'UNFOLLOWED')),
+    stop_reason TEXT NOT NULL DEFAULT '',
+    copied_trades INT NOT NULL DEFAULT 0,
+    realized_pnl DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_follows_follower ON follows(follower_account_id, id DESC);
+CREATE INDEX idx_follows_leader_active ON follows(leader_account_id) WHERE status = 'ACTIVE';
+
+-- Audit trail: what was done on the follower account for every leader trade
+CREATE TABLE copy_trades (
+    id BIGSERIAL PRIMARY KEY,
+    follow_id BIGINT NOT NULL REFERENCES follows(id) ON DELETE CASCADE,
+    leader_trade_id BIGINT NOT NULL,
+    leader_trade_type VARCHAR(20) NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    side VARCHAR(10) NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('FILLED', 'FAILED', 'SKIPPED')),
+    order_id BIGINT REFERENCES orders(id) ON DELETE SET NULL,
+    quantity DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    price DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    pnl DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_copy_trades_follow ON copy_trades(follow_id, id DESC);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS script_logs;
+DROP TABLE IF EXISTS scripts;
@@ -0,0 +1,31 @@
+-- User written Starlark strategies, each trading one perpetual symbol
+CREATE TABLE scripts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    source TEXT NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(10) NOT NULL DEFAULT 'STOPPED' CHECK (status IN ('STOPPED', 'RUNNING', 'ERROR')),
+    last_error TEXT NOT NULL DEFAULT '',
+    state JSONB NOT NULL DEFAULT '{}',
+    order_ids BIGINT[] NOT NULL DEFAULT '{}',
+    started_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_scripts_account ON scripts(account_id, id DESC);
+CREATE INDEX idx_scripts_running ON scripts(symbol) WHERE status = 'RUNNING';
+
+-- Output of print() and log() plus failed callbacks, latest lines only
+CREATE TABLE script_logs (
+    id BIGSERIAL PRIMARY KEY,
+    script_id BIGINT NOT NULL REFERENCES scripts(id) ON DELETE CASCADE,
+    level VARCHAR(10) NOT NULL CHECK (level IN ('INFO', 'ERROR')),
+    message TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_script_logs_script ON script_logs(script_id, id DESC);
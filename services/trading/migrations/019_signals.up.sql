@@ -0,0 +1,37 @@
+-- Signal strategies: external alerts trading an account through a secret webhook URL
+CREATE TABLE signal_strategies (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    symbol VARCHAR(20) NOT NULL DEFAULT '',
+    quantity DECIMAL(20, 8),
+    max_quantity DECIMAL(20, 8),
+    leverage INT NOT NULL DEFAULT 0 CHECK (leverage >= 0),
+    stop_loss_pct DECIMAL(10, 4),
+    take_profit_pct DECIMAL(10, 4),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_signal_strategies_account ON signal_strategies(account_id, id DESC);
+
+-- Signal log: every received payload and its outcome
+CREATE TABLE signals (
+    id BIGSERIAL PRIMARY KEY,
+    strategy_id BIGINT NOT NULL REFERENCES signal_strategies(id) ON DELETE CASCADE,
+    payload TEXT NOT NULL,
+    action VARCHAR(10) NOT NULL DEFAULT '',
+    symbol VARCHAR(20) NOT NULL DEFAULT '',
+    side VARCHAR(10) NOT NULL DEFAULT '',
+    status VARCHAR(10) NOT NULL CHECK (status IN ('EXECUTED', 'FAILED', 'SKIPPED')),
+    order_id BIGINT REFERENCES orders(id) ON DELETE SET NULL,
+    quantity DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    price DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_signals_strategy ON signals(strategy_id, id DESC);
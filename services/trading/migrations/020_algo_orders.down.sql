@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS algo_slices;
+DROP TABLE IF EXISTS algo_orders;
+
+-- Child orders are kept as plain orders
+ALTER TABLE orders DROP COLUMN IF EXISTS parent_id;
+DELETE FROM orders WHERE type IN ('TWAP', 'VWAP');
+ALTER TABLE orders DROP CONSTRAINT orders_type_check;
+ALTER TABLE orders ADD CONSTRAINT orders_type_check
+    CHECK (type IN ('MARKET', 'LIMIT'));
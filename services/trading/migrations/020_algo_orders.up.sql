@@ -0,0 +1,35 @@
+-- Algo orders are parents executed through child orders
+ALTER TABLE orders DROP CONSTRAINT orders_type_check;
+ALTER TABLE orders ADD CONSTRAINT orders_type_check
+    CHECK (type IN ('MARKET', 'LIMIT', 'TWAP', 'VWAP'));
+ALTER TABLE orders ADD COLUMN parent_id BIGINT REFERENCES orders(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_orders_parent ON orders(parent_id) WHERE parent_id IS NOT NULL;
+
+-- The schedule of a TWAP or VWAP parent order
+CREATE TABLE algo_orders (
+    order_id BIGINT PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    duration_seconds BIGINT NOT NULL CHECK (duration_seconds > 0),
+    slices INT NOT NULL CHECK (slices >= 2),
+    dataset VARCHAR(255) NOT NULL DEFAULT '',
+    arrival_price DECIMAL(20, 8) NOT NULL,
+    start_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Slices of the schedule, each executed as a child order
+CREATE TABLE algo_slices (
+    order_id BIGINT NOT NULL REFERENCES algo_orders(order_id) ON DELETE CASCADE,
+    slice INT NOT NULL,
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    quantity DECIMAL(20, 8) NOT NULL CHECK (quantity > 0),
+    status VARCHAR(10) NOT NULL DEFAULT 'SCHEDULED'
+        CHECK (status IN ('SCHEDULED', 'OPEN', 'FILLED', 'EXPIRED', 'FAILED', 'CANCELLED')),
+    child_id BIGINT REFERENCES orders(id) ON DELETE SET NULL,
+    filled_quantity DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    price DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (order_id, slice)
+);
+
+CREATE INDEX idx_algo_slices_unresolved ON algo_slices(order_id) WHERE status IN ('SCHEDULED', 'OPEN');
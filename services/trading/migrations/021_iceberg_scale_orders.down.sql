@@ -0,0 +1,15 @@
+ALTER TABLE algo_slices DROP COLUMN IF EXISTS limit_price;
+ALTER TABLE algo_orders DROP COLUMN IF EXISTS distribution;
+ALTER TABLE algo_orders DROP COLUMN IF EXISTS visible_quantity;
+
+-- Child orders are kept as plain orders
+UPDATE orders SET parent_id = NULL
+WHERE parent_id IN (SELECT id FROM orders WHERE type IN ('ICEBERG', 'SCALE'));
+DELETE FROM orders WHERE type IN ('ICEBERG', 'SCALE');
+
+ALTER TABLE algo_orders DROP CONSTRAINT algo_orders_duration_seconds_check;
+ALTER TABLE algo_orders ADD CONSTRAINT algo_orders_duration_seconds_check
+    CHECK (duration_seconds > 0);
+ALTER TABLE orders DROP CONSTRAINT orders_type_check;
+ALTER TABLE orders ADD CONSTRAINT orders_type_check
+    CHECK (type IN ('MARKET', 'LIMIT', 'TWAP', 'VWAP'));
@@ -0,0 +1,14 @@
+-- Iceberg and scale orders are algo order parents whose slices rest as limit orders
+ALTER TABLE orders DROP CONSTRAINT orders_type_check;
+ALTER TABLE orders ADD CONSTRAINT orders_type_check
+    CHECK (type IN ('MARKET', 'LIMIT', 'TWAP', 'VWAP', 'ICEBERG', 'SCALE'));
+
+-- They rest until filled or cancelled instead of running for a duration
+ALTER TABLE algo_orders DROP CONSTRAINT algo_orders_duration_seconds_check;
+ALTER TABLE algo_orders ADD CONSTRAINT algo_orders_duration_seconds_check
+    CHECK (duration_seconds >= 0);
+ALTER TABLE algo_orders ADD COLUMN visible_quantity DECIMAL(20, 8) NOT NULL DEFAULT 0;
+ALTER TABLE algo_orders ADD COLUMN distribution VARCHAR(10) NOT NULL DEFAULT '';
+
+-- Limit price of each slice, 0 for TWAP and VWAP slices
+ALTER TABLE algo_slices ADD COLUMN limit_price DECIMAL(20, 8) NOT NULL DEFAULT 0;
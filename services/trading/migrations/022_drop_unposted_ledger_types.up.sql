@@ -0,0 +1,4 @@
+-- Nothing posts fees or funding, so the ledger does not accept them
+ALTER TABLE ledger_entries DROP CONSTRAINT ledger_entries_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_type_check
+    CHECK (type IN ('INITIAL_DEPOSIT', 'REALIZED_PNL', 'LIQUIDATION', 'ADJUSTMENT', 'TRANSFER', 'CONVERSION', 'SPOT_TRADE'));
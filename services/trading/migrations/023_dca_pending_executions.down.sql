@@ -0,0 +1,4 @@
+UPDATE dca_executions SET status = 'FAILED', error = 'order not recorded' WHERE status = 'PENDING';
+ALTER TABLE dca_executions DROP CONSTRAINT dca_executions_status_check;
+ALTER TABLE dca_executions ADD CONSTRAINT dca_executions_status_check
+    CHECK (status IN ('FILLED', 'FAILED', 'SKIPPED'));
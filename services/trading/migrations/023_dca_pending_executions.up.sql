@@ -0,0 +1,4 @@
+-- Executions are claimed as PENDING together with the plan's next run, before their order is placed
+ALTER TABLE dca_executions DROP CONSTRAINT dca_executions_status_check;
+ALTER TABLE dca_executions ADD CONSTRAINT dca_executions_status_check
+    CHECK (status IN ('PENDING', 'FILLED', 'FAILED', 'SKIPPED'));
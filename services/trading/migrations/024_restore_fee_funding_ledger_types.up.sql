@@ -0,0 +1,4 @@
+-- Trades with a fee post it to the ledger as a FEE entry; FUNDING is kept for funding payments
+ALTER TABLE ledger_entries DROP CONSTRAINT ledger_entries_type_check;
+ALTER TABLE ledger_entries ADD CONSTRAINT ledger_entries_type_check
+    CHECK (type IN ('INITIAL_DEPOSIT', 'REALIZED_PNL', 'FEE', 'FUNDING', 'LIQUIDATION', 'ADJUSTMENT', 'TRANSFER', 'CONVERSION', 'SPOT_TRADE'));